@@ -12,6 +12,42 @@ const { validateLabels } = require("./safe_output_validator.cjs");
 const { getErrorMessage } = require("./error_helpers.cjs");
 const { resolveTargetRepoConfig, resolveAndValidateRepo } = require("./repo_helpers.cjs");
 
+/** @type {string} Color used for labels created without a configured color */
+const DEFAULT_LABEL_COLOR = "ededed";
+
+/**
+ * Creates any of the given labels that do not exist in the repository
+ * @param {{owner: string, repo: string}} repoParts - Target repository
+ * @param {string[]} labels - Labels to ensure
+ * @param {string} color - Color for created labels (6-digit hex, no leading #)
+ * @returns {Promise<string[]>} Labels that were created
+ */
+async function ensureLabelsExist(repoParts, labels, color) {
+  const created = [];
+  for (const name of labels) {
+    try {
+      await github.rest.issues.getLabel({ owner: repoParts.owner, repo: repoParts.repo, name });
+      continue;
+    } catch (error) {
+      if (/** @type {any} */ (error)?.status !== 404) {
+        throw error;
+      }
+    }
+
+    try {
+      await github.rest.issues.createLabel({ owner: repoParts.owner, repo: repoParts.repo, name, color });
+      core.info(`Created missing label "${name}" with color #${color}`);
+      created.push(name);
+    } catch (error) {
+      // 422 means the label was created concurrently
+      if (/** @type {any} */ (error)?.status !== 422) {
+        throw error;
+      }
+    }
+  }
+  return created;
+}
+
 /**
  * Main handler factory for add_labels
  * Returns a message handler function that processes individual add_labels messages
@@ -21,6 +57,8 @@ async function main(config = {}) {
   // Extract configuration
   const allowedLabels = config.allowed || [];
   const maxCount = config.max || 10;
+  const createIfMissing = config.create_if_missing === true;
+  const labelColor = config.color || DEFAULT_LABEL_COLOR;
   const { defaultTargetRepo, allowedRepos } = resolveTargetRepoConfig(config);
 
   // Check if we're in staged mode
@@ -30,6 +68,9 @@ async function main(config = {}) {
   if (allowedLabels.length > 0) {
     core.info(`Allowed labels: ${allowedLabels.join(", ")}`);
   }
+  if (createIfMissing) {
+    core.info(`Missing labels will be created with color #${labelColor}`);
+  }
   core.info(`Default target repo: ${defaultTargetRepo}`);
   if (allowedRepos.size > 0) {
     core.info(`Allowed repos: ${[...allowedRepos].join(", ")}`);
@@ -141,6 +182,10 @@ async function main(config = {}) {
     }
 
     try {
+      if (createIfMissing) {
+        await ensureLabelsExist(repoParts, uniqueLabels, labelColor);
+      }
+
       await github.rest.issues.addLabels({
         owner: repoParts.owner,
         repo: repoParts.repo,
@@ -163,4 +208,4 @@ async function main(config = {}) {
   };
 }
 
-module.exports = { main };
+module.exports = { main, ensureLabelsExist };
@@ -479,4 +479,63 @@ describe("add_labels", () => {
       expect(addLabelsCalls[0].repo).toBe("gh-aw");
     });
   });
+
+  describe("create-if-missing", () => {
+    it("should create missing labels with the configured color before adding them", async () => {
+      const createdLabels = [];
+      const addLabelsCalls = [];
+      mockGithub.rest.issues.getLabel = async ({ name }) => {
+        if (name === "existing") {
+          return { data: { name } };
+        }
+        throw Object.assign(new Error("Not Found"), { status: 404 });
+      };
+      mockGithub.rest.issues.createLabel = async params => {
+        createdLabels.push(params);
+        return {};
+      };
+      mockGithub.rest.issues.addLabels = async params => {
+        addLabelsCalls.push(params);
+        return {};
+      };
+
+      const handler = await main({ create_if_missing: true, color: "d73a4a" });
+      const result = await handler({ labels: ["existing", "new-label"] }, {});
+
+      expect(result.success).toBe(true);
+      expect(createdLabels).toHaveLength(1);
+      expect(createdLabels[0].name).toBe("new-label");
+      expect(createdLabels[0].color).toBe("d73a4a");
+      expect(addLabelsCalls).toHaveLength(1);
+    });
+
+    it("should use the default color when none is configured", async () => {
+      const createdLabels = [];
+      mockGithub.rest.issues.getLabel = async () => {
+        throw Object.assign(new Error("Not Found"), { status: 404 });
+      };
+      mockGithub.rest.issues.createLabel = async params => {
+        createdLabels.push(params);
+        return {};
+      };
+
+      const handler = await main({ create_if_missing: true });
+      await handler({ labels: ["new-label"] }, {});
+
+      expect(createdLabels[0].color).toBe("ededed");
+    });
+
+    it("should not look up labels when create-if-missing is disabled", async () => {
+      let lookups = 0;
+      mockGithub.rest.issues.getLabel = async () => {
+        lookups++;
+        return {};
+      };
+
+      const handler = await main({});
+      await handler({ labels: ["bug"] }, {});
+
+      expect(lookups).toBe(0);
+    });
+  });
 });
@@ -12,18 +12,25 @@ const { getErrorMessage } = require("./error_helpers.cjs");
 async function main() {
   // Read inputs from environment variables
   const reaction = process.env.GH_AW_REACTION || "eyes";
+  const fallbacks = (process.env.GH_AW_REACTION_FALLBACKS || "")
+    .split(",")
+    .map(r => r.trim())
+    .filter(Boolean);
+  const reactions = [reaction, ...fallbacks];
 
-  core.info(`Adding reaction: ${reaction}`);
+  core.info(`Adding reaction: ${reactions.join(", ")}`);
 
-  // Validate reaction type
+  // Validate reaction types
   const validReactions = ["+1", "-1", "laugh", "confused", "heart", "hooray", "rocket", "eyes"];
-  if (!validReactions.includes(reaction)) {
-    core.setFailed(`Invalid reaction type: ${reaction}. Valid reactions are: ${validReactions.join(", ")}`);
+  const invalidReaction = reactions.find(r => !validReactions.includes(r));
+  if (invalidReaction) {
+    core.setFailed(`Invalid reaction type: ${invalidReaction}. Valid reactions are: ${validReactions.join(", ")}`);
     return;
   }
 
-  // Determine the API endpoint based on the event type
+  // Determine the API endpoint (or GraphQL subject for discussions) based on the event type
   let reactionEndpoint;
+  let discussionSubjectId;
   const eventName = context.eventName;
   const owner = context.repo.owner;
   const repo = context.repo.repo;
@@ -75,8 +82,8 @@ async function main() {
         }
         // Discussions use GraphQL API - get the node ID
         const discussion = await getDiscussionId(owner, repo, discussionNumber);
-        await addDiscussionReaction(discussion.id, reaction);
-        return; // Early return for discussion events
+        discussionSubjectId = discussion.id;
+        break;
 
       case "discussion_comment":
         const commentNodeId = context.payload?.comment?.node_id;
@@ -84,17 +91,34 @@ async function main() {
           core.setFailed("Discussion comment node ID not found in event payload");
           return;
         }
-        await addDiscussionReaction(commentNodeId, reaction);
-        return; // Early return for discussion comment events
+        discussionSubjectId = commentNodeId;
+        break;
 
       default:
         core.setFailed(`Unsupported event type: ${eventName}`);
         return;
     }
 
-    // Add reaction using REST API (for non-discussion events)
-    core.info(`Adding reaction to: ${reactionEndpoint}`);
-    await addReaction(reactionEndpoint, reaction);
+    // Try each reaction in order; the first one that can be applied wins
+    let lastError;
+    for (const candidate of reactions) {
+      try {
+        if (discussionSubjectId) {
+          await addDiscussionReaction(discussionSubjectId, candidate);
+        } else {
+          // Add reaction using REST API (for non-discussion events)
+          core.info(`Adding reaction to: ${reactionEndpoint}`);
+          await addReaction(reactionEndpoint, candidate);
+        }
+        return;
+      } catch (error) {
+        lastError = error;
+        if (reactions.length > 1) {
+          core.warning(`Could not add reaction ${candidate}: ${getErrorMessage(error)}`);
+        }
+      }
+    }
+    throw lastError;
   } catch (error) {
     const errorMessage = getErrorMessage(error);
     core.error(`Failed to add reaction: ${errorMessage}`);
@@ -41,6 +41,7 @@ describe("add_reaction", () => {
 
     // Reset environment variables
     delete process.env.GH_AW_REACTION;
+    delete process.env.GH_AW_REACTION_FALLBACKS;
 
     // Reset context to default
     global.context = {
@@ -391,6 +392,70 @@ describe("add_reaction", () => {
     });
   });
 
+  describe("reaction fallbacks", () => {
+    it("should use the first reaction when it can be applied", async () => {
+      process.env.GH_AW_REACTION = "rocket";
+      process.env.GH_AW_REACTION_FALLBACKS = "eyes";
+
+      await runScript();
+
+      expect(mockGithub.request).toHaveBeenCalledTimes(1);
+      expect(mockGithub.request).toHaveBeenCalledWith("POST /repos/testowner/testrepo/issues/123/reactions", expect.objectContaining({ content: "rocket" }));
+      expect(mockCore.warning).not.toHaveBeenCalled();
+    });
+
+    it("should fall back to the next reaction when the first fails", async () => {
+      process.env.GH_AW_REACTION = "rocket";
+      process.env.GH_AW_REACTION_FALLBACKS = "eyes";
+      mockGithub.request.mockRejectedValueOnce(new Error("Resource not accessible by integration"));
+
+      await runScript();
+
+      expect(mockGithub.request).toHaveBeenCalledTimes(2);
+      expect(mockGithub.request).toHaveBeenLastCalledWith("POST /repos/testowner/testrepo/issues/123/reactions", expect.objectContaining({ content: "eyes" }));
+      expect(mockCore.warning).toHaveBeenCalledWith(expect.stringContaining("Could not add reaction rocket"));
+      expect(mockCore.setFailed).not.toHaveBeenCalled();
+    });
+
+    it("should fall back for discussion reactions", async () => {
+      global.context = {
+        eventName: "discussion_comment",
+        repo: { owner: "testowner", repo: "testrepo" },
+        payload: { comment: { node_id: "DC_test" } },
+      };
+      process.env.GH_AW_REACTION = "heart";
+      process.env.GH_AW_REACTION_FALLBACKS = "+1";
+      mockGithub.graphql.mockRejectedValueOnce(new Error("Forbidden"));
+
+      await runScript();
+
+      expect(mockGithub.graphql).toHaveBeenCalledTimes(2);
+      expect(mockGithub.graphql).toHaveBeenLastCalledWith(expect.any(String), { subjectId: "DC_test", content: "THUMBS_UP" });
+      expect(mockCore.setFailed).not.toHaveBeenCalled();
+    });
+
+    it("should fail when no reaction can be applied", async () => {
+      process.env.GH_AW_REACTION = "rocket";
+      process.env.GH_AW_REACTION_FALLBACKS = "eyes";
+      mockGithub.request.mockRejectedValue(new Error("Forbidden"));
+
+      await runScript();
+
+      expect(mockGithub.request).toHaveBeenCalledTimes(2);
+      expect(mockCore.setFailed).toHaveBeenCalledWith("Failed to add reaction: Forbidden");
+    });
+
+    it("should fail for an invalid fallback reaction", async () => {
+      process.env.GH_AW_REACTION = "rocket";
+      process.env.GH_AW_REACTION_FALLBACKS = "thumbsup";
+
+      await runScript();
+
+      expect(mockCore.setFailed).toHaveBeenCalledWith(expect.stringContaining("Invalid reaction type: thumbsup"));
+      expect(mockGithub.request).not.toHaveBeenCalled();
+    });
+  });
+
   describe("output handling", () => {
     it("should set reaction-id output when API returns ID", async () => {
       mockGithub.request.mockResolvedValueOnce({
@@ -60,6 +60,14 @@ const mockCore = {
           expect(mockCore.setOutput).toHaveBeenCalledWith("command_position_ok", "false"),
           expect(mockCore.warning).toHaveBeenCalledWith(expect.stringContaining("None of the commands")));
       }),
+      it("should match any alias and export the matched name", async () => {
+        ((process.env.GH_AW_COMMANDS = JSON.stringify(["deploy", "ship"])),
+          (mockContext.eventName = "issue_comment"),
+          (mockContext.payload = { comment: { body: "/ship to production" } }),
+          await eval(`(async () => { ${checkCommandPositionScript}; await main(); })()`),
+          expect(mockCore.setOutput).toHaveBeenCalledWith("command_position_ok", "true"),
+          expect(mockCore.setOutput).toHaveBeenCalledWith("matched_command", "ship"));
+      }),
       it("should pass when command is first word after whitespace", async () => {
         ((process.env.GH_AW_COMMANDS = JSON.stringify(["helper"])),
           (mockContext.eventName = "issue_comment"),
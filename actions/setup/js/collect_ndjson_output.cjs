@@ -197,6 +197,9 @@ async function main() {
         const errorMsg = getErrorMessage(error);
         core.info(`Warning: Could not parse safe-outputs config: ${errorMsg}`);
       }
+      // Apply max values given as expressions; an invalid evaluated value fails ingestion
+      const { resolveMaxValues } = require("./safe_output_helpers.cjs");
+      expectedOutputTypes = resolveMaxValues(expectedOutputTypes);
     }
     // Parse JSONL (JSON Lines) format: each line is a separate JSON object
     // CRITICAL: This expects one JSON object per line. If JSON is formatted with
@@ -5332,6 +5332,101 @@ var CopilotClient = class {
 // src/index.ts
 var import_debug = __toESM(require_src());
 var debug = (0, import_debug.default)("copilot-client");
+var DEFAULT_PRICING_PER_MILLION_TOKENS = {
+  input: 3,
+  cachedInput: 0.3,
+  output: 15
+};
+function estimateUsageCost(usage, pricing = DEFAULT_PRICING_PER_MILLION_TOKENS) {
+  if (typeof usage?.cost === "number") {
+    return usage.cost;
+  }
+  const inputTokens = usage?.inputTokens ?? 0;
+  const cachedTokens = usage?.cacheReadTokens ?? 0;
+  const outputTokens = usage?.outputTokens ?? 0;
+  return (Math.max(inputTokens - cachedTokens, 0) * pricing.input + cachedTokens * pricing.cachedInput + outputTokens * pricing.output) / 1e6;
+}
+function resolveToolTimeoutSeconds(config, toolName, mcpServerName) {
+  const overrides = config.toolTimeouts ?? {};
+  return overrides[toolName] ?? (mcpServerName ? overrides[mcpServerName] : void 0) ?? config.toolTimeoutSeconds ?? config.toolTimeout;
+}
+function enforceSessionLimits(session, config, logEvent, onLimitExceeded) {
+  const unsubscribers = [];
+  let limitExceeded = false;
+  const abort = (type, data, message) => {
+    if (limitExceeded) {
+      return;
+    }
+    limitExceeded = true;
+    logEvent(type, data, session.sessionId);
+    session.abort().catch((error) => debug("Error aborting session:", error));
+    onLimitExceeded(new Error(message));
+  };
+  if (config.maxTokens && config.maxTokens > 0) {
+    const maxTokens = config.maxTokens;
+    let totalTokens = 0;
+    unsubscribers.push(session.on("assistant.usage", (event) => {
+      totalTokens += (event.data?.inputTokens ?? 0) + (event.data?.outputTokens ?? 0);
+      debug("Accumulated tokens:", totalTokens);
+      if (totalTokens > maxTokens) {
+        abort(
+          "token_budget.exceeded",
+          { maxTokens, totalTokens },
+          `Token budget exceeded: ${totalTokens} tokens used is over engine.max-tokens of ${maxTokens}`
+        );
+      }
+    }));
+  }
+  if (config.maxCostUsd && config.maxCostUsd > 0) {
+    const maxCostUsd = config.maxCostUsd;
+    let accumulatedCostUsd = 0;
+    unsubscribers.push(session.on("assistant.usage", (event) => {
+      accumulatedCostUsd += estimateUsageCost(event.data, config.pricingPerMillionTokens);
+      debug("Estimated accumulated cost (USD):", accumulatedCostUsd);
+      if (accumulatedCostUsd > maxCostUsd) {
+        abort(
+          "budget.exceeded",
+          { maxCostUsd, estimatedCostUsd: accumulatedCostUsd },
+          `Cost budget exceeded: estimated cost $${accumulatedCostUsd.toFixed(4)} is over engine.max-cost of $${maxCostUsd}`
+        );
+      }
+    }));
+  }
+  if (config.toolTimeout || config.toolTimeoutSeconds || config.toolTimeouts) {
+    const timers = /* @__PURE__ */ new Map();
+    unsubscribers.push(session.on("tool.execution_start", (event) => {
+      const { toolCallId, toolName, mcpServerName } = event.data;
+      const timeoutSeconds = resolveToolTimeoutSeconds(config, toolName, mcpServerName);
+      if (!timeoutSeconds || timeoutSeconds <= 0) {
+        return;
+      }
+      timers.set(toolCallId, setTimeout(() => {
+        timers.delete(toolCallId);
+        abort(
+          "tool.timeout",
+          { toolCallId, toolName, timeoutSeconds },
+          `Tool call ${toolName} did not complete within ${timeoutSeconds}s`
+        );
+      }, timeoutSeconds * 1e3));
+    }));
+    unsubscribers.push(session.on("tool.execution_complete", (event) => {
+      const { toolCallId } = event.data;
+      clearTimeout(timers.get(toolCallId));
+      timers.delete(toolCallId);
+    }));
+    unsubscribers.push(() => {
+      for (const timer of timers.values()) {
+        clearTimeout(timer);
+      }
+      timers.clear();
+    });
+  }
+  return () => {
+    for (const unsubscribe of unsubscribers) {
+      unsubscribe();
+    }
+  };
+}
 async function runCopilotSession(config) {
   debug("Starting Copilot session with config:", config);
   mkdirSync(dirname(config.eventLogFile), { recursive: true });
@@ -5374,6 +5469,7 @@ async function runCopilotSession(config) {
   await client.start();
   logEvent("client.started", {});
   let session = null;
+  let stopLimits = null;
   try {
     debug("Creating Copilot session");
     session = await client.createSession({
@@ -5408,6 +5504,7 @@ async function runCopilotSession(config) {
         debug("Session error:", event.data);
         reject(new Error(event.data.message || "Session error"));
       });
+      stopLimits = enforceSessionLimits(session, config, logEvent, reject);
     });
     debug("Sending prompt");
     await session.send({ prompt });
@@ -5422,6 +5519,7 @@ async function runCopilotSession(config) {
     logEvent("session.error", { error: errorMessage }, session?.sessionId);
     throw error;
   } finally {
+    stopLimits?.();
     if (session) {
       debug("Destroying session");
       try {
@@ -5471,6 +5569,6 @@ async function main() {
   }
 }
 
-export { main, runCopilotSession };
+export { DEFAULT_PRICING_PER_MILLION_TOKENS, enforceSessionLimits, estimateUsageCost, main, resolveToolTimeoutSeconds, runCopilotSession };
 //# sourceMappingURL=index.js.map
 //# sourceMappingURL=index.js.map
\ No newline at end of file
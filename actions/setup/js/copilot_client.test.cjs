@@ -0,0 +1,146 @@
+import { describe, it, expect, vi, beforeEach } from "vitest";
+import { createRequire } from "module";
+
+// Tests run against the bundled copilot-client.js shipped to the runner, so a stale
+// bundle that does not match copilot-client/src fails here
+describe("copilot-client bundle", () => {
+  let client;
+
+  beforeEach(async () => {
+    // The bundle inlines CommonJS dependencies that load Node built-ins through require
+    globalThis.require ??= createRequire(import.meta.url);
+    client = await import("./copilot-client.js");
+  });
+
+  function createSession() {
+    const handlers = new Map();
+    return {
+      sessionId: "session-1",
+      abort: vi.fn().mockResolvedValue(undefined),
+      on(type, handler) {
+        if (!handlers.has(type)) {
+          handlers.set(type, new Set());
+        }
+        handlers.get(type).add(handler);
+        return () => handlers.get(type).delete(handler);
+      },
+      emit(type, data) {
+        for (const handler of handlers.get(type) ?? []) {
+          handler({ type, data });
+        }
+      },
+    };
+  }
+
+  describe("estimateUsageCost", () => {
+    it("should prefer the cost reported by the usage event", () => {
+      expect(client.estimateUsageCost({ cost: 0.42, inputTokens: 1000000 })).toBe(0.42);
+    });
+
+    it("should estimate cost from token counts", () => {
+      expect(client.estimateUsageCost({ inputTokens: 1000000, outputTokens: 100000 })).toBeCloseTo(4.5);
+    });
+  });
+
+  describe("resolveToolTimeoutSeconds", () => {
+    const config = { toolTimeout: 60, toolTimeouts: { playwright: 300, web_fetch: 30 } };
+
+    it("should prefer an override for the tool name", () => {
+      expect(client.resolveToolTimeoutSeconds(config, "web_fetch")).toBe(30);
+    });
+
+    it("should use an override for the MCP server name", () => {
+      expect(client.resolveToolTimeoutSeconds(config, "browser_navigate", "playwright")).toBe(300);
+    });
+
+    it("should prefer the per-call timeout over the default tool timeout", () => {
+      expect(client.resolveToolTimeoutSeconds({ ...config, toolTimeoutSeconds: 90 }, "bash")).toBe(90);
+      expect(client.resolveToolTimeoutSeconds({ ...config, toolTimeoutSeconds: 90 }, "web_fetch")).toBe(30);
+    });
+
+    it("should fall back to the default tool timeout", () => {
+      expect(client.resolveToolTimeoutSeconds(config, "bash")).toBe(60);
+      expect(client.resolveToolTimeoutSeconds({}, "bash")).toBeUndefined();
+    });
+  });
+
+  describe("enforceSessionLimits", () => {
+    it("should abort the session once the cost budget is exceeded", () => {
+      const session = createSession();
+      const logEvent = vi.fn();
+      const onLimitExceeded = vi.fn();
+
+      client.enforceSessionLimits(session, { maxCostUsd: 1 }, logEvent, onLimitExceeded);
+      session.emit("assistant.usage", { cost: 0.6 });
+      expect(session.abort).not.toHaveBeenCalled();
+
+      session.emit("assistant.usage", { cost: 0.6 });
+      expect(session.abort).toHaveBeenCalledTimes(1);
+      expect(logEvent).toHaveBeenCalledWith("budget.exceeded", { maxCostUsd: 1, estimatedCostUsd: 1.2 }, "session-1");
+      expect(onLimitExceeded).toHaveBeenCalledTimes(1);
+      expect(onLimitExceeded.mock.calls[0][0].message).toContain("is over engine.max-cost of $1");
+
+      session.emit("assistant.usage", { cost: 0.6 });
+      expect(session.abort).toHaveBeenCalledTimes(1);
+    });
+
+    it("should abort the session once the token budget is exceeded", () => {
+      const session = createSession();
+      const logEvent = vi.fn();
+      const onLimitExceeded = vi.fn();
+
+      client.enforceSessionLimits(session, { maxTokens: 1000 }, logEvent, onLimitExceeded);
+      session.emit("assistant.usage", { inputTokens: 600, outputTokens: 300 });
+      expect(session.abort).not.toHaveBeenCalled();
+
+      session.emit("assistant.usage", { inputTokens: 100, outputTokens: 50 });
+      expect(session.abort).toHaveBeenCalledTimes(1);
+      expect(logEvent).toHaveBeenCalledWith("token_budget.exceeded", { maxTokens: 1000, totalTokens: 1050 }, "session-1");
+      expect(onLimitExceeded.mock.calls[0][0].message).toContain("over engine.max-tokens of 1000");
+    });
+
+    it("should not watch usage without a budget", () => {
+      const session = createSession();
+      const onLimitExceeded = vi.fn();
+
+      client.enforceSessionLimits(session, {}, vi.fn(), onLimitExceeded);
+      session.emit("assistant.usage", { cost: 100 });
+      expect(session.abort).not.toHaveBeenCalled();
+      expect(onLimitExceeded).not.toHaveBeenCalled();
+    });
+
+    it("should abort the session when a tool call exceeds its timeout", () => {
+      vi.useFakeTimers();
+      try {
+        const session = createSession();
+        const logEvent = vi.fn();
+        const onLimitExceeded = vi.fn();
+
+        client.enforceSessionLimits(session, { toolTimeout: 60, toolTimeouts: { playwright: 300 } }, logEvent, onLimitExceeded);
+        session.emit("tool.execution_start", { toolCallId: "call-1", toolName: "bash" });
+        session.emit("tool.execution_start", { toolCallId: "call-2", toolName: "browser_navigate", mcpServerName: "playwright" });
+        vi.advanceTimersByTime(30000);
+        session.emit("tool.execution_complete", { toolCallId: "call-1" });
+        vi.advanceTimersByTime(240000);
+        expect(session.abort).not.toHaveBeenCalled();
+
+        vi.advanceTimersByTime(30000);
+        expect(session.abort).toHaveBeenCalledTimes(1);
+        expect(logEvent).toHaveBeenCalledWith("tool.timeout", { toolCallId: "call-2", toolName: "browser_navigate", timeoutSeconds: 300 }, "session-1");
+        expect(onLimitExceeded.mock.calls[0][0].message).toBe("Tool call browser_navigate did not complete within 300s");
+      } finally {
+        vi.useRealTimers();
+      }
+    });
+
+    it("should stop watching the session when stopped", () => {
+      const session = createSession();
+      const onLimitExceeded = vi.fn();
+
+      const stop = client.enforceSessionLimits(session, { maxCostUsd: 1 }, vi.fn(), onLimitExceeded);
+      stop();
+      session.emit("assistant.usage", { cost: 2 });
+      expect(onLimitExceeded).not.toHaveBeenCalled();
+    });
+  });
+});
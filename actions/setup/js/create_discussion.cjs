@@ -219,6 +219,58 @@ async function applyLabelsToDiscussion(discussionId, labelIds) {
   }
 }
 
+/**
+ * Finds an open discussion in the repository with the same title (case-insensitive)
+ * @param {string} owner - Repository owner
+ * @param {string} repo - Repository name
+ * @param {string} title - Final discussion title, including any title prefix
+ * @returns {Promise<{id: string, number: number, title: string, url: string}|null>} Matching discussion, or null if none
+ */
+async function findDuplicateDiscussion(owner, repo, title) {
+  const searchQuery = `repo:${owner}/${repo} is:open in:title "${title.replace(/"/g, "")}"`;
+  const duplicateQuery = `
+    query($searchQuery: String!) {
+      search(query: $searchQuery, type: DISCUSSION, first: 20) {
+        nodes {
+          ... on Discussion {
+            id
+            number
+            title
+            url
+          }
+        }
+      }
+    }
+  `;
+  const result = await github.graphql(duplicateQuery, { searchQuery });
+
+  // Search matches title words, so compare the full title to find an exact duplicate
+  const normalizedTitle = title.trim().toLowerCase();
+  const nodes = result?.search?.nodes || [];
+  return nodes.find(node => node && typeof node.title === "string" && node.title.trim().toLowerCase() === normalizedTitle) || null;
+}
+
+/**
+ * Adds a comment to an existing discussion
+ * @param {string} discussionId - Discussion node ID
+ * @param {string} body - Comment body
+ * @returns {Promise<{id: string, url: string}>} Created comment
+ */
+async function addDiscussionComment(discussionId, body) {
+  const addCommentMutation = `
+    mutation($dId: ID!, $body: String!) {
+      addDiscussionComment(input: { discussionId: $dId, body: $body }) {
+        comment {
+          id
+          url
+        }
+      }
+    }
+  `;
+  const result = await github.graphql(addCommentMutation, { dId: discussionId, body });
+  return result.addDiscussionComment.comment;
+}
+
 /**
  * Checks if an error is a permissions-related error
  * @param {string} errorMessage - The error message to check
@@ -300,6 +352,7 @@ async function main(config = {}) {
   const fallbackToIssue = config.fallback_to_issue !== false; // Default to true
   const closeOlderDiscussions = config.close_older_discussions === true || config.close_older_discussions === "true";
   const includeFooter = config.footer !== false; // Default to true (include footer)
+  const dedupe = config.dedupe === "skip" || config.dedupe === "comment" ? config.dedupe : "";
 
   // Check if we're in staged mode
   const isStaged = process.env.GH_AW_SAFE_OUTPUTS_STAGED === "true";
@@ -324,6 +377,9 @@ async function main(config = {}) {
   if (closeOlderDiscussions) {
     core.info("Close older discussions enabled: will close older discussions/issues with same workflow-id marker");
   }
+  if (dedupe) {
+    core.info(`Dedupe enabled: open discussions with the same title are handled with strategy '${dedupe}'`);
+  }
 
   // Track state
   let processedCount = 0;
@@ -550,6 +606,51 @@ async function main(config = {}) {
       };
     }
 
+    // Skip or comment instead of creating a discussion with the same title as an open one
+    if (dedupe) {
+      let duplicate = null;
+      try {
+        duplicate = await findDuplicateDiscussion(repoParts.owner, repoParts.repo, title);
+      } catch (error) {
+        core.warning(`Failed to search for duplicate discussions, creating a new one: ${getErrorMessage(error)}`);
+      }
+
+      if (duplicate) {
+        if (dedupe === "skip") {
+          const warning = `Discussion #${duplicate.number} with the same title is already open (dedupe: skip)`;
+          core.warning(`Skipping create_discussion: ${warning}: ${duplicate.url}`);
+          return {
+            success: true,
+            warning,
+            skipped: true,
+            repo: qualifiedItemRepo,
+            number: duplicate.number,
+            url: duplicate.url,
+          };
+        }
+
+        try {
+          const comment = await addDiscussionComment(duplicate.id, body);
+          core.info(`Commented on existing discussion ${qualifiedItemRepo}#${duplicate.number} instead of creating a duplicate: ${comment.url}`);
+          return {
+            success: true,
+            repo: qualifiedItemRepo,
+            number: duplicate.number,
+            url: duplicate.url,
+            commentUrl: comment.url,
+            deduplicated: true,
+          };
+        } catch (error) {
+          const errorMessage = `Failed to comment on existing discussion ${qualifiedItemRepo}#${duplicate.number}: ${getErrorMessage(error)}`;
+          core.error(errorMessage);
+          return {
+            success: false,
+            error: errorMessage,
+          };
+        }
+      }
+    }
+
     try {
       const createDiscussionMutation = `
         mutation($repositoryId: ID!, $categoryId: ID!, $title: String!, $body: String!) {
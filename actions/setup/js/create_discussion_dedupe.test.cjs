@@ -0,0 +1,121 @@
+// @ts-check
+import { describe, it, expect, beforeEach, afterEach, vi } from "vitest";
+import { createRequire } from "module";
+
+const require = createRequire(import.meta.url);
+const { main: createDiscussionMain } = require("./create_discussion.cjs");
+
+describe("create_discussion with dedupe", () => {
+  let mockGithub;
+  let mockCore;
+  let originalEnv;
+  /** @type {Array<{id: string, number: number, title: string, url: string}>} */
+  let openDiscussions;
+
+  beforeEach(() => {
+    originalEnv = { ...process.env };
+    openDiscussions = [];
+
+    mockGithub = {
+      graphql: vi.fn().mockImplementation(async query => {
+        if (query.includes("discussionCategories")) {
+          return {
+            repository: {
+              id: "R_test123",
+              discussionCategories: {
+                nodes: [{ id: "DIC_test456", name: "General", slug: "general", description: "General discussions" }],
+              },
+            },
+          };
+        }
+        if (query.includes("search(query:")) {
+          return { search: { nodes: openDiscussions } };
+        }
+        if (query.includes("addDiscussionComment")) {
+          return { addDiscussionComment: { comment: { id: "DC_comment1", url: "https://github.com/test-owner/test-repo/discussions/7#discussioncomment-1" } } };
+        }
+        if (query.includes("createDiscussion")) {
+          return {
+            createDiscussion: {
+              discussion: { id: "D_new", number: 42, title: "New", url: "https://github.com/test-owner/test-repo/discussions/42" },
+            },
+          };
+        }
+        throw new Error(`Unexpected GraphQL query: ${query.substring(0, 100)}`);
+      }),
+    };
+
+    mockCore = {
+      info: vi.fn(),
+      warning: vi.fn(),
+      error: vi.fn(),
+      setOutput: vi.fn(),
+    };
+
+    global.github = mockGithub;
+    global.core = mockCore;
+    global.context = {
+      repo: { owner: "test-owner", repo: "test-repo" },
+      runId: 12345,
+      payload: { repository: { html_url: "https://github.com/test-owner/test-repo" } },
+    };
+
+    process.env.GH_AW_WORKFLOW_NAME = "Test Workflow";
+    process.env.GH_AW_WORKFLOW_ID = "test-workflow";
+  });
+
+  afterEach(() => {
+    process.env = originalEnv;
+    vi.clearAllMocks();
+  });
+
+  const findCall = text => mockGithub.graphql.mock.calls.find(call => call[0].includes(text));
+
+  it("skips creation when an open discussion has the same prefixed title", async () => {
+    openDiscussions = [{ id: "D_existing", number: 7, title: "[report] Weekly Summary", url: "https://github.com/test-owner/test-repo/discussions/7" }];
+    const handler = await createDiscussionMain({ title_prefix: "[report] ", dedupe: "skip", fallback_to_issue: false });
+
+    const result = await handler({ title: "Weekly Summary", body: "Body" }, {});
+
+    expect(result.success).toBe(true);
+    expect(result.skipped).toBe(true);
+    expect(result.number).toBe(7);
+    expect(findCall("search(query:")[1].searchQuery).toContain('"[report] Weekly Summary"');
+    expect(findCall("createDiscussion")).toBeUndefined();
+  });
+
+  it("creates a discussion when the matching title lacks the prefix", async () => {
+    openDiscussions = [{ id: "D_existing", number: 7, title: "Weekly Summary", url: "https://github.com/test-owner/test-repo/discussions/7" }];
+    const handler = await createDiscussionMain({ title_prefix: "[report] ", dedupe: "skip", fallback_to_issue: false });
+
+    const result = await handler({ title: "Weekly Summary", body: "Body" }, {});
+
+    expect(result.success).toBe(true);
+    expect(result.skipped).toBeUndefined();
+    expect(result.number).toBe(42);
+  });
+
+  it("comments on the existing discussion instead of creating a duplicate", async () => {
+    openDiscussions = [{ id: "D_existing", number: 7, title: "Weekly Summary", url: "https://github.com/test-owner/test-repo/discussions/7" }];
+    const handler = await createDiscussionMain({ dedupe: "comment", fallback_to_issue: false });
+
+    const result = await handler({ title: "weekly summary", body: "Fresh findings" }, {});
+
+    expect(result.success).toBe(true);
+    expect(result.deduplicated).toBe(true);
+    expect(result.number).toBe(7);
+    const commentCall = findCall("addDiscussionComment");
+    expect(commentCall[1].dId).toBe("D_existing");
+    expect(commentCall[1].body).toContain("Fresh findings");
+    expect(findCall("createDiscussion")).toBeUndefined();
+  });
+
+  it("does not search for duplicates when dedupe is not configured", async () => {
+    const handler = await createDiscussionMain({ fallback_to_issue: false });
+
+    const result = await handler({ title: "Weekly Summary", body: "Body" }, {});
+
+    expect(result.success).toBe(true);
+    expect(findCall("search(query:")).toBeUndefined();
+  });
+});
@@ -26,9 +26,9 @@ function resetIssuesToAssignCopilot() {
 }
 
 const { sanitizeLabelContent } = require("./sanitize_label_content.cjs");
-const { sanitizeTitle, applyTitlePrefix } = require("./sanitize_title.cjs");
+const { sanitizeTitle, applyTitlePrefix, expandTitlePrefix } = require("./sanitize_title.cjs");
 const { generateFooterWithMessages } = require("./messages_footer.cjs");
-const { generateWorkflowIdMarker } = require("./generate_footer.cjs");
+const { generateWorkflowIdMarker, getWorkflowIdMarkerContent } = require("./generate_footer.cjs");
 const { getTrackerID } = require("./get_tracker_id.cjs");
 const { generateTemporaryId, isTemporaryId, normalizeTemporaryId, getOrGenerateTemporaryId, replaceTemporaryIdReferences } = require("./temporary_id.cjs");
 const { parseAllowedRepos, getDefaultTargetRepo, validateRepo, parseRepoSlug } = require("./repo_helpers.cjs");
@@ -103,6 +103,110 @@ async function searchForExistingParent(owner, repo, markerComment) {
   }
 }
 
+/**
+ * Finds the most recent issue created by this workflow within the throttle interval
+ * @param {string} owner - Repository owner
+ * @param {string} repo - Repository name
+ * @param {string} workflowId - Workflow identifier used in the workflow-id marker
+ * @param {number} minIntervalHours - Minimum interval between issues in hours
+ * @returns {Promise<{number: number, html_url: string}|null>} - Recent issue or null if none found
+ */
+async function findRecentWorkflowIssue(owner, repo, workflowId, minIntervalHours) {
+  // GitHub search accepts ISO 8601 timestamps without milliseconds
+  const since = new Date(Date.now() - minIntervalHours * 60 * 60 * 1000).toISOString().replace(/\.\d{3}Z$/, "Z");
+  // Escape quotes in workflow ID to prevent query injection
+  const escapedMarker = getWorkflowIdMarkerContent(workflowId).replace(/"/g, '\\"');
+  const searchQuery = `repo:${owner}/${repo} is:issue "${escapedMarker}" in:body created:>=${since}`;
+  core.info(`Checking for issues created since ${since}: ${searchQuery}`);
+
+  try {
+    const searchResults = await github.rest.search.issuesAndPullRequests({
+      q: searchQuery,
+      per_page: 1,
+      sort: "created",
+      order: "desc",
+    });
+    const recent = searchResults.data.items.find(item => !item.pull_request);
+    return recent ? { number: recent.number, html_url: recent.html_url } : null;
+  } catch (error) {
+    // Fail open: a search failure should not block issue creation
+    core.warning(`Could not check for recent issues (min-interval): ${getErrorMessage(error)}`);
+    return null;
+  }
+}
+
+/**
+ * Parses an assign-from-team value ("org/team-slug" or "team-slug") into its org and slug
+ * @param {string} team - Team reference from the configuration
+ * @param {string} defaultOrg - Organization used when the team has no org prefix
+ * @returns {{org: string, slug: string}|null} - Parsed team or null if invalid
+ */
+function parseTeamReference(team, defaultOrg) {
+  const parts = String(team).trim().split("/");
+  if (parts.length === 1 && parts[0]) {
+    return { org: defaultOrg, slug: parts[0] };
+  }
+  if (parts.length === 2 && parts[0] && parts[1]) {
+    return { org: parts[0], slug: parts[1] };
+  }
+  return null;
+}
+
+/**
+ * Picks the next team member to assign, round-robin. The previous assignee is the one chosen
+ * earlier in this run or, for the first issue, the team member assigned to the most recent
+ * issue carrying this workflow's workflow-id marker.
+ * @param {object} params - Parameters
+ * @param {string} params.owner - Repository owner
+ * @param {string} params.repo - Repository name
+ * @param {{org: string, slug: string}} params.team - Team to assign from
+ * @param {string} params.workflowId - Workflow identifier used in the workflow-id marker
+ * @param {string|null} params.previousAssignee - Member assigned earlier in this run, if any
+ * @returns {Promise<string|null>} - Login of the next member or null if none could be picked
+ */
+async function pickTeamAssignee({ owner, repo, team, workflowId, previousAssignee }) {
+  let members;
+  try {
+    const { data } = await github.rest.teams.listMembersInOrg({ org: team.org, team_slug: team.slug, per_page: 100 });
+    members = data.map(member => member.login).sort();
+  } catch (error) {
+    // Fail open: the issue is still created without the team assignee
+    core.warning(`Could not list members of team ${team.org}/${team.slug} (assign-from-team): ${getErrorMessage(error)}`);
+    return null;
+  }
+  if (members.length === 0) {
+    core.warning(`Team ${team.org}/${team.slug} has no members (assign-from-team)`);
+    return null;
+  }
+
+  let previous = previousAssignee;
+  if (!previous && workflowId) {
+    const escapedMarker = getWorkflowIdMarkerContent(workflowId).replace(/"/g, '\\"');
+    try {
+      const searchResults = await github.rest.search.issuesAndPullRequests({
+        q: `repo:${owner}/${repo} is:issue "${escapedMarker}" in:body`,
+        per_page: 10,
+        sort: "created",
+        order: "desc",
+      });
+      for (const item of searchResults.data.items) {
+        const assigned = (item.assignees || []).map(assignee => assignee.login).find(login => members.includes(login));
+        if (assigned) {
+          previous = assigned;
+          break;
+        }
+      }
+    } catch (error) {
+      core.warning(`Could not find the previous team assignee (assign-from-team): ${getErrorMessage(error)}`);
+    }
+  }
+
+  // indexOf returns -1 for an unknown previous assignee, which starts at the first member
+  const next = members[(members.indexOf(previous ?? "") + 1) % members.length];
+  core.info(`Round-robin assignee from team ${team.org}/${team.slug}: ${next}${previous ? ` (previous: ${previous})` : ""}`);
+  return next;
+}
+
 /**
  * Finds an existing parent issue for a group, or creates a new one if needed
  * @param {object} params - Parameters for finding/creating parent issue
@@ -192,14 +296,21 @@ async function main(config = {}) {
   // Extract configuration
   const envLabels = config.labels ? (Array.isArray(config.labels) ? config.labels : config.labels.split(",")).map(label => String(label).trim()).filter(Boolean) : [];
   const envAssignees = config.assignees ? (Array.isArray(config.assignees) ? config.assignees : config.assignees.split(",")).map(assignee => String(assignee).trim()).filter(Boolean) : [];
-  const titlePrefix = config.title_prefix ?? "";
+  // title_prefix may contain run-context tokens such as {{workflow}} and {{date}}
+  const titlePrefix = expandTitlePrefix(config.title_prefix ?? "", {
+    workflow: process.env.GH_AW_WORKFLOW_NAME,
+    date: new Date().toISOString().slice(0, 10),
+    run_number: context.runNumber,
+  });
   const expiresHours = config.expires ? parseInt(String(config.expires), 10) : 0;
+  const minIntervalHours = config.min_interval ? parseInt(String(config.min_interval), 10) : 0;
   const maxCount = config.max ?? 10;
   const allowedRepos = parseAllowedRepos(config.allowed_repos);
   const defaultTargetRepo = getDefaultTargetRepo(config);
   const groupEnabled = config.group === true || config.group === "true";
   const closeOlderIssuesEnabled = config.close_older_issues === true || config.close_older_issues === "true";
   const includeFooter = config.footer !== false; // Default to true (include footer)
+  const assignFromTeam = config.assign_from_team ? String(config.assign_from_team).trim() : "";
 
   // Check if copilot assignment is enabled
   const assignCopilot = process.env.GH_AW_ASSIGN_COPILOT === "true";
@@ -223,6 +334,12 @@ async function main(config = {}) {
   if (expiresHours > 0) {
     core.info(`Issues expire after: ${expiresHours} hours`);
   }
+  if (minIntervalHours > 0) {
+    core.info(`Minimum interval between issues: ${minIntervalHours} hours`);
+  }
+  if (assignFromTeam) {
+    core.info(`Assigning issues round-robin from team: ${assignFromTeam}`);
+  }
   core.info(`Max count: ${maxCount}`);
   if (groupEnabled) {
     core.info(`Issue grouping enabled: issues will be grouped as sub-issues`);
@@ -243,6 +360,9 @@ async function main(config = {}) {
   // Cache for parent issue per group ID
   const parentIssueCache = new Map();
 
+  // Team member assigned to the previous issue in this run (assign-from-team round-robin)
+  let lastTeamAssignee = null;
+
   // Extract triggering context for footer generation
   const triggeringIssueNumber = context.payload?.issue?.number && !context.payload?.issue?.pull_request ? context.payload.issue.number : undefined;
   const triggeringPRNumber = context.payload?.pull_request?.number || (context.payload?.issue?.pull_request ? context.payload.issue.number : undefined);
@@ -308,6 +428,25 @@ async function main(config = {}) {
       };
     }
 
+    // Skip creation if this workflow already created an issue within the minimum interval
+    if (minIntervalHours > 0) {
+      const throttleWorkflowId = process.env.GH_AW_WORKFLOW_ID ?? "";
+      if (!throttleWorkflowId) {
+        core.warning("min-interval is set but GH_AW_WORKFLOW_ID environment variable is not set - skipping throttle check");
+      } else {
+        const recentIssue = await findRecentWorkflowIssue(repoParts.owner, repoParts.repo, throttleWorkflowId, minIntervalHours);
+        if (recentIssue) {
+          const warning = `Issue #${recentIssue.number} was created by this workflow within the last ${minIntervalHours} hours (min-interval)`;
+          core.warning(`Skipping create_issue: ${warning}: ${recentIssue.html_url}`);
+          return {
+            success: true,
+            warning,
+            skipped: true,
+          };
+        }
+      }
+    }
+
     // Get or generate the temporary ID for this issue
     const tempIdResult = getOrGenerateTemporaryId(message, "issue");
     if (tempIdResult.error) {
@@ -446,6 +585,22 @@ async function main(config = {}) {
     bodyLines.push("");
     const body = bodyLines.join("\n").trim();
 
+    // Add the next member of the configured team (round-robin)
+    if (assignFromTeam) {
+      const team = parseTeamReference(assignFromTeam, repoParts.owner);
+      if (!team) {
+        core.warning(`Invalid assign-from-team value '${assignFromTeam}'. Expected 'org/team-slug' or 'team-slug'.`);
+      } else {
+        const teamAssignee = await pickTeamAssignee({ owner: repoParts.owner, repo: repoParts.repo, team, workflowId, previousAssignee: lastTeamAssignee });
+        if (teamAssignee) {
+          lastTeamAssignee = teamAssignee;
+          if (!assignees.includes(teamAssignee)) {
+            assignees.push(teamAssignee);
+          }
+        }
+      }
+    }
+
     core.info(`Creating issue in ${qualifiedItemRepo} with title: ${title}`);
     core.info(`Labels: ${labels.join(", ")}`);
     if (assignees.length > 0) {
@@ -659,4 +814,4 @@ async function main(config = {}) {
   };
 }
 
-module.exports = { main, createParentIssueTemplate, searchForExistingParent, getSubIssueCount, getIssuesToAssignCopilot, resetIssuesToAssignCopilot };
+module.exports = { main, createParentIssueTemplate, searchForExistingParent, getSubIssueCount, getIssuesToAssignCopilot, resetIssuesToAssignCopilot, parseTeamReference, pickTeamAssignee };
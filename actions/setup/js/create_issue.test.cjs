@@ -3,7 +3,7 @@ import { describe, it, expect, beforeEach, afterEach, vi } from "vitest";
 import { createRequire } from "module";
 
 const require = createRequire(import.meta.url);
-const { main, getIssuesToAssignCopilot, resetIssuesToAssignCopilot } = require("./create_issue.cjs");
+const { main, getIssuesToAssignCopilot, resetIssuesToAssignCopilot, parseTeamReference } = require("./create_issue.cjs");
 
 describe("create_issue", () => {
   let mockGithub;
@@ -241,6 +241,116 @@ describe("create_issue", () => {
     });
   });
 
+  describe("min interval throttle", () => {
+    it("should not search for recent issues when min_interval is not set", async () => {
+      const handler = await main({});
+      const result = await handler({ title: "Daily Report" });
+
+      expect(result.success).toBe(true);
+      expect(mockGithub.rest.search.issuesAndPullRequests).not.toHaveBeenCalled();
+      expect(mockGithub.rest.issues.create).toHaveBeenCalled();
+    });
+
+    it("should create issue when no recent issue exists", async () => {
+      const handler = await main({ min_interval: 24 });
+      const result = await handler({ title: "Daily Report" });
+
+      expect(result.success).toBe(true);
+      expect(result.skipped).toBeUndefined();
+      expect(mockGithub.rest.search.issuesAndPullRequests).toHaveBeenCalledWith(
+        expect.objectContaining({
+          q: expect.stringMatching(/repo:test-owner\/test-repo is:issue "gh-aw-workflow-id: test-workflow" in:body created:>=\d{4}-\d{2}-\d{2}T/),
+        })
+      );
+      expect(mockGithub.rest.issues.create).toHaveBeenCalled();
+    });
+
+    it("should skip issue creation when a recent issue exists", async () => {
+      mockGithub.rest.search.issuesAndPullRequests.mockResolvedValueOnce({
+        data: {
+          total_count: 1,
+          items: [{ number: 42, html_url: "https://github.com/test-owner/test-repo/issues/42" }],
+        },
+      });
+
+      const handler = await main({ min_interval: 24 });
+      const result = await handler({ title: "Daily Report" });
+
+      expect(result.success).toBe(true);
+      expect(result.skipped).toBe(true);
+      expect(result.warning).toContain("#42");
+      expect(mockGithub.rest.issues.create).not.toHaveBeenCalled();
+    });
+
+    it("should create issue when the recent issue search fails", async () => {
+      mockGithub.rest.search.issuesAndPullRequests.mockRejectedValueOnce(new Error("Search unavailable"));
+
+      const handler = await main({ min_interval: 24 });
+      const result = await handler({ title: "Daily Report" });
+
+      expect(result.success).toBe(true);
+      expect(mockCore.warning).toHaveBeenCalledWith(expect.stringContaining("Could not check for recent issues"));
+      expect(mockGithub.rest.issues.create).toHaveBeenCalled();
+    });
+  });
+
+  describe("assign from team", () => {
+    beforeEach(() => {
+      mockGithub.rest.teams = {
+        listMembersInOrg: vi.fn().mockResolvedValue({
+          data: [{ login: "carol" }, { login: "alice" }, { login: "bob" }],
+        }),
+      };
+    });
+
+    it("should parse team references with and without an org", () => {
+      expect(parseTeamReference("triage", "test-owner")).toEqual({ org: "test-owner", slug: "triage" });
+      expect(parseTeamReference("my-org/triage", "test-owner")).toEqual({ org: "my-org", slug: "triage" });
+      expect(parseTeamReference("a/b/c", "test-owner")).toBeNull();
+    });
+
+    it("should assign the first member when no previous assignee is found", async () => {
+      const handler = await main({ assign_from_team: "triage" });
+      const result = await handler({ title: "Bug" });
+
+      expect(result.success).toBe(true);
+      expect(mockGithub.rest.teams.listMembersInOrg).toHaveBeenCalledWith(expect.objectContaining({ org: "test-owner", team_slug: "triage" }));
+      expect(mockGithub.rest.issues.create).toHaveBeenCalledWith(expect.objectContaining({ assignees: ["alice"] }));
+    });
+
+    it("should continue after the member assigned to the most recent workflow issue", async () => {
+      mockGithub.rest.search.issuesAndPullRequests.mockResolvedValueOnce({
+        data: { total_count: 1, items: [{ number: 7, assignees: [{ login: "outsider" }, { login: "carol" }] }] },
+      });
+
+      const handler = await main({ assign_from_team: "my-org/triage" });
+      await handler({ title: "Bug" });
+
+      expect(mockGithub.rest.issues.create).toHaveBeenCalledWith(expect.objectContaining({ assignees: ["alice"] }));
+    });
+
+    it("should rotate through members within a run", async () => {
+      const handler = await main({ assign_from_team: "triage" });
+      await handler({ title: "First" });
+      await handler({ title: "Second" });
+
+      expect(mockGithub.rest.issues.create).toHaveBeenNthCalledWith(1, expect.objectContaining({ assignees: ["alice"] }));
+      expect(mockGithub.rest.issues.create).toHaveBeenNthCalledWith(2, expect.objectContaining({ assignees: ["bob"] }));
+      expect(mockGithub.rest.search.issuesAndPullRequests).toHaveBeenCalledTimes(1);
+    });
+
+    it("should create the issue without a team assignee when listing members fails", async () => {
+      mockGithub.rest.teams.listMembersInOrg.mockRejectedValueOnce(new Error("Not Found"));
+
+      const handler = await main({ assign_from_team: "triage" });
+      const result = await handler({ title: "Bug" });
+
+      expect(result.success).toBe(true);
+      expect(mockCore.warning).toHaveBeenCalledWith(expect.stringContaining("Could not list members of team test-owner/triage"));
+      expect(mockGithub.rest.issues.create).toHaveBeenCalledWith(expect.objectContaining({ assignees: [] }));
+    });
+  });
+
   describe("title prefix", () => {
     it("should apply title prefix", async () => {
       const handler = await main({
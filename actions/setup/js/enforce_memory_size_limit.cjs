@@ -0,0 +1,87 @@
+// @ts-check
+/// <reference types="@actions/github-script" />
+
+const fs = require("fs");
+const path = require("path");
+
+/**
+ * @typedef {Object} SizeLimitResult
+ * @property {number} totalBytes - Total size of the memory directory after enforcement
+ * @property {string[]} removedFiles - Files removed to bring the directory under the limit
+ */
+
+/**
+ * Enforce a total size limit on a memory directory by removing the least recently
+ * modified files until the directory fits within the limit
+ * If maxSizeMB is not a positive number, the directory is left unchanged
+ *
+ * @param {string} memoryDir - Path to the memory directory
+ * @param {number} maxSizeMB - Maximum total size in megabytes
+ * @param {string} [memoryType="cache"] - Type of memory ("cache" or "repo") for log messages
+ * @returns {SizeLimitResult} Total size after enforcement and the list of removed files
+ */
+function enforceMemorySizeLimit(memoryDir, maxSizeMB, memoryType = "cache") {
+  if (!Number.isFinite(maxSizeMB) || maxSizeMB <= 0) {
+    core.info(`No size limit configured for ${memoryType}-memory directory`);
+    return { totalBytes: 0, removedFiles: [] };
+  }
+
+  if (!fs.existsSync(memoryDir)) {
+    core.info(`Memory directory does not exist: ${memoryDir}`);
+    return { totalBytes: 0, removedFiles: [] };
+  }
+
+  const maxBytes = maxSizeMB * 1024 * 1024;
+
+  /** @type {{relativePath: string, fullPath: string, size: number, mtimeMs: number}[]} */
+  const files = [];
+
+  /**
+   * Recursively collect files in the memory directory
+   * @param {string} dirPath - Directory to scan
+   * @param {string} [relativePath=""] - Relative path from memory directory
+   */
+  const collectFiles = (dirPath, relativePath = "") => {
+    for (const entry of fs.readdirSync(dirPath, { withFileTypes: true })) {
+      const fullPath = path.join(dirPath, entry.name);
+      const relativeFilePath = relativePath ? path.join(relativePath, entry.name) : entry.name;
+      if (entry.isDirectory()) {
+        collectFiles(fullPath, relativeFilePath);
+      } else if (entry.isFile()) {
+        const stats = fs.statSync(fullPath);
+        files.push({ relativePath: relativeFilePath, fullPath, size: stats.size, mtimeMs: stats.mtimeMs });
+      }
+    }
+  };
+
+  collectFiles(memoryDir);
+
+  let totalBytes = files.reduce((sum, file) => sum + file.size, 0);
+  if (totalBytes <= maxBytes) {
+    core.info(`${memoryType}-memory size ${totalBytes} bytes is within the ${maxSizeMB} MB limit`);
+    return { totalBytes, removedFiles: [] };
+  }
+
+  core.warning(`${memoryType}-memory size ${totalBytes} bytes exceeds the ${maxSizeMB} MB limit, removing least recently modified files`);
+
+  // Oldest files first; ties broken by path for deterministic results
+  files.sort((a, b) => a.mtimeMs - b.mtimeMs || a.relativePath.localeCompare(b.relativePath));
+
+  const removedFiles = [];
+  for (const file of files) {
+    if (totalBytes <= maxBytes) {
+      break;
+    }
+    fs.rmSync(file.fullPath, { force: true });
+    totalBytes -= file.size;
+    removedFiles.push(file.relativePath);
+    core.info(`  Removed ${file.relativePath} (${file.size} bytes)`);
+  }
+
+  core.info(`Removed ${removedFiles.length} file(s); ${memoryType}-memory size is now ${totalBytes} bytes`);
+  return { totalBytes, removedFiles };
+}
+
+module.exports = {
+  enforceMemorySizeLimit,
+};
@@ -0,0 +1,79 @@
+// @ts-check
+
+import { describe, it, expect, beforeEach, afterEach } from "vitest";
+import fs from "fs";
+import path from "path";
+import os from "os";
+
+const { enforceMemorySizeLimit } = require("./enforce_memory_size_limit.cjs");
+
+// Mock core globally
+global.core = {
+  info: () => {},
+  error: () => {},
+  warning: () => {},
+  debug: () => {},
+};
+
+const MB = 1024 * 1024;
+
+describe("enforceMemorySizeLimit", () => {
+  let tempDir = "";
+
+  beforeEach(() => {
+    tempDir = fs.mkdtempSync(path.join(os.tmpdir(), "memory-size-limit-test-"));
+  });
+
+  afterEach(() => {
+    if (tempDir && fs.existsSync(tempDir)) {
+      fs.rmSync(tempDir, { recursive: true, force: true });
+    }
+  });
+
+  /**
+   * @param {string} relativePath
+   * @param {number} size
+   * @param {number} ageSeconds
+   */
+  const writeFile = (relativePath, size, ageSeconds) => {
+    const fullPath = path.join(tempDir, relativePath);
+    fs.mkdirSync(path.dirname(fullPath), { recursive: true });
+    fs.writeFileSync(fullPath, Buffer.alloc(size, "a"));
+    const mtime = new Date(Date.now() - ageSeconds * 1000);
+    fs.utimesSync(fullPath, mtime, mtime);
+  };
+
+  it("leaves the directory unchanged when within the limit", () => {
+    writeFile("notes.md", 1024, 10);
+    const result = enforceMemorySizeLimit(tempDir, 1);
+    expect(result.removedFiles).toEqual([]);
+    expect(result.totalBytes).toBe(1024);
+    expect(fs.existsSync(path.join(tempDir, "notes.md"))).toBe(true);
+  });
+
+  it("removes the least recently modified files until under the limit", () => {
+    writeFile("old.json", MB, 300);
+    writeFile("state/middle.json", MB, 200);
+    writeFile("new.json", MB, 100);
+
+    const result = enforceMemorySizeLimit(tempDir, 2);
+
+    expect(result.removedFiles).toEqual(["old.json"]);
+    expect(result.totalBytes).toBe(2 * MB);
+    expect(fs.existsSync(path.join(tempDir, "old.json"))).toBe(false);
+    expect(fs.existsSync(path.join(tempDir, "state", "middle.json"))).toBe(true);
+    expect(fs.existsSync(path.join(tempDir, "new.json"))).toBe(true);
+  });
+
+  it("does nothing when no limit is configured", () => {
+    writeFile("big.json", 2 * MB, 10);
+    const result = enforceMemorySizeLimit(tempDir, 0);
+    expect(result.removedFiles).toEqual([]);
+    expect(fs.existsSync(path.join(tempDir, "big.json"))).toBe(true);
+  });
+
+  it("handles a missing directory", () => {
+    const result = enforceMemorySizeLimit(path.join(tempDir, "missing"), 1);
+    expect(result.removedFiles).toEqual([]);
+  });
+});
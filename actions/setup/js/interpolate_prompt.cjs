@@ -130,6 +130,41 @@ function renderMarkdownTemplate(markdown) {
   return result;
 }
 
+/**
+ * Collects the values of the env vars named in GH_AW_PROMPT_REDACT (prompt.redact), longest
+ * first so that a value containing another is replaced whole. The values come from the
+ * variables this step already uses for substitution; only their names are listed.
+ * @param {Record<string, string | undefined>} env - Environment variables to read
+ * @returns {string[]} - The non-empty values to redact
+ */
+function collectRedactedValues(env) {
+  /** @type {string[]} */
+  const values = [];
+  for (const name of (env.GH_AW_PROMPT_REDACT || "").split(",")) {
+    const value = name.trim() ? env[name.trim()] : "";
+    if (value) {
+      values.push(value);
+    }
+  }
+  return values.sort((a, b) => b.length - a.length);
+}
+
+/**
+ * Replaces every redacted value in text with ***. Only used for text that is logged and for
+ * the printed copy of the prompt; the prompt file still receives the real values, and the
+ * values are not registered as secrets so job outputs containing them are unaffected.
+ * @param {string} text - Text about to be logged
+ * @param {string[]} redactedValues - Values returned by collectRedactedValues
+ * @returns {string} - The text with redacted values replaced
+ */
+function redactValues(text, redactedValues) {
+  let result = text;
+  for (const value of redactedValues) {
+    result = result.split(value).join("***");
+  }
+  return result;
+}
+
 /**
  * Main function for prompt variable interpolation and template rendering
  */
@@ -146,6 +181,12 @@ async function main() {
     }
     core.info(`[main] Prompt path: ${promptPath}`);
 
+    // Redact prompt.redact values in everything logged below
+    const redactedValues = collectRedactedValues(process.env);
+    if (redactedValues.length > 0) {
+      core.info(`[main] Redacting ${redactedValues.length} value(s) in logs`);
+    }
+
     // Get the workspace directory for runtime imports
     const workspaceDir = process.env.GITHUB_WORKSPACE;
     if (!workspaceDir) {
@@ -159,7 +200,7 @@ async function main() {
     let content = fs.readFileSync(promptPath, "utf8");
     const originalLength = content.length;
     core.info(`[main] Original content length: ${originalLength} characters`);
-    core.info(`[main] First 200 characters: ${content.substring(0, 200).replace(/\n/g, "\\n")}`);
+    core.info(`[main] First 200 characters: ${redactValues(content, redactedValues).substring(0, 200).replace(/\n/g, "\\n")}`);
 
     // Step 1: Process runtime imports (files and URLs)
     core.info("\n========================================");
@@ -199,8 +240,9 @@ async function main() {
     if (varCount > 0) {
       core.info(`Found ${varCount} expression variable(s) to interpolate:`);
       for (const [key, value] of Object.entries(variables)) {
-        const preview = value.substring(0, 60);
-        core.info(`  ${key}: ${preview}${value.length > 60 ? "..." : ""}`);
+        const printedValue = redactValues(value, redactedValues);
+        const preview = printedValue.substring(0, 60);
+        core.info(`  ${key}: ${preview}${printedValue.length > 60 ? "..." : ""}`);
       }
 
       const beforeInterpolation = content.length;
@@ -242,7 +284,13 @@ async function main() {
 
     fs.writeFileSync(promptPath, content, "utf8");
 
-    core.info(`Last 200 characters: ${content.substring(Math.max(0, content.length - 200)).replace(/\n/g, "\\n")}`);
+    const printedContent = redactValues(content, redactedValues);
+    const redactedPath = process.env.GH_AW_PROMPT_REDACTED;
+    if (redactedPath) {
+      core.info(`Writing redacted copy for printing to: ${redactedPath}`);
+      fs.writeFileSync(redactedPath, printedContent, "utf8");
+    }
+    core.info(`Last 200 characters: ${printedContent.substring(Math.max(0, printedContent.length - 200)).replace(/\n/g, "\\n")}`);
     core.info("========================================");
     core.info("[main] Processing complete - SUCCESS");
     core.info("========================================");
@@ -260,4 +308,4 @@ async function main() {
   }
 }
 
-module.exports = { main };
+module.exports = { main, collectRedactedValues, redactValues };
@@ -120,5 +120,25 @@ describe("interpolate_prompt", () => {
           const main = eval(`(${mainMatch[0]})`);
           (main(), expect(core.setFailed).toHaveBeenCalledWith("GH_AW_PROMPT environment variable is not set"));
         }));
+    }),
+    describe("redactValues", () => {
+      const collectRedactedValuesMatch = interpolatePromptScript.match(/function collectRedactedValues\(env\)\s*{[\s\S]*?^}/m),
+        redactValuesMatch = interpolatePromptScript.match(/function redactValues\(text, redactedValues\)\s*{[\s\S]*?^}/m);
+      if (!collectRedactedValuesMatch) throw new Error("Could not extract collectRedactedValues function from interpolate_prompt.cjs");
+      if (!redactValuesMatch) throw new Error("Could not extract redactValues function from interpolate_prompt.cjs");
+      const collectRedactedValues = eval(`(${collectRedactedValuesMatch[0]})`),
+        redactValues = eval(`(${redactValuesMatch[0]})`);
+      (it("should collect non-empty redacted values longest first", () => {
+        const values = collectRedactedValues({ GH_AW_PROMPT_REDACT: "GH_AW_EXPR_1,GH_AW_EXPR_2,GH_AW_GITHUB_ACTOR,GH_AW_MISSING", GH_AW_EXPR_1: "octo", GH_AW_EXPR_2: "", GH_AW_GITHUB_ACTOR: "octocat", GH_AW_GITHUB_REPOSITORY: "hubot" });
+        expect(values).toEqual(["octocat", "octo"]);
+      }),
+        it("should replace redacted values only in the returned text without registering secrets", () => {
+          core.setSecret = vi.fn();
+          const text = "Triggered by octocat (octo team), octocat again";
+          (expect(redactValues(text, ["octocat", "octo"])).toBe("Triggered by *** (*** team), *** again"), expect(core.setSecret).not.toHaveBeenCalled());
+        }),
+        it("should leave text unchanged without redacted values", () => {
+          expect(redactValues("Triggered by octocat", [])).toBe("Triggered by octocat");
+        }));
     }));
 });
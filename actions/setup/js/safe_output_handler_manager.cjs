@@ -14,9 +14,11 @@ const { getErrorMessage } = require("./error_helpers.cjs");
 const { hasUnresolvedTemporaryIds, replaceTemporaryIdReferences, normalizeTemporaryId } = require("./temporary_id.cjs");
 const { generateMissingInfoSections } = require("./missing_info_formatter.cjs");
 const { setCollectedMissings } = require("./missing_messages_helper.cjs");
-const { writeSafeOutputSummaries } = require("./safe_output_summary.cjs");
+const { writeSafeOutputSummaries, trackRateLimit } = require("./safe_output_summary.cjs");
 const { getIssuesToAssignCopilot } = require("./create_issue.cjs");
 const { createReviewBuffer } = require("./pr_review_buffer.cjs");
+const { withRetry } = require("./error_recovery.cjs");
+const { resolveMaxValues, resolveHandlerConditions } = require("./safe_output_helpers.cjs");
 
 /**
  * Handler map configuration
@@ -70,6 +72,12 @@ const HANDLER_MAP = {
  */
 const STANDALONE_STEP_TYPES = new Set(["assign_to_agent", "create_agent_session", "upload_asset", "noop"]);
 
+/**
+ * Handler types disabled by their if condition, with the reason their messages are skipped
+ * @type {Map<string, string>}
+ */
+const skippedHandlerTypes = new Map();
+
 /**
  * Load configuration for safe outputs
  * Reads configuration from GH_AW_SAFE_OUTPUTS_HANDLER_CONFIG environment variable
@@ -84,12 +92,44 @@ function loadConfig() {
     const config = JSON.parse(process.env.GH_AW_SAFE_OUTPUTS_HANDLER_CONFIG);
     core.info(`Loaded config from GH_AW_SAFE_OUTPUTS_HANDLER_CONFIG: ${JSON.stringify(config)}`);
     // Normalize config keys: convert hyphens to underscores
-    return Object.fromEntries(Object.entries(config).map(([k, v]) => [k.replace(/-/g, "_"), v]));
+    return resolveHandlerConditions(resolveMaxValues(Object.fromEntries(Object.entries(config).map(([k, v]) => [k.replace(/-/g, "_"), v]))), skippedHandlerTypes);
   } catch (error) {
     throw new Error(`Failed to parse GH_AW_SAFE_OUTPUTS_HANDLER_CONFIG: ${getErrorMessage(error)}`);
   }
 }
 
+/**
+ * Retry transient GitHub API failures (e.g. secondary rate limits) at the Octokit request layer.
+ * Handlers catch API errors and report them as failed results, so retries must happen
+ * before the error reaches the handler. Settings come from the `retry` block the compiler
+ * serializes into each handler config; every handler carries the same policy.
+ * @param {any} octokit - Octokit client whose requests should be retried
+ * @param {Object} config - Safe outputs configuration
+ * @returns {boolean} True if the retry hook was installed
+ */
+function installRequestRetry(octokit, config) {
+  const retry = Object.values(config || {}).find(handlerConfig => handlerConfig?.retry)?.retry;
+  const maxAttempts = parseInt(String(retry?.max_attempts ?? "1"), 10);
+  const maxRetries = Number.isFinite(maxAttempts) ? Math.max(0, maxAttempts - 1) : 0;
+  if (maxRetries === 0 || typeof octokit?.hook?.wrap !== "function") {
+    return false;
+  }
+  const baseDelayMs = parseInt(String(retry?.base_delay_ms ?? "1000"), 10);
+  const initialDelayMs = Number.isFinite(baseDelayMs) ? Math.max(0, baseDelayMs) : 1000;
+
+  octokit.hook.wrap("request", async (/** @type {Function} */ request, /** @type {any} */ options) => {
+    try {
+      return await withRetry(() => request(options), { maxRetries, initialDelayMs, maxDelayMs: Math.max(initialDelayMs, 60000) }, `${options.method} ${options.url}`);
+    } catch (error) {
+      // Surface the original API error so handlers keep their existing error handling
+      // @ts-ignore - originalError is added by withRetry
+      throw error?.originalError ?? error;
+    }
+  });
+  core.info(`Retrying transient GitHub API failures up to ${maxRetries} time(s)`);
+  return true;
+}
+
 /** @type {Set<string>} Handler types that participate in the PR review buffer */
 const PR_REVIEW_HANDLER_TYPES = new Set(["create_pull_request_review_comment", "submit_pull_request_review"]);
 
@@ -256,6 +296,20 @@ async function processMessages(messageHandlers, messages) {
         continue;
       }
 
+      const skipReason = skippedHandlerTypes.get(messageType);
+      if (skipReason) {
+        // The handler was disabled by its if condition
+        core.info(`Message ${i + 1} (${messageType}) skipped: ${skipReason}`);
+        results.push({
+          type: messageType,
+          messageIndex: i,
+          success: false,
+          skipped: true,
+          reason: skipReason,
+        });
+        continue;
+      }
+
       // Unknown message type - warn the user
       core.warning(
         `⚠️ No handler loaded for message type '${messageType}' (message ${i + 1}/${messages.length}). The message will be skipped. This may happen if the safe output type is not configured in the workflow's safe-outputs section.`
@@ -724,6 +778,8 @@ async function main() {
     const config = loadConfig();
     core.debug(`Configuration: ${JSON.stringify(Object.keys(config))}`);
 
+    installRequestRetry(github, config);
+
     // Load agent output
     const agentOutput = loadAgentOutput();
     if (!agentOutput.success) {
@@ -763,6 +819,9 @@ async function main() {
       return;
     }
 
+    // Observe API rate-limit headroom while handlers run (reported in the step summary)
+    trackRateLimit(github);
+
     // Process all messages in order of appearance
     const processingResult = await processMessages(messageHandlers, agentOutput.items);
 
@@ -887,4 +946,4 @@ async function main() {
   }
 }
 
-module.exports = { main, loadConfig, loadHandlers, processMessages };
+module.exports = { main, loadConfig, loadHandlers, processMessages, installRequestRetry };
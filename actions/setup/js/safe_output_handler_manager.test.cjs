@@ -1,7 +1,7 @@
 // @ts-check
 
 import { describe, it, expect, beforeEach, afterEach, vi } from "vitest";
-import { loadConfig, loadHandlers, processMessages } from "./safe_output_handler_manager.cjs";
+import { loadConfig, loadHandlers, processMessages, installRequestRetry } from "./safe_output_handler_manager.cjs";
 
 describe("Safe Output Handler Manager", () => {
   beforeEach(() => {
@@ -112,6 +112,57 @@ describe("Safe Output Handler Manager", () => {
     });
   });
 
+  describe("installRequestRetry", () => {
+    /** Minimal Octokit stand-in exposing the request hook */
+    function createOctokit() {
+      const octokit = {
+        /** @type {Function | undefined} */
+        wrapper: undefined,
+        hook: {
+          wrap: vi.fn((name, fn) => {
+            octokit.wrapper = fn;
+          }),
+        },
+      };
+      return octokit;
+    }
+
+    const requestOptions = { method: "POST", url: "/repos/{owner}/{repo}/issues" };
+
+    const retryConfig = { create_issue: { max: 1, retry: { max_attempts: 3, base_delay_ms: 0 } } };
+
+    it("should retry transient API failures before they reach the handler", async () => {
+      const octokit = createOctokit();
+      const request = vi.fn().mockRejectedValueOnce(new Error("You have exceeded a secondary rate limit")).mockResolvedValueOnce({ data: { number: 1 } });
+
+      expect(installRequestRetry(octokit, retryConfig)).toBe(true);
+      expect(octokit.hook.wrap).toHaveBeenCalledWith("request", expect.any(Function));
+
+      const result = await octokit.wrapper(request, requestOptions);
+
+      expect(result).toEqual({ data: { number: 1 } });
+      expect(request).toHaveBeenCalledTimes(2);
+    });
+
+    it("should rethrow the original error for non-transient failures", async () => {
+      const octokit = createOctokit();
+      const request = vi.fn().mockRejectedValue(new Error("Validation failed"));
+
+      installRequestRetry(octokit, retryConfig);
+
+      await expect(octokit.wrapper(request, requestOptions)).rejects.toThrow(/^Validation failed$/);
+      expect(request).toHaveBeenCalledTimes(1);
+    });
+
+    it("should not install the hook when retries are disabled", () => {
+      const octokit = createOctokit();
+
+      expect(installRequestRetry(octokit, { create_issue: { max: 1 } })).toBe(false);
+      expect(installRequestRetry(octokit, { create_issue: { max: 1, retry: { max_attempts: 1, base_delay_ms: 0 } } })).toBe(false);
+      expect(octokit.hook.wrap).not.toHaveBeenCalled();
+    });
+  });
+
   describe("processMessages", () => {
     it("should process messages in order of appearance", async () => {
       const messages = [
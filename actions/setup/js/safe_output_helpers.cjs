@@ -44,6 +44,71 @@ function parseMaxCount(envValue, defaultValue = 3) {
   return { valid: true, value: parsed };
 }
 
+/**
+ * Apply handler max values provided as expressions.
+ * The compiler passes `max: ${{ vars.MAX_COMMENTS }}` to the step as the env var
+ * GH_AW_SAFE_OUTPUTS_MAX_<TYPE> rather than writing it into the config, and records the schema
+ * maximum for that type as `max_limit`. An unset or empty variable keeps the default max.
+ * @param {Object} config - Handler config keyed by safe output type
+ * @returns {Object} Config with max values from the environment applied and `max_limit` removed
+ * @throws {Error} If an evaluated max is not a positive integer or exceeds `max_limit`
+ */
+function resolveMaxValues(config) {
+  return Object.fromEntries(
+    Object.entries(config).map(([type, handlerConfig]) => {
+      if (!handlerConfig || typeof handlerConfig !== "object") {
+        return [type, handlerConfig];
+      }
+      const { max_limit: maxLimit, ...rest } = handlerConfig;
+      const envValue = process.env[`GH_AW_SAFE_OUTPUTS_MAX_${type.replace(/-/g, "_").toUpperCase()}`];
+      if (!envValue || !envValue.trim()) {
+        return [type, rest];
+      }
+      const result = parseMaxCount(envValue.trim());
+      if (!result.valid) {
+        throw new Error(`${type}: ${result.error}`);
+      }
+      if (typeof maxLimit === "number" && result.value > maxLimit) {
+        throw new Error(`${type}: Invalid max value: ${result.value}. Must not exceed ${maxLimit}`);
+      }
+      return [type, { ...rest, max: result.value }];
+    })
+  );
+}
+
+/**
+ * Drop handlers whose `if` condition evaluated to false.
+ * The compiler marks conditional handlers with `conditional: true` and passes
+ * `if: github.event_name == 'pull_request'` to the step as the env var GH_AW_SAFE_OUTPUTS_IF_<TYPE>,
+ * so by the time the config is loaded GitHub Actions has evaluated the condition into a string.
+ * Conditions evaluating to "false", "0", "null" or an empty string (or an unset variable) disable
+ * the handler; any other value keeps it. The `conditional` key is removed from the handlers that remain.
+ * @param {Object} config - Handler config keyed by safe output type
+ * @param {Map<string, string>} [skipped] - Receives the disabled handler types and the reason they were skipped
+ * @returns {Object} Config without the disabled handlers
+ */
+function resolveHandlerConditions(config, skipped) {
+  const falsy = new Set(["", "false", "0", "null"]);
+  return Object.fromEntries(
+    Object.entries(config).flatMap(([type, handlerConfig]) => {
+      if (!handlerConfig || typeof handlerConfig !== "object" || !("conditional" in handlerConfig)) {
+        return [[type, handlerConfig]];
+      }
+      const { conditional, ...rest } = handlerConfig;
+      if (!conditional) {
+        return [[type, rest]];
+      }
+      const condition = process.env[`GH_AW_SAFE_OUTPUTS_IF_${type.replace(/-/g, "_").toUpperCase()}`] ?? "";
+      if (falsy.has(condition.trim().toLowerCase())) {
+        core.info(`Handler ${type} disabled: if condition evaluated to '${condition}'`);
+        skipped?.set(type.replace(/-/g, "_"), "if condition false");
+        return [];
+      }
+      return [[type, rest]];
+    })
+  );
+}
+
 /**
  * Resolve the target number (issue/PR) based on configuration and context
  *
@@ -310,6 +375,8 @@ function extractAssignees(message) {
 module.exports = {
   parseAllowedItems,
   parseMaxCount,
+  resolveMaxValues,
+  resolveHandlerConditions,
   resolveTarget,
   loadCustomSafeOutputJobTypes,
   resolveIssueNumber,
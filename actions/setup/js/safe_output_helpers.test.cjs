@@ -1,4 +1,4 @@
-import { describe, it, expect } from "vitest";
+import { describe, it, expect, vi } from "vitest";
 
 describe("safe_output_helpers", () => {
   let helpers;
@@ -75,6 +75,67 @@ describe("safe_output_helpers", () => {
     });
   });
 
+  describe("resolveMaxValues", () => {
+    afterEach(() => {
+      delete process.env.GH_AW_SAFE_OUTPUTS_MAX_ADD_COMMENT;
+    });
+
+    it("should apply the max from the environment", () => {
+      process.env.GH_AW_SAFE_OUTPUTS_MAX_ADD_COMMENT = "5";
+      const result = helpers.resolveMaxValues({ add_comment: { max: 1, max_limit: 100, target: "*" } });
+      expect(result.add_comment).toEqual({ max: 5, target: "*" });
+    });
+
+    it("should keep the configured max when the variable is unset or empty", () => {
+      const result = helpers.resolveMaxValues({ add_comment: { max: 3, max_limit: 100 }, noop: {} });
+      expect(result.add_comment).toEqual({ max: 3 });
+      expect(result.noop).toEqual({});
+
+      process.env.GH_AW_SAFE_OUTPUTS_MAX_ADD_COMMENT = " ";
+      expect(helpers.resolveMaxValues({ add_comment: { max: 3 } }).add_comment).toEqual({ max: 3 });
+    });
+
+    it("should throw for a max that is not a positive integer", () => {
+      process.env.GH_AW_SAFE_OUTPUTS_MAX_ADD_COMMENT = "many";
+      expect(() => helpers.resolveMaxValues({ add_comment: { max: 1 } })).toThrow("add_comment: Invalid max value: many");
+    });
+
+    it("should throw for a max above the schema maximum", () => {
+      process.env.GH_AW_SAFE_OUTPUTS_MAX_ADD_COMMENT = "101";
+      expect(() => helpers.resolveMaxValues({ "add-comment": { max: 1, max_limit: 100 } })).toThrow("add-comment: Invalid max value: 101. Must not exceed 100");
+    });
+  });
+
+  describe("resolveHandlerConditions", () => {
+    beforeEach(() => {
+      global.core = { info: vi.fn() };
+    });
+
+    afterEach(() => {
+      delete process.env.GH_AW_SAFE_OUTPUTS_IF_ADD_COMMENT;
+      delete process.env.GH_AW_SAFE_OUTPUTS_IF_CREATE_ISSUE;
+    });
+
+    it("should keep handlers whose condition evaluated to true and drop the conditional key", () => {
+      process.env.GH_AW_SAFE_OUTPUTS_IF_ADD_COMMENT = "true";
+      const result = helpers.resolveHandlerConditions({ add_comment: { conditional: true, target: "*" }, noop: {} });
+      expect(result).toEqual({ add_comment: { target: "*" }, noop: {} });
+    });
+
+    it("should drop handlers whose condition evaluated to false or is unset and record them as skipped", () => {
+      process.env.GH_AW_SAFE_OUTPUTS_IF_ADD_COMMENT = "false";
+      const skipped = new Map();
+      const result = helpers.resolveHandlerConditions({ add_comment: { conditional: true }, "create-issue": { conditional: true }, add_labels: { max: 3 } }, skipped);
+      expect(result).toEqual({ add_labels: { max: 3 } });
+      expect(skipped).toEqual(
+        new Map([
+          ["add_comment", "if condition false"],
+          ["create_issue", "if condition false"],
+        ])
+      );
+    });
+  });
+
   describe("resolveTarget", () => {
     describe("with supportsPR=true (for labels)", () => {
       const baseParams = {
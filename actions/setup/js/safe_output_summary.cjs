@@ -6,10 +6,135 @@
  *
  * This module provides functionality to generate step summaries for safe-output messages.
  * Each processed safe-output generates a summary enclosed in a <details> section.
+ * The summary starts with per-handler counts and the lowest API rate-limit headroom
+ * observed while the handlers ran.
  */
 
 const { displayFileContent } = require("./display_file_helpers.cjs");
 
+/**
+ * @typedef {Object} ObservedRateLimit
+ * @property {string} resource - Rate-limit resource (e.g., "core", "graphql")
+ * @property {number} limit - Maximum requests for the resource in the current window
+ * @property {number} remaining - Lowest remaining request count observed
+ * @property {number} reset - Window reset time in epoch seconds (0 if unknown)
+ */
+
+/** @type {ObservedRateLimit | null} */
+let observedRateLimit = null;
+
+/**
+ * Record rate-limit headers from a GitHub API response, keeping the lowest headroom seen
+ * @param {Record<string, any> | undefined} headers - Response headers
+ */
+function recordRateLimitHeaders(headers) {
+  if (!headers) {
+    return;
+  }
+  const remaining = parseInt(headers["x-ratelimit-remaining"], 10);
+  const limit = parseInt(headers["x-ratelimit-limit"], 10);
+  if (!Number.isFinite(remaining) || !Number.isFinite(limit) || limit <= 0) {
+    return;
+  }
+  if (observedRateLimit && remaining / limit >= observedRateLimit.remaining / observedRateLimit.limit) {
+    return;
+  }
+  const reset = parseInt(headers["x-ratelimit-reset"], 10);
+  observedRateLimit = {
+    resource: headers["x-ratelimit-resource"] || "core",
+    limit,
+    remaining,
+    reset: Number.isFinite(reset) ? reset : 0,
+  };
+}
+
+/**
+ * Observe rate-limit headers on every request made through an Octokit client
+ * Clients without request hooks (e.g., test mocks) are ignored
+ * @param {any} octokit - Octokit client (e.g., the github-script `github` object)
+ */
+function trackRateLimit(octokit) {
+  if (!octokit?.hook?.after || !octokit?.hook?.error) {
+    return;
+  }
+  octokit.hook.after("request", (/** @type {any} */ response) => {
+    recordRateLimitHeaders(response?.headers);
+  });
+  octokit.hook.error("request", (/** @type {any} */ error) => {
+    recordRateLimitHeaders(error?.response?.headers);
+    throw error;
+  });
+}
+
+/**
+ * Get the lowest rate-limit headroom observed so far
+ * @returns {ObservedRateLimit | null}
+ */
+function getObservedRateLimit() {
+  return observedRateLimit;
+}
+
+/**
+ * Reset the observed rate-limit headroom (for testing)
+ */
+function resetObservedRateLimit() {
+  observedRateLimit = null;
+}
+
+/**
+ * Generate per-handler counts and rate-limit headroom for the step summary
+ * @param {Array<Object>} results - Array of processing results
+ * @param {ObservedRateLimit | null} rateLimit - Lowest rate-limit headroom observed, if any
+ * @returns {string} - Markdown content for the step summary
+ */
+function generateHandlerMetrics(results, rateLimit) {
+  /** @type {Map<string, {total: number, succeeded: number, failed: number, deferred: number, skipped: number}>} */
+  const counts = new Map();
+  for (const result of results) {
+    const type = result.type || "unknown";
+    let entry = counts.get(type);
+    if (!entry) {
+      entry = { total: 0, succeeded: 0, failed: 0, deferred: 0, skipped: 0 };
+      counts.set(type, entry);
+    }
+    entry.total++;
+    if (result.success) {
+      entry.succeeded++;
+    } else if (result.deferred) {
+      entry.deferred++;
+    } else if (result.skipped) {
+      entry.skipped++;
+    } else {
+      entry.failed++;
+    }
+  }
+
+  let metrics = "";
+  if (counts.size > 0) {
+    metrics += `| Handler | Total | Succeeded | Failed | Deferred | Skipped |\n`;
+    metrics += `| --- | ---: | ---: | ---: | ---: | ---: |\n`;
+    for (const type of [...counts.keys()].sort()) {
+      const entry = counts.get(type);
+      if (!entry) {
+        continue;
+      }
+      metrics += `| \`${type}\` | ${entry.total} | ${entry.succeeded} | ${entry.failed} | ${entry.deferred} | ${entry.skipped} |\n`;
+    }
+    metrics += `\n`;
+  }
+
+  if (rateLimit) {
+    const headroom = Math.round((rateLimit.remaining / rateLimit.limit) * 100);
+    metrics += `**API rate limit:** lowest observed headroom ${rateLimit.remaining}/${rateLimit.limit} ${rateLimit.resource} requests (${headroom}%)`;
+    if (rateLimit.reset > 0) {
+      metrics += `, resets at ${new Date(rateLimit.reset * 1000).toISOString()}`;
+    }
+    metrics += `\n\n`;
+  }
+
+  return metrics;
+}
+
 /**
  * Generate a step summary for a single safe-output message
  * @param {Object} options - Summary generation options
@@ -116,6 +241,7 @@ async function writeSafeOutputSummaries(results, messages) {
 
   let summaryContent = `## Safe Output Processing Summary\n\n`;
   summaryContent += `Processed ${results.length} safe-output message(s).\n\n`;
+  summaryContent += generateHandlerMetrics(results, getObservedRateLimit());
 
   // Generate summary for each result
   for (const result of results) {
@@ -146,6 +272,11 @@ async function writeSafeOutputSummaries(results, messages) {
 }
 
 module.exports = {
+  generateHandlerMetrics,
   generateSafeOutputSummary,
+  getObservedRateLimit,
+  recordRateLimitHeaders,
+  resetObservedRateLimit,
+  trackRateLimit,
   writeSafeOutputSummaries,
 };
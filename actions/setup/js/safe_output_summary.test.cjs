@@ -19,11 +19,12 @@ const mockCore = {
 // Set up global mocks before importing the module
 globalThis.core = mockCore;
 
-const { generateSafeOutputSummary, writeSafeOutputSummaries } = await import("./safe_output_summary.cjs");
+const { generateHandlerMetrics, generateSafeOutputSummary, getObservedRateLimit, recordRateLimitHeaders, resetObservedRateLimit, trackRateLimit, writeSafeOutputSummaries } = await import("./safe_output_summary.cjs");
 
 describe("safe_output_summary", () => {
   beforeEach(() => {
     vi.clearAllMocks();
+    resetObservedRateLimit();
   });
 
   describe("generateSafeOutputSummary", () => {
@@ -365,4 +366,85 @@ describe("safe_output_summary", () => {
       }
     });
   });
+
+  describe("generateHandlerMetrics", () => {
+    it("should count results per handler for a multi-action run", () => {
+      const results = [
+        { type: "create_issue", messageIndex: 0, success: true },
+        { type: "add_comment", messageIndex: 1, success: true },
+        { type: "create_issue", messageIndex: 2, success: false, error: "Validation failed" },
+        { type: "add_comment", messageIndex: 3, success: false, deferred: true },
+        { type: "noop", messageIndex: 4, success: false, skipped: true, reason: "Handled by standalone step" },
+        { type: "create_issue", messageIndex: 5, success: true },
+      ];
+
+      const metrics = generateHandlerMetrics(results, null);
+
+      expect(metrics).toContain("| Handler | Total | Succeeded | Failed | Deferred | Skipped |");
+      expect(metrics).toContain("| `add_comment` | 2 | 1 | 0 | 1 | 0 |");
+      expect(metrics).toContain("| `create_issue` | 3 | 2 | 1 | 0 | 0 |");
+      expect(metrics).toContain("| `noop` | 1 | 0 | 0 | 0 | 1 |");
+      expect(metrics.indexOf("add_comment")).toBeLessThan(metrics.indexOf("create_issue"));
+      expect(metrics).not.toContain("API rate limit");
+    });
+
+    it("should include rate-limit headroom when observed", () => {
+      const metrics = generateHandlerMetrics([{ type: "create_issue", success: true }], { resource: "core", limit: 5000, remaining: 4500, reset: 1700000000 });
+
+      expect(metrics).toContain("**API rate limit:** lowest observed headroom 4500/5000 core requests (90%), resets at 2023-11-14T22:13:20.000Z");
+    });
+  });
+
+  describe("rate-limit tracking", () => {
+    it("should keep the lowest headroom observed", () => {
+      recordRateLimitHeaders({ "x-ratelimit-limit": "5000", "x-ratelimit-remaining": "4990", "x-ratelimit-resource": "core", "x-ratelimit-reset": "1700000000" });
+      recordRateLimitHeaders({ "x-ratelimit-limit": "5000", "x-ratelimit-remaining": "4980", "x-ratelimit-resource": "core", "x-ratelimit-reset": "1700000000" });
+      recordRateLimitHeaders({ "x-ratelimit-limit": "5000", "x-ratelimit-remaining": "4995", "x-ratelimit-resource": "core", "x-ratelimit-reset": "1700000000" });
+      recordRateLimitHeaders({});
+
+      expect(getObservedRateLimit()).toEqual({ resource: "core", limit: 5000, remaining: 4980, reset: 1700000000 });
+    });
+
+    it("should observe headers from Octokit request hooks", async () => {
+      /** @type {Record<string, Function>} */
+      const hooks = {};
+      const octokit = {
+        hook: {
+          after: vi.fn((name, fn) => (hooks.after = fn)),
+          error: vi.fn((name, fn) => (hooks.error = fn)),
+        },
+      };
+
+      trackRateLimit(octokit);
+      hooks.after({ headers: { "x-ratelimit-limit": "5000", "x-ratelimit-remaining": "4000" } });
+      const error = Object.assign(new Error("Forbidden"), { response: { headers: { "x-ratelimit-limit": "5000", "x-ratelimit-remaining": "10", "x-ratelimit-resource": "graphql" } } });
+      expect(() => hooks.error(error)).toThrow("Forbidden");
+
+      expect(getObservedRateLimit()).toEqual({ resource: "graphql", limit: 5000, remaining: 10, reset: 0 });
+    });
+
+    it("should ignore clients without request hooks", () => {
+      expect(() => trackRateLimit({})).not.toThrow();
+      expect(() => trackRateLimit(null)).not.toThrow();
+    });
+
+    it("should include per-handler counts and headroom in the written summary", async () => {
+      recordRateLimitHeaders({ "x-ratelimit-limit": "5000", "x-ratelimit-remaining": "4900" });
+
+      const results = [
+        { type: "create_issue", messageIndex: 0, success: true, result: { repo: "owner/repo", number: 1 } },
+        { type: "add_labels", messageIndex: 1, success: true, result: {} },
+        { type: "add_labels", messageIndex: 2, success: false, error: "Label not allowed" },
+      ];
+      const messages = [{ title: "Issue 1" }, { labels: ["bug"] }, { labels: ["wontfix"] }];
+
+      await writeSafeOutputSummaries(results, messages);
+
+      const summaryContent = mockCore.summary.addRaw.mock.calls[0][0];
+      expect(summaryContent).toContain("| `add_labels` | 2 | 1 | 1 | 0 | 0 |");
+      expect(summaryContent).toContain("| `create_issue` | 1 | 1 | 0 | 0 | 0 |");
+      expect(summaryContent).toContain("lowest observed headroom 4900/5000 core requests (98%)");
+      expect(summaryContent.indexOf("| Handler |")).toBeLessThan(summaryContent.indexOf("<details>"));
+    });
+  });
 });
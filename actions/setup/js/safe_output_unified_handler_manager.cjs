@@ -19,10 +19,10 @@ const { getErrorMessage } = require("./error_helpers.cjs");
 const { hasUnresolvedTemporaryIds, replaceTemporaryIdReferences, normalizeTemporaryId, loadTemporaryIdMap, isTemporaryId } = require("./temporary_id.cjs");
 const { generateMissingInfoSections } = require("./missing_info_formatter.cjs");
 const { setCollectedMissings } = require("./missing_messages_helper.cjs");
-const { writeSafeOutputSummaries } = require("./safe_output_summary.cjs");
+const { writeSafeOutputSummaries, trackRateLimit } = require("./safe_output_summary.cjs");
 const { getIssuesToAssignCopilot } = require("./create_issue.cjs");
 const { sortSafeOutputMessages } = require("./safe_output_topological_sort.cjs");
-const { loadCustomSafeOutputJobTypes } = require("./safe_output_helpers.cjs");
+const { loadCustomSafeOutputJobTypes, resolveMaxValues, resolveHandlerConditions } = require("./safe_output_helpers.cjs");
 const { createReviewBuffer } = require("./pr_review_buffer.cjs");
 
 /**
@@ -92,6 +92,12 @@ const STANDALONE_STEP_TYPES = new Set(["assign_to_agent", "create_agent_session"
  */
 const PROJECT_RELATED_TYPES = new Set(Object.keys(PROJECT_HANDLER_MAP));
 
+/**
+ * Handler types disabled by their if condition, with the reason their messages are skipped
+ * @type {Map<string, string>}
+ */
+const skippedHandlerTypes = new Map();
+
 /**
  * Load configuration for safe outputs
  * Reads configuration from both GH_AW_SAFE_OUTPUTS_HANDLER_CONFIG and GH_AW_SAFE_OUTPUTS_PROJECT_HANDLER_CONFIG
@@ -109,7 +115,7 @@ function loadConfig() {
       core.info(`Loaded config from GH_AW_SAFE_OUTPUTS_HANDLER_CONFIG: ${JSON.stringify(config)}`);
 
       // Normalize config keys: convert hyphens to underscores
-      const normalizedEntries = Object.entries(config).map(([k, v]) => [k.replace(/-/g, "_"), v]);
+      const normalizedEntries = Object.entries(resolveHandlerConditions(resolveMaxValues(config), skippedHandlerTypes)).map(([k, v]) => [k.replace(/-/g, "_"), v]);
 
       // Automatically split project handlers from regular handlers
       // Project handlers (update_project, create_project, create_project_status_update) require
@@ -136,7 +142,7 @@ function loadConfig() {
       core.info(`Loaded project handler config: ${JSON.stringify(config)}`);
       // Normalize config keys: convert hyphens to underscores
       // Explicitly provided project config takes precedence over auto-split config
-      Object.assign(project, Object.fromEntries(Object.entries(config).map(([k, v]) => [k.replace(/-/g, "_"), v])));
+      Object.assign(project, resolveHandlerConditions(resolveMaxValues(Object.fromEntries(Object.entries(config).map(([k, v]) => [k.replace(/-/g, "_"), v]))), skippedHandlerTypes));
     } catch (error) {
       throw new Error(`Failed to parse GH_AW_SAFE_OUTPUTS_PROJECT_HANDLER_CONFIG: ${getErrorMessage(error)}`);
     }
@@ -418,6 +424,20 @@ async function processMessages(messageHandlers, messages, projectOctokit = null)
         continue;
       }
 
+      const skipReason = skippedHandlerTypes.get(messageType);
+      if (skipReason) {
+        // The handler was disabled by its if condition
+        core.info(`Message ${i + 1} (${messageType}) skipped: ${skipReason}`);
+        results.push({
+          type: messageType,
+          messageIndex: i,
+          success: false,
+          skipped: true,
+          reason: skipReason,
+        });
+        continue;
+      }
+
       // Unknown message type - warn the user
       core.warning(
         `⚠️ No handler loaded for message type '${messageType}' (message ${i + 1}/${messages.length}). The message will be skipped. This may happen if the safe output type is not configured in the workflow's safe-outputs section.`
@@ -1009,6 +1029,10 @@ async function main() {
       return;
     }
 
+    // Observe API rate-limit headroom while handlers run (reported in the step summary)
+    trackRateLimit(github);
+    trackRateLimit(projectOctokit);
+
     // Process all messages in order of appearance
     // Pass the projectOctokit so project handlers can use it
     const processingResult = await processMessages(messageHandlers, agentOutput.items, projectOctokit);
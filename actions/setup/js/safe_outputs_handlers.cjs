@@ -81,7 +81,16 @@ function createHandlers(server, appendSafeOutput, config = {}) {
    * Handler for upload_asset tool
    */
   const uploadAssetHandler = args => {
-    const branchName = process.env.GH_AW_ASSETS_BRANCH;
+    // A named group overrides the default branch and limits from upload-asset.groups
+    const groupName = args?.group;
+    const groups = config.upload_asset?.groups || {};
+    const group = groupName ? groups[groupName] : undefined;
+    if (groupName && !group) {
+      const available = Object.keys(groups);
+      throw new Error(`Unknown asset group '${groupName}'. ` + (available.length > 0 ? `Available groups: ${available.join(", ")}` : "No asset groups are configured"));
+    }
+
+    const branchName = group ? group.branch : process.env.GH_AW_ASSETS_BRANCH;
     if (!branchName) throw new Error("GH_AW_ASSETS_BRANCH not set");
 
     // Normalize the branch name to ensure it's a valid git branch name
@@ -111,15 +120,17 @@ function createHandlers(server, appendSafeOutput, config = {}) {
     const sizeBytes = stats.size;
     const sizeKB = Math.ceil(sizeBytes / 1024);
 
-    // Check file size - read from environment variable if available
-    const maxSizeKB = process.env.GH_AW_ASSETS_MAX_SIZE_KB ? parseInt(process.env.GH_AW_ASSETS_MAX_SIZE_KB, 10) : 10240; // Default 10MB
+    // Check file size - read from the group or environment variable if available
+    const maxSizeKB = group ? group.max_size_kb : process.env.GH_AW_ASSETS_MAX_SIZE_KB ? parseInt(process.env.GH_AW_ASSETS_MAX_SIZE_KB, 10) : 10240; // Default 10MB
     if (sizeKB > maxSizeKB) {
       throw new Error(`File size ${sizeKB} KB exceeds maximum allowed size ${maxSizeKB} KB`);
     }
 
-    // Check file extension - read from environment variable if available
+    // Check file extension - read from the group or environment variable if available
     const ext = path.extname(filePath).toLowerCase();
-    const allowedExts = process.env.GH_AW_ASSETS_ALLOWED_EXTS
+    const allowedExts = group
+      ? group.allowed_exts
+      : process.env.GH_AW_ASSETS_ALLOWED_EXTS
       ? process.env.GH_AW_ASSETS_ALLOWED_EXTS.split(",").map(ext => ext.trim())
       : [
           // Default set as specified in problem statement
@@ -166,6 +177,7 @@ function createHandlers(server, appendSafeOutput, config = {}) {
       size: sizeBytes,
       url: url,
       targetFileName: targetFileName,
+      ...(group ? { group: groupName } : {}),
     };
 
     appendSafeOutput(entry);
@@ -76,7 +76,27 @@ function applyTitlePrefix(sanitizedTitle, titlePrefix) {
   return cleanTitle;
 }
 
+/**
+ * Expands run-context tokens in a title prefix template, e.g. "[{{workflow}} {{date}}] ".
+ * Supported tokens are {{workflow}}, {{date}} (UTC, YYYY-MM-DD) and {{run_number}}.
+ * Unknown tokens, and tokens without a value, are left as-is.
+ * @param {string} titlePrefix - The title prefix template
+ * @param {Record<string, string | number | undefined>} values - Token values keyed by token name
+ * @returns {string} The title prefix with known tokens replaced
+ */
+function expandTitlePrefix(titlePrefix, values) {
+  if (!titlePrefix || !titlePrefix.includes("{{")) {
+    return titlePrefix;
+  }
+
+  return titlePrefix.replace(/\{\{\s*(\w+)\s*\}\}/g, (match, token) => {
+    const value = values[token];
+    return value !== undefined && value !== null && value !== "" ? String(value) : match;
+  });
+}
+
 module.exports = {
   sanitizeTitle,
   applyTitlePrefix,
+  expandTitlePrefix,
 };
@@ -1,7 +1,7 @@
 // @ts-check
 import { describe, it, expect } from "vitest";
 
-const { sanitizeTitle, applyTitlePrefix } = require("./sanitize_title.cjs");
+const { sanitizeTitle, applyTitlePrefix, expandTitlePrefix } = require("./sanitize_title.cjs");
 
 describe("sanitize_title", () => {
   describe("sanitizeTitle", () => {
@@ -166,6 +166,28 @@ describe("sanitize_title", () => {
     });
   });
 
+  describe("expandTitlePrefix", () => {
+    const values = { workflow: "Daily Report", date: "2026-01-15", run_number: 42 };
+
+    it("should expand known tokens", () => {
+      expect(expandTitlePrefix("[{{workflow}} {{date}}] ", values)).toBe("[Daily Report 2026-01-15] ");
+      expect(expandTitlePrefix("[#{{ run_number }}] ", values)).toBe("[#42] ");
+    });
+
+    it("should leave unknown tokens literal", () => {
+      expect(expandTitlePrefix("[{{unknown}} {{date}}] ", values)).toBe("[{{unknown}} 2026-01-15] ");
+    });
+
+    it("should leave tokens without a value literal", () => {
+      expect(expandTitlePrefix("[{{workflow}}] ", { workflow: "" })).toBe("[{{workflow}}] ");
+    });
+
+    it("should return prefixes without tokens unchanged", () => {
+      expect(expandTitlePrefix("[bot] ", values)).toBe("[bot] ");
+      expect(expandTitlePrefix("", values)).toBe("");
+    });
+  });
+
   describe("integration scenarios", () => {
     it("should handle typical workflow: sanitize then apply prefix", () => {
       const rawTitle = "［Agent］\u200BFix\u202Ebug #123\u202C";
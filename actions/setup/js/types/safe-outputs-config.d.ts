@@ -147,6 +147,8 @@ interface AutofixCodeScanningAlertConfig extends SafeOutputConfig {
  */
 interface AddLabelsConfig extends SafeOutputConfig {
   allowed?: string[];
+  create_if_missing?: boolean;
+  color?: string;
 }
 
 /**
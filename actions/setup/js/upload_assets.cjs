@@ -55,40 +55,44 @@ function normalizeBranchName(branchName) {
   return normalized;
 }
 
-async function main() {
-  // Check if we're in staged mode
-  const isStaged = process.env.GH_AW_SAFE_OUTPUTS_STAGED === "true";
-
-  // Get the branch name from environment variable (required)
-  const branchName = process.env.GH_AW_ASSETS_BRANCH;
-  if (!branchName || typeof branchName !== "string") {
-    core.setFailed("GH_AW_ASSETS_BRANCH environment variable is required but not set");
-    return;
+/**
+ * Resolves a named asset group from the GH_AW_ASSETS_GROUPS list and its
+ * GH_AW_ASSETS_<NAME>_BRANCH, _MAX_SIZE_KB and _ALLOWED_EXTS variables.
+ * @param {string} groupName - The group name recorded by the upload_asset tool
+ * @returns {{branch: string, maxSizeKB: number, allowedExts: string[]} | null} The group, or null if it is not configured
+ */
+function getAssetGroup(groupName) {
+  const configuredGroups = (process.env.GH_AW_ASSETS_GROUPS || "")
+    .split(",")
+    .map(name => name.trim())
+    .filter(Boolean);
+  if (!configuredGroups.includes(groupName)) {
+    return null;
   }
 
-  // Normalize the branch name to ensure it's a valid git branch name
-  const normalizedBranchName = normalizeBranchName(branchName);
-  core.info(`Using assets branch: ${normalizedBranchName}`);
-
-  const result = loadAgentOutput();
-  if (!result.success) {
-    core.setOutput("upload_count", "0");
-    core.setOutput("branch_name", normalizedBranchName);
-    return;
+  const prefix = `GH_AW_ASSETS_${groupName.toUpperCase().replace(/-/g, "_")}_`;
+  const branch = process.env[`${prefix}BRANCH`];
+  if (!branch) {
+    return null;
   }
+  return {
+    branch,
+    maxSizeKB: parseInt(process.env[`${prefix}MAX_SIZE_KB`] || "0", 10),
+    allowedExts: (process.env[`${prefix}ALLOWED_EXTS`] || "")
+      .split(",")
+      .map(ext => ext.trim())
+      .filter(Boolean),
+  };
+}
 
-  // Find all upload-asset items
-  const uploadItems = result.items.filter(/** @param {any} item */ item => item.type === "upload_asset");
-
-  if (uploadItems.length === 0) {
-    core.info("No upload-asset items found in agent output");
-    core.setOutput("upload_count", "0");
-    core.setOutput("branch_name", normalizedBranchName);
-    return;
-  }
-
-  core.info(`Found ${uploadItems.length} upload-asset item(s)`);
-
+/**
+ * Commits the given assets to a branch, creating it as an orphaned branch when needed.
+ * @param {string} normalizedBranchName - The normalized branch name
+ * @param {any[]} uploadItems - The upload_asset items to publish on this branch
+ * @param {boolean} isStaged - Whether to skip the push (staged mode)
+ * @returns {Promise<number>} The number of uploaded assets, or -1 when the step failed
+ */
+async function publishAssetsToBranch(normalizedBranchName, uploadItems, isStaged) {
   let uploadCount = 0;
   let hasChanges = false;
 
@@ -106,7 +110,7 @@ async function main() {
             `Orphaned branches can only be automatically created under the 'assets/' prefix. ` +
             `Please create the branch manually first, or use a branch name starting with 'assets/'.`
         );
-        return;
+        return -1;
       }
 
       // Branch doesn't exist on origin and has valid prefix, create orphaned branch
@@ -122,14 +126,14 @@ async function main() {
 
       if (!fileName || !sha || !targetFileName) {
         core.setFailed(`Invalid asset entry missing required fields: ${JSON.stringify(asset)}`);
-        return;
+        return -1;
       }
 
       // Check if file exists in artifacts
       const assetSourcePath = path.join("/tmp/gh-aw/safeoutputs/assets", fileName);
       if (!fs.existsSync(assetSourcePath)) {
         core.setFailed(`Asset file not found: ${assetSourcePath}`);
-        return;
+        return -1;
       }
 
       // Verify SHA matches
@@ -138,7 +142,7 @@ async function main() {
 
       if (computedSha !== sha) {
         core.setFailed(`SHA mismatch for ${fileName}: expected ${sha}, got ${computedSha}`);
-        return;
+        return -1;
       }
 
       // Check if file already exists in the branch
@@ -160,7 +164,7 @@ async function main() {
         core.info(`Added asset: ${targetFileName} (${size} bytes)`);
       } catch (error) {
         core.setFailed(`Failed to process asset ${fileName}: ${getErrorMessage(error)}`);
-        return;
+        return -1;
       }
     }
 
@@ -183,13 +187,90 @@ async function main() {
       }
       core.summary.write();
     } else {
-      core.info("No new assets to upload");
+      core.info(`No new assets to upload to ${normalizedBranchName}`);
     }
   } catch (error) {
     core.setFailed(`Failed to upload assets: ${getErrorMessage(error)}`);
+    return -1;
+  }
+
+  return uploadCount;
+}
+
+async function main() {
+  // Check if we're in staged mode
+  const isStaged = process.env.GH_AW_SAFE_OUTPUTS_STAGED === "true";
+
+  // Get the branch name from environment variable (required)
+  const branchName = process.env.GH_AW_ASSETS_BRANCH;
+  if (!branchName || typeof branchName !== "string") {
+    core.setFailed("GH_AW_ASSETS_BRANCH environment variable is required but not set");
+    return;
+  }
+
+  // Normalize the branch name to ensure it's a valid git branch name
+  const normalizedBranchName = normalizeBranchName(branchName);
+  core.info(`Using assets branch: ${normalizedBranchName}`);
+
+  const result = loadAgentOutput();
+  if (!result.success) {
+    core.setOutput("upload_count", "0");
+    core.setOutput("branch_name", normalizedBranchName);
+    return;
+  }
+
+  // Find all upload-asset items
+  const uploadItems = result.items.filter(/** @param {any} item */ item => item.type === "upload_asset");
+
+  if (uploadItems.length === 0) {
+    core.info("No upload-asset items found in agent output");
+    core.setOutput("upload_count", "0");
+    core.setOutput("branch_name", normalizedBranchName);
     return;
   }
 
+  core.info(`Found ${uploadItems.length} upload-asset item(s)`);
+
+  // Split items by target branch: items without a group use the default branch,
+  // grouped items use their group's branch and are re-checked against its limits
+  /** @type {Map<string, any[]>} */
+  const itemsByBranch = new Map([[normalizedBranchName, []]]);
+  for (const asset of uploadItems) {
+    let targetBranch = normalizedBranchName;
+    if (asset.group) {
+      const group = getAssetGroup(asset.group);
+      if (!group) {
+        core.setFailed(`Asset ${asset.fileName} references unknown asset group '${asset.group}'`);
+        return;
+      }
+      const ext = path.extname(asset.fileName || "").toLowerCase();
+      if (!group.allowedExts.includes(ext)) {
+        core.setFailed(`File extension '${ext}' of asset ${asset.fileName} is not allowed in group '${asset.group}'. Allowed extensions: ${group.allowedExts.join(", ")}`);
+        return;
+      }
+      if (group.maxSizeKB > 0 && Math.ceil((asset.size || 0) / 1024) > group.maxSizeKB) {
+        core.setFailed(`Asset ${asset.fileName} exceeds the maximum size of ${group.maxSizeKB} KB for group '${asset.group}'`);
+        return;
+      }
+      targetBranch = normalizeBranchName(group.branch);
+    }
+    const branchItems = itemsByBranch.get(targetBranch) || [];
+    branchItems.push(asset);
+    itemsByBranch.set(targetBranch, branchItems);
+  }
+
+  let uploadCount = 0;
+  for (const [targetBranch, branchItems] of itemsByBranch) {
+    if (branchItems.length === 0) {
+      continue;
+    }
+    const branchCount = await publishAssetsToBranch(targetBranch, branchItems, isStaged);
+    if (branchCount < 0) {
+      return;
+    }
+    uploadCount += branchCount;
+  }
+
   core.setOutput("upload_count", uploadCount.toString());
   core.setOutput("branch_name", normalizedBranchName);
 }
@@ -136,5 +136,44 @@ const mockCore = { debug: vi.fn(), info: vi.fn(), notice: vi.fn(), warning: vi.f
               fs.existsSync(assetPath) && fs.unlinkSync(assetPath),
               fs.existsSync("test.png") && fs.unlinkSync("test.png"));
           }));
+      }),
+      describe("asset groups", () => {
+        const writeGroupAsset = fileName => {
+          const assetDir = "/tmp/gh-aw/safeoutputs/assets";
+          fs.existsSync(assetDir) || fs.mkdirSync(assetDir, { recursive: !0 });
+          const assetPath = path.join(assetDir, fileName);
+          fs.writeFileSync(assetPath, "fake log data");
+          const fileContent = fs.readFileSync(assetPath);
+          return { assetPath, sha: require("crypto").createHash("sha256").update(fileContent).digest("hex"), size: fileContent.length };
+        };
+        beforeEach(() => {
+          ((process.env.GH_AW_ASSETS_BRANCH = "assets/default"),
+            (process.env.GH_AW_SAFE_OUTPUTS_STAGED = "false"),
+            (process.env.GH_AW_ASSETS_GROUPS = "logs"),
+            (process.env.GH_AW_ASSETS_LOGS_BRANCH = "assets/logs"),
+            (process.env.GH_AW_ASSETS_LOGS_MAX_SIZE_KB = "1024"),
+            (process.env.GH_AW_ASSETS_LOGS_ALLOWED_EXTS = ".log,.txt"));
+        });
+        afterEach(() => {
+          for (const name of ["GH_AW_ASSETS_GROUPS", "GH_AW_ASSETS_LOGS_BRANCH", "GH_AW_ASSETS_LOGS_MAX_SIZE_KB", "GH_AW_ASSETS_LOGS_ALLOWED_EXTS"]) delete process.env[name];
+        });
+        it("should publish grouped assets to the group branch", async () => {
+          const { assetPath, sha, size } = writeGroupAsset("run.log");
+          setAgentOutput({ items: [{ type: "upload_asset", group: "logs", fileName: "run.log", sha, size, targetFileName: "run-target.log", url: "https://example.com/run.log" }] });
+          await executeScript();
+          const pushCall = mockExec.exec.mock.calls.find(call => "string" == typeof call[0] && call[0].startsWith("git push"));
+          (expect(mockCore.setFailed).not.toHaveBeenCalled(), expect(pushCall[0]).toBe("git push origin assets/logs"));
+          (fs.existsSync(assetPath) && fs.unlinkSync(assetPath), fs.existsSync("run-target.log") && fs.unlinkSync("run-target.log"));
+        });
+        it("should reject grouped assets with an extension outside the group allowlist", async () => {
+          const { assetPath, sha, size } = writeGroupAsset("chart.png");
+          setAgentOutput({ items: [{ type: "upload_asset", group: "logs", fileName: "chart.png", sha, size, targetFileName: "chart-target.png", url: "https://example.com/chart.png" }] });
+          (await executeScript(), expect(mockCore.setFailed).toHaveBeenCalledWith(expect.stringContaining("not allowed in group 'logs'")));
+          fs.existsSync(assetPath) && fs.unlinkSync(assetPath);
+        });
+        it("should fail for an unknown group", async () => {
+          setAgentOutput({ items: [{ type: "upload_asset", group: "videos", fileName: "clip.mp4", sha: "abc", size: 1, targetFileName: "clip.mp4", url: "https://example.com/clip.mp4" }] });
+          (await executeScript(), expect(mockCore.setFailed).toHaveBeenCalledWith(expect.stringContaining("unknown asset group 'videos'")));
+        });
       }));
   }));
@@ -0,0 +1,104 @@
+// @ts-check
+
+/**
+ * OAuth scopes that only grant read access. Any other scope reported for a
+ * classic token (repo, public_repo, workflow, write:*, admin:*, ...) allows writes.
+ */
+const READ_ONLY_OAUTH_SCOPES = new Set([
+  "read:audit_log",
+  "read:discussion",
+  "read:enterprise",
+  "read:gpg_key",
+  "read:org",
+  "read:packages",
+  "read:project",
+  "read:public_key",
+  "read:repo_hook",
+  "read:ssh_signing_key",
+  "read:user",
+  "user:email",
+]);
+
+/**
+ * Returns the scopes from an X-OAuth-Scopes header value that grant write access.
+ *
+ * @param {string} scopesHeader - Comma-separated scope list
+ * @returns {string[]}
+ */
+function getWriteScopes(scopesHeader) {
+  return scopesHeader
+    .split(",")
+    .map(scope => scope.trim())
+    .filter(scope => scope !== "" && !READ_ONLY_OAUTH_SCOPES.has(scope));
+}
+
+/**
+ * Verifies that the token used by the GitHub MCP server cannot write.
+ *
+ * Enabled by tools.github.read-only-enforcement. Read-only toolsets only limit the
+ * tools the agent is offered; this check fails fast when the token itself is broader:
+ *   - Classic PATs and OAuth tokens: scopes are read from the X-OAuth-Scopes header
+ *   - Installation tokens (GITHUB_TOKEN, GitHub App): bounded by the job permissions,
+ *     passed by the compiler in GH_AW_JOB_WRITE_PERMISSIONS
+ *   - Fine-grained PATs: permissions cannot be introspected, so a warning is emitted
+ *
+ * @param {any} core - GitHub Actions core library
+ * @returns {Promise<void>}
+ */
+async function validateReadOnlyToken(core) {
+  const token = process.env.GH_AW_READ_ONLY_TOKEN || "";
+  if (!token) {
+    const errorMessage = "read-only-enforcement is enabled but no GitHub token is available for the GitHub MCP server";
+    core.setFailed(errorMessage);
+    throw new Error(errorMessage);
+  }
+
+  const apiUrl = process.env.GITHUB_API_URL || "https://api.github.com";
+  const response = await fetch(`${apiUrl}/rate_limit`, {
+    headers: {
+      Accept: "application/vnd.github+json",
+      Authorization: `Bearer ${token}`,
+    },
+  });
+  if (!response.ok) {
+    const errorMessage = `Failed to verify GitHub token scopes: ${apiUrl}/rate_limit returned HTTP ${response.status}`;
+    core.setFailed(errorMessage);
+    throw new Error(errorMessage);
+  }
+
+  const scopesHeader = response.headers.get("x-oauth-scopes");
+  if (scopesHeader !== null) {
+    core.info(`Token OAuth scopes: ${scopesHeader || "(none)"}`);
+    const writeScopes = getWriteScopes(scopesHeader);
+    if (writeScopes.length > 0) {
+      const errorMessage =
+        `The GitHub MCP server token has write scopes: ${writeScopes.join(", ")}.\n` +
+        "tools.github.read-only-enforcement requires a token without write access.\n" +
+        "Use a token limited to read scopes, or remove read-only-enforcement.";
+      core.setFailed(errorMessage);
+      throw new Error(errorMessage);
+    }
+    core.info("✓ GitHub MCP server token has no write scopes");
+    return;
+  }
+
+  if (token.startsWith("ghs_")) {
+    const writePermissions = (process.env.GH_AW_JOB_WRITE_PERMISSIONS || "")
+      .split(",")
+      .map(permission => permission.trim())
+      .filter(permission => permission !== "");
+    if (writePermissions.length > 0) {
+      const errorMessage =
+        `The GitHub MCP server uses an installation token and the job grants write permissions: ${writePermissions.join(", ")}.\n` +
+        "tools.github.read-only-enforcement requires read-only job permissions or a separate read-only github-token.";
+      core.setFailed(errorMessage);
+      throw new Error(errorMessage);
+    }
+    core.info("✓ GitHub MCP server installation token is limited to read-only job permissions");
+    return;
+  }
+
+  core.warning("Could not determine the permissions of the GitHub MCP server token (fine-grained tokens do not report them). Make sure it is limited to read access.");
+}
+
+module.exports = { validateReadOnlyToken, getWriteScopes };
@@ -0,0 +1,104 @@
+import { describe, it, expect, beforeEach, afterEach, vi } from "vitest";
+
+const { validateReadOnlyToken, getWriteScopes } = require("./validate_read_only_token.cjs");
+
+describe("validate_read_only_token", () => {
+  let mockCore;
+
+  /**
+   * @param {Record<string, string>} headers
+   * @param {number} [status]
+   */
+  const mockFetch = (headers, status = 200) => {
+    global.fetch = vi.fn().mockResolvedValue({
+      ok: status >= 200 && status < 300,
+      status,
+      headers: new Headers(headers),
+    });
+  };
+
+  beforeEach(() => {
+    mockCore = {
+      info: vi.fn(),
+      warning: vi.fn(),
+      setFailed: vi.fn(),
+    };
+    delete process.env.GH_AW_READ_ONLY_TOKEN;
+    delete process.env.GH_AW_JOB_WRITE_PERMISSIONS;
+  });
+
+  afterEach(() => {
+    vi.restoreAllMocks();
+  });
+
+  it("fails when no token is configured", async () => {
+    await expect(validateReadOnlyToken(mockCore)).rejects.toThrow("no GitHub token");
+    expect(mockCore.setFailed).toHaveBeenCalled();
+  });
+
+  it("passes for a classic token with only read scopes", async () => {
+    process.env.GH_AW_READ_ONLY_TOKEN = "ghp_test";
+    mockFetch({ "x-oauth-scopes": "read:org, read:user" });
+
+    await validateReadOnlyToken(mockCore);
+
+    expect(mockCore.setFailed).not.toHaveBeenCalled();
+    expect(mockCore.info).toHaveBeenCalledWith("✓ GitHub MCP server token has no write scopes");
+  });
+
+  it("fails for a classic token with write scopes", async () => {
+    process.env.GH_AW_READ_ONLY_TOKEN = "ghp_test";
+    mockFetch({ "x-oauth-scopes": "repo, read:org, workflow" });
+
+    await expect(validateReadOnlyToken(mockCore)).rejects.toThrow("write scopes: repo, workflow");
+    expect(mockCore.setFailed).toHaveBeenCalled();
+  });
+
+  it("fails for an installation token when the job grants write permissions", async () => {
+    process.env.GH_AW_READ_ONLY_TOKEN = "ghs_test";
+    process.env.GH_AW_JOB_WRITE_PERMISSIONS = "contents,issues";
+    mockFetch({});
+
+    await expect(validateReadOnlyToken(mockCore)).rejects.toThrow("contents, issues");
+  });
+
+  it("passes for an installation token with read-only job permissions", async () => {
+    process.env.GH_AW_READ_ONLY_TOKEN = "ghs_test";
+    mockFetch({});
+
+    await validateReadOnlyToken(mockCore);
+
+    expect(mockCore.setFailed).not.toHaveBeenCalled();
+  });
+
+  it("warns when the token permissions cannot be determined", async () => {
+    process.env.GH_AW_READ_ONLY_TOKEN = "github_pat_test";
+    mockFetch({});
+
+    await validateReadOnlyToken(mockCore);
+
+    expect(mockCore.warning).toHaveBeenCalled();
+    expect(mockCore.setFailed).not.toHaveBeenCalled();
+  });
+
+  it("fails when the API request is rejected", async () => {
+    process.env.GH_AW_READ_ONLY_TOKEN = "ghp_test";
+    mockFetch({}, 401);
+
+    await expect(validateReadOnlyToken(mockCore)).rejects.toThrow("HTTP 401");
+  });
+});
+
+describe("getWriteScopes", () => {
+  it("ignores read-only scopes", () => {
+    expect(getWriteScopes("read:org, user:email, read:packages")).toEqual([]);
+  });
+
+  it("handles an empty scope list", () => {
+    expect(getWriteScopes("")).toEqual([]);
+  });
+
+  it("returns write scopes", () => {
+    expect(getWriteScopes("public_repo, read:org, write:packages")).toEqual(["public_repo", "write:packages"]);
+  });
+});
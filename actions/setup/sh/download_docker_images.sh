@@ -9,9 +9,28 @@
 #
 # Each image is pulled with retry logic (3 attempts with exponential backoff).
 # The script fails if any image fails to download after all retry attempts.
+#
+# Images pinned by digest with a tag (e.g. alpine:3.20@sha256:...) are also tagged
+# locally as alpine:3.20, so tools that only accept a tag (such as AWF with
+# --image-tag and --skip-pull) run the pinned image instead of resolving the tag again.
 
 set -euo pipefail
 
+# Helper function to tag a digest-pinned image with its tag (name:tag@sha256:... -> name:tag)
+tag_pinned_image() {
+  local image="$1"
+  local ref="${image%@*}"
+  local digest="${image#*@}"
+
+  # Only references with both a tag and a digest need a local tag
+  if [ "$ref" = "$image" ] || [[ "${ref##*/}" != *:* ]]; then
+    return 0
+  fi
+
+  docker tag "${ref%:*}@${digest}" "$ref"
+  echo "Tagged $ref as pinned digest $digest"
+}
+
 # Helper function to pull Docker images with retry logic
 docker_pull_with_retry() {
   local image="$1"
@@ -23,6 +42,7 @@ docker_pull_with_retry() {
     
     if timeout 5m docker pull --quiet "$image" 2>&1; then
       echo "Successfully pulled $image"
+      tag_pinned_image "$image"
       return 0
     fi
     
@@ -47,7 +67,7 @@ docker_pull_with_retry() {
 }
 
 # Export function so xargs can use it
-export -f docker_pull_with_retry
+export -f docker_pull_with_retry tag_pinned_image
 
 # Pull images with controlled parallelism using xargs
 echo "Starting download of ${#@} image(s) with max 4 concurrent downloads..."
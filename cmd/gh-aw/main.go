@@ -239,6 +239,8 @@ Examples:
   ` + string(constants.CLIExtensionPrefix) + ` compile --dir custom/workflows  # Compile from custom directory
   ` + string(constants.CLIExtensionPrefix) + ` compile --watch ci-doctor     # Watch and auto-compile
   ` + string(constants.CLIExtensionPrefix) + ` compile --trial --logical-repo owner/repo  # Compile for trial mode
+  ` + string(constants.CLIExtensionPrefix) + ` compile --dry-run           # Show lock file changes without writing them
+  ` + string(constants.CLIExtensionPrefix) + ` compile --stdin < draft.md    # Compile stdin and print the lock file YAML
   ` + string(constants.CLIExtensionPrefix) + ` compile --dependabot        # Generate Dependabot manifests
   ` + string(constants.CLIExtensionPrefix) + ` compile --dependabot --force  # Force overwrite existing dependabot.yml`,
 	RunE: func(cmd *cobra.Command, args []string) error {
@@ -252,6 +254,10 @@ Examples:
 		noEmit, _ := cmd.Flags().GetBool("no-emit")
 		purge, _ := cmd.Flags().GetBool("purge")
 		strict, _ := cmd.Flags().GetBool("strict")
+		failOnWarning, _ := cmd.Flags().GetBool("fail-on-warning")
+		explain, _ := cmd.Flags().GetBool("explain")
+		emitJobGraph, _ := cmd.Flags().GetBool("emit-job-graph")
+		inlineImports, _ := cmd.Flags().GetBool("inline-imports")
 		trial, _ := cmd.Flags().GetBool("trial")
 		logicalRepo, _ := cmd.Flags().GetString("logical-repo")
 		dependabot, _ := cmd.Flags().GetBool("dependabot")
@@ -265,6 +271,13 @@ Examples:
 		fix, _ := cmd.Flags().GetBool("fix")
 		stats, _ := cmd.Flags().GetBool("stats")
 		failFast, _ := cmd.Flags().GetBool("fail-fast")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		lintTokens, _ := cmd.Flags().GetBool("lint-tokens")
+		emitBodyOnly, _ := cmd.Flags().GetBool("emit-body-only")
+		printJobs, _ := cmd.Flags().GetBool("print-jobs")
+		jobs, _ := cmd.Flags().GetInt("jobs")
+		stdin, _ := cmd.Flags().GetBool("stdin")
+		baseDir, _ := cmd.Flags().GetString("base-dir")
 		noCheckUpdate, _ := cmd.Flags().GetBool("no-check-update")
 		verbose, _ := cmd.Flags().GetBool("verbose")
 		if err := validateEngine(engineOverride); err != nil {
@@ -307,6 +320,10 @@ Examples:
 			TrialMode:              trial,
 			TrialLogicalRepoSlug:   logicalRepo,
 			Strict:                 strict,
+			FailOnWarning:          failOnWarning,
+			Explain:                explain,
+			EmitJobGraph:           emitJobGraph,
+			InlineImports:          inlineImports,
 			Dependabot:             dependabot,
 			ForceOverwrite:         forceOverwrite,
 			RefreshStopTime:        refreshStopTime,
@@ -317,6 +334,13 @@ Examples:
 			JSONOutput:             jsonOutput,
 			Stats:                  stats,
 			FailFast:               failFast,
+			DryRun:                 dryRun,
+			LintTokens:             lintTokens,
+			EmitBodyOnly:           emitBodyOnly,
+			PrintJobs:              printJobs,
+			Jobs:                   jobs,
+			Stdin:                  stdin,
+			BaseDir:                baseDir,
 		}
 		if _, err := cli.CompileWorkflows(cmd.Context(), config); err != nil {
 			// Return error as-is without additional formatting
@@ -535,6 +559,10 @@ Use "` + string(constants.CLIExtensionPrefix) + ` help all" to show help for all
 	compileCmd.Flags().Bool("no-emit", false, "Validate workflow without generating lock files")
 	compileCmd.Flags().Bool("purge", false, "Delete .lock.yml files that were not regenerated during compilation (only when no specific files are specified)")
 	compileCmd.Flags().Bool("strict", false, "Override frontmatter to enforce strict mode validation for all workflows (enforces action pinning, network config, safe-outputs, refuses write permissions and deprecated fields). Note: Workflows default to strict mode unless frontmatter sets strict: false")
+	compileCmd.Flags().Bool("fail-on-warning", false, "Fail the compile of any workflow that emits warnings, without enabling strict mode validation")
+	compileCmd.Flags().Bool("explain", false, "Add a comment above each generated job in the lock file explaining why it exists")
+	compileCmd.Flags().Bool("emit-job-graph", false, "Write a <workflow>.jobs.json file next to each lock file describing the generated jobs and their needs")
+	compileCmd.Flags().Bool("inline-imports", false, "Inline imported and main workflow markdown into the lock file instead of loading it at runtime with runtime-import macros (for air-gapped or vendored deployments)")
 	compileCmd.Flags().Bool("trial", false, "Enable trial mode compilation (modifies workflows for trial execution)")
 	compileCmd.Flags().String("logical-repo", "", "Repository to simulate workflow execution against (for trial mode)")
 	compileCmd.Flags().Bool("dependabot", false, "Generate dependency manifests (package.json, requirements.txt, go.mod) and Dependabot config when dependencies are detected")
@@ -548,6 +576,13 @@ Use "` + string(constants.CLIExtensionPrefix) + ` help all" to show help for all
 	compileCmd.Flags().BoolP("json", "j", false, "Output results in JSON format")
 	compileCmd.Flags().Bool("stats", false, "Display statistics table sorted by file size (shows jobs, steps, scripts, and shells)")
 	compileCmd.Flags().Bool("fail-fast", false, "Stop at the first validation error instead of collecting all errors")
+	compileCmd.Flags().Bool("dry-run", false, "Compile without writing lock files and print a unified diff against the existing lock files (exits with an error if any are out of date)")
+	compileCmd.Flags().Bool("lint-tokens", false, "Warn when safe-outputs github-token is broader than the enabled safe outputs need")
+	compileCmd.Flags().Bool("emit-body-only", false, "Print the assembled prompt body of each workflow to stdout without generating lock files (for prompt debugging)")
+	compileCmd.Flags().Bool("print-jobs", false, "Print a table of the generated jobs with their needs, if conditions, and permissions after compiling each workflow")
+	compileCmd.Flags().Int("jobs", 1, "Number of workflows to compile in parallel when compiling all workflows in the directory")
+	compileCmd.Flags().Bool("stdin", false, "Read the workflow source from stdin and print the lock file YAML to stdout without writing files")
+	compileCmd.Flags().String("base-dir", "", "Directory used to resolve imports for --stdin (default: the workflow directory)")
 	compileCmd.Flags().Bool("no-check-update", false, "Skip checking for gh-aw updates")
 	compileCmd.MarkFlagsMutuallyExclusive("dir", "workflows-dir")
 
@@ -604,6 +639,12 @@ Use "` + string(constants.CLIExtensionPrefix) + ` help all" to show help for all
 	completionCmd := cli.NewCompletionCommand()
 	hashCmd := cli.NewHashCommand()
 	projectCmd := cli.NewProjectCommand()
+	coverageCmd := cli.NewCoverageCommand()
+	replayCmd := cli.NewReplayCommand()
+	diffMetricsCmd := cli.NewDiffMetricsCommand()
+	enginesCmd := cli.NewEnginesCommand()
+	schemaCmd := cli.NewSchemaCommand()
+	verifyCmd := cli.NewVerifyCommand()
 
 	// Assign commands to groups
 	// Setup Commands
@@ -621,17 +662,21 @@ Use "` + string(constants.CLIExtensionPrefix) + ` help all" to show help for all
 	statusCmd.GroupID = "development"
 	listCmd.GroupID = "development"
 	fixCmd.GroupID = "development"
+	coverageCmd.GroupID = "development"
+	verifyCmd.GroupID = "development"
 
 	// Execution Commands
 	runCmd.GroupID = "execution"
 	enableCmd.GroupID = "execution"
 	disableCmd.GroupID = "execution"
 	trialCmd.GroupID = "execution"
+	replayCmd.GroupID = "execution"
 
 	// Analysis Commands
 	logsCmd.GroupID = "analysis"
 	auditCmd.GroupID = "analysis"
 	healthCmd.GroupID = "analysis"
+	diffMetricsCmd.GroupID = "analysis"
 
 	// Utilities
 	mcpServerCmd.GroupID = "utilities"
@@ -639,6 +684,8 @@ Use "` + string(constants.CLIExtensionPrefix) + ` help all" to show help for all
 	completionCmd.GroupID = "utilities"
 	hashCmd.GroupID = "utilities"
 	projectCmd.GroupID = "utilities"
+	enginesCmd.GroupID = "utilities"
+	schemaCmd.GroupID = "utilities"
 
 	// version command is intentionally left without a group (common practice)
 
@@ -668,6 +715,12 @@ Use "` + string(constants.CLIExtensionPrefix) + ` help all" to show help for all
 	rootCmd.AddCommand(completionCmd)
 	rootCmd.AddCommand(hashCmd)
 	rootCmd.AddCommand(projectCmd)
+	rootCmd.AddCommand(coverageCmd)
+	rootCmd.AddCommand(replayCmd)
+	rootCmd.AddCommand(diffMetricsCmd)
+	rootCmd.AddCommand(enginesCmd)
+	rootCmd.AddCommand(schemaCmd)
+	rootCmd.AddCommand(verifyCmd)
 }
 
 func main() {
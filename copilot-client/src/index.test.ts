@@ -3,7 +3,7 @@
  */
 
 import { describe, it, expect, beforeEach, afterEach, vi } from 'vitest';
-import { runCopilotSession } from './index.js';
+import { runCopilotSession, estimateUsageCost } from './index.js';
 import type { CopilotClientConfig } from './types.js';
 import { readFileSync, existsSync, unlinkSync, mkdirSync, writeFileSync } from 'fs';
 import { join } from 'path';
@@ -79,3 +79,26 @@ describe('CopilotClient', () => {
     expect(events[0].type).toBe('prompt.loaded');
   });
 });
+
+describe('estimateUsageCost', () => {
+  it('should prefer the cost reported by the usage event', () => {
+    expect(estimateUsageCost({ cost: 0.42, inputTokens: 1_000_000 })).toBe(0.42);
+  });
+
+  it('should estimate cost from token counts', () => {
+    expect(estimateUsageCost({ inputTokens: 1_000_000, outputTokens: 100_000 })).toBeCloseTo(4.5);
+  });
+
+  it('should bill cached input tokens at the cached rate', () => {
+    expect(estimateUsageCost({ inputTokens: 1_000_000, cacheReadTokens: 1_000_000 })).toBeCloseTo(0.3);
+  });
+
+  it('should treat missing usage as free', () => {
+    expect(estimateUsageCost(undefined)).toBe(0);
+  });
+
+  it('should use configured pricing', () => {
+    const pricing = { input: 1, cachedInput: 0.1, output: 4 };
+    expect(estimateUsageCost({ inputTokens: 2_000_000, cacheReadTokens: 1_000_000, outputTokens: 500_000 }, pricing)).toBeCloseTo(3.1);
+  });
+});
@@ -9,10 +9,154 @@ import { CopilotClient, CopilotSession, type SessionEvent } from '@github/copilo
 import { readFileSync, appendFileSync, mkdirSync } from 'fs';
 import { dirname } from 'path';
 import debugFactory from 'debug';
-import type { CopilotClientConfig, LoggedEvent } from './types.js';
+import type { CopilotClientConfig, LoggedEvent, ModelPricing } from './types.js';
 
 const debug = debugFactory('copilot-client');
 
+/**
+ * Default pricing in USD per million tokens, used to estimate cost when a usage event
+ * does not report one and engine.pricing is not configured
+ */
+export const DEFAULT_PRICING_PER_MILLION_TOKENS: ModelPricing = {
+  input: 3,
+  cachedInput: 0.3,
+  output: 15
+};
+
+/**
+ * Estimate the cost in USD of a single assistant.usage event
+ *
+ * @param usage - Usage event data reported by the SDK
+ * @param pricing - Token pricing from engine.pricing (defaults to DEFAULT_PRICING_PER_MILLION_TOKENS)
+ * @returns Estimated cost in USD
+ */
+export function estimateUsageCost(usage: any, pricing: ModelPricing = DEFAULT_PRICING_PER_MILLION_TOKENS): number {
+  if (typeof usage?.cost === 'number') {
+    return usage.cost;
+  }
+  const inputTokens = usage?.inputTokens ?? 0;
+  const cachedTokens = usage?.cacheReadTokens ?? 0;
+  const outputTokens = usage?.outputTokens ?? 0;
+  return (
+    Math.max(inputTokens - cachedTokens, 0) * pricing.input +
+    cachedTokens * pricing.cachedInput +
+    outputTokens * pricing.output
+  ) / 1_000_000;
+}
+
+/**
+ * Resolve the timeout in seconds for a tool call. An override from tools.tool-timeouts,
+ * keyed by tool or MCP server name, wins over engine.tool-timeout-per-call, which wins
+ * over the default tools.timeout.
+ *
+ * @param config - Configuration for the Copilot client
+ * @param toolName - Name of the tool being called
+ * @param mcpServerName - Name of the MCP server providing the tool, if any
+ * @returns Timeout in seconds, or undefined when tool calls are not limited
+ */
+export function resolveToolTimeoutSeconds(
+  config: CopilotClientConfig,
+  toolName: string,
+  mcpServerName?: string
+): number | undefined {
+  const overrides = config.toolTimeouts ?? {};
+  return overrides[toolName]
+    ?? (mcpServerName ? overrides[mcpServerName] : undefined)
+    ?? config.toolTimeoutSeconds
+    ?? config.toolTimeout;
+}
+
+/**
+ * Watch session events and abort the session once a configured limit is exceeded.
+ * The token budget (engine.max-tokens) and cost budget (engine.max-cost) are enforced
+ * from assistant.usage events; an unset or zero budget means unlimited. A tool call that
+ * runs past its timeout (see resolveToolTimeoutSeconds) also aborts the session.
+ *
+ * @param session - Session to watch
+ * @param config - Configuration for the Copilot client
+ * @param logEvent - Event logger for the JSONL event log
+ * @param onLimitExceeded - Called once with the error that ends the run
+ * @returns Function that stops watching the session
+ */
+export function enforceSessionLimits(
+  session: Pick<CopilotSession, 'sessionId' | 'on' | 'abort'>,
+  config: CopilotClientConfig,
+  logEvent: (type: string, data: any, sessionId?: string) => void,
+  onLimitExceeded: (error: Error) => void
+): () => void {
+  const unsubscribers: Array<() => void> = [];
+  let limitExceeded = false;
+
+  const abort = (type: string, data: any, message: string): void => {
+    if (limitExceeded) {
+      return;
+    }
+    limitExceeded = true;
+    logEvent(type, data, session.sessionId);
+    session.abort().catch((error) => debug('Error aborting session:', error));
+    onLimitExceeded(new Error(message));
+  };
+
+  if (config.maxTokens && config.maxTokens > 0) {
+    const maxTokens = config.maxTokens;
+    let totalTokens = 0;
+    unsubscribers.push(session.on('assistant.usage', (event) => {
+      totalTokens += (event.data?.inputTokens ?? 0) + (event.data?.outputTokens ?? 0);
+      debug('Accumulated tokens:', totalTokens);
+      if (totalTokens > maxTokens) {
+        abort('token_budget.exceeded', { maxTokens, totalTokens },
+          `Token budget exceeded: ${totalTokens} tokens used is over engine.max-tokens of ${maxTokens}`);
+      }
+    }));
+  }
+
+  if (config.maxCostUsd && config.maxCostUsd > 0) {
+    const maxCostUsd = config.maxCostUsd;
+    let accumulatedCostUsd = 0;
+    unsubscribers.push(session.on('assistant.usage', (event) => {
+      accumulatedCostUsd += estimateUsageCost(event.data, config.pricingPerMillionTokens);
+      debug('Estimated accumulated cost (USD):', accumulatedCostUsd);
+      if (accumulatedCostUsd > maxCostUsd) {
+        abort('budget.exceeded', { maxCostUsd, estimatedCostUsd: accumulatedCostUsd },
+          `Cost budget exceeded: estimated cost $${accumulatedCostUsd.toFixed(4)} is over engine.max-cost of $${maxCostUsd}`);
+      }
+    }));
+  }
+
+  if (config.toolTimeout || config.toolTimeoutSeconds || config.toolTimeouts) {
+    const timers = new Map<string, ReturnType<typeof setTimeout>>();
+    unsubscribers.push(session.on('tool.execution_start', (event) => {
+      const { toolCallId, toolName, mcpServerName } = event.data as any;
+      const timeoutSeconds = resolveToolTimeoutSeconds(config, toolName, mcpServerName);
+      if (!timeoutSeconds || timeoutSeconds <= 0) {
+        return;
+      }
+      timers.set(toolCallId, setTimeout(() => {
+        timers.delete(toolCallId);
+        abort('tool.timeout', { toolCallId, toolName, timeoutSeconds },
+          `Tool call ${toolName} did not complete within ${timeoutSeconds}s`);
+      }, timeoutSeconds * 1000));
+    }));
+    unsubscribers.push(session.on('tool.execution_complete', (event) => {
+      const { toolCallId } = event.data as any;
+      clearTimeout(timers.get(toolCallId));
+      timers.delete(toolCallId);
+    }));
+    unsubscribers.push(() => {
+      for (const timer of timers.values()) {
+        clearTimeout(timer);
+      }
+      timers.clear();
+    });
+  }
+
+  return () => {
+    for (const unsubscribe of unsubscribers) {
+      unsubscribe();
+    }
+  };
+}
+
 /**
  * Run a Copilot agentic session with the given configuration
  * 
@@ -80,6 +224,7 @@ export async function runCopilotSession(config: CopilotClientConfig): Promise<vo
   logEvent('client.started', {});
 
   let session: CopilotSession | null = null;
+  let stopLimits: (() => void) | null = null;
 
   try {
     // Create session
@@ -128,6 +273,8 @@ export async function runCopilotSession(config: CopilotClientConfig): Promise<vo
         debug('Session error:', event.data);
         reject(new Error(event.data.message || 'Session error'));
       });
+
+      stopLimits = enforceSessionLimits(session!, config, logEvent, reject);
     });
 
     // Send the prompt
@@ -149,6 +296,7 @@ export async function runCopilotSession(config: CopilotClientConfig): Promise<vo
     throw error;
   } finally {
     // Clean up
+    stopLimits?.();
     if (session) {
       debug('Destroying session');
       try {
@@ -208,4 +356,4 @@ export async function main(): Promise<void> {
 }
 
 // Export for testing
-export type { CopilotClientConfig, LoggedEvent } from './types.js';
+export type { CopilotClientConfig, LoggedEvent, ModelPricing } from './types.js';
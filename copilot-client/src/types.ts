@@ -87,6 +87,43 @@ export interface CopilotClientConfig {
     mcpServers?: Record<string, any>;
   };
 
+  /**
+   * Maximum number of input and output tokens the session may consume (from engine.max-tokens).
+   * The session is aborted once the reported usage exceeds it. Omitted when unlimited.
+   */
+  maxTokens?: number;
+
+  /**
+   * Maximum estimated cost in USD for the session (from engine.max-cost).
+   * The session is aborted once the accumulated estimated cost exceeds it.
+   * Omitted when unlimited.
+   */
+  maxCostUsd?: number;
+
+  /**
+   * Token pricing used to estimate cost when a usage event does not report one
+   * (from engine.pricing). Defaults to DEFAULT_PRICING_PER_MILLION_TOKENS.
+   */
+  pricingPerMillionTokens?: ModelPricing;
+
+  /**
+   * Timeout in seconds for each individual tool call (from engine.tool-timeout-per-call),
+   * independent of the MCP server startup timeout. Takes precedence over toolTimeout.
+   */
+  toolTimeoutSeconds?: number;
+
+  /**
+   * Default timeout in seconds for tool calls (from tools.timeout).
+   * A tool call that runs past its timeout aborts the session.
+   */
+  toolTimeout?: number;
+
+  /**
+   * Per-tool timeout overrides in seconds, keyed by tool or MCP server name.
+   * Tools without an override fall back to toolTimeout.
+   */
+  toolTimeouts?: Record<string, number>;
+
   /**
    * Path to the prompt file to load
    */
@@ -98,6 +135,15 @@ export interface CopilotClientConfig {
   eventLogFile: string;
 }
 
+/**
+ * Token prices in USD per million tokens
+ */
+export interface ModelPricing {
+  input: number;
+  cachedInput: number;
+  output: number;
+}
+
 /**
  * Event logged to the JSONL file
  */
@@ -34,6 +34,7 @@ type AuditData struct {
 	Warnings                []ErrorInfo              `json:"warnings,omitempty"`
 	ToolUsage               []ToolUsageInfo          `json:"tool_usage,omitempty"`
 	MCPToolUsage            *MCPToolUsageData        `json:"mcp_tool_usage,omitempty"`
+	Report                  RunReport                `json:"report"` // Stable summary; always present, zeroed when the run has no metrics
 }
 
 // Finding represents a key insight discovered during audit
@@ -93,6 +94,7 @@ type MetricsData struct {
 	Turns         int     `json:"turns,omitempty" console:"header:Turns,omitempty"`
 	ErrorCount    int     `json:"error_count" console:"header:Errors"`
 	WarningCount  int     `json:"warning_count" console:"header:Warnings"`
+	Truncated     bool    `json:"truncated,omitempty" console:"header:Truncated Log,omitempty"`
 }
 
 // JobData contains information about individual jobs
@@ -225,6 +227,7 @@ func buildAuditData(processedRun ProcessedRun, metrics LogMetrics, mcpToolUsage
 		Turns:         run.Turns,
 		ErrorCount:    run.ErrorCount,
 		WarningCount:  run.WarningCount,
+		Truncated:     metrics.Truncated,
 	}
 
 	// Build job data
@@ -323,6 +326,7 @@ func buildAuditData(processedRun ProcessedRun, metrics LogMetrics, mcpToolUsage
 		Warnings:                warnings,
 		ToolUsage:               toolUsage,
 		MCPToolUsage:            mcpToolUsage,
+		Report:                  buildRunReport(processedRun, metrics),
 	}
 }
 
@@ -36,6 +36,16 @@ func generateFindings(processedRun ProcessedRun, metrics MetricsData, errors []E
 		})
 	}
 
+	if metrics.Truncated {
+		findings = append(findings, Finding{
+			Category:    "error",
+			Severity:    "medium",
+			Title:       "Incomplete Agent Log",
+			Description: fmt.Sprintf("Agent log ended without a final result entry; turns (%d) are estimated and token usage may be missing", metrics.Turns),
+			Impact:      "The run was likely killed mid-stream, so metrics are partial",
+		})
+	}
+
 	// Cost findings
 	if metrics.EstimatedCost > 1.0 {
 		findings = append(findings, Finding{
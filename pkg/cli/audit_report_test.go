@@ -167,6 +167,23 @@ func TestGenerateFindings(t *testing.T) {
 					"Timed out workflow should generate a timeout finding")
 			},
 		},
+		{
+			name: "truncated agent log",
+			processedRun: func() ProcessedRun {
+				return createTestProcessedRun()
+			}(),
+			metrics: MetricsData{
+				Turns:     4,
+				Truncated: true,
+			},
+			errors:        []ErrorInfo{},
+			warnings:      []ErrorInfo{},
+			expectedCount: 1, // Incomplete log finding
+			checkFindings: func(t *testing.T, findings []Finding) {
+				assertFindingContains(t, findings, "error", "Incomplete Agent Log",
+					"Truncated log should generate an incomplete log finding")
+			},
+		},
 		{
 			name: "high cost workflow",
 			processedRun: func() ProcessedRun {
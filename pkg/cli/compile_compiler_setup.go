@@ -21,6 +21,7 @@
 //   - configureCompilerFlags() - Sets validation, strict mode, trial mode flags
 //   - setupActionMode() - Configures action script inlining mode
 //   - setupRepositoryContext() - Sets repository slug for schedule scattering
+//   - setWorkflowIdentifier() - Sets the per-workflow identifier for schedule scattering
 //
 // These functions abstract compiler setup, allowing the main compile
 // orchestrator to focus on coordination while these handle configuration.
@@ -127,6 +128,21 @@ func configureCompilerFlags(compiler *workflow.Compiler, config CompileConfig) {
 	// Set strict mode if specified
 	compiler.SetStrictMode(config.Strict)
 
+	// Fail workflows that compile with warnings if requested
+	compiler.SetFailOnWarning(config.FailOnWarning)
+
+	// Annotate generated jobs with why they exist if requested
+	compiler.SetExplain(config.Explain)
+
+	// Write a job graph sidecar next to each lock file if requested
+	compiler.SetEmitJobGraph(config.EmitJobGraph)
+
+	// Inline imports and the main workflow markdown into the lock file if requested
+	compiler.SetInlineImports(config.InlineImports)
+
+	// Enable advisory token scope linting if requested
+	compiler.SetLintTokens(config.LintTokens)
+
 	// Set trial mode if specified
 	if config.TrialMode {
 		compileCompilerSetupLog.Printf("Enabling trial mode: repoSlug=%s", config.TrialLogicalRepoSlug)
@@ -193,6 +209,18 @@ func setupRepositoryContext(compiler *workflow.Compiler) {
 	}
 }
 
+// setWorkflowIdentifier sets the workflow identifier used for schedule scattering to the
+// repository-relative path of markdownFile, which stays stable across checkouts. Falls back
+// to the file name when the path is not inside a repository.
+func setWorkflowIdentifier(compiler *workflow.Compiler, markdownFile string) {
+	relPath, err := getRepositoryRelativePath(markdownFile)
+	if err != nil {
+		compileCompilerSetupLog.Printf("Warning: failed to get repository-relative path for %s: %v", markdownFile, err)
+		relPath = filepath.Base(markdownFile)
+	}
+	compiler.SetWorkflowIdentifier(relPath)
+}
+
 // validateActionModeConfig validates the action mode configuration
 func validateActionModeConfig(actionMode string) error {
 	if actionMode == "" {
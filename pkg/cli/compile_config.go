@@ -21,6 +21,10 @@ type CompileConfig struct {
 	TrialMode              bool     // Enable trial mode (suppress safe outputs)
 	TrialLogicalRepoSlug   string   // Target repository for trial mode
 	Strict                 bool     // Enable strict mode validation
+	FailOnWarning          bool     // Fail a workflow's compile when it emits any warning
+	Explain                bool     // Annotate each generated job in the lock file with why it exists
+	EmitJobGraph           bool     // Write a <workflow>.jobs.json job graph next to each lock file
+	InlineImports          bool     // Inline imported and main workflow markdown instead of emitting runtime-import macros
 	Dependabot             bool     // Generate Dependabot manifests for npm dependencies
 	ForceOverwrite         bool     // Force overwrite of existing files (dependabot.yml)
 	RefreshStopTime        bool     // Force regeneration of stop-after times instead of preserving existing ones
@@ -33,6 +37,14 @@ type CompileConfig struct {
 	ActionTag              string   // Override action SHA or tag for actions/setup (overrides action-mode to release)
 	Stats                  bool     // Display statistics table sorted by file size
 	FailFast               bool     // Stop at first error instead of collecting all errors
+	DryRun                 bool     // Print a unified diff against existing lock files instead of writing them
+	LintTokens             bool     // Warn when safe-outputs tokens are broader than needed
+	EmitBodyOnly           bool     // Print the assembled prompt body to stdout instead of writing lock files
+	PrintJobs              bool     // Print the generated jobs with their needs, if conditions, and permissions
+	Jobs                   int      // Number of workflows to compile in parallel when compiling a directory (0 or 1 = sequential)
+
+	Stdin   bool   // Read workflow source from stdin and write the lock file YAML to stdout
+	BaseDir string // Directory used to resolve imports for stdin source (default: workflow directory)
 }
 
 // WorkflowFailure represents a failed workflow with its error count
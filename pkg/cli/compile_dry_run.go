@@ -0,0 +1,138 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/console"
+	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/stringutil"
+	"github.com/github/gh-aw/pkg/workflow"
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+var compileDryRunLog = logger.New("cli:compile_dry_run")
+
+// compileWorkflowsDryRun compiles workflows without writing lock files and prints a
+// unified diff between each existing lock file and the freshly compiled output.
+// Returns an error if any lock file is stale or any workflow fails to compile,
+// which makes it suitable for CI checks.
+func compileWorkflowsDryRun(compiler *workflow.Compiler, config CompileConfig, workflowDir string) error {
+	markdownFiles, err := resolveDryRunFiles(config, workflowDir)
+	if err != nil {
+		return err
+	}
+
+	compileDryRunLog.Printf("Dry run: comparing %d workflow(s) against existing lock files", len(markdownFiles))
+
+	var staleCount int
+	for _, markdownFile := range markdownFiles {
+		setWorkflowIdentifier(compiler, markdownFile)
+
+		diff, err := diffCompiledLockFile(compiler, markdownFile)
+		if err != nil {
+			return err
+		}
+		if diff == "" {
+			if config.Verbose {
+				fmt.Fprintln(os.Stderr, console.FormatSuccessMessage(fmt.Sprintf("%s is up to date", console.ToRelativePath(stringutil.MarkdownToLockFile(markdownFile)))))
+			}
+			continue
+		}
+
+		staleCount++
+		fmt.Print(diff)
+	}
+
+	if staleCount > 0 {
+		return fmt.Errorf("%d lock file(s) are out of date; run '%s compile' to update them", staleCount, constants.CLIExtensionPrefix)
+	}
+
+	fmt.Fprintln(os.Stderr, console.FormatSuccessMessage(fmt.Sprintf("All %d lock file(s) are up to date", len(markdownFiles))))
+	return nil
+}
+
+// resolveDryRunFiles returns the markdown files to compare, either the files
+// specified on the command line or every workflow in the workflow directory
+func resolveDryRunFiles(config CompileConfig, workflowDir string) ([]string, error) {
+	if len(config.MarkdownFiles) > 0 {
+		var files []string
+		for _, markdownFile := range config.MarkdownFiles {
+			resolvedFile, err := resolveWorkflowFile(markdownFile, config.Verbose)
+			if err != nil {
+				return nil, err
+			}
+			files = append(files, resolvedFile)
+		}
+		return files, nil
+	}
+
+	gitRoot, err := findGitRoot()
+	if err != nil {
+		return nil, fmt.Errorf("compile --dry-run without arguments requires being in a git repository: %w", err)
+	}
+
+	workflowsDir := filepath.Join(gitRoot, workflowDir)
+	mdFiles, err := filepath.Glob(filepath.Join(workflowsDir, "*.md"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find markdown files: %w", err)
+	}
+	mdFiles = filterWorkflowFiles(mdFiles)
+	if len(mdFiles) == 0 {
+		return nil, fmt.Errorf("no markdown files found in %s", workflowsDir)
+	}
+	return mdFiles, nil
+}
+
+// diffCompiledLockFile compiles a workflow in memory and returns a unified diff
+// against its existing lock file. Returns an empty string when the lock file is
+// up to date. A missing lock file is diffed against /dev/null so the whole
+// compiled output shows as added.
+func diffCompiledLockFile(compiler *workflow.Compiler, markdownFile string) (string, error) {
+	compiled, err := compiler.CompileWorkflowDryRun(markdownFile)
+	if err != nil {
+		return "", err
+	}
+
+	lockFile := stringutil.MarkdownToLockFile(markdownFile)
+	fromFile := console.ToRelativePath(lockFile)
+	var existing string
+	content, err := os.ReadFile(lockFile)
+	switch {
+	case err == nil:
+		existing = string(content)
+	case os.IsNotExist(err):
+		compileDryRunLog.Printf("Lock file does not exist yet: %s", lockFile)
+		fromFile = "/dev/null"
+	default:
+		return "", fmt.Errorf("failed to read lock file %s: %w", lockFile, err)
+	}
+
+	if existing == compiled && fromFile != "/dev/null" {
+		return "", nil
+	}
+
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        splitDiffLines(existing),
+		B:        splitDiffLines(compiled),
+		FromFile: fromFile,
+		ToFile:   console.ToRelativePath(lockFile),
+		Context:  3,
+	})
+}
+
+// splitDiffLines splits content into lines for diffing, keeping line terminators.
+// Unlike difflib.SplitLines, empty content yields no lines so a new file diffs as a pure addition.
+func splitDiffLines(content string) []string {
+	if content == "" {
+		return nil
+	}
+	lines := strings.SplitAfter(content, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
@@ -0,0 +1,112 @@
+//go:build !integration
+
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/stringutil"
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/github/gh-aw/pkg/workflow"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const dryRunCLITestWorkflow = `---
+on: push
+permissions:
+  contents: read
+engine: copilot
+---
+
+# Dry Run Test Workflow
+
+Summarize the latest changes.
+`
+
+func TestDiffCompiledLockFile(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "compile-dry-run")
+	markdownFile := filepath.Join(tmpDir, "dry-run.md")
+	require.NoError(t, os.WriteFile(markdownFile, []byte(dryRunCLITestWorkflow), 0644), "Failed to write workflow")
+	lockFile := stringutil.MarkdownToLockFile(markdownFile)
+
+	t.Run("missing lock file shows full addition", func(t *testing.T) {
+		diff, err := diffCompiledLockFile(workflow.NewCompiler(), markdownFile)
+		require.NoError(t, err, "Diff should succeed when the lock file does not exist")
+		assert.Contains(t, diff, "--- /dev/null", "Diff should be against /dev/null")
+		assert.Contains(t, diff, "@@ -0,0 +1,", "Diff should add every line")
+		assert.Contains(t, diff, "+name: \"Dry Run Test Workflow\"", "Diff should include compiled content")
+
+		_, statErr := os.Stat(lockFile)
+		assert.True(t, os.IsNotExist(statErr), "Dry run should not create the lock file")
+	})
+
+	t.Run("up to date lock file produces no diff", func(t *testing.T) {
+		require.NoError(t, workflow.NewCompiler().CompileWorkflow(markdownFile), "Workflow should compile")
+
+		diff, err := diffCompiledLockFile(workflow.NewCompiler(), markdownFile)
+		require.NoError(t, err, "Diff should succeed")
+		assert.Empty(t, diff, "Up to date lock file should produce no diff")
+	})
+
+	t.Run("stale lock file produces diff", func(t *testing.T) {
+		content, err := os.ReadFile(lockFile)
+		require.NoError(t, err, "Lock file should exist")
+		stale := string(content) + "# stale trailer\n"
+		require.NoError(t, os.WriteFile(lockFile, []byte(stale), 0644), "Failed to write stale lock file")
+
+		diff, err := diffCompiledLockFile(workflow.NewCompiler(), markdownFile)
+		require.NoError(t, err, "Diff should succeed")
+		assert.Contains(t, diff, "-# stale trailer", "Diff should remove the stale line")
+
+		after, err := os.ReadFile(lockFile)
+		require.NoError(t, err, "Lock file should still exist")
+		assert.Equal(t, stale, string(after), "Dry run should not overwrite the lock file")
+	})
+
+	t.Run("compile error is returned", func(t *testing.T) {
+		invalidFile := filepath.Join(tmpDir, "invalid.md")
+		require.NoError(t, os.WriteFile(invalidFile, []byte("---\non: push\nengine: not-a-real-engine\n---\n# Invalid\n"), 0644), "Failed to write workflow")
+
+		diff, err := diffCompiledLockFile(workflow.NewCompiler(), invalidFile)
+		require.Error(t, err, "Compile error should be returned")
+		assert.Empty(t, diff, "No diff should be returned on compile error")
+	})
+}
+
+func TestValidateCompileConfigDryRun(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  CompileConfig
+		wantErr string
+	}{
+		{
+			name:   "dry-run alone",
+			config: CompileConfig{DryRun: true},
+		},
+		{
+			name:    "dry-run with watch",
+			config:  CompileConfig{DryRun: true, Watch: true},
+			wantErr: "--watch",
+		},
+		{
+			name:    "dry-run with no-emit",
+			config:  CompileConfig{DryRun: true, NoEmit: true},
+			wantErr: "--no-emit",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateCompileConfig(tt.config)
+			if tt.wantErr == "" {
+				assert.NoError(t, err, "Config should be valid")
+				return
+			}
+			require.Error(t, err, "Config should be rejected")
+			assert.Contains(t, err.Error(), tt.wantErr, "Error should name the conflicting flag")
+		})
+	}
+}
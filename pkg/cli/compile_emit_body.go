@@ -0,0 +1,47 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/github/gh-aw/pkg/console"
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/workflow"
+)
+
+var compileEmitBodyLog = logger.New("cli:compile_emit_body")
+
+// emitWorkflowBodies prints the assembled prompt body of each workflow to stdout
+// without generating lock files. When several workflows are given, each body is
+// preceded by a comment naming its workflow file.
+func emitWorkflowBodies(compiler *workflow.Compiler, config CompileConfig) error {
+	compileEmitBodyLog.Printf("Emitting prompt bodies for %d workflow(s)", len(config.MarkdownFiles))
+
+	for i, markdownFile := range config.MarkdownFiles {
+		resolvedFile, err := resolveWorkflowFile(markdownFile, config.Verbose)
+		if err != nil {
+			return err
+		}
+
+		setWorkflowIdentifier(compiler, resolvedFile)
+
+		body, err := compiler.CompileWorkflowBody(resolvedFile)
+		if err != nil {
+			return err
+		}
+
+		if len(config.MarkdownFiles) > 1 {
+			if i > 0 {
+				fmt.Println()
+			}
+			fmt.Printf("<!-- %s -->\n", console.ToRelativePath(resolvedFile))
+		}
+		fmt.Print(body)
+
+		if config.Verbose {
+			fmt.Fprintln(os.Stderr, console.FormatInfoMessage(fmt.Sprintf("Emitted prompt body for %s (%d bytes)", console.ToRelativePath(resolvedFile), len(body))))
+		}
+	}
+
+	return nil
+}
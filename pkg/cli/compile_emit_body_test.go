@@ -0,0 +1,81 @@
+//go:build !integration
+
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/stringutil"
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/github/gh-aw/pkg/workflow"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmitWorkflowBodies(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "compile-emit-body")
+	markdownFile := filepath.Join(tmpDir, "emit-body.md")
+	content := "---\non: push\npermissions:\n  contents: read\nengine:\n  id: copilot\n  prompt-prefix: Read CONTRIBUTING.md first.\n---\n\n# Emit Body Test\n"
+	require.NoError(t, os.WriteFile(markdownFile, []byte(content), 0644), "Failed to write workflow")
+
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	err := emitWorkflowBodies(workflow.NewCompiler(), CompileConfig{MarkdownFiles: []string{markdownFile}})
+
+	w.Close()
+	os.Stdout = oldStdout
+	var buf bytes.Buffer
+	buf.ReadFrom(r)
+	output := buf.String()
+
+	require.NoError(t, err, "Body should be emitted for a valid workflow")
+	assert.Equal(t, "Read CONTRIBUTING.md first.\n{{#runtime-import emit-body.md}}\n", output, "Stdout should contain only the assembled body")
+
+	_, statErr := os.Stat(stringutil.MarkdownToLockFile(markdownFile))
+	assert.True(t, os.IsNotExist(statErr), "Emitting the body should not write the lock file")
+}
+
+func TestValidateCompileConfigEmitBodyOnly(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  CompileConfig
+		wantErr string
+	}{
+		{
+			name:   "emit-body-only with a workflow",
+			config: CompileConfig{EmitBodyOnly: true, MarkdownFiles: []string{"test.md"}},
+		},
+		{
+			name:    "emit-body-only without workflows",
+			config:  CompileConfig{EmitBodyOnly: true},
+			wantErr: "requires at least one workflow file",
+		},
+		{
+			name:    "emit-body-only with dry-run",
+			config:  CompileConfig{EmitBodyOnly: true, DryRun: true, MarkdownFiles: []string{"test.md"}},
+			wantErr: "--emit-body-only flag cannot be used with",
+		},
+		{
+			name:    "emit-body-only with json",
+			config:  CompileConfig{EmitBodyOnly: true, JSONOutput: true, MarkdownFiles: []string{"test.md"}},
+			wantErr: "--emit-body-only flag cannot be used with",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateCompileConfig(tt.config)
+			if tt.wantErr == "" {
+				assert.NoError(t, err, "Config should be valid")
+				return
+			}
+			require.Error(t, err, "Config should be rejected")
+			assert.Contains(t, err.Error(), tt.wantErr, "Error should name the conflicting flag")
+		})
+	}
+}
@@ -0,0 +1,99 @@
+//go:build !integration
+
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/stringutil"
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateCompileConfigJobs(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  CompileConfig
+		wantErr string
+	}{
+		{
+			name:   "jobs for all workflows",
+			config: CompileConfig{Jobs: 4},
+		},
+		{
+			name:   "single job with specific files",
+			config: CompileConfig{Jobs: 1, MarkdownFiles: []string{"test.md"}},
+		},
+		{
+			name:    "negative jobs",
+			config:  CompileConfig{Jobs: -1},
+			wantErr: "must not be negative",
+		},
+		{
+			name:    "jobs with specific files",
+			config:  CompileConfig{Jobs: 4, MarkdownFiles: []string{"test.md"}},
+			wantErr: "--jobs",
+		},
+		{
+			name:    "jobs with watch",
+			config:  CompileConfig{Jobs: 4, Watch: true},
+			wantErr: "--watch",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateCompileConfig(tt.config)
+			if tt.wantErr == "" {
+				assert.NoError(t, err, "Config should be valid")
+				return
+			}
+			require.Error(t, err, "Config should be rejected")
+			assert.Contains(t, err.Error(), tt.wantErr, "Error should explain the invalid --jobs usage")
+		})
+	}
+}
+
+func TestCompileAllWorkflowsWithJobs(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "compile-jobs")
+	require.NoError(t, exec.Command("git", "-C", tmpDir, "init").Run(), "Should initialize git repository")
+	t.Chdir(tmpDir)
+
+	workflowsDir := filepath.Join(tmpDir, ".github", "workflows")
+	require.NoError(t, os.MkdirAll(workflowsDir, 0755), "Should create workflows directory")
+
+	var lockFiles []string
+	for i := range 6 {
+		content := fmt.Sprintf("---\non:\n  schedule: daily\npermissions:\n  contents: read\nengine: copilot\n---\n\n# Workflow %d\n", i)
+		if i%2 == 1 {
+			content = fmt.Sprintf("---\non: issues\npermissions:\n  contents: read\nengine: claude\nsafe-outputs:\n  create-issue:\n---\n\n# Workflow %d\n", i)
+		}
+		path := filepath.Join(workflowsDir, fmt.Sprintf("workflow-%d.md", i))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644), "Should write workflow file")
+		lockFiles = append(lockFiles, stringutil.MarkdownToLockFile(path))
+	}
+
+	compileAll := func(jobs int) map[string]string {
+		_, err := CompileWorkflows(context.Background(), CompileConfig{WorkflowDir: ".github/workflows", Jobs: jobs})
+		require.NoError(t, err, "Workflows should compile with --jobs %d", jobs)
+
+		contents := make(map[string]string)
+		for _, lockFile := range lockFiles {
+			content, err := os.ReadFile(lockFile)
+			require.NoError(t, err, "Each workflow should produce its lock file")
+			contents[filepath.Base(lockFile)] = string(content)
+			require.NoError(t, os.Remove(lockFile), "Should remove lock file")
+		}
+		return contents
+	}
+
+	sequential := compileAll(1)
+	parallel := compileAll(4)
+	assert.Equal(t, sequential, parallel, "Parallel compilation should produce the same lock files as sequential compilation")
+}
@@ -119,6 +119,10 @@ func compileSpecificFiles(
 			compiledCount++
 			workflowDataList = append(workflowDataList, fileResult.workflowData)
 
+			if config.PrintJobs && !config.JSONOutput {
+				printJobsTable(fileResult.jobManager, resolvedFile)
+			}
+
 			// Collect lock files for batch security tools
 			if !config.NoEmit && fileResult.lockFile != "" {
 				if _, err := os.Stat(fileResult.lockFile); err == nil {
@@ -255,15 +259,36 @@ func compileAllFilesInDirectory(
 	var lockFilesForActionlint []string
 	var lockFilesForZizmor []string
 
-	for _, file := range mdFiles {
-		stats.Total++
-
-		// Compile regular workflow file (disable per-file security tools)
-		fileResult := compileWorkflowFile(
+	// Compile regular workflow files (disable per-file security tools)
+	compileFile := func(compiler *workflow.Compiler, file string) compileWorkflowFileResult {
+		return compileWorkflowFile(
 			compiler, file, config.Verbose, config.JSONOutput,
 			config.NoEmit, false, false, false, // Disable per-file security tools
 			config.Strict, shouldValidate,
 		)
+	}
+
+	// With --jobs, compile the workflows in parallel up front; results are still
+	// reported below in file order
+	var parallelResults []compileWorkflowFileResult
+	if config.Jobs > 1 {
+		compileOrchestrationLog.Printf("Compiling %d workflows with up to %d parallel jobs", len(mdFiles), config.Jobs)
+		parallelResults = make([]compileWorkflowFileResult, len(mdFiles))
+		compiler.CompileWorkflowsWith(mdFiles, config.Jobs, func(worker *workflow.Compiler, index int) error {
+			parallelResults[index] = compileFile(worker, mdFiles[index])
+			return nil
+		})
+	}
+
+	for i, file := range mdFiles {
+		stats.Total++
+
+		var fileResult compileWorkflowFileResult
+		if parallelResults != nil {
+			fileResult = parallelResults[i]
+		} else {
+			fileResult = compileFile(compiler, file)
+		}
 
 		if !fileResult.success {
 			errorCount++
@@ -278,6 +303,10 @@ func compileAllFilesInDirectory(
 			successCount++
 			workflowDataList = append(workflowDataList, fileResult.workflowData)
 
+			if config.PrintJobs && !config.JSONOutput {
+				printJobsTable(fileResult.jobManager, file)
+			}
+
 			// Collect lock files for batch security tools
 			if !config.NoEmit && fileResult.lockFile != "" {
 				if _, err := os.Stat(fileResult.lockFile); err == nil {
@@ -80,6 +80,25 @@ func CompileWorkflows(ctx context.Context, config CompileConfig) ([]*workflow.Wo
 		return nil, watchAndCompileWorkflows(markdownFile, compiler, config.Verbose)
 	}
 
+	// Handle dry-run mode (early return)
+	if config.DryRun {
+		return nil, compileWorkflowsDryRun(compiler, config, workflowDir)
+	}
+
+	// Handle stdin mode (early return)
+	if config.Stdin {
+		baseDir := config.BaseDir
+		if baseDir == "" {
+			baseDir = workflowDir
+		}
+		return nil, compileWorkflowFromStdin(compiler, config, baseDir, os.Stdin, os.Stdout)
+	}
+
+	// Handle emit-body-only mode (early return)
+	if config.EmitBodyOnly {
+		return nil, emitWorkflowBodies(compiler, config)
+	}
+
 	// Compile specific files or all files in directory
 	if len(config.MarkdownFiles) > 0 {
 		// Compile specific workflow files
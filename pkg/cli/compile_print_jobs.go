@@ -0,0 +1,82 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/console"
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/workflow"
+)
+
+var compilePrintJobsLog = logger.New("cli:compile_print_jobs")
+
+// printJobsTable prints the jobs generated for a compiled workflow, taken from the
+// job manager it was compiled with, so the job graph can be checked without reading
+// the lock file
+func printJobsTable(jobManager *workflow.JobManager, workflowPath string) {
+	if jobManager == nil {
+		compilePrintJobsLog.Printf("No job manager available for %s", workflowPath)
+		return
+	}
+
+	fmt.Fprint(os.Stderr, console.RenderTable(buildJobsTableConfig(console.ToRelativePath(workflowPath), jobManager)))
+}
+
+// buildJobsTableConfig builds a table with one row per job in dependency order, showing
+// the job's needs, if condition, and permissions on a single line each
+func buildJobsTableConfig(title string, jobManager *workflow.JobManager) console.TableConfig {
+	jobs := jobManager.GetAllJobs()
+
+	order, err := jobManager.GetTopologicalOrder()
+	if err != nil {
+		compilePrintJobsLog.Printf("Falling back to alphabetical job order: %v", err)
+		order = make([]string, 0, len(jobs))
+		for name := range jobs {
+			order = append(order, name)
+		}
+		sort.Strings(order)
+	}
+
+	rows := make([][]string, 0, len(order))
+	for _, name := range order {
+		job := jobs[name]
+		rows = append(rows, []string{
+			name,
+			formatJobCell(strings.Join(job.Needs, ", ")),
+			formatJobCell(strings.Join(strings.Fields(job.If), " ")),
+			formatJobCell(formatJobPermissions(job.Permissions)),
+		})
+	}
+	compilePrintJobsLog.Printf("Built jobs table for %s: jobs=%d", title, len(rows))
+
+	return console.TableConfig{
+		Title:   title,
+		Headers: []string{"JOB", "NEEDS", "IF", "PERMISSIONS"},
+		Rows:    rows,
+	}
+}
+
+// formatJobPermissions flattens a rendered permissions block ("permissions:\n  contents: read")
+// into a comma-separated list ("contents: read")
+func formatJobPermissions(permissions string) string {
+	var scopes []string
+	for line := range strings.SplitSeq(permissions, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || line == "permissions:" {
+			continue
+		}
+		scopes = append(scopes, strings.TrimPrefix(line, "permissions: "))
+	}
+	return strings.Join(scopes, ", ")
+}
+
+// formatJobCell returns "-" for empty cells so missing values are visible in the table
+func formatJobCell(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
@@ -0,0 +1,74 @@
+//go:build !integration
+
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/github/gh-aw/pkg/workflow"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildJobsTableConfig_ThreatDetectionWorkflow(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "compile-print-jobs")
+	markdownFile := filepath.Join(tmpDir, "print-jobs.md")
+	content := "---\non: issues\npermissions:\n  contents: read\nengine: copilot\nsafe-outputs:\n  create-issue:\n---\n\n# Print Jobs Test\n"
+	require.NoError(t, os.WriteFile(markdownFile, []byte(content), 0644), "Failed to write workflow")
+
+	compiler := workflow.NewCompiler()
+	require.NoError(t, compiler.CompileWorkflow(markdownFile), "Workflow should compile")
+	require.NotNil(t, compiler.GetJobManager(), "Job manager should be available after compilation")
+
+	table := buildJobsTableConfig("print-jobs.md", compiler.GetJobManager())
+	assert.Equal(t, []string{"JOB", "NEEDS", "IF", "PERMISSIONS"}, table.Headers, "Table headers should match")
+
+	rows := make(map[string][]string)
+	var order []string
+	for _, row := range table.Rows {
+		require.Len(t, row, 4, "Each row should have a cell per column")
+		rows[row[0]] = row
+		order = append(order, row[0])
+	}
+
+	expectedNeeds := map[string]string{
+		"pre_activation": "-",
+		"activation":     "pre_activation",
+		"agent":          "activation",
+		"detection":      "agent",
+		"safe_outputs":   "agent, detection",
+		"conclusion":     "agent, activation, safe_outputs, detection",
+	}
+	for job, needs := range expectedNeeds {
+		row, exists := rows[job]
+		require.True(t, exists, "Table should list the %s job", job)
+		assert.Equal(t, needs, row[1], "Needs of %s should match", job)
+	}
+
+	assert.Equal(t, "pre_activation", order[0], "Jobs should be listed in dependency order")
+	assert.Equal(t, "contents: read", rows["agent"][3], "Permissions should be flattened to a single line")
+	assert.NotContains(t, rows["activation"][2], "\n", "If conditions should be rendered on a single line")
+	assert.NotEqual(t, "-", rows["activation"][2], "Activation should be gated on the pre-activation result")
+}
+
+func TestFormatJobPermissions(t *testing.T) {
+	tests := []struct {
+		name        string
+		permissions string
+		expected    string
+	}{
+		{name: "empty", permissions: "", expected: ""},
+		{name: "block", permissions: "permissions:\n  contents: read\n  issues: write", expected: "contents: read, issues: write"},
+		{name: "shorthand", permissions: "permissions: read-all", expected: "read-all"},
+		{name: "none", permissions: "permissions: {}", expected: "{}"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, formatJobPermissions(tt.permissions), "Permissions should be flattened")
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/github/gh-aw/pkg/console"
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/workflow"
+)
+
+var compileStdinLog = logger.New("cli:compile_stdin")
+
+// stdinWorkflowFileName is the virtual file name given to workflow source read from stdin.
+// It determines the workflow ID and the runtime-import path in the generated lock file.
+const stdinWorkflowFileName = "stdin.md"
+
+// compileWorkflowFromStdin reads workflow markdown from in, compiles it as if it were
+// stored in baseDir, and writes the generated lock file YAML to out. Nothing is read
+// from or written to the workflow directory other than imported files.
+func compileWorkflowFromStdin(compiler *workflow.Compiler, config CompileConfig, baseDir string, in io.Reader, out io.Writer) error {
+	info, err := os.Stat(baseDir)
+	if err != nil {
+		return fmt.Errorf("failed to access base directory: %w", err)
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("base directory %s is not a directory", baseDir)
+	}
+
+	content, err := io.ReadAll(in)
+	if err != nil {
+		return fmt.Errorf("failed to read workflow from stdin: %w", err)
+	}
+	compileStdinLog.Printf("Read %d bytes from stdin, compiling with base directory %s", len(content), baseDir)
+
+	markdownPath := filepath.Join(baseDir, stdinWorkflowFileName)
+
+	setWorkflowIdentifier(compiler, markdownPath)
+
+	lockContent, err := compiler.CompileString(string(content), markdownPath)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.WriteString(out, lockContent); err != nil {
+		return fmt.Errorf("failed to write lock file YAML: %w", err)
+	}
+
+	if config.Verbose {
+		fmt.Fprintln(os.Stderr, console.FormatInfoMessage(fmt.Sprintf("Compiled workflow from stdin (%d bytes)", len(lockContent))))
+	}
+	return nil
+}
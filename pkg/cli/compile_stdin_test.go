@@ -0,0 +1,137 @@
+//go:build !integration
+
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/github/gh-aw/pkg/workflow"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompileWorkflowFromStdin(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "compile-stdin")
+	content := `---
+on: push
+permissions:
+  contents: read
+engine: copilot
+jobs:
+  prepare:
+    runs-on: ubuntu-latest
+    steps:
+      - run: echo "prepare"
+---
+
+# Stdin Test
+
+Summarize the repository.
+`
+
+	var out bytes.Buffer
+	err := compileWorkflowFromStdin(workflow.NewCompiler(), CompileConfig{}, tmpDir, strings.NewReader(content), &out)
+	require.NoError(t, err, "Workflow from stdin should compile")
+
+	lockYAML := out.String()
+	assert.Contains(t, lockYAML, "name: \"Stdin Test\"", "Lock YAML should use the H1 header as the workflow name")
+	assert.Contains(t, lockYAML, "\njobs:\n", "Lock YAML should contain a jobs section")
+	for _, job := range []string{"activation", "agent", "prepare"} {
+		assert.Contains(t, lockYAML, "\n  "+job+":\n", "Lock YAML should contain the %s job", job)
+	}
+
+	entries, err := os.ReadDir(tmpDir)
+	require.NoError(t, err, "Failed to read base dir")
+	assert.Empty(t, entries, "Compiling from stdin should not write files")
+}
+
+func TestCompileWorkflowFromStdinErrors(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "compile-stdin-errors")
+	notADir := filepath.Join(tmpDir, "file.md")
+	require.NoError(t, os.WriteFile(notADir, []byte("x"), 0644), "Failed to write file")
+
+	tests := []struct {
+		name    string
+		baseDir string
+		content string
+		wantErr string
+	}{
+		{
+			name:    "missing base dir",
+			baseDir: filepath.Join(tmpDir, "missing"),
+			content: "---\non: push\n---\n",
+			wantErr: "failed to access base directory",
+		},
+		{
+			name:    "base dir is a file",
+			baseDir: notADir,
+			content: "---\non: push\n---\n",
+			wantErr: "is not a directory",
+		},
+		{
+			name:    "invalid workflow",
+			baseDir: tmpDir,
+			content: "---\non: push\nengine: not-a-real-engine\n---\n\n# Invalid\n",
+			wantErr: "value must be one of",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			err := compileWorkflowFromStdin(workflow.NewCompiler(), CompileConfig{}, tt.baseDir, strings.NewReader(tt.content), &out)
+			require.Error(t, err, "Compiling from stdin should fail")
+			assert.Contains(t, err.Error(), tt.wantErr, "Error should describe the failure")
+			assert.Empty(t, out.String(), "Nothing should be written to stdout on error")
+		})
+	}
+}
+
+func TestValidateCompileConfigStdin(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  CompileConfig
+		wantErr string
+	}{
+		{
+			name:   "stdin alone",
+			config: CompileConfig{Stdin: true},
+		},
+		{
+			name:   "stdin with base dir",
+			config: CompileConfig{Stdin: true, BaseDir: ".github/workflows"},
+		},
+		{
+			name:    "stdin with workflow files",
+			config:  CompileConfig{Stdin: true, MarkdownFiles: []string{"test.md"}},
+			wantErr: "--stdin flag cannot be used with workflow files",
+		},
+		{
+			name:    "stdin with watch",
+			config:  CompileConfig{Stdin: true, Watch: true},
+			wantErr: "--stdin flag cannot be used with",
+		},
+		{
+			name:    "base dir without stdin",
+			config:  CompileConfig{BaseDir: ".github/workflows"},
+			wantErr: "--base-dir flag requires --stdin",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateCompileConfig(tt.config)
+			if tt.wantErr == "" {
+				assert.NoError(t, err, "Config should be valid")
+				return
+			}
+			require.Error(t, err, "Config should be rejected")
+			assert.Contains(t, err.Error(), tt.wantErr, "Error should name the conflicting flag")
+		})
+	}
+}
@@ -42,13 +42,7 @@ func CompileWorkflowWithValidation(compiler *workflow.Compiler, filePath string,
 	compileValidationLog.Printf("Compiling workflow with validation: file=%s, strict=%v, validateSHAs=%v", filePath, strict, validateActionSHAs)
 
 	// Set workflow identifier for schedule scattering (use repository-relative path for stability)
-	relPath, err := getRepositoryRelativePath(filePath)
-	if err != nil {
-		compileValidationLog.Printf("Warning: failed to get repository-relative path for %s: %v", filePath, err)
-		// Fallback to basename if we can't get relative path
-		relPath = filepath.Base(filePath)
-	}
-	compiler.SetWorkflowIdentifier(relPath)
+	setWorkflowIdentifier(compiler, filePath)
 
 	// Set repository slug for this specific file (may differ from CWD's repo)
 	fileRepoSlug := getRepositorySlugFromRemoteForPath(filePath)
@@ -211,6 +205,55 @@ func validateCompileConfig(config CompileConfig) error {
 		return fmt.Errorf("--purge flag can only be used when compiling all markdown files (no specific files specified)")
 	}
 
+	// Validate jobs flag usage
+	if config.Jobs < 0 {
+		compileValidationLog.Printf("Config validation failed: negative jobs count %d", config.Jobs)
+		return fmt.Errorf("--jobs must not be negative, got: %d", config.Jobs)
+	}
+	if config.Jobs > 1 && (len(config.MarkdownFiles) > 0 || config.Watch) {
+		compileValidationLog.Print("Config validation failed: jobs flag with specific files or watch")
+		return fmt.Errorf("--jobs flag can only be used when compiling all markdown files (no specific files specified, no --watch)")
+	}
+
+	// Validate dry-run flag usage
+	if config.DryRun {
+		if config.Watch {
+			compileValidationLog.Print("Config validation failed: dry-run flag with watch")
+			return fmt.Errorf("--dry-run flag cannot be used with --watch")
+		}
+		if config.NoEmit || config.Purge || config.Dependabot {
+			compileValidationLog.Print("Config validation failed: dry-run flag with flags that write or skip lock files")
+			return fmt.Errorf("--dry-run flag cannot be used with --no-emit, --purge, or --dependabot")
+		}
+	}
+
+	// Validate emit-body-only flag usage
+	if config.EmitBodyOnly {
+		if len(config.MarkdownFiles) == 0 {
+			compileValidationLog.Print("Config validation failed: emit-body-only flag without workflow files")
+			return fmt.Errorf("--emit-body-only flag requires at least one workflow file")
+		}
+		if config.Watch || config.DryRun || config.NoEmit || config.Purge || config.Dependabot || config.JSONOutput {
+			compileValidationLog.Print("Config validation failed: emit-body-only flag with incompatible flags")
+			return fmt.Errorf("--emit-body-only flag cannot be used with --watch, --dry-run, --no-emit, --purge, --dependabot, or --json")
+		}
+	}
+
+	// Validate stdin flag usage
+	if config.Stdin {
+		if len(config.MarkdownFiles) > 0 {
+			compileValidationLog.Print("Config validation failed: stdin flag with workflow files")
+			return fmt.Errorf("--stdin flag cannot be used with workflow files")
+		}
+		if config.Watch || config.DryRun || config.NoEmit || config.Purge || config.Dependabot || config.JSONOutput || config.EmitBodyOnly {
+			compileValidationLog.Print("Config validation failed: stdin flag with incompatible flags")
+			return fmt.Errorf("--stdin flag cannot be used with --watch, --dry-run, --no-emit, --purge, --dependabot, --json, or --emit-body-only")
+		}
+	} else if config.BaseDir != "" {
+		compileValidationLog.Print("Config validation failed: base-dir flag without stdin")
+		return fmt.Errorf("--base-dir flag requires --stdin")
+	}
+
 	// Validate workflow directory path
 	if config.WorkflowDir != "" && filepath.IsAbs(config.WorkflowDir) {
 		compileValidationLog.Printf("Config validation failed: absolute path in workflowDir: %s", config.WorkflowDir)
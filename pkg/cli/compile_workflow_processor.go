@@ -40,6 +40,7 @@ type compileWorkflowFileResult struct {
 	lockFile         string
 	validationResult ValidationResult
 	success          bool
+	jobManager       *workflow.JobManager // Jobs of the compiled workflow, for --print-jobs
 }
 
 // compileWorkflowFile compiles a single workflow file (not a campaign spec)
@@ -79,13 +80,7 @@ func compileWorkflowFile(
 	compileWorkflowProcessorLog.Printf("Parsing workflow file: %s", resolvedFile)
 
 	// Set workflow identifier for schedule scattering (use repository-relative path for stability)
-	relPath, err := getRepositoryRelativePath(resolvedFile)
-	if err != nil {
-		compileWorkflowProcessorLog.Printf("Warning: failed to get repository-relative path for %s: %v", resolvedFile, err)
-		// Fallback to basename if we can't get relative path
-		relPath = filepath.Base(resolvedFile)
-	}
-	compiler.SetWorkflowIdentifier(relPath)
+	setWorkflowIdentifier(compiler, resolvedFile)
 
 	// Set repository slug for this specific file (may differ from CWD's repo)
 	fileRepoSlug := getRepositorySlugFromRemoteForPath(resolvedFile)
@@ -140,6 +135,7 @@ func compileWorkflowFile(
 	}
 
 	result.success = true
+	result.jobManager = compiler.GetJobManager()
 	compileWorkflowProcessorLog.Printf("Successfully processed workflow file: %s", resolvedFile)
 	return result
 }
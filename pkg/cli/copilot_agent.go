@@ -191,6 +191,7 @@ func ParseCopilotAgentLogMetrics(logContent string, verbose bool) workflow.LogMe
 	lines := strings.Split(logContent, "\n")
 	toolCallMap := make(map[string]*workflow.ToolCallInfo)
 	var currentSequence []string
+	var currentSequenceTurn int // Turn in which the current sequence started
 	turns := 0
 
 	// GitHub Copilot agent log patterns
@@ -209,7 +210,7 @@ func ParseCopilotAgentLogMetrics(logContent string, verbose bool) workflow.LogMe
 			turns++
 			// Start of a new turn, save previous sequence if any
 			if len(currentSequence) > 0 {
-				metrics.ToolSequences = append(metrics.ToolSequences, currentSequence)
+				metrics.AddToolSequence(currentSequence, currentSequenceTurn)
 				currentSequence = []string{}
 			}
 		}
@@ -228,6 +229,9 @@ func ParseCopilotAgentLogMetrics(logContent string, verbose bool) workflow.LogMe
 				toolCallMap[toolName].CallCount++
 
 				// Add to current sequence
+				if len(currentSequence) == 0 {
+					currentSequenceTurn = turns
+				}
 				currentSequence = append(currentSequence, toolName)
 
 				if verbose {
@@ -250,7 +254,7 @@ func ParseCopilotAgentLogMetrics(logContent string, verbose bool) workflow.LogMe
 
 	// Add final sequence if any
 	if len(currentSequence) > 0 {
-		metrics.ToolSequences = append(metrics.ToolSequences, currentSequence)
+		metrics.AddToolSequence(currentSequence, currentSequenceTurn)
 	}
 
 	// Convert tool call map to slice
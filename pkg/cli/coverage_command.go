@@ -0,0 +1,358 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	goparser "go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/console"
+	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/parser"
+	"github.com/spf13/cobra"
+)
+
+var coverageLog = logger.New("cli:coverage_command")
+
+// defaultCoverageDepth is the default nesting depth of frontmatter keys to analyze
+// (e.g., depth 2 reports "engine.model" but not "tools.github.toolsets").
+const defaultCoverageDepth = 2
+
+// CoverageConfig holds configuration for the coverage command
+type CoverageConfig struct {
+	Paths      []string // Directories or files to scan for fixtures
+	Depth      int      // Maximum key nesting depth to analyze
+	JSONOutput bool
+	Verbose    bool
+}
+
+// FrontmatterCoverageReport describes which schema keys are exercised by the test fixtures
+type FrontmatterCoverageReport struct {
+	FixturesScanned int      `json:"fixtures_scanned"`
+	SchemaKeys      int      `json:"schema_keys"`
+	TestedKeys      int      `json:"tested_keys"`
+	CoveragePercent float64  `json:"coverage_percent"`
+	UntestedKeys    []string `json:"untested_keys"`
+}
+
+// NewCoverageCommand creates the coverage command
+func NewCoverageCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "coverage [path...]",
+		Short: "Report frontmatter schema keys not exercised by test fixtures",
+		Long: `Report which frontmatter keys declared in the workflow schema are exercised by the test corpus.
+
+This is a maintainer tool. It collects every key path declared in the embedded
+frontmatter schema, scans test fixtures for frontmatter (string literals in
+*_test.go files and *.md files), and reports schema keys that no fixture uses.
+
+When no paths are given, the compiler test corpus (pkg/workflow) is scanned.
+
+Examples:
+  ` + string(constants.CLIExtensionPrefix) + ` coverage                      # Scan pkg/workflow
+  ` + string(constants.CLIExtensionPrefix) + ` coverage pkg/workflow pkg/cli # Scan multiple directories
+  ` + string(constants.CLIExtensionPrefix) + ` coverage --depth 3            # Include deeper keys like tools.github.toolsets
+  ` + string(constants.CLIExtensionPrefix) + ` coverage --json               # Output in JSON format`,
+		Hidden: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			depth, _ := cmd.Flags().GetInt("depth")
+			jsonOutput, _ := cmd.Flags().GetBool("json")
+			verbose, _ := cmd.Flags().GetBool("verbose")
+
+			return RunCoverage(CoverageConfig{
+				Paths:      args,
+				Depth:      depth,
+				JSONOutput: jsonOutput,
+				Verbose:    verbose,
+			})
+		},
+	}
+
+	cmd.Flags().Int("depth", defaultCoverageDepth, "Maximum nesting depth of frontmatter keys to analyze")
+	addJSONFlag(cmd)
+
+	return cmd
+}
+
+// RunCoverage executes the coverage command with the given configuration
+func RunCoverage(config CoverageConfig) error {
+	coverageLog.Printf("Running coverage: paths=%v, depth=%d", config.Paths, config.Depth)
+
+	if config.Depth < 1 {
+		return fmt.Errorf("invalid depth value: %d. Must be at least 1", config.Depth)
+	}
+
+	paths := config.Paths
+	if len(paths) == 0 {
+		gitRoot, err := findGitRoot()
+		if err != nil {
+			return fmt.Errorf("coverage without arguments requires being in a git repository: %w", err)
+		}
+		paths = []string{filepath.Join(gitRoot, "pkg", "workflow")}
+	}
+
+	schemaKeys, err := collectSchemaKeyPaths(parser.GetMainWorkflowSchema(), config.Depth)
+	if err != nil {
+		return err
+	}
+
+	fixtureKeys, fixtures, err := scanFixtureKeyPaths(paths, config.Depth)
+	if err != nil {
+		return err
+	}
+
+	if config.Verbose {
+		fmt.Fprintln(os.Stderr, console.FormatVerboseMessage(fmt.Sprintf("Found %d schema keys and %d fixtures", len(schemaKeys), fixtures)))
+	}
+
+	report := computeFrontmatterCoverage(schemaKeys, fixtureKeys)
+	report.FixturesScanned = fixtures
+
+	if config.JSONOutput {
+		jsonBytes, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal coverage report: %w", err)
+		}
+		fmt.Println(string(jsonBytes))
+		return nil
+	}
+
+	fmt.Fprintln(os.Stderr, console.FormatInfoMessage(fmt.Sprintf("Frontmatter key coverage: %d/%d (%.1f%%) across %d fixtures",
+		report.TestedKeys, report.SchemaKeys, report.CoveragePercent, report.FixturesScanned)))
+	if len(report.UntestedKeys) == 0 {
+		fmt.Fprintln(os.Stderr, console.FormatSuccessMessage("All schema keys are exercised by test fixtures"))
+		return nil
+	}
+
+	fmt.Fprintln(os.Stderr, console.FormatListHeader("Untested keys:"))
+	for _, key := range report.UntestedKeys {
+		// Print keys to stdout so they can be piped to other tools
+		fmt.Println(key)
+	}
+	return nil
+}
+
+// computeFrontmatterCoverage compares schema key paths against key paths found in fixtures
+func computeFrontmatterCoverage(schemaKeys []string, fixtureKeys map[string]bool) FrontmatterCoverageReport {
+	report := FrontmatterCoverageReport{
+		SchemaKeys:   len(schemaKeys),
+		UntestedKeys: []string{},
+	}
+	for _, key := range schemaKeys {
+		if fixtureKeys[key] {
+			report.TestedKeys++
+		} else {
+			report.UntestedKeys = append(report.UntestedKeys, key)
+		}
+	}
+	if report.SchemaKeys > 0 {
+		report.CoveragePercent = float64(report.TestedKeys) * 100 / float64(report.SchemaKeys)
+	}
+	coverageLog.Printf("Coverage computed: tested=%d, total=%d", report.TestedKeys, report.SchemaKeys)
+	return report
+}
+
+// collectSchemaKeyPaths returns the sorted, dot-separated key paths declared in a JSON schema
+// up to maxDepth levels deep. $ref, oneOf, anyOf, allOf, and array items are followed.
+func collectSchemaKeyPaths(schemaJSON string, maxDepth int) ([]string, error) {
+	var root map[string]any
+	if err := json.Unmarshal([]byte(schemaJSON), &root); err != nil {
+		return nil, fmt.Errorf("failed to parse schema: %w", err)
+	}
+
+	keys := make(map[string]bool)
+	walkSchemaNode(root, root, "", 1, maxDepth, keys, make(map[string]bool))
+
+	result := make([]string, 0, len(keys))
+	for key := range keys {
+		result = append(result, key)
+	}
+	sort.Strings(result)
+	return result, nil
+}
+
+// walkSchemaNode records the property names of a schema node and recurses into nested schemas.
+// activeRefs guards against cycles in recursive $ref definitions.
+func walkSchemaNode(root map[string]any, node map[string]any, prefix string, depth, maxDepth int, keys map[string]bool, activeRefs map[string]bool) {
+	if ref, ok := node["$ref"].(string); ok {
+		if activeRefs[ref] {
+			return
+		}
+		resolved := resolveSchemaRef(root, ref)
+		if resolved == nil {
+			return
+		}
+		activeRefs[ref] = true
+		walkSchemaNode(root, resolved, prefix, depth, maxDepth, keys, activeRefs)
+		delete(activeRefs, ref)
+	}
+
+	if properties, ok := node["properties"].(map[string]any); ok {
+		for name, child := range properties {
+			path := joinKeyPath(prefix, name)
+			keys[path] = true
+			if childNode, ok := child.(map[string]any); ok && depth < maxDepth {
+				walkSchemaNode(root, childNode, path, depth+1, maxDepth, keys, activeRefs)
+			}
+		}
+	}
+
+	for _, combinator := range []string{"oneOf", "anyOf", "allOf"} {
+		if branches, ok := node[combinator].([]any); ok {
+			for _, branch := range branches {
+				if branchNode, ok := branch.(map[string]any); ok {
+					walkSchemaNode(root, branchNode, prefix, depth, maxDepth, keys, activeRefs)
+				}
+			}
+		}
+	}
+
+	if items, ok := node["items"].(map[string]any); ok {
+		walkSchemaNode(root, items, prefix, depth, maxDepth, keys, activeRefs)
+	}
+}
+
+// resolveSchemaRef resolves a local JSON pointer reference (e.g., "#/$defs/engine_config")
+func resolveSchemaRef(root map[string]any, ref string) map[string]any {
+	if !strings.HasPrefix(ref, "#/") {
+		return nil
+	}
+	var current any = root
+	for _, part := range strings.Split(strings.TrimPrefix(ref, "#/"), "/") {
+		part = strings.ReplaceAll(strings.ReplaceAll(part, "~1", "/"), "~0", "~")
+		currentMap, ok := current.(map[string]any)
+		if !ok {
+			return nil
+		}
+		current = currentMap[part]
+	}
+	resolved, _ := current.(map[string]any)
+	return resolved
+}
+
+// scanFixtureKeyPaths scans files and directories for frontmatter fixtures and returns the set of
+// key paths they use along with the number of fixtures found.
+// Fixtures are read from string literals in *_test.go files and from *.md files.
+func scanFixtureKeyPaths(paths []string, maxDepth int) (map[string]bool, int, error) {
+	keys := make(map[string]bool)
+	fixtures := 0
+
+	for _, root := range paths {
+		err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() {
+				if path != root && (d.Name() == "node_modules" || strings.HasPrefix(d.Name(), ".git")) {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+
+			var contents []string
+			switch {
+			case strings.HasSuffix(path, "_test.go"):
+				literals, err := extractGoStringLiterals(path)
+				if err != nil {
+					coverageLog.Printf("Skipping unparsable Go file %s: %v", path, err)
+					return nil
+				}
+				contents = literals
+			case strings.HasSuffix(path, ".md"):
+				content, err := os.ReadFile(path)
+				if err != nil {
+					return fmt.Errorf("failed to read %s: %w", path, err)
+				}
+				contents = []string{string(content)}
+			default:
+				return nil
+			}
+
+			for _, content := range contents {
+				frontmatter := extractFixtureFrontmatter(content)
+				if frontmatter == nil {
+					continue
+				}
+				fixtures++
+				collectFrontmatterKeyPaths(frontmatter, "", 1, maxDepth, keys)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan %s: %w", root, err)
+		}
+	}
+
+	coverageLog.Printf("Scanned %d fixtures, found %d distinct keys", fixtures, len(keys))
+	return keys, fixtures, nil
+}
+
+// extractGoStringLiterals returns the unquoted values of all string literals in a Go source file
+func extractGoStringLiterals(path string) ([]string, error) {
+	file, err := goparser.ParseFile(token.NewFileSet(), path, nil, goparser.SkipObjectResolution)
+	if err != nil {
+		return nil, err
+	}
+
+	var literals []string
+	ast.Inspect(file, func(n ast.Node) bool {
+		lit, ok := n.(*ast.BasicLit)
+		if !ok || lit.Kind != token.STRING {
+			return true
+		}
+		if value, err := strconv.Unquote(lit.Value); err == nil {
+			literals = append(literals, value)
+		}
+		return true
+	})
+	return literals, nil
+}
+
+// extractFixtureFrontmatter parses the frontmatter of a fixture, returning nil when the
+// content has no valid, non-empty frontmatter block
+func extractFixtureFrontmatter(content string) map[string]any {
+	content = strings.TrimLeft(content, " \t\r\n")
+	if !strings.HasPrefix(content, "---") {
+		return nil
+	}
+	result, err := parser.ExtractFrontmatterFromContent(content)
+	if err != nil || len(result.Frontmatter) == 0 {
+		return nil
+	}
+	return result.Frontmatter
+}
+
+// collectFrontmatterKeyPaths records the dot-separated key paths of a parsed frontmatter value.
+// List items are walked at the same level as the list key, matching schema array items.
+func collectFrontmatterKeyPaths(value any, prefix string, depth, maxDepth int, keys map[string]bool) {
+	switch v := value.(type) {
+	case map[string]any:
+		for name, child := range v {
+			path := joinKeyPath(prefix, name)
+			keys[path] = true
+			if depth < maxDepth {
+				collectFrontmatterKeyPaths(child, path, depth+1, maxDepth, keys)
+			}
+		}
+	case []any:
+		for _, item := range v {
+			collectFrontmatterKeyPaths(item, prefix, depth, maxDepth, keys)
+		}
+	}
+}
+
+// joinKeyPath joins a parent key path and a child key name with a dot
+func joinKeyPath(prefix, name string) string {
+	if prefix == "" {
+		return name
+	}
+	return prefix + "." + name
+}
@@ -0,0 +1,132 @@
+//go:build !integration
+
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/parser"
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const coverageTestSchema = `{
+  "type": "object",
+  "properties": {
+    "on": {"type": "string"},
+    "engine": {"$ref": "#/$defs/engine_config"},
+    "steps": {
+      "type": "array",
+      "items": {"type": "object", "properties": {"name": {"type": "string"}, "run": {"type": "string"}}}
+    },
+    "timeout-minutes": {"type": "integer"}
+  },
+  "$defs": {
+    "engine_config": {
+      "oneOf": [
+        {"type": "string"},
+        {"type": "object", "properties": {"id": {"type": "string"}, "model": {"type": "string"}, "nested": {"$ref": "#/$defs/engine_config"}}}
+      ]
+    }
+  }
+}`
+
+func TestCollectSchemaKeyPaths(t *testing.T) {
+	tests := []struct {
+		name     string
+		depth    int
+		expected []string
+	}{
+		{
+			name:     "top-level keys only",
+			depth:    1,
+			expected: []string{"engine", "on", "steps", "timeout-minutes"},
+		},
+		{
+			name:  "follows refs, combinators and array items",
+			depth: 2,
+			expected: []string{
+				"engine", "engine.id", "engine.model", "engine.nested",
+				"on", "steps", "steps.name", "steps.run", "timeout-minutes",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keys, err := collectSchemaKeyPaths(coverageTestSchema, tt.depth)
+			require.NoError(t, err, "Schema should parse")
+			assert.Equal(t, tt.expected, keys, "Schema key paths should match")
+		})
+	}
+}
+
+func TestCollectSchemaKeyPathsRecursiveRef(t *testing.T) {
+	keys, err := collectSchemaKeyPaths(coverageTestSchema, 10)
+	require.NoError(t, err, "Recursive $ref should not cause infinite recursion")
+	assert.Contains(t, keys, "engine.nested", "Recursive property should be listed once")
+	assert.NotContains(t, keys, "engine.nested.id", "Recursive $ref should not be expanded again")
+}
+
+func TestCollectMainSchemaKeyPaths(t *testing.T) {
+	keys, err := collectSchemaKeyPaths(parser.GetMainWorkflowSchema(), defaultCoverageDepth)
+	require.NoError(t, err, "Main workflow schema should parse")
+	assert.Contains(t, keys, "engine.model", "Engine config keys should be resolved through $ref")
+	assert.Contains(t, keys, "safe-outputs.create-issue", "Nested safe-outputs keys should be listed")
+}
+
+func TestFrontmatterCoverageReportsUntestedKeys(t *testing.T) {
+	dir := testutil.TempDir(t, "coverage-test-*")
+
+	goFixture := "package fixtures\n\nconst workflow = `---\non: push\nengine:\n  id: copilot\nsteps:\n  - name: Setup\n    run: echo hi\n---\n\n# Test\n`\n\nconst notFrontmatter = \"plain string\"\n"
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "fixtures_test.go"), []byte(goFixture), 0644), "Failed to write Go fixture")
+
+	mdFixture := "---\non: issues\ntimeout-minutes: 10\n---\n\n# Markdown fixture\n"
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "workflow.md"), []byte(mdFixture), 0644), "Failed to write markdown fixture")
+
+	// Non-test Go files are not part of the fixture corpus
+	ignored := "package fixtures\n\nconst workflow = `---\nengine:\n  model: gpt-5\n---\n`\n"
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "fixtures.go"), []byte(ignored), 0644), "Failed to write non-test Go file")
+
+	schemaKeys, err := collectSchemaKeyPaths(coverageTestSchema, 2)
+	require.NoError(t, err, "Schema should parse")
+
+	fixtureKeys, fixtures, err := scanFixtureKeyPaths([]string{dir}, 2)
+	require.NoError(t, err, "Fixture scan should succeed")
+	assert.Equal(t, 2, fixtures, "Should find one Go fixture and one markdown fixture")
+
+	report := computeFrontmatterCoverage(schemaKeys, fixtureKeys)
+	assert.Equal(t, []string{"engine.model", "engine.nested"}, report.UntestedKeys, "Keys in the schema but absent from fixtures should be reported")
+	assert.Equal(t, 9, report.SchemaKeys, "All schema keys should be counted")
+	assert.Equal(t, 7, report.TestedKeys, "Keys used by fixtures should be counted as tested")
+	assert.InDelta(t, 77.78, report.CoveragePercent, 0.01, "Coverage percentage should be computed")
+}
+
+func TestExtractFixtureFrontmatter(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected bool
+	}{
+		{name: "frontmatter with leading newline", content: "\n---\non: push\n---\n# Body", expected: true},
+		{name: "no frontmatter", content: "# Just markdown", expected: false},
+		{name: "empty frontmatter", content: "---\n---\n# Body", expected: false},
+		{name: "invalid yaml", content: "---\non: [push\n---\n", expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			frontmatter := extractFixtureFrontmatter(tt.content)
+			assert.Equal(t, tt.expected, frontmatter != nil, "Frontmatter detection should match")
+		})
+	}
+}
+
+func TestRunCoverageInvalidDepth(t *testing.T) {
+	err := RunCoverage(CoverageConfig{Paths: []string{t.TempDir()}, Depth: 0})
+	require.Error(t, err, "Depth of zero should be rejected")
+	assert.Contains(t, err.Error(), "invalid depth", "Error should describe the invalid depth")
+}
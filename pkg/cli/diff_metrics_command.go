@@ -0,0 +1,243 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/console"
+	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/workflow"
+	"github.com/spf13/cobra"
+)
+
+var diffMetricsLog = logger.New("cli:diff_metrics_command")
+
+// defaultRegressionThreshold is the percentage increase above which a metric is flagged as a regression
+const defaultRegressionThreshold = 10.0
+
+// DiffMetricsConfig holds configuration for the diff-metrics command
+type DiffMetricsConfig struct {
+	BaseRun    string  // Run ID, run folder, or run_summary.json of the baseline run
+	HeadRun    string  // Run ID, run folder, or run_summary.json of the run being compared
+	OutputDir  string  // Logs directory used to resolve run IDs
+	Threshold  float64 // Percentage increase flagged as a regression
+	JSONOutput bool
+	Verbose    bool
+}
+
+// MetricsDiff is the result of comparing the metrics of two runs
+type MetricsDiff struct {
+	BaseRun     string                        `json:"base_run"`
+	HeadRun     string                        `json:"head_run"`
+	Threshold   float64                       `json:"threshold"`
+	Comparison  workflow.LogMetricsComparison `json:"comparison"`
+	Regressions []workflow.MetricDelta        `json:"regressions"`
+}
+
+// NewDiffMetricsCommand creates the diff-metrics command
+func NewDiffMetricsCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "diff-metrics <base-run> <head-run>",
+		Short: "Compare the metrics of two workflow runs side by side",
+		Long: `Compare the metrics of two workflow runs side by side: token usage, turns,
+estimated cost, and per-tool call counts.
+
+Each run is given as a run ID, a run folder, or a run_summary.json file. Run IDs
+are resolved against the logs directory (--output), so the runs must have been
+downloaded first with the logs or audit command.
+
+Metrics that increased by more than --threshold percent from the base run to the
+head run are flagged as regressions.
+
+Examples:
+  ` + string(constants.CLIExtensionPrefix) + ` diff-metrics 1234567890 1234567999
+  ` + string(constants.CLIExtensionPrefix) + ` diff-metrics 1234567890 1234567999 --threshold 25
+  ` + string(constants.CLIExtensionPrefix) + ` diff-metrics ./logs/run-1234567890 ./logs/run-1234567999 --json`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			outputDir, _ := cmd.Flags().GetString("output")
+			threshold, _ := cmd.Flags().GetFloat64("threshold")
+			jsonOutput, _ := cmd.Flags().GetBool("json")
+			verbose, _ := cmd.Flags().GetBool("verbose")
+
+			return RunDiffMetrics(DiffMetricsConfig{
+				BaseRun:    args[0],
+				HeadRun:    args[1],
+				OutputDir:  outputDir,
+				Threshold:  threshold,
+				JSONOutput: jsonOutput,
+				Verbose:    verbose,
+			})
+		},
+	}
+
+	addOutputFlag(cmd, defaultLogsOutputDir)
+	cmd.Flags().Float64("threshold", defaultRegressionThreshold, "Percentage increase above which a metric is flagged as a regression")
+	addJSONFlag(cmd)
+
+	return cmd
+}
+
+// RunDiffMetrics executes the diff-metrics command with the given configuration
+func RunDiffMetrics(config DiffMetricsConfig) error {
+	diffMetricsLog.Printf("Comparing runs: base=%s, head=%s, threshold=%.1f", config.BaseRun, config.HeadRun, config.Threshold)
+
+	if config.Threshold < 0 {
+		return fmt.Errorf("threshold must be a non-negative percentage, got %v", config.Threshold)
+	}
+
+	baseSummary, err := readRunSummaryForDiff(config.BaseRun, config.OutputDir)
+	if err != nil {
+		return err
+	}
+	headSummary, err := readRunSummaryForDiff(config.HeadRun, config.OutputDir)
+	if err != nil {
+		return err
+	}
+
+	diff := buildMetricsDiff(runLabel(config.BaseRun, baseSummary), runLabel(config.HeadRun, headSummary), baseSummary.Metrics, headSummary.Metrics, config.Threshold)
+
+	if config.JSONOutput {
+		jsonBytes, err := json.MarshalIndent(diff, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal metrics diff: %w", err)
+		}
+		fmt.Println(string(jsonBytes))
+		return nil
+	}
+
+	fmt.Fprint(os.Stderr, renderMetricsDiff(diff))
+	return nil
+}
+
+// resolveRunSummaryPath resolves a run ID, run folder, or summary file to a run_summary.json path
+func resolveRunSummaryPath(run, outputDir string) string {
+	if _, err := strconv.ParseInt(run, 10, 64); err == nil {
+		return filepath.Join(outputDir, fmt.Sprintf("run-%s", run), runSummaryFileName)
+	}
+	if info, err := os.Stat(run); err == nil && info.IsDir() {
+		return filepath.Join(run, runSummaryFileName)
+	}
+	return run
+}
+
+// readRunSummaryForDiff reads the run summary of a run. Unlike loadRunSummary, summaries
+// written by other CLI versions are accepted since only the metrics are compared.
+func readRunSummaryForDiff(run, outputDir string) (*RunSummary, error) {
+	summaryPath := resolveRunSummaryPath(run, outputDir)
+	diffMetricsLog.Printf("Reading run summary: run=%s, path=%s", run, summaryPath)
+
+	data, err := os.ReadFile(summaryPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("no run summary found for %s at %s; download the run first with '%s logs' or '%s audit'",
+				run, summaryPath, constants.CLIExtensionPrefix, constants.CLIExtensionPrefix)
+		}
+		return nil, fmt.Errorf("failed to read run summary for %s: %w", run, err)
+	}
+
+	var summary RunSummary
+	if err := json.Unmarshal(data, &summary); err != nil {
+		return nil, fmt.Errorf("failed to parse run summary %s: %w", summaryPath, err)
+	}
+	return &summary, nil
+}
+
+// runLabel returns the column label for a run, preferring its run ID
+func runLabel(run string, summary *RunSummary) string {
+	if summary.RunID != 0 {
+		return fmt.Sprintf("Run %d", summary.RunID)
+	}
+	return filepath.Base(run)
+}
+
+// buildMetricsDiff compares the metrics of two runs and collects the regressions
+func buildMetricsDiff(baseRun, headRun string, base, head LogMetrics, threshold float64) *MetricsDiff {
+	comparison := workflow.CompareLogMetrics(base, head)
+	regressions := comparison.Regressions(threshold)
+	if regressions == nil {
+		regressions = []workflow.MetricDelta{}
+	}
+	return &MetricsDiff{
+		BaseRun:     baseRun,
+		HeadRun:     headRun,
+		Threshold:   threshold,
+		Comparison:  comparison,
+		Regressions: regressions,
+	}
+}
+
+// renderMetricsDiff renders the comparison as a side-by-side table followed by
+// a warning for each regression
+func renderMetricsDiff(diff *MetricsDiff) string {
+	regressed := make(map[string]bool)
+	for _, delta := range diff.Regressions {
+		regressed[delta.Name] = true
+	}
+
+	row := func(delta workflow.MetricDelta, format func(float64) string) []string {
+		change := formatMetricChange(delta, format)
+		if regressed[delta.Name] {
+			change += " ▲"
+		}
+		return []string{delta.Name, format(delta.Base), format(delta.Head), change}
+	}
+
+	rows := [][]string{
+		row(diff.Comparison.TokenUsage, formatMetricCount),
+		row(diff.Comparison.Turns, formatMetricCount),
+		row(diff.Comparison.EstimatedCost, formatMetricCost),
+	}
+	for _, delta := range diff.Comparison.ToolCalls {
+		rows = append(rows, row(delta, formatMetricCount))
+	}
+
+	var output strings.Builder
+	output.WriteString(console.RenderTable(console.TableConfig{
+		Title:   "Metrics: " + diff.BaseRun + " → " + diff.HeadRun,
+		Headers: []string{"Metric", diff.BaseRun, diff.HeadRun, "Change"},
+		Rows:    rows,
+	}))
+
+	if len(diff.Regressions) == 0 {
+		output.WriteString(console.FormatSuccessMessage(fmt.Sprintf("No metric increased by more than %.0f%%", diff.Threshold)))
+		output.WriteString("\n")
+		return output.String()
+	}
+	for _, delta := range diff.Regressions {
+		output.WriteString(console.FormatWarningMessage(fmt.Sprintf("Regression: %s increased by %.1f%% (threshold %.0f%%)", delta.Name, delta.PercentChange, diff.Threshold)))
+		output.WriteString("\n")
+	}
+	return output.String()
+}
+
+// formatMetricChange formats the absolute and relative change of a metric
+func formatMetricChange(delta workflow.MetricDelta, format func(float64) string) string {
+	if delta.Change == 0 {
+		return "="
+	}
+	sign := "+"
+	if delta.Change < 0 {
+		sign = "-"
+	}
+	absChange := delta.Change
+	if absChange < 0 {
+		absChange = -absChange
+	}
+	return fmt.Sprintf("%s%s (%+.1f%%)", sign, format(absChange), delta.PercentChange)
+}
+
+// formatMetricCount formats a count metric such as tokens, turns, or tool calls
+func formatMetricCount(value float64) string {
+	return console.FormatNumber(int(value))
+}
+
+// formatMetricCost formats an estimated cost in US dollars
+func formatMetricCost(value float64) string {
+	return fmt.Sprintf("$%.3f", value)
+}
@@ -0,0 +1,89 @@
+//go:build !integration
+
+package cli
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/github/gh-aw/pkg/workflow"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var diffMetricsBase = LogMetrics{
+	TokenUsage:    1000,
+	Turns:         4,
+	EstimatedCost: 0.20,
+	ToolCalls:     []workflow.ToolCallInfo{{Name: "bash", CallCount: 4}, {Name: "github::get_issue", CallCount: 2}},
+}
+
+var diffMetricsHead = LogMetrics{
+	TokenUsage:    1500,
+	Turns:         3,
+	EstimatedCost: 0.21,
+	ToolCalls:     []workflow.ToolCallInfo{{Name: "bash", CallCount: 4}, {Name: "github::get_issue", CallCount: 1}},
+}
+
+func writeRunSummary(t *testing.T, dir string, runID int64, metrics LogMetrics) {
+	t.Helper()
+	runDir := filepath.Join(dir, "run-"+strconv.FormatInt(runID, 10))
+	require.NoError(t, os.MkdirAll(runDir, 0755), "Failed to create run folder")
+	data, err := json.Marshal(RunSummary{RunID: runID, Metrics: metrics})
+	require.NoError(t, err, "Failed to marshal run summary")
+	require.NoError(t, os.WriteFile(filepath.Join(runDir, runSummaryFileName), data, 0644), "Failed to write run summary")
+}
+
+func TestRenderMetricsDiffShowsChanges(t *testing.T) {
+	diff := buildMetricsDiff("Run 1", "Run 2", diffMetricsBase, diffMetricsHead, defaultRegressionThreshold)
+	output := renderMetricsDiff(diff)
+
+	assert.Contains(t, output, "+500 (+50.0%) ▲", "Token increase should be shown and flagged")
+	assert.Contains(t, output, "-1 (-25.0%)", "Turn decrease should be shown")
+	assert.Contains(t, output, "$0.210", "Head cost should be shown")
+	assert.Contains(t, output, "-1 (-50.0%)", "Per-tool decrease should be shown")
+	assert.Contains(t, output, "Regression: Token Usage increased by 50.0% (threshold 10%)", "Regression beyond the threshold should be flagged")
+	assert.NotContains(t, output, "Regression: Estimated Cost", "Increase within the threshold should not be flagged")
+	assert.NotContains(t, output, "Regression: Turns", "Decrease should not be flagged")
+}
+
+func TestRenderMetricsDiffWithoutRegressions(t *testing.T) {
+	diff := buildMetricsDiff("Run 1", "Run 2", diffMetricsBase, diffMetricsHead, 75)
+	output := renderMetricsDiff(diff)
+
+	assert.Empty(t, diff.Regressions, "No metric should exceed a 75% threshold")
+	assert.NotContains(t, output, "▲", "No metric should be flagged")
+	assert.Contains(t, output, "No metric increased by more than 75%", "Output should confirm there are no regressions")
+}
+
+func TestRunDiffMetricsResolvesRunIDs(t *testing.T) {
+	logsDir := testutil.TempDir(t, "diff-metrics-test")
+	writeRunSummary(t, logsDir, 101, diffMetricsBase)
+	writeRunSummary(t, logsDir, 102, diffMetricsHead)
+
+	base, err := readRunSummaryForDiff("101", logsDir)
+	require.NoError(t, err, "Run ID should resolve to the run folder in the logs directory")
+	assert.Equal(t, 1000, base.Metrics.TokenUsage, "Base metrics should be read")
+
+	head, err := readRunSummaryForDiff(filepath.Join(logsDir, "run-102"), logsDir)
+	require.NoError(t, err, "Run folder should resolve to its summary")
+	assert.Equal(t, "Run 102", runLabel("run-102", head), "Run label should use the run ID")
+
+	require.NoError(t, RunDiffMetrics(DiffMetricsConfig{BaseRun: "101", HeadRun: "102", OutputDir: logsDir, Threshold: 10}), "Diff should succeed")
+}
+
+func TestRunDiffMetricsMissingRun(t *testing.T) {
+	logsDir := testutil.TempDir(t, "diff-metrics-missing-test")
+	writeRunSummary(t, logsDir, 101, diffMetricsBase)
+
+	err := RunDiffMetrics(DiffMetricsConfig{BaseRun: "101", HeadRun: "999", OutputDir: logsDir, Threshold: 10})
+	require.Error(t, err, "Missing run should fail")
+	assert.Contains(t, err.Error(), "no run summary found for 999", "Error should name the missing run")
+
+	err = RunDiffMetrics(DiffMetricsConfig{BaseRun: "101", HeadRun: "101", OutputDir: logsDir, Threshold: -1})
+	require.Error(t, err, "Negative threshold should fail")
+}
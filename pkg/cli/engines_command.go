@@ -0,0 +1,119 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/github/gh-aw/pkg/console"
+	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/workflow"
+	"github.com/spf13/cobra"
+)
+
+var enginesLog = logger.New("cli:engines_command")
+
+// engineCapability is a row of the capability matrix
+type engineCapability struct {
+	name  string
+	value func(workflow.EngineInfo) string
+}
+
+// engineCapabilities lists the capability matrix rows in display order
+var engineCapabilities = []engineCapability{
+	{"Experimental", func(e workflow.EngineInfo) string { return formatCapability(e.Experimental) }},
+	{"Tools allowlist", func(e workflow.EngineInfo) string { return formatCapability(e.SupportsToolsAllowlist) }},
+	{"HTTP transport", func(e workflow.EngineInfo) string { return formatCapability(e.SupportsHTTPTransport) }},
+	{"Max turns", func(e workflow.EngineInfo) string { return formatCapability(e.SupportsMaxTurns) }},
+	{"Max tokens", func(e workflow.EngineInfo) string { return formatCapability(e.SupportsMaxTokens) }},
+	{"Cost budget", func(e workflow.EngineInfo) string { return formatCapability(e.SupportsMaxCost) }},
+	{"Tool call timeout", func(e workflow.EngineInfo) string { return formatCapability(e.SupportsCallTimeout) }},
+	{"Gated allowlists", func(e workflow.EngineInfo) string { return formatCapability(e.SupportsAllowedWhen) }},
+	{"Web fetch", func(e workflow.EngineInfo) string { return formatCapability(e.SupportsWebFetch) }},
+	{"Web search", func(e workflow.EngineInfo) string { return formatCapability(e.SupportsWebSearch) }},
+	{"Firewall", func(e workflow.EngineInfo) string { return formatCapability(e.SupportsFirewall) }},
+	{"Plugins", func(e workflow.EngineInfo) string { return formatCapability(e.SupportsPlugins) }},
+	{"Bash shell", func(e workflow.EngineInfo) string { return formatCapability(e.SupportsBashShell) }},
+	{"LLM gateway port", func(e workflow.EngineInfo) string {
+		if e.LLMGatewayPort < 0 {
+			return "-"
+		}
+		return strconv.Itoa(e.LLMGatewayPort)
+	}},
+}
+
+// NewEnginesCommand creates the engines command
+func NewEnginesCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "engines",
+		Short: "List the available agentic engines and their capabilities",
+		Long: `List the agentic engines that can be selected with the engine: frontmatter field,
+with a capability matrix showing which features each engine supports.
+
+Examples:
+  ` + string(constants.CLIExtensionPrefix) + ` engines          # Show the capability matrix
+  ` + string(constants.CLIExtensionPrefix) + ` engines --json   # Output engine capabilities as JSON`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			jsonOutput, _ := cmd.Flags().GetBool("json")
+			return RunEngines(jsonOutput)
+		},
+	}
+
+	addJSONFlag(cmd)
+
+	return cmd
+}
+
+// RunEngines prints the registered engines and their capabilities
+func RunEngines(jsonOutput bool) error {
+	engines := workflow.GetGlobalEngineRegistry().ListEngines()
+	enginesLog.Printf("Listing %d engines", len(engines))
+
+	if jsonOutput {
+		jsonBytes, err := json.MarshalIndent(engines, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal engines: %w", err)
+		}
+		fmt.Println(string(jsonBytes))
+		return nil
+	}
+
+	fmt.Fprint(os.Stderr, console.RenderTable(buildEngineCapabilityTable(engines)))
+	return nil
+}
+
+// buildEngineCapabilityTable builds the capability matrix with one column per engine
+func buildEngineCapabilityTable(engines []workflow.EngineInfo) console.TableConfig {
+	headers := []string{"Capability"}
+	nameRow := []string{"Name"}
+	for _, engine := range engines {
+		headers = append(headers, engine.ID)
+		nameRow = append(nameRow, engine.DisplayName)
+	}
+
+	rows := [][]string{nameRow}
+	for _, capability := range engineCapabilities {
+		row := []string{capability.name}
+		for _, engine := range engines {
+			row = append(row, capability.value(engine))
+		}
+		rows = append(rows, row)
+	}
+
+	return console.TableConfig{
+		Title:   "Agentic Engines",
+		Headers: headers,
+		Rows:    rows,
+	}
+}
+
+// formatCapability formats a capability flag for the matrix
+func formatCapability(supported bool) string {
+	if supported {
+		return "✓"
+	}
+	return "-"
+}
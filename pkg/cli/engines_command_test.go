@@ -0,0 +1,37 @@
+//go:build !integration
+
+package cli
+
+import (
+	"testing"
+
+	"github.com/github/gh-aw/pkg/workflow"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildEngineCapabilityTable(t *testing.T) {
+	engines := []workflow.EngineInfo{
+		{ID: "alpha", DisplayName: "Alpha", SupportsMaxTurns: true, LLMGatewayPort: 10000},
+		{ID: "beta", DisplayName: "Beta", Experimental: true, LLMGatewayPort: -1},
+	}
+
+	table := buildEngineCapabilityTable(engines)
+
+	assert.Equal(t, []string{"Capability", "alpha", "beta"}, table.Headers, "There should be one column per engine")
+	require.Len(t, table.Rows, len(engineCapabilities)+1, "There should be a name row plus one row per capability")
+	assert.Equal(t, []string{"Name", "Alpha", "Beta"}, table.Rows[0], "First row should hold display names")
+
+	rows := make(map[string][]string)
+	for _, row := range table.Rows {
+		rows[row[0]] = row[1:]
+	}
+	assert.Equal(t, []string{"-", "✓"}, rows["Experimental"], "Experimental flag should be shown")
+	assert.Equal(t, []string{"✓", "-"}, rows["Max turns"], "Capability flags should be shown")
+	assert.Equal(t, []string{"10000", "-"}, rows["LLM gateway port"], "Gateway port should be shown when supported")
+}
+
+func TestRunEngines(t *testing.T) {
+	require.NoError(t, RunEngines(false), "Capability matrix should render")
+	require.NoError(t, RunEngines(true), "JSON output should render")
+}
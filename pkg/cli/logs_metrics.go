@@ -158,8 +158,12 @@ func extractLogMetrics(logDir string, verbose bool, workflowPath ...string) (Log
 				metrics.Turns = fileMetrics.Turns
 			}
 
+			// A single truncated log makes the run's metrics partial
+			metrics.Truncated = metrics.Truncated || fileMetrics.Truncated
+
 			// Aggregate tool sequences and tool calls
 			metrics.ToolSequences = append(metrics.ToolSequences, fileMetrics.ToolSequences...)
+			metrics.ToolSequenceTurns = append(metrics.ToolSequenceTurns, fileMetrics.ToolSequenceTurns...)
 			metrics.ToolCalls = append(metrics.ToolCalls, fileMetrics.ToolCalls...)
 		}
 
@@ -0,0 +1,269 @@
+package cli
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/console"
+	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/workflow"
+	"github.com/spf13/cobra"
+)
+
+var replayLog = logger.New("cli:replay_command")
+
+// ReplayConfig holds configuration for the replay command
+type ReplayConfig struct {
+	WorkflowFile string // Workflow whose safe-outputs configuration is used
+	OutputsFile  string // Captured safe outputs (JSONL, one item per line)
+	ActionsDir   string // Directory containing the safe output handler scripts
+	DryRun       bool   // Run the handlers in staged mode, previewing outputs without applying them
+	Verbose      bool
+}
+
+// capturedOutput is a single safe output item read from the captured outputs file
+type capturedOutput struct {
+	line int
+	item map[string]any
+}
+
+// NewReplayCommand creates the replay command
+func NewReplayCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "replay <workflow> <outputs.jsonl>",
+		Short: "Re-run safe output handlers against captured agent outputs",
+		Long: `Re-run the safe output handlers of a workflow against a captured outputs file,
+without re-running the agent.
+
+The outputs file is the JSONL safe outputs file written by the agent (one JSON
+object with a "type" field per line). The items are processed by the safe output
+handler manager with Node.js from --actions-dir (actions/setup/js of a gh-aw
+checkout with its npm dependencies installed), using the same handler
+configuration as the compiled "Process Safe Outputs" step. GITHUB_TOKEN and
+GITHUB_REPOSITORY must be set.
+
+With --dry-run, the handlers run in staged mode (GH_AW_SAFE_OUTPUTS_STAGED=true):
+they report what they would do, honoring each handler's max, and make no changes.
+The previews are printed from the step summary.
+
+Max values and handler if conditions that are GitHub Actions expressions are only
+evaluated inside a workflow run. Set the corresponding environment variable (for
+example GH_AW_SAFE_OUTPUTS_MAX_CREATE_ISSUE) to the evaluated value to replay them.
+
+Examples:
+  ` + string(constants.CLIExtensionPrefix) + ` replay my-workflow outputs.jsonl --actions-dir ../gh-aw/actions/setup/js --dry-run
+  ` + string(constants.CLIExtensionPrefix) + ` replay .github/workflows/triage.md outputs.jsonl --actions-dir ../gh-aw/actions/setup/js`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			actionsDir, _ := cmd.Flags().GetString("actions-dir")
+			dryRun, _ := cmd.Flags().GetBool("dry-run")
+			verbose, _ := cmd.Flags().GetBool("verbose")
+
+			return RunReplay(ReplayConfig{
+				WorkflowFile: args[0],
+				OutputsFile:  args[1],
+				ActionsDir:   actionsDir,
+				DryRun:       dryRun,
+				Verbose:      verbose,
+			})
+		},
+	}
+
+	cmd.Flags().String("actions-dir", "", "Directory containing the safe output handler scripts (actions/setup/js in a gh-aw checkout)")
+	_ = cmd.MarkFlagRequired("actions-dir")
+	cmd.Flags().Bool("dry-run", false, "Run the handlers in staged mode to preview the outputs without applying them")
+
+	return cmd
+}
+
+// RunReplay executes the replay command with the given configuration
+func RunReplay(config ReplayConfig) error {
+	replayLog.Printf("Running replay: workflow=%s, outputs=%s, dryRun=%v", config.WorkflowFile, config.OutputsFile, config.DryRun)
+
+	workflowPath, err := resolveWorkflowFile(config.WorkflowFile, config.Verbose)
+	if err != nil {
+		return err
+	}
+
+	workflowData, err := workflow.NewCompiler().ParseWorkflowFile(workflowPath)
+	if err != nil {
+		return fmt.Errorf("failed to parse workflow: %w", err)
+	}
+	if workflowData.SafeOutputs == nil {
+		return fmt.Errorf("workflow %s has no safe-outputs configured", filepath.Base(workflowPath))
+	}
+	handlerConfig := workflow.BuildHandlerManagerConfig(workflowData.SafeOutputs)
+	handlerEnv, err := resolveReplayHandlerEnv(workflow.BuildHandlerManagerEnv(workflowData.SafeOutputs))
+	if err != nil {
+		return err
+	}
+
+	outputs, err := readCapturedOutputs(config.OutputsFile)
+	if err != nil {
+		return err
+	}
+
+	return runHandlerManager(config, outputs, handlerConfig, handlerEnv)
+}
+
+// resolveReplayHandlerEnv returns the handler manager environment for a replay. Values that
+// are GitHub Actions expressions (max expressions and handler if conditions) cannot be
+// evaluated locally, so they are taken from the caller's environment and must be set there.
+func resolveReplayHandlerEnv(handlerEnv map[string]string) (map[string]string, error) {
+	resolved := make(map[string]string, len(handlerEnv))
+	var missing []string
+	for name, value := range handlerEnv {
+		if !strings.Contains(value, "${{") {
+			resolved[name] = value
+			continue
+		}
+		if envValue, ok := os.LookupEnv(name); ok {
+			resolved[name] = envValue
+			continue
+		}
+		missing = append(missing, fmt.Sprintf("  %s (evaluates %s)", name, value))
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return nil, fmt.Errorf("the workflow's safe-outputs use GitHub Actions expressions that can only be evaluated in a workflow run. Set these environment variables to the evaluated values:\n%s", strings.Join(missing, "\n"))
+	}
+	return resolved, nil
+}
+
+// readCapturedOutputs reads the captured safe outputs JSONL file, skipping blank lines
+func readCapturedOutputs(path string) ([]capturedOutput, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open outputs file: %w", err)
+	}
+	defer file.Close()
+
+	var outputs []capturedOutput
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		var item map[string]any
+		if err := json.Unmarshal([]byte(line), &item); err != nil {
+			return nil, fmt.Errorf("invalid JSON on line %d of %s: %w", lineNum, path, err)
+		}
+		outputs = append(outputs, capturedOutput{line: lineNum, item: item})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read outputs file: %w", err)
+	}
+
+	replayLog.Printf("Read %d captured outputs from %s", len(outputs), path)
+	return outputs, nil
+}
+
+// runHandlerManager runs the safe output handler manager with Node.js against the captured
+// outputs. In dry-run mode the handlers run staged and their previews are printed.
+func runHandlerManager(config ReplayConfig, outputs []capturedOutput, handlerConfig map[string]map[string]any, handlerEnv map[string]string) error {
+	actionsDir := config.ActionsDir
+	managerScript := filepath.Join(actionsDir, "safe_output_handler_manager.cjs")
+	if _, err := os.Stat(managerScript); err != nil {
+		return fmt.Errorf("safe output handler manager not found in %s. Use --actions-dir to point at actions/setup/js in a gh-aw checkout", actionsDir)
+	}
+	if os.Getenv("GITHUB_TOKEN") == "" || os.Getenv("GITHUB_REPOSITORY") == "" {
+		return fmt.Errorf("GITHUB_TOKEN and GITHUB_REPOSITORY must be set to replay safe outputs")
+	}
+
+	tempDir, err := os.MkdirTemp("", "gh-aw-replay")
+	if err != nil {
+		return fmt.Errorf("failed to create temp dir: %w", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	// The handler manager reads the validated agent output format ({"items": [...]})
+	items := make([]map[string]any, 0, len(outputs))
+	for _, output := range outputs {
+		items = append(items, output.item)
+	}
+	agentOutput, err := json.Marshal(map[string]any{"items": items, "errors": []string{}})
+	if err != nil {
+		return fmt.Errorf("failed to marshal agent output: %w", err)
+	}
+	agentOutputFile := filepath.Join(tempDir, "agent_output.json")
+	if err := os.WriteFile(agentOutputFile, agentOutput, 0644); err != nil {
+		return fmt.Errorf("failed to write agent output: %w", err)
+	}
+
+	configJSON, err := json.Marshal(handlerConfig)
+	if err != nil {
+		return fmt.Errorf("failed to marshal handler config: %w", err)
+	}
+
+	// Provide the globals that actions/github-script injects into the handler scripts
+	nodeScript := `
+const core = require("@actions/core");
+const github = require("@actions/github");
+global.core = core;
+global.github = github.getOctokit(process.env.GITHUB_TOKEN);
+global.context = github.context;
+global.exec = require("@actions/exec");
+global.io = require("@actions/io");
+require("./safe_output_handler_manager.cjs").main().catch(error => {
+  core.setFailed(error instanceof Error ? error.message : String(error));
+});
+`
+
+	absActionsDir, err := filepath.Abs(actionsDir)
+	if err != nil {
+		return fmt.Errorf("failed to resolve actions dir: %w", err)
+	}
+	summaryFile := filepath.Join(tempDir, "summary.md")
+	if err := os.WriteFile(summaryFile, nil, 0644); err != nil {
+		return fmt.Errorf("failed to create step summary file: %w", err)
+	}
+
+	cmd := exec.Command("node", "-e", nodeScript)
+	cmd.Dir = absActionsDir
+	cmd.Env = append(os.Environ(),
+		"GH_AW_AGENT_OUTPUT="+agentOutputFile,
+		"GH_AW_SAFE_OUTPUTS_HANDLER_CONFIG="+string(configJSON),
+		"GITHUB_STEP_SUMMARY="+summaryFile,
+	)
+	for name, value := range handlerEnv {
+		cmd.Env = append(cmd.Env, name+"="+value)
+	}
+	if config.DryRun {
+		cmd.Env = append(cmd.Env, "GH_AW_SAFE_OUTPUTS_STAGED=true")
+	}
+	cmd.Stdout = os.Stderr
+	cmd.Stderr = os.Stderr
+
+	replayLog.Printf("Running handler manager in %s: dryRun=%v", absActionsDir, config.DryRun)
+	if config.Verbose {
+		fmt.Fprintln(os.Stderr, console.FormatVerboseMessage(fmt.Sprintf("Running safe output handler manager from %s", absActionsDir)))
+	}
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("safe output handler manager failed: %w", err)
+	}
+
+	if config.DryRun {
+		// Staged handlers write their previews to the step summary
+		summary, err := os.ReadFile(summaryFile)
+		if err != nil {
+			return fmt.Errorf("failed to read staged previews: %w", err)
+		}
+		fmt.Print(string(summary))
+		fmt.Fprintln(os.Stderr, console.FormatSuccessMessage(fmt.Sprintf("Previewed %d outputs (dry run, no changes made)", len(outputs))))
+		return nil
+	}
+
+	fmt.Fprintln(os.Stderr, console.FormatSuccessMessage(fmt.Sprintf("Replayed %d outputs", len(outputs))))
+	return nil
+}
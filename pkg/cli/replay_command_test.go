@@ -0,0 +1,130 @@
+//go:build !integration
+
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const replayTestWorkflow = `---
+on: workflow_dispatch
+permissions:
+  contents: read
+engine: copilot
+safe-outputs:
+  create-issue:
+    max: 1
+  add-comment:
+---
+
+# Triage
+`
+
+const replayTestOutputs = `{"type": "create_issue", "title": "First", "body": "one"}
+{"type": "create-issue", "title": "Second", "body": "two"}
+
+{"type": "add_comment", "body": "hello"}
+{"type": "update_issue", "title": "nope"}
+{"body": "untyped"}
+`
+
+func writeReplayFixtures(t *testing.T) (string, string) {
+	t.Helper()
+	tmpDir := testutil.TempDir(t, "replay-test")
+	workflowFile := filepath.Join(tmpDir, "triage.md")
+	outputsFile := filepath.Join(tmpDir, "outputs.jsonl")
+	require.NoError(t, os.WriteFile(workflowFile, []byte(replayTestWorkflow), 0644), "Failed to write workflow")
+	require.NoError(t, os.WriteFile(outputsFile, []byte(replayTestOutputs), 0644), "Failed to write outputs")
+	return workflowFile, outputsFile
+}
+
+func TestReadCapturedOutputsSkipsBlankLines(t *testing.T) {
+	_, outputsFile := writeReplayFixtures(t)
+
+	outputs, err := readCapturedOutputs(outputsFile)
+	require.NoError(t, err, "Outputs should be read")
+	require.Len(t, outputs, 5, "Blank lines should be skipped")
+	assert.Equal(t, 4, outputs[2].line, "Items should keep their line numbers")
+}
+
+func TestReadCapturedOutputsInvalidJSON(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "replay-invalid-test")
+	outputsFile := filepath.Join(tmpDir, "outputs.jsonl")
+	require.NoError(t, os.WriteFile(outputsFile, []byte("{\"type\": \"noop\"}\nnot json\n"), 0644), "Failed to write outputs")
+
+	_, err := readCapturedOutputs(outputsFile)
+	require.Error(t, err, "Invalid JSON should fail")
+	assert.Contains(t, err.Error(), "invalid JSON on line 2", "Error should name the offending line")
+}
+
+func TestRunReplayDryRunRunsHandlerManager(t *testing.T) {
+	workflowFile, outputsFile := writeReplayFixtures(t)
+
+	err := RunReplay(ReplayConfig{
+		WorkflowFile: workflowFile,
+		OutputsFile:  outputsFile,
+		ActionsDir:   filepath.Join(t.TempDir(), "missing"),
+		DryRun:       true,
+	})
+	require.Error(t, err, "Dry run should run the real handler manager")
+	assert.Contains(t, err.Error(), "safe output handler manager not found", "Error should explain the missing scripts")
+}
+
+func TestRunReplayRequiresExpressionValues(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "replay-expression-test")
+	workflowFile := filepath.Join(tmpDir, "triage.md")
+	outputsFile := filepath.Join(tmpDir, "outputs.jsonl")
+	content := "---\non: workflow_dispatch\npermissions:\n  contents: read\nengine: copilot\nsafe-outputs:\n  create-issue:\n    max: ${{ vars.MAX_ISSUES }}\n---\n\n# Triage\n"
+	require.NoError(t, os.WriteFile(workflowFile, []byte(content), 0644), "Failed to write workflow")
+	require.NoError(t, os.WriteFile(outputsFile, []byte(replayTestOutputs), 0644), "Failed to write outputs")
+
+	err := RunReplay(ReplayConfig{
+		WorkflowFile: workflowFile,
+		OutputsFile:  outputsFile,
+		ActionsDir:   filepath.Join(t.TempDir(), "missing"),
+		DryRun:       true,
+	})
+	require.Error(t, err, "Max expressions cannot be evaluated locally")
+	assert.Contains(t, err.Error(), "GH_AW_SAFE_OUTPUTS_MAX_CREATE_ISSUE (evaluates ${{ vars.MAX_ISSUES }})", "Error should name the variable to set")
+
+	t.Setenv("GH_AW_SAFE_OUTPUTS_MAX_CREATE_ISSUE", "2")
+	err = RunReplay(ReplayConfig{
+		WorkflowFile: workflowFile,
+		OutputsFile:  outputsFile,
+		ActionsDir:   filepath.Join(t.TempDir(), "missing"),
+		DryRun:       true,
+	})
+	require.Error(t, err, "Replay should still need the handler scripts")
+	assert.Contains(t, err.Error(), "safe output handler manager not found", "Evaluated max values should be accepted")
+}
+
+func TestResolveReplayHandlerEnv(t *testing.T) {
+	t.Setenv("GH_AW_SAFE_OUTPUTS_IF_ADD_COMMENT", "true")
+
+	env, err := resolveReplayHandlerEnv(map[string]string{
+		"GH_AW_SAFE_OUTPUTS_IF_ADD_COMMENT":   "${{ github.event_name == 'issues' }}",
+		"GH_AW_SAFE_OUTPUTS_MAX_CREATE_ISSUE": "5",
+	})
+	require.NoError(t, err, "Expressions with values in the environment should resolve")
+	assert.Equal(t, map[string]string{
+		"GH_AW_SAFE_OUTPUTS_IF_ADD_COMMENT":   "true",
+		"GH_AW_SAFE_OUTPUTS_MAX_CREATE_ISSUE": "5",
+	}, env, "Literal values should pass through and expressions should come from the environment")
+}
+
+func TestRunReplayWithoutSafeOutputs(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "replay-no-safe-outputs-test")
+	workflowFile := filepath.Join(tmpDir, "plain.md")
+	content := "---\non: workflow_dispatch\npermissions:\n  contents: read\nengine: copilot\n---\n\n# Plain\n"
+	require.NoError(t, os.WriteFile(workflowFile, []byte(content), 0644), "Failed to write workflow")
+
+	err := RunReplay(ReplayConfig{WorkflowFile: workflowFile, OutputsFile: filepath.Join(tmpDir, "outputs.jsonl"), ActionsDir: tmpDir, DryRun: true})
+	require.Error(t, err, "Workflows without safe-outputs cannot be replayed")
+	assert.Contains(t, err.Error(), "has no safe-outputs configured", "Error should explain why")
+}
@@ -0,0 +1,104 @@
+package cli
+
+import (
+	"encoding/json"
+	"slices"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var runReportLog = logger.New("cli:run_report")
+
+// RunReport is a stable, machine-readable summary of a workflow run built from its log
+// metrics, jobs and cost. Every field is always serialized: a run without metrics reports
+// zeros and empty lists instead of omitting fields, so jq filters never see null.
+type RunReport struct {
+	RunID           int64               `json:"run_id"`
+	WorkflowName    string              `json:"workflow_name"`
+	Status          string              `json:"status"`
+	Conclusion      string              `json:"conclusion"`
+	DurationSeconds float64             `json:"duration_seconds"`
+	TokenUsage      int                 `json:"token_usage"`
+	Turns           int                 `json:"turns"`
+	EstimatedCost   float64             `json:"estimated_cost"`
+	Truncated       bool                `json:"truncated"` // Agent log ended early; metrics are partial
+	ToolCalls       []RunReportToolCall `json:"tool_calls"`
+	Jobs            []RunReportJob      `json:"jobs"`
+}
+
+// RunReportToolCall summarizes the calls to a single tool
+type RunReportToolCall struct {
+	Name               string  `json:"name"`
+	CallCount          int     `json:"call_count"`
+	MaxInputSize       int     `json:"max_input_size"`
+	MaxOutputSize      int     `json:"max_output_size"`
+	MaxDurationSeconds float64 `json:"max_duration_seconds"`
+}
+
+// RunReportJob summarizes a job of the run, in the order the jobs were reported
+type RunReportJob struct {
+	Name            string  `json:"name"`
+	Status          string  `json:"status"`
+	Conclusion      string  `json:"conclusion"`
+	DurationSeconds float64 `json:"duration_seconds"`
+}
+
+// buildRunReport assembles the run report from a processed run and its log metrics.
+// Token usage, turns and cost come from the run, matching the audit metrics section.
+func buildRunReport(processedRun ProcessedRun, metrics LogMetrics) RunReport {
+	run := processedRun.Run
+	report := RunReport{
+		RunID:           run.DatabaseID,
+		WorkflowName:    run.WorkflowName,
+		Status:          run.Status,
+		Conclusion:      run.Conclusion,
+		DurationSeconds: run.Duration.Seconds(),
+		TokenUsage:      run.TokenUsage,
+		Turns:           run.Turns,
+		EstimatedCost:   run.EstimatedCost,
+		Truncated:       metrics.Truncated,
+		ToolCalls:       make([]RunReportToolCall, 0, len(metrics.ToolCalls)),
+		Jobs:            make([]RunReportJob, 0, len(processedRun.JobDetails)),
+	}
+
+	for _, toolCall := range metrics.ToolCalls {
+		report.ToolCalls = append(report.ToolCalls, RunReportToolCall{
+			Name:               toolCall.Name,
+			CallCount:          toolCall.CallCount,
+			MaxInputSize:       toolCall.MaxInputSize,
+			MaxOutputSize:      toolCall.MaxOutputSize,
+			MaxDurationSeconds: toolCall.MaxDuration.Seconds(),
+		})
+	}
+	slices.SortFunc(report.ToolCalls, func(a, b RunReportToolCall) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	for _, job := range processedRun.JobDetails {
+		report.Jobs = append(report.Jobs, RunReportJob{
+			Name:            job.Name,
+			Status:          job.Status,
+			Conclusion:      job.Conclusion,
+			DurationSeconds: job.Duration.Seconds(),
+		})
+	}
+
+	runReportLog.Printf("Built run report for run %d: tokens=%d, turns=%d, tools=%d, jobs=%d",
+		report.RunID, report.TokenUsage, report.Turns, len(report.ToolCalls), len(report.Jobs))
+	return report
+}
+
+// MarshalJSON serializes the report with empty lists in place of nil ones, so a zero
+// RunReport still has every field
+func (r RunReport) MarshalJSON() ([]byte, error) {
+	type runReportFields RunReport // drops the MarshalJSON method to avoid recursion
+	fields := runReportFields(r)
+	if fields.ToolCalls == nil {
+		fields.ToolCalls = []RunReportToolCall{}
+	}
+	if fields.Jobs == nil {
+		fields.Jobs = []RunReportJob{}
+	}
+	return json.Marshal(fields)
+}
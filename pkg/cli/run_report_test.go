@@ -0,0 +1,87 @@
+//go:build !integration
+
+package cli
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/github/gh-aw/pkg/workflow"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunReportJSON(t *testing.T) {
+	processedRun := createTestProcessedRun(func(pr *ProcessedRun) {
+		pr.Run.TokenUsage = 25000
+		pr.Run.Turns = 8
+		pr.Run.EstimatedCost = 0.75
+		pr.JobDetails = []JobInfoWithDuration{
+			{JobInfo: JobInfo{Name: "activation", Status: "completed", Conclusion: "success"}, Duration: 10 * time.Second},
+			{JobInfo: JobInfo{Name: "agent", Status: "completed", Conclusion: "success"}, Duration: 3 * time.Minute},
+		}
+	})
+	metrics := workflow.LogMetrics{
+		TokenUsage: 25000,
+		Turns:      8,
+		ToolCalls: []workflow.ToolCallInfo{
+			{Name: "github::list_issues", CallCount: 3, MaxDuration: 2 * time.Second},
+			{Name: "bash", CallCount: 15, MaxInputSize: 500, MaxOutputSize: 2000},
+		},
+	}
+
+	data, err := json.Marshal(buildAuditData(processedRun, metrics, nil))
+	require.NoError(t, err, "Audit data should marshal")
+
+	var parsed struct {
+		Report map[string]any `json:"report"`
+	}
+	require.NoError(t, json.Unmarshal(data, &parsed), "Audit JSON should be valid")
+	report := parsed.Report
+	require.NotNil(t, report, "Audit JSON should contain the run report")
+
+	assert.InDelta(t, 25000, report["token_usage"], 0, "Report should contain token usage")
+	assert.InDelta(t, 8, report["turns"], 0, "Report should contain turns")
+	assert.InDelta(t, 0.75, report["estimated_cost"], 0.0001, "Report should contain the estimated cost")
+
+	toolCalls, ok := report["tool_calls"].([]any)
+	require.True(t, ok, "tool_calls should be a list")
+	require.Len(t, toolCalls, 2, "Report should contain every tool")
+	firstTool := toolCalls[0].(map[string]any)
+	assert.Equal(t, "bash", firstTool["name"], "Tool calls should be sorted by name")
+	assert.InDelta(t, 15, firstTool["call_count"], 0, "Tool call should contain the call count")
+	assert.InDelta(t, 2, toolCalls[1].(map[string]any)["max_duration_seconds"], 0, "Tool call should contain the max duration")
+
+	jobs, ok := report["jobs"].([]any)
+	require.True(t, ok, "jobs should be a list")
+	require.Len(t, jobs, 2, "Report should contain every job")
+	assert.Equal(t, "agent", jobs[1].(map[string]any)["name"], "Jobs should keep their order")
+	assert.InDelta(t, 180, jobs[1].(map[string]any)["duration_seconds"], 0, "Job should contain its duration")
+}
+
+func TestRunReportWithoutMetrics(t *testing.T) {
+	tests := []struct {
+		name   string
+		report RunReport
+	}{
+		{name: "built from a run without metrics", report: buildRunReport(ProcessedRun{}, workflow.LogMetrics{})},
+		{name: "zero value", report: RunReport{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(tt.report)
+			require.NoError(t, err, "Report should marshal")
+
+			var fields map[string]any
+			require.NoError(t, json.Unmarshal(data, &fields), "Report JSON should be valid")
+			for _, key := range []string{"run_id", "workflow_name", "status", "conclusion", "duration_seconds", "token_usage", "turns", "estimated_cost", "truncated"} {
+				assert.Contains(t, fields, key, "Zeroed field %s should not be omitted", key)
+			}
+			assert.InDelta(t, 0, fields["token_usage"], 0, "Token usage should be zero")
+			assert.Equal(t, []any{}, fields["tool_calls"], "tool_calls should be an empty list, not null")
+			assert.Equal(t, []any{}, fields["jobs"], "jobs should be an empty list, not null")
+		})
+	}
+}
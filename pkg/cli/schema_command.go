@@ -0,0 +1,64 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/parser"
+	"github.com/spf13/cobra"
+)
+
+var schemaLog = logger.New("cli:schema_command")
+
+// NewSchemaCommand creates the schema command
+func NewSchemaCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "schema",
+		Short: "Print the JSON Schema for workflow frontmatter",
+		Long: `Print the JSON Schema describing the frontmatter of agentic workflow files.
+
+This is the same schema the compiler validates frontmatter against. Point
+$schema-aware editors at the output to get completion and validation while
+editing workflows.
+
+Examples:
+  ` + string(constants.CLIExtensionPrefix) + ` schema                                  # Print the schema to stdout
+  ` + string(constants.CLIExtensionPrefix) + ` schema -o .github/aw/schema.json        # Write the schema to a file`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			outputPath, _ := cmd.Flags().GetString("output")
+			if outputPath == "" {
+				return RunSchema(os.Stdout)
+			}
+
+			file, err := os.Create(outputPath)
+			if err != nil {
+				return fmt.Errorf("failed to create schema file: %w", err)
+			}
+			defer file.Close()
+			return RunSchema(file)
+		},
+	}
+
+	cmd.Flags().StringP("output", "o", "", "Write the schema to a file instead of stdout")
+
+	return cmd
+}
+
+// RunSchema writes the frontmatter JSON Schema to out
+func RunSchema(out io.Writer) error {
+	schemaLog.Print("Exporting frontmatter schema")
+
+	schemaJSON, err := parser.ExportMainWorkflowSchema()
+	if err != nil {
+		return err
+	}
+
+	if _, err := out.Write(schemaJSON); err != nil {
+		return fmt.Errorf("failed to write schema: %w", err)
+	}
+	return nil
+}
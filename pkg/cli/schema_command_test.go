@@ -0,0 +1,40 @@
+//go:build !integration
+
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunSchema(t *testing.T) {
+	var out bytes.Buffer
+	require.NoError(t, RunSchema(&out), "Schema export should succeed")
+
+	var schemaDoc map[string]any
+	require.NoError(t, json.Unmarshal(out.Bytes(), &schemaDoc), "Output should be valid JSON")
+
+	properties, ok := schemaDoc["properties"].(map[string]any)
+	require.True(t, ok, "Schema should declare properties")
+	for _, key := range []string{"on", "engine", "tools", "safe-outputs", "network", "permissions"} {
+		assert.Contains(t, properties, key, "Schema should describe %s", key)
+	}
+}
+
+func TestSchemaCommandOutputFile(t *testing.T) {
+	outputPath := filepath.Join(t.TempDir(), "schema.json")
+
+	cmd := NewSchemaCommand()
+	cmd.SetArgs([]string{"--output", outputPath})
+	require.NoError(t, cmd.Execute(), "Schema command should succeed")
+
+	content, err := os.ReadFile(outputPath)
+	require.NoError(t, err, "Schema file should be written")
+	assert.True(t, json.Valid(content), "Schema file should contain valid JSON")
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"slices"
 	"strings"
 
 	"github.com/github/gh-aw/pkg/console"
@@ -186,6 +187,11 @@ func runTokensBootstrap(engine, owner, repo string) error {
 		secretsLog.Printf("Checking all recommended tokens: count=%d", len(tokensToCheck))
 	}
 
+	// Warn about repository variables the engine reads (for example its default model)
+	if engine != "" {
+		warnMissingEngineVariables(engine, repoSlug)
+	}
+
 	missing := make([]tokenSpec, 0, len(tokensToCheck))
 
 	for _, spec := range tokensToCheck {
@@ -285,6 +291,63 @@ func checkSecretExistsInRepo(secretName, repoSlug string) (bool, error) {
 	return false, nil
 }
 
+// getRequiredVarNamesForEngine returns the repository variables read by the agent and
+// threat detection jobs of the given engine
+func getRequiredVarNamesForEngine(engineID string) []string {
+	engine, err := workflow.GetGlobalEngineRegistry().GetEngine(engineID)
+	if err != nil {
+		tokensBootstrapLog.Printf("Unknown engine %s, no repository variables to check", engineID)
+		return nil
+	}
+
+	var varNames []string
+	agentData := &workflow.WorkflowData{SafeOutputs: &workflow.SafeOutputsConfig{}}
+	detectionData := &workflow.WorkflowData{}
+	for _, name := range append(engine.GetRequiredVarNames(agentData), engine.GetRequiredVarNames(detectionData)...) {
+		if !slices.Contains(varNames, name) {
+			varNames = append(varNames, name)
+		}
+	}
+	return varNames
+}
+
+// warnMissingEngineVariables prints a warning for each repository variable read by the
+// engine that is not set in the repository. Variables are optional, so this never fails.
+func warnMissingEngineVariables(engine, repoSlug string) {
+	varNames := getRequiredVarNamesForEngine(engine)
+	if len(varNames) == 0 {
+		return
+	}
+
+	output, err := workflow.RunGH("Listing variables...", "variable", "list", "--repo", repoSlug, "--json", "name")
+	if err != nil {
+		tokensBootstrapLog.Printf("Failed to list repository variables: %v", err)
+		fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("Unable to inspect repository variables in %s, skipping variable check", repoSlug)))
+		return
+	}
+
+	var variables []struct {
+		Name string `json:"name"`
+	}
+	if err := json.Unmarshal(output, &variables); err != nil {
+		tokensBootstrapLog.Printf("Failed to parse repository variables: %v", err)
+		return
+	}
+	existing := make(map[string]bool, len(variables))
+	for _, variable := range variables {
+		existing[variable.Name] = true
+	}
+
+	parts := splitRepoSlug(repoSlug)
+	for _, name := range varNames {
+		if existing[name] {
+			continue
+		}
+		fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("Repository variable %s is not set; %s workflows without engine.model use the engine's default model", name, engine)))
+		fmt.Fprintln(os.Stderr, console.FormatCommandMessage(fmt.Sprintf("gh variable set %s --repo %s/%s --body <model>", name, parts[0], parts[1])))
+	}
+}
+
 // splitRepoSlug splits "owner/repo" into [owner, repo]
 // Uses repoutil.SplitRepoSlug internally but provides backward-compatible array return
 func splitRepoSlug(slug string) [2]string {
@@ -0,0 +1,29 @@
+//go:build !integration
+
+package cli
+
+import (
+	"testing"
+
+	"github.com/github/gh-aw/pkg/constants"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetRequiredVarNamesForEngine(t *testing.T) {
+	tests := []struct {
+		engine   string
+		expected []string
+	}{
+		{engine: "copilot", expected: []string{constants.EnvVarModelAgentCopilot, constants.EnvVarModelDetectionCopilot}},
+		{engine: "claude", expected: []string{constants.EnvVarModelAgentClaude, constants.EnvVarModelDetectionClaude}},
+		{engine: "codex", expected: []string{constants.EnvVarModelAgentCodex, constants.EnvVarModelDetectionCodex}},
+		{engine: "copilot-sdk", expected: nil},
+		{engine: "unknown", expected: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.engine, func(t *testing.T) {
+			assert.Equal(t, tt.expected, getRequiredVarNamesForEngine(tt.engine), "Repository variables should match the engine")
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/github/gh-aw/pkg/console"
+	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/stringutil"
+	"github.com/github/gh-aw/pkg/workflow"
+	"github.com/pmezard/go-difflib/difflib"
+	"github.com/spf13/cobra"
+)
+
+var verifyLog = logger.New("cli:verify_command")
+
+// lockFileStatus describes how a committed lock file compares to its recompiled source
+type lockFileStatus string
+
+const (
+	lockFileUpToDate     lockFileStatus = "up-to-date"
+	lockFileModified     lockFileStatus = "modified"
+	lockFileNeedsCompile lockFileStatus = "needs-compile"
+)
+
+// NewVerifyCommand creates the verify command
+func NewVerifyCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "verify <workflow>...",
+		Short: "Verify that lock files match their workflow sources",
+		Long: `Verify that each workflow's committed .lock.yml file matches its markdown source.
+
+Each workflow is recompiled in memory and compared against its lock file after
+normalizing trailing whitespace. No files are written. A lock file that was
+edited by hand is reported with a unified diff, and a missing lock file is
+reported as needing compilation. The command exits with an error if any lock
+file does not match.
+
+Examples:
+  ` + string(constants.CLIExtensionPrefix) + ` verify my-workflow
+  ` + string(constants.CLIExtensionPrefix) + ` verify .github/workflows/ci-doctor.md
+  ` + string(constants.CLIExtensionPrefix) + ` verify issue-triage ci-doctor`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			verbose, _ := cmd.Flags().GetBool("verbose")
+			return RunVerify(args, verbose)
+		},
+	}
+
+	return cmd
+}
+
+// RunVerify recompiles each workflow in memory and compares the result against its
+// committed lock file, printing a diff for every lock file that does not match
+func RunVerify(workflows []string, verbose bool) error {
+	verifyLog.Printf("Verifying %d workflow(s)", len(workflows))
+
+	compiler := createAndConfigureCompiler(CompileConfig{Verbose: verbose})
+
+	var failedCount int
+	for _, workflowArg := range workflows {
+		markdownFile, err := resolveWorkflowFile(workflowArg, verbose)
+		if err != nil {
+			return err
+		}
+
+		setWorkflowIdentifier(compiler, markdownFile)
+
+		status, diff, err := verifyLockFile(compiler, markdownFile)
+		if err != nil {
+			return err
+		}
+
+		lockFile := console.ToRelativePath(stringutil.MarkdownToLockFile(markdownFile))
+		switch status {
+		case lockFileUpToDate:
+			fmt.Fprintln(os.Stderr, console.FormatSuccessMessage(fmt.Sprintf("%s matches %s", lockFile, console.ToRelativePath(markdownFile))))
+		case lockFileNeedsCompile:
+			failedCount++
+			fmt.Fprintln(os.Stderr, console.FormatErrorMessage(fmt.Sprintf("%s does not exist (needs compile)", lockFile)))
+		case lockFileModified:
+			failedCount++
+			fmt.Fprintln(os.Stderr, console.FormatErrorMessage(fmt.Sprintf("%s does not match %s", lockFile, console.ToRelativePath(markdownFile))))
+			fmt.Print(diff)
+		}
+	}
+
+	if failedCount > 0 {
+		return fmt.Errorf("%d lock file(s) do not match their workflow source; run '%s compile' to regenerate them", failedCount, constants.CLIExtensionPrefix)
+	}
+	return nil
+}
+
+// verifyLockFile compiles a workflow in memory and compares it against its lock file with
+// whitespace normalized. Returns the status and, when the lock file differs, a unified diff
+// of the normalized content.
+func verifyLockFile(compiler *workflow.Compiler, markdownFile string) (lockFileStatus, string, error) {
+	compiled, err := compiler.CompileWorkflowDryRun(markdownFile)
+	if err != nil {
+		return "", "", err
+	}
+
+	lockFile := stringutil.MarkdownToLockFile(markdownFile)
+	content, err := os.ReadFile(lockFile)
+	if os.IsNotExist(err) {
+		verifyLog.Printf("Lock file does not exist: %s", lockFile)
+		return lockFileNeedsCompile, "", nil
+	}
+	if err != nil {
+		return "", "", fmt.Errorf("failed to read lock file %s: %w", lockFile, err)
+	}
+
+	existing := stringutil.NormalizeWhitespace(string(content))
+	expected := stringutil.NormalizeWhitespace(compiled)
+	if existing == expected {
+		return lockFileUpToDate, "", nil
+	}
+
+	verifyLog.Printf("Lock file differs from compiled output: %s", lockFile)
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        splitDiffLines(existing),
+		B:        splitDiffLines(expected),
+		FromFile: console.ToRelativePath(lockFile),
+		ToFile:   console.ToRelativePath(markdownFile) + " (compiled)",
+		Context:  3,
+	})
+	if err != nil {
+		return "", "", fmt.Errorf("failed to diff lock file %s: %w", lockFile, err)
+	}
+	return lockFileModified, diff, nil
+}
@@ -0,0 +1,108 @@
+//go:build !integration
+
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/stringutil"
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/github/gh-aw/pkg/workflow"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const verifyTestWorkflow = `---
+on: push
+permissions:
+  contents: read
+engine: copilot
+---
+
+# Verify Test Workflow
+
+Summarize the latest changes.
+`
+
+func TestVerifyLockFile(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "verify-lock-file")
+	markdownFile := filepath.Join(tmpDir, "verify.md")
+	require.NoError(t, os.WriteFile(markdownFile, []byte(verifyTestWorkflow), 0644), "Failed to write workflow")
+	lockFile := stringutil.MarkdownToLockFile(markdownFile)
+
+	t.Run("missing lock file needs compile", func(t *testing.T) {
+		status, diff, err := verifyLockFile(workflow.NewCompiler(), markdownFile)
+		require.NoError(t, err, "Verify should succeed when the lock file does not exist")
+		assert.Equal(t, lockFileNeedsCompile, status, "Missing lock file should need compile")
+		assert.Empty(t, diff, "No diff should be produced for a missing lock file")
+
+		_, statErr := os.Stat(lockFile)
+		assert.True(t, os.IsNotExist(statErr), "Verify should not create the lock file")
+	})
+
+	require.NoError(t, workflow.NewCompiler().CompileWorkflow(markdownFile), "Workflow should compile")
+	original, err := os.ReadFile(lockFile)
+	require.NoError(t, err, "Lock file should exist")
+
+	t.Run("compiled lock file is up to date", func(t *testing.T) {
+		status, diff, err := verifyLockFile(workflow.NewCompiler(), markdownFile)
+		require.NoError(t, err, "Verify should succeed")
+		assert.Equal(t, lockFileUpToDate, status, "Freshly compiled lock file should match")
+		assert.Empty(t, diff, "Matching lock file should produce no diff")
+	})
+
+	t.Run("whitespace-only changes are ignored", func(t *testing.T) {
+		padded := strings.Replace(string(original), "\n", "  \n", 5) + "\n\n"
+		require.NoError(t, os.WriteFile(lockFile, []byte(padded), 0644), "Failed to write lock file")
+
+		status, _, err := verifyLockFile(workflow.NewCompiler(), markdownFile)
+		require.NoError(t, err, "Verify should succeed")
+		assert.Equal(t, lockFileUpToDate, status, "Trailing whitespace should be normalized before comparing")
+	})
+
+	t.Run("hand-edited lock file fails with diff", func(t *testing.T) {
+		edited := strings.Replace(string(original), "contents: read", "contents: write", 1)
+		require.NotEqual(t, string(original), edited, "Lock file should contain a permission to edit")
+		require.NoError(t, os.WriteFile(lockFile, []byte(edited), 0644), "Failed to write lock file")
+
+		status, diff, err := verifyLockFile(workflow.NewCompiler(), markdownFile)
+		require.NoError(t, err, "Verify should succeed")
+		assert.Equal(t, lockFileModified, status, "Edited lock file should not match")
+		assert.Contains(t, diff, "-      contents: write", "Diff should show the hand-edited line")
+		assert.Contains(t, diff, "+      contents: read", "Diff should show the compiled line")
+
+		after, err := os.ReadFile(lockFile)
+		require.NoError(t, err, "Lock file should still exist")
+		assert.Equal(t, edited, string(after), "Verify should not overwrite the lock file")
+	})
+
+	t.Run("compile error is returned", func(t *testing.T) {
+		invalidFile := filepath.Join(tmpDir, "invalid.md")
+		require.NoError(t, os.WriteFile(invalidFile, []byte("---\non: push\nengine: not-a-real-engine\n---\n# Invalid\n"), 0644), "Failed to write workflow")
+
+		_, _, err := verifyLockFile(workflow.NewCompiler(), invalidFile)
+		require.Error(t, err, "Compile error should be returned")
+	})
+}
+
+func TestRunVerify(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "run-verify")
+	markdownFile := filepath.Join(tmpDir, "verify.md")
+	require.NoError(t, os.WriteFile(markdownFile, []byte(verifyTestWorkflow), 0644), "Failed to write workflow")
+
+	err := RunVerify([]string{markdownFile}, false)
+	require.Error(t, err, "Verify should fail when the lock file is missing")
+	assert.Contains(t, err.Error(), "1 lock file(s) do not match", "Error should count failing lock files")
+
+	require.NoError(t, workflow.NewCompiler().CompileWorkflow(markdownFile), "Workflow should compile")
+	require.NoError(t, RunVerify([]string{markdownFile}, false), "Verify should pass after compiling")
+
+	lockFile := stringutil.MarkdownToLockFile(markdownFile)
+	content, err := os.ReadFile(lockFile)
+	require.NoError(t, err, "Lock file should exist")
+	require.NoError(t, os.WriteFile(lockFile, append(content, []byte("# hand edit\n")...), 0644), "Failed to write lock file")
+	require.Error(t, RunVerify([]string{markdownFile}, false), "Verify should fail after the lock file is edited")
+}
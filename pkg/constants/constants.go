@@ -342,6 +342,9 @@ const (
 	EnvVarModelDetectionCodex = "GH_AW_MODEL_DETECTION_CODEX"
 )
 
+// EnvVarFirewallLogLevel overrides the AWF firewall log level for a run (e.g. "debug")
+const EnvVarFirewallLogLevel = "GH_AW_FIREWALL_LOG_LEVEL"
+
 // DefaultCodexVersion is the default version of the OpenAI Codex CLI
 const DefaultCodexVersion Version = "0.101.0"
 
@@ -580,6 +583,10 @@ const AgentJobName JobName = "agent"
 const ActivationJobName JobName = "activation"
 const PreActivationJobName JobName = "pre_activation"
 const DetectionJobName JobName = "detection"
+const ConclusionJobName JobName = "conclusion"
+const SafeOutputsJobName JobName = "safe_outputs"
+const PushRepoMemoryJobName JobName = "push_repo_memory"
+const UpdateCacheMemoryJobName JobName = "update_cache_memory"
 const SafeOutputArtifactName = "safe-output"
 const AgentOutputArtifactName = "agent-output"
 
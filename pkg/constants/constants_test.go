@@ -234,6 +234,10 @@ func TestConstantValues(t *testing.T) {
 		{"ActivationJobName", string(ActivationJobName), "activation"},
 		{"PreActivationJobName", string(PreActivationJobName), "pre_activation"},
 		{"DetectionJobName", string(DetectionJobName), "detection"},
+		{"ConclusionJobName", string(ConclusionJobName), "conclusion"},
+		{"SafeOutputsJobName", string(SafeOutputsJobName), "safe_outputs"},
+		{"PushRepoMemoryJobName", string(PushRepoMemoryJobName), "push_repo_memory"},
+		{"UpdateCacheMemoryJobName", string(UpdateCacheMemoryJobName), "update_cache_memory"},
 		{"SafeOutputArtifactName", SafeOutputArtifactName, "safe-output"},
 		{"AgentOutputArtifactName", AgentOutputArtifactName, "agent-output"},
 		{"SafeOutputsMCPServerID", SafeOutputsMCPServerID, "safeoutputs"},
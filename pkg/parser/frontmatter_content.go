@@ -244,10 +244,21 @@ func ExtractYamlChunk(yamlContent, key string) (string, error) {
 // ExtractWorkflowNameFromMarkdown extracts workflow name from first H1 header
 // This matches the bash extract_workflow_name_from_markdown function exactly
 func ExtractWorkflowNameFromMarkdown(filePath string) (string, error) {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read file %s: %w", filePath, err)
+	}
+
+	return ExtractWorkflowNameFromContent(string(content), filePath)
+}
+
+// ExtractWorkflowNameFromContent extracts workflow name from the first H1 header of
+// workflow file content. If no H1 header is found, a default name is generated from filePath.
+func ExtractWorkflowNameFromContent(content string, filePath string) (string, error) {
 	log.Printf("Extracting workflow name from markdown: file=%s", filePath)
 
 	// First extract markdown content (excluding frontmatter)
-	markdownContent, err := ExtractMarkdown(filePath)
+	markdownContent, err := ExtractMarkdownContent(content)
 	if err != nil {
 		return "", err
 	}
@@ -96,6 +96,7 @@ func buildCanonicalFrontmatter(frontmatter map[string]any, result *ImportsResult
 	// Tool and integration fields
 	addField("tools")
 	addField("mcp-servers")
+	addField("mcp")
 	addField("network")
 	addField("safe-outputs")
 	addField("safe-inputs")
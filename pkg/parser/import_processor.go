@@ -89,23 +89,32 @@ type importQueueItem struct {
 	sectionName string         // Optional section name (from file.md#Section syntax)
 	baseDir     string         // Base directory for resolving nested imports
 	inputs      map[string]any // Optional input values from parent import
+	remote      *remoteImport  // Set when the import was fetched from another repository
 }
 
 // ProcessImportsFromFrontmatterWithManifest processes imports field from frontmatter
 // Returns result containing merged tools, engines, markdown content, and list of imported files
 // Uses BFS traversal with queues for deterministic ordering and cycle detection
 func ProcessImportsFromFrontmatterWithManifest(frontmatter map[string]any, baseDir string, cache *ImportCache) (*ImportsResult, error) {
-	return processImportsFromFrontmatterWithManifestAndSource(frontmatter, baseDir, cache, "", "")
+	return processImportsFromFrontmatterWithManifestAndSource(frontmatter, baseDir, cache, "", "", false)
 }
 
 // ProcessImportsFromFrontmatterWithSource processes imports field from frontmatter with source tracking
 // This version includes the workflow file path and YAML content for better error reporting
 func ProcessImportsFromFrontmatterWithSource(frontmatter map[string]any, baseDir string, cache *ImportCache, workflowFilePath string, yamlContent string) (*ImportsResult, error) {
-	return processImportsFromFrontmatterWithManifestAndSource(frontmatter, baseDir, cache, workflowFilePath, yamlContent)
+	return processImportsFromFrontmatterWithManifestAndSource(frontmatter, baseDir, cache, workflowFilePath, yamlContent, false)
 }
 
-// processImportsFromFrontmatterWithManifestAndSource is the internal implementation that includes source tracking
-func processImportsFromFrontmatterWithManifestAndSource(frontmatter map[string]any, baseDir string, cache *ImportCache, workflowFilePath string, yamlContent string) (*ImportsResult, error) {
+// ProcessImportsFromFrontmatterInlined is like ProcessImportsFromFrontmatterWithSource but inlines
+// the markdown of every import into MergedMarkdown (with @include directives expanded) and leaves
+// ImportPaths empty, so the compiled prompt does not read imports from the repository at runtime
+func ProcessImportsFromFrontmatterInlined(frontmatter map[string]any, baseDir string, cache *ImportCache, workflowFilePath string, yamlContent string) (*ImportsResult, error) {
+	return processImportsFromFrontmatterWithManifestAndSource(frontmatter, baseDir, cache, workflowFilePath, yamlContent, true)
+}
+
+// processImportsFromFrontmatterWithManifestAndSource is the internal implementation that includes source tracking.
+// When inlineAll is set, imports without inputs are inlined like imports with inputs.
+func processImportsFromFrontmatterWithManifestAndSource(frontmatter map[string]any, baseDir string, cache *ImportCache, workflowFilePath string, yamlContent string, inlineAll bool) (*ImportsResult, error) {
 	// Check if imports field exists
 	importsField, exists := frontmatter["imports"]
 	if !exists {
@@ -217,7 +226,7 @@ func processImportsFromFrontmatterWithManifestAndSource(frontmatter map[string]a
 		}
 
 		// Resolve import path (supports workflowspec format)
-		fullPath, err := ResolveIncludePath(filePath, baseDir, cache)
+		fullPath, remote, err := resolveImportPath(filePath, baseDir, cache)
 		if err != nil {
 			// If we have source information, create a structured import error
 			if workflowFilePath != "" && yamlContent != "" {
@@ -252,14 +261,16 @@ func processImportsFromFrontmatterWithManifestAndSource(frontmatter map[string]a
 		}
 
 		// Check for duplicates before adding to queue
-		if !visited[fullPath] {
-			visited[fullPath] = true
+		visitKey := importVisitKey(fullPath, remote)
+		if !visited[visitKey] {
+			visited[visitKey] = true
 			queue = append(queue, importQueueItem{
 				importPath:  importPath,
 				fullPath:    fullPath,
 				sectionName: sectionName,
 				baseDir:     baseDir,
 				inputs:      importSpec.Inputs,
+				remote:      remote,
 			})
 			log.Printf("Queued import: %s (resolved to %s)", importPath, fullPath)
 		} else {
@@ -310,13 +321,13 @@ func processImportsFromFrontmatterWithManifestAndSource(frontmatter map[string]a
 
 			// Track import path for runtime-import macro generation (only if no inputs)
 			// Imports with inputs must be inlined for compile-time substitution
-			if len(item.inputs) == 0 {
+			if len(item.inputs) == 0 && !inlineAll {
 				// No inputs - use runtime-import macro
 				importPaths = append(importPaths, importRelPath)
 				log.Printf("Added agent import path for runtime-import: %s", importRelPath)
 			} else {
-				// Has inputs - must inline for compile-time substitution
-				log.Printf("Agent file has inputs - will be inlined instead of runtime-imported")
+				// Has inputs (or all imports are inlined) - inline the markdown at compile time
+				log.Printf("Agent file has inputs or imports are inlined - will be inlined instead of runtime-imported")
 
 				// For agent files, extract markdown content (only when inputs are present)
 				markdownContent, err := processIncludedFileWithVisited(item.fullPath, item.sectionName, false, visited)
@@ -426,8 +437,17 @@ func processImportsFromFrontmatterWithManifestAndSource(frontmatter map[string]a
 						nestedFilePath = nestedImportPath
 					}
 
+					// Imports inside a remote file resolve within the same repository at the same commit
+					if item.remote != nil && !isWorkflowSpec(nestedFilePath) {
+						nestedFilePath = item.remote.nestedSpec(nestedFilePath)
+						nestedImportPath = nestedFilePath
+						if nestedSectionName != "" {
+							nestedImportPath += "#" + nestedSectionName
+						}
+					}
+
 					// Resolve nested import path relative to the workflows directory, not the nested file's directory
-					nestedFullPath, err := ResolveIncludePath(nestedFilePath, baseDir, cache)
+					nestedFullPath, nestedRemote, err := resolveImportPath(nestedFilePath, baseDir, cache)
 					if err != nil {
 						// If we have source information for the parent workflow, create a structured error
 						if workflowFilePath != "" && yamlContent != "" {
@@ -448,13 +468,15 @@ func processImportsFromFrontmatterWithManifestAndSource(frontmatter map[string]a
 					}
 
 					// Check for cycles - skip if already visited
-					if !visited[nestedFullPath] {
-						visited[nestedFullPath] = true
+					nestedVisitKey := importVisitKey(nestedFullPath, nestedRemote)
+					if !visited[nestedVisitKey] {
+						visited[nestedVisitKey] = true
 						queue = append(queue, importQueueItem{
 							importPath:  nestedImportPath,
 							fullPath:    nestedFullPath,
 							sectionName: nestedSectionName,
 							baseDir:     baseDir, // Use original baseDir, not nestedBaseDir
+							remote:      nestedRemote,
 						})
 						log.Printf("Discovered nested import: %s -> %s (queued)", item.fullPath, nestedFullPath)
 					} else {
@@ -482,13 +504,20 @@ func processImportsFromFrontmatterWithManifestAndSource(frontmatter map[string]a
 			importRelPath = item.importPath
 		}
 
-		if len(item.inputs) == 0 {
+		if len(item.inputs) == 0 && item.remote == nil && !inlineAll {
 			// No inputs - use runtime-import macro
 			importPaths = append(importPaths, importRelPath)
 			log.Printf("Added import path for runtime-import: %s", importRelPath)
 		} else {
 			// Has inputs - must inline for compile-time substitution
-			log.Printf("Import %s has inputs - will be inlined for compile-time substitution", importRelPath)
+			// Remote imports are always inlined so the prompt is pinned to the resolved commit
+			if item.remote != nil {
+				log.Printf("Import %s is remote - will be inlined at %s", item.importPath, item.remote.pinnedSpec())
+			} else if len(item.inputs) == 0 {
+				log.Printf("Import %s will be inlined (runtime imports disabled)", importRelPath)
+			} else {
+				log.Printf("Import %s has inputs - will be inlined for compile-time substitution", importRelPath)
+			}
 
 			// Extract markdown content from imported file (only for imports with inputs)
 			markdownContent, err := processIncludedFileWithVisited(item.fullPath, item.sectionName, false, visited)
@@ -701,7 +730,7 @@ func topologicalSortImports(imports []string, baseDir string, cache *ImportCache
 			filePath = importPath
 		}
 
-		fullPath, err := ResolveIncludePath(filePath, baseDir, cache)
+		fullPath, remote, err := resolveImportPath(filePath, baseDir, cache)
 		if err != nil {
 			importLog.Printf("Failed to resolve import path %s during topological sort: %v", importPath, err)
 			dependencies[importPath] = []string{}
@@ -725,6 +754,19 @@ func topologicalSortImports(imports []string, baseDir string, cache *ImportCache
 
 		// Extract nested imports
 		nestedImports := extractImportPaths(result.Frontmatter)
+		if remote != nil {
+			// Match the workflowspecs that nested imports of remote files were queued under
+			for i, nested := range nestedImports {
+				nestedPath, section, hasSection := strings.Cut(nested, "#")
+				if isWorkflowSpec(nestedPath) {
+					continue
+				}
+				nestedImports[i] = remote.nestedSpec(nestedPath)
+				if hasSection {
+					nestedImports[i] += "#" + section
+				}
+			}
+		}
 		dependencies[importPath] = nestedImports
 		importLog.Printf("Import %s has %d dependencies: %v", importPath, len(nestedImports), nestedImports)
 	}
@@ -788,6 +830,26 @@ func topologicalSortImports(imports []string, baseDir string, cache *ImportCache
 	return result
 }
 
+// resolveImportPath resolves an import to a local file path. For workflowspec imports it
+// also returns the remote import, pinned to the commit its ref resolved to.
+func resolveImportPath(filePath, baseDir string, cache *ImportCache) (string, *remoteImport, error) {
+	if isWorkflowSpec(filePath) {
+		return resolveRemoteImport(filePath, cache)
+	}
+	fullPath, err := ResolveIncludePath(filePath, baseDir, cache)
+	return fullPath, nil, err
+}
+
+// importVisitKey returns the key used for duplicate and cycle detection. Remote imports
+// are keyed by their pinned workflowspec so cycles are detected across repositories
+// even when downloads are not cached at a stable path.
+func importVisitKey(fullPath string, remote *remoteImport) string {
+	if remote != nil {
+		return remote.pinnedSpec()
+	}
+	return fullPath
+}
+
 // extractImportPaths extracts just the import paths from frontmatter
 func extractImportPaths(frontmatter map[string]any) []string {
 	var imports []string
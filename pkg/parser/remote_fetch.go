@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"path"
 	"path/filepath"
 	"strings"
 
@@ -188,14 +189,53 @@ func isWorkflowSpec(path string) bool {
 	return true
 }
 
-// downloadIncludeFromWorkflowSpec downloads an include file from GitHub using workflowspec
-// It first checks the cache, and only downloads if not cached
-func downloadIncludeFromWorkflowSpec(spec string, cache *ImportCache) (string, error) {
-	remoteLog.Printf("Downloading from workflowspec: %s", spec)
+// remoteImportResolver resolves refs and downloads files for remote imports.
+// It is a variable so tests can substitute a fake resolver that does not hit the network.
+var remoteImportResolver = struct {
+	resolveRef   func(owner, repo, ref string) (string, error)
+	downloadFile func(owner, repo, path, ref string) ([]byte, error)
+}{
+	resolveRef:   resolveRefToSHA,
+	downloadFile: downloadFileFromGitHub,
+}
 
-	// Parse the workflowspec
-	// Format: owner/repo/path@ref or owner/repo/path@ref#section
+// remoteImport identifies a file imported from another repository
+type remoteImport struct {
+	owner string
+	repo  string
+	path  string // File path within the repository
+	ref   string // Ref as written in the import (defaults to main)
+	sha   string // Commit SHA the ref resolved to, empty if it could not be resolved
+}
+
+// pinnedRef returns the resolved commit SHA, or the original ref if it could not be resolved
+func (r *remoteImport) pinnedRef() string {
+	if r.sha != "" {
+		return r.sha
+	}
+	return r.ref
+}
+
+// pinnedSpec returns the workflowspec of the import pinned to the resolved commit.
+// It identifies the file across repositories for cycle detection.
+func (r *remoteImport) pinnedSpec() string {
+	return fmt.Sprintf("%s/%s/%s@%s", r.owner, r.repo, r.path, r.pinnedRef())
+}
 
+// nestedSpec resolves an import declared inside a remote file to a workflowspec in the
+// same repository at the same commit. Like local imports, relative paths are resolved
+// against the .github/workflows directory when the file lives under it, otherwise
+// against the directory of the importing file.
+func (r *remoteImport) nestedSpec(importPath string) string {
+	baseDir := path.Dir(r.path)
+	if idx := strings.Index(r.path, ".github/workflows/"); idx >= 0 {
+		baseDir = r.path[:idx] + ".github/workflows"
+	}
+	return fmt.Sprintf("%s/%s/%s@%s", r.owner, r.repo, path.Join(baseDir, importPath), r.pinnedRef())
+}
+
+// parseRemoteImport parses a workflowspec (owner/repo/path[@ref][#section]) into its parts
+func parseRemoteImport(spec string) (*remoteImport, error) {
 	// Remove section reference if present
 	cleanSpec := spec
 	if idx := strings.Index(spec, "#"); idx != -1 {
@@ -205,11 +245,13 @@ func downloadIncludeFromWorkflowSpec(spec string, cache *ImportCache) (string, e
 	// Split on @ to get path and ref
 	parts := strings.SplitN(cleanSpec, "@", 2)
 	pathPart := parts[0]
-	var ref string
+	ref := "main" // default to main branch
 	if len(parts) == 2 {
+		if parts[1] == "" {
+			return nil, fmt.Errorf("invalid workflowspec %s: ref after '@' is empty", spec)
+		}
 		ref = parts[1]
 	} else {
-		ref = "main" // default to main branch
 		remoteLog.Print("No ref specified, defaulting to 'main'")
 	}
 
@@ -217,61 +259,82 @@ func downloadIncludeFromWorkflowSpec(spec string, cache *ImportCache) (string, e
 	slashParts := strings.Split(pathPart, "/")
 	if len(slashParts) < 3 {
 		remoteLog.Printf("Invalid workflowspec format: %s", spec)
-		return "", fmt.Errorf("invalid workflowspec: must be owner/repo/path[@ref]")
+		return nil, fmt.Errorf("invalid workflowspec: must be owner/repo/path[@ref]")
 	}
 
-	owner := slashParts[0]
-	repo := slashParts[1]
-	filePath := strings.Join(slashParts[2:], "/")
+	return &remoteImport{
+		owner: slashParts[0],
+		repo:  slashParts[1],
+		path:  strings.Join(slashParts[2:], "/"),
+		ref:   ref,
+	}, nil
+}
+
+// downloadIncludeFromWorkflowSpec downloads an include file from GitHub using workflowspec
+// It first checks the cache, and only downloads if not cached
+func downloadIncludeFromWorkflowSpec(spec string, cache *ImportCache) (string, error) {
+	fullPath, _, err := resolveRemoteImport(spec, cache)
+	return fullPath, err
+}
+
+// resolveRemoteImport downloads a remote import pinned to the commit its ref resolves to.
+// Returns the local path of the downloaded file and the resolved import.
+func resolveRemoteImport(spec string, cache *ImportCache) (string, *remoteImport, error) {
+	remoteLog.Printf("Downloading from workflowspec: %s", spec)
+
+	remote, err := parseRemoteImport(spec)
+	if err != nil {
+		return "", nil, err
+	}
+	owner, repo, filePath, ref := remote.owner, remote.repo, remote.path, remote.ref
 	remoteLog.Printf("Parsed workflowspec: owner=%s, repo=%s, file=%s, ref=%s", owner, repo, filePath, ref)
 
-	// Resolve ref to SHA for cache lookup
-	var sha string
+	// Resolve ref to SHA so the download (and cache entry) is pinned to a single commit
+	resolvedSHA, err := remoteImportResolver.resolveRef(owner, repo, ref)
+	if err != nil {
+		// If the error is an authentication error, propagate it immediately
+		lowerErr := strings.ToLower(err.Error())
+		if strings.Contains(lowerErr, "auth") || strings.Contains(lowerErr, "unauthoriz") || strings.Contains(lowerErr, "forbidden") || strings.Contains(lowerErr, "token") || strings.Contains(lowerErr, "permission denied") {
+			return "", nil, fmt.Errorf("failed to resolve ref to SHA due to authentication error: %w", err)
+		}
+		// Never fall back to downloading the unpinned ref: the import must stay pinned to one commit
+		remoteLog.Printf("Failed to resolve ref to SHA: %v", err)
+		return "", nil, fmt.Errorf("failed to resolve %s/%s@%s to a commit SHA for import %s: %w", owner, repo, ref, spec, err)
+	}
+	remote.sha = resolvedSHA
+
+	// Check cache using SHA
 	if cache != nil {
-		// Only resolve SHA if we're using the cache
-		resolvedSHA, err := resolveRefToSHA(owner, repo, ref)
-		if err != nil {
-			// If the error is an authentication error, propagate it immediately
-			lowerErr := strings.ToLower(err.Error())
-			if strings.Contains(lowerErr, "auth") || strings.Contains(lowerErr, "unauthoriz") || strings.Contains(lowerErr, "forbidden") || strings.Contains(lowerErr, "token") || strings.Contains(lowerErr, "permission denied") {
-				return "", fmt.Errorf("failed to resolve ref to SHA due to authentication error: %w", err)
-			}
-			remoteLog.Printf("Failed to resolve ref to SHA, will skip cache: %v", err)
-			// Continue without caching if SHA resolution fails
-		} else {
-			sha = resolvedSHA
-			// Check cache using SHA
-			if cachedPath, found := cache.Get(owner, repo, filePath, sha); found {
-				remoteLog.Printf("Using cached import: %s/%s/%s@%s (SHA: %s)", owner, repo, filePath, ref, sha)
-				return cachedPath, nil
-			}
+		if cachedPath, found := cache.Get(owner, repo, filePath, remote.sha); found {
+			remoteLog.Printf("Using cached import: %s/%s/%s@%s (SHA: %s)", owner, repo, filePath, ref, remote.sha)
+			return cachedPath, remote, nil
 		}
 	}
 
 	// Download the file content from GitHub
-	remoteLog.Printf("Fetching file from GitHub: %s/%s/%s@%s", owner, repo, filePath, ref)
-	content, err := downloadFileFromGitHub(owner, repo, filePath, ref)
+	remoteLog.Printf("Fetching file from GitHub: %s/%s/%s@%s", owner, repo, filePath, remote.pinnedRef())
+	content, err := remoteImportResolver.downloadFile(owner, repo, filePath, remote.pinnedRef())
 	if err != nil {
-		return "", fmt.Errorf("failed to download include from %s: %w", spec, err)
+		return "", nil, fmt.Errorf("failed to download include from %s: %w", spec, err)
 	}
 	remoteLog.Printf("Successfully downloaded file: size=%d bytes", len(content))
 
-	// If cache is available and we have a SHA, store in cache
-	if cache != nil && sha != "" {
-		cachedPath, err := cache.Set(owner, repo, filePath, sha, content)
+	// If cache is available, store in cache
+	if cache != nil {
+		cachedPath, err := cache.Set(owner, repo, filePath, remote.sha, content)
 		if err != nil {
 			remoteLog.Printf("Failed to cache import: %v", err)
 			// Don't fail the compilation, fall back to temp file
 		} else {
 			remoteLog.Printf("Successfully cached download at: %s", cachedPath)
-			return cachedPath, nil
+			return cachedPath, remote, nil
 		}
 	}
 
 	// Fallback: Create a temporary file to store the downloaded content
 	tempFile, err := os.CreateTemp("", "gh-aw-include-*.md")
 	if err != nil {
-		return "", fmt.Errorf("failed to create temp file: %w", err)
+		return "", nil, fmt.Errorf("failed to create temp file: %w", err)
 	}
 
 	if _, err := tempFile.Write(content); err != nil {
@@ -282,7 +345,7 @@ func downloadIncludeFromWorkflowSpec(spec string, cache *ImportCache) (string, e
 		if rmErr := os.Remove(tempFile.Name()); rmErr != nil {
 			remoteLog.Printf("Warning: failed to remove temp file %s: %v", tempFile.Name(), rmErr)
 		}
-		return "", fmt.Errorf("failed to write temp file: %w", err)
+		return "", nil, fmt.Errorf("failed to write temp file: %w", err)
 	}
 
 	if err := tempFile.Close(); err != nil {
@@ -290,10 +353,10 @@ func downloadIncludeFromWorkflowSpec(spec string, cache *ImportCache) (string, e
 		if rmErr := os.Remove(tempFile.Name()); rmErr != nil {
 			remoteLog.Printf("Warning: failed to remove temp file %s: %v", tempFile.Name(), rmErr)
 		}
-		return "", fmt.Errorf("failed to close temp file: %w", err)
+		return "", nil, fmt.Errorf("failed to close temp file: %w", err)
 	}
 
-	return tempFile.Name(), nil
+	return tempFile.Name(), remote, nil
 }
 
 // resolveRefToSHAViaGit resolves a git ref to SHA using git ls-remote
@@ -0,0 +1,192 @@
+//go:build !integration
+
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	remoteTestSHA      = "0123456789abcdef0123456789abcdef01234567"
+	otherRemoteTestSHA = "89abcdef0123456789abcdef0123456789abcdef"
+)
+
+// fakeRemoteRepos serves remote imports from memory, keyed by owner/repo/path@sha
+type fakeRemoteRepos struct {
+	refs      map[string]string // owner/repo@ref -> sha
+	files     map[string]string // owner/repo/path@sha -> content
+	downloads []string
+}
+
+func (f *fakeRemoteRepos) resolveRef(owner, repo, ref string) (string, error) {
+	if sha, ok := f.refs[fmt.Sprintf("%s/%s@%s", owner, repo, ref)]; ok {
+		return sha, nil
+	}
+	if len(ref) == 40 {
+		return ref, nil
+	}
+	if owner == "private" {
+		return "", errors.New("HTTP 401: Bad credentials (authentication required)")
+	}
+	return "", fmt.Errorf("no commit found for ref %s", ref)
+}
+
+func (f *fakeRemoteRepos) downloadFile(owner, repo, path, ref string) ([]byte, error) {
+	key := fmt.Sprintf("%s/%s/%s@%s", owner, repo, path, ref)
+	f.downloads = append(f.downloads, key)
+	content, ok := f.files[key]
+	if !ok {
+		return nil, fmt.Errorf("file not found: %s", key)
+	}
+	return []byte(content), nil
+}
+
+// useFakeRemoteRepos replaces the remote import resolver for the duration of the test
+func useFakeRemoteRepos(t *testing.T, fake *fakeRemoteRepos) {
+	t.Helper()
+	original := remoteImportResolver
+	remoteImportResolver.resolveRef = fake.resolveRef
+	remoteImportResolver.downloadFile = fake.downloadFile
+	t.Cleanup(func() { remoteImportResolver = original })
+}
+
+func newRemoteImportTestDir(t *testing.T) string {
+	t.Helper()
+	workflowsDir := filepath.Join(testutil.TempDir(t, "remote-import-test"), ".github", "workflows")
+	require.NoError(t, os.MkdirAll(workflowsDir, 0755), "Failed to create workflows directory")
+	return workflowsDir
+}
+
+func TestRemoteImportNestedSpec(t *testing.T) {
+	tests := []struct {
+		name     string
+		remote   remoteImport
+		nested   string
+		expected string
+	}{
+		{
+			name:     "under workflows directory",
+			remote:   remoteImport{owner: "acme", repo: "shared", path: ".github/workflows/shared/common.md", ref: "v1", sha: remoteTestSHA},
+			nested:   "shared/tools.md",
+			expected: "acme/shared/.github/workflows/shared/tools.md@" + remoteTestSHA,
+		},
+		{
+			name:     "outside workflows directory",
+			remote:   remoteImport{owner: "acme", repo: "shared", path: "prompts/common.md", ref: "v1", sha: remoteTestSHA},
+			nested:   "tools.md",
+			expected: "acme/shared/prompts/tools.md@" + remoteTestSHA,
+		},
+		{
+			name:     "unresolved ref",
+			remote:   remoteImport{owner: "acme", repo: "shared", path: "prompts/common.md", ref: "main"},
+			nested:   "tools.md",
+			expected: "acme/shared/prompts/tools.md@main",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.remote.nestedSpec(tt.nested), "Nested import should resolve in the same repository and commit")
+		})
+	}
+}
+
+func TestRemoteImportInlinedAndPinned(t *testing.T) {
+	fake := &fakeRemoteRepos{
+		refs: map[string]string{"acme/shared@v1": remoteTestSHA},
+		files: map[string]string{
+			"acme/shared/shared/common.md@" + remoteTestSHA: "---\ntools:\n  bash: [\"ls\"]\n---\n\nRemote instructions at v1.\n",
+		},
+	}
+	useFakeRemoteRepos(t, fake)
+
+	workflowsDir := newRemoteImportTestDir(t)
+	require.NoError(t, os.WriteFile(filepath.Join(workflowsDir, "local.md"), []byte("Local instructions.\n"), 0644), "Failed to write local import")
+
+	frontmatter := map[string]any{"imports": []any{"acme/shared/shared/common.md@v1", "local.md"}}
+	result, err := ProcessImportsFromFrontmatterWithManifest(frontmatter, workflowsDir, nil)
+	require.NoError(t, err, "Remote import should be processed")
+
+	require.NotEmpty(t, fake.downloads, "Remote file should be downloaded")
+	for _, download := range fake.downloads {
+		assert.Equal(t, "acme/shared/shared/common.md@"+remoteTestSHA, download, "Remote file should be downloaded at the resolved commit")
+	}
+	assert.Contains(t, result.MergedMarkdown, "Remote instructions at v1.", "Remote content should be inlined")
+	assert.Equal(t, []string{".github/workflows/local.md"}, result.ImportPaths, "Only the local import should be runtime-imported")
+	assert.Contains(t, result.MergedTools, "bash", "Tools from the remote import should be merged")
+}
+
+func TestRemoteImportNestedImports(t *testing.T) {
+	prefix := "acme/shared/.github/workflows/shared/"
+	fake := &fakeRemoteRepos{
+		refs: map[string]string{"acme/shared@v2": remoteTestSHA},
+		files: map[string]string{
+			prefix + "a.md@" + remoteTestSHA:               "---\nimports:\n  - shared/b.md\n---\n\nRemote A.\n",
+			prefix + "b.md@" + remoteTestSHA:               "---\nimports:\n  - shared/a.md\n  - other/lib/prompts/c.md@v3\n---\n\nRemote B.\n",
+			"other/lib/prompts/c.md@" + otherRemoteTestSHA: "---\nimports:\n  - acme/shared/.github/workflows/shared/a.md@v2\n---\n\nRemote C.\n",
+		},
+	}
+	fake.refs["other/lib@v3"] = otherRemoteTestSHA
+	useFakeRemoteRepos(t, fake)
+
+	workflowsDir := newRemoteImportTestDir(t)
+	frontmatter := map[string]any{"imports": []any{prefix + "a.md@v2"}}
+	result, err := ProcessImportsFromFrontmatterWithManifest(frontmatter, workflowsDir, nil)
+	require.NoError(t, err, "Cyclic remote imports across repositories should be processed")
+
+	for _, expected := range []string{"Remote A.", "Remote B.", "Remote C."} {
+		assert.Equal(t, 1, strings.Count(result.MergedMarkdown, expected), "Each remote file should be inlined exactly once: %s", expected)
+	}
+	assert.Empty(t, result.ImportPaths, "Remote imports should not be runtime-imported")
+	assert.Contains(t, fake.downloads, prefix+"b.md@"+remoteTestSHA, "Nested import should be pinned to the parent's commit")
+}
+
+func TestRemoteImportErrors(t *testing.T) {
+	fake := &fakeRemoteRepos{refs: map[string]string{}, files: map[string]string{}}
+	useFakeRemoteRepos(t, fake)
+	workflowsDir := newRemoteImportTestDir(t)
+
+	tests := []struct {
+		name        string
+		importPath  string
+		errContains string
+	}{
+		{name: "unknown ref", importPath: "acme/shared/shared/common.md@does-not-exist", errContains: "failed to resolve acme/shared@does-not-exist to a commit SHA"},
+		{name: "empty ref", importPath: "acme/shared/shared/common.md@", errContains: "ref after '@' is empty"},
+		{name: "private repository without access", importPath: "private/repo/shared/common.md@v1", errContains: "authentication error"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			frontmatter := map[string]any{"imports": []any{tt.importPath}}
+			_, err := ProcessImportsFromFrontmatterWithManifest(frontmatter, workflowsDir, nil)
+			require.Error(t, err, "Unresolvable remote import should fail")
+			assert.Contains(t, err.Error(), tt.errContains, "Error should explain why the import failed")
+		})
+	}
+}
+
+func TestRemoteImportResolveFailureDoesNotDownloadUnpinnedRef(t *testing.T) {
+	fake := &fakeRemoteRepos{
+		refs:  map[string]string{},
+		files: map[string]string{"acme/shared/shared/common.md@main": "Unpinned content.\n"},
+	}
+	useFakeRemoteRepos(t, fake)
+	remoteImportResolver.resolveRef = func(owner, repo, ref string) (string, error) {
+		return "", errors.New("dial tcp: connection reset by peer")
+	}
+
+	_, err := downloadIncludeFromWorkflowSpec("acme/shared/shared/common.md@main", nil)
+	require.Error(t, err, "A ref that cannot be resolved should fail the import")
+	assert.Contains(t, err.Error(), "connection reset by peer", "Error should carry the resolve failure")
+	assert.Empty(t, fake.downloads, "The unpinned ref should never be downloaded")
+}
@@ -0,0 +1,49 @@
+package parser
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var schemaExportLog = logger.New("parser:schema_export")
+
+// ExportMainWorkflowSchema returns the JSON Schema describing the frontmatter of main
+// workflow files for use by editors. This is the same schema the compiler validates
+// frontmatter against, so editors using it stay in sync with the compiler. The schema
+// is compiled before it is returned to guarantee that only a usable schema is exported.
+func ExportMainWorkflowSchema() ([]byte, error) {
+	schemaExportLog.Print("Exporting main workflow schema")
+
+	if _, err := getCompiledMainWorkflowSchema(); err != nil {
+		return nil, fmt.Errorf("failed to compile main workflow schema: %w", err)
+	}
+
+	return []byte(mainWorkflowSchema), nil
+}
+
+// GetFrontmatterTopLevelKeys returns the sorted list of top-level frontmatter keys
+// supported by the main workflow schema
+func GetFrontmatterTopLevelKeys() ([]string, error) {
+	var schemaDoc map[string]any
+	if err := json.Unmarshal([]byte(mainWorkflowSchema), &schemaDoc); err != nil {
+		return nil, fmt.Errorf("failed to parse main workflow schema: %w", err)
+	}
+
+	properties, ok := schemaDoc["properties"].(map[string]any)
+	if !ok {
+		return nil, errors.New("schema missing 'properties' field")
+	}
+
+	keys := make([]string, 0, len(properties))
+	for key := range properties {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	schemaExportLog.Printf("Found %d top-level frontmatter keys", len(keys))
+	return keys, nil
+}
@@ -0,0 +1,94 @@
+//go:build !integration
+
+package parser
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+)
+
+func TestGetFrontmatterTopLevelKeys(t *testing.T) {
+	keys, err := GetFrontmatterTopLevelKeys()
+	if err != nil {
+		t.Fatalf("GetFrontmatterTopLevelKeys() error = %v", err)
+	}
+
+	if !slices.IsSorted(keys) {
+		t.Errorf("Expected keys to be sorted, got %v", keys)
+	}
+
+	for _, expected := range []string{"on", "engine", "tools", "safe-outputs", "network", "jobs", "permissions", "imports", "steps", "timeout-minutes"} {
+		if !slices.Contains(keys, expected) {
+			t.Errorf("Expected schema to include top-level key %q", expected)
+		}
+	}
+}
+
+func TestExportMainWorkflowSchema(t *testing.T) {
+	schemaJSON, err := ExportMainWorkflowSchema()
+	if err != nil {
+		t.Fatalf("ExportMainWorkflowSchema() error = %v", err)
+	}
+
+	var schemaDoc map[string]any
+	if err := json.Unmarshal(schemaJSON, &schemaDoc); err != nil {
+		t.Fatalf("Exported schema is not valid JSON: %v", err)
+	}
+	if schemaDoc["$schema"] == nil || schemaDoc["$id"] == nil {
+		t.Errorf("Expected exported schema to declare $schema and $id")
+	}
+
+	// The exported schema must be usable on its own to validate workflow frontmatter
+	schema, err := compileSchema(string(schemaJSON), "http://contoso.com/exported-schema.json")
+	if err != nil {
+		t.Fatalf("Exported schema failed to compile: %v", err)
+	}
+
+	valid := `---
+on:
+  issues:
+    types: [opened]
+permissions:
+  contents: read
+engine: copilot
+tools:
+  github:
+    toolsets: [issues]
+  cache-memory: true
+network: defaults
+safe-outputs:
+  add-comment:
+    max: 1
+timeout-minutes: 10
+---
+
+# Triage
+`
+	result, err := ExtractFrontmatterFromContent(valid)
+	if err != nil {
+		t.Fatalf("Failed to extract frontmatter: %v", err)
+	}
+	if err := schema.Validate(toJSONCompatible(t, result.Frontmatter)); err != nil {
+		t.Errorf("Expected sample workflow to validate against exported schema, got: %v", err)
+	}
+
+	invalid := map[string]any{"on": "push", "unknown-key": true}
+	if err := schema.Validate(toJSONCompatible(t, invalid)); err == nil {
+		t.Errorf("Expected unknown top-level key to fail validation")
+	}
+}
+
+// toJSONCompatible round-trips a value through JSON so it matches what the schema validator expects
+func toJSONCompatible(t *testing.T, value any) any {
+	t.Helper()
+	data, err := json.Marshal(value)
+	if err != nil {
+		t.Fatalf("Failed to marshal value: %v", err)
+	}
+	var doc any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatalf("Failed to unmarshal value: %v", err)
+	}
+	return doc
+}
@@ -194,7 +194,8 @@ func generateFieldSuggestions(invalidProps, acceptedFields []string) string {
 }
 
 // FindClosestMatches finds the closest matching strings using Levenshtein distance.
-// It returns up to maxResults matches that have a Levenshtein distance of 3 or less.
+// It returns up to maxResults matches that have a Levenshtein distance of 3 or less,
+// counting Unicode characters rather than bytes.
 // Results are sorted by distance (closest first), then alphabetically for ties.
 func FindClosestMatches(target string, candidates []string, maxResults int) []string {
 	schemaSuggestionsLog.Printf("Finding closest matches for '%s' from %d candidates", target, len(candidates))
@@ -216,7 +217,7 @@ func FindClosestMatches(target string, candidates []string, maxResults int) []st
 			continue
 		}
 
-		distance := LevenshteinDistance(targetLower, candidateLower)
+		distance := LevenshteinDistanceRunes(targetLower, candidateLower)
 
 		// Only include if distance is within acceptable range
 		if distance <= maxDistance {
@@ -245,7 +246,22 @@ func FindClosestMatches(target string, candidates []string, maxResults int) []st
 // LevenshteinDistance computes the Levenshtein distance between two strings.
 // This is the minimum number of single-character edits (insertions, deletions, or substitutions)
 // required to change one string into the other.
+//
+// The distance is computed over bytes, so each byte of a multi-byte UTF-8 character counts
+// as a separate character. Use LevenshteinDistanceRunes to compare non-ASCII text.
 func LevenshteinDistance(a, b string) int {
+	return levenshteinDistance([]byte(a), []byte(b))
+}
+
+// LevenshteinDistanceRunes computes the Levenshtein distance between two strings over
+// Unicode code points, so a multi-byte character such as a CJK ideograph counts as a
+// single character. This is the distance used by FindClosestMatches.
+func LevenshteinDistanceRunes(a, b string) int {
+	return levenshteinDistance([]rune(a), []rune(b))
+}
+
+// levenshteinDistance computes the Levenshtein distance between two sequences of characters
+func levenshteinDistance[T comparable](a, b []T) int {
 	aLen := len(a)
 	bLen := len(b)
 
@@ -245,6 +245,89 @@ func TestFindClosestMatches(t *testing.T) {
 	}
 }
 
+func TestLevenshteinDistanceRunes(t *testing.T) {
+	tests := []struct {
+		name         string
+		a            string
+		b            string
+		wantBytes    int
+		wantRunes    int
+		bytesInflate bool // byte distance is larger than rune distance
+	}{
+		{
+			name:      "ASCII strings",
+			a:         "content",
+			b:         "contnt",
+			wantBytes: 1,
+			wantRunes: 1,
+		},
+		{
+			name:         "accented character",
+			a:            "café",
+			b:            "cafe",
+			wantBytes:    2,
+			wantRunes:    1,
+			bytesInflate: true,
+		},
+		{
+			name:         "CJK insertion",
+			a:            "日本",
+			b:            "日本語",
+			wantBytes:    3,
+			wantRunes:    1,
+			bytesInflate: true,
+		},
+		{
+			name:         "CJK substitutions",
+			a:            "データ取得",
+			b:            "データ保存",
+			wantRunes:    2,
+			bytesInflate: true,
+		},
+		{
+			name:      "empty string",
+			a:         "",
+			b:         "日本",
+			wantBytes: 6,
+			wantRunes: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bytesDistance := LevenshteinDistance(tt.a, tt.b)
+			runesDistance := LevenshteinDistanceRunes(tt.a, tt.b)
+
+			if tt.wantBytes != 0 && bytesDistance != tt.wantBytes {
+				t.Errorf("LevenshteinDistance(%q, %q) = %d, want %d", tt.a, tt.b, bytesDistance, tt.wantBytes)
+			}
+			if runesDistance != tt.wantRunes {
+				t.Errorf("LevenshteinDistanceRunes(%q, %q) = %d, want %d", tt.a, tt.b, runesDistance, tt.wantRunes)
+			}
+			if tt.bytesInflate && bytesDistance <= runesDistance {
+				t.Errorf("Expected byte distance %d to exceed rune distance %d for multi-byte characters", bytesDistance, runesDistance)
+			}
+		})
+	}
+}
+
+func TestFindClosestMatchesUnicode(t *testing.T) {
+	candidates := []string{"データ保存", "データ削除", "search"}
+
+	result := FindClosestMatches("データ取得", candidates, 2)
+	if len(result) != 2 {
+		t.Fatalf("Expected 2 matches for a CJK identifier, got %v", result)
+	}
+	if result[0] != "データ保存" && result[0] != "データ削除" {
+		t.Errorf("Expected a CJK suggestion first, got %v", result)
+	}
+
+	// Distance 4 in characters stays outside the suggestion range
+	if result := FindClosestMatches("データ", []string{"データ取得保存確認"}, 1); len(result) != 0 {
+		t.Errorf("Expected no match beyond the maximum distance, got %v", result)
+	}
+}
+
 func TestGenerateExampleJSONForPath(t *testing.T) {
 	schemaJSON := `{
 		"type": "object",
@@ -0,0 +1,95 @@
+package stringutil
+
+// The helpers in this file follow two contracts. IsValidSemver is the strict check for
+// versions that must be complete (MAJOR.MINOR.PATCH). IsSemverTag, CompareSemver,
+// ParseSemver and SemverMajor also accept the "v1" and "v1.2" shorthands used by release
+// tags, so a shorthand version can be compared and parsed even though IsValidSemver
+// rejects it.
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"golang.org/x/mod/semver"
+)
+
+// IsSemverTag reports whether ref is a semantic version, with an optional "v" prefix.
+// Unlike IsValidSemver it accepts the "v1" and "v1.2" shorthands used by release tags.
+// Returns true for "v1", "1.2", "v1.2.3-beta" and "1.2.3+build" but false for branch
+// names and commit SHAs.
+func IsSemverTag(ref string) bool {
+	return semver.IsValid(canonicalSemverInput(ref))
+}
+
+// IsValidSemver reports whether s is a semantic version of the form MAJOR.MINOR.PATCH,
+// with an optional "v" prefix, pre-release tag, and build metadata.
+// Returns true for strings like "1.2.3", "v1.2.3", and "1.2.3-beta.1" but false for:
+//   - Shorthand versions ("1", "v1.2"), which CompareSemver and ParseSemver still accept
+//   - Leading zeros in numeric components ("01.2.3")
+//   - Non-version strings ("latest", "")
+func IsValidSemver(s string) bool {
+	v := canonicalSemverInput(s)
+	if !semver.IsValid(v) {
+		return false
+	}
+
+	// semver.IsValid accepts "v1" and "v1.2" shorthands; require all three components
+	core := strings.TrimPrefix(v, "v")
+	if i := strings.IndexAny(core, "-+"); i >= 0 {
+		core = core[:i]
+	}
+	return strings.Count(core, ".") == 2
+}
+
+// CompareSemver compares two semantic versions, ignoring any "v" prefix.
+// Returns -1 if a < b, 0 if a == b, and 1 if a > b. Shorthand versions are padded with
+// zeros ("1.2" == "1.2.0"), pre-release versions order before the corresponding release
+// ("1.2.3-beta" < "1.2.3") and build metadata is ignored.
+// Returns an error if either version is not valid according to IsSemverTag.
+func CompareSemver(a, b string) (int, error) {
+	if !IsSemverTag(a) {
+		return 0, fmt.Errorf("invalid semantic version: %q", a)
+	}
+	if !IsSemverTag(b) {
+		return 0, fmt.Errorf("invalid semantic version: %q", b)
+	}
+	return semver.Compare(canonicalSemverInput(a), canonicalSemverInput(b)), nil
+}
+
+// ParseSemver splits a version accepted by IsSemverTag into its numeric components and
+// pre-release tag (without the leading "-"). Missing minor and patch components are zero.
+// Returns ok=false for invalid versions.
+func ParseSemver(version string) (major, minor, patch int, prerelease string, ok bool) {
+	v := canonicalSemverInput(version)
+	if !semver.IsValid(v) {
+		return 0, 0, 0, "", false
+	}
+
+	// Canonical form is always vMAJOR.MINOR.PATCH[-PRERELEASE]
+	core := strings.TrimPrefix(semver.Canonical(v), "v")
+	if i := strings.IndexByte(core, '-'); i >= 0 {
+		core = core[:i]
+	}
+	parts := strings.Split(core, ".")
+	major, _ = strconv.Atoi(parts[0])
+	minor, _ = strconv.Atoi(parts[1])
+	patch, _ = strconv.Atoi(parts[2])
+	return major, minor, patch, strings.TrimPrefix(semver.Prerelease(v), "-"), true
+}
+
+// SemverMajor returns the major version number of a version accepted by IsSemverTag,
+// or 0 for invalid versions.
+// Examples: "v5.0.0" -> 5, "v6" -> 6, "5.1.0" -> 5
+func SemverMajor(version string) int {
+	major, _ := strconv.Atoi(strings.TrimPrefix(semver.Major(canonicalSemverInput(version)), "v"))
+	return major
+}
+
+// canonicalSemverInput adds the "v" prefix required by golang.org/x/mod/semver
+func canonicalSemverInput(s string) string {
+	if strings.HasPrefix(s, "v") {
+		return s
+	}
+	return "v" + s
+}
@@ -0,0 +1,197 @@
+//go:build !integration
+
+package stringutil
+
+import "testing"
+
+func TestIsValidSemver(t *testing.T) {
+	tests := []struct {
+		name     string
+		version  string
+		expected bool
+	}{
+		{name: "plain version", version: "1.2.3", expected: true},
+		{name: "v prefix", version: "v1.2.3", expected: true},
+		{name: "pre-release tag", version: "1.2.3-beta.1", expected: true},
+		{name: "build metadata", version: "v1.2.3+build.5", expected: true},
+		{name: "pre-release and build metadata", version: "1.2.3-rc.1+sha.abc", expected: true},
+		{name: "zero version", version: "0.0.0", expected: true},
+		{name: "empty string", version: "", expected: false},
+		{name: "v only", version: "v", expected: false},
+		{name: "major only", version: "1", expected: false},
+		{name: "major and minor only", version: "v1.2", expected: false},
+		{name: "four components", version: "1.2.3.4", expected: false},
+		{name: "leading zero", version: "01.2.3", expected: false},
+		{name: "non-numeric component", version: "1.x.3", expected: false},
+		{name: "word", version: "latest", expected: false},
+		{name: "whitespace", version: " 1.2.3", expected: false},
+		{name: "empty pre-release", version: "1.2.3-", expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsValidSemver(tt.version); got != tt.expected {
+				t.Errorf("IsValidSemver(%q) = %v; want %v", tt.version, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCompareSemver(t *testing.T) {
+	tests := []struct {
+		name     string
+		a        string
+		b        string
+		expected int
+	}{
+		{name: "v prefix against plain lower", a: "v1.2.3", b: "1.2.4", expected: -1},
+		{name: "plain against v prefix higher", a: "1.2.4", b: "v1.2.3", expected: 1},
+		{name: "equal with mixed prefixes", a: "v1.2.3", b: "1.2.3", expected: 0},
+		{name: "minor outranks patch", a: "1.10.0", b: "1.9.9", expected: 1},
+		{name: "major outranks minor", a: "2.0.0", b: "1.99.99", expected: 1},
+		{name: "numeric not lexical", a: "0.0.354", b: "0.0.99", expected: 1},
+		{name: "pre-release before release", a: "1.2.3-beta", b: "1.2.3", expected: -1},
+		{name: "release after pre-release", a: "1.2.3", b: "1.2.3-rc.1", expected: 1},
+		{name: "alpha before beta", a: "1.0.0-alpha", b: "1.0.0-beta", expected: -1},
+		{name: "numeric pre-release identifiers", a: "1.0.0-beta.2", b: "1.0.0-beta.11", expected: -1},
+		{name: "numeric before alphanumeric identifier", a: "1.0.0-1", b: "1.0.0-alpha", expected: -1},
+		{name: "longer pre-release ranks higher", a: "1.0.0-alpha.1", b: "1.0.0-alpha", expected: 1},
+		{name: "pre-release of next version above release", a: "1.2.4-alpha", b: "1.2.3", expected: 1},
+		{name: "build metadata ignored", a: "1.2.3+build.1", b: "1.2.3+build.2", expected: 0},
+		{name: "shorthand minor numeric not lexical", a: "3.11", b: "3.9", expected: 1},
+		{name: "shorthand major only", a: "20", b: "24", expected: -1},
+		{name: "shorthand equals padded version", a: "v1.2", b: "1.2.0", expected: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := CompareSemver(tt.a, tt.b)
+			if err != nil {
+				t.Fatalf("CompareSemver(%q, %q) unexpected error: %v", tt.a, tt.b, err)
+			}
+			if got != tt.expected {
+				t.Errorf("CompareSemver(%q, %q) = %d; want %d", tt.a, tt.b, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCompareSemverInvalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		a       string
+		b       string
+		invalid string
+	}{
+		{name: "invalid first version", a: "latest", b: "1.2.3", invalid: "latest"},
+		{name: "invalid second version", a: "1.2.3", b: "1.2.x", invalid: "1.2.x"},
+		{name: "empty version", a: "", b: "1.2.3", invalid: ""},
+		{name: "both invalid reports first", a: "abc", b: "def", invalid: "abc"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := CompareSemver(tt.a, tt.b)
+			if err == nil {
+				t.Fatalf("CompareSemver(%q, %q) = %d; want error", tt.a, tt.b, got)
+			}
+			want := "invalid semantic version: \"" + tt.invalid + "\""
+			if err.Error() != want {
+				t.Errorf("CompareSemver(%q, %q) error = %q; want %q", tt.a, tt.b, err.Error(), want)
+			}
+		})
+	}
+}
+
+func TestIsSemverTag(t *testing.T) {
+	tests := []struct {
+		name     string
+		ref      string
+		expected bool
+	}{
+		{name: "full version", ref: "v1.2.3", expected: true},
+		{name: "major only", ref: "v1", expected: true},
+		{name: "major and minor without prefix", ref: "1.2", expected: true},
+		{name: "pre-release tag", ref: "v1.0.0-beta", expected: true},
+		{name: "branch name", ref: "main", expected: false},
+		{name: "commit sha", ref: "abc123def456", expected: false},
+		{name: "empty string", ref: "", expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsSemverTag(tt.ref); got != tt.expected {
+				t.Errorf("IsSemverTag(%q) = %v; want %v", tt.ref, got, tt.expected)
+			}
+		})
+	}
+}
+
+// TestShorthandIsComparableButNotValid pins the split contract: shorthand release tags
+// compare and parse like their zero-padded form but are not valid semantic versions.
+func TestShorthandIsComparableButNotValid(t *testing.T) {
+	for _, shorthand := range []string{"1", "v1", "1.2", "v1.2"} {
+		if IsValidSemver(shorthand) {
+			t.Errorf("IsValidSemver(%q) = true; want false", shorthand)
+		}
+		if !IsSemverTag(shorthand) {
+			t.Errorf("IsSemverTag(%q) = false; want true", shorthand)
+		}
+		if _, err := CompareSemver(shorthand, "v1.0.0"); err != nil {
+			t.Errorf("CompareSemver(%q, \"v1.0.0\") error = %v; want nil", shorthand, err)
+		}
+		if _, _, _, _, ok := ParseSemver(shorthand); !ok {
+			t.Errorf("ParseSemver(%q) ok = false; want true", shorthand)
+		}
+	}
+}
+
+func TestParseSemver(t *testing.T) {
+	tests := []struct {
+		version    string
+		major      int
+		minor      int
+		patch      int
+		prerelease string
+		ok         bool
+	}{
+		{version: "v1.2.3", major: 1, minor: 2, patch: 3, ok: true},
+		{version: "10.20.30-rc.1+build.5", major: 10, minor: 20, patch: 30, prerelease: "rc.1", ok: true},
+		{version: "v6", major: 6, ok: true},
+		{version: "2.5", major: 2, minor: 5, ok: true},
+		{version: "latest"},
+		{version: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			major, minor, patch, prerelease, ok := ParseSemver(tt.version)
+			if major != tt.major || minor != tt.minor || patch != tt.patch || prerelease != tt.prerelease || ok != tt.ok {
+				t.Errorf("ParseSemver(%q) = %d, %d, %d, %q, %v; want %d, %d, %d, %q, %v", tt.version,
+					major, minor, patch, prerelease, ok, tt.major, tt.minor, tt.patch, tt.prerelease, tt.ok)
+			}
+		})
+	}
+}
+
+func TestSemverMajor(t *testing.T) {
+	tests := []struct {
+		version  string
+		expected int
+	}{
+		{"v5.0.0", 5},
+		{"v6", 6},
+		{"5.1.0", 5},
+		{"v10.2.3", 10},
+		{"main", 0},
+		{"", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			if got := SemverMajor(tt.version); got != tt.expected {
+				t.Errorf("SemverMajor(%q) = %d; want %d", tt.version, got, tt.expected)
+			}
+		})
+	}
+}
@@ -6,11 +6,16 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"unicode/utf8"
 )
 
 // Truncate truncates a string to a maximum length, adding "..." if truncated.
 // If maxLen is 3 or less, the string is truncated without "...".
 //
+// maxLen is measured in bytes, so truncation may split a multi-byte UTF-8
+// character and produce invalid UTF-8. Use TruncateRunes when the input may
+// contain non-ASCII text and the result must remain valid UTF-8.
+//
 // This is a general-purpose utility for truncating any string to a configurable
 // length. For domain-specific workflow command identifiers with newline handling,
 // see workflow.ShortenCommand instead.
@@ -24,6 +29,26 @@ func Truncate(s string, maxLen int) string {
 	return s[:maxLen-3] + "..."
 }
 
+// TruncateRunes truncates a string to a maximum number of runes, adding "..." if truncated.
+// If maxLen is 3 or less, the string is truncated without "...".
+//
+// Unlike Truncate, maxLen is measured in runes rather than bytes, so the result
+// never contains a partial multi-byte character and is always valid UTF-8 when
+// the input is. Note that combining characters count as separate runes.
+func TruncateRunes(s string, maxLen int) string {
+	if maxLen <= 0 {
+		return ""
+	}
+	if utf8.RuneCountInString(s) <= maxLen {
+		return s
+	}
+	runes := []rune(s)
+	if maxLen <= 3 {
+		return string(runes[:maxLen])
+	}
+	return string(runes[:maxLen-3]) + "..."
+}
+
 // NormalizeWhitespace normalizes trailing whitespace and newlines to reduce spurious conflicts.
 // It trims trailing whitespace from each line and ensures exactly one trailing newline.
 func NormalizeWhitespace(content string) string {
@@ -43,6 +68,33 @@ func NormalizeWhitespace(content string) string {
 	return normalized
 }
 
+// NormalizeWhitespaceCollapse normalizes whitespace like NormalizeWhitespace and additionally
+// collapses any run of more than maxBlankLines consecutive empty lines down to maxBlankLines.
+// A negative maxBlankLines is treated as 0 (all blank lines are removed).
+func NormalizeWhitespaceCollapse(content string, maxBlankLines int) string {
+	if maxBlankLines < 0 {
+		maxBlankLines = 0
+	}
+
+	lines := strings.Split(NormalizeWhitespace(content), "\n")
+	collapsed := make([]string, 0, len(lines))
+	blankRun := 0
+	for _, line := range lines {
+		if line == "" {
+			blankRun++
+			if blankRun > maxBlankLines {
+				continue
+			}
+		} else {
+			blankRun = 0
+		}
+		collapsed = append(collapsed, line)
+	}
+
+	// Re-normalize so the trailing newline is preserved after collapsing
+	return NormalizeWhitespace(strings.Join(collapsed, "\n"))
+}
+
 // ParseVersionValue converts version values of various types to strings.
 // Supports string, int, int64, uint64, and float64 types.
 // Returns empty string for unsupported types.
@@ -84,9 +136,13 @@ func IsPositiveInteger(s string) bool {
 }
 
 // ansiEscapePattern matches ANSI escape sequences
-// Pattern matches: ESC [ <optional params> <command letter>
-// Examples: \x1b[0m, \x1b[31m, \x1b[1;32m
-var ansiEscapePattern = regexp.MustCompile(`\x1b\[[0-9;]*[a-zA-Z]`)
+// Pattern matches:
+//   - CSI: ESC [ <optional params> <command letter> (e.g. \x1b[0m, \x1b[1;32m)
+//   - OSC: ESC ] <payload> terminated by BEL or ST (ESC \), or the end of the string
+//     (e.g. \x1b]0;title\x07, \x1b]8;;https://example.com\x1b\\)
+//   - Character set selection: ESC ( or ESC ) followed by one character (e.g. \x1b(B)
+//   - Single-character escapes: ESC followed by one final character (e.g. \x1b7, \x1bM)
+var ansiEscapePattern = regexp.MustCompile(`\x1b(?:\[[0-9;]*[a-zA-Z]|\][^\x07\x1b]*(?:\x07|\x1b\\|$)|[()][0-9A-Za-z]|[0-9=>@-Z\\^_a-z~])`)
 
 // StripANSIEscapeCodes removes ANSI escape sequences from a string.
 // This prevents terminal color codes and other control sequences from
@@ -96,6 +152,9 @@ var ansiEscapePattern = regexp.MustCompile(`\x1b\[[0-9;]*[a-zA-Z]`)
 //   - Color codes: \x1b[31m (red), \x1b[0m (reset)
 //   - Text formatting: \x1b[1m (bold), \x1b[4m (underline)
 //   - Cursor control: \x1b[2J (clear screen)
+//   - Terminal titles: \x1b]0;title\x07
+//   - Hyperlinks: \x1b]8;;https://example.com\x07text\x1b]8;;\x07
+//   - Single-character escapes: \x1b7 (save cursor), \x1bc (reset)
 //
 // Example:
 //
@@ -5,6 +5,7 @@ package stringutil
 import (
 	"strings"
 	"testing"
+	"unicode/utf8"
 )
 
 func TestTruncate(t *testing.T) {
@@ -219,6 +220,103 @@ func TestTruncate_Unicode(t *testing.T) {
 	}
 }
 
+func TestTruncateRunes(t *testing.T) {
+	tests := []struct {
+		name     string
+		s        string
+		maxLen   int
+		expected string
+	}{
+		{
+			name:     "ascii shorter than max length",
+			s:        "hello",
+			maxLen:   10,
+			expected: "hello",
+		},
+		{
+			name:     "ascii longer than max length",
+			s:        "hello world",
+			maxLen:   8,
+			expected: "hello...",
+		},
+		{
+			name:     "zero max length",
+			s:        "hello",
+			maxLen:   0,
+			expected: "",
+		},
+		{
+			name:     "negative max length",
+			s:        "hello",
+			maxLen:   -1,
+			expected: "",
+		},
+		{
+			name:     "empty string",
+			s:        "",
+			maxLen:   5,
+			expected: "",
+		},
+		{
+			name:     "emoji truncation keeps whole runes",
+			s:        "Hello 👋 World 🌍",
+			maxLen:   10,
+			expected: "Hello 👋...",
+		},
+		{
+			name:     "emoji exactly at max length",
+			s:        "👋🌍🎉",
+			maxLen:   3,
+			expected: "👋🌍🎉",
+		},
+		{
+			name:     "emoji with small max length",
+			s:        "👋🌍🎉🚀",
+			maxLen:   2,
+			expected: "👋🌍",
+		},
+		{
+			name:     "cjk characters",
+			s:        "测试字符串截断功能",
+			maxLen:   6,
+			expected: "测试字...",
+		},
+		{
+			name:     "cjk fits by rune count but not byte count",
+			s:        "测试测试",
+			maxLen:   4,
+			expected: "测试测试",
+		},
+		{
+			name:     "mixed unicode and ascii",
+			s:        "Test-测试-テスト",
+			maxLen:   8,
+			expected: "Test-...",
+		},
+		{
+			name:     "combining characters",
+			s:        "e\u0301e\u0301e\u0301e\u0301",
+			maxLen:   5,
+			expected: "e\u0301...",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := TruncateRunes(tt.s, tt.maxLen)
+			if result != tt.expected {
+				t.Errorf("TruncateRunes(%q, %d) = %q; want %q", tt.s, tt.maxLen, result, tt.expected)
+			}
+			if !utf8.ValidString(result) {
+				t.Errorf("TruncateRunes(%q, %d) produced invalid UTF-8: %q", tt.s, tt.maxLen, result)
+			}
+			if tt.maxLen > 0 && utf8.RuneCountInString(result) > tt.maxLen {
+				t.Errorf("TruncateRunes(%q, %d) = %q has %d runes; want at most %d", tt.s, tt.maxLen, result, utf8.RuneCountInString(result), tt.maxLen)
+			}
+		})
+	}
+}
+
 func TestNormalizeWhitespace_OnlyWhitespace(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -327,6 +425,79 @@ func BenchmarkNormalizeWhitespace_ManyChanges(b *testing.B) {
 	}
 }
 
+func TestNormalizeWhitespaceCollapse(t *testing.T) {
+	tests := []struct {
+		name          string
+		content       string
+		maxBlankLines int
+		expected      string
+	}{
+		{
+			name:          "interior blank-line run collapsed",
+			content:       "hello\n\n\n\n\nworld",
+			maxBlankLines: 1,
+			expected:      "hello\n\nworld\n",
+		},
+		{
+			name:          "interior run collapsed to two",
+			content:       "hello\n\n\n\n\nworld",
+			maxBlankLines: 2,
+			expected:      "hello\n\n\nworld\n",
+		},
+		{
+			name:          "single blank lines preserved",
+			content:       "a\n\nb\n\nc",
+			maxBlankLines: 1,
+			expected:      "a\n\nb\n\nc\n",
+		},
+		{
+			name:          "whitespace-only lines count as blank",
+			content:       "a\n  \n\t\n\nb",
+			maxBlankLines: 1,
+			expected:      "a\n\nb\n",
+		},
+		{
+			name:          "zero removes all blank lines",
+			content:       "a\n\nb\n\n\nc",
+			maxBlankLines: 0,
+			expected:      "a\nb\nc\n",
+		},
+		{
+			name:          "negative treated as zero",
+			content:       "a\n\nb",
+			maxBlankLines: -1,
+			expected:      "a\nb\n",
+		},
+		{
+			name:          "trailing newlines trimmed",
+			content:       "a\n\n\nb\n\n\n",
+			maxBlankLines: 1,
+			expected:      "a\n\nb\n",
+		},
+		{
+			name:          "content already normalized",
+			content:       "hello\n\nworld\n",
+			maxBlankLines: 1,
+			expected:      "hello\n\nworld\n",
+		},
+		{
+			name:          "empty string",
+			content:       "",
+			maxBlankLines: 1,
+			expected:      "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := NormalizeWhitespaceCollapse(tt.content, tt.maxBlankLines)
+			if result != tt.expected {
+				t.Errorf("NormalizeWhitespaceCollapse(%q, %d) = %q; want %q", tt.content, tt.maxBlankLines, result, tt.expected)
+			}
+		})
+	}
+}
+
 func TestParseVersionValue(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -540,6 +711,36 @@ func TestStripANSIEscapeCodes(t *testing.T) {
 			input:    "\x1b[1mThis\x1b[0m \x1b[31mis\x1b[0m \x1b[32ma\x1b[0m \x1b[33mvery\x1b[0m \x1b[34mlong\x1b[0m \x1b[35mstring\x1b[0m \x1b[36mwith\x1b[0m \x1b[37mmany\x1b[0m \x1b[1mANSI\x1b[0m \x1b[4mcodes\x1b[0m",
 			expected: "This is a very long string with many ANSI codes",
 		},
+		{
+			name:     "OSC title terminated by BEL",
+			input:    "\x1b]0;my terminal\x07Build finished",
+			expected: "Build finished",
+		},
+		{
+			name:     "OSC title terminated by ST",
+			input:    "\x1b]2;my terminal\x1b\\Build finished",
+			expected: "Build finished",
+		},
+		{
+			name:     "OSC hyperlink",
+			input:    "See \x1b]8;;https://github.com/github/gh-aw\x07the docs\x1b]8;;\x07 for details",
+			expected: "See the docs for details",
+		},
+		{
+			name:     "unterminated OSC",
+			input:    "Done\x1b]0;partial title",
+			expected: "Done",
+		},
+		{
+			name:     "mixed CSI and OSC",
+			input:    "\x1b]0;title\x07\x1b[1mBold\x1b[0m and \x1b]8;;https://example.com\x1b\\\x1b[34mlink\x1b[0m\x1b]8;;\x1b\\",
+			expected: "Bold and link",
+		},
+		{
+			name:     "single-character escapes",
+			input:    "\x1b7Saved\x1b8 \x1b(Bcharset\x1bM reverse\x1bc",
+			expected: "Saved charset reverse",
+		},
 	}
 
 	for _, tt := range tests {
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"slices"
 	"sort"
 	"strings"
 	"sync"
@@ -248,21 +249,9 @@ func GetActionPinWithData(actionRepo, version string, data *WorkflowData) (strin
 
 			// Only emit warning if the version is not a SHA (SHAs shouldn't generate warnings)
 			if !isAlreadySHA {
-				// Initialize the warning cache if needed
-				if data.ActionPinWarnings == nil {
-					data.ActionPinWarnings = make(map[string]bool)
-				}
-
-				// Create a cache key for this action@version combination
-				cacheKey := formatActionCacheKey(actionRepo, version)
-
-				// Only emit warning if we haven't already warned about this action
-				if !data.ActionPinWarnings[cacheKey] {
-					warningMsg := fmt.Sprintf("Unable to resolve %s@%s dynamically, using hardcoded pin for %s@%s",
-						actionRepo, version, actionRepo, selectedPin.Version)
-					fmt.Fprintln(os.Stderr, console.FormatWarningMessage(warningMsg))
-					data.ActionPinWarnings[cacheKey] = true
-				}
+				warningMsg := fmt.Sprintf("Unable to resolve %s@%s dynamically, using hardcoded pin for %s@%s",
+					actionRepo, version, actionRepo, selectedPin.Version)
+				warnActionPin(data, formatActionCacheKey(actionRepo, version), warningMsg)
 			}
 			actionPinsLog.Printf("Using version in non-strict mode: %s@%s (requested) → %s@%s (used)",
 				actionRepo, version, actionRepo, selectedPin.Version)
@@ -283,24 +272,41 @@ func GetActionPinWithData(actionRepo, version string, data *WorkflowData) (strin
 		return formatActionReference(actionRepo, version, version), nil
 	}
 
-	// Initialize the warning cache if needed
-	if data.ActionPinWarnings == nil {
-		data.ActionPinWarnings = make(map[string]bool)
+	warningMsg := fmt.Sprintf("Unable to pin action %s@%s", actionRepo, version)
+	if data.ActionResolver != nil {
+		warningMsg = fmt.Sprintf("Unable to pin action %s@%s: resolution failed", actionRepo, version)
 	}
+	warnActionPin(data, formatActionCacheKey(actionRepo, version), warningMsg)
+	return "", nil
+}
 
-	// Create a cache key for this action@version combination
-	cacheKey := formatActionCacheKey(actionRepo, version)
-
-	// Only emit warning if we haven't already warned about this action
-	if !data.ActionPinWarnings[cacheKey] {
-		warningMsg := fmt.Sprintf("Unable to pin action %s@%s", actionRepo, version)
-		if data.ActionResolver != nil {
-			warningMsg = fmt.Sprintf("Unable to pin action %s@%s: resolution failed", actionRepo, version)
-		}
+// warnActionPin records an action pin warning for the workflow being compiled, so the
+// compiler reports it as a compile warning, and prints it unless it was already printed
+// for this "repo@version" key
+func warnActionPin(data *WorkflowData, cacheKey, warningMsg string) {
+	if !slices.Contains(data.ActionPinWarningMessages, warningMsg) {
+		data.ActionPinWarningMessages = append(data.ActionPinWarningMessages, warningMsg)
+	}
+	if markActionPinWarned(data, cacheKey) {
 		fmt.Fprintln(os.Stderr, console.FormatWarningMessage(warningMsg))
-		data.ActionPinWarnings[cacheKey] = true
 	}
-	return "", nil
+}
+
+// markActionPinWarned records a pin warning for the given "repo@version" key and reports
+// whether it is the first one, initializing the warning cache if needed
+func markActionPinWarned(data *WorkflowData, cacheKey string) bool {
+	if data.ActionPinWarningsMu != nil {
+		data.ActionPinWarningsMu.Lock()
+		defer data.ActionPinWarningsMu.Unlock()
+	}
+	if data.ActionPinWarnings == nil {
+		data.ActionPinWarnings = make(map[string]bool)
+	}
+	if data.ActionPinWarnings[cacheKey] {
+		return false
+	}
+	data.ActionPinWarnings[cacheKey] = true
+	return true
 }
 
 // ApplyActionPinToTypedStep applies SHA pinning to a WorkflowStep if it uses an action.
@@ -121,7 +121,7 @@ func (c *Compiler) buildCreateOutputAddCommentJob(data *WorkflowData, mainJobNam
 		MainJobName:    mainJobName,
 		CustomEnvVars:  customEnvVars,
 		Script:         getAddCommentScript(),
-		Permissions:    NewPermissionsContentsReadIssuesWritePRWriteDiscussionsWrite(),
+		Permissions:    NewPermissionsBuilder().Read(PermissionContents).Write(PermissionIssues).Write(PermissionPullRequests).Write(PermissionDiscussions).Build(),
 		Outputs:        outputs,
 		Condition:      jobCondition,
 		Needs:          needs,
@@ -1,7 +1,10 @@
 package workflow
 
 import (
+	"errors"
 	"fmt"
+	"regexp"
+	"strings"
 
 	"github.com/github/gh-aw/pkg/logger"
 )
@@ -12,9 +15,14 @@ var addLabelsLog = logger.New("workflow:add_labels")
 type AddLabelsConfig struct {
 	BaseSafeOutputConfig   `yaml:",inline"`
 	SafeOutputTargetConfig `yaml:",inline"`
-	Allowed                []string `yaml:"allowed,omitempty"` // Optional list of allowed labels. Labels will be created if they don't already exist in the repository. If omitted, any labels are allowed (including creating new ones).
+	Allowed                []string `yaml:"allowed,omitempty"`           // Optional list of allowed labels. Labels will be created if they don't already exist in the repository. If omitted, any labels are allowed (including creating new ones).
+	CreateIfMissing        bool     `yaml:"create-if-missing,omitempty"` // Create missing labels before applying them
+	Color                  string   `yaml:"color,omitempty"`             // Color for labels created by create-if-missing (6-digit hex, optional leading #)
 }
 
+// labelColorPattern matches a 6-digit hex color with an optional leading #
+var labelColorPattern = regexp.MustCompile(`^#?[0-9a-fA-F]{6}$`)
+
 // parseAddLabelsConfig handles add-labels configuration
 func (c *Compiler) parseAddLabelsConfig(outputMap map[string]any) *AddLabelsConfig {
 	// Check if the key exists
@@ -62,7 +70,31 @@ func (c *Compiler) buildAddLabelsJob(data *WorkflowData, mainJobName string) (*J
 		EnvPrefix:   "GH_AW_LABELS",
 		OutputName:  "labels_added",
 		Script:      getAddLabelsScript(),
-		Permissions: NewPermissionsContentsReadIssuesWritePRWrite(),
+		Permissions: NewPermissionsBuilder().Read(PermissionContents).Write(PermissionIssues).Write(PermissionPullRequests).Build(),
 		DefaultMax:  3,
 	})
 }
+
+// validateAddLabelsColor validates the color used for labels created by add-labels.create-if-missing
+func validateAddLabelsColor(safeOutputs *SafeOutputsConfig) error {
+	if safeOutputs == nil || safeOutputs.AddLabels == nil || safeOutputs.AddLabels.Color == "" {
+		return nil
+	}
+
+	color := safeOutputs.AddLabels.Color
+	addLabelsLog.Printf("Validating add-labels color: %s", color)
+
+	if !labelColorPattern.MatchString(color) {
+		return fmt.Errorf("invalid safe-outputs.add-labels.color value %q: expected a 6-digit hex color such as 'd73a4a' or '#d73a4a'", color)
+	}
+	if !safeOutputs.AddLabels.CreateIfMissing {
+		return errors.New("safe-outputs.add-labels.color requires create-if-missing: true, because the color only applies to labels created by the handler")
+	}
+
+	return nil
+}
+
+// normalizeLabelColor returns the label color in the form expected by the GitHub API (no leading #, lowercase)
+func normalizeLabelColor(color string) string {
+	return strings.ToLower(strings.TrimPrefix(color, "#"))
+}
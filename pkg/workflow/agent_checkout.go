@@ -0,0 +1,76 @@
+package workflow
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/github/gh-aw/pkg/console"
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var agentCheckoutLog = logger.New("workflow:agent_checkout")
+
+// extractCheckoutDisabled returns true when the frontmatter sets checkout: false
+func extractCheckoutDisabled(frontmatter map[string]any) bool {
+	checkout, ok := frontmatter["checkout"].(bool)
+	return ok && !checkout
+}
+
+// agentCheckoutRequiredReason returns why the agent job still needs the repository
+// checkout, or "" when checkout: false can be honored. Runtime-import macros read files
+// from the workspace, so any file macro in the prompt keeps the checkout in place.
+func agentCheckoutRequiredReason(data *WorkflowData) string {
+	promptContent := data.MarkdownContent
+	if data.EngineConfig != nil {
+		promptContent += "\n" + data.EngineConfig.PromptPrefix + "\n" + data.EngineConfig.PromptSuffix
+	}
+	if len(extractRuntimeImportPaths(promptContent)) > 0 {
+		return "the prompt uses {{#runtime-import}} macros that read files from the repository"
+	}
+	if len(data.ImportPaths) > 0 {
+		return "imported markdown is loaded at runtime from the repository"
+	}
+	if data.AgentFile != "" {
+		return "the custom agent file is read from the repository"
+	}
+	if data.EngineConfig != nil && len(data.EngineConfig.EnvFiles) > 0 {
+		return "engine.env-file reads files from the repository"
+	}
+	if len(data.RepositoryImports) > 0 {
+		return "repository imports are merged into the .github folder"
+	}
+	if data.SafeOutputs != nil && data.SafeOutputs.CreatePullRequests != nil {
+		return "safe-outputs.create-pull-request needs the repository to generate a patch"
+	}
+	if data.SafeOutputs != nil && data.SafeOutputs.PushToPullRequestBranch != nil {
+		return "safe-outputs.push-to-pull-request-branch needs the repository to generate a patch"
+	}
+	return ""
+}
+
+// isAgentCheckoutSkipped returns true when the agent job runs without checking out the
+// repository: checkout: false is set and nothing in the workflow reads from the workspace
+func isAgentCheckoutSkipped(data *WorkflowData) bool {
+	return data.CheckoutDisabled && agentCheckoutRequiredReason(data) == ""
+}
+
+// validateCheckoutDisabled warns when checkout: false cannot be honored because the
+// workflow reads files from the repository at runtime
+func (c *Compiler) validateCheckoutDisabled(data *WorkflowData) {
+	if !data.CheckoutDisabled {
+		return
+	}
+
+	reason := agentCheckoutRequiredReason(data)
+	if reason == "" {
+		agentCheckoutLog.Print("Agent job checkout disabled")
+		return
+	}
+
+	agentCheckoutLog.Printf("Keeping agent job checkout: %s", reason)
+	warningMsg := fmt.Sprintf(
+		"checkout: false is ignored because %s. Remove checkout: false or inline the content to skip the checkout.",
+		reason)
+	fmt.Fprintln(os.Stderr, console.FormatWarningMessage(warningMsg))
+	c.addWarning(warningMsg)
+}
@@ -0,0 +1,96 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// agentJobSection returns the agent job from a compiled lock file
+func agentJobSection(t *testing.T, lockContent string) string {
+	t.Helper()
+	start := strings.Index(lockContent, "\n  agent:\n")
+	require.NotEqual(t, -1, start, "Lock file should contain the agent job")
+	section := lockContent[start+1:]
+	if end := regexp.MustCompile(`\n  [a-z_-]+:\n`).FindStringIndex(section[1:]); end != nil {
+		section = section[:end[0]+1]
+	}
+	return section
+}
+
+func TestCheckoutDisabled(t *testing.T) {
+	content := `---
+on: issues
+permissions:
+  contents: read
+  issues: read
+engine: copilot
+checkout: false
+---
+
+# Triage
+
+Summarize issue #${{ github.event.issue.number }}.
+`
+	markdownPath := filepath.Join(testutil.TempDir(t, "checkout-disabled"), "triage.md")
+
+	lockContent, err := NewCompiler().CompileString(content, markdownPath)
+	require.NoError(t, err, "Workflow with checkout: false should compile")
+	agentJob := agentJobSection(t, lockContent)
+
+	assert.NotContains(t, agentJob, "Checkout repository", "Agent job should not check out the repository")
+	assert.NotContains(t, agentJob, "Checkout .github and .agents folders", "Agent job should not check out the .github folder")
+	assert.NotContains(t, agentJob, "Configure Git credentials", "Agent job should not configure git without a checkout")
+	assert.NotContains(t, agentJob, "Checkout PR branch", "Agent job should not check out the PR branch")
+	assert.NotRegexp(t, `\{\{#runtime-import [^}]*triage\.md\}\}`, agentJob, "Main markdown should not be runtime-imported")
+	assert.Contains(t, agentJob, "Summarize issue", "Main markdown should be inlined in the prompt")
+	assert.NotContains(t, agentJob, "Summarize issue #${{ github.event.issue.number }}", "Expressions in the inlined markdown should be replaced with env vars")
+}
+
+func TestCheckoutDisabledKeptForRuntimeImports(t *testing.T) {
+	tests := []struct {
+		name        string
+		frontmatter string
+		body        string
+	}{
+		{
+			name:        "runtime-import macro",
+			frontmatter: "checkout: false",
+			body:        "{{#runtime-import shared/guidelines.md}}",
+		},
+		{
+			name:        "create-pull-request safe output",
+			frontmatter: "checkout: false\nsafe-outputs:\n  create-pull-request:",
+			body:        "Fix the typo.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content := "---\non: issues\npermissions:\n  contents: read\nengine: copilot\n" + tt.frontmatter + "\n---\n\n# Test\n\n" + tt.body + "\n"
+			markdownPath := filepath.Join(testutil.TempDir(t, "checkout-kept"), "kept.md")
+
+			compiler := NewCompiler()
+			lockContent, err := compiler.CompileString(content, markdownPath)
+			require.NoError(t, err, "Workflow should compile")
+			agentJob := agentJobSection(t, lockContent)
+
+			assert.Contains(t, agentJob, "Checkout repository", "Checkout should remain when the workflow reads repository files")
+			assert.Regexp(t, `\{\{#runtime-import [^}]*kept\.md\}\}`, agentJob, "Main markdown should still be runtime-imported")
+			assert.Positive(t, compiler.GetWarningCount(), "Ignoring checkout: false should emit a warning")
+		})
+	}
+}
+
+func TestCheckoutEnabledByDefault(t *testing.T) {
+	assert.False(t, extractCheckoutDisabled(map[string]any{}), "Checkout should be enabled without the key")
+	assert.False(t, extractCheckoutDisabled(map[string]any{"checkout": true}), "checkout: true should keep the checkout")
+	assert.True(t, extractCheckoutDisabled(map[string]any{"checkout": false}), "checkout: false should disable the checkout")
+}
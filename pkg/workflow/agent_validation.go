@@ -5,14 +5,18 @@
 // This file validates agent-specific configuration and feature compatibility
 // for agentic workflows. It ensures that:
 //   - Custom agent files exist when specified
-//   - Engine features are supported (HTTP transport, max-turns, web-search)
+//   - Engine features are supported (HTTP transport, max-turns, max-tokens, max-cost, web-search)
 //   - Workflow triggers have appropriate security constraints
 //
 // # Validation Functions
 //
 //   - validateAgentFile() - Validates custom agent file exists
 //   - validateHTTPTransportSupport() - Validates HTTP MCP compatibility with engine
+//   - validateMaxTurnsCeiling() - Validates max-turns against the engine.max-turns-ceiling repository policy
 //   - validateMaxTurnsSupport() - Validates max-turns feature support
+//   - validateMaxTokensSupport() - Validates max-tokens feature support
+//   - validateMaxCost() - Validates max-cost and pricing values and feature support
+//   - validateAllowedWhenSupport() - Validates allowed-when feature support
 //   - validateWebSearchSupport() - Validates web-search feature support (warning)
 //   - validateWorkflowRunBranches() - Validates workflow_run has branch restrictions
 //
@@ -47,6 +51,8 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
 
 	"github.com/github/gh-aw/pkg/console"
 	"github.com/github/gh-aw/pkg/logger"
@@ -118,6 +124,38 @@ func (c *Compiler) validateHTTPTransportSupport(tools map[string]any, engine Cod
 	return nil
 }
 
+// validateMaxTurnsCeiling validates that max-turns does not exceed the engine.max-turns-ceiling
+// repository policy in .github/aw/config.json. The ceiling applies to every engine, so it is checked before engine
+// support: a workflow cannot bypass the policy by choosing an engine with its own notion
+// of turns. Values that are not literal integers (e.g. expressions) cannot be checked at
+// compile time and are rejected while a ceiling is set.
+func (c *Compiler) validateMaxTurnsCeiling(frontmatter map[string]any) error {
+	repositoryConfig, err := c.getRepositoryConfig()
+	if err != nil {
+		return err
+	}
+	ceiling := repositoryConfig.Engine.MaxTurnsCeiling
+	if ceiling <= 0 {
+		return nil
+	}
+
+	_, engineConfig := c.ExtractEngineConfig(frontmatter)
+	if engineConfig == nil || engineConfig.MaxTurns == "" {
+		return nil
+	}
+
+	maxTurns, err := strconv.Atoi(strings.TrimSpace(engineConfig.MaxTurns))
+	if err != nil {
+		return fmt.Errorf("max-turns must be an integer when a max-turns ceiling of %d is set, got '%s'. Example:\nengine:\n  id: claude\n  max-turns: %d", ceiling, engineConfig.MaxTurns, ceiling)
+	}
+	if maxTurns > ceiling {
+		return fmt.Errorf("max-turns %d exceeds the max-turns ceiling of %d set in .github/aw/config.json. Lower engine.max-turns to %d or less", maxTurns, ceiling, ceiling)
+	}
+
+	agentValidationLog.Printf("max-turns %d is within the ceiling of %d", maxTurns, ceiling)
+	return nil
+}
+
 // validateMaxTurnsSupport validates that max-turns is only used with engines that support this feature
 func (c *Compiler) validateMaxTurnsSupport(frontmatter map[string]any, engine CodingAgentEngine) error {
 	// Check if max-turns is specified in the engine config
@@ -142,6 +180,83 @@ func (c *Compiler) validateMaxTurnsSupport(frontmatter map[string]any, engine Co
 	return nil
 }
 
+// validateMaxTokensSupport validates that max-tokens is only used with engines that support this feature
+func (c *Compiler) validateMaxTokensSupport(frontmatter map[string]any, engine CodingAgentEngine) error {
+	_, engineConfig := c.ExtractEngineConfig(frontmatter)
+	if engineConfig == nil || engineConfig.MaxTokens == 0 {
+		// No max-tokens specified, no validation needed
+		return nil
+	}
+
+	if !engine.SupportsMaxTokens() {
+		return fmt.Errorf("max-tokens not supported: engine '%s' does not support the max-tokens feature. Use engine: copilot-sdk or remove max-tokens from your configuration. Example:\nengine:\n  id: copilot-sdk\n  max-tokens: 8000", engine.GetID())
+	}
+
+	return nil
+}
+
+// validateMaxCost validates that max-cost is a positive budget, that pricing has
+// non-negative prices, and that both are only used with engines that support this feature
+func (c *Compiler) validateMaxCost(frontmatter map[string]any, engine CodingAgentEngine) error {
+	_, engineConfig := c.ExtractEngineConfig(frontmatter)
+	if engineConfig == nil || (engineConfig.MaxCost == 0 && engineConfig.Pricing == nil) {
+		// No max-cost specified (or explicitly unlimited), no validation needed
+		return nil
+	}
+
+	if engineConfig.MaxCost < 0 {
+		return fmt.Errorf("invalid max-cost: must be a positive amount in USD. Example:\nengine:\n  id: copilot-sdk\n  max-cost: 2.50")
+	}
+
+	if pricing := engineConfig.Pricing; pricing != nil && (pricing.Input < 0 || pricing.CachedInput < 0 || pricing.Output < 0) {
+		return fmt.Errorf("invalid pricing: input, cached-input and output must be non-negative prices in USD per million tokens. Example:\nengine:\n  id: copilot-sdk\n  max-cost: 2.50\n  pricing:\n    input: 3\n    cached-input: 0.3\n    output: 15")
+	}
+
+	if !engine.SupportsMaxCost() {
+		return fmt.Errorf("max-cost not supported: engine '%s' does not support the max-cost feature. Use engine: copilot-sdk or remove max-cost and pricing from your configuration. Example:\nengine:\n  id: copilot-sdk\n  max-cost: 2.50", engine.GetID())
+	}
+
+	return nil
+}
+
+// validateToolTimeoutPerCall validates that tool-timeout-per-call is positive and is only used
+// with engines that support this feature
+func (c *Compiler) validateToolTimeoutPerCall(frontmatter map[string]any, engine CodingAgentEngine) error {
+	_, engineConfig := c.ExtractEngineConfig(frontmatter)
+	if engineConfig == nil || engineConfig.ToolTimeoutPerCall == 0 {
+		// No tool-timeout-per-call specified, no validation needed
+		return nil
+	}
+
+	if engineConfig.ToolTimeoutPerCall < 0 {
+		return fmt.Errorf("invalid tool-timeout-per-call: must be a positive number of seconds. Example:\nengine:\n  id: copilot-sdk\n  tool-timeout-per-call: 60")
+	}
+
+	if !engine.SupportsCallTimeout() {
+		return fmt.Errorf("tool-timeout-per-call not supported: engine '%s' does not support the tool-timeout-per-call feature. Use engine: copilot-sdk or use tools.timeout instead. Example:\nengine:\n  id: copilot-sdk\n  tool-timeout-per-call: 60", engine.GetID())
+	}
+
+	return nil
+}
+
+// validateAllowedWhenSupport validates that gated MCP allowlists (allowed-when) are only used with
+// engines that enforce them; other engines would expose the gated tools unconditionally
+func (c *Compiler) validateAllowedWhenSupport(tools map[string]any, engine CodingAgentEngine) error {
+	if engine.SupportsAllowedWhen() {
+		return nil
+	}
+
+	for toolName, toolConfig := range tools {
+		if config, ok := toolConfig.(map[string]any); ok {
+			if _, hasAllowedWhen := config["allowed-when"]; hasAllowedWhen {
+				return fmt.Errorf("allowed-when not supported: tool '%s' uses allowed-when, which is not supported by engine '%s'. Use engine: copilot or remove allowed-when. Example:\nengine: copilot\ntools:\n  %s:\n    allowed: [\"search\"]\n    allowed-when: \"vars.ALLOW_EXTRA == 'true'\"", toolName, engine.GetID(), toolName)
+			}
+		}
+	}
+
+	return nil
+}
+
 // validateWebSearchSupport validates that web-search tool is only used with engines that support this feature
 func (c *Compiler) validateWebSearchSupport(tools map[string]any, engine CodingAgentEngine) {
 	// Check if web-search tool is requested
@@ -154,8 +269,9 @@ func (c *Compiler) validateWebSearchSupport(tools map[string]any, engine CodingA
 
 	// web-search is specified, check if the engine supports it
 	if !engine.SupportsWebSearch() {
-		fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("Engine '%s' does not support the web-search tool. See https://github.github.com/gh-aw/guides/web-search/ for alternatives.", engine.GetID())))
-		c.IncrementWarningCount()
+		warningMsg := fmt.Sprintf("Engine '%s' does not support the web-search tool. See https://github.github.com/gh-aw/guides/web-search/ for alternatives.", engine.GetID())
+		fmt.Fprintln(os.Stderr, console.FormatWarningMessage(warningMsg))
+		c.addWarning(warningMsg)
 	}
 }
 
@@ -234,7 +350,7 @@ func (c *Compiler) validateWorkflowRunBranches(workflowData *WorkflowData, markd
 	// In normal mode, this is a warning
 	formattedWarning := formatCompilerMessage(markdownPath, "warning", message)
 	fmt.Fprintln(os.Stderr, formattedWarning)
-	c.IncrementWarningCount()
+	c.addWarning(message)
 
 	return nil
 }
@@ -2,6 +2,7 @@ package workflow
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 	"sync"
 
@@ -39,9 +40,14 @@ type GitHubActionStep []string
 //   ├── SupportsToolsAllowlist()
 //   ├── SupportsHTTPTransport()
 //   ├── SupportsMaxTurns()
+//   ├── SupportsMaxTokens()
+//   ├── SupportsMaxCost()
+//   ├── SupportsCallTimeout()
+//   ├── SupportsAllowedWhen()
 //   ├── SupportsWebFetch()
 //   ├── SupportsWebSearch()
-//   └── SupportsFirewall()
+//   ├── SupportsFirewall()
+//   └── SupportsBashShell()
 //
 //   WorkflowExecutor (compilation - required)
 //   ├── GetDeclaredOutputFiles()
@@ -58,7 +64,8 @@ type GitHubActionStep []string
 //
 //   SecurityProvider (security features - optional)
 //   ├── GetDefaultDetectionModel()
-//   └── GetRequiredSecretNames()
+//   ├── GetRequiredSecretNames()
+//   └── GetRequiredVarNames()
 //
 //   CodingAgentEngine (composite - backward compatibility)
 //   └── Composes all above interfaces
@@ -116,6 +123,18 @@ type CapabilityProvider interface {
 	// SupportsMaxTurns returns true if this engine supports the max-turns feature
 	SupportsMaxTurns() bool
 
+	// SupportsMaxTokens returns true if this engine supports the max-tokens feature
+	SupportsMaxTokens() bool
+
+	// SupportsMaxCost returns true if this engine supports the max-cost budget feature
+	SupportsMaxCost() bool
+
+	// SupportsCallTimeout returns true if this engine supports the tool-timeout-per-call feature
+	SupportsCallTimeout() bool
+
+	// SupportsAllowedWhen returns true if this engine enforces allowed-when conditions on MCP server allowlists
+	SupportsAllowedWhen() bool
+
 	// SupportsWebFetch returns true if this engine has built-in support for the web-fetch tool
 	SupportsWebFetch() bool
 
@@ -130,6 +149,10 @@ type CapabilityProvider interface {
 	// When true, plugins can be installed using the engine's plugin install command
 	SupportsPlugins() bool
 
+	// SupportsBashShell returns true if this engine runs bash tool commands with the shell
+	// selected by tools.bash.shell (exported through the SHELL environment variable)
+	SupportsBashShell() bool
+
 	// SupportsLLMGateway returns the LLM gateway port number for this engine
 	// Returns the port number (e.g., 10000) if the engine supports an LLM gateway
 	// Returns -1 if the engine does not support an LLM gateway
@@ -184,6 +207,11 @@ type SecurityProvider interface {
 	// This includes engine-specific auth tokens and the MCP gateway API key when MCP servers are present
 	// Returns: slice of secret names (e.g., ["COPILOT_GITHUB_TOKEN", "MCP_GATEWAY_API_KEY"])
 	GetRequiredSecretNames(workflowData *WorkflowData) []string
+
+	// GetRequiredVarNames returns the list of repository variable names (vars.*) that this engine
+	// reads during execution, such as the variable providing the default model
+	// Returns: slice of variable names (e.g., ["GH_AW_MODEL_AGENT_COPILOT"])
+	GetRequiredVarNames(workflowData *WorkflowData) []string
 }
 
 // CodingAgentEngine is a composite interface that combines all focused interfaces
@@ -207,10 +235,15 @@ type BaseEngine struct {
 	supportsToolsAllowlist bool
 	supportsHTTPTransport  bool
 	supportsMaxTurns       bool
+	supportsMaxTokens      bool
+	supportsMaxCost        bool
+	supportsCallTimeout    bool
+	supportsAllowedWhen    bool
 	supportsWebFetch       bool
 	supportsWebSearch      bool
 	supportsFirewall       bool
 	supportsPlugins        bool
+	supportsBashShell      bool
 	supportsLLMGateway     bool
 }
 
@@ -242,6 +275,22 @@ func (e *BaseEngine) SupportsMaxTurns() bool {
 	return e.supportsMaxTurns
 }
 
+func (e *BaseEngine) SupportsMaxTokens() bool {
+	return e.supportsMaxTokens
+}
+
+func (e *BaseEngine) SupportsMaxCost() bool {
+	return e.supportsMaxCost
+}
+
+func (e *BaseEngine) SupportsCallTimeout() bool {
+	return e.supportsCallTimeout
+}
+
+func (e *BaseEngine) SupportsAllowedWhen() bool {
+	return e.supportsAllowedWhen
+}
+
 func (e *BaseEngine) SupportsWebFetch() bool {
 	return e.supportsWebFetch
 }
@@ -258,6 +307,10 @@ func (e *BaseEngine) SupportsPlugins() bool {
 	return e.supportsPlugins
 }
 
+func (e *BaseEngine) SupportsBashShell() bool {
+	return e.supportsBashShell
+}
+
 func (e *BaseEngine) SupportsLLMGateway() int {
 	// Engines that support LLM gateway must override this method
 	// to return their specific port number (e.g., 10000, 10001, 10002)
@@ -288,6 +341,12 @@ func (e *BaseEngine) GetRequiredSecretNames(workflowData *WorkflowData) []string
 	return []string{}
 }
 
+// GetRequiredVarNames returns an empty list by default
+// Engines that read repository variables override this to list them
+func (e *BaseEngine) GetRequiredVarNames(workflowData *WorkflowData) []string {
+	return []string{}
+}
+
 // convertStepToYAML converts a step map to YAML string - uses proper YAML serialization
 // This is a shared implementation inherited by all engines that embed BaseEngine
 func (e *BaseEngine) convertStepToYAML(stepMap map[string]any) (string, error) {
@@ -453,6 +512,59 @@ func (r *EngineRegistry) GetAllEngines() []CodingAgentEngine {
 	return engines
 }
 
+// EngineInfo describes a registered engine and its capabilities.
+// The capability fields mirror the BaseEngine capability flags.
+type EngineInfo struct {
+	ID                     string `json:"id"`
+	DisplayName            string `json:"display_name"`
+	Description            string `json:"description"`
+	Experimental           bool   `json:"experimental"`
+	SupportsToolsAllowlist bool   `json:"supports_tools_allowlist"`
+	SupportsHTTPTransport  bool   `json:"supports_http_transport"`
+	SupportsMaxTurns       bool   `json:"supports_max_turns"`
+	SupportsMaxTokens      bool   `json:"supports_max_tokens"`
+	SupportsMaxCost        bool   `json:"supports_max_cost"`
+	SupportsCallTimeout    bool   `json:"supports_call_timeout"`
+	SupportsAllowedWhen    bool   `json:"supports_allowed_when"`
+	SupportsWebFetch       bool   `json:"supports_web_fetch"`
+	SupportsWebSearch      bool   `json:"supports_web_search"`
+	SupportsFirewall       bool   `json:"supports_firewall"`
+	SupportsPlugins        bool   `json:"supports_plugins"`
+	SupportsBashShell      bool   `json:"supports_bash_shell"`
+	LLMGatewayPort         int    `json:"llm_gateway_port"` // LLM gateway port, or -1 if not supported
+}
+
+// ListEngines returns the ID, display name, experimental flag, and capabilities of all
+// registered engines, sorted by engine ID
+func (r *EngineRegistry) ListEngines() []EngineInfo {
+	engines := make([]EngineInfo, 0, len(r.engines))
+	for _, engine := range r.engines {
+		engines = append(engines, EngineInfo{
+			ID:                     engine.GetID(),
+			DisplayName:            engine.GetDisplayName(),
+			Description:            engine.GetDescription(),
+			Experimental:           engine.IsExperimental(),
+			SupportsToolsAllowlist: engine.SupportsToolsAllowlist(),
+			SupportsHTTPTransport:  engine.SupportsHTTPTransport(),
+			SupportsMaxTurns:       engine.SupportsMaxTurns(),
+			SupportsMaxTokens:      engine.SupportsMaxTokens(),
+			SupportsMaxCost:        engine.SupportsMaxCost(),
+			SupportsCallTimeout:    engine.SupportsCallTimeout(),
+			SupportsAllowedWhen:    engine.SupportsAllowedWhen(),
+			SupportsWebFetch:       engine.SupportsWebFetch(),
+			SupportsWebSearch:      engine.SupportsWebSearch(),
+			SupportsFirewall:       engine.SupportsFirewall(),
+			SupportsPlugins:        engine.SupportsPlugins(),
+			SupportsBashShell:      engine.SupportsBashShell(),
+			LLMGatewayPort:         engine.SupportsLLMGateway(),
+		})
+	}
+	sort.Slice(engines, func(i, j int) bool {
+		return engines[i].ID < engines[j].ID
+	})
+	return engines
+}
+
 // GetCopilotAgentPlaywrightTools returns the list of playwright tools available in the copilot agent
 // This matches the tools available in the copilot agent MCP server configuration
 // This is a shared function used by all engines for consistent playwright tool configuration
@@ -290,6 +290,7 @@ func TestBaseEngineImplementsAllInterfaces(t *testing.T) {
 		Tools:       map[string]any{},
 	}
 	assert.Empty(t, base.GetRequiredSecretNames(workflowData))
+	assert.Empty(t, base.GetRequiredVarNames(workflowData))
 }
 
 // TestEngineCapabilityVariety validates that different engines have different capabilities
@@ -342,6 +343,27 @@ func TestEngineCapabilityVariety(t *testing.T) {
 	})
 }
 
+// TestEngineRegistryListEngines validates that ListEngines reports every engine with its capabilities
+func TestEngineRegistryListEngines(t *testing.T) {
+	engines := NewEngineRegistry().ListEngines()
+
+	expected := []EngineInfo{
+		{ID: "claude", DisplayName: "Claude Code", SupportsToolsAllowlist: true, SupportsHTTPTransport: true, SupportsMaxTurns: true, SupportsWebFetch: true, SupportsWebSearch: true, SupportsFirewall: true, LLMGatewayPort: 10000},
+		{ID: "codex", DisplayName: "Codex", SupportsToolsAllowlist: true, SupportsHTTPTransport: true, SupportsWebSearch: true, SupportsFirewall: true, LLMGatewayPort: 10001},
+		{ID: "copilot", DisplayName: "GitHub Copilot CLI", SupportsToolsAllowlist: true, SupportsHTTPTransport: true, SupportsAllowedWhen: true, SupportsWebFetch: true, SupportsFirewall: true, SupportsPlugins: true, LLMGatewayPort: -1},
+		{ID: "copilot-sdk", DisplayName: "GitHub Copilot SDK", Experimental: true, SupportsToolsAllowlist: true, SupportsHTTPTransport: true, SupportsMaxTokens: true, SupportsMaxCost: true, SupportsCallTimeout: true, SupportsAllowedWhen: true, SupportsWebFetch: true, LLMGatewayPort: 10002},
+		{ID: "custom", DisplayName: "Custom Steps", SupportsMaxTurns: true, SupportsBashShell: true, LLMGatewayPort: -1},
+	}
+
+	require.Len(t, engines, len(expected), "All registered engines should be listed")
+	for i, want := range expected {
+		got := engines[i]
+		assert.NotEmpty(t, got.Description, "Engine %s should have a description", want.ID)
+		got.Description = ""
+		assert.Equal(t, want, got, "Engine info for %s should match its capabilities", want.ID)
+	}
+}
+
 // TestEngineRegistryAcceptsEngineInterface validates that EngineRegistry works with the Engine interface
 func TestEngineRegistryAcceptsEngineInterface(t *testing.T) {
 	registry := NewEngineRegistry()
@@ -0,0 +1,119 @@
+package workflow
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/parser"
+)
+
+var bashShellLog = logger.New("workflow:bash_shell")
+
+// DefaultBashShell is the shell used by the bash tool when tools.bash.shell is not set
+const DefaultBashShell = "bash"
+
+// supportedBashShells maps each supported tools.bash.shell value to the executable
+// exported to the agent via the SHELL environment variable
+var supportedBashShells = map[string]string{
+	"bash": "/bin/bash",
+	"sh":   "/bin/sh",
+	"pwsh": "/usr/bin/pwsh",
+}
+
+// extractBashShell extracts the shell selection from the object form of tools.bash
+// (bash: { allowed: [...], shell: sh }) and rewrites tools["bash"] to the list form
+// used by the rest of the compiler. Returns "" when no shell is configured.
+func extractBashShell(tools map[string]any) (string, error) {
+	bashObj, ok := tools["bash"].(map[string]any)
+	if !ok {
+		return "", nil
+	}
+
+	// Rewrite to the list form; an object without allowed keeps the default safe commands
+	// rather than enabling every command
+	if allowed, hasAllowed := bashObj["allowed"]; hasAllowed {
+		allowedList, ok := allowed.([]any)
+		if !ok {
+			return "", fmt.Errorf("tools.bash.allowed must be an array of commands, got %T", allowed)
+		}
+		tools["bash"] = allowedList
+	} else {
+		defaultCommands := make([]any, len(constants.DefaultBashTools))
+		for i, cmd := range constants.DefaultBashTools {
+			defaultCommands[i] = cmd
+		}
+		tools["bash"] = defaultCommands
+	}
+
+	shellValue, hasShell := bashObj["shell"]
+	if !hasShell {
+		return "", nil
+	}
+	shell, ok := shellValue.(string)
+	if !ok {
+		return "", fmt.Errorf("tools.bash.shell must be a string, got %T", shellValue)
+	}
+	if err := validateBashShell(shell); err != nil {
+		return "", err
+	}
+
+	bashShellLog.Printf("Extracted tools.bash.shell: %s", shell)
+	return shell, nil
+}
+
+// validateBashShell validates that shell is one of the supported bash tool shells
+func validateBashShell(shell string) error {
+	if _, ok := supportedBashShells[shell]; ok {
+		return nil
+	}
+
+	validShells := make([]string, 0, len(supportedBashShells))
+	for name := range supportedBashShells {
+		validShells = append(validShells, name)
+	}
+	sort.Strings(validShells)
+
+	errMsg := fmt.Sprintf("invalid tools.bash.shell: %s. Valid shells are: %s.", shell, strings.Join(validShells, ", "))
+	if suggestions := parser.FindClosestMatches(shell, validShells, 1); len(suggestions) > 0 {
+		errMsg += fmt.Sprintf("\n\nDid you mean: %s?", suggestions[0])
+	}
+	errMsg += "\n\nExample:\ntools:\n  bash:\n    allowed: [\"echo\", \"ls\"]\n    shell: sh"
+
+	return fmt.Errorf("%s", errMsg)
+}
+
+// validateBashShellSupport validates that a non-default bash tool shell is only used with
+// engines that run bash tool commands with the shell from the SHELL environment variable.
+// The other engines pick their shell themselves and would silently ignore the selection.
+func validateBashShellSupport(shell string, engine CodingAgentEngine) error {
+	if shell == "" || shell == DefaultBashShell || engine.SupportsBashShell() {
+		return nil
+	}
+	return fmt.Errorf("tools.bash.shell not supported: engine '%s' does not run bash tool commands with the selected shell. Use engine: custom or remove tools.bash.shell. Example:\nengine: custom\ntools:\n  bash:\n    allowed: [\"echo\", \"ls\"]\n    shell: %s", engine.GetID(), shell)
+}
+
+// applyBashShellEnv exports the selected bash tool shell to the agent through the SHELL
+// environment variable. The default shell leaves the environment unchanged, and an
+// explicit SHELL in engine.env takes precedence.
+func applyBashShellEnv(workflowData *WorkflowData) {
+	if workflowData.BashShell == "" || workflowData.BashShell == DefaultBashShell {
+		return
+	}
+
+	if workflowData.EngineConfig == nil {
+		workflowData.EngineConfig = &EngineConfig{ID: workflowData.AI}
+	}
+	if workflowData.EngineConfig.Env == nil {
+		workflowData.EngineConfig.Env = make(map[string]string)
+	}
+	if _, exists := workflowData.EngineConfig.Env["SHELL"]; exists {
+		bashShellLog.Print("SHELL already set in engine.env, keeping it")
+		return
+	}
+
+	workflowData.EngineConfig.Env["SHELL"] = supportedBashShells[workflowData.BashShell]
+	bashShellLog.Printf("Exporting SHELL=%s for bash tool", workflowData.EngineConfig.Env["SHELL"])
+}
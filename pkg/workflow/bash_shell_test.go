@@ -0,0 +1,213 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtractBashShell(t *testing.T) {
+	tests := []struct {
+		name          string
+		bash          any
+		expectedShell string
+		expectedBash  any
+		errContains   string
+	}{
+		{
+			name:          "object form with shell and allowed commands",
+			bash:          map[string]any{"allowed": []any{"echo", "ls"}, "shell": "sh"},
+			expectedShell: "sh",
+			expectedBash:  []any{"echo", "ls"},
+		},
+		{
+			name:          "object form without allowed keeps the default commands",
+			bash:          map[string]any{"shell": "pwsh"},
+			expectedShell: "pwsh",
+			expectedBash:  []any{"echo", "ls", "pwd", "cat", "head", "tail", "grep", "wc", "sort", "uniq", "date", "yq"},
+		},
+		{
+			name:         "list form is left unchanged",
+			bash:         []any{"echo"},
+			expectedBash: []any{"echo"},
+		},
+		{
+			name:        "unknown shell",
+			bash:        map[string]any{"shell": "bsah"},
+			errContains: "Did you mean: bash?",
+		},
+		{
+			name:        "non-string shell",
+			bash:        map[string]any{"shell": 1},
+			errContains: "tools.bash.shell must be a string",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tools := map[string]any{"bash": tt.bash}
+			shell, err := extractBashShell(tools)
+			if tt.errContains != "" {
+				require.Error(t, err, "Invalid shell configuration should error")
+				assert.Contains(t, err.Error(), tt.errContains, "Error message should match")
+				return
+			}
+			require.NoError(t, err, "Valid shell configuration should not error")
+			assert.Equal(t, tt.expectedShell, shell, "Extracted shell should match")
+			assert.Equal(t, tt.expectedBash, tools["bash"], "tools.bash should be normalized")
+		})
+	}
+}
+
+func TestValidateBashShellListsValidShells(t *testing.T) {
+	err := validateBashShell("zsh")
+	require.Error(t, err, "Unsupported shell should error")
+	assert.Contains(t, err.Error(), "Valid shells are: bash, pwsh, sh", "Error should list the supported shells")
+}
+
+func TestApplyBashShellEnv(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     *WorkflowData
+		expected map[string]string
+	}{
+		{
+			name:     "default shell leaves env unchanged",
+			data:     &WorkflowData{AI: "copilot", BashShell: "bash"},
+			expected: nil,
+		},
+		{
+			name:     "non-default shell exports SHELL",
+			data:     &WorkflowData{AI: "copilot", BashShell: "sh"},
+			expected: map[string]string{"SHELL": "/bin/sh"},
+		},
+		{
+			name:     "engine.env SHELL takes precedence",
+			data:     &WorkflowData{BashShell: "pwsh", EngineConfig: &EngineConfig{ID: "claude", Env: map[string]string{"SHELL": "/bin/zsh"}}},
+			expected: map[string]string{"SHELL": "/bin/zsh"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			applyBashShellEnv(tt.data)
+			if tt.expected == nil {
+				assert.Nil(t, tt.data.EngineConfig, "Engine config should not be created for the default shell")
+				return
+			}
+			require.NotNil(t, tt.data.EngineConfig, "Engine config should be set")
+			assert.Equal(t, tt.expected, tt.data.EngineConfig.Env, "Engine env should match")
+		})
+	}
+}
+
+func TestCompileWorkflowWithBashShell(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "bash-shell-test")
+	content := `---
+on: workflow_dispatch
+permissions:
+  contents: read
+engine:
+  id: custom
+  steps:
+    - name: Run agent
+      run: ./agent.sh
+tools:
+  bash:
+    allowed: ["echo", "ls"]
+    shell: sh
+---
+
+# Test Workflow
+`
+	testFile := filepath.Join(tmpDir, "bash-shell.md")
+	require.NoError(t, os.WriteFile(testFile, []byte(content), 0644), "Failed to write test file")
+
+	compiler := NewCompiler()
+	require.NoError(t, compiler.CompileWorkflow(testFile), "Workflow with tools.bash.shell should compile")
+
+	lockContent, err := os.ReadFile(filepath.Join(tmpDir, "bash-shell.lock.yml"))
+	require.NoError(t, err, "Failed to read lock file")
+	assert.Contains(t, string(lockContent), "SHELL: /bin/sh", "Agent step should export the selected shell")
+}
+
+func TestCompileWorkflowWithBashShellKeepsDefaultCommands(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "bash-shell-default-test")
+	content := `---
+on: workflow_dispatch
+permissions:
+  contents: read
+engine: copilot
+tools:
+  bash:
+    shell: bash
+---
+
+# Test Workflow
+`
+	testFile := filepath.Join(tmpDir, "bash-shell.md")
+	require.NoError(t, os.WriteFile(testFile, []byte(content), 0644), "Failed to write test file")
+
+	require.NoError(t, NewCompiler().CompileWorkflow(testFile), "Workflow with tools.bash.shell should compile")
+
+	lockContent, err := os.ReadFile(filepath.Join(tmpDir, "bash-shell.lock.yml"))
+	require.NoError(t, err, "Failed to read lock file")
+	assert.Contains(t, string(lockContent), "# --allow-tool shell(cat)", "Omitting allowed should keep the default commands")
+	assert.NotContains(t, string(lockContent), "--allow-all-tools", "Omitting allowed should not allow every command")
+}
+
+func TestCompileWorkflowWithBashShellUnsupportedEngine(t *testing.T) {
+	for _, engine := range []string{"copilot", "claude", "codex"} {
+		t.Run(engine, func(t *testing.T) {
+			tmpDir := testutil.TempDir(t, "bash-shell-engine-test")
+			content := `---
+on: workflow_dispatch
+permissions:
+  contents: read
+engine: ` + engine + `
+tools:
+  bash:
+    allowed: ["echo"]
+    shell: sh
+---
+
+# Test Workflow
+`
+			testFile := filepath.Join(tmpDir, "bash-shell.md")
+			require.NoError(t, os.WriteFile(testFile, []byte(content), 0644), "Failed to write test file")
+
+			err := NewCompiler().CompileWorkflow(testFile)
+			require.Error(t, err, "Engines that ignore SHELL should reject a non-default shell")
+			assert.Contains(t, err.Error(), "tools.bash.shell not supported", "Error should explain the engine does not honor the shell")
+		})
+	}
+}
+
+func TestCompileWorkflowWithUnknownBashShell(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "bash-shell-invalid-test")
+	content := `---
+on: workflow_dispatch
+permissions:
+  contents: read
+engine: copilot
+tools:
+  bash:
+    shell: psh
+---
+
+# Test Workflow
+`
+	testFile := filepath.Join(tmpDir, "bash-shell.md")
+	require.NoError(t, os.WriteFile(testFile, []byte(content), 0644), "Failed to write test file")
+
+	err := NewCompiler().CompileWorkflow(testFile)
+	require.Error(t, err, "Unknown shell should fail compilation")
+	assert.Contains(t, err.Error(), "invalid tools.bash.shell: psh", "Error should name the invalid shell")
+	assert.Contains(t, err.Error(), "Did you mean:", "Error should include a suggestion")
+}
@@ -28,6 +28,7 @@ type CacheMemoryEntry struct {
 	RestoreOnly       bool     `yaml:"restore-only,omitempty"`       // if true, only restore cache without saving
 	Scope             string   `yaml:"scope,omitempty"`              // scope for restore keys: "workflow" (default) or "repo"
 	AllowedExtensions []string `yaml:"allowed-extensions,omitempty"` // allowed file extensions (default: [".json", ".jsonl", ".txt", ".md", ".csv"])
+	MaxSizeMB         int      `yaml:"max-size-mb,omitempty"`        // maximum total size in megabytes before the oldest files are removed (0 = unlimited)
 }
 
 // generateDefaultCacheKey generates a default cache key for a given cache ID
@@ -129,9 +130,43 @@ func parseCacheMemoryEntry(cacheMap map[string]any, defaultID string) (CacheMemo
 		entry.AllowedExtensions = constants.DefaultAllowedMemoryExtensions
 	}
 
+	// Parse max-size-mb field
+	if maxSize, exists := cacheMap["max-size-mb"]; exists {
+		if maxSizeInt, ok := maxSize.(int); ok {
+			entry.MaxSizeMB = maxSizeInt
+		} else if maxSizeFloat, ok := maxSize.(float64); ok {
+			entry.MaxSizeMB = int(maxSizeFloat)
+		} else if maxSizeUint64, ok := maxSize.(uint64); ok {
+			entry.MaxSizeMB = int(maxSizeUint64)
+		}
+		// Validate max-size-mb bounds (GitHub Actions caches are limited to 10 GB per repository)
+		if err := validateIntRange(entry.MaxSizeMB, 1, 10240, "max-size-mb"); err != nil {
+			return entry, err
+		}
+	}
+
 	return entry, nil
 }
 
+// generateCacheMemorySizeLimitStep generates a step that removes the least recently modified
+// files from a cache-memory directory until it fits within the configured max-size-mb
+func generateCacheMemorySizeLimitStep(cache CacheMemoryEntry, cacheDir, stepName string) string {
+	var step strings.Builder
+	fmt.Fprintf(&step, "      - name: %s\n", stepName)
+	step.WriteString("        if: always()\n")
+	fmt.Fprintf(&step, "        uses: %s\n", GetActionPin("actions/github-script"))
+	step.WriteString("        env:\n")
+	fmt.Fprintf(&step, "          GH_AW_CACHE_DIR: %s\n", cacheDir)
+	fmt.Fprintf(&step, "          GH_AW_CACHE_MAX_SIZE_MB: \"%d\"\n", cache.MaxSizeMB)
+	step.WriteString("        with:\n")
+	step.WriteString("          script: |\n")
+	step.WriteString("            const { setupGlobals } = require('/opt/gh-aw/actions/setup_globals.cjs');\n")
+	step.WriteString("            setupGlobals(core, github, context, exec, io);\n")
+	step.WriteString("            const { enforceMemorySizeLimit } = require('/opt/gh-aw/actions/enforce_memory_size_limit.cjs');\n")
+	step.WriteString("            enforceMemorySizeLimit(process.env.GH_AW_CACHE_DIR, parseInt(process.env.GH_AW_CACHE_MAX_SIZE_MB, 10), 'cache');\n")
+	return step.String()
+}
+
 // extractCacheMemoryConfig extracts cache-memory configuration from tools section
 // Updated to use ToolsConfig instead of map[string]any
 func (c *Compiler) extractCacheMemoryConfig(toolsConfig *ToolsConfig) (*CacheMemoryConfig, error) {
@@ -448,7 +483,7 @@ func generateCacheMemorySteps(builder *strings.Builder, data *WorkflowData) {
 	}
 }
 
-// generateCacheMemoryValidation generates validation steps for cache-memory file types
+// generateCacheMemoryValidation generates validation steps for cache-memory file types and size limits
 // This should be called after agent execution to validate files before upload/save
 func generateCacheMemoryValidation(builder *strings.Builder, data *WorkflowData) {
 	if data.CacheMemoryConfig == nil || len(data.CacheMemoryConfig.Caches) == 0 {
@@ -466,12 +501,6 @@ func generateCacheMemoryValidation(builder *strings.Builder, data *WorkflowData)
 			continue
 		}
 
-		// Skip validation step if allowed extensions is empty (means all files are allowed)
-		if len(cache.AllowedExtensions) == 0 {
-			cacheLog.Printf("Skipping validation step for cache %s (empty allowed-extensions means all files are allowed)", cache.ID)
-			continue
-		}
-
 		// Default cache uses /tmp/gh-aw/cache-memory/ for backward compatibility
 		// Other caches use /tmp/gh-aw/cache-memory-{id}/ to prevent overlaps
 		var cacheDir string
@@ -481,6 +510,21 @@ func generateCacheMemoryValidation(builder *strings.Builder, data *WorkflowData)
 			cacheDir = fmt.Sprintf("/tmp/gh-aw/cache-memory-%s", cache.ID)
 		}
 
+		// Enforce the size limit before the cache is uploaded or saved
+		if cache.MaxSizeMB > 0 {
+			stepName := "Enforce cache-memory size limit"
+			if !useBackwardCompatiblePaths {
+				stepName = fmt.Sprintf("Enforce cache-memory size limit (%s)", cache.ID)
+			}
+			builder.WriteString(generateCacheMemorySizeLimitStep(cache, cacheDir, stepName))
+		}
+
+		// Skip validation step if allowed extensions is empty (means all files are allowed)
+		if len(cache.AllowedExtensions) == 0 {
+			cacheLog.Printf("Skipping validation step for cache %s (empty allowed-extensions means all files are allowed)", cache.ID)
+			continue
+		}
+
 		// Prepare allowed extensions array for JavaScript
 		allowedExtsJSON, _ := json.Marshal(cache.AllowedExtensions)
 
@@ -579,6 +623,9 @@ func buildCacheMemoryPromptSection(config *CacheMemoryConfig) *PromptSection {
 		if cache.Description != "" {
 			descriptionText = " " + cache.Description
 		}
+		if cache.MaxSizeMB > 0 {
+			descriptionText += fmt.Sprintf(" Keep the folder under %d MB; the least recently modified files are removed when it exceeds this limit.", cache.MaxSizeMB)
+		}
 
 		// Build allowed extensions text
 		allowedExtsText := strings.Join(cache.AllowedExtensions, ", ")
@@ -609,10 +656,14 @@ func buildCacheMemoryPromptSection(config *CacheMemoryConfig) *PromptSection {
 		} else {
 			cacheDir = fmt.Sprintf("/tmp/gh-aw/cache-memory-%s/", cache.ID)
 		}
+		sizeLimitText := ""
+		if cache.MaxSizeMB > 0 {
+			sizeLimitText = fmt.Sprintf(" (max %d MB)", cache.MaxSizeMB)
+		}
 		if cache.Description != "" {
-			fmt.Fprintf(&cacheList, "- **%s**: `%s` - %s\n", cache.ID, cacheDir, cache.Description)
+			fmt.Fprintf(&cacheList, "- **%s**: `%s`%s - %s\n", cache.ID, cacheDir, sizeLimitText, cache.Description)
 		} else {
-			fmt.Fprintf(&cacheList, "- **%s**: `%s`\n", cache.ID, cacheDir)
+			fmt.Fprintf(&cacheList, "- **%s**: `%s`%s\n", cache.ID, cacheDir, sizeLimitText)
 		}
 	}
 
@@ -748,6 +799,11 @@ func (c *Compiler) buildUpdateCacheMemoryJob(data *WorkflowData, threatDetection
 			steps = append(steps, generateInlineGitHubScriptStep(stepName, validationScript.String(), ""))
 		}
 
+		// Enforce the size limit before saving
+		if cache.MaxSizeMB > 0 {
+			steps = append(steps, generateCacheMemorySizeLimitStep(cache, cacheDir, fmt.Sprintf("Enforce cache-memory size limit (%s)", cache.ID)))
+		}
+
 		// Generate cache key (same logic as in generateCacheMemorySteps)
 		cacheKey := cache.Key
 		if cacheKey == "" {
@@ -801,12 +857,12 @@ func (c *Compiler) buildUpdateCacheMemoryJob(data *WorkflowData, threatDetection
 	permissions := NewPermissionsEmpty().RenderToYAML() // Default: no special permissions needed
 	if setupActionRef != "" && len(c.generateCheckoutActionsFolder(data)) > 0 {
 		// Need contents: read to checkout the actions folder
-		perms := NewPermissionsContentsRead()
+		perms := NewPermissionsBuilder().Read(PermissionContents).Build()
 		permissions = perms.RenderToYAML()
 	}
 
 	job := &Job{
-		Name:        "update_cache_memory",
+		Name:        string(constants.UpdateCacheMemoryJobName),
 		DisplayName: "", // No display name - job ID is sufficient
 		RunsOn:      "runs-on: ubuntu-latest",
 		If:          jobCondition,
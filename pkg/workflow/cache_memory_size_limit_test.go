@@ -0,0 +1,101 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCacheMemoryEntryMaxSize(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    any
+		expected int
+		wantErr  bool
+	}{
+		{name: "int", value: 50, expected: 50},
+		{name: "float", value: float64(25), expected: 25},
+		{name: "uint64", value: uint64(100), expected: 100},
+		{name: "zero is rejected", value: 0, wantErr: true},
+		{name: "above cache quota is rejected", value: 20000, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entry, err := parseCacheMemoryEntry(map[string]any{"max-size-mb": tt.value}, "default")
+			if tt.wantErr {
+				require.Error(t, err, "Out of range max-size-mb should be rejected")
+				assert.Contains(t, err.Error(), "max-size-mb", "Error should name the field")
+				return
+			}
+			require.NoError(t, err, "Valid max-size-mb should be parsed")
+			assert.Equal(t, tt.expected, entry.MaxSizeMB, "max-size-mb should match")
+		})
+	}
+
+	entry, err := parseCacheMemoryEntry(map[string]any{}, "default")
+	require.NoError(t, err, "Entry without max-size-mb should be parsed")
+	assert.Zero(t, entry.MaxSizeMB, "Size should be unlimited by default")
+}
+
+func TestBuildCacheMemoryPromptSection_SizeLimit(t *testing.T) {
+	t.Run("multiple caches", func(t *testing.T) {
+		config := &CacheMemoryConfig{
+			Caches: []CacheMemoryEntry{
+				{ID: "default", MaxSizeMB: 50, Description: "Shared notes", AllowedExtensions: []string{".md"}},
+				{ID: "session", AllowedExtensions: []string{".md"}},
+			},
+		}
+
+		section := buildCacheMemoryPromptSection(config)
+
+		require.NotNil(t, section, "Should return a prompt section")
+		cacheList := section.EnvVars["GH_AW_CACHE_LIST"]
+		assert.Contains(t, cacheList, "- **default**: `/tmp/gh-aw/cache-memory/` (max 50 MB) - Shared notes", "Cache list should include the size limit")
+		assert.Contains(t, cacheList, "- **session**: `/tmp/gh-aw/cache-memory-session/`\n", "Caches without a limit should not show one")
+	})
+
+	t.Run("single default cache", func(t *testing.T) {
+		config := &CacheMemoryConfig{Caches: []CacheMemoryEntry{{ID: "default", MaxSizeMB: 10}}}
+
+		section := buildCacheMemoryPromptSection(config)
+
+		require.NotNil(t, section, "Should return a prompt section")
+		assert.Contains(t, section.EnvVars["GH_AW_CACHE_DESCRIPTION"], "under 10 MB", "Description should tell the agent the limit")
+	})
+}
+
+func TestCacheMemorySizeLimitEnforcement(t *testing.T) {
+	data := &WorkflowData{
+		CacheMemoryConfig: &CacheMemoryConfig{
+			Caches: []CacheMemoryEntry{
+				{ID: "default", Key: "memory-default", MaxSizeMB: 25, AllowedExtensions: []string{".json"}},
+				{ID: "unbounded", Key: "memory-unbounded", AllowedExtensions: []string{".json"}},
+			},
+		},
+	}
+
+	t.Run("update job", func(t *testing.T) {
+		job, err := NewCompiler().buildUpdateCacheMemoryJob(data, true)
+		require.NoError(t, err, "Update job should be built")
+		require.NotNil(t, job, "Update job should be created when threat detection is enabled")
+
+		steps := strings.Join(job.Steps, "")
+		assert.Contains(t, steps, "- name: Enforce cache-memory size limit (default)", "Update job should enforce the size limit")
+		assert.Contains(t, steps, `GH_AW_CACHE_MAX_SIZE_MB: "25"`, "Enforcement env var should be set on the update job")
+		assert.NotContains(t, steps, "Enforce cache-memory size limit (unbounded)", "Caches without a limit should not be truncated")
+		assert.Less(t, strings.Index(steps, "Enforce cache-memory size limit (default)"), strings.Index(steps, "Save cache-memory to cache (default)"), "Size limit should be enforced before saving")
+	})
+
+	t.Run("agent job", func(t *testing.T) {
+		var builder strings.Builder
+		generateCacheMemoryValidation(&builder, data)
+		output := builder.String()
+		assert.Contains(t, output, "GH_AW_CACHE_DIR: /tmp/gh-aw/cache-memory\n", "Agent job should enforce the limit on the cache directory")
+		assert.Contains(t, output, "enforce_memory_size_limit.cjs", "Agent job should run the enforcement script")
+	})
+}
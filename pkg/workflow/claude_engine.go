@@ -61,6 +61,12 @@ func (e *ClaudeEngine) GetRequiredSecretNames(workflowData *WorkflowData) []stri
 	return secrets
 }
 
+// GetRequiredVarNames returns the repository variables read by the Claude engine
+// The default model variable is only read when engine.model is not set
+func (e *ClaudeEngine) GetRequiredVarNames(workflowData *WorkflowData) []string {
+	return getModelVarNames(workflowData, constants.EnvVarModelAgentClaude, constants.EnvVarModelDetectionClaude)
+}
+
 func (e *ClaudeEngine) GetInstallationSteps(workflowData *WorkflowData) []GitHubActionStep {
 	claudeLog.Printf("Generating installation steps for Claude engine: workflow=%s", workflowData.Name)
 
@@ -268,11 +274,6 @@ func (e *ClaudeEngine) GetExecutionSteps(workflowData *WorkflowData, logFile str
 		// Build the AWF-wrapped command
 		firewallConfig := getFirewallConfig(workflowData)
 		agentConfig := getAgentConfig(workflowData)
-		var awfLogLevel = "info"
-		if firewallConfig != nil && firewallConfig.LogLevel != "" {
-			awfLogLevel = firewallConfig.LogLevel
-		}
-
 		// Get allowed domains (Claude defaults + network permissions + HTTP MCP server URLs + runtime ecosystem domains)
 		allowedDomains := GetClaudeAllowedDomainsWithToolsAndRuntimes(workflowData.NetworkPermissions, workflowData.Tools, workflowData.Runtimes)
 
@@ -314,7 +315,7 @@ func (e *ClaudeEngine) GetExecutionSteps(workflowData *WorkflowData, logFile str
 			claudeLog.Printf("Added blocked domains: %s", blockedDomains)
 		}
 
-		awfArgs = append(awfArgs, "--log-level", awfLogLevel)
+		awfArgs = append(awfArgs, "--log-level", awfLogLevelArg)
 		awfArgs = append(awfArgs, "--proxy-logs-dir", "/tmp/gh-aw/sandbox/firewall/logs")
 
 		// Add --enable-host-access when MCP servers are configured (gateway is used)
@@ -449,6 +450,11 @@ func (e *ClaudeEngine) GetExecutionSteps(workflowData *WorkflowData, logFile str
 	// Add GH_AW_SAFE_OUTPUTS if output is needed
 	applySafeOutputEnvToMap(env, workflowData)
 
+	// Add GH_AW_FIREWALL_LOG_LEVEL for the AWF --log-level argument
+	if isFirewallEnabled(workflowData) {
+		applyFirewallLogLevelEnv(env, workflowData)
+	}
+
 	// Add GH_AW_STARTUP_TIMEOUT environment variable (in seconds) if startup-timeout is specified
 	if workflowData.ToolsStartupTimeout > 0 {
 		env["GH_AW_STARTUP_TIMEOUT"] = fmt.Sprintf("%d", workflowData.ToolsStartupTimeout)
@@ -482,6 +488,9 @@ func (e *ClaudeEngine) GetExecutionSteps(workflowData *WorkflowData, logFile str
 			env[key] = value
 		}
 	}
+	for key, value := range engineEnvFilesStepEnv(workflowData) {
+		env[key] = value
+	}
 
 	// Add custom environment variables from agent config
 	agentConfig := getAgentConfig(workflowData)
@@ -26,6 +26,7 @@ func (e *ClaudeEngine) ParseLogMetrics(logContent string, verbose bool) LogMetri
 			metrics.Turns = resultMetrics.Turns
 			metrics.ToolCalls = resultMetrics.ToolCalls         // Copy tool calls
 			metrics.ToolSequences = resultMetrics.ToolSequences // Copy tool sequences
+			metrics.ToolSequenceTurns = resultMetrics.ToolSequenceTurns
 		}
 	}
 
@@ -232,6 +233,8 @@ func (e *ClaudeEngine) parseClaudeJSONLog(logContent string, verbose bool) LogMe
 	// Look for the result entry with type: "result"
 	toolCallMap := make(map[string]*ToolCallInfo) // Track tool calls across entries
 	var currentSequence []string                  // Track tool sequence within current context
+	var currentSequenceTurn int                   // Assistant message in which the current sequence started
+	assistantMessages := 0
 
 	for _, entry := range logEntries {
 		if entryType, exists := entry["type"]; exists {
@@ -282,6 +285,7 @@ func (e *ClaudeEngine) parseClaudeJSONLog(logContent string, verbose bool) LogMe
 				}
 				break
 			} else if typeStr == "assistant" {
+				assistantMessages++
 				// Parse tool_use entries for tool call statistics and sequence
 				if message, exists := entry["message"]; exists {
 					if messageMap, ok := message.(map[string]any); ok {
@@ -289,6 +293,9 @@ func (e *ClaudeEngine) parseClaudeJSONLog(logContent string, verbose bool) LogMe
 							if contentArray, ok := content.([]any); ok {
 								sequenceInMessage := e.parseToolCallsWithSequence(contentArray, toolCallMap)
 								if len(sequenceInMessage) > 0 {
+									if len(currentSequence) == 0 {
+										currentSequenceTurn = assistantMessages
+									}
 									currentSequence = append(currentSequence, sequenceInMessage...)
 								}
 							}
@@ -313,7 +320,7 @@ func (e *ClaudeEngine) parseClaudeJSONLog(logContent string, verbose bool) LogMe
 	}
 
 	// Finalize tool calls and sequences using shared helper
-	FinalizeToolCallsAndSequence(&metrics, toolCallMap, currentSequence)
+	FinalizeToolCallsAndSequence(&metrics, toolCallMap, currentSequence, currentSequenceTurn)
 
 	if verbose && len(metrics.ToolSequences) > 0 {
 		totalTools := 0
@@ -147,8 +147,10 @@ var closeEntityRegistry = []closeEntityDefinition{
 		OutputURLKey:     "issue_url",
 		EventNumberPath1: "github.event.issue.number",
 		EventNumberPath2: "github.event.comment.issue.number",
-		PermissionsFunc:  NewPermissionsContentsReadIssuesWrite,
-		Logger:           logger.New("workflow:close_issue"),
+		PermissionsFunc: func() *Permissions {
+			return NewPermissionsBuilder().Read(PermissionContents).Write(PermissionIssues).Build()
+		},
+		Logger: logger.New("workflow:close_issue"),
 	},
 	{
 		EntityType:       CloseEntityPullRequest,
@@ -160,8 +162,10 @@ var closeEntityRegistry = []closeEntityDefinition{
 		OutputURLKey:     "pull_request_url",
 		EventNumberPath1: "github.event.pull_request.number",
 		EventNumberPath2: "github.event.comment.pull_request.number",
-		PermissionsFunc:  NewPermissionsContentsReadPRWrite,
-		Logger:           logger.New("workflow:close_pull_request"),
+		PermissionsFunc: func() *Permissions {
+			return NewPermissionsBuilder().Read(PermissionContents).Write(PermissionPullRequests).Build()
+		},
+		Logger: logger.New("workflow:close_pull_request"),
 	},
 	{
 		EntityType:       CloseEntityDiscussion,
@@ -173,8 +177,10 @@ var closeEntityRegistry = []closeEntityDefinition{
 		OutputURLKey:     "discussion_url",
 		EventNumberPath1: "github.event.discussion.number",
 		EventNumberPath2: "github.event.comment.discussion.number",
-		PermissionsFunc:  NewPermissionsContentsReadDiscussionsWrite,
-		Logger:           logger.New("workflow:close_discussion"),
+		PermissionsFunc: func() *Permissions {
+			return NewPermissionsBuilder().Read(PermissionContents).Write(PermissionDiscussions).Build()
+		},
+		Logger: logger.New("workflow:close_discussion"),
 	},
 }
 
@@ -72,6 +72,12 @@ func (e *CodexEngine) GetRequiredSecretNames(workflowData *WorkflowData) []strin
 	return secrets
 }
 
+// GetRequiredVarNames returns the repository variables read by the Codex engine
+// The default model variable is only read when engine.model is not set
+func (e *CodexEngine) GetRequiredVarNames(workflowData *WorkflowData) []string {
+	return getModelVarNames(workflowData, constants.EnvVarModelAgentCodex, constants.EnvVarModelDetectionCodex)
+}
+
 func (e *CodexEngine) GetInstallationSteps(workflowData *WorkflowData) []GitHubActionStep {
 	codexEngineLog.Printf("Generating installation steps for Codex engine: workflow=%s", workflowData.Name)
 
@@ -192,11 +198,6 @@ func (e *CodexEngine) GetExecutionSteps(workflowData *WorkflowData, logFile stri
 		// Build AWF-wrapped command
 		firewallConfig := getFirewallConfig(workflowData)
 		agentConfig := getAgentConfig(workflowData)
-		var awfLogLevel = "info"
-		if firewallConfig != nil && firewallConfig.LogLevel != "" {
-			awfLogLevel = firewallConfig.LogLevel
-		}
-
 		// Get allowed domains (Codex defaults + network permissions + HTTP MCP server URLs + runtime ecosystem domains)
 		allowedDomains := GetCodexAllowedDomainsWithToolsAndRuntimes(workflowData.NetworkPermissions, workflowData.Tools, workflowData.Runtimes)
 
@@ -233,7 +234,7 @@ func (e *CodexEngine) GetExecutionSteps(workflowData *WorkflowData, logFile stri
 			codexEngineLog.Printf("Added blocked domains: %s", blockedDomains)
 		}
 
-		awfArgs = append(awfArgs, "--log-level", awfLogLevel)
+		awfArgs = append(awfArgs, "--log-level", awfLogLevelArg)
 		awfArgs = append(awfArgs, "--proxy-logs-dir", "/tmp/gh-aw/sandbox/firewall/logs")
 
 		// Add --enable-host-access when MCP servers are configured (gateway is used)
@@ -356,6 +357,11 @@ mkdir -p "$CODEX_HOME/logs"
 	// Add GH_AW_SAFE_OUTPUTS if output is needed
 	applySafeOutputEnvToMap(env, workflowData)
 
+	// Add GH_AW_FIREWALL_LOG_LEVEL for the AWF --log-level argument
+	if isFirewallEnabled(workflowData) {
+		applyFirewallLogLevelEnv(env, workflowData)
+	}
+
 	// Add GH_AW_STARTUP_TIMEOUT environment variable (in seconds) if startup-timeout is specified
 	if workflowData.ToolsStartupTimeout > 0 {
 		env["GH_AW_STARTUP_TIMEOUT"] = fmt.Sprintf("%d", workflowData.ToolsStartupTimeout)
@@ -387,6 +393,9 @@ mkdir -p "$CODEX_HOME/logs"
 			env[key] = value
 		}
 	}
+	for key, value := range engineEnvFilesStepEnv(workflowData) {
+		env[key] = value
+	}
 
 	// Add custom environment variables from agent config
 	agentConfig := getAgentConfig(workflowData)
@@ -24,6 +24,7 @@ func (e *CodexEngine) ParseLogMetrics(logContent string, verbose bool) LogMetric
 	inThinkingSection := false
 	toolCallMap := make(map[string]*ToolCallInfo) // Track tool calls
 	var currentSequence []string                  // Track tool sequence
+	var currentSequenceTurn int                   // Turn in which the current sequence started
 	var lastToolName string                       // Track most recent tool for output size extraction
 
 	for i := 0; i < len(lines); i++ {
@@ -43,7 +44,7 @@ func (e *CodexEngine) ParseLogMetrics(logContent string, verbose bool) LogMetric
 				inThinkingSection = true
 				// Start of a new thinking section, save previous sequence if any
 				if len(currentSequence) > 0 {
-					metrics.ToolSequences = append(metrics.ToolSequences, currentSequence)
+					metrics.AddToolSequence(currentSequence, currentSequenceTurn)
 					currentSequence = []string{}
 				}
 			}
@@ -54,6 +55,9 @@ func (e *CodexEngine) ParseLogMetrics(logContent string, verbose bool) LogMetric
 
 		// Extract tool calls from Codex logs and add to sequence
 		if toolName := e.parseCodexToolCallsWithSequence(line, toolCallMap); toolName != "" {
+			if len(currentSequence) == 0 {
+				currentSequenceTurn = turns
+			}
 			currentSequence = append(currentSequence, toolName)
 			lastToolName = toolName
 		}
@@ -78,11 +82,12 @@ func (e *CodexEngine) ParseLogMetrics(logContent string, verbose bool) LogMetric
 
 	// Finalize metrics using shared helper
 	FinalizeToolMetrics(FinalizeToolMetricsOptions{
-		Metrics:         &metrics,
-		ToolCallMap:     toolCallMap,
-		CurrentSequence: currentSequence,
-		Turns:           turns,
-		TokenUsage:      totalTokenUsage,
+		Metrics:             &metrics,
+		ToolCallMap:         toolCallMap,
+		CurrentSequence:     currentSequence,
+		CurrentSequenceTurn: currentSequenceTurn,
+		Turns:               turns,
+		TokenUsage:          totalTokenUsage,
 	})
 
 	codexLogsLog.Printf("Parsed Codex metrics: turns=%d, token_usage=%d, tool_calls=%d",
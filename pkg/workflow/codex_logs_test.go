@@ -96,6 +96,35 @@ func TestCodexParseLogMetricsMultipleToolsWithOutputSizes(t *testing.T) {
 	assert.Equal(t, len("[{\"number\":123,\"title\":\"Test PR\"}]"), searchPRTool.MaxOutputSize, "search_pull_requests output size")
 }
 
+func TestCodexParseLogMetricsToolSequenceTurns(t *testing.T) {
+	engine := NewCodexEngine()
+
+	logContent := `[2025-08-31T12:37:47] thinking
+[2025-08-31T12:37:49] tool github.list_pull_requests({"owner":"githubnext","repo":"gh-aw"})
+[2025-08-31T12:37:50] tool github.get_pull_request({"owner":"githubnext","repo":"gh-aw","pullNumber":1})
+[2025-08-31T12:38:00] thinking
+[2025-08-31T12:38:02] tool github.search_issues({"query":"codex"})
+[2025-08-31T12:38:10] thinking
+[2025-08-31T12:38:12] codex
+[2025-08-31T12:38:20] thinking
+[2025-08-31T12:38:22] tool github.get_file_contents({"owner":"githubnext","repo":"gh-aw","path":"README.md"})
+[2025-08-31T12:38:30] tokens used: 5000`
+
+	metrics := engine.ParseLogMetrics(logContent, false)
+
+	require.Equal(t, 4, metrics.Turns, "Each thinking section should count as a turn")
+	assert.Equal(t, [][]string{
+		{"github_list_pull_requests", "github_get_pull_request"},
+		{"github_search_issues"},
+		{"github_get_file_contents"},
+	}, metrics.ToolSequences, "Tool sequences should be split at each turn")
+	assert.Equal(t, []int{1, 2, 4}, metrics.ToolSequenceTurns, "Each sequence should record the turn it started in")
+
+	assert.Equal(t, metrics.ToolSequences, FilterToolSequencesByTurn(metrics, 1), "Filtering from the first turn should keep every sequence")
+	assert.Equal(t, [][]string{{"github_search_issues"}, {"github_get_file_contents"}}, FilterToolSequencesByTurn(metrics, 2), "Filtering should drop sequences before the given turn")
+	assert.Equal(t, [][]string{{"github_get_file_contents"}}, FilterToolSequencesByTurn(metrics, 3), "Turns without tool calls should not produce sequences")
+}
+
 func TestCodexParseLogMetricsNoOutputSize(t *testing.T) {
 	engine := NewCodexEngine()
 
@@ -3,6 +3,7 @@ package workflow
 import (
 	"fmt"
 	"slices"
+	"strings"
 
 	"github.com/github/gh-aw/pkg/logger"
 )
@@ -188,3 +189,21 @@ func buildEventAwareCommandCondition(commandNames []string, commandEvents []stri
 		Right: nonCommentEvents,
 	}, nil
 }
+
+// validateCommandNames rejects command names that would match the same comment. GitHub
+// Actions compares strings case-insensitively, so names differing only in case are
+// ambiguous: the job condition accepts either and the matched name is not well defined.
+func validateCommandNames(commandNames []string) error {
+	seen := make(map[string]string, len(commandNames))
+	for _, name := range commandNames {
+		key := strings.ToLower(name)
+		if previous, exists := seen[key]; exists {
+			if previous == name {
+				return fmt.Errorf("command name '%s' is listed more than once", name)
+			}
+			return fmt.Errorf("command names '%s' and '%s' are ambiguous: slash commands are matched case-insensitively", previous, name)
+		}
+		seen[key] = name
+	}
+	return nil
+}
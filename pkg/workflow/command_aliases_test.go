@@ -0,0 +1,76 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommandNameAliases(t *testing.T) {
+	tests := []struct {
+		name        string
+		on          string
+		expected    []string
+		errContains string
+	}{
+		{
+			name: "any alias activates the workflow",
+			on:   "  slash_command:\n    names: [deploy, ship]",
+			expected: []string{
+				`GH_AW_COMMANDS: "[\"deploy\",\"ship\"]"`,
+				"'/deploy '",
+				"'/ship '",
+				"matched_command: ${{ steps.check_command_position.outputs.matched_command }}",
+				"slash_command: ${{ needs.pre_activation.outputs.matched_command }}",
+			},
+		},
+		{
+			name:     "aliases on the deprecated command trigger",
+			on:       "  command:\n    names: [deploy, ship]",
+			expected: []string{`GH_AW_COMMANDS: "[\"deploy\",\"ship\"]"`},
+		},
+		{
+			name:        "names overlapping by case",
+			on:          "  slash_command:\n    names: [deploy, Deploy]",
+			errContains: "on.slash_command: command names 'deploy' and 'Deploy' are ambiguous",
+		},
+		{
+			name:        "repeated name",
+			on:          "  slash_command:\n    names: [deploy, ship, deploy]",
+			errContains: "on.slash_command: command name 'deploy' is listed more than once",
+		},
+		{
+			name:        "repeated name in name list",
+			on:          "  slash_command:\n    name: [deploy, deploy]",
+			errContains: "command name 'deploy' is listed more than once",
+		},
+		{
+			name:        "name combined with names",
+			on:          "  slash_command:\n    name: deploy\n    names: [ship]",
+			errContains: "on.slash_command: use either 'name' or 'names'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content := "---\non:\n" + tt.on + "\npermissions:\n  contents: read\nengine: copilot\n---\n\n# Command aliases\n"
+			markdownPath := filepath.Join(testutil.TempDir(t, "command-aliases"), "test.md")
+
+			lockContent, err := NewCompiler().CompileString(content, markdownPath)
+			if tt.errContains != "" {
+				require.Error(t, err, "Compilation should fail")
+				assert.Contains(t, err.Error(), tt.errContains, "Error should explain the command name problem")
+				return
+			}
+			require.NoError(t, err, "Compilation should succeed")
+			for _, expected := range tt.expected {
+				assert.Contains(t, lockContent, expected, "Lock file should wire up every alias")
+			}
+		})
+	}
+}
@@ -0,0 +1,105 @@
+package workflow
+
+import (
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/goccy/go-yaml/ast"
+	yamlparser "github.com/goccy/go-yaml/parser"
+)
+
+var compileErrorLog = logger.New("workflow:compile_error")
+
+// Machine-readable codes carried by CompileError
+const (
+	// CompileErrorCodeJobDependency indicates a job that needs a job which does not exist
+	CompileErrorCodeJobDependency = "job-dependency"
+	// CompileErrorCodeConcurrency indicates an invalid concurrency group expression
+	CompileErrorCodeConcurrency = "invalid-concurrency"
+)
+
+// frontmatterStartLine is the file line where frontmatter content begins (after the opening ---)
+const frontmatterStartLine = 2
+
+// CompileError is a structured compiler error that points at the offending frontmatter
+// key. It carries the source file, line, column and a machine-readable code so tools
+// consuming the compiler API can surface diagnostics in place. Compile errors returned
+// by the compiler wrap it, so callers retrieve it with errors.As.
+type CompileError struct {
+	File    string // Workflow markdown file
+	Line    int    // 1-based line of the offending key in File
+	Column  int    // 1-based column of the offending key in File
+	Code    string // Machine-readable error code (one of the CompileErrorCode constants)
+	Message string // Human-readable error message
+	Cause   error  // Optional underlying error
+}
+
+// Error returns the human-readable error message
+func (e *CompileError) Error() string {
+	return e.Message
+}
+
+// Unwrap returns the underlying error
+func (e *CompileError) Unwrap() error {
+	return e.Cause
+}
+
+// newFrontmatterCompileError creates a CompileError positioned at the frontmatter key
+// identified by keyPath (e.g. "jobs", "build", "needs"). When the key cannot be found,
+// the error points at its closest enclosing key, or at the start of the file.
+func newFrontmatterCompileError(filePath, frontmatterYAML string, keyPath []string, code, message string, cause error) *CompileError {
+	line, column := locateFrontmatterKey(frontmatterYAML, keyPath)
+	compileErrorLog.Printf("Creating compile error: code=%s, file=%s, line=%d, column=%d", code, filePath, line, column)
+	return &CompileError{
+		File:    filePath,
+		Line:    line,
+		Column:  column,
+		Code:    code,
+		Message: message,
+		Cause:   cause,
+	}
+}
+
+// locateFrontmatterKey returns the file line and column of the deepest key along keyPath
+// that exists in the frontmatter YAML. Positions are relative to the markdown file.
+func locateFrontmatterKey(frontmatterYAML string, keyPath []string) (int, int) {
+	line, column := 1, 1
+	if frontmatterYAML == "" || len(keyPath) == 0 {
+		return line, column
+	}
+
+	file, err := yamlparser.ParseBytes([]byte(frontmatterYAML), 0)
+	if err != nil || len(file.Docs) == 0 {
+		compileErrorLog.Printf("Unable to parse frontmatter for key location: %v", err)
+		return line, column
+	}
+
+	node := file.Docs[0].Body
+	for _, key := range keyPath {
+		entry := findMappingEntry(node, key)
+		if entry == nil {
+			break
+		}
+		pos := entry.Key.GetToken().Position
+		line = pos.Line + frontmatterStartLine - 1
+		column = pos.Column
+		node = entry.Value
+	}
+	return line, column
+}
+
+// findMappingEntry returns the mapping entry for key in node, or nil if node is not a
+// mapping or does not contain key
+func findMappingEntry(node ast.Node, key string) *ast.MappingValueNode {
+	switch n := node.(type) {
+	case *ast.MappingValueNode:
+		if n.Key.GetToken().Value == key {
+			return n
+		}
+	case *ast.MappingNode:
+		for _, value := range n.Values {
+			if value.Key.GetToken().Value == key {
+				return value
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,150 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompileErrorPositions(t *testing.T) {
+	tests := []struct {
+		name         string
+		content      string
+		expectedCode string
+		expectedLine int
+		expectedCol  int
+	}{
+		{
+			name: "non-existent job dependency points at needs",
+			content: `---
+on: push
+permissions:
+  contents: read
+engine: copilot
+jobs:
+  setup:
+    runs-on: ubuntu-latest
+    needs: [activation]
+    steps:
+      - run: echo "setup"
+  build:
+    runs-on: ubuntu-latest
+    needs: [missing_job]
+    steps:
+      - run: echo "build"
+---
+
+# Test Workflow
+`,
+			expectedCode: CompileErrorCodeJobDependency,
+			expectedLine: 14,
+			expectedCol:  5,
+		},
+		{
+			name: "invalid workflow-level concurrency group points at group",
+			content: `---
+on: push
+permissions:
+  contents: read
+engine: copilot
+concurrency:
+  group: workflow-${{ github.ref
+---
+
+# Test Workflow
+`,
+			expectedCode: CompileErrorCodeConcurrency,
+			expectedLine: 7,
+			expectedCol:  3,
+		},
+		{
+			name: "invalid workflow-level concurrency string points at concurrency",
+			content: `---
+on: push
+permissions:
+  contents: read
+engine: copilot
+concurrency: workflow-${{ github.ref
+---
+
+# Test Workflow
+`,
+			expectedCode: CompileErrorCodeConcurrency,
+			expectedLine: 6,
+			expectedCol:  1,
+		},
+		{
+			name: "invalid engine concurrency points at engine.concurrency",
+			content: `---
+on: push
+permissions:
+  contents: read
+engine:
+  id: copilot
+  concurrency: copilot-${{ github.workflow
+---
+
+# Test Workflow
+`,
+			expectedCode: CompileErrorCodeConcurrency,
+			expectedLine: 7,
+			expectedCol:  3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := testutil.TempDir(t, "compile-error-test")
+			testFile := filepath.Join(tmpDir, "test.md")
+			require.NoError(t, os.WriteFile(testFile, []byte(tt.content), 0644), "Failed to write test file")
+
+			compiler := NewCompiler()
+			err := compiler.CompileWorkflow(testFile)
+			require.Error(t, err, "Compilation should fail")
+
+			var compileErr *CompileError
+			require.ErrorAs(t, err, &compileErr, "Error chain should contain a CompileError")
+			assert.Equal(t, tt.expectedCode, compileErr.Code, "Error code should match")
+			assert.Equal(t, testFile, compileErr.File, "Error file should be the workflow file")
+			assert.Equal(t, tt.expectedLine, compileErr.Line, "Reported line should match the offending frontmatter key")
+			assert.Equal(t, tt.expectedCol, compileErr.Column, "Reported column should match the offending frontmatter key")
+		})
+	}
+}
+
+func TestLocateFrontmatterKey(t *testing.T) {
+	frontmatter := "on: push\njobs:\n  build:\n    runs-on: ubuntu-latest\n"
+
+	tests := []struct {
+		name         string
+		keyPath      []string
+		expectedLine int
+		expectedCol  int
+	}{
+		{name: "nested key", keyPath: []string{"jobs", "build", "runs-on"}, expectedLine: 5, expectedCol: 5},
+		{name: "missing key falls back to parent", keyPath: []string{"jobs", "build", "needs"}, expectedLine: 4, expectedCol: 3},
+		{name: "missing top-level key falls back to file start", keyPath: []string{"concurrency"}, expectedLine: 1, expectedCol: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			line, column := locateFrontmatterKey(frontmatter, tt.keyPath)
+			assert.Equal(t, tt.expectedLine, line, "Line should match")
+			assert.Equal(t, tt.expectedCol, column, "Column should match")
+		})
+	}
+}
+
+func TestFormatCompilerErrorUsesCompileErrorPosition(t *testing.T) {
+	compileErr := &CompileError{File: "test.md", Line: 7, Column: 3, Code: CompileErrorCodeConcurrency, Message: "bad group"}
+
+	err := formatCompilerError("test.md", "error", "concurrency validation failed: bad group", compileErr)
+	assert.Contains(t, err.Error(), "test.md:7:3", "Formatted error should use the CompileError position")
+	assert.ErrorIs(t, err, compileErr, "Formatted error should wrap the CompileError")
+}
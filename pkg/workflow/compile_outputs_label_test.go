@@ -173,7 +173,7 @@ This workflow tests the safe_outputs job generation.
 	if !strings.Contains(lockContent, "GH_AW_SAFE_OUTPUTS_HANDLER_CONFIG") {
 		t.Error("Expected handler config to be passed as environment variable")
 	}
-	if !strings.Contains(lockContent, `\"add_labels\":{\"allowed\":[\"triage\",\"bug\",\"enhancement\"]}`) {
+	if !strings.Contains(lockContent, `\"add_labels\":{\"allowed\":[\"triage\",\"bug\",\"enhancement\"],\"retry\":{\"base_delay_ms\":1000,\"max_attempts\":3}}`) {
 		t.Error("Expected allowed labels to be in handler config")
 	}
 
@@ -42,12 +42,19 @@ var githubWorkflowSchema string
 // errType: the error type ("error" or "warning")
 // message: the error message text
 // cause: optional underlying error to wrap (use nil for validation errors)
+// If cause wraps a CompileError, its line and column are used for the error position.
 func formatCompilerError(filePath string, errType string, message string, cause error) error {
+	line, column := 1, 1
+	var compileErr *CompileError
+	if errors.As(cause, &compileErr) && compileErr.Line > 0 {
+		line, column = compileErr.Line, compileErr.Column
+	}
+
 	formattedErr := console.FormatError(console.CompilerError{
 		Position: console.ErrorPosition{
 			File:   filePath,
-			Line:   1,
-			Column: 1,
+			Line:   line,
+			Column: column,
 		},
 		Type:    errType,
 		Message: message,
@@ -92,6 +99,96 @@ func formatCompilerMessage(filePath string, msgType string, message string) stri
 // This is the main entry point for compiling workflows from disk. For compiling
 // pre-parsed workflow data, use CompileWorkflowData instead.
 func (c *Compiler) CompileWorkflow(markdownPath string) error {
+	workflowData, err := c.parseWorkflowFileForCompile(markdownPath)
+	if err != nil {
+		return err
+	}
+
+	return c.CompileWorkflowData(workflowData, markdownPath)
+}
+
+// CompileWorkflowWithReport compiles a workflow markdown file like CompileWorkflow and
+// also returns the warnings emitted while compiling it, so callers can decide how to
+// handle them. With SetFailOnWarning(true), any warning fails the compile before the
+// lock file is written and is returned as an error alongside the collected warnings.
+func (c *Compiler) CompileWorkflowWithReport(markdownPath string) ([]string, error) {
+	err := c.CompileWorkflow(markdownPath)
+	return c.GetWarnings(), err
+}
+
+// CompileWorkflowDryRun compiles a workflow markdown file and returns the generated
+// lock file YAML without writing it to disk. The returned content is identical to
+// what CompileWorkflow would write to the .lock.yml file.
+//
+// This is useful for previewing changes or detecting stale lock files in CI.
+// If the markdown fails to parse or compile, the compile error is returned.
+func (c *Compiler) CompileWorkflowDryRun(markdownPath string) (string, error) {
+	workflowData, err := c.parseWorkflowFileForCompile(markdownPath)
+	if err != nil {
+		return "", err
+	}
+
+	_, yamlContent, err := c.compileWorkflowDataToYAML(workflowData, markdownPath)
+	if err != nil {
+		return "", err
+	}
+
+	log.Print("Dry run completed - lock file not written")
+	return yamlContent, nil
+}
+
+// CompileString compiles workflow markdown content and returns the generated lock file
+// YAML without reading or writing the workflow on disk. The content is compiled as if
+// it were stored at markdownPath, which need not exist: imports are resolved relative to
+// its directory and the workflow ID is derived from its file name.
+//
+// This is useful for editor integrations and piping unsaved workflow source.
+func (c *Compiler) CompileString(content string, markdownPath string) (string, error) {
+	c.sourceOverridePath = filepath.Clean(markdownPath)
+	c.sourceOverride = []byte(content)
+	defer func() {
+		c.sourceOverridePath = ""
+		c.sourceOverride = nil
+	}()
+
+	return c.CompileWorkflowDryRun(markdownPath)
+}
+
+// readWorkflowSource reads a workflow markdown file, serving in-memory content
+// provided to CompileString instead of reading from disk
+func (c *Compiler) readWorkflowSource(markdownPath string) ([]byte, error) {
+	if c.sourceOverridePath != "" && filepath.Clean(markdownPath) == c.sourceOverridePath {
+		return c.sourceOverride, nil
+	}
+	return os.ReadFile(markdownPath)
+}
+
+// CompileWorkflowBody parses a workflow markdown file and returns the assembled user
+// prompt body without generating the lock file. The body is the prompt template as
+// written by the prompt creation step, before runtime-import macros and template
+// conditionals are processed at runtime. Built-in prompt sections are not included.
+//
+// This is useful for inspecting prompt prefix/suffix, inlined import and runtime-import
+// ordering while debugging a prompt.
+func (c *Compiler) CompileWorkflowBody(markdownPath string) (string, error) {
+	workflowData, err := c.parseWorkflowFileForCompile(markdownPath)
+	if err != nil {
+		return "", err
+	}
+
+	userPromptChunks, _ := c.buildUserPromptChunks(workflowData)
+
+	var body strings.Builder
+	for _, chunk := range userPromptChunks {
+		body.WriteString(chunk)
+		body.WriteString("\n")
+	}
+	return body.String(), nil
+}
+
+// parseWorkflowFileForCompile parses a workflow markdown file and formats parse errors
+// for display as compiler errors
+func (c *Compiler) parseWorkflowFileForCompile(markdownPath string) (*WorkflowData, error) {
 	// Store markdownPath for use in dynamic tool generation
 	c.markdownPath = markdownPath
 
@@ -102,13 +199,12 @@ func (c *Compiler) CompileWorkflow(markdownPath string) error {
 		// Check if this is already a formatted console error
 		if strings.Contains(err.Error(), ":") && (strings.Contains(err.Error(), "error:") || strings.Contains(err.Error(), "warning:")) {
 			// Already formatted, return as-is
-			return err
+			return nil, err
 		}
 		// Otherwise, create a basic formatted error with wrapping
-		return formatCompilerError(markdownPath, "error", err.Error(), err)
+		return nil, formatCompilerError(markdownPath, "error", err.Error(), err)
 	}
-
-	return c.CompileWorkflowData(workflowData, markdownPath)
+	return workflowData, nil
 }
 
 // validateWorkflowData performs comprehensive validation of workflow configuration
@@ -119,6 +215,9 @@ func (c *Compiler) validateWorkflowData(workflowData *WorkflowData, markdownPath
 	if err := validateExpressionSafety(workflowData.MarkdownContent); err != nil {
 		return formatCompilerError(markdownPath, "error", err.Error(), err)
 	}
+	if err := validatePromptAffixExpressions(workflowData.EngineConfig); err != nil {
+		return formatCompilerError(markdownPath, "error", err.Error(), err)
+	}
 
 	// Validate expressions in runtime-import files at compile time
 	log.Printf("Validating runtime-import files")
@@ -130,6 +229,25 @@ func (c *Compiler) validateWorkflowData(workflowData *WorkflowData, markdownPath
 		return formatCompilerError(markdownPath, "error", err.Error(), err)
 	}
 
+	// Check the assembled prompt against the prompt.max-chars budget
+	log.Printf("Validating prompt budget")
+	if err := c.validatePromptBudget(workflowData, workspaceDir); err != nil {
+		return formatCompilerError(markdownPath, "error", err.Error(), err)
+	}
+
+	// Validate the expressions redacted from the printed prompt
+	log.Printf("Validating prompt redactions")
+	if err := validatePromptRedact(workflowData.PromptRedact); err != nil {
+		return formatCompilerError(markdownPath, "error", err.Error(), err)
+	}
+
+	// Warn when checkout: false is set but the workflow reads repository files at runtime
+	log.Printf("Validating agent job checkout setting")
+	c.validateCheckoutDisabled(workflowData)
+
+	// Warn when imports are inlined but the markdown still uses runtime-import macros
+	c.validateInlineImports(workflowData)
+
 	// Validate feature flags
 	log.Printf("Validating feature flags")
 	if err := validateFeatures(workflowData); err != nil {
@@ -174,18 +292,47 @@ func (c *Compiler) validateWorkflowData(workflowData *WorkflowData, markdownPath
 		return formatCompilerError(markdownPath, "error", err.Error(), err)
 	}
 
+	// Warn when permission shorthands are combined with write safe-outputs
+	log.Printf("Validating permissions shorthand against safe-outputs")
+	c.validateSafeOutputsPermissionsShorthand(workflowData)
+
+	// Validate that safe outputs do not declare conflicting environments
+	log.Printf("Validating safe-outputs environments")
+	if _, err := resolveSafeOutputsEnvironment(workflowData.SafeOutputs); err != nil {
+		return formatCompilerError(markdownPath, "error", err.Error(), err)
+	}
+
 	// Validate safe-outputs allowed-domains configuration
 	log.Printf("Validating safe-outputs allowed-domains")
 	if err := c.validateSafeOutputsAllowedDomains(workflowData.SafeOutputs); err != nil {
 		return formatCompilerError(markdownPath, "error", err.Error(), err)
 	}
 
+	// Validate create-issue min-interval (invalid values would silently disable throttling)
+	if err := validateCreateIssueMinInterval(workflowData.SafeOutputs); err != nil {
+		return formatCompilerError(markdownPath, "error", err.Error(), err)
+	}
+
+	// Check safe-outputs token scope (advisory, only with token linting enabled)
+	c.validateSafeOutputsTokenScope(workflowData)
+
+	// Warn when the schedule frequency would exceed the declared monthly budget
+	if err := c.validateScheduleBudget(workflowData); err != nil {
+		return formatCompilerError(markdownPath, "error", err.Error(), err)
+	}
+
 	// Validate network allowed domains configuration
 	log.Printf("Validating network allowed domains")
 	if err := c.validateNetworkAllowedDomains(workflowData.NetworkPermissions); err != nil {
 		return formatCompilerError(markdownPath, "error", err.Error(), err)
 	}
 
+	// Validate network blocked domains configuration
+	log.Printf("Validating network blocked domains")
+	if err := c.validateNetworkBlockedDomains(workflowData.NetworkPermissions); err != nil {
+		return formatCompilerError(markdownPath, "error", err.Error(), err)
+	}
+
 	// Validate network firewall configuration
 	log.Printf("Validating network firewall configuration")
 	if err := validateNetworkFirewallConfig(workflowData.NetworkPermissions); err != nil {
@@ -198,6 +345,30 @@ func (c *Compiler) validateWorkflowData(workflowData *WorkflowData, markdownPath
 		return formatCompilerError(markdownPath, "error", err.Error(), err)
 	}
 
+	// Validate reusable workflow references in custom jobs
+	log.Printf("Validating reusable workflow references")
+	if err := validateReusableWorkflowUses(workflowData.Jobs); err != nil {
+		return formatCompilerError(markdownPath, "error", err.Error(), err)
+	}
+
+	// Validate create-issue team assignment configuration
+	log.Printf("Validating create-issue assign-from-team")
+	if err := validateCreateIssueAssignFromTeam(workflowData.SafeOutputs); err != nil {
+		return formatCompilerError(markdownPath, "error", err.Error(), err)
+	}
+
+	// Validate add-labels label creation configuration
+	log.Printf("Validating add-labels color")
+	if err := validateAddLabelsColor(workflowData.SafeOutputs); err != nil {
+		return formatCompilerError(markdownPath, "error", err.Error(), err)
+	}
+
+	// Validate commit signing configuration
+	log.Printf("Validating require-signed-commits configuration")
+	if err := validateSignedCommitsConfig(workflowData); err != nil {
+		return formatCompilerError(markdownPath, "error", err.Error(), err)
+	}
+
 	// Validate workflow-level concurrency group expression
 	log.Printf("Validating workflow-level concurrency configuration")
 	if workflowData.Concurrency != "" {
@@ -206,8 +377,9 @@ func (c *Compiler) validateWorkflowData(workflowData *WorkflowData, markdownPath
 		// We need to extract just the group value
 		groupExpr := extractConcurrencyGroupFromYAML(workflowData.Concurrency)
 		if groupExpr != "" {
-			if err := validateConcurrencyGroupExpression(groupExpr); err != nil {
-				return formatCompilerError(markdownPath, "error", fmt.Sprintf("workflow-level concurrency validation failed: %s", err.Error()), err)
+			if err := ValidateConcurrencyExpression(groupExpr); err != nil {
+				compileErr := newFrontmatterCompileError(markdownPath, workflowData.FrontmatterYAML, []string{"concurrency", "group"}, CompileErrorCodeConcurrency, err.Error(), err)
+				return formatCompilerError(markdownPath, "error", fmt.Sprintf("workflow-level concurrency validation failed: %s", err.Error()), compileErr)
 			}
 		}
 	}
@@ -218,40 +390,46 @@ func (c *Compiler) validateWorkflowData(workflowData *WorkflowData, markdownPath
 		// Extract the group expression from the engine concurrency YAML
 		groupExpr := extractConcurrencyGroupFromYAML(workflowData.EngineConfig.Concurrency)
 		if groupExpr != "" {
-			if err := validateConcurrencyGroupExpression(groupExpr); err != nil {
-				return formatCompilerError(markdownPath, "error", fmt.Sprintf("engine.concurrency validation failed: %s", err.Error()), err)
+			if err := ValidateConcurrencyExpression(groupExpr); err != nil {
+				compileErr := newFrontmatterCompileError(markdownPath, workflowData.FrontmatterYAML, []string{"engine", "concurrency", "group"}, CompileErrorCodeConcurrency, err.Error(), err)
+				return formatCompilerError(markdownPath, "error", fmt.Sprintf("engine.concurrency validation failed: %s", err.Error()), compileErr)
 			}
 		}
 	}
 
 	// Emit experimental warning for sandbox-runtime feature
 	if isSRTEnabled(workflowData) {
-		fmt.Fprintln(os.Stderr, console.FormatWarningMessage("Using experimental feature: sandbox-runtime firewall"))
-		c.IncrementWarningCount()
+		warningMsg := "Using experimental feature: sandbox-runtime firewall"
+		fmt.Fprintln(os.Stderr, console.FormatWarningMessage(warningMsg))
+		c.addWarning(warningMsg)
 	}
 
 	// Emit warning for sandbox.agent: false (disables agent sandbox firewall)
 	if isAgentSandboxDisabled(workflowData) {
-		fmt.Fprintln(os.Stderr, console.FormatWarningMessage("⚠️  WARNING: Agent sandbox disabled (sandbox.agent: false). This removes firewall protection. The AI agent will have direct network access without firewall filtering. The MCP gateway remains enabled. Only use this for testing or in controlled environments where you trust the AI agent completely."))
-		c.IncrementWarningCount()
+		warningMsg := "⚠️  WARNING: Agent sandbox disabled (sandbox.agent: false). This removes firewall protection. The AI agent will have direct network access without firewall filtering. The MCP gateway remains enabled. Only use this for testing or in controlled environments where you trust the AI agent completely."
+		fmt.Fprintln(os.Stderr, console.FormatWarningMessage(warningMsg))
+		c.addWarning(warningMsg)
 	}
 
 	// Emit experimental warning for safe-inputs feature
 	if IsSafeInputsEnabled(workflowData.SafeInputs, workflowData) {
-		fmt.Fprintln(os.Stderr, console.FormatWarningMessage("Using experimental feature: safe-inputs"))
-		c.IncrementWarningCount()
+		warningMsg := "Using experimental feature: safe-inputs"
+		fmt.Fprintln(os.Stderr, console.FormatWarningMessage(warningMsg))
+		c.addWarning(warningMsg)
 	}
 
 	// Emit experimental warning for plugins feature
 	if workflowData.PluginInfo != nil && len(workflowData.PluginInfo.Plugins) > 0 {
-		fmt.Fprintln(os.Stderr, console.FormatWarningMessage("Using experimental feature: plugins"))
-		c.IncrementWarningCount()
+		warningMsg := "Using experimental feature: plugins"
+		fmt.Fprintln(os.Stderr, console.FormatWarningMessage(warningMsg))
+		c.addWarning(warningMsg)
 	}
 
 	// Emit experimental warning for rate-limit feature
 	if workflowData.RateLimit != nil {
-		fmt.Fprintln(os.Stderr, console.FormatWarningMessage("Using experimental feature: rate-limit"))
-		c.IncrementWarningCount()
+		warningMsg := "Using experimental feature: rate-limit"
+		fmt.Fprintln(os.Stderr, console.FormatWarningMessage(warningMsg))
+		c.addWarning(warningMsg)
 	}
 
 	// Validate workflow_run triggers have branch restrictions
@@ -293,7 +471,7 @@ func (c *Compiler) validateWorkflowData(workflowData *WorkflowData, markdownPath
 					} else {
 						// In non-strict mode, missing permissions are warnings
 						fmt.Fprintln(os.Stderr, formatCompilerMessage(markdownPath, "warning", message))
-						c.IncrementWarningCount()
+						c.addWarning(message)
 					}
 				}
 			}
@@ -311,7 +489,7 @@ func (c *Compiler) validateWorkflowData(workflowData *WorkflowData, markdownPath
 OIDC tokens can authenticate to cloud providers (AWS, Azure, GCP).
 Ensure proper audience validation and trust policies are configured.`
 				fmt.Fprintln(os.Stderr, formatCompilerMessage(markdownPath, "warning", warningMsg))
-				c.IncrementWarningCount()
+				c.addWarning(warningMsg)
 			}
 		}
 	}
@@ -425,8 +603,9 @@ func (c *Compiler) generateAndValidateYAML(workflowData *WorkflowData, markdownP
 		if err := c.validateContainerImages(workflowData); err != nil {
 			// Treat container image validation failures as warnings, not errors
 			// This is because validation may fail due to auth issues locally (e.g., private registries)
-			fmt.Fprintln(os.Stderr, formatCompilerMessage(markdownPath, "warning", fmt.Sprintf("container image validation failed: %v", err)))
-			c.IncrementWarningCount()
+			warningMsg := fmt.Sprintf("container image validation failed: %v", err)
+			fmt.Fprintln(os.Stderr, formatCompilerMessage(markdownPath, "warning", warningMsg))
+			c.addWarning(warningMsg)
 		}
 
 		// Validate runtime packages (npx, uv)
@@ -447,8 +626,9 @@ func (c *Compiler) generateAndValidateYAML(workflowData *WorkflowData, markdownP
 			return "", formatCompilerError(markdownPath, "error", fmt.Sprintf("repository feature validation failed: %v", err), err)
 		}
 	} else if c.verbose {
-		fmt.Fprintln(os.Stderr, console.FormatWarningMessage("Schema validation available but skipped (use SetSkipValidation(false) to enable)"))
-		c.IncrementWarningCount()
+		warningMsg := "Schema validation available but skipped (use SetSkipValidation(false) to enable)"
+		fmt.Fprintln(os.Stderr, console.FormatWarningMessage(warningMsg))
+		c.addWarning(warningMsg)
 	}
 
 	return yamlContent, nil
@@ -480,16 +660,6 @@ func (c *Compiler) writeWorkflowOutput(lockFile, yamlContent string, markdownPat
 			}
 			log.Print("Lock file written successfully")
 		}
-
-		// Validate file size after writing
-		if lockFileInfo, err := os.Stat(lockFile); err == nil {
-			if lockFileInfo.Size() > MaxLockFileSize {
-				lockSize := console.FormatFileSize(lockFileInfo.Size())
-				maxSize := console.FormatFileSize(MaxLockFileSize)
-				warningMsg := fmt.Sprintf("Generated lock file size (%s) exceeds recommended maximum size (%s)", lockSize, maxSize)
-				fmt.Fprintln(os.Stderr, console.FormatWarningMessage(warningMsg))
-			}
-		}
 	}
 
 	// Display success message with file size if we generated a lock file (unless quiet mode)
@@ -524,6 +694,28 @@ func (c *Compiler) writeWorkflowOutput(lockFile, yamlContent string, markdownPat
 // making it efficient for scenarios where the same workflow is compiled multiple times
 // or when workflow data comes from a non-file source.
 func (c *Compiler) CompileWorkflowData(workflowData *WorkflowData, markdownPath string) error {
+	lockFile, yamlContent, err := c.compileWorkflowDataToYAML(workflowData, markdownPath)
+	if err != nil {
+		return err
+	}
+
+	// Write output
+	if err := c.writeWorkflowOutput(lockFile, yamlContent, markdownPath); err != nil {
+		return err
+	}
+
+	// Write the job graph sidecar if requested
+	if c.emitJobGraph && !c.noEmit {
+		if err := c.WriteJobGraph(workflowData, jobGraphFilePath(lockFile)); err != nil {
+			return formatCompilerError(markdownPath, "error", err.Error(), err)
+		}
+	}
+	return nil
+}
+
+// compileWorkflowDataToYAML validates the workflow data and generates the lock file YAML.
+// Returns the lock file path and generated content without writing anything to disk.
+func (c *Compiler) compileWorkflowDataToYAML(workflowData *WorkflowData, markdownPath string) (string, string, error) {
 	// Store markdownPath for use in dynamic tool generation and prompt generation
 	c.markdownPath = markdownPath
 
@@ -556,7 +748,7 @@ func (c *Compiler) CompileWorkflowData(workflowData *WorkflowData, markdownPath
 
 	// Validate workflow data
 	if err := c.validateWorkflowData(workflowData, markdownPath); err != nil {
-		return err
+		return "", "", err
 	}
 
 	// Note: Markdown content size is now handled by splitting into multiple steps in generatePrompt
@@ -568,11 +760,31 @@ func (c *Compiler) CompileWorkflowData(workflowData *WorkflowData, markdownPath
 	// Generate and validate YAML
 	yamlContent, err := c.generateAndValidateYAML(workflowData, markdownPath, lockFile)
 	if err != nil {
-		return err
+		return "", "", err
 	}
 
-	// Write output
-	return c.writeWorkflowOutput(lockFile, yamlContent, markdownPath)
+	// Action pin warnings are emitted while resolving steps, which only have the workflow data
+	for _, warningMsg := range workflowData.ActionPinWarningMessages {
+		c.addWarning(warningMsg)
+	}
+	workflowData.ActionPinWarningMessages = nil
+
+	// Validate the lock file size before it is written
+	if !c.noEmit && len(yamlContent) > MaxLockFileSize {
+		lockSize := console.FormatFileSize(int64(len(yamlContent)))
+		maxSize := console.FormatFileSize(MaxLockFileSize)
+		warningMsg := fmt.Sprintf("Generated lock file size (%s) exceeds recommended maximum size (%s)", lockSize, maxSize)
+		fmt.Fprintln(os.Stderr, console.FormatWarningMessage(warningMsg))
+		c.addWarning(warningMsg)
+	}
+
+	if c.failOnWarning && len(c.warnings) > 0 {
+		log.Printf("Failing compilation on %d warnings", len(c.warnings))
+		message := fmt.Sprintf("fail-on-warning: compilation emitted %d warning(s):\n  - %s", len(c.warnings), strings.Join(c.warnings, "\n  - "))
+		return "", "", formatCompilerError(markdownPath, "error", message, nil)
+	}
+
+	return lockFile, yamlContent, nil
 }
 
 // ParseWorkflowFile parses a markdown workflow file and extracts all necessary data
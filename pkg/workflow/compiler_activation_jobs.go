@@ -42,7 +42,7 @@ func (c *Compiler) buildPreActivationJob(data *WorkflowData, needsPermissionChec
 	// Determine permissions for pre-activation job
 	var perms *Permissions
 	if needsContentsRead {
-		perms = NewPermissionsContentsRead()
+		perms = NewPermissionsBuilder().Read(PermissionContents).Build()
 	}
 
 	// Add reaction permissions if reaction is configured (reactions added in pre-activation for immediate feedback)
@@ -83,6 +83,9 @@ func (c *Compiler) buildPreActivationJob(data *WorkflowData, needsPermissionChec
 		steps = append(steps, "        env:\n")
 		// Quote the reaction value to prevent YAML interpreting +1/-1 as integers
 		steps = append(steps, fmt.Sprintf("          GH_AW_REACTION: %q\n", data.AIReaction))
+		if len(data.AIReactionFallbacks) > 0 {
+			steps = append(steps, fmt.Sprintf("          GH_AW_REACTION_FALLBACKS: %q\n", strings.Join(data.AIReactionFallbacks, ",")))
+		}
 
 		steps = append(steps, "        with:\n")
 		// Explicitly use the GitHub Actions token (GITHUB_TOKEN) for reactions
@@ -481,10 +484,7 @@ func (c *Compiler) buildActivationJob(data *WorkflowData, preActivationJobCreate
 		// Build condition: only lock if event type is 'issues' or 'issue_comment'
 		// lock-for-agent can be configured under on.issues or on.issue_comment
 		// For issue_comment events, context.issue.number automatically resolves to the parent issue
-		lockCondition := BuildOr(
-			BuildEventTypeEquals("issues"),
-			BuildEventTypeEquals("issue_comment"),
-		)
+		lockCondition := BuildEventTypeIn("issues", "issue_comment")
 
 		steps = append(steps, "      - name: Lock issue for agent workflow\n")
 		steps = append(steps, "        id: lock-issue\n")
@@ -826,21 +826,20 @@ func (c *Compiler) buildMainJob(data *WorkflowData, activationJobCreated bool) (
 	agentConcurrency := GenerateJobConcurrencyConfig(data)
 
 	// Set up permissions for the agent job
-	// Agent job ALWAYS needs contents: read to access .github and .actions folders
+	// Agent job ALWAYS needs contents: read to access .github and .actions folders.
+	// GitHub toolset permissions are validated rather than granted, so only the
+	// baseline is derived here.
 	permissions := data.Permissions
 	if permissions == "" {
 		// No permissions specified, just add contents: read
-		perms := NewPermissionsContentsRead()
-		permissions = perms.RenderToYAML()
+		permissions = DerivePermissionsFromTools(nil, nil).RenderToYAML()
 	} else {
 		// Parse existing permissions and add contents: read
-		parser := NewPermissionsParser(permissions)
-		perms := parser.ToPermissions()
+		perms := NewPermissionsParser(permissions).ToPermissions()
 
-		// Only add contents: read if not already present
-		if level, exists := perms.Get(PermissionContents); !exists || level == PermissionNone {
-			perms.Set(PermissionContents, PermissionRead)
-			permissions = perms.RenderToYAML()
+		// Only re-render when permissions were added, preserving the original formatting otherwise
+		if derived := DerivePermissionsFromTools(nil, perms); derived.RenderToYAML() != perms.RenderToYAML() {
+			permissions = derived.RenderToYAML()
 		}
 	}
 
@@ -148,6 +148,82 @@ func TestBuildPreActivationJob_WithReaction(t *testing.T) {
 	}
 }
 
+// TestBuildActivationJobs_ReactionList tests that on.reaction accepts a single reaction or a
+// list of fallbacks, and that "none" anywhere in the list disables reactions
+func TestBuildActivationJobs_ReactionList(t *testing.T) {
+	tests := []struct {
+		name              string
+		reaction          any
+		expectedStep      string
+		expectedFallbacks string
+		shouldHaveComment bool
+	}{
+		{
+			name:              "single string",
+			reaction:          "rocket",
+			expectedStep:      "Add rocket reaction for immediate feedback",
+			shouldHaveComment: true,
+		},
+		{
+			name:              "list with fallback",
+			reaction:          []any{"rocket", "eyes"},
+			expectedStep:      "Add rocket reaction for immediate feedback",
+			expectedFallbacks: `GH_AW_REACTION_FALLBACKS: "eyes"`,
+			shouldHaveComment: true,
+		},
+		{
+			name:              "list with numeric entries",
+			reaction:          []any{1, "heart", "eyes"},
+			expectedStep:      "Add +1 reaction for immediate feedback",
+			expectedFallbacks: `GH_AW_REACTION_FALLBACKS: "heart,eyes"`,
+			shouldHaveComment: true,
+		},
+		{
+			name:     "none in list",
+			reaction: []any{"rocket", "none"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reactions, err := parseReactionList(tt.reaction)
+			require.NoError(t, err, "Reaction value should parse")
+
+			workflowData := &WorkflowData{
+				Name:            "Test Workflow",
+				Command:         []string{"test"},
+				MarkdownContent: "# Test\n\nContent",
+			}
+			workflowData.AIReaction, workflowData.AIReactionFallbacks = resolveReactions(reactions)
+
+			compiler := NewCompiler()
+			preActivation, err := compiler.buildPreActivationJob(workflowData, false)
+			require.NoError(t, err, "buildPreActivationJob should succeed")
+			activation, err := compiler.buildActivationJob(workflowData, true, "", "test.lock.yml")
+			require.NoError(t, err, "buildActivationJob should succeed")
+
+			preActivationSteps := strings.Join(preActivation.Steps, "")
+			activationSteps := strings.Join(activation.Steps, "")
+
+			if tt.expectedStep == "" {
+				assert.NotContains(t, preActivationSteps, "GH_AW_REACTION", "No reaction step should be added")
+				assert.NotContains(t, activationSteps, "Add comment with workflow run link", "No run link comment should be added")
+				return
+			}
+
+			assert.Contains(t, preActivationSteps, tt.expectedStep, "Reaction step should use the first reaction")
+			if tt.expectedFallbacks != "" {
+				assert.Contains(t, preActivationSteps, tt.expectedFallbacks, "Fallback reactions should be passed in order")
+			} else {
+				assert.NotContains(t, preActivationSteps, "GH_AW_REACTION_FALLBACKS", "Single reaction should not set fallbacks")
+			}
+			assert.Contains(t, preActivation.Permissions, "issues: write", "Reactions need issues: write")
+			assert.Equal(t, tt.shouldHaveComment, strings.Contains(activationSteps, "Add comment with workflow run link"), "Run link comment should follow the reaction configuration")
+			assert.Equal(t, "${{ steps.add-comment.outputs.comment-id }}", activation.Outputs["comment_id"], "Activation job should expose the comment ID")
+		})
+	}
+}
+
 // TestBuildPreActivationJob_WithCustomStepsAndOutputs tests custom steps/outputs extraction
 func TestBuildPreActivationJob_WithCustomStepsAndOutputs(t *testing.T) {
 	compiler := NewCompiler()
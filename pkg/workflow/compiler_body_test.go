@@ -0,0 +1,97 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/stringutil"
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompileWorkflowBody(t *testing.T) {
+	repoDir := testutil.TempDir(t, "body-test")
+	workflowsDir := filepath.Join(repoDir, ".github", "workflows")
+	sharedDir := filepath.Join(workflowsDir, "shared")
+	require.NoError(t, os.MkdirAll(sharedDir, 0755), "Failed to create shared directory")
+
+	withInputs := `---
+inputs:
+  label:
+    type: string
+---
+
+Triage issues labeled ${{ github.aw.inputs.label }}.
+
+{{#if github.event.issue.number}}
+Comment on the issue when done.
+{{/if}}
+`
+	require.NoError(t, os.WriteFile(filepath.Join(sharedDir, "triage.md"), []byte(withInputs), 0644), "Failed to write import with inputs")
+	require.NoError(t, os.WriteFile(filepath.Join(sharedDir, "style.md"), []byte("Use a friendly tone.\n"), 0644), "Failed to write plain import")
+
+	content := `---
+on: issues
+permissions:
+  contents: read
+engine:
+  id: copilot
+  prompt-prefix: "You are the triage bot."
+  prompt-suffix: "Never close issues."
+imports:
+  - shared/style.md
+  - path: shared/triage.md
+    inputs:
+      label: bug
+---
+
+# Body Test
+
+Handle the issue.
+`
+	testFile := filepath.Join(workflowsDir, "body.md")
+	require.NoError(t, os.WriteFile(testFile, []byte(content), 0644), "Failed to write workflow")
+
+	body, err := NewCompiler().CompileWorkflowBody(testFile)
+	require.NoError(t, err, "Body should be assembled for a valid workflow")
+
+	_, statErr := os.Stat(stringutil.MarkdownToLockFile(testFile))
+	assert.True(t, os.IsNotExist(statErr), "Emitting the body should not write the lock file")
+
+	assert.Contains(t, body, "Triage issues labeled bug.", "Import inputs should be substituted in inlined imports")
+	assert.Contains(t, body, "{{#if __GH_AW_GITHUB_EVENT_ISSUE_NUMBER__ }}", "Template conditionals should be kept for runtime rendering")
+	assert.Contains(t, body, "{{/if}}", "Template conditionals should be closed")
+	assert.NotContains(t, body, "<system>", "Built-in prompt sections should not be included")
+	assert.NotContains(t, body, "Handle the issue.", "Main workflow markdown should not be inlined")
+
+	// Prefix, inlined imports, import runtime-imports, main workflow runtime-import, suffix
+	ordered := []string{
+		"You are the triage bot.",
+		"Triage issues labeled bug.",
+		"{{#runtime-import .github/workflows/shared/style.md}}",
+		"{{#runtime-import .github/workflows/body.md}}",
+		"Never close issues.",
+	}
+	previous := -1
+	for _, marker := range ordered {
+		index := strings.Index(body, marker)
+		require.GreaterOrEqual(t, index, 0, "Body should contain %q", marker)
+		assert.Greater(t, index, previous, "%q should appear after the previous section", marker)
+		previous = index
+	}
+}
+
+func TestCompileWorkflowBody_ParseError(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "body-error-test")
+	testFile := filepath.Join(tmpDir, "invalid.md")
+	require.NoError(t, os.WriteFile(testFile, []byte("---\non: push\nengine: not-a-real-engine\n---\n# Invalid\n"), 0644), "Failed to write workflow")
+
+	body, err := NewCompiler().CompileWorkflowBody(testFile)
+	require.Error(t, err, "Invalid workflow should fail")
+	assert.Empty(t, body, "No body should be returned on error")
+}
@@ -0,0 +1,115 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/stringutil"
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const dryRunTestWorkflow = `---
+on: push
+permissions:
+  contents: read
+engine: copilot
+---
+
+# Dry Run Test Workflow
+
+Summarize the latest changes.
+`
+
+func TestCompileWorkflowDryRun_MatchesCompileWorkflow(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "dry-run-test")
+	testFile := filepath.Join(tmpDir, "dry-run.md")
+	require.NoError(t, os.WriteFile(testFile, []byte(dryRunTestWorkflow), 0644), "Failed to write test file")
+	lockFile := stringutil.MarkdownToLockFile(testFile)
+
+	compiler := NewCompiler()
+	dryRunYAML, err := compiler.CompileWorkflowDryRun(testFile)
+	require.NoError(t, err, "Dry run should compile valid workflow")
+	assert.NotEmpty(t, dryRunYAML, "Dry run should return compiled YAML")
+
+	_, err = os.Stat(lockFile)
+	assert.True(t, os.IsNotExist(err), "Dry run should not write the lock file")
+
+	require.NoError(t, NewCompiler().CompileWorkflow(testFile), "CompileWorkflow should compile valid workflow")
+	written, err := os.ReadFile(lockFile)
+	require.NoError(t, err, "CompileWorkflow should write the lock file")
+
+	assert.Equal(t, string(written), dryRunYAML, "Dry run YAML should match the lock file written by CompileWorkflow")
+}
+
+func TestCompileWorkflowDryRun_DoesNotOverwriteExistingLockFile(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "dry-run-existing-test")
+	testFile := filepath.Join(tmpDir, "dry-run.md")
+	require.NoError(t, os.WriteFile(testFile, []byte(dryRunTestWorkflow), 0644), "Failed to write test file")
+	lockFile := stringutil.MarkdownToLockFile(testFile)
+	require.NoError(t, os.WriteFile(lockFile, []byte("stale: true\n"), 0644), "Failed to write stale lock file")
+
+	_, err := NewCompiler().CompileWorkflowDryRun(testFile)
+	require.NoError(t, err, "Dry run should compile valid workflow")
+
+	content, err := os.ReadFile(lockFile)
+	require.NoError(t, err, "Lock file should still exist")
+	assert.Equal(t, "stale: true\n", string(content), "Dry run should leave the existing lock file untouched")
+}
+
+func TestCompileWorkflowDryRun_CompileError(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "dry-run-error-test")
+	testFile := filepath.Join(tmpDir, "invalid.md")
+	content := `---
+on: push
+engine: not-a-real-engine
+---
+
+# Invalid Workflow
+`
+	require.NoError(t, os.WriteFile(testFile, []byte(content), 0644), "Failed to write test file")
+
+	yamlContent, err := NewCompiler().CompileWorkflowDryRun(testFile)
+	require.Error(t, err, "Dry run should return the compile error")
+	assert.Empty(t, yamlContent, "Dry run should not return YAML on error")
+
+	_, statErr := os.Stat(stringutil.MarkdownToLockFile(testFile))
+	assert.True(t, os.IsNotExist(statErr), "Dry run should not write a lock file on error")
+}
+
+func TestCompileString_MatchesCompileWorkflowDryRun(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "compile-string-test")
+	testFile := filepath.Join(tmpDir, "dry-run.md")
+	require.NoError(t, os.WriteFile(testFile, []byte(dryRunTestWorkflow), 0644), "Failed to write test file")
+
+	fromFile, err := NewCompiler().CompileWorkflowDryRun(testFile)
+	require.NoError(t, err, "Dry run should compile valid workflow")
+	require.NoError(t, os.Remove(testFile), "Failed to remove test file")
+
+	fromString, err := NewCompiler().CompileString(dryRunTestWorkflow, testFile)
+	require.NoError(t, err, "CompileString should compile content for a path that does not exist")
+	assert.Equal(t, fromFile, fromString, "CompileString YAML should match compiling the same content from disk")
+
+	entries, err := os.ReadDir(tmpDir)
+	require.NoError(t, err, "Failed to read temp dir")
+	assert.Empty(t, entries, "CompileString should not write any files")
+}
+
+func TestCompileString_ResolvesImportsFromBaseDir(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "compile-string-imports-test")
+	require.NoError(t, os.MkdirAll(filepath.Join(tmpDir, "shared"), 0755), "Failed to create shared dir")
+	shared := "---\ntools:\n  web-fetch:\n---\n\nShared instructions.\n"
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "shared", "fetch.md"), []byte(shared), 0644), "Failed to write shared file")
+
+	content := "---\non: push\npermissions:\n  contents: read\nengine: copilot\nimports:\n  - shared/fetch.md\n---\n\n# Import Test\n"
+	yamlContent, err := NewCompiler().CompileString(content, filepath.Join(tmpDir, "stdin.md"))
+	require.NoError(t, err, "CompileString should resolve imports relative to the markdown path")
+	assert.Contains(t, yamlContent, "shared/fetch.md", "Lock YAML should record the import")
+
+	_, err = NewCompiler().CompileString("---\non: push\nengine: not-a-real-engine\n---\n", filepath.Join(tmpDir, "bad.md"))
+	require.Error(t, err, "CompileString should return compile errors")
+}
@@ -1,9 +1,12 @@
 package workflow
 
 import (
+	"errors"
 	"fmt"
-	"os"
 	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
 	"strings"
 
 	"github.com/github/gh-aw/pkg/stringutil"
@@ -17,6 +20,9 @@ import (
 
 var compilerJobsLog = logger.New("workflow:compiler_jobs")
 
+// agentOutputReferencePattern matches references to agent job outputs such as needs.agent.outputs.output_types
+var agentOutputReferencePattern = regexp.MustCompile(`needs\.` + string(constants.AgentJobName) + `\.outputs\.([A-Za-z0-9_-]+)`)
+
 // This file contains job building functions extracted from compiler.go
 // These functions are responsible for constructing the various jobs that make up
 // a compiled agentic workflow, including activation, main, safe outputs, and custom jobs.
@@ -70,8 +76,12 @@ func jobDependsOnPreActivation(jobConfig map[string]any) bool {
 // Jobs that depend on agent should run AFTER the agent job, not before it.
 // The jobConfig parameter is expected to be a map representing the job's YAML configuration,
 // where "needs" can be either a string (single dependency) or []any (multiple dependencies).
+// A job that reads needs.agent.outputs.* also depends on agent, even without listing it in "needs".
 // Returns false if "needs" is missing, malformed, or doesn't contain the agent job.
 func jobDependsOnAgent(jobConfig map[string]any) bool {
+	if len(referencedAgentOutputs(jobConfig)) > 0 {
+		return true
+	}
 	if needs, hasNeeds := jobConfig["needs"]; hasNeeds {
 		if needsList, ok := needs.([]any); ok {
 			for _, need := range needsList {
@@ -86,6 +96,64 @@ func jobDependsOnAgent(jobConfig map[string]any) bool {
 	return false
 }
 
+// referencedAgentOutputs returns the sorted, de-duplicated names of the agent job outputs
+// referenced anywhere in a job config as needs.agent.outputs.<name>
+func referencedAgentOutputs(jobConfig map[string]any) []string {
+	seen := make(map[string]bool)
+	var collect func(value any)
+	collect = func(value any) {
+		switch v := value.(type) {
+		case string:
+			for _, match := range agentOutputReferencePattern.FindAllStringSubmatch(v, -1) {
+				seen[match[1]] = true
+			}
+		case map[string]any:
+			for _, item := range v {
+				collect(item)
+			}
+		case []any:
+			for _, item := range v {
+				collect(item)
+			}
+		}
+	}
+	collect(jobConfig)
+
+	outputs := make([]string, 0, len(seen))
+	for name := range seen {
+		outputs = append(outputs, name)
+	}
+	sort.Strings(outputs)
+	return outputs
+}
+
+// validateAgentOutputReferences checks that every agent output referenced by a custom job
+// is declared by the agent job. The safe-output outputs (output, output_types, has_patch)
+// are only declared when the workflow configures safe-outputs.
+func (c *Compiler) validateAgentOutputReferences(jobName string, referencedOutputs []string) error {
+	agentJob, exists := c.jobManager.GetJob(string(constants.AgentJobName))
+	if !exists {
+		return nil
+	}
+	for _, output := range referencedOutputs {
+		if _, declared := agentJob.Outputs[output]; declared {
+			continue
+		}
+		declaredOutputs := make([]string, 0, len(agentJob.Outputs))
+		for name := range agentJob.Outputs {
+			declaredOutputs = append(declaredOutputs, name)
+		}
+		sort.Strings(declaredOutputs)
+		message := fmt.Sprintf("jobs.%s references needs.%s.outputs.%s, but the %s job does not declare that output (declared outputs: %s)",
+			jobName, constants.AgentJobName, output, constants.AgentJobName, strings.Join(declaredOutputs, ", "))
+		if output == "output" || output == "output_types" || output == "has_patch" {
+			message += ". This output is only available when safe-outputs is configured"
+		}
+		return errors.New(message)
+	}
+	return nil
+}
+
 // getCustomJobsDependingOnPreActivation returns custom job names that explicitly depend on pre_activation.
 // These jobs run after pre_activation but before activation, and activation should depend on them.
 func (c *Compiler) getCustomJobsDependingOnPreActivation(customJobs map[string]any) []string {
@@ -127,7 +195,7 @@ func (c *Compiler) buildJobs(data *WorkflowData, markdownPath string) error {
 
 	// Try to read frontmatter to determine event types for safe events check
 	var frontmatter map[string]any
-	if content, err := os.ReadFile(markdownPath); err == nil {
+	if content, err := c.readWorkflowSource(markdownPath); err == nil {
 		if result, err := parser.ExtractFrontmatterFromContent(string(content)); err == nil {
 			frontmatter = result.Frontmatter
 		}
@@ -187,6 +255,8 @@ func (c *Compiler) buildPreActivationAndActivationJobs(data *WorkflowData, front
 		if err != nil {
 			return false, false, fmt.Errorf("failed to build %s job: %w", constants.PreActivationJobName, err)
 		}
+		c.explainJob(preActivationJob, "created because %s; the workflow only runs when these checks pass",
+			preActivationJobReasons(needsPermissionCheck, hasStopTime, hasSkipIfMatch, hasSkipIfNoMatch, hasCommandTrigger))
 		if err := c.jobManager.AddJob(preActivationJob); err != nil {
 			return false, false, fmt.Errorf("failed to add %s job: %w", constants.PreActivationJobName, err)
 		}
@@ -208,6 +278,7 @@ func (c *Compiler) buildPreActivationAndActivationJobs(data *WorkflowData, front
 		if err != nil {
 			return preActivationJobCreated, false, fmt.Errorf("failed to build activation job: %w", err)
 		}
+		c.explainJob(activationJob, "always created; checks that the lock file is up to date and prepares the prompt before the agent runs")
 		if err := c.jobManager.AddJob(activationJob); err != nil {
 			return preActivationJobCreated, false, fmt.Errorf("failed to add activation job: %w", err)
 		}
@@ -225,6 +296,7 @@ func (c *Compiler) buildMainJobWrapper(data *WorkflowData, activationJobCreated
 	if err != nil {
 		return fmt.Errorf("failed to build main job: %w", err)
 	}
+	c.explainJob(mainJob, "always created; runs the %s engine with the workflow prompt", agentJobEngineID(data))
 	if err := c.jobManager.AddJob(mainJob); err != nil {
 		return fmt.Errorf("failed to add main job: %w", err)
 	}
@@ -274,6 +346,8 @@ func (c *Compiler) buildPushRepoMemoryJobWrapper(data *WorkflowData, threatDetec
 		return "", nil
 	}
 
+	c.explainJob(pushRepoMemoryJob, "created because tools.repo-memory is configured; pushes the agent's memory files to their git branch")
+
 	// Add detection dependency if threat detection is enabled
 	if threatDetectionEnabled {
 		pushRepoMemoryJob.Needs = append(pushRepoMemoryJob.Needs, string(constants.DetectionJobName))
@@ -309,6 +383,8 @@ func (c *Compiler) buildUpdateCacheMemoryJobWrapper(data *WorkflowData, threatDe
 		return "", nil
 	}
 
+	c.explainJob(updateCacheMemoryJob, "created because tools.cache-memory is configured with threat detection; saves the cache only after detection passes")
+
 	if err := c.jobManager.AddJob(updateCacheMemoryJob); err != nil {
 		return "", fmt.Errorf("failed to add update_cache_memory job: %w", err)
 	}
@@ -342,8 +418,79 @@ func (c *Compiler) updateConclusionJobDependencies(pushRepoMemoryJobName, update
 
 // extractJobsFromFrontmatter extracts job configuration from frontmatter
 // This now uses the structured extraction helper for consistency
-func (c *Compiler) extractJobsFromFrontmatter(frontmatter map[string]any) map[string]any {
-	return ExtractMapField(frontmatter, "jobs")
+// Returns an error when a job declares an invalid timeout-minutes value
+func (c *Compiler) extractJobsFromFrontmatter(frontmatter map[string]any) (map[string]any, error) {
+	jobs := ExtractMapField(frontmatter, "jobs")
+	if err := validateCustomJobNames(jobs); err != nil {
+		return nil, err
+	}
+	if err := validateCustomJobTimeouts(jobs); err != nil {
+		return nil, err
+	}
+	return jobs, nil
+}
+
+// reservedJobNames are the names of jobs generated by the compiler. A custom job with one of
+// these names would collide with the generated job. jobs.pre-activation is not reserved
+// because it extends the generated pre_activation job (see buildPreActivationJob).
+var reservedJobNames = []constants.JobName{
+	constants.AgentJobName,
+	constants.ActivationJobName,
+	constants.DetectionJobName,
+	constants.ConclusionJobName,
+	constants.SafeOutputsJobName,
+	constants.PushRepoMemoryJobName,
+	constants.UpdateCacheMemoryJobName,
+}
+
+// validateCustomJobNames rejects custom jobs whose names collide with generated jobs
+func validateCustomJobNames(jobs map[string]any) error {
+	jobNames := make([]string, 0, len(jobs))
+	for jobName := range jobs {
+		jobNames = append(jobNames, jobName)
+	}
+	slices.Sort(jobNames)
+
+	for _, jobName := range jobNames {
+		if slices.Contains(reservedJobNames, constants.JobName(jobName)) {
+			reserved := make([]string, len(reservedJobNames))
+			for i, name := range reservedJobNames {
+				reserved[i] = name.String()
+			}
+			return fmt.Errorf("jobs.%s conflicts with the '%s' job generated by gh-aw. Rename the custom job; reserved job names are: %s", jobName, jobName, strings.Join(reserved, ", "))
+		}
+	}
+	return nil
+}
+
+// validateCustomJobTimeouts checks that every custom job's timeout-minutes is a positive
+// integer or a GitHub Actions expression. GitHub Actions ignores invalid timeouts instead
+// of failing, so bad literal values are rejected at compile time; expressions are only
+// known at runtime and are passed through.
+func validateCustomJobTimeouts(jobs map[string]any) error {
+	jobNames := make([]string, 0, len(jobs))
+	for jobName := range jobs {
+		jobNames = append(jobNames, jobName)
+	}
+	slices.Sort(jobNames)
+
+	for _, jobName := range jobNames {
+		configMap, ok := jobs[jobName].(map[string]any)
+		if !ok {
+			continue
+		}
+		timeout, hasTimeout := configMap["timeout-minutes"]
+		if !hasTimeout {
+			continue
+		}
+		if timeoutStr, ok := timeout.(string); ok && isGitHubExpression(timeoutStr) {
+			continue
+		}
+		if !stringutil.IsPositiveInteger(fmt.Sprint(timeout)) {
+			return fmt.Errorf("jobs.%s.timeout-minutes must be a positive integer or a GitHub Actions expression, got %v. Example: timeout-minutes: 30", jobName, timeout)
+		}
+	}
+	return nil
 }
 
 // buildCustomJobs creates custom jobs defined in the frontmatter jobs section
@@ -377,6 +524,25 @@ func (c *Compiler) buildCustomJobs(data *WorkflowData, activationJobCreated bool
 				}
 			}
 
+			// Jobs reading needs.agent.outputs.* need a real dependency on the agent job,
+			// and may only reference outputs the agent job declares
+			if agentOutputs := referencedAgentOutputs(configMap); len(agentOutputs) > 0 {
+				if err := c.validateAgentOutputReferences(jobName, agentOutputs); err != nil {
+					return err
+				}
+				if !slices.Contains(job.Needs, string(constants.AgentJobName)) {
+					job.Needs = append(job.Needs, string(constants.AgentJobName))
+					compilerJobsLog.Printf("Added dependency on agent job to custom job '%s' for outputs: %v", jobName, agentOutputs)
+				}
+				hasExplicitNeeds = true
+			}
+
+			// Drop the dependency on the conclusion job when it was disabled (safe-outputs.conclusion: false)
+			if isConclusionJobDisabled(data) && slices.Contains(job.Needs, "conclusion") {
+				job.Needs = slices.DeleteFunc(job.Needs, func(need string) bool { return need == "conclusion" })
+				compilerJobsLog.Printf("Removed dependency on disabled conclusion job from custom job '%s'", jobName)
+			}
+
 			// If no explicit needs and activation job exists, automatically add activation as dependency
 			// This ensures custom jobs wait for workflow validation before executing
 			if !hasExplicitNeeds && activationJobCreated {
@@ -386,9 +552,11 @@ func (c *Compiler) buildCustomJobs(data *WorkflowData, activationJobCreated bool
 
 			// Extract other job properties
 			if runsOn, hasRunsOn := configMap["runs-on"]; hasRunsOn {
-				if runsOnStr, ok := runsOn.(string); ok {
-					job.RunsOn = fmt.Sprintf("runs-on: %s", runsOnStr)
+				runsOnConfig, err := parseRunsOn(runsOn)
+				if err != nil {
+					return fmt.Errorf("invalid jobs.%s.runs-on: %w", jobName, err)
 				}
+				job.RunsOn = c.indentYAMLLines(runsOnConfig.RenderToYAML(), "    ")
 			}
 
 			if ifCond, hasIf := configMap["if"]; hasIf {
@@ -397,6 +565,26 @@ func (c *Compiler) buildCustomJobs(data *WorkflowData, activationJobCreated bool
 				}
 			}
 
+			// Extract timeout-minutes (validated in extractJobsFromFrontmatter)
+			if timeout, hasTimeout := configMap["timeout-minutes"]; hasTimeout {
+				if timeoutStr, ok := timeout.(string); ok && isGitHubExpression(timeoutStr) {
+					job.TimeoutMinutesExpression = timeoutStr
+				} else if timeoutMinutes, ok := parseIntValue(timeout); ok {
+					job.TimeoutMinutes = timeoutMinutes
+				}
+			}
+
+			// Extract strategy (matrix, fail-fast, max-parallel)
+			if strategy, hasStrategy := configMap["strategy"]; hasStrategy {
+				if strategyMap, ok := strategy.(map[string]any); ok {
+					strategyYAML, err := renderJobStrategy(strategyMap)
+					if err != nil {
+						return fmt.Errorf("failed to convert strategy to YAML for job '%s': %w", jobName, err)
+					}
+					job.Strategy = strategyYAML
+				}
+			}
+
 			// Extract permissions
 			if permissions, hasPermissions := configMap["permissions"]; hasPermissions {
 				if permsMap, ok := permissions.(map[string]any); ok {
@@ -489,6 +677,8 @@ func (c *Compiler) buildCustomJobs(data *WorkflowData, activationJobCreated bool
 				}
 			}
 
+			c.explainJob(job, "created because jobs.%s is defined in the frontmatter", jobName)
+
 			if err := c.jobManager.AddJob(job); err != nil {
 				return fmt.Errorf("failed to add custom job '%s': %w", jobName, err)
 			}
@@ -500,15 +690,38 @@ func (c *Compiler) buildCustomJobs(data *WorkflowData, activationJobCreated bool
 	return nil
 }
 
+// renderJobStrategy renders a custom job strategy as a job-level YAML block.
+// The strategy is preserved verbatim, with matrix first followed by fail-fast and max-parallel.
+func renderJobStrategy(strategy map[string]any) (string, error) {
+	yamlBytes, err := MarshalWithFieldOrder(strategy, []string{"matrix", "fail-fast", "max-parallel"})
+	if err != nil {
+		return "", err
+	}
+
+	var formatted strings.Builder
+	formatted.WriteString("strategy:")
+	for line := range strings.SplitSeq(strings.TrimRight(string(yamlBytes), "\n"), "\n") {
+		formatted.WriteString("\n      " + line)
+	}
+	return formatted.String(), nil
+}
+
 // shouldAddCheckoutStep returns true if the workflow requires a checkout step.
 // The repository checkout is needed in the agent job to access workflow files,
 // custom agent files, and other repository content.
 //
 // The checkout step is only skipped when:
 //   - Custom steps already contain a checkout action
+//   - checkout: false is set and nothing reads repository files at runtime
 //
 // Otherwise, checkout is always added to ensure the agent has access to the repository.
 func (c *Compiler) shouldAddCheckoutStep(data *WorkflowData) bool {
+	// checkout: false opts out of the repository checkout for read-only workflows
+	if isAgentCheckoutSkipped(data) {
+		log.Print("Skipping checkout step: checkout disabled in frontmatter")
+		return false
+	}
+
 	// If custom steps already contain checkout, don't add another one
 	if data.CustomSteps != "" && ContainsCheckout(data.CustomSteps) {
 		log.Print("Skipping checkout step: custom steps already contain checkout")
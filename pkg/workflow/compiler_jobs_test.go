@@ -5,6 +5,7 @@ package workflow
 import (
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
 
@@ -58,7 +59,10 @@ func TestExtractJobsFromFrontmatter(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := compiler.extractJobsFromFrontmatter(tt.frontmatter)
+			result, err := compiler.extractJobsFromFrontmatter(tt.frontmatter)
+			if err != nil {
+				t.Fatalf("extractJobsFromFrontmatter() unexpected error: %v", err)
+			}
 			if len(result) != tt.expectedLen {
 				t.Errorf("extractJobsFromFrontmatter() returned %d jobs, want %d", len(result), tt.expectedLen)
 			}
@@ -66,6 +70,90 @@ func TestExtractJobsFromFrontmatter(t *testing.T) {
 	}
 }
 
+// TestExtractJobsFromFrontmatterTimeoutValidation tests validation of custom job timeout-minutes
+func TestExtractJobsFromFrontmatterTimeoutValidation(t *testing.T) {
+	compiler := NewCompiler()
+
+	tests := []struct {
+		name        string
+		timeout     any
+		expectError bool
+	}{
+		{name: "valid timeout", timeout: 30},
+		{name: "valid unsigned timeout", timeout: uint64(45)},
+		{name: "zero timeout", timeout: 0, expectError: true},
+		{name: "negative timeout", timeout: -5, expectError: true},
+		{name: "fractional timeout", timeout: 1.5, expectError: true},
+		{name: "non-numeric timeout", timeout: "soon", expectError: true},
+		{name: "expression timeout", timeout: "${{ inputs.timeout }}"},
+		{name: "expression with fallback timeout", timeout: "${{ fromJSON(vars.TIMEOUT || '30') }}"},
+		{name: "unterminated expression timeout", timeout: "${{ inputs.timeout", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			frontmatter := map[string]any{
+				"jobs": map[string]any{
+					"build": map[string]any{"runs-on": "ubuntu-latest", "timeout-minutes": tt.timeout},
+				},
+			}
+
+			_, err := compiler.extractJobsFromFrontmatter(frontmatter)
+			if !tt.expectError {
+				if err != nil {
+					t.Errorf("extractJobsFromFrontmatter() unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("extractJobsFromFrontmatter() expected an error for invalid timeout")
+			}
+			if !strings.Contains(err.Error(), "jobs.build.timeout-minutes must be a positive integer or a GitHub Actions expression") {
+				t.Errorf("error should name the job, got: %v", err)
+			}
+		})
+	}
+}
+
+// TestExtractJobsFromFrontmatterReservedNames tests that custom jobs cannot use the names of generated jobs
+func TestExtractJobsFromFrontmatterReservedNames(t *testing.T) {
+	compiler := NewCompiler()
+
+	for _, reserved := range reservedJobNames {
+		t.Run(reserved.String(), func(t *testing.T) {
+			frontmatter := map[string]any{
+				"jobs": map[string]any{
+					reserved.String(): map[string]any{"runs-on": "ubuntu-latest"},
+				},
+			}
+
+			_, err := compiler.extractJobsFromFrontmatter(frontmatter)
+			if err == nil {
+				t.Fatalf("extractJobsFromFrontmatter() expected an error for reserved job name %q", reserved)
+			}
+			if !strings.Contains(err.Error(), "jobs."+reserved.String()+" conflicts with the '"+reserved.String()+"' job generated by gh-aw") {
+				t.Errorf("error should name the conflicting job, got: %v", err)
+			}
+			if !strings.Contains(err.Error(), "reserved job names are: agent, activation, detection, conclusion, safe_outputs, push_repo_memory, update_cache_memory") {
+				t.Errorf("error should list the reserved job names, got: %v", err)
+			}
+		})
+	}
+
+	for _, allowed := range []string{"build", "agent_setup", "pre-activation", string(constants.PreActivationJobName)} {
+		t.Run(allowed, func(t *testing.T) {
+			frontmatter := map[string]any{
+				"jobs": map[string]any{
+					allowed: map[string]any{"runs-on": "ubuntu-latest"},
+				},
+			}
+			if _, err := compiler.extractJobsFromFrontmatter(frontmatter); err != nil {
+				t.Errorf("extractJobsFromFrontmatter() unexpected error for job %q: %v", allowed, err)
+			}
+		})
+	}
+}
+
 // ========================================
 // Helper Function Tests
 // ========================================
@@ -157,6 +245,20 @@ func TestJobDependsOnAgentEdgeCases(t *testing.T) {
 			},
 			expected: true,
 		},
+		{
+			name: "references agent outputs without needs",
+			jobConfig: map[string]any{
+				"steps": []any{map[string]any{"run": "echo ${{ needs.agent.outputs.output_types }}"}},
+			},
+			expected: true,
+		},
+		{
+			name: "references other job outputs",
+			jobConfig: map[string]any{
+				"steps": []any{map[string]any{"run": "echo ${{ needs.build.outputs.version }}"}},
+			},
+			expected: false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -169,6 +271,32 @@ func TestJobDependsOnAgentEdgeCases(t *testing.T) {
 	}
 }
 
+// TestReferencedAgentOutputs tests collecting agent output references from a job config
+func TestReferencedAgentOutputs(t *testing.T) {
+	jobConfig := map[string]any{
+		"if": "contains(needs.agent.outputs.output_types, 'create_issue')",
+		"steps": []any{
+			map[string]any{
+				"run": "echo $TYPES",
+				"env": map[string]any{
+					"TYPES":     "${{ needs.agent.outputs.output_types }}",
+					"HAS_PATCH": "${{ needs.agent.outputs.has_patch }}",
+				},
+			},
+		},
+	}
+
+	got := referencedAgentOutputs(jobConfig)
+	want := []string{"has_patch", "output_types"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("referencedAgentOutputs() = %v, want %v", got, want)
+	}
+
+	if got := referencedAgentOutputs(map[string]any{"needs": "agent"}); len(got) != 0 {
+		t.Errorf("referencedAgentOutputs() = %v, want no outputs", got)
+	}
+}
+
 // TestGetCustomJobsDependingOnPreActivationEdgeCases tests edge cases for getCustomJobsDependingOnPreActivation method
 func TestGetCustomJobsDependingOnPreActivationEdgeCases(t *testing.T) {
 	compiler := NewCompiler()
@@ -1033,6 +1161,103 @@ Test content`
 	}
 }
 
+// TestPostAgentJobConsumesOutputTypes tests a custom job that reads the agent's output_types
+func TestPostAgentJobConsumesOutputTypes(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "post-agent-job-test")
+
+	frontmatter := `---
+on: issues
+permissions:
+  contents: read
+engine: copilot
+safe-outputs:
+  create-issue:
+jobs:
+  report:
+    runs-on: ubuntu-latest
+    if: contains(needs.agent.outputs.output_types, 'create_issue')
+    steps:
+      - run: echo "Agent produced $OUTPUT_TYPES"
+        env:
+          OUTPUT_TYPES: ${{ needs.agent.outputs.output_types }}
+---
+
+# Test Workflow
+
+Test content`
+
+	testFile := filepath.Join(tmpDir, "test.md")
+	if err := os.WriteFile(testFile, []byte(frontmatter), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	compiler := NewCompiler()
+	if err := compiler.CompileWorkflow(testFile); err != nil {
+		t.Fatalf("CompileWorkflow() error: %v", err)
+	}
+
+	reportJob, exists := compiler.jobManager.GetJob("report")
+	if !exists {
+		t.Fatal("Expected report job")
+	}
+	if !slices.Contains(reportJob.Needs, string(constants.AgentJobName)) {
+		t.Errorf("Expected report job to depend on agent, got needs: %v", reportJob.Needs)
+	}
+	if slices.Contains(reportJob.Needs, string(constants.ActivationJobName)) {
+		t.Errorf("Expected report job to depend on activation only through agent, got needs: %v", reportJob.Needs)
+	}
+
+	agentJob, exists := compiler.jobManager.GetJob(string(constants.AgentJobName))
+	if !exists {
+		t.Fatal("Expected agent job")
+	}
+	if _, declared := agentJob.Outputs["output_types"]; !declared {
+		t.Errorf("Expected agent job to declare output_types, got outputs: %v", agentJob.Outputs)
+	}
+	if slices.Contains(agentJob.Needs, "report") {
+		t.Errorf("Expected agent job not to wait for the post-agent report job, got needs: %v", agentJob.Needs)
+	}
+}
+
+// TestPostAgentJobUndeclaredOutput tests that referencing an agent output that is not
+// declared fails compilation
+func TestPostAgentJobUndeclaredOutput(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "post-agent-job-error-test")
+
+	frontmatter := `---
+on: issues
+permissions:
+  contents: read
+engine: copilot
+jobs:
+  report:
+    runs-on: ubuntu-latest
+    needs: agent
+    steps:
+      - run: echo "${{ needs.agent.outputs.output_types }}"
+---
+
+# Test Workflow
+
+Test content`
+
+	testFile := filepath.Join(tmpDir, "test.md")
+	if err := os.WriteFile(testFile, []byte(frontmatter), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	err := NewCompiler().CompileWorkflow(testFile)
+	if err == nil {
+		t.Fatal("Expected compilation to fail for an undeclared agent output")
+	}
+	if !strings.Contains(err.Error(), "jobs.report references needs.agent.outputs.output_types") {
+		t.Errorf("Expected error to name the undeclared output, got: %v", err)
+	}
+	if !strings.Contains(err.Error(), "only available when safe-outputs is configured") {
+		t.Errorf("Expected error to mention safe-outputs, got: %v", err)
+	}
+}
+
 // TestJobReferencingCustomJobOutputs tests jobs that reference outputs from custom jobs
 func TestJobReferencingCustomJobOutputs(t *testing.T) {
 	tmpDir := testutil.TempDir(t, "job-outputs-ref-test")
@@ -1732,6 +1957,231 @@ Test content`
 	}
 }
 
+// TestBuildCustomJobsWithMatrixStrategy tests that custom job matrix strategies round-trip into the lock file
+func TestBuildCustomJobsWithMatrixStrategy(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "matrix-strategy-test")
+
+	frontmatter := `---
+on: push
+permissions:
+  contents: read
+engine: copilot
+strict: false
+jobs:
+  test_matrix:
+    runs-on: ${{ matrix.os }}
+    strategy:
+      fail-fast: false
+      max-parallel: 2
+      matrix:
+        os: [ubuntu-latest, windows-latest]
+        node: [20, 22]
+        include:
+          - os: ubuntu-latest
+            node: 24
+            experimental: true
+        exclude:
+          - os: windows-latest
+            node: 20
+    steps:
+      - run: echo "${{ matrix.node }}"
+  dynamic_matrix:
+    runs-on: ubuntu-latest
+    strategy:
+      matrix: ${{ fromJSON(needs.activation.outputs.text) }}
+    steps:
+      - run: echo "dynamic"
+---
+
+# Test Workflow
+
+Test content`
+
+	testFile := filepath.Join(tmpDir, "test.md")
+	if err := os.WriteFile(testFile, []byte(frontmatter), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	compiler := NewCompiler()
+	if err := compiler.CompileWorkflow(testFile); err != nil {
+		t.Fatalf("CompileWorkflow() error: %v", err)
+	}
+
+	// Read compiled output
+	lockFile := filepath.Join(tmpDir, "test.lock.yml")
+	content, err := os.ReadFile(lockFile)
+	if err != nil {
+		t.Fatalf("Failed to read lock file: %v", err)
+	}
+
+	yamlStr := string(content)
+
+	expectedStrategy := `    strategy:
+      matrix:
+        exclude:
+        - node: 20
+          os: windows-latest
+        include:
+        - experimental: true
+          node: 24
+          os: ubuntu-latest
+        node:
+        - 20
+        - 22
+        os:
+        - ubuntu-latest
+        - windows-latest
+      fail-fast: false
+      max-parallel: 2
+`
+	if !strings.Contains(yamlStr, expectedStrategy) {
+		t.Errorf("Expected matrix strategy block to round-trip into the lock file, got:\n%s", yamlStr)
+	}
+
+	if !strings.Contains(yamlStr, "    strategy:\n      matrix: ${{ fromJSON(needs.activation.outputs.text) }}\n") {
+		t.Error("Expected expression matrix to be preserved")
+	}
+}
+
+// TestCompileCustomJobWithReservedName tests that compiling a workflow with a reserved custom job name fails
+func TestCompileCustomJobWithReservedName(t *testing.T) {
+	tests := []struct {
+		jobName     string
+		expectError bool
+	}{
+		{jobName: "conclusion", expectError: true},
+		{jobName: "safe_outputs", expectError: true},
+		{jobName: "report", expectError: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.jobName, func(t *testing.T) {
+			content := `---
+on: push
+permissions:
+  contents: read
+engine: copilot
+strict: false
+jobs:
+  ` + tt.jobName + `:
+    runs-on: ubuntu-latest
+    steps:
+      - run: echo "custom job"
+---
+
+# Test Workflow
+`
+			markdownPath := filepath.Join(testutil.TempDir(t, "reserved-job-name-test"), "test.md")
+
+			lockContent, err := NewCompiler().CompileString(content, markdownPath)
+			if !tt.expectError {
+				if err != nil {
+					t.Fatalf("CompileString() unexpected error: %v", err)
+				}
+				if !strings.Contains(lockContent, "  "+tt.jobName+":\n") {
+					t.Errorf("lock file should contain the custom job %q", tt.jobName)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("CompileString() expected an error for reserved job name %q", tt.jobName)
+			}
+			if !strings.Contains(err.Error(), "jobs."+tt.jobName+" conflicts with") {
+				t.Errorf("error should name the conflicting job, got: %v", err)
+			}
+		})
+	}
+}
+
+// TestBuildCustomJobsWithTimeout tests that custom job timeout-minutes is rendered into the lock file
+func TestBuildCustomJobsWithTimeout(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "custom-job-timeout-test")
+
+	frontmatter := `---
+on: push
+permissions:
+  contents: read
+engine: copilot
+strict: false
+jobs:
+  build:
+    runs-on: ubuntu-latest
+    timeout-minutes: 15
+    steps:
+      - run: echo "build"
+---
+
+# Test Workflow
+
+Test content`
+
+	testFile := filepath.Join(tmpDir, "test.md")
+	if err := os.WriteFile(testFile, []byte(frontmatter), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	compiler := NewCompiler()
+	if err := compiler.CompileWorkflow(testFile); err != nil {
+		t.Fatalf("CompileWorkflow() error: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(tmpDir, "test.lock.yml"))
+	if err != nil {
+		t.Fatalf("Failed to read lock file: %v", err)
+	}
+
+	if !strings.Contains(string(content), "    runs-on: ubuntu-latest\n    timeout-minutes: 15\n") {
+		t.Errorf("Expected custom job timeout-minutes in lock file, got:\n%s", content)
+	}
+}
+
+// TestBuildCustomJobsWithTimeoutExpression tests that an expression timeout-minutes is passed through to the lock file
+func TestBuildCustomJobsWithTimeoutExpression(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "custom-job-timeout-expression-test")
+
+	frontmatter := `---
+on:
+  workflow_dispatch:
+    inputs:
+      timeout:
+        description: Build timeout in minutes
+        default: "20"
+permissions:
+  contents: read
+engine: copilot
+strict: false
+jobs:
+  build:
+    runs-on: ubuntu-latest
+    timeout-minutes: ${{ fromJSON(inputs.timeout) }}
+    steps:
+      - run: echo "build"
+---
+
+# Test Workflow
+
+Test content`
+
+	testFile := filepath.Join(tmpDir, "test.md")
+	if err := os.WriteFile(testFile, []byte(frontmatter), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	compiler := NewCompiler()
+	if err := compiler.CompileWorkflow(testFile); err != nil {
+		t.Fatalf("CompileWorkflow() error: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(tmpDir, "test.lock.yml"))
+	if err != nil {
+		t.Fatalf("Failed to read lock file: %v", err)
+	}
+
+	if !strings.Contains(string(content), "    runs-on: ubuntu-latest\n    timeout-minutes: ${{ fromJSON(inputs.timeout) }}\n") {
+		t.Errorf("Expected custom job timeout-minutes expression in lock file, got:\n%s", content)
+	}
+}
+
 // TestBuildCustomJobsWithConditionals tests custom jobs with if conditions
 func TestBuildCustomJobsWithConditionals(t *testing.T) {
 	tmpDir := testutil.TempDir(t, "conditionals-test")
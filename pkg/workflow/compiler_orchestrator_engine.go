@@ -84,8 +84,9 @@ func (c *Compiler) setupEngineAndImports(result *parser.FrontmatterResult, clean
 	if c.engineOverride != "" {
 		originalEngineSetting := engineSetting
 		if originalEngineSetting != "" && originalEngineSetting != c.engineOverride {
-			fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("Command line --engine %s overrides markdown file engine: %s", c.engineOverride, originalEngineSetting)))
-			c.IncrementWarningCount()
+			warningMsg := fmt.Sprintf("Command line --engine %s overrides markdown file engine: %s", c.engineOverride, originalEngineSetting)
+			fmt.Fprintln(os.Stderr, console.FormatWarningMessage(warningMsg))
+			c.addWarning(warningMsg)
 		}
 		engineSetting = c.engineOverride
 	}
@@ -94,7 +95,12 @@ func (c *Compiler) setupEngineAndImports(result *parser.FrontmatterResult, clean
 	orchestratorEngineLog.Printf("Processing imports from frontmatter")
 	importCache := c.getSharedImportCache()
 	// Pass the full file content for accurate line/column error reporting
-	importsResult, err := parser.ProcessImportsFromFrontmatterWithSource(result.Frontmatter, markdownDir, importCache, cleanPath, string(content))
+	processImports := parser.ProcessImportsFromFrontmatterWithSource
+	if c.inlineImports {
+		// Inline every import so the prompt does not read the repository at runtime
+		processImports = parser.ProcessImportsFromFrontmatterInlined
+	}
+	importsResult, err := processImports(result.Frontmatter, markdownDir, importCache, cleanPath, string(content))
 	if err != nil {
 		orchestratorEngineLog.Printf("Import processing failed: %v", err)
 		return nil, err // Error is already formatted with source location
@@ -213,8 +219,9 @@ func (c *Compiler) setupEngineAndImports(result *parser.FrontmatterResult, clean
 
 	log.Printf("AI engine: %s (%s)", agenticEngine.GetDisplayName(), engineSetting)
 	if agenticEngine.IsExperimental() && c.verbose {
-		fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("Using experimental engine: %s", agenticEngine.GetDisplayName())))
-		c.IncrementWarningCount()
+		warningMsg := fmt.Sprintf("Using experimental engine: %s", agenticEngine.GetDisplayName())
+		fmt.Fprintln(os.Stderr, console.FormatWarningMessage(warningMsg))
+		c.addWarning(warningMsg)
 	}
 
 	// Enable firewall by default for copilot engine when network restrictions are present
@@ -2,7 +2,6 @@ package workflow
 
 import (
 	"fmt"
-	"os"
 	"path/filepath"
 
 	"github.com/github/gh-aw/pkg/logger"
@@ -33,7 +32,7 @@ func (c *Compiler) parseFrontmatterSection(markdownPath string) (*frontmatterPar
 	cleanPath := filepath.Clean(markdownPath)
 
 	// Read the file
-	content, err := os.ReadFile(cleanPath)
+	content, err := c.readWorkflowSource(cleanPath)
 	if err != nil {
 		orchestratorFrontmatterLog.Printf("Failed to read file: %s, error: %v", cleanPath, err)
 		// Don't wrap os.PathError - format it instead to avoid exposing internals
@@ -66,6 +65,18 @@ func (c *Compiler) parseFrontmatterSection(markdownPath string) (*frontmatterPar
 		return nil, err
 	}
 
+	// Validate GitHub toolset names before schema validation so typos get a suggestion
+	if err := validateGitHubToolsetNames(result.Frontmatter); err != nil {
+		orchestratorFrontmatterLog.Printf("GitHub toolset validation failed: %v", err)
+		return nil, err
+	}
+
+	// Validate runs-on before schema validation so runner group mistakes get an example
+	if err := validateRunsOnFields(result.Frontmatter); err != nil {
+		orchestratorFrontmatterLog.Printf("runs-on validation failed: %v", err)
+		return nil, err
+	}
+
 	// Create a copy of frontmatter without internal markers for schema validation
 	// Keep the original frontmatter with markers for YAML generation
 	frontmatterForValidation := c.copyFrontmatterWithoutInternalMarkers(result.Frontmatter)
@@ -20,6 +20,8 @@ type toolsProcessingResult struct {
 	pluginInfo            *PluginInfo // Consolidated plugin information
 	toolsTimeout          int
 	toolsStartupTimeout   int
+	toolTimeouts          map[string]int // Per-tool timeout overrides in seconds
+	bashShell             string         // Shell selected via tools.bash.shell
 	markdownContent       string
 	importedMarkdown      string   // Only imports WITH inputs (for compile-time substitution)
 	importPaths           []string // Import paths for runtime-import macro generation (imports without inputs)
@@ -49,6 +51,16 @@ func (c *Compiler) processToolsAndMarkdown(result *parser.FrontmatterResult, cle
 	orchestratorToolsLog.Printf("Processing tools and markdown")
 	log.Print("Processing tools and includes...")
 
+	// Validate safe-outputs max values before they are parsed, since parsing ignores invalid values
+	if err := validateSafeOutputsMax(result.Frontmatter); err != nil {
+		return nil, err
+	}
+
+	// Validate per-handler if conditions, which are only evaluated when the safe_outputs job runs
+	if err := validateSafeOutputsIf(result.Frontmatter); err != nil {
+		return nil, err
+	}
+
 	// Extract SafeOutputs configuration early so we can use it when applying default tools
 	safeOutputs := c.extractSafeOutputsConfig(result.Frontmatter)
 
@@ -142,10 +154,22 @@ func (c *Compiler) processToolsAndMarkdown(result *parser.FrontmatterResult, cle
 		return nil, fmt.Errorf("invalid tools startup timeout configuration: %w", err)
 	}
 
-	// Remove meta fields (timeout, startup-timeout) from merged tools map
+	toolTimeouts, err := c.extractToolTimeouts(tools)
+	if err != nil {
+		return nil, fmt.Errorf("invalid tools timeout configuration: %w", err)
+	}
+
+	// Extract the bash tool shell selection (normalizes the object form of tools.bash)
+	bashShell, err := extractBashShell(tools)
+	if err != nil {
+		return nil, fmt.Errorf("invalid bash tool configuration: %w", err)
+	}
+
+	// Remove meta fields (timeout, startup-timeout, tool-timeouts) from merged tools map
 	// These are configuration fields, not actual tools
 	delete(tools, "timeout")
 	delete(tools, "startup-timeout")
+	delete(tools, "tool-timeouts")
 
 	// Extract and merge runtimes from frontmatter and imports
 	topRuntimes := extractRuntimesFromFrontmatter(result.Frontmatter)
@@ -220,13 +244,27 @@ func (c *Compiler) processToolsAndMarkdown(result *parser.FrontmatterResult, cle
 		return nil, err
 	}
 
+	// Validate gated allowlist support for the current engine
+	if err := c.validateAllowedWhenSupport(tools, agenticEngine); err != nil {
+		orchestratorToolsLog.Printf("allowed-when support validation failed: %v", err)
+		return nil, err
+	}
+
+	// Validate that the engine honors the bash tool shell selection
+	if err := validateBashShellSupport(bashShell, agenticEngine); err != nil {
+		orchestratorToolsLog.Printf("bash shell support validation failed: %v", err)
+		return nil, err
+	}
+
 	if !agenticEngine.SupportsToolsAllowlist() {
 		// For engines that don't support tool allowlists (like custom engine), ignore tools section and provide warnings
-		fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("Using experimental %s support (engine: %s)", agenticEngine.GetDisplayName(), agenticEngine.GetID())))
-		c.IncrementWarningCount()
+		warningMsg := fmt.Sprintf("Using experimental %s support (engine: %s)", agenticEngine.GetDisplayName(), agenticEngine.GetID())
+		fmt.Fprintln(os.Stderr, console.FormatWarningMessage(warningMsg))
+		c.addWarning(warningMsg)
 		if _, hasTools := result.Frontmatter["tools"]; hasTools {
-			fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("'tools' section ignored when using engine: %s (%s doesn't support MCP tool allow-listing)", agenticEngine.GetID(), agenticEngine.GetDisplayName())))
-			c.IncrementWarningCount()
+			warningMsg := fmt.Sprintf("'tools' section ignored when using engine: %s (%s doesn't support MCP tool allow-listing)", agenticEngine.GetID(), agenticEngine.GetDisplayName())
+			fmt.Fprintln(os.Stderr, console.FormatWarningMessage(warningMsg))
+			c.addWarning(warningMsg)
 		}
 		tools = map[string]any{}
 		// For now, we'll add a basic github tool (always uses docker MCP)
@@ -234,11 +272,31 @@ func (c *Compiler) processToolsAndMarkdown(result *parser.FrontmatterResult, cle
 		tools["github"] = githubConfig
 	}
 
+	// Validate max-turns against the repository ceiling (applies to all engines)
+	if err := c.validateMaxTurnsCeiling(result.Frontmatter); err != nil {
+		return nil, err
+	}
+
 	// Validate max-turns support for the current engine
 	if err := c.validateMaxTurnsSupport(result.Frontmatter, agenticEngine); err != nil {
 		return nil, err
 	}
 
+	// Validate max-tokens support for the current engine
+	if err := c.validateMaxTokensSupport(result.Frontmatter, agenticEngine); err != nil {
+		return nil, err
+	}
+
+	// Validate max-cost value and support for the current engine
+	if err := c.validateMaxCost(result.Frontmatter, agenticEngine); err != nil {
+		return nil, err
+	}
+
+	// Validate tool-timeout-per-call value and support for the current engine
+	if err := c.validateToolTimeoutPerCall(result.Frontmatter, agenticEngine); err != nil {
+		return nil, err
+	}
+
 	// Validate web-search support for the current engine (warning only)
 	c.validateWebSearchSupport(tools, agenticEngine)
 
@@ -289,7 +347,11 @@ func (c *Compiler) processToolsAndMarkdown(result *parser.FrontmatterResult, cle
 	sort.Strings(allIncludedFiles)
 
 	// Extract workflow name
-	workflowName, err := parser.ExtractWorkflowNameFromMarkdown(cleanPath)
+	workflowSource, err := c.readWorkflowSource(cleanPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract workflow name: failed to read file %s: %w", cleanPath, err)
+	}
+	workflowName, err := parser.ExtractWorkflowNameFromContent(string(workflowSource), cleanPath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to extract workflow name: %w", err)
 	}
@@ -325,6 +387,8 @@ func (c *Compiler) processToolsAndMarkdown(result *parser.FrontmatterResult, cle
 		pluginInfo:            pluginInfo,
 		toolsTimeout:          toolsTimeout,
 		toolsStartupTimeout:   toolsStartupTimeout,
+		toolTimeouts:          toolTimeouts,
+		bashShell:             bashShell,
 		markdownContent:       markdownContent,
 		importedMarkdown:      importedMarkdown, // Only imports WITH inputs
 		importPaths:           importPaths,      // Import paths for runtime-import macros (imports without inputs)
@@ -17,6 +17,9 @@ var orchestratorWorkflowLog = logger.New("workflow:compiler_orchestrator_workflo
 func (c *Compiler) ParseWorkflowFile(markdownPath string) (*WorkflowData, error) {
 	orchestratorWorkflowLog.Printf("Starting workflow file parsing: %s", markdownPath)
 
+	// Warnings are collected per workflow; the warning count keeps accumulating
+	c.warnings = nil
+
 	// Parse frontmatter section
 	parseResult, err := c.parseFrontmatterSection(markdownPath)
 	if err != nil {
@@ -51,17 +54,39 @@ func (c *Compiler) ParseWorkflowFile(markdownPath string) (*WorkflowData, error)
 	// Store a stable workflow identifier derived from the file name.
 	workflowData.WorkflowID = GetWorkflowIDFromPath(cleanPath)
 
+	// Load engine prompt prefix/suffix files (paths are relative to the repository root)
+	if err := loadEnginePromptAffixes(workflowData.EngineConfig, markdownDir); err != nil {
+		return nil, fmt.Errorf("%s: %w", cleanPath, err)
+	}
+
+	// Resolve engine env files (paths are relative to the workflow file)
+	if err := resolveEngineEnvFiles(workflowData.EngineConfig, cleanPath); err != nil {
+		return nil, fmt.Errorf("%s: %w", cleanPath, err)
+	}
+
+	// Export the bash tool shell selection to the agent environment
+	applyBashShellEnv(workflowData)
+
 	// Validate bash tool configuration BEFORE applying defaults
 	// This must happen before applyDefaults() which converts nil bash to default commands
 	if err := validateBashToolConfig(workflowData.ParsedTools, workflowData.Name); err != nil {
 		return nil, fmt.Errorf("%s: %w", cleanPath, err)
 	}
 
+	if err := validateGitHubReadOnlyEnforcement(workflowData.ParsedTools, workflowData.Name); err != nil {
+		return nil, fmt.Errorf("%s: %w", cleanPath, err)
+	}
+
+	if err := validateGitHubReadOnlyAllowed(workflowData.ParsedTools, workflowData.Name); err != nil {
+		return nil, fmt.Errorf("%s: %w", cleanPath, err)
+	}
+
 	// Use shared action cache and resolver from the compiler
 	actionCache, actionResolver := c.getSharedActionResolver()
 	workflowData.ActionCache = actionCache
 	workflowData.ActionResolver = actionResolver
 	workflowData.ActionPinWarnings = c.actionPinWarnings
+	workflowData.ActionPinWarningsMu = c.actionPinWarningsMu
 
 	// Extract YAML configuration sections from frontmatter
 	c.extractYAMLSections(result.Frontmatter, workflowData)
@@ -141,6 +166,12 @@ func (c *Compiler) buildInitialWorkflowData(
 		NeedsTextOutput:       toolsResult.needsTextOutput,
 		ToolsTimeout:          toolsResult.toolsTimeout,
 		ToolsStartupTimeout:   toolsResult.toolsStartupTimeout,
+		ToolTimeouts:          toolsResult.toolTimeouts,
+		BashShell:             toolsResult.bashShell,
+		PinDockerDigests:      extractMCPPinDigests(result.Frontmatter),
+		PromptMaxChars:        extractPromptMaxChars(result.Frontmatter),
+		CheckoutDisabled:      extractCheckoutDisabled(result.Frontmatter),
+		PromptRedact:          extractPromptRedact(result.Frontmatter),
 		TrialMode:             c.trialMode,
 		TrialLogicalRepo:      c.trialLogicalRepoSlug,
 		GitHubToken:           extractStringFromMap(result.Frontmatter, "github-token", nil),
@@ -417,8 +448,14 @@ func (c *Compiler) extractAdditionalConfigurations(
 	workflowData.RepoMemoryConfig = repoMemoryConfig
 
 	// Extract and process safe-inputs and safe-outputs
-	workflowData.Command, workflowData.CommandEvents = c.extractCommandConfig(frontmatter)
-	workflowData.Jobs = c.extractJobsFromFrontmatter(frontmatter)
+	workflowData.Command, workflowData.CommandEvents, err = c.extractCommandConfig(frontmatter)
+	if err != nil {
+		return err
+	}
+	workflowData.Jobs, err = c.extractJobsFromFrontmatter(frontmatter)
+	if err != nil {
+		return err
+	}
 
 	// Merge jobs from imported YAML workflows
 	if importsResult.MergedJobs != "" && importsResult.MergedJobs != "{}" {
@@ -0,0 +1,150 @@
+package workflow
+
+import (
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var compilerParallelLog = logger.New("workflow:compiler_parallel")
+
+// CompileWorkflows compiles the given workflow markdown files in parallel with up to
+// concurrency workers (runtime.NumCPU() when concurrency is less than 1).
+//
+// Each worker compiles with its own copy of this compiler, so per-workflow state such as
+// the job manager and step order tracker is never shared between workflows. Action pins
+// resolved by the workers are merged into this compiler's shared action cache, and their
+// warnings are added to its warning count, so callers can save the cache and report
+// warnings exactly as after sequential compilation.
+//
+// Returns one error per path, in the same order as paths; the error is nil for workflows
+// that compiled successfully.
+func (c *Compiler) CompileWorkflows(paths []string, concurrency int) []error {
+	return c.CompileWorkflowsWith(paths, concurrency, func(worker *Compiler, index int) error {
+		return worker.CompileWorkflow(paths[index])
+	})
+}
+
+// CompileWorkflowsWith is like CompileWorkflows but calls compile to process each path, so
+// callers can parse, compile, and validate a workflow their own way on the worker compiler.
+// compile receives the worker assigned to the workflow and the workflow's index in paths;
+// the worker's workflow identifier is already set for that path.
+func (c *Compiler) CompileWorkflowsWith(paths []string, concurrency int, compile func(worker *Compiler, index int) error) []error {
+	errs := make([]error, len(paths))
+	if len(paths) == 0 {
+		return errs
+	}
+
+	if concurrency < 1 {
+		concurrency = runtime.NumCPU()
+	}
+	if concurrency > len(paths) {
+		concurrency = len(paths)
+	}
+	compilerParallelLog.Printf("Compiling %d workflows with %d workers", len(paths), concurrency)
+
+	var tracker FileTracker
+	if c.fileTracker != nil {
+		tracker = &lockedFileTracker{tracker: c.fileTracker}
+	}
+	digestResolver := c.getDigestResolver()
+	if c.actionPinWarnings == nil {
+		c.actionPinWarnings = make(map[string]bool)
+	}
+	pinWarningsMu := &sync.Mutex{}
+
+	indexes := make(chan int)
+	workers := make([]*Compiler, concurrency)
+	var wg sync.WaitGroup
+	for i := range workers {
+		worker := c.newWorkerCompiler(tracker, digestResolver, pinWarningsMu)
+		workers[i] = worker
+		wg.Go(func() {
+			for index := range indexes {
+				worker.SetWorkflowIdentifier(c.workflowIdentifierForPath(paths[index]))
+				errs[index] = compile(worker, index)
+			}
+		})
+	}
+	for index := range paths {
+		indexes <- index
+	}
+	close(indexes)
+	wg.Wait()
+
+	c.mergeWorkerCompilers(workers)
+	return errs
+}
+
+// newWorkerCompiler returns a copy of c with the same options and fresh per-workflow state.
+// The action and import caches are not safe for concurrent use, so each worker builds its
+// own; digest lookups go through the shared resolver, and action pin warnings are
+// deduplicated across workers through c's warning cache guarded by pinWarningsMu.
+func (c *Compiler) newWorkerCompiler(tracker FileTracker, digestResolver DigestResolver, pinWarningsMu *sync.Mutex) *Compiler {
+	worker := *c
+	worker.jobManager = NewJobManager()
+	worker.stepOrderTracker = NewStepOrderTracker()
+	worker.artifactManager = NewArtifactManager()
+	worker.warningCount = 0
+	worker.warnings = nil
+	worker.scheduleWarnings = nil
+	worker.scheduleFriendlyFormats = nil
+	worker.actionCache = nil
+	worker.actionResolver = nil
+	worker.actionCacheCleared = false
+	worker.actionPinWarningsMu = pinWarningsMu
+	worker.importCache = nil
+	worker.fileTracker = tracker
+	worker.digestResolver = digestResolver
+	worker.markdownPath = ""
+	worker.sourceOverridePath = ""
+	worker.sourceOverride = nil
+	return &worker
+}
+
+// mergeWorkerCompilers folds the warnings and resolved action pins of the workers back into c
+func (c *Compiler) mergeWorkerCompilers(workers []*Compiler) {
+	for _, worker := range workers {
+		c.warningCount += worker.warningCount
+		c.scheduleWarnings = append(c.scheduleWarnings, worker.scheduleWarnings...)
+
+		if worker.actionCache == nil || !worker.actionCache.dirty {
+			continue
+		}
+		cache, _ := c.getSharedActionResolver()
+		for key, entry := range worker.actionCache.Entries {
+			if existing, exists := cache.Entries[key]; !exists || existing != entry {
+				cache.Set(entry.Repo, entry.Version, entry.SHA)
+			}
+		}
+	}
+}
+
+// workflowIdentifierForPath returns the repository-relative path used to seed schedule
+// scattering for a workflow, falling back to its base name outside a git repository
+func (c *Compiler) workflowIdentifierForPath(markdownPath string) string {
+	if c.gitRoot != "" {
+		if absPath, err := filepath.Abs(markdownPath); err == nil {
+			if relPath, err := filepath.Rel(c.gitRoot, absPath); err == nil && !strings.HasPrefix(relPath, "..") {
+				return filepath.ToSlash(relPath)
+			}
+		}
+	}
+	return filepath.Base(markdownPath)
+}
+
+// lockedFileTracker serializes calls to a FileTracker shared by parallel compile workers
+type lockedFileTracker struct {
+	mu      sync.Mutex
+	tracker FileTracker
+}
+
+// TrackCreated records a created file with the underlying tracker
+func (t *lockedFileTracker) TrackCreated(filePath string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.tracker.TrackCreated(filePath)
+}
@@ -0,0 +1,124 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/stringutil"
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// parallelTestWorkflows are distinct enough that sharing per-workflow compiler state
+// (jobs, steps, artifacts) between them would change the generated lock files
+var parallelTestWorkflows = map[string]string{
+	"issues.md": `---
+on: issues
+permissions:
+  contents: read
+engine: claude
+safe-outputs:
+  add-comment:
+---
+
+# Comment on issues
+`,
+	"pull-requests.md": `---
+on: pull_request
+permissions:
+  contents: read
+engine: copilot
+safe-outputs:
+  create-pull-request:
+---
+
+# Open pull requests
+`,
+	"custom-job.md": `---
+on: workflow_dispatch
+permissions:
+  contents: read
+engine: codex
+jobs:
+  lint:
+    runs-on: ubuntu-latest
+    steps:
+      - run: echo lint
+---
+
+# With a custom job
+`,
+	"plain.md": `---
+on: push
+permissions:
+  contents: read
+---
+
+# Plain workflow
+`,
+}
+
+func TestCompileWorkflows(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "parallel-compile")
+
+	var paths []string
+	for name, content := range parallelTestWorkflows {
+		path := filepath.Join(tmpDir, name)
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644), "Should write workflow file")
+		paths = append(paths, path)
+	}
+
+	// Compile each workflow on its own to get the expected lock files
+	expected := make(map[string]string)
+	for _, path := range paths {
+		require.NoError(t, NewCompiler().CompileWorkflow(path), "Workflow should compile sequentially: %s", path)
+		content, err := os.ReadFile(stringutil.MarkdownToLockFile(path))
+		require.NoError(t, err, "Should read lock file")
+		expected[path] = string(content)
+		require.NoError(t, os.Remove(stringutil.MarkdownToLockFile(path)), "Should remove lock file")
+	}
+
+	errs := NewCompiler().CompileWorkflows(paths, 3)
+	require.Len(t, errs, len(paths), "There should be one result per workflow")
+
+	for i, path := range paths {
+		require.NoError(t, errs[i], "Workflow should compile in parallel: %s", path)
+		content, err := os.ReadFile(stringutil.MarkdownToLockFile(path))
+		require.NoError(t, err, "Each workflow should produce its lock file")
+		assert.Equal(t, expected[path], string(content), "Parallel compilation should match sequential compilation for %s", filepath.Base(path))
+	}
+}
+
+func TestCompileWorkflowsReportsErrorsPerFile(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "parallel-compile-errors")
+
+	var paths []string
+	for i := range 4 {
+		content := parallelTestWorkflows["plain.md"]
+		if i%2 == 1 {
+			content = "---\non: push\nengine: not-an-engine\n---\n\n# Invalid\n"
+		}
+		path := filepath.Join(tmpDir, fmt.Sprintf("workflow-%d.md", i))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644), "Should write workflow file")
+		paths = append(paths, path)
+	}
+
+	errs := NewCompiler().CompileWorkflows(paths, 0)
+	require.Len(t, errs, len(paths), "There should be one result per workflow")
+
+	for i, path := range paths {
+		_, statErr := os.Stat(stringutil.MarkdownToLockFile(path))
+		if i%2 == 1 {
+			assert.Error(t, errs[i], "Invalid workflow should report its error: %s", path)
+			assert.True(t, os.IsNotExist(statErr), "Invalid workflow should not produce a lock file: %s", path)
+		} else {
+			assert.NoError(t, errs[i], "Valid workflow should compile: %s", path)
+			assert.NoError(t, statErr, "Valid workflow should produce a lock file: %s", path)
+		}
+	}
+}
@@ -29,6 +29,7 @@ func (c *Compiler) buildSafeOutputsJobs(data *WorkflowData, jobName, markdownPat
 		if err != nil {
 			return fmt.Errorf("failed to build detection job: %w", err)
 		}
+		c.explainJob(detectionJob, "created because safe-outputs are configured and threat detection is enabled; scans the agent output before it is applied")
 		if err := c.jobManager.AddJob(detectionJob); err != nil {
 			return fmt.Errorf("failed to add detection job: %w", err)
 		}
@@ -45,6 +46,7 @@ func (c *Compiler) buildSafeOutputsJobs(data *WorkflowData, jobName, markdownPat
 		return fmt.Errorf("failed to build consolidated safe outputs job: %w", err)
 	}
 	if consolidatedJob != nil {
+		c.explainJob(consolidatedJob, "created because safe-outputs are configured; applies the agent output with write permissions")
 		if err := c.jobManager.AddJob(consolidatedJob); err != nil {
 			return fmt.Errorf("failed to add consolidated safe outputs job: %w", err)
 		}
@@ -74,6 +76,7 @@ func (c *Compiler) buildSafeOutputsJobs(data *WorkflowData, jobName, markdownPat
 		if err != nil {
 			return fmt.Errorf("failed to build upload_assets job: %w", err)
 		}
+		c.explainJob(uploadAssetsJob, "created because safe-outputs.upload-asset is configured; pushes assets to their git branch")
 		if err := c.jobManager.AddJob(uploadAssetsJob); err != nil {
 			return fmt.Errorf("failed to add upload_assets job: %w", err)
 		}
@@ -89,6 +92,7 @@ func (c *Compiler) buildSafeOutputsJobs(data *WorkflowData, jobName, markdownPat
 		return fmt.Errorf("failed to build conclusion job: %w", err)
 	}
 	if conclusionJob != nil {
+		c.explainJob(conclusionJob, "created because safe-outputs are configured; reports the run outcome after all other jobs finish")
 		// If push_repo_memory job exists, conclusion should depend on it
 		// Check if the job was already created (it's created in buildJobs)
 		if _, exists := c.jobManager.GetJob("push_repo_memory"); exists {
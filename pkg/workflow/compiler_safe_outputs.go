@@ -46,16 +46,12 @@ func (c *Compiler) parseOnSection(frontmatter map[string]any, workflowData *Work
 			// Extract reaction from on section
 			if reactionValue, hasReactionField := onMap["reaction"]; hasReactionField {
 				hasReaction = true
-				reactionStr, err := parseReactionValue(reactionValue)
+				reactions, err := parseReactionList(reactionValue)
 				if err != nil {
 					return err
 				}
-				// Validate reaction value
-				if !isValidReaction(reactionStr) {
-					return fmt.Errorf("invalid reaction value '%s': must be one of %v", reactionStr, getValidReactions())
-				}
 				// Set AIReaction even if it's "none" - "none" explicitly disables reactions
-				workflowData.AIReaction = reactionStr
+				workflowData.AIReaction, workflowData.AIReactionFallbacks = resolveReactions(reactions)
 			}
 
 			// Extract lock-for-agent from on.issues section
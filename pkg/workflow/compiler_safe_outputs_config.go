@@ -58,6 +58,12 @@ func (b *handlerConfigBuilder) AddIfPositive(key string, value int) *handlerConf
 	return b
 }
 
+// AddMax adds the literal max field. A max expression is not written into the config;
+// the runtime reads it from the env vars added by renderSafeOutputsMaxEnvVars.
+func (b *handlerConfigBuilder) AddMax(config BaseSafeOutputConfig) *handlerConfigBuilder {
+	return b.AddIfPositive("max", config.Max)
+}
+
 // AddIfNotEmpty adds a string field only if the value is not empty
 func (b *handlerConfigBuilder) AddIfNotEmpty(key string, value string) *handlerConfigBuilder {
 	if value != "" {
@@ -130,13 +136,15 @@ var handlerRegistry = map[string]handlerBuilder{
 		}
 		c := cfg.CreateIssues
 		return newHandlerConfigBuilder().
-			AddIfPositive("max", c.Max).
+			AddMax(c.BaseSafeOutputConfig).
 			AddStringSlice("allowed_labels", c.AllowedLabels).
 			AddStringSlice("allowed_repos", c.AllowedRepos).
 			AddIfPositive("expires", c.Expires).
+			AddIfPositive("min_interval", c.MinInterval).
 			AddStringSlice("labels", c.Labels).
 			AddIfNotEmpty("title_prefix", c.TitlePrefix).
 			AddStringSlice("assignees", c.Assignees).
+			AddIfNotEmpty("assign_from_team", c.AssignFromTeam).
 			AddIfNotEmpty("target-repo", c.TargetRepoSlug).
 			AddIfTrue("group", c.Group).
 			AddIfTrue("close_older_issues", c.CloseOlderIssues).
@@ -149,7 +157,7 @@ var handlerRegistry = map[string]handlerBuilder{
 		}
 		c := cfg.AddComments
 		return newHandlerConfigBuilder().
-			AddIfPositive("max", c.Max).
+			AddMax(c.BaseSafeOutputConfig).
 			AddIfNotEmpty("target", c.Target).
 			AddIfTrue("hide_older_comments", c.HideOlderComments).
 			AddIfNotEmpty("target-repo", c.TargetRepoSlug).
@@ -162,7 +170,7 @@ var handlerRegistry = map[string]handlerBuilder{
 		}
 		c := cfg.CreateDiscussions
 		return newHandlerConfigBuilder().
-			AddIfPositive("max", c.Max).
+			AddMax(c.BaseSafeOutputConfig).
 			AddIfNotEmpty("category", c.Category).
 			AddIfNotEmpty("title_prefix", c.TitlePrefix).
 			AddStringSlice("labels", c.Labels).
@@ -170,6 +178,7 @@ var handlerRegistry = map[string]handlerBuilder{
 			AddStringSlice("allowed_repos", c.AllowedRepos).
 			AddIfTrue("close_older_discussions", c.CloseOlderDiscussions).
 			AddIfNotEmpty("required_category", c.RequiredCategory).
+			AddIfNotEmpty("dedupe", c.Dedupe).
 			AddIfPositive("expires", c.Expires).
 			AddBoolPtr("fallback_to_issue", c.FallbackToIssue).
 			AddIfNotEmpty("target-repo", c.TargetRepoSlug).
@@ -182,7 +191,7 @@ var handlerRegistry = map[string]handlerBuilder{
 		}
 		c := cfg.CloseIssues
 		return newHandlerConfigBuilder().
-			AddIfPositive("max", c.Max).
+			AddMax(c.BaseSafeOutputConfig).
 			AddIfNotEmpty("target", c.Target).
 			AddStringSlice("required_labels", c.RequiredLabels).
 			AddIfNotEmpty("required_title_prefix", c.RequiredTitlePrefix).
@@ -196,7 +205,7 @@ var handlerRegistry = map[string]handlerBuilder{
 		}
 		c := cfg.CloseDiscussions
 		return newHandlerConfigBuilder().
-			AddIfPositive("max", c.Max).
+			AddMax(c.BaseSafeOutputConfig).
 			AddIfNotEmpty("target", c.Target).
 			AddStringSlice("required_labels", c.RequiredLabels).
 			AddIfNotEmpty("required_title_prefix", c.RequiredTitlePrefix).
@@ -210,11 +219,13 @@ var handlerRegistry = map[string]handlerBuilder{
 		}
 		c := cfg.AddLabels
 		config := newHandlerConfigBuilder().
-			AddIfPositive("max", c.Max).
+			AddMax(c.BaseSafeOutputConfig).
 			AddStringSlice("allowed", c.Allowed).
 			AddIfNotEmpty("target", c.Target).
 			AddIfNotEmpty("target-repo", c.TargetRepoSlug).
 			AddStringSlice("allowed_repos", c.AllowedRepos).
+			AddIfTrue("create_if_missing", c.CreateIfMissing).
+			AddIfNotEmpty("color", normalizeLabelColor(c.Color)).
 			Build()
 		// If config is empty, it means add_labels was explicitly configured with no options
 		// (null config), which means "allow any labels". Return non-nil empty map to
@@ -231,7 +242,7 @@ var handlerRegistry = map[string]handlerBuilder{
 		}
 		c := cfg.RemoveLabels
 		return newHandlerConfigBuilder().
-			AddIfPositive("max", c.Max).
+			AddMax(c.BaseSafeOutputConfig).
 			AddStringSlice("allowed", c.Allowed).
 			AddIfNotEmpty("target", c.Target).
 			AddIfNotEmpty("target-repo", c.TargetRepoSlug).
@@ -244,7 +255,7 @@ var handlerRegistry = map[string]handlerBuilder{
 		}
 		c := cfg.UpdateIssues
 		builder := newHandlerConfigBuilder().
-			AddIfPositive("max", c.Max).
+			AddMax(c.BaseSafeOutputConfig).
 			AddIfNotEmpty("target", c.Target)
 		// Boolean pointer fields indicate which fields can be updated
 		if c.Status != nil {
@@ -267,7 +278,7 @@ var handlerRegistry = map[string]handlerBuilder{
 		}
 		c := cfg.UpdateDiscussions
 		builder := newHandlerConfigBuilder().
-			AddIfPositive("max", c.Max).
+			AddMax(c.BaseSafeOutputConfig).
 			AddIfNotEmpty("target", c.Target)
 		// Boolean pointer fields indicate which fields can be updated
 		if c.Title != nil {
@@ -292,7 +303,7 @@ var handlerRegistry = map[string]handlerBuilder{
 		}
 		c := cfg.LinkSubIssue
 		return newHandlerConfigBuilder().
-			AddIfPositive("max", c.Max).
+			AddMax(c.BaseSafeOutputConfig).
 			AddStringSlice("parent_required_labels", c.ParentRequiredLabels).
 			AddIfNotEmpty("parent_title_prefix", c.ParentTitlePrefix).
 			AddStringSlice("sub_required_labels", c.SubRequiredLabels).
@@ -307,7 +318,7 @@ var handlerRegistry = map[string]handlerBuilder{
 		}
 		c := cfg.UpdateRelease
 		return newHandlerConfigBuilder().
-			AddIfPositive("max", c.Max).
+			AddMax(c.BaseSafeOutputConfig).
 			AddBoolPtr("footer", getEffectiveFooter(c.Footer, cfg.Footer)).
 			Build()
 	},
@@ -317,7 +328,7 @@ var handlerRegistry = map[string]handlerBuilder{
 		}
 		c := cfg.CreatePullRequestReviewComments
 		return newHandlerConfigBuilder().
-			AddIfPositive("max", c.Max).
+			AddMax(c.BaseSafeOutputConfig).
 			AddIfNotEmpty("side", c.Side).
 			AddIfNotEmpty("target", c.Target).
 			AddIfNotEmpty("target-repo", c.TargetRepoSlug).
@@ -330,7 +341,7 @@ var handlerRegistry = map[string]handlerBuilder{
 		}
 		c := cfg.SubmitPullRequestReview
 		return newHandlerConfigBuilder().
-			AddIfPositive("max", c.Max).
+			AddMax(c.BaseSafeOutputConfig).
 			AddStringPtr("footer", getEffectiveFooterString(c.Footer, cfg.Footer)).
 			Build()
 	},
@@ -340,7 +351,7 @@ var handlerRegistry = map[string]handlerBuilder{
 		}
 		c := cfg.ReplyToPullRequestReviewComment
 		return newHandlerConfigBuilder().
-			AddIfPositive("max", c.Max).
+			AddMax(c.BaseSafeOutputConfig).
 			AddIfNotEmpty("target", c.Target).
 			AddIfNotEmpty("target-repo", c.TargetRepoSlug).
 			AddStringSlice("allowed_repos", c.AllowedRepos).
@@ -353,7 +364,7 @@ var handlerRegistry = map[string]handlerBuilder{
 		}
 		c := cfg.ResolvePullRequestReviewThread
 		return newHandlerConfigBuilder().
-			AddIfPositive("max", c.Max).
+			AddMax(c.BaseSafeOutputConfig).
 			Build()
 	},
 	"create_pull_request": func(cfg *SafeOutputsConfig) map[string]any {
@@ -366,7 +377,7 @@ var handlerRegistry = map[string]handlerBuilder{
 			maxPatchSize = cfg.MaximumPatchSize
 		}
 		builder := newHandlerConfigBuilder().
-			AddIfPositive("max", c.Max).
+			AddMax(c.BaseSafeOutputConfig).
 			AddIfNotEmpty("title_prefix", c.TitlePrefix).
 			AddStringSlice("labels", c.Labels).
 			AddBoolPtr("draft", c.Draft).
@@ -397,7 +408,7 @@ var handlerRegistry = map[string]handlerBuilder{
 			maxPatchSize = cfg.MaximumPatchSize
 		}
 		return newHandlerConfigBuilder().
-			AddIfPositive("max", c.Max).
+			AddMax(c.BaseSafeOutputConfig).
 			AddIfNotEmpty("target", c.Target).
 			AddIfNotEmpty("title_prefix", c.TitlePrefix).
 			AddStringSlice("labels", c.Labels).
@@ -413,7 +424,7 @@ var handlerRegistry = map[string]handlerBuilder{
 		}
 		c := cfg.UpdatePullRequests
 		return newHandlerConfigBuilder().
-			AddIfPositive("max", c.Max).
+			AddMax(c.BaseSafeOutputConfig).
 			AddIfNotEmpty("target", c.Target).
 			AddBoolPtrOrDefault("allow_title", c.Title, true).
 			AddBoolPtrOrDefault("allow_body", c.Body, true).
@@ -428,7 +439,7 @@ var handlerRegistry = map[string]handlerBuilder{
 		}
 		c := cfg.ClosePullRequests
 		return newHandlerConfigBuilder().
-			AddIfPositive("max", c.Max).
+			AddMax(c.BaseSafeOutputConfig).
 			AddIfNotEmpty("target", c.Target).
 			AddStringSlice("required_labels", c.RequiredLabels).
 			AddIfNotEmpty("required_title_prefix", c.RequiredTitlePrefix).
@@ -442,7 +453,7 @@ var handlerRegistry = map[string]handlerBuilder{
 		}
 		c := cfg.HideComment
 		return newHandlerConfigBuilder().
-			AddIfPositive("max", c.Max).
+			AddMax(c.BaseSafeOutputConfig).
 			AddStringSlice("allowed_reasons", c.AllowedReasons).
 			AddIfNotEmpty("target-repo", c.TargetRepoSlug).
 			AddStringSlice("allowed_repos", c.AllowedRepos).
@@ -454,7 +465,7 @@ var handlerRegistry = map[string]handlerBuilder{
 		}
 		c := cfg.DispatchWorkflow
 		builder := newHandlerConfigBuilder().
-			AddIfPositive("max", c.Max).
+			AddMax(c.BaseSafeOutputConfig).
 			AddStringSlice("workflows", c.Workflows)
 
 		// Add workflow_files map if it has entries
@@ -470,7 +481,7 @@ var handlerRegistry = map[string]handlerBuilder{
 		}
 		c := cfg.MissingTool
 		return newHandlerConfigBuilder().
-			AddIfPositive("max", c.Max).
+			AddMax(c.BaseSafeOutputConfig).
 			Build()
 	},
 	"missing_data": func(cfg *SafeOutputsConfig) map[string]any {
@@ -479,7 +490,7 @@ var handlerRegistry = map[string]handlerBuilder{
 		}
 		c := cfg.MissingData
 		return newHandlerConfigBuilder().
-			AddIfPositive("max", c.Max).
+			AddMax(c.BaseSafeOutputConfig).
 			Build()
 	},
 	// Note: "noop" is intentionally NOT included here because it is always processed
@@ -491,7 +502,7 @@ var handlerRegistry = map[string]handlerBuilder{
 		}
 		c := cfg.AutofixCodeScanningAlert
 		return newHandlerConfigBuilder().
-			AddIfPositive("max", c.Max).
+			AddMax(c.BaseSafeOutputConfig).
 			AddIfNotEmpty("github-token", c.GitHubToken).
 			Build()
 	},
@@ -503,7 +514,7 @@ var handlerRegistry = map[string]handlerBuilder{
 		}
 		c := cfg.CreateProjects
 		builder := newHandlerConfigBuilder().
-			AddIfPositive("max", c.Max).
+			AddMax(c.BaseSafeOutputConfig).
 			AddIfNotEmpty("target_owner", c.TargetOwner).
 			AddIfNotEmpty("title_prefix", c.TitlePrefix).
 			AddIfNotEmpty("github-token", c.GitHubToken)
@@ -521,7 +532,7 @@ var handlerRegistry = map[string]handlerBuilder{
 		}
 		c := cfg.UpdateProjects
 		builder := newHandlerConfigBuilder().
-			AddIfPositive("max", c.Max).
+			AddMax(c.BaseSafeOutputConfig).
 			AddIfNotEmpty("github-token", c.GitHubToken).
 			AddIfNotEmpty("project", c.Project)
 		if len(c.Views) > 0 {
@@ -538,37 +549,80 @@ var handlerRegistry = map[string]handlerBuilder{
 		}
 		c := cfg.CreateProjectStatusUpdates
 		return newHandlerConfigBuilder().
-			AddIfPositive("max", c.Max).
+			AddMax(c.BaseSafeOutputConfig).
 			AddIfNotEmpty("github-token", c.GitHubToken).
 			AddIfNotEmpty("project", c.Project).
 			Build()
 	},
 }
 
-func (c *Compiler) addHandlerManagerConfigEnvVar(steps *[]string, data *WorkflowData) {
-	if data.SafeOutputs == nil {
-		compilerSafeOutputsConfigLog.Print("No safe-outputs configuration, skipping handler manager config")
-		return
-	}
-
-	compilerSafeOutputsConfigLog.Print("Building handler manager configuration for safe-outputs")
+// BuildHandlerManagerConfig builds the per-handler configuration passed to the safe output
+// handler manager (GH_AW_SAFE_OUTPUTS_HANDLER_CONFIG), keyed by handler name
+func BuildHandlerManagerConfig(safeOutputs *SafeOutputsConfig) map[string]map[string]any {
 	config := make(map[string]map[string]any)
+	if safeOutputs == nil {
+		return config
+	}
 
 	// Build configuration for each handler using the registry
 	for handlerName, builder := range handlerRegistry {
-		handlerConfig := builder(data.SafeOutputs)
+		handlerConfig := builder(safeOutputs)
 		// Include handler if:
 		// 1. It returns a non-nil config (explicitly enabled, even if empty)
 		// 2. For auto-enabled handlers, include even with empty config
 		if handlerConfig != nil {
 			compilerSafeOutputsConfigLog.Printf("Adding %s handler configuration", handlerName)
+			if _, ok := safeOutputs.Conditions[handlerName]; ok {
+				handlerConfig["conditional"] = true
+			}
+			handlerConfig["retry"] = handlerRetryConfig(safeOutputs.Retry)
 			config[handlerName] = handlerConfig
 		}
 	}
 
+	// Bound max expressions by the schema maximum; the values themselves are passed via env
+	for handlerName := range getMaxExpressionsReflection(safeOutputs) {
+		if handlerConfig, ok := config[handlerName]; ok {
+			if limit := getSafeOutputsMaxLimit(handlerName); limit > 0 {
+				handlerConfig["max_limit"] = limit
+			}
+		}
+	}
+	return config
+}
+
+// BuildHandlerManagerEnv returns the environment variables the handler manager step receives
+// next to GH_AW_SAFE_OUTPUTS_HANDLER_CONFIG, keyed by name: max expressions and handler if
+// conditions. These values are GitHub Actions expressions that are only evaluated inside a
+// workflow run.
+func BuildHandlerManagerEnv(safeOutputs *SafeOutputsConfig) map[string]string {
+	env := make(map[string]string)
+	if safeOutputs == nil {
+		return env
+	}
+	for toolName, expression := range getMaxExpressionsReflection(safeOutputs) {
+		env[safeOutputsMaxEnvVarName(toolName)] = expression
+	}
+	for handlerName, condition := range safeOutputs.Conditions {
+		env[safeOutputsConditionEnvVarName(handlerName)] = condition
+	}
+	return env
+}
+
+func (c *Compiler) addHandlerManagerConfigEnvVar(steps *[]string, data *WorkflowData) {
+	if data.SafeOutputs == nil {
+		compilerSafeOutputsConfigLog.Print("No safe-outputs configuration, skipping handler manager config")
+		return
+	}
+
+	compilerSafeOutputsConfigLog.Print("Building handler manager configuration for safe-outputs")
+	config := BuildHandlerManagerConfig(data.SafeOutputs)
+
 	// Only add the env var if there are handlers to configure
 	if len(config) > 0 {
 		compilerSafeOutputsConfigLog.Printf("Marshaling handler config with %d handlers", len(config))
+		// encoding/json writes map keys in sorted order at every level, so recompiling an
+		// unchanged workflow yields byte-identical handler configuration
 		configJSON, err := json.Marshal(config)
 		if err != nil {
 			consolidatedSafeOutputsLog.Printf("Failed to marshal handler config: %v", err)
@@ -577,6 +631,8 @@ func (c *Compiler) addHandlerManagerConfigEnvVar(steps *[]string, data *Workflow
 		// Escape the JSON for YAML (handle quotes and special chars)
 		configStr := string(configJSON)
 		*steps = append(*steps, fmt.Sprintf("          GH_AW_SAFE_OUTPUTS_HANDLER_CONFIG: %q\n", configStr))
+		*steps = append(*steps, renderSafeOutputsMaxEnvVars(data.SafeOutputs)...)
+		*steps = append(*steps, renderSafeOutputsConditionEnvVars(data.SafeOutputs.Conditions)...)
 		compilerSafeOutputsConfigLog.Printf("Added handler config env var: size=%d bytes", len(configStr))
 	} else {
 		compilerSafeOutputsConfigLog.Print("No handlers configured, skipping config env var")
@@ -4,9 +4,14 @@ package workflow
 
 import (
 	"encoding/json"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
 	"strings"
 	"testing"
 
+	"github.com/github/gh-aw/pkg/testutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -806,3 +811,230 @@ func TestCreatePullRequestBaseBranch(t *testing.T) {
 		})
 	}
 }
+
+// TestHandlerConfigRetry tests that the retry policy is serialized into each handler config
+func TestHandlerConfigRetry(t *testing.T) {
+	tests := []struct {
+		name                string
+		safeOutputs         map[string]any
+		expectedMaxAttempts int
+		expectedBaseDelayMs int
+	}{
+		{
+			name: "configured retry",
+			safeOutputs: map[string]any{
+				"create-issue": nil,
+				"add-comment":  nil,
+				"retry": map[string]any{
+					"max-attempts":  5,
+					"base-delay-ms": 2000,
+				},
+			},
+			expectedMaxAttempts: 5,
+			expectedBaseDelayMs: 2000,
+		},
+		{
+			name: "default retry when omitted",
+			safeOutputs: map[string]any{
+				"create-issue": nil,
+				"add-comment":  nil,
+			},
+			expectedMaxAttempts: 3,
+			expectedBaseDelayMs: 1000,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			compiler := NewCompiler()
+			safeOutputs := compiler.extractSafeOutputsConfig(map[string]any{"safe-outputs": tt.safeOutputs})
+			require.NotNil(t, safeOutputs, "Safe outputs should be parsed")
+
+			var steps []string
+			compiler.addHandlerManagerConfigEnvVar(&steps, &WorkflowData{SafeOutputs: safeOutputs})
+			require.NotEmpty(t, steps, "Steps should not be empty")
+			stepsContent := strings.Join(steps, "")
+			assert.NotContains(t, stepsContent, "GH_AW_SAFE_OUTPUTS_RETRY_", "Retry should not be passed via separate env vars")
+
+			for _, step := range steps {
+				if !strings.Contains(step, "GH_AW_SAFE_OUTPUTS_HANDLER_CONFIG") {
+					continue
+				}
+				parts := strings.Split(step, "GH_AW_SAFE_OUTPUTS_HANDLER_CONFIG: ")
+				require.Len(t, parts, 2, "Should have JSON after env var name")
+
+				jsonStr, err := strconv.Unquote(strings.TrimSpace(parts[1]))
+				require.NoError(t, err, "Handler config should be a quoted string")
+
+				var config map[string]map[string]any
+				require.NoError(t, json.Unmarshal([]byte(jsonStr), &config), "Handler config JSON should be valid")
+
+				for _, handler := range []string{"create_issue", "add_comment"} {
+					handlerConfig, ok := config[handler]
+					require.True(t, ok, "%s config should exist", handler)
+
+					retry, ok := handlerConfig["retry"].(map[string]any)
+					require.True(t, ok, "%s config should carry retry settings", handler)
+					assert.InDelta(t, float64(tt.expectedMaxAttempts), retry["max_attempts"], 0.0001, "max_attempts should match for %s", handler)
+					assert.InDelta(t, float64(tt.expectedBaseDelayMs), retry["base_delay_ms"], 0.0001, "base_delay_ms should match for %s", handler)
+				}
+			}
+		})
+	}
+}
+
+func TestHandlerConfigAddLabelsCreateIfMissing(t *testing.T) {
+	tests := []struct {
+		name              string
+		addLabels         map[string]any
+		expectCreate      bool
+		expectedColor     string
+		expectColorAbsent bool
+	}{
+		{
+			name:          "create-if-missing with color",
+			addLabels:     map[string]any{"create-if-missing": true, "color": "#D73A4A"},
+			expectCreate:  true,
+			expectedColor: "d73a4a",
+		},
+		{
+			name:              "create-if-missing without color",
+			addLabels:         map[string]any{"create-if-missing": true},
+			expectCreate:      true,
+			expectColorAbsent: true,
+		},
+		{
+			name:              "not configured",
+			addLabels:         map[string]any{"max": 2},
+			expectColorAbsent: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			compiler := NewCompiler()
+			safeOutputs := compiler.extractSafeOutputsConfig(map[string]any{"safe-outputs": map[string]any{"add-labels": tt.addLabels}})
+			require.NotNil(t, safeOutputs, "Safe outputs should be parsed")
+
+			configJSON, err := json.Marshal(BuildHandlerManagerConfig(safeOutputs))
+			require.NoError(t, err, "Handler config should marshal")
+
+			var decoded map[string]map[string]any
+			require.NoError(t, json.Unmarshal(configJSON, &decoded), "Handler config should be valid JSON")
+			addLabelsConfig, ok := decoded["add_labels"]
+			require.True(t, ok, "add_labels handler should be configured")
+
+			if tt.expectCreate {
+				assert.Equal(t, true, addLabelsConfig["create_if_missing"], "create_if_missing should be set")
+			} else {
+				assert.NotContains(t, addLabelsConfig, "create_if_missing", "create_if_missing should be omitted")
+			}
+			if tt.expectColorAbsent {
+				assert.NotContains(t, addLabelsConfig, "color", "color should be omitted")
+			} else {
+				assert.Equal(t, tt.expectedColor, addLabelsConfig["color"], "color should be normalized")
+			}
+		})
+	}
+}
+
+func TestValidateAddLabelsColor(t *testing.T) {
+	tests := []struct {
+		name      string
+		config    *AddLabelsConfig
+		expectErr string
+	}{
+		{name: "no color", config: &AddLabelsConfig{CreateIfMissing: true}},
+		{name: "hex color", config: &AddLabelsConfig{CreateIfMissing: true, Color: "d73a4a"}},
+		{name: "hex color with hash", config: &AddLabelsConfig{CreateIfMissing: true, Color: "#A2EEEF"}},
+		{name: "short hex", config: &AddLabelsConfig{CreateIfMissing: true, Color: "fff"}, expectErr: "expected a 6-digit hex color"},
+		{name: "not hex", config: &AddLabelsConfig{CreateIfMissing: true, Color: "red"}, expectErr: "expected a 6-digit hex color"},
+		{name: "color without create-if-missing", config: &AddLabelsConfig{Color: "d73a4a"}, expectErr: "requires create-if-missing: true"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateAddLabelsColor(&SafeOutputsConfig{AddLabels: tt.config})
+			if tt.expectErr == "" {
+				assert.NoError(t, err, "Color should be accepted")
+				return
+			}
+			require.Error(t, err, "Color should be rejected")
+			assert.Contains(t, err.Error(), tt.expectErr, "Error should explain the problem")
+		})
+	}
+}
+
+func TestHandlerManagerStepIsDeterministic(t *testing.T) {
+	content := `---
+on: issues
+permissions:
+  contents: read
+engine: copilot
+safe-outputs:
+  env:
+    ZETA_VALUE: "z"
+    ALPHA_VALUE: "a"
+    MIDDLE_VALUE: "m"
+  create-issue:
+    labels: [automation]
+  add-comment:
+    max: 2
+  add-labels:
+    allowed: [bug, triage]
+  update-issue:
+  close-issue:
+---
+
+# Multi-handler workflow
+`
+	markdownPath := filepath.Join(testutil.TempDir(t, "handler-config-order"), "multi-handler.md")
+
+	extract := func(lockContent string) (string, string) {
+		t.Helper()
+		handlerConfig := regexp.MustCompile(`GH_AW_SAFE_OUTPUTS_HANDLER_CONFIG: .*`).FindString(lockContent)
+		require.NotEmpty(t, handlerConfig, "Lock file should contain the handler config")
+		step := lockContent[strings.Index(lockContent, "id: process_safe_outputs"):]
+		step = step[:strings.Index(step, "GH_AW_SAFE_OUTPUTS_HANDLER_CONFIG")]
+		return handlerConfig, step
+	}
+
+	first, err := NewCompiler().CompileString(content, markdownPath)
+	require.NoError(t, err, "First compilation should succeed")
+	firstConfig, firstEnv := extract(first)
+
+	for range 5 {
+		again, err := NewCompiler().CompileString(content, markdownPath)
+		require.NoError(t, err, "Recompilation should succeed")
+		config, env := extract(again)
+		assert.Equal(t, firstConfig, config, "Handler config JSON should be byte-identical across compilations")
+		assert.Equal(t, firstEnv, env, "Handler manager env vars should be identical across compilations")
+	}
+
+	var positions []int
+	for _, name := range []string{"add_comment", "add_labels", "close_issue", "create_issue", "update_issue"} {
+		idx := strings.Index(firstConfig, `\"`+name+`\":`)
+		require.GreaterOrEqual(t, idx, 0, "Handler config should contain %s", name)
+		positions = append(positions, idx)
+	}
+	assert.True(t, sort.IntsAreSorted(positions), "Handlers should be serialized in sorted order")
+
+	assert.Less(t, strings.Index(firstEnv, "ALPHA_VALUE"), strings.Index(firstEnv, "MIDDLE_VALUE"), "Custom env vars should be sorted")
+	assert.Less(t, strings.Index(firstEnv, "MIDDLE_VALUE"), strings.Index(firstEnv, "ZETA_VALUE"), "Custom env vars should be sorted")
+}
+
+// TestBuildHandlerManagerEnv tests that the handler manager env carries max expressions
+// and handler conditions
+func TestBuildHandlerManagerEnv(t *testing.T) {
+	compiler := NewCompiler()
+	safeOutputs := compiler.extractSafeOutputsConfig(map[string]any{"safe-outputs": map[string]any{
+		"create-issue": map[string]any{"max": "${{ vars.MAX_ISSUES }}"},
+		"add-comment":  map[string]any{"if": "github.event_name == 'issues'"},
+	}})
+	require.NotNil(t, safeOutputs, "Safe outputs should be parsed")
+
+	env := BuildHandlerManagerEnv(safeOutputs)
+	assert.Equal(t, "${{ vars.MAX_ISSUES }}", env["GH_AW_SAFE_OUTPUTS_MAX_CREATE_ISSUE"], "Max expression should be passed via env")
+	assert.Equal(t, "${{ github.event_name == 'issues' }}", env["GH_AW_SAFE_OUTPUTS_IF_ADD_COMMENT"], "Handler condition should be passed via env")
+	assert.Len(t, env, 2, "Only max expressions and handler conditions should be passed via env")
+}
@@ -32,17 +32,16 @@ func (c *Compiler) buildConsolidatedSafeOutputsJob(data *WorkflowData, mainJobNa
 	// Compute permissions based on configured safe outputs (principle of least privilege)
 	permissions := computePermissionsForSafeOutputs(data.SafeOutputs)
 
+	// Resolve the deployment environment providing secrets for handler github-tokens
+	environment, err := resolveSafeOutputsEnvironment(data.SafeOutputs)
+	if err != nil {
+		return nil, nil, err
+	}
+	jobEnvironment := renderSafeOutputsJobEnvironment(environment)
+
 	// Track whether threat detection job is enabled for step conditions
 	threatDetectionEnabled := data.SafeOutputs.ThreatDetection != nil
 
-	// Add GitHub App token minting step if app is configured
-	if data.SafeOutputs.App != nil {
-		consolidatedSafeOutputsJobLog.Print("Adding GitHub App token minting step")
-		// Prepend GitHub App token step before other steps
-		appTokenSteps := c.buildGitHubAppTokenMintStep(data.SafeOutputs.App, permissions)
-		steps = append(steps, appTokenSteps...)
-	}
-
 	// Add setup action to copy JavaScript files
 	setupActionRef := c.resolveActionReference("./actions/setup", data)
 	if setupActionRef != "" || c.actionMode.IsScript() {
@@ -88,10 +87,7 @@ func (c *Compiler) buildConsolidatedSafeOutputsJob(data *WorkflowData, mainJobNa
 		// Build condition: only unlock if issue was locked by activation job
 		// Must match lock condition: event type is 'issues' or 'issue_comment'
 		// Use the issue_locked output from activation job to determine if unlock is needed
-		eventTypeCheck := BuildOr(
-			BuildEventTypeEquals("issues"),
-			BuildEventTypeEquals("issue_comment"),
-		)
+		eventTypeCheck := BuildEventTypeIn("issues", "issue_comment")
 		lockedOutputCheck := BuildEquals(
 			BuildPropertyAccess(fmt.Sprintf("needs.%s.outputs.issue_locked", constants.ActivationJobName)),
 			BuildStringLiteral("true"),
@@ -267,9 +263,10 @@ func (c *Compiler) buildConsolidatedSafeOutputsJob(data *WorkflowData, mainJobNa
 		return nil, nil, nil
 	}
 
-	// Add GitHub App token minting step at the beginning if app is configured
+	// Add GitHub App token minting step if app is configured
 	if data.SafeOutputs.App != nil {
-		appTokenSteps := c.buildGitHubAppTokenMintStep(data.SafeOutputs.App, permissions)
+		consolidatedSafeOutputsJobLog.Print("Adding GitHub App token minting step")
+		appTokenSteps := c.buildGitHubAppTokenMintStepWithFields(data.SafeOutputs.App, safeOutputsAppTokenFields(data.SafeOutputs, permissions))
 		// Calculate insertion index: after setup action (if present) and artifact downloads, but before safe output steps
 		insertIndex := 0
 
@@ -342,10 +339,11 @@ func (c *Compiler) buildConsolidatedSafeOutputsJob(data *WorkflowData, mainJobNa
 	jobEnv := c.buildJobLevelSafeOutputEnvVars(data, workflowID)
 
 	job := &Job{
-		Name:           "safe_outputs",
+		Name:           string(constants.SafeOutputsJobName),
 		If:             jobCondition.Render(),
 		RunsOn:         c.formatSafeOutputsRunsOn(data.SafeOutputs),
 		Permissions:    permissions.RenderToYAML(),
+		Environment:    jobEnvironment,
 		TimeoutMinutes: 15, // Slightly longer timeout for consolidated job with multiple steps
 		Env:            jobEnv,
 		Steps:          steps,
@@ -160,6 +160,12 @@ func (c *Compiler) buildSharedPRCheckoutSteps(data *WorkflowData) []string {
 	}
 	steps = append(steps, gitConfigSteps...)
 
+	// Step 3: Configure commit signing when tools.github.require-signed-commits is enabled
+	if signedCommits := getSignedCommitsConfig(data); signedCommits != nil {
+		steps = append(steps, buildCommitSigningSteps(signedCommits, condition)...)
+		consolidatedSafeOutputsStepsLog.Print("Added commit signing step")
+	}
+
 	consolidatedSafeOutputsStepsLog.Printf("Added shared checkout with condition: %s", condition.Render())
 	return steps
 }
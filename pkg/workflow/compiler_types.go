@@ -2,6 +2,8 @@ package workflow
 
 import (
 	"os"
+	"slices"
+	"sync"
 
 	"github.com/github/gh-aw/pkg/logger"
 	"github.com/github/gh-aw/pkg/parser"
@@ -72,6 +74,12 @@ func WithRepositorySlug(slug string) CompilerOption {
 	return func(c *Compiler) { c.repositorySlug = slug }
 }
 
+// WithPromptTokenWarningThreshold sets the estimated prompt size (in tokens) above which
+// the compiler warns about context overflow risk
+func WithPromptTokenWarningThreshold(threshold int) CompilerOption {
+	return func(c *Compiler) { c.promptTokenThreshold = threshold }
+}
+
 // WithGitRoot sets the git repository root directory for action cache path
 func WithGitRoot(gitRoot string) CompilerOption {
 	return func(c *Compiler) { c.gitRoot = gitRoot }
@@ -120,17 +128,32 @@ type Compiler struct {
 	engineRegistry          *EngineRegistry     // Registry of available agentic engines
 	fileTracker             FileTracker         // Optional file tracker for tracking created files
 	warningCount            int                 // Number of warnings encountered during compilation
+	warnings                []string            // Warning messages emitted while compiling the current workflow
+	failOnWarning           bool                // If true, fail the compile when the current workflow emits any warning
+	explain                 bool                // If true, annotate each generated job in the lock file with why it exists
+	inlineImports           bool                // If true, inline imports and the main workflow markdown instead of emitting runtime-import macros
 	stepOrderTracker        *StepOrderTracker   // Tracks step ordering for validation
 	actionCache             *ActionCache        // Shared cache for action pin resolutions across all workflows
 	actionResolver          *ActionResolver     // Shared resolver for action pins across all workflows
 	actionPinWarnings       map[string]bool     // Shared cache of already-warned action pin failures (key: "repo@version")
+	actionPinWarningsMu     *sync.Mutex         // Guards actionPinWarnings while parallel compile workers share it
 	importCache             *parser.ImportCache // Shared cache for imported workflow files
 	workflowIdentifier      string              // Identifier for the current workflow being compiled (for schedule scattering)
 	scheduleWarnings        []string            // Accumulated schedule warnings for this compiler instance
 	repositorySlug          string              // Repository slug (owner/repo) used as seed for scattering
 	artifactManager         *ArtifactManager    // Tracks artifact uploads/downloads for validation
 	scheduleFriendlyFormats map[int]string      // Maps schedule item index to friendly format string for current workflow
+	scheduleBudget          float64             // Monthly cost budget in USD from on.schedule.budget (0 = none)
+	scheduleCrons           []string            // Cron expressions of the workflow schedule, used for the schedule budget
 	gitRoot                 string              // Git repository root directory (if set, used for action cache path)
+	repositoryConfig        *RepositoryConfig   // Repository-level policies from .github/aw/config.json, loaded on first use
+	promptTokenThreshold    int                 // Estimated prompt tokens above which a warning is emitted (0 = default)
+	emitJobGraph            bool                // If true, write a <workflow>.jobs.json job graph next to the lock file
+	lintTokens              bool                // If true, warn about safe-outputs tokens broader than needed
+	digestResolver          DigestResolver      // Shared resolver for pinning container images by digest (mcp.pin-digests)
+
+	sourceOverridePath string // Markdown path whose source is served from sourceOverride instead of disk (CompileString)
+	sourceOverride     []byte // In-memory workflow source for sourceOverridePath
 }
 
 // NewCompiler creates a new workflow compiler with functional options.
@@ -183,6 +206,23 @@ func (c *Compiler) SetSkipValidation(skip bool) {
 	c.skipValidation = skip
 }
 
+// SetPromptTokenWarningThreshold configures the estimated prompt size (in tokens) above
+// which the compiler warns about context overflow risk (0 = default)
+func (c *Compiler) SetPromptTokenWarningThreshold(threshold int) {
+	c.promptTokenThreshold = threshold
+}
+
+// SetEmitJobGraph configures whether to write a <workflow>.jobs.json sidecar describing
+// the generated jobs and their dependencies next to each lock file
+func (c *Compiler) SetEmitJobGraph(emit bool) {
+	c.emitJobGraph = emit
+}
+
+// SetLintTokens configures whether to warn when safe-outputs tokens are broader than needed
+func (c *Compiler) SetLintTokens(lint bool) {
+	c.lintTokens = lint
+}
+
 // SetQuiet configures whether to suppress success messages (for interactive mode)
 func (c *Compiler) SetQuiet(quiet bool) {
 	c.quiet = quiet
@@ -213,6 +253,27 @@ func (c *Compiler) SetStrictMode(strict bool) {
 	c.strictMode = strict
 }
 
+// SetFailOnWarning configures whether a workflow that compiles with warnings is reported
+// as a compile error. Unlike strict mode, the warnings themselves are not turned into
+// stricter validation; the compile fails after validation if any warning was emitted.
+func (c *Compiler) SetFailOnWarning(failOnWarning bool) {
+	c.failOnWarning = failOnWarning
+}
+
+// SetExplain configures whether each generated job is preceded by a comment in the lock
+// file explaining why it was created. Off by default to keep lock file diffs quiet.
+func (c *Compiler) SetExplain(explain bool) {
+	c.explain = explain
+}
+
+// SetInlineImports configures whether imported markdown and the main workflow markdown are
+// inlined into the lock file instead of loaded at runtime with {{#runtime-import}} macros.
+// Inlined workflows do not need the .github folder at runtime (e.g., air-gapped or vendored
+// deployments), but edits to the markdown require recompiling.
+func (c *Compiler) SetInlineImports(inline bool) {
+	c.inlineImports = inline
+}
+
 // SetRefreshStopTime configures whether to force regeneration of stop-after times
 func (c *Compiler) SetRefreshStopTime(refresh bool) {
 	c.refreshStopTime = refresh
@@ -253,11 +314,23 @@ func (c *Compiler) IncrementWarningCount() {
 	c.warningCount++
 }
 
+// addWarning increments the warning counter and records the message for the workflow
+// being compiled, so that it is returned by GetWarnings and CompileWorkflowWithReport
+func (c *Compiler) addWarning(message string) {
+	c.warningCount++
+	c.warnings = append(c.warnings, message)
+}
+
 // GetWarningCount returns the current warning count
 func (c *Compiler) GetWarningCount() int {
 	return c.warningCount
 }
 
+// GetWarnings returns the warning messages emitted while compiling the most recent workflow
+func (c *Compiler) GetWarnings() []string {
+	return slices.Clone(c.warnings)
+}
+
 // ResetWarningCount resets the warning counter to zero
 func (c *Compiler) ResetWarningCount() {
 	c.warningCount = 0
@@ -362,6 +435,12 @@ func (c *Compiler) GetArtifactManager() *ArtifactManager {
 	return c.artifactManager
 }
 
+// GetJobManager returns the job manager holding the jobs generated for the most recently
+// compiled workflow, or nil if no workflow has been compiled yet
+func (c *Compiler) GetJobManager() *JobManager {
+	return c.jobManager
+}
+
 // SkipIfMatchConfig holds the configuration for skip-if-match conditions
 type SkipIfMatchConfig struct {
 	Query string // GitHub search query to check before running workflow
@@ -376,85 +455,96 @@ type SkipIfNoMatchConfig struct {
 
 // WorkflowData holds all the data needed to generate a GitHub Actions workflow
 type WorkflowData struct {
-	Name                  string
-	WorkflowID            string         // workflow identifier derived from markdown filename (basename without extension)
-	TrialMode             bool           // whether the workflow is running in trial mode
-	TrialLogicalRepo      string         // target repository slug for trial mode (owner/repo)
-	FrontmatterName       string         // name field from frontmatter (for code scanning alert driver default)
-	FrontmatterYAML       string         // raw frontmatter YAML content (rendered as comment in lock file for reference)
-	Description           string         // optional description rendered as comment in lock file
-	Source                string         // optional source field (owner/repo@ref/path) rendered as comment in lock file
-	TrackerID             string         // optional tracker identifier for created assets (min 8 chars, alphanumeric + hyphens/underscores)
-	ImportedFiles         []string       // list of files imported via imports field (rendered as comment in lock file)
-	ImportedMarkdown      string         // Only imports WITH inputs (for compile-time substitution)
-	ImportPaths           []string       // Import file paths for runtime-import macro generation (imports without inputs)
-	MainWorkflowMarkdown  string         // main workflow markdown without imports (for runtime-import)
-	IncludedFiles         []string       // list of files included via @include directives (rendered as comment in lock file)
-	ImportInputs          map[string]any // input values from imports with inputs (for github.aw.inputs.* substitution)
-	On                    string
-	Permissions           string
-	Network               string // top-level network permissions configuration
-	Concurrency           string // workflow-level concurrency configuration
-	RunName               string
-	Env                   string
-	If                    string
-	TimeoutMinutes        string
-	CustomSteps           string
-	PostSteps             string // steps to run after AI execution
-	RunsOn                string
-	Environment           string // environment setting for the main job
-	Container             string // container setting for the main job
-	Services              string // services setting for the main job
-	Tools                 map[string]any
-	ParsedTools           *Tools // Structured tools configuration (NEW: parsed from Tools map)
-	MarkdownContent       string
-	AI                    string        // "claude" or "codex" (for backwards compatibility)
-	EngineConfig          *EngineConfig // Extended engine configuration
-	AgentFile             string        // Path to custom agent file (from imports)
-	AgentImportSpec       string        // Original import specification for agent file (e.g., "owner/repo/path@ref")
-	RepositoryImports     []string      // Repository-only imports (format: "owner/repo@ref") for .github folder merging
-	StopTime              string
-	SkipIfMatch           *SkipIfMatchConfig   // skip-if-match configuration with query and max threshold
-	SkipIfNoMatch         *SkipIfNoMatchConfig // skip-if-no-match configuration with query and min threshold
-	ManualApproval        string               // environment name for manual approval from on: section
-	Command               []string             // for /command trigger support - multiple command names
-	CommandEvents         []string             // events where command should be active (nil = all events)
-	CommandOtherEvents    map[string]any       // for merging command with other events
-	AIReaction            string               // AI reaction type like "eyes", "heart", etc.
-	LockForAgent          bool                 // whether to lock the issue during agent workflow execution
-	Jobs                  map[string]any       // custom job configurations with dependencies
-	Cache                 string               // cache configuration
-	NeedsTextOutput       bool                 // whether the workflow uses ${{ needs.task.outputs.text }}
-	NetworkPermissions    *NetworkPermissions  // parsed network permissions
-	SandboxConfig         *SandboxConfig       // parsed sandbox configuration (AWF or SRT)
-	SafeOutputs           *SafeOutputsConfig   // output configuration for automatic output routes
-	SafeInputs            *SafeInputsConfig    // safe-inputs configuration for custom MCP tools
-	Roles                 []string             // permission levels required to trigger workflow
-	Bots                  []string             // allow list of bot identifiers that can trigger workflow
-	RateLimit             *RateLimitConfig     // rate limiting configuration for workflow triggers
-	CacheMemoryConfig     *CacheMemoryConfig   // parsed cache-memory configuration
-	RepoMemoryConfig      *RepoMemoryConfig    // parsed repo-memory configuration
-	Runtimes              map[string]any       // runtime version overrides from frontmatter
-	PluginInfo            *PluginInfo          // Consolidated plugin information (plugins, custom token, MCP configs)
-	ToolsTimeout          int                  // timeout in seconds for tool/MCP operations (0 = use engine default)
-	GitHubToken           string               // top-level github-token expression from frontmatter
-	ToolsStartupTimeout   int                  // timeout in seconds for MCP server startup (0 = use engine default)
-	Features              map[string]any       // feature flags and configuration options from frontmatter (supports bool and string values)
-	ActionCache           *ActionCache         // cache for action pin resolutions
-	ActionResolver        *ActionResolver      // resolver for action pins
-	StrictMode            bool                 // strict mode for action pinning
-	SecretMasking         *SecretMaskingConfig // secret masking configuration
-	ParsedFrontmatter     *FrontmatterConfig   // cached parsed frontmatter configuration (for performance optimization)
-	ActionPinWarnings     map[string]bool      // cache of already-warned action pin failures (key: "repo@version")
-	ActionMode            ActionMode           // action mode for workflow compilation (dev, release, script)
-	HasExplicitGitHubTool bool                 // true if tools.github was explicitly configured in frontmatter
+	Name                     string
+	WorkflowID               string         // workflow identifier derived from markdown filename (basename without extension)
+	TrialMode                bool           // whether the workflow is running in trial mode
+	TrialLogicalRepo         string         // target repository slug for trial mode (owner/repo)
+	FrontmatterName          string         // name field from frontmatter (for code scanning alert driver default)
+	FrontmatterYAML          string         // raw frontmatter YAML content (rendered as comment in lock file for reference)
+	Description              string         // optional description rendered as comment in lock file
+	Source                   string         // optional source field (owner/repo@ref/path) rendered as comment in lock file
+	TrackerID                string         // optional tracker identifier for created assets (min 8 chars, alphanumeric + hyphens/underscores)
+	ImportedFiles            []string       // list of files imported via imports field (rendered as comment in lock file)
+	ImportedMarkdown         string         // Only imports WITH inputs (for compile-time substitution)
+	ImportPaths              []string       // Import file paths for runtime-import macro generation (imports without inputs)
+	MainWorkflowMarkdown     string         // main workflow markdown without imports (for runtime-import)
+	IncludedFiles            []string       // list of files included via @include directives (rendered as comment in lock file)
+	ImportInputs             map[string]any // input values from imports with inputs (for github.aw.inputs.* substitution)
+	On                       string
+	Permissions              string
+	Network                  string // top-level network permissions configuration
+	Concurrency              string // workflow-level concurrency configuration
+	RunName                  string
+	Env                      string
+	If                       string
+	TimeoutMinutes           string
+	CustomSteps              string
+	PostSteps                string // steps to run after AI execution
+	RunsOn                   string
+	Environment              string // environment setting for the main job
+	Container                string // container setting for the main job
+	Services                 string // services setting for the main job
+	Tools                    map[string]any
+	ParsedTools              *Tools // Structured tools configuration (NEW: parsed from Tools map)
+	MarkdownContent          string
+	AI                       string        // "claude" or "codex" (for backwards compatibility)
+	EngineConfig             *EngineConfig // Extended engine configuration
+	AgentFile                string        // Path to custom agent file (from imports)
+	AgentImportSpec          string        // Original import specification for agent file (e.g., "owner/repo/path@ref")
+	RepositoryImports        []string      // Repository-only imports (format: "owner/repo@ref") for .github folder merging
+	StopTime                 string
+	SkipIfMatch              *SkipIfMatchConfig   // skip-if-match configuration with query and max threshold
+	SkipIfNoMatch            *SkipIfNoMatchConfig // skip-if-no-match configuration with query and min threshold
+	ManualApproval           string               // environment name for manual approval from on: section
+	Command                  []string             // for /command trigger support - multiple command names
+	CommandEvents            []string             // events where command should be active (nil = all events)
+	CommandOtherEvents       map[string]any       // for merging command with other events
+	AIReaction               string               // AI reaction type like "eyes", "heart", etc.
+	AIReactionFallbacks      []string             // Reactions tried in order when AIReaction cannot be applied
+	LockForAgent             bool                 // whether to lock the issue during agent workflow execution
+	Jobs                     map[string]any       // custom job configurations with dependencies
+	Cache                    string               // cache configuration
+	NeedsTextOutput          bool                 // whether the workflow uses ${{ needs.task.outputs.text }}
+	NetworkPermissions       *NetworkPermissions  // parsed network permissions
+	SandboxConfig            *SandboxConfig       // parsed sandbox configuration (AWF or SRT)
+	SafeOutputs              *SafeOutputsConfig   // output configuration for automatic output routes
+	SafeInputs               *SafeInputsConfig    // safe-inputs configuration for custom MCP tools
+	Roles                    []string             // permission levels required to trigger workflow
+	Bots                     []string             // allow list of bot identifiers that can trigger workflow
+	RateLimit                *RateLimitConfig     // rate limiting configuration for workflow triggers
+	CacheMemoryConfig        *CacheMemoryConfig   // parsed cache-memory configuration
+	RepoMemoryConfig         *RepoMemoryConfig    // parsed repo-memory configuration
+	Runtimes                 map[string]any       // runtime version overrides from frontmatter
+	PluginInfo               *PluginInfo          // Consolidated plugin information (plugins, custom token, MCP configs)
+	ToolsTimeout             int                  // timeout in seconds for tool/MCP operations (0 = use engine default)
+	GitHubToken              string               // top-level github-token expression from frontmatter
+	ToolsStartupTimeout      int                  // timeout in seconds for MCP server startup (0 = use engine default)
+	ToolTimeouts             map[string]int       // per-tool timeout overrides in seconds (tools without an override use ToolsTimeout)
+	BashShell                string               // shell used by the bash tool from tools.bash.shell ("" = default bash)
+	PinDockerDigests         bool                 // pin container images by digest wherever they run (mcp.pin-digests)
+	PromptMaxChars           int                  // budget for the assembled prompt size in characters (prompt.max-chars, 0 = no budget)
+	CheckoutDisabled         bool                 // skip the agent job repository checkout (checkout: false)
+	PromptRedact             []string             // expressions masked in the printed prompt and logs (prompt.redact)
+	Features                 map[string]any       // feature flags and configuration options from frontmatter (supports bool and string values)
+	ActionCache              *ActionCache         // cache for action pin resolutions
+	ActionResolver           *ActionResolver      // resolver for action pins
+	StrictMode               bool                 // strict mode for action pinning
+	SecretMasking            *SecretMaskingConfig // secret masking configuration
+	ParsedFrontmatter        *FrontmatterConfig   // cached parsed frontmatter configuration (for performance optimization)
+	ActionPinWarnings        map[string]bool      // cache of already-warned action pin failures (key: "repo@version")
+	ActionPinWarningsMu      *sync.Mutex          // guards ActionPinWarnings when it is shared by parallel compile workers (nil otherwise)
+	ActionPinWarningMessages []string             // action pin warnings emitted for this workflow, reported as compile warnings
+	ActionMode               ActionMode           // action mode for workflow compilation (dev, release, script)
+	HasExplicitGitHubTool    bool                 // true if tools.github was explicitly configured in frontmatter
 }
 
 // BaseSafeOutputConfig holds common configuration fields for all safe output types
 type BaseSafeOutputConfig struct {
-	Max         int    `yaml:"max,omitempty"`          // Maximum number of items to create
-	GitHubToken string `yaml:"github-token,omitempty"` // GitHub token for this specific output type
-	Staged      bool   `yaml:"staged,omitempty"`       // If true, emit step summary messages instead of making GitHub API calls for this specific output type
+	Max           int    `yaml:"max,omitempty"`          // Maximum number of items to create
+	MaxExpression string `yaml:"-"`                      // GitHub Actions expression for max, resolved at runtime (e.g. "${{ vars.MAX_COMMENTS }}")
+	GitHubToken   string `yaml:"github-token,omitempty"` // GitHub token for this specific output type
+	Environment   string `yaml:"environment,omitempty"`  // Deployment environment providing the secrets referenced by GitHubToken
+	Staged        bool   `yaml:"staged,omitempty"`       // If true, emit step summary messages instead of making GitHub API calls for this specific output type
 }
 
 // SafeOutputsConfig holds configuration for automatic output routes
@@ -509,6 +599,9 @@ type SafeOutputsConfig struct {
 	Messages                        *SafeOutputMessagesConfig              `yaml:"messages,omitempty"`                  // Custom message templates for footer and notifications
 	Mentions                        *MentionsConfig                        `yaml:"mentions,omitempty"`                  // Configuration for @mention filtering in safe outputs
 	Footer                          *bool                                  `yaml:"footer,omitempty"`                    // Global footer control - when false, omits visible footer from all safe outputs (XML markers still included)
+	Retry                           *SafeOutputsRetryConfig                `yaml:"retry,omitempty"`                     // Retry policy for transient GitHub API failures in handler requests
+	Conclusion                      *bool                                  `yaml:"conclusion,omitempty"`                // When false, the conclusion job is not generated
+	Conditions                      map[string]string                      `yaml:"-"`                                   // Per-handler if conditions keyed by handler name (e.g. "add_comment"), evaluated at runtime
 }
 
 // SafeOutputMessagesConfig holds custom message templates for safe-output footer and notification messages
@@ -0,0 +1,137 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/stringutil"
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const rateLimitWarningWorkflow = `---
+on: workflow_dispatch
+engine: copilot
+rate-limit:
+  max: 5
+  window: 60
+permissions:
+  contents: read
+---
+
+# Test Workflow
+`
+
+const noWarningWorkflow = `---
+on: workflow_dispatch
+engine: copilot
+permissions:
+  contents: read
+---
+
+# Test Workflow
+`
+
+// writeWarningTestWorkflow writes the workflow content to a temporary markdown file
+func writeWarningTestWorkflow(t *testing.T, content string) string {
+	t.Helper()
+	markdownPath := filepath.Join(testutil.TempDir(t, "compiler-warnings"), "test.md")
+	require.NoError(t, os.WriteFile(markdownPath, []byte(content), 0644), "Failed to write workflow file")
+	return markdownPath
+}
+
+func TestCompileWorkflowWithReportCollectsWarnings(t *testing.T) {
+	markdownPath := writeWarningTestWorkflow(t, rateLimitWarningWorkflow)
+
+	compiler := NewCompiler()
+	warnings, err := compiler.CompileWorkflowWithReport(markdownPath)
+	require.NoError(t, err, "Warnings should not fail the compile by default")
+
+	assert.Contains(t, warnings, "Using experimental feature: rate-limit", "Experimental warning should be collected")
+	assert.Len(t, warnings, compiler.GetWarningCount(), "Every counted warning should be collected")
+	assert.FileExists(t, stringutil.MarkdownToLockFile(markdownPath), "Lock file should be written")
+}
+
+func TestCompileWorkflowWithReportNoWarnings(t *testing.T) {
+	markdownPath := writeWarningTestWorkflow(t, noWarningWorkflow)
+
+	compiler := NewCompiler()
+	compiler.SetFailOnWarning(true)
+	warnings, err := compiler.CompileWorkflowWithReport(markdownPath)
+	require.NoError(t, err, "Workflow without warnings should compile with fail-on-warning")
+	assert.Empty(t, warnings, "No warnings should be collected")
+}
+
+func TestCompileWorkflowWithReportFailOnWarning(t *testing.T) {
+	markdownPath := writeWarningTestWorkflow(t, rateLimitWarningWorkflow)
+
+	compiler := NewCompiler()
+	compiler.SetFailOnWarning(true)
+	warnings, err := compiler.CompileWorkflowWithReport(markdownPath)
+	require.Error(t, err, "Warnings should fail the compile with fail-on-warning")
+
+	assert.Contains(t, err.Error(), "fail-on-warning", "Error should name the fail-on-warning setting")
+	assert.Contains(t, err.Error(), "Using experimental feature: rate-limit", "Error should list the warnings")
+	assert.Contains(t, warnings, "Using experimental feature: rate-limit", "Warnings should still be returned")
+	assert.NoFileExists(t, stringutil.MarkdownToLockFile(markdownPath), "Lock file should not be written")
+}
+
+func TestWarningsCollectedPerWorkflow(t *testing.T) {
+	compiler := NewCompiler()
+
+	_, err := compiler.CompileWorkflowWithReport(writeWarningTestWorkflow(t, rateLimitWarningWorkflow))
+	require.NoError(t, err, "First workflow should compile")
+	warningCount := compiler.GetWarningCount()
+
+	warnings, err := compiler.CompileWorkflowWithReport(writeWarningTestWorkflow(t, noWarningWorkflow))
+	require.NoError(t, err, "Second workflow should compile")
+
+	assert.Empty(t, warnings, "Warnings from the previous workflow should not be reported")
+	assert.Equal(t, warningCount, compiler.GetWarningCount(), "Warning count should keep accumulating across workflows")
+}
+
+func TestFailOnWarningCompileString(t *testing.T) {
+	markdownPath := filepath.Join(testutil.TempDir(t, "compiler-warnings"), "test.md")
+
+	compiler := NewCompiler()
+	compiler.SetFailOnWarning(true)
+	_, err := compiler.CompileString(rateLimitWarningWorkflow, markdownPath)
+	require.Error(t, err, "Fail-on-warning should apply to in-memory compiles")
+	assert.Equal(t, []string{"Using experimental feature: rate-limit"}, compiler.GetWarnings(), "GetWarnings should return the collected warnings")
+}
+
+const unresolvablePinWorkflow = `---
+on: workflow_dispatch
+engine: copilot
+permissions:
+  contents: read
+steps:
+  - name: Unpinned step
+    uses: gh-aw-test-org/unresolvable-action@v1
+---
+
+# Test Workflow
+`
+
+func TestFailOnWarningUnresolvableActionPin(t *testing.T) {
+	markdownPath := writeWarningTestWorkflow(t, unresolvablePinWorkflow)
+
+	compiler := NewCompiler()
+	compiler.SetFailOnWarning(true)
+	warnings, err := compiler.CompileWorkflowWithReport(markdownPath)
+	require.Error(t, err, "An unresolvable action pin should fail the compile with fail-on-warning")
+
+	assert.Contains(t, err.Error(), "Unable to pin action gh-aw-test-org/unresolvable-action@v1", "Error should list the pin warning")
+	require.Len(t, warnings, 1, "The pin warning should be collected once")
+	assert.Contains(t, warnings[0], "Unable to pin action gh-aw-test-org/unresolvable-action@v1", "Pin warning should be returned")
+	assert.NoFileExists(t, stringutil.MarkdownToLockFile(markdownPath), "Lock file should not be written")
+
+	// The pin warning is only printed once per compiler, but it is still reported for every workflow
+	warnings, err = compiler.CompileWorkflowWithReport(writeWarningTestWorkflow(t, unresolvablePinWorkflow))
+	require.Error(t, err, "A second workflow with the same unresolvable pin should also fail")
+	assert.Len(t, warnings, 1, "The pin warning should be collected for the second workflow")
+}
@@ -2,6 +2,7 @@ package workflow
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"path/filepath"
 	"strings"
@@ -33,6 +34,10 @@ func (c *Compiler) buildJobsAndValidate(data *WorkflowData, markdownPath string)
 
 	// Validate job dependencies
 	if err := c.jobManager.ValidateDependencies(); err != nil {
+		var depErr *JobDependencyError
+		if errors.As(err, &depErr) {
+			err = newFrontmatterCompileError(markdownPath, data.FrontmatterYAML, []string{"jobs", depErr.JobName, "needs"}, CompileErrorCodeJobDependency, err.Error(), err)
+		}
 		return fmt.Errorf("job dependency validation failed: %w", err)
 	}
 
@@ -169,7 +174,7 @@ func (c *Compiler) generateYAML(data *WorkflowData, markdownPath string) (string
 	if markdownPath != "" {
 		baseDir := filepath.Dir(markdownPath)
 		cache := parser.NewImportCache(baseDir)
-		hash, err := parser.ComputeFrontmatterHashFromFile(markdownPath, cache)
+		hash, err := parser.ComputeFrontmatterHashFromFileWithReader(markdownPath, cache, c.readWorkflowSource)
 		if err != nil {
 			compilerYamlLog.Printf("Warning: failed to compute frontmatter hash: %v", err)
 			// Continue without hash - non-fatal error
@@ -184,6 +189,9 @@ func (c *Compiler) generateYAML(data *WorkflowData, markdownPath string) (string
 	var yaml strings.Builder
 	yaml.Grow(256 * 1024)
 
+	// Generate the machine-readable lock file header first so tools can read it without parsing YAML
+	yaml.WriteString(generateLockFileHeader(markdownPath, frontmatterHash))
+
 	// Generate workflow header comments (including hash)
 	c.generateWorkflowHeader(&yaml, data, frontmatterHash)
 
@@ -203,6 +211,19 @@ func (c *Compiler) generateYAML(data *WorkflowData, markdownPath string) (string
 	return yamlContent, nil
 }
 
+// workflowSourcePath returns the path of a workflow markdown file relative to the
+// workspace root. For a workflow at ".github/workflows/test.md" this is
+// ".github/workflows/test.md"; for non-standard paths (like /tmp/test.md) it is the
+// basename. The path always uses forward slashes for cross-platform compatibility.
+func workflowSourcePath(markdownPath string) string {
+	workflowFilePath := filepath.Base(markdownPath)
+	if githubIndex := strings.Index(markdownPath, ".github"); githubIndex != -1 {
+		// Extract everything from ".github/" onwards (inclusive)
+		workflowFilePath = markdownPath[githubIndex:]
+	}
+	return filepath.ToSlash(workflowFilePath)
+}
+
 func splitContentIntoChunks(content string) []string {
 	const maxChunkSize = 20900        // 21000 - 100 character buffer
 	const indentSpaces = "          " // 10 spaces added to each line
@@ -241,6 +262,45 @@ func (c *Compiler) generatePrompt(yaml *strings.Builder, data *WorkflowData) {
 	builtinSections := c.collectPromptSections(data)
 	compilerYamlLog.Printf("Collected %d built-in prompt sections", len(builtinSections))
 
+	userPromptChunks, expressionMappings := c.buildUserPromptChunks(data)
+
+	// Warn when the assembled prompt risks overflowing the engine context window
+	c.checkPromptSize(data, builtinSections)
+
+	// Generate a single unified prompt creation step
+	c.generateUnifiedPromptCreationStep(yaml, builtinSections, userPromptChunks, expressionMappings, data)
+
+	// Values hidden by prompt.redact are replaced in a copy of the prompt written by the
+	// interpolation step; the print step reads that copy
+	redactMappings := promptRedactMappings(data.PromptRedact, builtinSections, expressionMappings)
+
+	// Add combined interpolation and template rendering step
+	c.generateInterpolationAndTemplateStep(yaml, expressionMappings, redactMappings, data)
+
+	// Validate that all placeholders have been substituted
+	yaml.WriteString("      - name: Validate prompt placeholders\n")
+	yaml.WriteString("        env:\n")
+	yaml.WriteString("          GH_AW_PROMPT: /tmp/gh-aw/aw-prompts/prompt.txt\n")
+	yaml.WriteString("        run: bash /opt/gh-aw/actions/validate_prompt_placeholders.sh\n")
+
+	// Print prompt (merged into prompt generation)
+	yaml.WriteString("      - name: Print prompt\n")
+	yaml.WriteString("        env:\n")
+	if len(redactMappings) > 0 {
+		fmt.Fprintf(yaml, "          GH_AW_PROMPT: %s\n", promptRedactedPath)
+	} else {
+		yaml.WriteString("          GH_AW_PROMPT: /tmp/gh-aw/aw-prompts/prompt.txt\n")
+	}
+	yaml.WriteString("        run: bash /opt/gh-aw/actions/print_prompt_summary.sh\n")
+}
+
+// buildUserPromptChunks assembles the user prompt body that follows the built-in prompt
+// sections: engine.prompt-prefix, inlined imports, runtime-import macros for imports and
+// the main workflow markdown (inlined instead when checkout: false skips the checkout or
+// imports are inlined with SetInlineImports), and
+// engine.prompt-suffix, in that order. Returns the chunks and the expression mappings
+// extracted from them.
+func (c *Compiler) buildUserPromptChunks(data *WorkflowData) ([]string, []*ExpressionMapping) {
 	// NEW APPROACH: Use runtime-import macros for imports without inputs
 	// - Imported markdown without inputs uses runtime-import macros (loaded at runtime)
 	// - Imported markdown with inputs is still inlined (compile-time substitution required)
@@ -250,6 +310,14 @@ func (c *Compiler) generatePrompt(yaml *strings.Builder, data *WorkflowData) {
 	var userPromptChunks []string
 	var expressionMappings []*ExpressionMapping
 
+	// Step 0: Prepend engine.prompt-prefix so it precedes all imported and main workflow content
+	if data.EngineConfig != nil && data.EngineConfig.PromptPrefix != "" {
+		prefixChunks, prefixMappings := buildPromptAffixChunks(data.EngineConfig.PromptPrefix)
+		userPromptChunks = append(userPromptChunks, prefixChunks...)
+		expressionMappings = append(expressionMappings, prefixMappings...)
+		compilerYamlLog.Printf("Added engine prompt prefix in %d chunks", len(prefixChunks))
+	}
+
 	// Step 1a: Process and inline imported markdown with inputs (if any)
 	// Imports with inputs MUST be inlined because substitution happens at compile time
 	if data.ImportedMarkdown != "" {
@@ -272,7 +340,7 @@ func (c *Compiler) generatePrompt(yaml *strings.Builder, data *WorkflowData) {
 		importedExprMappings, err := extractor.ExtractExpressions(cleanedImportedMarkdown)
 		if err == nil && len(importedExprMappings) > 0 {
 			cleanedImportedMarkdown = extractor.ReplaceExpressionsWithEnvVars(cleanedImportedMarkdown)
-			expressionMappings = importedExprMappings
+			expressionMappings = append(expressionMappings, importedExprMappings...)
 		}
 
 		// Split imported content into chunks and add to user prompt
@@ -299,7 +367,9 @@ func (c *Compiler) generatePrompt(yaml *strings.Builder, data *WorkflowData) {
 	// The main workflow markdown uses runtime-import, but expressions like needs.* must be
 	// available at compile time for the substitute placeholders step
 	// Use MainWorkflowMarkdown (not MarkdownContent) to avoid extracting from imported content
-	if data.MainWorkflowMarkdown != "" {
+	// Skipped when the main workflow markdown is inlined below, which extracts its own expressions
+	inlineMainMarkdown := c.inlineImports || isAgentCheckoutSkipped(data)
+	if data.MainWorkflowMarkdown != "" && !inlineMainMarkdown {
 		compilerYamlLog.Printf("Extracting expressions from main workflow markdown (%d bytes)", len(data.MainWorkflowMarkdown))
 
 		// Create a new extractor for main workflow markdown
@@ -314,56 +384,46 @@ func (c *Compiler) generatePrompt(yaml *strings.Builder, data *WorkflowData) {
 
 	// Step 2: Add runtime-import for main workflow markdown
 	// This allows users to edit the main workflow file without recompilation
-	workflowBasename := filepath.Base(c.markdownPath)
+	// With checkout: false the file is not in the workspace, and with inlined imports the
+	// .github folder may not be available at runtime, so the body is inlined instead
+	if inlineMainMarkdown {
+		cleanedMainMarkdown := removeXMLComments(data.MainWorkflowMarkdown)
+		cleanedMainMarkdown = wrapExpressionsInTemplateConditionals(cleanedMainMarkdown)
 
-	// Determine the directory path relative to workspace root
-	// For a workflow at ".github/workflows/test.md", the runtime-import path should be ".github/workflows/test.md"
-	// This makes the path explicit and matches the actual file location in the repository
-	var workflowFilePath string
-	if strings.Contains(c.markdownPath, ".github") {
-		// Extract everything from ".github/" onwards (inclusive)
-		githubIndex := strings.Index(c.markdownPath, ".github")
-		if githubIndex != -1 {
-			workflowFilePath = c.markdownPath[githubIndex:]
-		} else {
-			// Fallback
-			workflowFilePath = workflowBasename
+		mainExtractor := NewExpressionExtractor()
+		mainExprMappings, err := mainExtractor.ExtractExpressions(cleanedMainMarkdown)
+		if err == nil && len(mainExprMappings) > 0 {
+			cleanedMainMarkdown = mainExtractor.ReplaceExpressionsWithEnvVars(cleanedMainMarkdown)
+			expressionMappings = append(expressionMappings, mainExprMappings...)
 		}
-	} else {
-		// For non-standard paths (like /tmp/test.md), just use the basename
-		workflowFilePath = workflowBasename
-	}
 
-	// Normalize to Unix paths (forward slashes) for cross-platform compatibility
-	workflowFilePath = filepath.ToSlash(workflowFilePath)
-
-	// Create a runtime-import macro for the main workflow markdown
-	// The runtime_import.cjs helper will extract and process the markdown body at runtime
-	// The path uses .github/ prefix for clarity (e.g., .github/workflows/test.md)
-	runtimeImportMacro := fmt.Sprintf("{{#runtime-import %s}}", workflowFilePath)
-	compilerYamlLog.Printf("Using runtime-import for main workflow markdown: %s", workflowFilePath)
+		mainChunks := splitContentIntoChunks(cleanedMainMarkdown)
+		userPromptChunks = append(userPromptChunks, mainChunks...)
+		compilerYamlLog.Printf("Inlined main workflow markdown in %d chunks", len(mainChunks))
+	} else {
+		workflowFilePath := workflowSourcePath(c.markdownPath)
 
-	// Append runtime-import macro after imported chunks
-	userPromptChunks = append(userPromptChunks, runtimeImportMacro)
+		// Create a runtime-import macro for the main workflow markdown
+		// The runtime_import.cjs helper will extract and process the markdown body at runtime
+		// The path uses .github/ prefix for clarity (e.g., .github/workflows/test.md)
+		runtimeImportMacro := fmt.Sprintf("{{#runtime-import %s}}", workflowFilePath)
+		compilerYamlLog.Printf("Using runtime-import for main workflow markdown: %s", workflowFilePath)
 
-	// Generate a single unified prompt creation step
-	c.generateUnifiedPromptCreationStep(yaml, builtinSections, userPromptChunks, expressionMappings, data)
-
-	// Add combined interpolation and template rendering step
-	c.generateInterpolationAndTemplateStep(yaml, expressionMappings, data)
+		// Append runtime-import macro after imported chunks
+		userPromptChunks = append(userPromptChunks, runtimeImportMacro)
+	}
 
-	// Validate that all placeholders have been substituted
-	yaml.WriteString("      - name: Validate prompt placeholders\n")
-	yaml.WriteString("        env:\n")
-	yaml.WriteString("          GH_AW_PROMPT: /tmp/gh-aw/aw-prompts/prompt.txt\n")
-	yaml.WriteString("        run: bash /opt/gh-aw/actions/validate_prompt_placeholders.sh\n")
+	// Step 3: Append engine.prompt-suffix after the main workflow content
+	if data.EngineConfig != nil && data.EngineConfig.PromptSuffix != "" {
+		suffixChunks, suffixMappings := buildPromptAffixChunks(data.EngineConfig.PromptSuffix)
+		userPromptChunks = append(userPromptChunks, suffixChunks...)
+		expressionMappings = append(expressionMappings, suffixMappings...)
+		compilerYamlLog.Printf("Added engine prompt suffix in %d chunks", len(suffixChunks))
+	}
 
-	// Print prompt (merged into prompt generation)
-	yaml.WriteString("      - name: Print prompt\n")
-	yaml.WriteString("        env:\n")
-	yaml.WriteString("          GH_AW_PROMPT: /tmp/gh-aw/aw-prompts/prompt.txt\n")
-	yaml.WriteString("        run: bash /opt/gh-aw/actions/print_prompt_summary.sh\n")
+	return userPromptChunks, expressionMappings
 }
+
 func (c *Compiler) generatePostSteps(yaml *strings.Builder, data *WorkflowData) {
 	if data.PostSteps != "" {
 		// Remove "post-steps:" line and adjust indentation, similar to CustomSteps processing
@@ -578,7 +638,12 @@ func (c *Compiler) generateOutputCollectionStep(yaml *strings.Builder, data *Wor
 	yaml.WriteString("        env:\n")
 	yaml.WriteString("          GH_AW_SAFE_OUTPUTS: ${{ env.GH_AW_SAFE_OUTPUTS }}\n")
 
-	// Config is written to file, not passed as env var
+	// Config is written to file, not passed as env var; max expressions are passed as env vars
+	if data.SafeOutputs != nil {
+		for _, line := range renderSafeOutputsMaxEnvVars(data.SafeOutputs) {
+			yaml.WriteString(line)
+		}
+	}
 
 	// Add allowed domains configuration for sanitization
 	// Use manually configured domains if available, otherwise compute from network configuration
@@ -179,6 +179,7 @@ func (c *Compiler) generateCheckoutActionsFolder(data *WorkflowData) []string {
 // - action-tag feature is specified (uses remote actions instead)
 // - full repository checkout will be performed (redundant to checkout .github separately)
 // - no contents permission (checkout not possible)
+// - checkout: false is set and nothing reads repository files at runtime
 func (c *Compiler) generateCheckoutGitHubFolder(data *WorkflowData) []string {
 	// Check if action-tag is specified - if so, skip checkout
 	if data != nil && data.Features != nil {
@@ -190,6 +191,12 @@ func (c *Compiler) generateCheckoutGitHubFolder(data *WorkflowData) []string {
 		}
 	}
 
+	// checkout: false skips every repository checkout in the agent job
+	if data != nil && isAgentCheckoutSkipped(data) {
+		compilerYamlLog.Print("Skipping .github and .agents checkout: checkout disabled in frontmatter")
+		return nil
+	}
+
 	// Check if we have contents permission - without it, checkout is not possible
 	permParser := NewPermissionsParser(data.Permissions)
 	if !permParser.HasContentsReadAccess() {
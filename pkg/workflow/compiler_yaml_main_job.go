@@ -179,11 +179,18 @@ func (c *Compiler) generateMainJobSteps(yaml *strings.Builder, data *WorkflowDat
 	generateRepoMemorySteps(yaml, data)
 
 	// Configure git credentials for agentic workflows
-	gitConfigSteps := c.generateGitConfigurationSteps()
-	for _, line := range gitConfigSteps {
-		yaml.WriteString(line)
+	// Without a checkout there is no git remote to authenticate
+	checkoutSkipped := isAgentCheckoutSkipped(data)
+	if !checkoutSkipped {
+		gitConfigSteps := c.generateGitConfigurationSteps()
+		for _, line := range gitConfigSteps {
+			yaml.WriteString(line)
+		}
 	}
 
+	// Read engine.env-file values before the PR branch checkout can change the workspace
+	generateEngineEnvFilesStep(yaml, data)
+
 	// Add step to checkout PR branch if the event is pull_request
 	c.generatePRReadyForReviewCheckout(yaml, data)
 
@@ -223,8 +230,13 @@ func (c *Compiler) generateMainJobSteps(yaml *strings.Builder, data *WorkflowDat
 	// Add GitHub MCP app token minting step if configured
 	c.generateGitHubMCPAppTokenMintingStep(yaml, data)
 
+	// Add GitHub MCP read-only token verification step if read-only-enforcement is enabled
+	c.generateGitHubMCPReadOnlyTokenVerificationStep(yaml, data)
+
 	// Add MCP setup
-	c.generateMCPSetup(yaml, data.Tools, engine, data)
+	if err := c.generateMCPSetup(yaml, data.Tools, engine, data); err != nil {
+		return err
+	}
 
 	// Stop-time safety checks are now handled by a dedicated job (stop_time_check)
 	// No longer generated in the main job steps
@@ -262,9 +274,11 @@ func (c *Compiler) generateMainJobSteps(yaml *strings.Builder, data *WorkflowDat
 	// Regenerate git credentials after agent execution
 	// This allows safe-outputs operations (like create_pull_request) to work properly
 	// We regenerate the credentials rather than restoring from backup
-	gitConfigStepsAfterAgent := c.generateGitConfigurationSteps()
-	for _, line := range gitConfigStepsAfterAgent {
-		yaml.WriteString(line)
+	if !checkoutSkipped {
+		gitConfigStepsAfterAgent := c.generateGitConfigurationSteps()
+		for _, line := range gitConfigStepsAfterAgent {
+			yaml.WriteString(line)
+		}
 	}
 
 	// Collect firewall logs BEFORE secret redaction so secrets in logs can be redacted
@@ -0,0 +1,81 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompileWorkflowConclusionJobToggle(t *testing.T) {
+	tests := []struct {
+		name             string
+		conclusion       string
+		expectConclusion bool
+	}{
+		{name: "present by default", conclusion: "", expectConclusion: true},
+		{name: "explicitly enabled", conclusion: "  conclusion: true\n", expectConclusion: true},
+		{name: "disabled", conclusion: "  conclusion: false\n", expectConclusion: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := testutil.TempDir(t, "conclusion-toggle-test")
+			content := "---\non: workflow_dispatch\npermissions:\n  contents: read\nengine: copilot\nsafe-outputs:\n" + tt.conclusion + "  create-issue:\n---\n\n# Test Workflow\n"
+			testFile := filepath.Join(tmpDir, "conclusion.md")
+			require.NoError(t, os.WriteFile(testFile, []byte(content), 0644), "Failed to write test file")
+
+			require.NoError(t, NewCompiler().CompileWorkflow(testFile), "Workflow should compile")
+
+			lockContent, err := os.ReadFile(filepath.Join(tmpDir, "conclusion.lock.yml"))
+			require.NoError(t, err, "Lock file should be written")
+			lock := string(lockContent)
+
+			assert.Contains(t, lock, "\n  safe_outputs:\n", "Safe outputs job should always be generated")
+			if tt.expectConclusion {
+				assert.Contains(t, lock, "\n  conclusion:\n", "Conclusion job should be generated")
+			} else {
+				assert.NotContains(t, lock, "\n  conclusion:\n", "Conclusion job should not be generated when disabled")
+			}
+		})
+	}
+}
+
+func TestCompileWorkflowConclusionDisabledDropsDependency(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "conclusion-needs-test")
+	content := `---
+on: workflow_dispatch
+permissions:
+  contents: read
+engine: copilot
+safe-outputs:
+  conclusion: false
+  create-issue:
+jobs:
+  report:
+    needs: [agent, conclusion]
+    runs-on: ubuntu-latest
+    steps:
+      - run: echo done
+---
+
+# Test Workflow
+`
+	testFile := filepath.Join(tmpDir, "conclusion-needs.md")
+	require.NoError(t, os.WriteFile(testFile, []byte(content), 0644), "Failed to write test file")
+
+	require.NoError(t, NewCompiler().CompileWorkflow(testFile), "Workflow should compile without the conclusion job")
+
+	lockContent, err := os.ReadFile(filepath.Join(tmpDir, "conclusion-needs.lock.yml"))
+	require.NoError(t, err, "Lock file should be written")
+	lock := string(lockContent)
+
+	assert.NotContains(t, lock, "\n  conclusion:\n", "Conclusion job should not be generated")
+	assert.NotContains(t, lock, "- conclusion", "Custom job should drop its dependency on the conclusion job")
+	assert.Contains(t, lock, "\n  report:\n", "Custom job should still be generated")
+}
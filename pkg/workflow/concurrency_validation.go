@@ -7,12 +7,13 @@
 //
 // # Validation Functions
 //
-//   - validateConcurrencyGroupExpression() - Validates syntax of a single group expression
+//   - ValidateConcurrencyExpression() - Validates syntax of a single group expression
 //   - extractGroupExpression() - Extracts group value from concurrency configuration
 //
 // # Validation Coverage
 //
 // The validation detects common syntactic errors at compile time:
+//   - Empty expressions
 //   - Unbalanced ${{ }} braces
 //   - Missing closing braces
 //   - Malformed GitHub Actions expressions
@@ -38,15 +39,19 @@ import (
 
 var concurrencyValidationLog = logger.New("workflow:concurrency_validation")
 
-// validateConcurrencyGroupExpression validates the syntax of a custom concurrency group expression.
+// ValidateConcurrencyExpression validates the syntax of a custom concurrency group expression.
 // It checks for common syntactic errors that would cause runtime failures:
+//   - Empty expressions
 //   - Unbalanced ${{ }} braces
 //   - Missing closing braces
-//   - Malformed GitHub Actions expressions
+//   - Malformed GitHub Actions expressions (unbalanced parentheses or quotes)
 //   - Invalid operator placement
 //
+// It is used by both the workflow-level and engine-level concurrency validation during
+// compilation, and is exported so callers can lint concurrency values without compiling.
+//
 // Returns an error if validation fails, nil otherwise.
-func validateConcurrencyGroupExpression(group string) error {
+func ValidateConcurrencyExpression(group string) error {
 	if strings.TrimSpace(group) == "" {
 		return NewValidationError(
 			"concurrency",
@@ -222,7 +222,7 @@ func TestValidateConcurrencyGroupExpression(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := validateConcurrencyGroupExpression(tt.group)
+			err := ValidateConcurrencyExpression(tt.group)
 
 			if tt.wantErr {
 				require.Error(t, err, "Test case: %s - Expected error but got nil", tt.description)
@@ -593,7 +593,7 @@ func TestValidateConcurrencyGroupExpressionRealWorld(t *testing.T) {
 
 	for _, tt := range realWorldExpressions {
 		t.Run(tt.name, func(t *testing.T) {
-			err := validateConcurrencyGroupExpression(tt.group)
+			err := ValidateConcurrencyExpression(tt.group)
 			assert.NoError(t, err, "Real-world pattern should be valid: %s - %s", tt.group, tt.description)
 		})
 	}
@@ -641,7 +641,7 @@ func TestValidateConcurrencyGroupExpressionErrorMessages(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := validateConcurrencyGroupExpression(tt.group)
+			err := ValidateConcurrencyExpression(tt.group)
 			require.Error(t, err, tt.description)
 
 			errorMsg := err.Error()
@@ -684,7 +684,7 @@ func TestValidateConcurrencyGroupExpressionWithComplexExpressions(t *testing.T)
 
 	for _, tt := range complexExpressions {
 		t.Run(tt.name, func(t *testing.T) {
-			err := validateConcurrencyGroupExpression(tt.group)
+			err := ValidateConcurrencyExpression(tt.group)
 			assert.NoError(t, err, "Complex expression should be valid: %s - %s", tt.group, tt.description)
 		})
 	}
@@ -702,7 +702,7 @@ func BenchmarkValidateConcurrencyGroupExpression(b *testing.B) {
 	for _, tc := range testCases {
 		b.Run(tc, func(b *testing.B) {
 			for i := 0; i < b.N; i++ {
-				_ = validateConcurrencyGroupExpression(tc)
+				_ = ValidateConcurrencyExpression(tc)
 			}
 		})
 	}
@@ -816,3 +816,37 @@ func TestExtractConcurrencyGroupFromYAML(t *testing.T) {
 		})
 	}
 }
+
+// TestValidateConcurrencyExpressionMirrorsIntegrationCases validates the concurrency values
+// from TestConcurrencyGroupValidationIntegration directly, without compiling a workflow
+func TestValidateConcurrencyExpressionMirrorsIntegrationCases(t *testing.T) {
+	tests := []struct {
+		name        string
+		expr        string
+		errorSubstr string // Empty when the expression is valid
+	}{
+		{name: "valid workflow-level concurrency string", expr: "my-workflow-${{ github.ref }}"},
+		{name: "valid workflow-level concurrency object", expr: "pr-${{ github.event.pull_request.number || github.ref }}"},
+		{name: "valid engine-level concurrency string", expr: "copilot-${{ github.workflow }}"},
+		{name: "valid engine-level concurrency object", expr: "copilot-${{ github.workflow }}-${{ github.ref }}"},
+		{name: "complex valid expression", expr: "workflow-${{ (github.workflow || github.ref) && github.repository }}"},
+		{name: "unclosed braces", expr: "workflow-${{ github.ref", errorSubstr: "unclosed expression braces"},
+		{name: "empty expression", expr: "workflow-${{}}", errorSubstr: "empty expression content"},
+		{name: "unbalanced parentheses", expr: "workflow-${{ (github.workflow }}", errorSubstr: "unclosed parentheses"},
+		{name: "engine-level unclosed braces", expr: "copilot-${{ github.workflow", errorSubstr: "unclosed expression braces"},
+		{name: "malformed operators", expr: "copilot-${{ github.workflow && && github.ref }}", errorSubstr: "invalid expression syntax"},
+		{name: "empty group", expr: "   ", errorSubstr: "empty concurrency group expression"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateConcurrencyExpression(tt.expr)
+			if tt.errorSubstr == "" {
+				assert.NoError(t, err, "Expression should be valid: %s", tt.expr)
+				return
+			}
+			require.Error(t, err, "Expression should be invalid: %s", tt.expr)
+			assert.Contains(t, err.Error(), tt.errorSubstr, "Error should match the integration test expectation")
+		})
+	}
+}
@@ -37,6 +37,7 @@ package workflow
 
 import (
 	"fmt"
+	"maps"
 
 	"github.com/github/gh-aw/pkg/logger"
 	"github.com/goccy/go-yaml"
@@ -215,6 +216,29 @@ func preprocessExpiresField(configData map[string]any, log *logger.Logger) bool
 	return expiresDisabled
 }
 
+// preprocessMinIntervalField normalizes the min-interval field to hours and updates
+// configData["min-interval"] in place. It accepts the same formats as expires
+// (integer days or relative time like "12h", "1d", "1w"). Invalid values are set to -1
+// so validateCreateIssueMinInterval can reject them.
+func preprocessMinIntervalField(configData map[string]any, log *logger.Logger) {
+	if configData == nil {
+		return
+	}
+	minInterval, exists := configData["min-interval"]
+	if !exists {
+		return
+	}
+
+	hours := parseRelativeDurationValue(minInterval)
+	if hours <= 0 {
+		hours = -1
+	}
+	configData["min-interval"] = hours
+	if log != nil {
+		log.Printf("Parsed min-interval value %v to %d hours", minInterval, hours)
+	}
+}
+
 // ParseIntFromConfig is a generic helper that extracts and validates an integer value from a map.
 // Supports int, int64, float64, and uint64 types.
 // Returns the integer value, or 0 if not present or invalid.
@@ -270,6 +294,9 @@ func ParseBoolFromConfig(m map[string]any, key string, log *logger.Logger) bool
 // 2. Marshals it to YAML bytes (preserving structure)
 // 3. Unmarshals the YAML into the typed struct (using struct tags for field mapping)
 // 4. Validates that all fields are properly typed
+//
+// A max given as a GitHub Actions expression cannot be decoded into an int, so it is
+// removed before unmarshaling and stored in the target's MaxExpression field.
 func unmarshalConfig(m map[string]any, key string, target any, log *logger.Logger) error {
 	configData, exists := m[key]
 	if !exists {
@@ -281,6 +308,16 @@ func unmarshalConfig(m map[string]any, key string, target any, log *logger.Logge
 		configData = map[string]any{}
 	}
 
+	var maxExpression string
+	if configMap, ok := configData.(map[string]any); ok {
+		if maxStr, ok := configMap["max"].(string); ok && isSafeOutputsMaxExpression(maxStr) {
+			maxExpression = maxStr
+			configMap = maps.Clone(configMap)
+			delete(configMap, "max")
+			configData = configMap
+		}
+	}
+
 	if log != nil {
 		log.Printf("Unmarshaling config for key %q into typed struct", key)
 	}
@@ -296,6 +333,12 @@ func unmarshalConfig(m map[string]any, key string, target any, log *logger.Logge
 		return fmt.Errorf("failed to unmarshal config for %q: %w", key, err)
 	}
 
+	if maxExpression != "" {
+		if setter, ok := target.(maxExpressionSetter); ok {
+			setter.setMaxExpression(maxExpression)
+		}
+	}
+
 	if log != nil {
 		log.Printf("Successfully unmarshaled config for key %q", key)
 	}
@@ -42,6 +42,7 @@ func NewCopilotEngine() *CopilotEngine {
 			supportsToolsAllowlist: true,
 			supportsHTTPTransport:  true,  // Copilot CLI supports HTTP transport via MCP
 			supportsMaxTurns:       false, // Copilot CLI does not support max-turns feature yet
+			supportsAllowedWhen:    true,  // allowed-when gates the MCP gateway tools filter
 			supportsWebFetch:       true,  // Copilot CLI has built-in web-fetch support
 			supportsWebSearch:      false, // Copilot CLI does not have built-in web-search support
 			supportsFirewall:       true,  // Copilot supports network firewalling via AWF
@@ -84,6 +85,12 @@ func (e *CopilotEngine) GetRequiredSecretNames(workflowData *WorkflowData) []str
 		copilotLog.Printf("Added %d HTTP MCP header secrets", len(headerSecrets))
 	}
 
+	// Add HTTP MCP OAuth client secret names
+	oauthSecrets := collectHTTPMCPOAuthSecrets(workflowData.Tools)
+	for varName := range oauthSecrets {
+		secrets = append(secrets, varName)
+	}
+
 	// Add safe-inputs secret names
 	if IsSafeInputsEnabled(workflowData.SafeInputs, workflowData) {
 		safeInputsSecrets := collectSafeInputsSecrets(workflowData.SafeInputs)
@@ -99,6 +106,12 @@ func (e *CopilotEngine) GetRequiredSecretNames(workflowData *WorkflowData) []str
 	return secrets
 }
 
+// GetRequiredVarNames returns the repository variables read by the Copilot engine
+// The default model variable is only read when engine.model is not set
+func (e *CopilotEngine) GetRequiredVarNames(workflowData *WorkflowData) []string {
+	return getModelVarNames(workflowData, constants.EnvVarModelAgentCopilot, constants.EnvVarModelDetectionCopilot)
+}
+
 // GetInstallationSteps is implemented in copilot_engine_installation.go
 
 func (e *CopilotEngine) GetDeclaredOutputFiles() []string {
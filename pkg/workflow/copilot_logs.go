@@ -48,10 +48,13 @@ func (e *CopilotEngine) parseSessionJSONL(logContent string, verbose bool) (LogM
 	var totalTokenUsage int
 	toolCallMap := make(map[string]*ToolCallInfo)
 	var currentSequence []string
+	var currentSequenceTurn int // Assistant entry in which the current sequence started
 	turns := 0
+	assistantEntries := 0
 
 	lines := strings.Split(logContent, "\n")
 	foundSessionEntry := false
+	foundResult := false
 
 	for _, line := range lines {
 		trimmedLine := strings.TrimSpace(line)
@@ -62,6 +65,7 @@ func (e *CopilotEngine) parseSessionJSONL(logContent string, verbose bool) (LogM
 		}
 
 		// Try to parse as session entry
+		// A run killed mid-stream can leave a half-written last line, which is skipped here
 		var entry SessionEntry
 		if err := json.Unmarshal([]byte(trimmedLine), &entry); err != nil {
 			continue
@@ -78,6 +82,7 @@ func (e *CopilotEngine) parseSessionJSONL(logContent string, verbose bool) (LogM
 			}
 
 		case "assistant":
+			assistantEntries++
 			// Assistant message with potential tool calls
 			if entry.Message != nil {
 				for _, content := range entry.Message.Content {
@@ -85,6 +90,9 @@ func (e *CopilotEngine) parseSessionJSONL(logContent string, verbose bool) (LogM
 						toolName := content.Name
 
 						// Track in sequence
+						if len(currentSequence) == 0 {
+							currentSequenceTurn = assistantEntries
+						}
 						currentSequence = append(currentSequence, toolName)
 
 						// Calculate input size
@@ -140,6 +148,7 @@ func (e *CopilotEngine) parseSessionJSONL(logContent string, verbose bool) (LogM
 			}
 
 		case "result":
+			foundResult = true
 			// Result entry with usage statistics
 			if entry.Usage != nil {
 				totalTokenUsage = entry.Usage.InputTokens + entry.Usage.OutputTokens
@@ -158,9 +167,17 @@ func (e *CopilotEngine) parseSessionJSONL(logContent string, verbose bool) (LogM
 		return metrics, false
 	}
 
+	// Without the final result entry (e.g. the run was killed), token usage and turns are lost.
+	// Estimate turns from the assistant entries and mark the metrics as partial.
+	if !foundResult {
+		turns = assistantEntries
+		metrics.Truncated = true
+		copilotLogsLog.Printf("No result entry found, session log is truncated: estimated turns=%d", turns)
+	}
+
 	// Save current sequence before finalizing
 	if len(currentSequence) > 0 {
-		metrics.ToolSequences = append(metrics.ToolSequences, currentSequence)
+		metrics.AddToolSequence(currentSequence, currentSequenceTurn)
 	}
 
 	// Finalize metrics
@@ -168,11 +185,12 @@ func (e *CopilotEngine) parseSessionJSONL(logContent string, verbose bool) (LogM
 		totalTokenUsage, turns, len(toolCallMap))
 
 	FinalizeToolMetrics(FinalizeToolMetricsOptions{
-		Metrics:         &metrics,
-		ToolCallMap:     toolCallMap,
-		CurrentSequence: currentSequence,
-		Turns:           turns,
-		TokenUsage:      totalTokenUsage,
+		Metrics:             &metrics,
+		ToolCallMap:         toolCallMap,
+		CurrentSequence:     currentSequence,
+		CurrentSequenceTurn: currentSequenceTurn,
+		Turns:               turns,
+		TokenUsage:          totalTokenUsage,
 	})
 
 	return metrics, true
@@ -210,6 +228,7 @@ func (e *CopilotEngine) ParseLogMetrics(logContent string, verbose bool) LogMetr
 	lines := strings.Split(logContent, "\n")
 	toolCallMap := make(map[string]*ToolCallInfo) // Track tool calls
 	var currentSequence []string                  // Track tool sequence
+	var currentSequenceTurn int                   // Turn in which the current sequence started
 	turns := 0
 
 	// Track multi-line JSON blocks for token extraction
@@ -287,13 +306,16 @@ func (e *CopilotEngine) ParseLogMetrics(logContent string, verbose bool) LogMetr
 			turns++
 			// Start of a new turn, save previous sequence if any
 			if len(currentSequence) > 0 {
-				metrics.ToolSequences = append(metrics.ToolSequences, currentSequence)
+				metrics.AddToolSequence(currentSequence, currentSequenceTurn)
 				currentSequence = []string{}
 			}
 		}
 
 		// Extract tool calls and add to sequence (adjust based on actual Copilot CLI output format)
 		if toolName := e.parseCopilotToolCallsWithSequence(line, toolCallMap); toolName != "" {
+			if len(currentSequence) == 0 {
+				currentSequenceTurn = turns
+			}
 			currentSequence = append(currentSequence, toolName)
 		}
 	}
@@ -321,11 +343,12 @@ func (e *CopilotEngine) ParseLogMetrics(logContent string, verbose bool) LogMetr
 	// Finalize metrics using shared helper
 	copilotLogsLog.Printf("Finalized metrics: totalTokenUsage=%d, turns=%d, toolCalls=%d", totalTokenUsage, turns, len(toolCallMap))
 	FinalizeToolMetrics(FinalizeToolMetricsOptions{
-		Metrics:         &metrics,
-		ToolCallMap:     toolCallMap,
-		CurrentSequence: currentSequence,
-		Turns:           turns,
-		TokenUsage:      totalTokenUsage,
+		Metrics:             &metrics,
+		ToolCallMap:         toolCallMap,
+		CurrentSequence:     currentSequence,
+		CurrentSequenceTurn: currentSequenceTurn,
+		Turns:               turns,
+		TokenUsage:          totalTokenUsage,
 	})
 
 	return metrics
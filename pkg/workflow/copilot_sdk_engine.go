@@ -18,6 +18,8 @@ package workflow
 import (
 	"encoding/json"
 	"fmt"
+	"maps"
+	"slices"
 	"strings"
 
 	"github.com/github/gh-aw/pkg/constants"
@@ -43,6 +45,10 @@ func NewCopilotSDKEngine() *CopilotSDKEngine {
 			supportsToolsAllowlist: true,
 			supportsHTTPTransport:  true,
 			supportsMaxTurns:       false,
+			supportsMaxTokens:      true, // Token budget is enforced by the SDK client via GH_AW_COPILOT_CONFIG
+			supportsMaxCost:        true, // Cost budget is enforced by the SDK client via GH_AW_COPILOT_CONFIG
+			supportsCallTimeout:    true, // Per-call tool timeout is passed to the SDK client via GH_AW_COPILOT_CONFIG
+			supportsAllowedWhen:    true, // Uses the Copilot MCP gateway config, which gates the tools filter
 			supportsWebFetch:       true,
 			supportsWebSearch:      false,
 			supportsFirewall:       false, // SDK mode doesn't use firewall/sandbox
@@ -88,6 +94,12 @@ func (e *CopilotSDKEngine) GetRequiredSecretNames(workflowData *WorkflowData) []
 		copilotSDKLog.Printf("Added %d HTTP MCP header secrets", len(headerSecrets))
 	}
 
+	// Add HTTP MCP OAuth client secret names
+	oauthSecrets := collectHTTPMCPOAuthSecrets(workflowData.Tools)
+	for varName := range oauthSecrets {
+		secrets = append(secrets, varName)
+	}
+
 	copilotSDKLog.Printf("Total required secrets: %d", len(secrets))
 	return secrets
 }
@@ -169,6 +181,35 @@ func (e *CopilotSDKEngine) generateConfigurationStep(workflowData *WorkflowData)
 		}
 	}
 
+	// Add token budget if specified
+	if workflowData.EngineConfig != nil && workflowData.EngineConfig.MaxTokens > 0 {
+		config["maxTokens"] = workflowData.EngineConfig.MaxTokens
+	}
+
+	// Add cost budget if specified (0 means unlimited, so the key is omitted)
+	if workflowData.EngineConfig != nil && workflowData.EngineConfig.MaxCost > 0 {
+		config["maxCostUsd"] = workflowData.EngineConfig.MaxCost
+	}
+
+	// Add token pricing if specified (used to estimate cost when usage events do not report it)
+	if workflowData.EngineConfig != nil && workflowData.EngineConfig.Pricing != nil {
+		config["pricingPerMillionTokens"] = workflowData.EngineConfig.Pricing
+	}
+
+	// Add per-call tool timeout if specified (independent of the MCP server startup timeout)
+	if workflowData.EngineConfig != nil && workflowData.EngineConfig.ToolTimeoutPerCall > 0 {
+		config["toolTimeoutSeconds"] = workflowData.EngineConfig.ToolTimeoutPerCall
+	}
+
+	// Add tool timeouts if specified (tools without an override fall back to toolTimeout)
+	if workflowData.ToolsTimeout > 0 {
+		config["toolTimeout"] = workflowData.ToolsTimeout
+	}
+	if len(workflowData.ToolTimeouts) > 0 {
+		copilotSDKLog.Printf("Adding %d per-tool timeout overrides", len(workflowData.ToolTimeouts))
+		config["toolTimeouts"] = workflowData.ToolTimeouts
+	}
+
 	// Serialize configuration to JSON
 	configJSON, err := json.Marshal(config)
 	if err != nil {
@@ -201,6 +242,12 @@ func (e *CopilotSDKEngine) generateClientExecutionStep(workflowData *WorkflowDat
 	stepLines = append(stepLines, "        run: |")
 	stepLines = append(stepLines, "          # Execute copilot-client.js with Node.js")
 	stepLines = append(stepLines, "          # Configuration is read from GH_AW_COPILOT_CONFIG environment variable")
+	if workflowData.EngineConfig != nil && workflowData.EngineConfig.MaxTokens > 0 {
+		stepLines = append(stepLines, fmt.Sprintf("          # Run is aborted when token usage exceeds %d tokens (engine.max-tokens)", workflowData.EngineConfig.MaxTokens))
+	}
+	if workflowData.EngineConfig != nil && workflowData.EngineConfig.MaxCost > 0 {
+		stepLines = append(stepLines, fmt.Sprintf("          # Run is aborted when the estimated cost exceeds $%.2f (engine.max-cost)", workflowData.EngineConfig.MaxCost))
+	}
 	stepLines = append(stepLines, "          node /opt/gh-aw/copilot/copilot-client.js")
 	stepLines = append(stepLines, "          ")
 	stepLines = append(stepLines, "          # Check exit code")
@@ -212,6 +259,10 @@ func (e *CopilotSDKEngine) generateClientExecutionStep(workflowData *WorkflowDat
 	stepLines = append(stepLines, "          echo \"✓ Copilot SDK client execution completed\"")
 	stepLines = append(stepLines, "        env:")
 	stepLines = append(stepLines, "          GH_AW_COPILOT_CONFIG: ${{ env.GH_AW_COPILOT_CONFIG }}")
+	envFileEnv := engineEnvFilesStepEnv(workflowData)
+	for _, name := range slices.Sorted(maps.Keys(envFileEnv)) {
+		stepLines = append(stepLines, fmt.Sprintf("          %s: %s", name, envFileEnv[name]))
+	}
 
 	return GitHubActionStep(stepLines)
 }
@@ -3,12 +3,17 @@
 package workflow
 
 import (
+	"encoding/json"
 	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
 	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/testutil"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestCopilotSDKEngineImplementsCodingAgentEngine(t *testing.T) {
@@ -28,6 +33,9 @@ func TestCopilotSDKEngineCapabilities(t *testing.T) {
 	assert.True(t, engine.SupportsToolsAllowlist())
 	assert.True(t, engine.SupportsHTTPTransport())
 	assert.False(t, engine.SupportsMaxTurns())
+	assert.True(t, engine.SupportsMaxTokens(), "SDK client accepts a token budget")
+	assert.True(t, engine.SupportsMaxCost(), "SDK client enforces a cost budget")
+	assert.True(t, engine.SupportsCallTimeout(), "SDK client accepts a per-call tool timeout")
 	assert.True(t, engine.SupportsWebFetch())
 	assert.False(t, engine.SupportsWebSearch())
 	assert.False(t, engine.SupportsFirewall(), "SDK mode doesn't use firewall")
@@ -62,6 +70,15 @@ func TestCopilotSDKEngineGetRequiredSecretNamesWithMCP(t *testing.T) {
 	assert.Contains(t, secrets, "MCP_GATEWAY_API_KEY", "MCP servers are configured")
 }
 
+func TestCopilotSDKEngineGetRequiredVarNames(t *testing.T) {
+	engine := NewCopilotSDKEngine()
+
+	// The SDK client only uses engine.model and reads no repository variables
+	assert.Empty(t, engine.GetRequiredVarNames(&WorkflowData{SafeOutputs: &SafeOutputsConfig{}}), "Copilot SDK engine should not read repository variables")
+	step := engine.generateClientExecutionStep(&WorkflowData{SafeOutputs: &SafeOutputsConfig{}})
+	assert.NotContains(t, strings.Join(step, "\n"), "vars.", "Execution step should not pass repository variables")
+}
+
 func TestCopilotSDKEngineGetDeclaredOutputFiles(t *testing.T) {
 	engine := NewCopilotSDKEngine()
 
@@ -124,6 +141,283 @@ func TestCopilotSDKEngineGetExecutionStepsWithModel(t *testing.T) {
 	assert.Contains(t, step2, "gpt-5.1-pro")
 }
 
+func TestCopilotSDKEngineConfigurationToolTimeouts(t *testing.T) {
+	tests := []struct {
+		name             string
+		toolsTimeout     int
+		toolTimeouts     map[string]int
+		expectedDefault  any
+		expectedOverride map[string]any
+	}{
+		{
+			name:         "per-tool overrides with global default",
+			toolsTimeout: 60,
+			toolTimeouts: map[string]int{"playwright": 600, "github": 30},
+			// JSON numbers decode as float64
+			expectedDefault:  float64(60),
+			expectedOverride: map[string]any{"playwright": float64(600), "github": float64(30)},
+		},
+		{
+			name:             "per-tool overrides without global default",
+			toolTimeouts:     map[string]int{"playwright": 600},
+			expectedOverride: map[string]any{"playwright": float64(600)},
+		},
+		{
+			name:            "global timeout only",
+			toolsTimeout:    120,
+			expectedDefault: float64(120),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			engine := NewCopilotSDKEngine()
+			workflowData := &WorkflowData{
+				Name:         "test-workflow",
+				ToolsTimeout: tt.toolsTimeout,
+				ToolTimeouts: tt.toolTimeouts,
+			}
+
+			config := parseCopilotSDKConfigFromStep(t, engine.generateConfigurationStep(workflowData))
+
+			assert.Equal(t, tt.expectedDefault, config["toolTimeout"], "Global tool timeout should match")
+			if tt.expectedOverride == nil {
+				assert.NotContains(t, config, "toolTimeouts", "No per-tool overrides should be emitted")
+				return
+			}
+			assert.Equal(t, tt.expectedOverride, config["toolTimeouts"], "Per-tool overrides should be serialized")
+		})
+	}
+}
+
+func TestCopilotSDKEngineCompileWithMaxTokens(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "copilot-sdk-max-tokens-test")
+	content := `---
+on: workflow_dispatch
+permissions:
+  contents: read
+engine:
+  id: copilot-sdk
+  max-tokens: 8000
+---
+
+# Test Workflow
+`
+	testFile := filepath.Join(tmpDir, "max-tokens.md")
+	require.NoError(t, os.WriteFile(testFile, []byte(content), 0644), "Failed to write test file")
+
+	compiler := NewCompiler()
+	require.NoError(t, compiler.CompileWorkflow(testFile), "copilot-sdk should accept max-tokens")
+
+	lockContent, err := os.ReadFile(filepath.Join(tmpDir, "max-tokens.lock.yml"))
+	require.NoError(t, err, "Failed to read lock file")
+	assert.Contains(t, string(lockContent), `"maxTokens":8000`, "Config JSON should include the token budget")
+	assert.Contains(t, string(lockContent), "# Run is aborted when token usage exceeds 8000 tokens", "Execution step should document the token cap")
+}
+
+func TestMaxTokensRejectedForUnsupportedEngine(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "copilot-max-tokens-test")
+	content := `---
+on: workflow_dispatch
+permissions:
+  contents: read
+engine:
+  id: copilot
+  max-tokens: 8000
+---
+
+# Test Workflow
+`
+	testFile := filepath.Join(tmpDir, "max-tokens.md")
+	require.NoError(t, os.WriteFile(testFile, []byte(content), 0644), "Failed to write test file")
+
+	err := NewCompiler().CompileWorkflow(testFile)
+	require.Error(t, err, "copilot should reject max-tokens")
+	assert.Contains(t, err.Error(), "max-tokens not supported", "Error should explain max-tokens is unsupported")
+}
+
+func TestCopilotSDKEngineConfigurationToolTimeoutPerCall(t *testing.T) {
+	engine := NewCopilotSDKEngine()
+	workflowData := &WorkflowData{
+		Name:                "test-workflow",
+		ToolsStartupTimeout: 120,
+		EngineConfig:        &EngineConfig{ID: "copilot-sdk", ToolTimeoutPerCall: 30},
+	}
+
+	config := parseCopilotSDKConfigFromStep(t, engine.generateConfigurationStep(workflowData))
+	assert.InDelta(t, float64(30), config["toolTimeoutSeconds"], 0.0001, "Per-call tool timeout should be serialized")
+	assert.NotContains(t, config, "toolTimeout", "Per-call timeout should not be folded into the global tool timeout")
+	for key, value := range config {
+		assert.NotEqual(t, float64(120), value, "Startup timeout should not be serialized as %s", key)
+	}
+
+	config = parseCopilotSDKConfigFromStep(t, engine.generateConfigurationStep(&WorkflowData{Name: "test-workflow"}))
+	assert.NotContains(t, config, "toolTimeoutSeconds", "Per-call tool timeout should be omitted when not configured")
+}
+
+func TestValidateToolTimeoutPerCall(t *testing.T) {
+	tests := []struct {
+		name               string
+		toolTimeoutPerCall any
+		engine             CodingAgentEngine
+		errContains        string
+	}{
+		{name: "positive", toolTimeoutPerCall: 60, engine: NewCopilotSDKEngine()},
+		{name: "negative", toolTimeoutPerCall: -5, engine: NewCopilotSDKEngine(), errContains: "must be a positive number of seconds"},
+		{name: "not a number", toolTimeoutPerCall: "slow", engine: NewCopilotSDKEngine(), errContains: "must be a positive number of seconds"},
+		{name: "unsupported engine", toolTimeoutPerCall: 60, engine: NewClaudeEngine(), errContains: "tool-timeout-per-call not supported: engine 'claude'"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			frontmatter := map[string]any{
+				"engine": map[string]any{"id": tt.engine.GetID(), "tool-timeout-per-call": tt.toolTimeoutPerCall},
+			}
+			err := NewCompiler().validateToolTimeoutPerCall(frontmatter, tt.engine)
+			if tt.errContains != "" {
+				require.Error(t, err, "Invalid tool-timeout-per-call should error")
+				assert.Contains(t, err.Error(), tt.errContains, "Error message should match")
+				return
+			}
+			assert.NoError(t, err, "Valid tool-timeout-per-call should not error")
+		})
+	}
+}
+
+func TestCopilotSDKEngineCompileWithToolTimeoutPerCall(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "copilot-sdk-tool-timeout-per-call-test")
+	content := `---
+on: workflow_dispatch
+permissions:
+  contents: read
+engine:
+  id: copilot-sdk
+  tool-timeout-per-call: 45
+tools:
+  startup-timeout: 90
+---
+
+# Test Workflow
+`
+	testFile := filepath.Join(tmpDir, "tool-timeout.md")
+	require.NoError(t, os.WriteFile(testFile, []byte(content), 0644), "Failed to write test file")
+
+	require.NoError(t, NewCompiler().CompileWorkflow(testFile), "copilot-sdk should accept tool-timeout-per-call")
+
+	lockContent, err := os.ReadFile(filepath.Join(tmpDir, "tool-timeout.lock.yml"))
+	require.NoError(t, err, "Failed to read lock file")
+	assert.Contains(t, string(lockContent), `"toolTimeoutSeconds":45`, "Config JSON should include the per-call tool timeout")
+}
+
+func TestCopilotSDKEngineConfigurationMaxCost(t *testing.T) {
+	engine := NewCopilotSDKEngine()
+	workflowData := &WorkflowData{
+		Name:         "test-workflow",
+		EngineConfig: &EngineConfig{ID: "copilot-sdk", MaxCost: 2.5},
+	}
+
+	config := parseCopilotSDKConfigFromStep(t, engine.generateConfigurationStep(workflowData))
+	assert.InDelta(t, 2.5, config["maxCostUsd"], 0.0001, "Cost budget should be serialized")
+
+	config = parseCopilotSDKConfigFromStep(t, engine.generateConfigurationStep(&WorkflowData{
+		Name:         "test-workflow",
+		EngineConfig: &EngineConfig{ID: "copilot-sdk", MaxCost: 0},
+	}))
+	assert.NotContains(t, config, "maxCostUsd", "Zero cost budget means unlimited and should be omitted")
+	assert.NotContains(t, config, "pricingPerMillionTokens", "Pricing should be omitted when not configured")
+
+	config = parseCopilotSDKConfigFromStep(t, engine.generateConfigurationStep(&WorkflowData{
+		Name:         "test-workflow",
+		EngineConfig: &EngineConfig{ID: "copilot-sdk", MaxCost: 2.5, Pricing: &ModelPricing{Input: 1.25, CachedInput: 0.125, Output: 10}},
+	}))
+	assert.Equal(t, map[string]any{"input": 1.25, "cachedInput": 0.125, "output": 10.0}, config["pricingPerMillionTokens"], "Pricing should be serialized")
+}
+
+func TestValidateMaxCost(t *testing.T) {
+	tests := []struct {
+		name        string
+		maxCost     any
+		pricing     any
+		engine      CodingAgentEngine
+		errContains string
+	}{
+		{name: "positive float", maxCost: 1.75, engine: NewCopilotSDKEngine()},
+		{name: "positive integer", maxCost: 3, engine: NewCopilotSDKEngine()},
+		{name: "zero is unlimited", maxCost: 0, engine: NewClaudeEngine()},
+		{name: "negative", maxCost: -1.0, engine: NewCopilotSDKEngine(), errContains: "must be a positive amount in USD"},
+		{name: "not a number", maxCost: "cheap", engine: NewCopilotSDKEngine(), errContains: "must be a positive amount in USD"},
+		{name: "unsupported engine", maxCost: 1.0, engine: NewClaudeEngine(), errContains: "max-cost not supported: engine 'claude'"},
+		{name: "pricing", maxCost: 1.0, pricing: map[string]any{"input": 3, "cached-input": 0.3, "output": 15}, engine: NewCopilotSDKEngine()},
+		{name: "negative price", maxCost: 1.0, pricing: map[string]any{"input": -3, "cached-input": 0.3, "output": 15}, engine: NewCopilotSDKEngine(), errContains: "invalid pricing"},
+		{name: "missing price", maxCost: 1.0, pricing: map[string]any{"input": 3}, engine: NewCopilotSDKEngine(), errContains: "invalid pricing"},
+		{name: "pricing on unsupported engine", maxCost: 0, pricing: map[string]any{"input": 3, "cached-input": 0.3, "output": 15}, engine: NewClaudeEngine(), errContains: "max-cost not supported: engine 'claude'"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			engineConfig := map[string]any{"id": tt.engine.GetID(), "max-cost": tt.maxCost}
+			if tt.pricing != nil {
+				engineConfig["pricing"] = tt.pricing
+			}
+			frontmatter := map[string]any{"engine": engineConfig}
+			err := NewCompiler().validateMaxCost(frontmatter, tt.engine)
+			if tt.errContains != "" {
+				require.Error(t, err, "Invalid max-cost should error")
+				assert.Contains(t, err.Error(), tt.errContains, "Error message should match")
+				return
+			}
+			assert.NoError(t, err, "Valid max-cost should not error")
+		})
+	}
+}
+
+func TestCopilotSDKEngineCompileWithMaxCost(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "copilot-sdk-max-cost-test")
+	content := `---
+on: workflow_dispatch
+permissions:
+  contents: read
+engine:
+  id: copilot-sdk
+  max-cost: 1.5
+  pricing:
+    input: 2
+    cached-input: 0.2
+    output: 8
+---
+
+# Test Workflow
+`
+	testFile := filepath.Join(tmpDir, "max-cost.md")
+	require.NoError(t, os.WriteFile(testFile, []byte(content), 0644), "Failed to write test file")
+
+	require.NoError(t, NewCompiler().CompileWorkflow(testFile), "copilot-sdk should accept max-cost")
+
+	lockContent, err := os.ReadFile(filepath.Join(tmpDir, "max-cost.lock.yml"))
+	require.NoError(t, err, "Failed to read lock file")
+	lock := string(lockContent)
+	assert.Contains(t, lock, `"maxCostUsd":1.5`, "Config JSON should include the cost budget")
+	assert.Contains(t, lock, `"pricingPerMillionTokens":{"input":2,"cachedInput":0.2,"output":8}`, "Config JSON should include the token pricing")
+	assert.Contains(t, lock, "exceeds $1.50 (engine.max-cost)", "Execution step should note the cost budget")
+}
+
+// parseCopilotSDKConfigFromStep extracts and decodes the GH_AW_COPILOT_CONFIG JSON from the configuration step
+func parseCopilotSDKConfigFromStep(t *testing.T, step GitHubActionStep) map[string]any {
+	t.Helper()
+	for _, line := range step {
+		_, after, found := strings.Cut(line, "echo 'GH_AW_COPILOT_CONFIG=")
+		if !found {
+			continue
+		}
+		configJSON := strings.TrimSuffix(after, "' >> $GITHUB_ENV")
+		var config map[string]any
+		require.NoError(t, json.Unmarshal([]byte(configJSON), &config), "Configuration should be valid JSON")
+		return config
+	}
+	require.FailNow(t, "GH_AW_COPILOT_CONFIG not found in configuration step")
+	return nil
+}
+
 func TestCopilotSDKEngineGetInstallationSteps(t *testing.T) {
 	engine := NewCopilotSDKEngine()
 	workflowData := &WorkflowData{
@@ -132,7 +132,7 @@ func (c *Compiler) buildCreateOutputAgentSessionJob(data *WorkflowData, mainJobN
 		MainJobName:     mainJobName,
 		CustomEnvVars:   customEnvVars,
 		Script:          "const { main } = require('/opt/gh-aw/actions/create_agent_session.cjs'); await main();",
-		Permissions:     NewPermissionsContentsWriteIssuesWritePRWrite(),
+		Permissions:     NewPermissionsBuilder().Write(PermissionContents).Write(PermissionIssues).Write(PermissionPullRequests).Build(),
 		Outputs:         outputs,
 		Condition:       jobCondition,
 		PreSteps:        preSteps,
@@ -77,7 +77,7 @@ func (c *Compiler) buildCreateOutputCodeScanningAlertJob(data *WorkflowData, mai
 		MainJobName:   mainJobName,
 		CustomEnvVars: customEnvVars,
 		Script:        getCreateCodeScanningAlertScript(),
-		Permissions:   NewPermissionsContentsReadSecurityEventsWriteActionsRead(),
+		Permissions:   NewPermissionsBuilder().Read(PermissionContents).Write(PermissionSecurityEvents).Read(PermissionActions).Build(),
 		Outputs:       outputs,
 		Condition:     jobCondition,
 		PostSteps:     postSteps,
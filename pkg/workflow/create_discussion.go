@@ -21,11 +21,18 @@ type CreateDiscussionsConfig struct {
 	AllowedRepos          []string `yaml:"allowed-repos,omitempty"`           // List of additional repositories that discussions can be created in
 	CloseOlderDiscussions bool     `yaml:"close-older-discussions,omitempty"` // When true, close older discussions with same title prefix or labels as outdated
 	RequiredCategory      string   `yaml:"required-category,omitempty"`       // Required category for matching when close-older-discussions is enabled
+	Dedupe                string   `yaml:"dedupe,omitempty"`                  // Duplicate handling when an open discussion has the same title: "skip" or "comment" (empty = always create)
 	Expires               int      `yaml:"expires,omitempty"`                 // Hours until the discussion expires and should be automatically closed
 	FallbackToIssue       *bool    `yaml:"fallback-to-issue,omitempty"`       // When true (default), fallback to create-issue if discussion creation fails due to permissions
 	Footer                *bool    `yaml:"footer,omitempty"`                  // Controls whether AI-generated footer is added. When false, visible footer is omitted but XML markers are kept.
 }
 
+// Supported create-discussion dedupe strategies
+const (
+	DiscussionDedupeSkip    = "skip"    // Don't create a discussion when an open one has the same title
+	DiscussionDedupeComment = "comment" // Comment on the open discussion with the same title instead of creating one
+)
+
 // parseDiscussionsConfig handles create-discussion configuration
 func (c *Compiler) parseDiscussionsConfig(outputMap map[string]any) *CreateDiscussionsConfig {
 	// Check if the key exists
@@ -75,6 +82,12 @@ func (c *Compiler) parseDiscussionsConfig(outputMap map[string]any) *CreateDiscu
 		return nil // Invalid configuration, return nil to cause validation error
 	}
 
+	// Validate dedupe strategy
+	if config.Dedupe != "" && config.Dedupe != DiscussionDedupeSkip && config.Dedupe != DiscussionDedupeComment {
+		discussionLog.Printf("Invalid dedupe strategy: %q", config.Dedupe)
+		return nil // Invalid configuration, return nil to cause validation error
+	}
+
 	// Normalize and validate category naming convention
 	config.Category = normalizeDiscussionCategory(config.Category, discussionLog, c.markdownPath)
 
@@ -103,6 +116,9 @@ func (c *Compiler) parseDiscussionsConfig(outputMap map[string]any) *CreateDiscu
 			discussionLog.Printf("Required category for close older discussions: %q", config.RequiredCategory)
 		}
 	}
+	if config.Dedupe != "" {
+		discussionLog.Printf("Duplicate discussions by title handled with strategy: %s", config.Dedupe)
+	}
 	if config.Expires > 0 {
 		discussionLog.Printf("Discussion expiration configured: %d hours", config.Expires)
 	}
@@ -180,7 +196,7 @@ func (c *Compiler) buildCreateOutputDiscussionJob(data *WorkflowData, mainJobNam
 		MainJobName:    mainJobName,
 		CustomEnvVars:  customEnvVars,
 		Script:         getCreateDiscussionScript(),
-		Permissions:    NewPermissionsContentsReadIssuesWriteDiscussionsWrite(),
+		Permissions:    NewPermissionsBuilder().Read(PermissionContents).Write(PermissionIssues).Write(PermissionDiscussions).Build(),
 		Outputs:        outputs,
 		Needs:          needs,
 		Token:          data.SafeOutputs.CreateDiscussions.GitHubToken,
@@ -7,6 +7,7 @@ import (
 
 	"github.com/github/gh-aw/pkg/logger"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNormalizeDiscussionCategory(t *testing.T) {
@@ -198,3 +199,56 @@ func TestParseDiscussionsConfigFallbackToIssue(t *testing.T) {
 		})
 	}
 }
+
+func TestParseDiscussionsConfigDedupe(t *testing.T) {
+	tests := []struct {
+		name           string
+		config         map[string]any
+		expectNil      bool
+		expectedDedupe string
+	}{
+		{
+			name:           "dedupe not set",
+			config:         map[string]any{"title-prefix": "[report] "},
+			expectedDedupe: "",
+		},
+		{
+			name:           "skip with title prefix",
+			config:         map[string]any{"title-prefix": "[report] ", "dedupe": "skip"},
+			expectedDedupe: DiscussionDedupeSkip,
+		},
+		{
+			name:           "comment",
+			config:         map[string]any{"dedupe": "comment"},
+			expectedDedupe: DiscussionDedupeComment,
+		},
+		{
+			name:      "invalid strategy",
+			config:    map[string]any{"dedupe": "replace"},
+			expectNil: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := NewCompiler().parseDiscussionsConfig(map[string]any{"create-discussion": tt.config})
+			if tt.expectNil {
+				assert.Nil(t, result, "Invalid dedupe strategy should be rejected")
+				return
+			}
+			require.NotNil(t, result, "Expected non-nil result")
+			assert.Equal(t, tt.expectedDedupe, result.Dedupe, "Dedupe strategy mismatch")
+		})
+	}
+}
+
+func TestCreateDiscussionHandlerConfigDedupe(t *testing.T) {
+	config := handlerRegistry["create_discussion"](&SafeOutputsConfig{
+		CreateDiscussions: &CreateDiscussionsConfig{TitlePrefix: "[report] ", Dedupe: DiscussionDedupeComment},
+	})
+	assert.Equal(t, "comment", config["dedupe"], "Dedupe strategy should be passed to the handler")
+	assert.Equal(t, "[report] ", config["title_prefix"], "Title prefix should be passed alongside dedupe so duplicates are matched on the prefixed title")
+
+	config = handlerRegistry["create_discussion"](&SafeOutputsConfig{CreateDiscussions: &CreateDiscussionsConfig{}})
+	assert.NotContains(t, config, "dedupe", "Dedupe should be omitted when not configured")
+}
@@ -15,12 +15,14 @@ type CreateIssuesConfig struct {
 	Labels               []string `yaml:"labels,omitempty"`
 	AllowedLabels        []string `yaml:"allowed-labels,omitempty"`     // Optional list of allowed labels. If omitted, any labels are allowed (including creating new ones).
 	Assignees            []string `yaml:"assignees,omitempty"`          // List of users/bots to assign the issue to
+	AssignFromTeam       string   `yaml:"assign-from-team,omitempty"`   // Team ("team-slug" or "org/team-slug") whose members are assigned round-robin
 	TargetRepoSlug       string   `yaml:"target-repo,omitempty"`        // Target repository in format "owner/repo" for cross-repository issues
 	AllowedRepos         []string `yaml:"allowed-repos,omitempty"`      // List of additional repositories that issues can be created in
 	CloseOlderIssues     bool     `yaml:"close-older-issues,omitempty"` // When true, close older issues with same title prefix or labels as "not planned"
 	Expires              int      `yaml:"expires,omitempty"`            // Hours until the issue expires and should be automatically closed
 	Group                bool     `yaml:"group,omitempty"`              // If true, group issues as sub-issues under a parent issue (workflow ID is used as group identifier)
 	Footer               *bool    `yaml:"footer,omitempty"`             // Controls whether AI-generated footer is added. When false, visible footer is omitted but XML markers are kept.
+	MinInterval          int      `yaml:"min-interval,omitempty"`       // Minimum hours between issues created by this workflow (0 = no throttling)
 }
 
 // parseIssuesConfig handles create-issue configuration
@@ -38,6 +40,9 @@ func (c *Compiler) parseIssuesConfig(outputMap map[string]any) *CreateIssuesConf
 	// Pre-process the expires field (convert to hours before unmarshaling)
 	expiresDisabled := preprocessExpiresField(configData, createIssueLog)
 
+	// Pre-process the min-interval field (convert to hours before unmarshaling)
+	preprocessMinIntervalField(configData, createIssueLog)
+
 	// Unmarshal into typed config struct
 	var config CreateIssuesConfig
 	if err := unmarshalConfig(outputMap, "create-issue", &config, createIssueLog); err != nil {
@@ -73,9 +78,26 @@ func (c *Compiler) parseIssuesConfig(outputMap map[string]any) *CreateIssuesConf
 		createIssueLog.Printf("Issue expiration configured: %d hours", config.Expires)
 	}
 
+	if config.MinInterval > 0 {
+		createIssueLog.Printf("Issue creation throttled: min-interval %d hours", config.MinInterval)
+	}
+
+	if config.AssignFromTeam != "" {
+		createIssueLog.Printf("Issues assigned round-robin from team: %s", config.AssignFromTeam)
+	}
+
 	return &config
 }
 
+// validateCreateIssueMinInterval validates that create-issue min-interval, when set, is a
+// duration of at least 2 hours
+func validateCreateIssueMinInterval(safeOutputs *SafeOutputsConfig) error {
+	if safeOutputs == nil || safeOutputs.CreateIssues == nil || safeOutputs.CreateIssues.MinInterval >= 0 {
+		return nil
+	}
+	return fmt.Errorf("invalid create-issue min-interval: must be a positive number of days or a relative time of at least 2 hours. Example:\nsafe-outputs:\n  create-issue:\n    min-interval: 1d")
+}
+
 // hasCopilotAssignee checks if "copilot" is in the assignees list
 func hasCopilotAssignee(assignees []string) bool {
 	for _, a := range assignees {
@@ -220,7 +242,7 @@ func (c *Compiler) buildCreateOutputIssueJob(data *WorkflowData, mainJobName str
 		CustomEnvVars:  customEnvVars,
 		Script:         getCreateIssueScript(),
 		ScriptName:     "create_issue", // For custom action mode
-		Permissions:    NewPermissionsContentsReadIssuesWrite(),
+		Permissions:    NewPermissionsBuilder().Read(PermissionContents).Write(PermissionIssues).Build(),
 		Outputs:        outputs,
 		PostSteps:      postSteps,
 		Token:          data.SafeOutputs.CreateIssues.GitHubToken,
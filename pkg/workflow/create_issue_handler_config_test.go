@@ -285,3 +285,67 @@ Create an issue.
 		}
 	}
 }
+
+// TestCreateIssueHandlerConfigKeepsTitlePrefixTemplate verifies that title prefix tokens
+// such as {{workflow}} and {{date}} are passed to the handler config unchanged, since the
+// create_issue handler expands them at runtime
+func TestCreateIssueHandlerConfigKeepsTitlePrefixTemplate(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "handler-config-test")
+
+	testContent := `---
+name: Test Handler Config
+on: workflow_dispatch
+permissions:
+  contents: read
+engine: copilot
+safe-outputs:
+  create-issue:
+    title-prefix: "[{{workflow}} {{date}} {{unknown}}] "
+---
+
+Create an issue with title "Test" and body "Test body".
+`
+
+	testFile := filepath.Join(tmpDir, "test-handler-config.md")
+	if err := os.WriteFile(testFile, []byte(testContent), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	compiler := NewCompiler()
+	if err := compiler.CompileWorkflow(testFile); err != nil {
+		t.Fatalf("Failed to compile workflow: %v", err)
+	}
+
+	compiledContent, err := os.ReadFile(filepath.Join(tmpDir, "test-handler-config.lock.yml"))
+	if err != nil {
+		t.Fatalf("Failed to read compiled output: %v", err)
+	}
+
+	var configJSON string
+	for line := range strings.SplitSeq(string(compiledContent), "\n") {
+		if _, value, found := strings.Cut(line, "GH_AW_SAFE_OUTPUTS_HANDLER_CONFIG:"); found {
+			if err := json.Unmarshal([]byte(strings.TrimSpace(value)), &configJSON); err != nil {
+				t.Fatalf("Failed to unquote handler config: %v", err)
+			}
+			break
+		}
+	}
+	if configJSON == "" {
+		t.Fatal("Could not extract handler config JSON")
+	}
+
+	var config map[string]any
+	if err := json.Unmarshal([]byte(configJSON), &config); err != nil {
+		t.Fatalf("Failed to parse handler config JSON: %v\nJSON: %s", err, configJSON)
+	}
+
+	createIssueConfig, ok := config["create_issue"].(map[string]any)
+	if !ok {
+		t.Fatal("Expected create_issue in handler config")
+	}
+
+	expected := "[{{workflow}} {{date}} {{unknown}}] "
+	if titlePrefix := createIssueConfig["title_prefix"]; titlePrefix != expected {
+		t.Errorf("Expected title_prefix=%q in create_issue config, got: %v", expected, titlePrefix)
+	}
+}
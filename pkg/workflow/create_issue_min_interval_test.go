@@ -0,0 +1,117 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseIssuesConfigMinInterval(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    any
+		expected int
+	}{
+		{name: "integer days", value: 2, expected: 48},
+		{name: "hours string", value: "12h", expected: 12},
+		{name: "days string", value: "1d", expected: 24},
+		{name: "weeks string", value: "1w", expected: 168},
+		{name: "invalid string", value: "soon", expected: -1},
+		{name: "below two hours", value: "1h", expected: -1},
+		{name: "false", value: false, expected: -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			compiler := NewCompiler()
+			outputMap := map[string]any{
+				"create-issue": map[string]any{
+					"min-interval": tt.value,
+				},
+			}
+
+			config := compiler.parseIssuesConfig(outputMap)
+			require.NotNil(t, config, "Config should be parsed")
+			assert.Equal(t, tt.expected, config.MinInterval, "MinInterval should be normalized to hours")
+		})
+	}
+}
+
+func TestParseIssuesConfigMinIntervalDefault(t *testing.T) {
+	compiler := NewCompiler()
+	outputMap := map[string]any{
+		"create-issue": map[string]any{
+			"title-prefix": "[report] ",
+		},
+	}
+
+	config := compiler.parseIssuesConfig(outputMap)
+	require.NotNil(t, config, "Config should be parsed")
+	assert.Zero(t, config.MinInterval, "MinInterval should default to no throttling")
+}
+
+func TestCompileInvalidCreateIssueMinInterval(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "create-issue-min-interval-test")
+	content := `---
+on: workflow_dispatch
+permissions:
+  contents: read
+engine: copilot
+safe-outputs:
+  create-issue:
+    min-interval: 1h
+---
+
+# Throttled Issues
+`
+	testFile := filepath.Join(tmpDir, "throttled.md")
+	require.NoError(t, os.WriteFile(testFile, []byte(content), 0644), "Failed to write test file")
+
+	err := NewCompiler().CompileWorkflow(testFile)
+	require.Error(t, err, "Invalid min-interval should fail the compile")
+	assert.Contains(t, err.Error(), "invalid create-issue min-interval", "Error should name the invalid field")
+}
+
+func TestCreateIssueHandlerConfigMinInterval(t *testing.T) {
+	tests := []struct {
+		name        string
+		minInterval int
+		expected    string
+	}{
+		{name: "interval set", minInterval: 24, expected: `\"min_interval\":24`},
+		{name: "interval unset", minInterval: 0, expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			compiler := NewCompiler()
+			workflowData := &WorkflowData{
+				Name: "Test Workflow",
+				SafeOutputs: &SafeOutputsConfig{
+					CreateIssues: &CreateIssuesConfig{
+						BaseSafeOutputConfig: BaseSafeOutputConfig{Max: 1},
+						MinInterval:          tt.minInterval,
+					},
+				},
+			}
+
+			var steps []string
+			compiler.addHandlerManagerConfigEnvVar(&steps, workflowData)
+			require.NotEmpty(t, steps, "Handler config env var should be emitted")
+
+			joined := strings.Join(steps, "")
+			if tt.expected != "" {
+				assert.Contains(t, joined, tt.expected, "Handler config should include min_interval")
+			} else {
+				assert.NotContains(t, joined, "min_interval", "Handler config should omit min_interval when unset")
+			}
+		})
+	}
+}
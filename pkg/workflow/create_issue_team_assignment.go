@@ -0,0 +1,55 @@
+package workflow
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var createIssueTeamLog = logger.New("workflow:create_issue_team_assignment")
+
+// teamReferencePattern matches "team-slug" or "org/team-slug". Organization logins are
+// alphanumeric with single hyphens; team slugs are lowercase letters, digits, hyphens, and underscores.
+var teamReferencePattern = regexp.MustCompile(`^(?:[A-Za-z0-9](?:-?[A-Za-z0-9]){0,38}/)?[a-z0-9](?:[a-z0-9_-]*[a-z0-9])?$`)
+
+// validateCreateIssueAssignFromTeam validates the create-issue assign-from-team option.
+// It checks that:
+// 1. The value is a valid team slug ("team-slug" or "org/team-slug")
+// 2. A token that can list team members is configured (github-token or a GitHub App),
+// since the default GITHUB_TOKEN cannot read organization teams. The App token requests
+// permission-members: read (see safeOutputsAppTokenFields)
+func validateCreateIssueAssignFromTeam(safeOutputs *SafeOutputsConfig) error {
+	if safeOutputs == nil || safeOutputs.CreateIssues == nil || safeOutputs.CreateIssues.AssignFromTeam == "" {
+		return nil
+	}
+
+	team := safeOutputs.CreateIssues.AssignFromTeam
+	createIssueTeamLog.Printf("Validating assign-from-team: %s", team)
+
+	if !teamReferencePattern.MatchString(team) {
+		return fmt.Errorf("invalid safe-outputs.create-issue.assign-from-team value %q: expected a team slug such as 'triage' or 'my-org/triage'", team)
+	}
+
+	if safeOutputs.CreateIssues.GitHubToken == "" && safeOutputs.GitHubToken == "" && safeOutputs.App == nil {
+		return errors.New("safe-outputs.create-issue.assign-from-team requires a github-token or app with organization members read access, because the default GITHUB_TOKEN cannot list team members. Set safe-outputs.create-issue.github-token, safe-outputs.github-token, or safe-outputs.app")
+	}
+
+	return nil
+}
+
+// safeOutputsAppTokenFields returns the permission-* inputs for the safe outputs GitHub App
+// token. Besides the job permissions it requests organization members read access when
+// create-issue assigns from a team, since listing team members needs that permission.
+func safeOutputsAppTokenFields(safeOutputs *SafeOutputsConfig, permissions *Permissions) map[string]string {
+	fields := make(map[string]string)
+	if permissions != nil {
+		fields = convertPermissionsToAppTokenFields(permissions)
+	}
+	if safeOutputs != nil && safeOutputs.CreateIssues != nil && safeOutputs.CreateIssues.AssignFromTeam != "" {
+		createIssueTeamLog.Print("Requesting permission-members: read for the GitHub App token")
+		fields["permission-members"] = "read"
+	}
+	return fields
+}
@@ -0,0 +1,168 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseIssuesConfigAssignFromTeam(t *testing.T) {
+	compiler := NewCompiler()
+	config := compiler.parseIssuesConfig(map[string]any{
+		"create-issue": map[string]any{"assign-from-team": "my-org/triage"},
+	})
+	require.NotNil(t, config, "Config should be parsed")
+	assert.Equal(t, "my-org/triage", config.AssignFromTeam, "assign-from-team should be parsed")
+}
+
+func TestCreateIssueHandlerConfigAssignFromTeam(t *testing.T) {
+	compiler := NewCompiler()
+	workflowData := &WorkflowData{
+		Name: "Test Workflow",
+		SafeOutputs: &SafeOutputsConfig{
+			CreateIssues: &CreateIssuesConfig{
+				BaseSafeOutputConfig: BaseSafeOutputConfig{Max: 1},
+				AssignFromTeam:       "triage",
+			},
+		},
+	}
+
+	var steps []string
+	compiler.addHandlerManagerConfigEnvVar(&steps, workflowData)
+	require.NotEmpty(t, steps, "Handler config env var should be emitted")
+	assert.Contains(t, strings.Join(steps, ""), `\"assign_from_team\":\"triage\"`, "Handler config should include the round-robin team")
+
+	workflowData.SafeOutputs.CreateIssues.AssignFromTeam = ""
+	steps = nil
+	compiler.addHandlerManagerConfigEnvVar(&steps, workflowData)
+	assert.NotContains(t, strings.Join(steps, ""), "assign_from_team", "Handler config should omit assign_from_team when unset")
+}
+
+func TestValidateCreateIssueAssignFromTeam(t *testing.T) {
+	pat := "${{ secrets.TRIAGE_TOKEN }}"
+
+	tests := []struct {
+		name        string
+		safeOutputs *SafeOutputsConfig
+		wantErr     string
+	}{
+		{
+			name:        "not configured",
+			safeOutputs: &SafeOutputsConfig{CreateIssues: &CreateIssuesConfig{}},
+		},
+		{
+			name:        "team slug with safe-outputs token",
+			safeOutputs: &SafeOutputsConfig{GitHubToken: pat, CreateIssues: &CreateIssuesConfig{AssignFromTeam: "triage"}},
+		},
+		{
+			name: "org team with create-issue token",
+			safeOutputs: &SafeOutputsConfig{CreateIssues: &CreateIssuesConfig{
+				BaseSafeOutputConfig: BaseSafeOutputConfig{GitHubToken: pat},
+				AssignFromTeam:       "my-org/triage_team",
+			}},
+		},
+		{
+			name:        "team slug with app",
+			safeOutputs: &SafeOutputsConfig{App: &GitHubAppConfig{AppID: "${{ vars.APP_ID }}"}, CreateIssues: &CreateIssuesConfig{AssignFromTeam: "triage"}},
+		},
+		{
+			name:        "invalid slug",
+			safeOutputs: &SafeOutputsConfig{GitHubToken: pat, CreateIssues: &CreateIssuesConfig{AssignFromTeam: "Triage Team"}},
+			wantErr:     "invalid safe-outputs.create-issue.assign-from-team value",
+		},
+		{
+			name:        "too many path segments",
+			safeOutputs: &SafeOutputsConfig{GitHubToken: pat, CreateIssues: &CreateIssuesConfig{AssignFromTeam: "org/team/extra"}},
+			wantErr:     "invalid safe-outputs.create-issue.assign-from-team value",
+		},
+		{
+			name:        "default token cannot list team members",
+			safeOutputs: &SafeOutputsConfig{CreateIssues: &CreateIssuesConfig{AssignFromTeam: "triage"}},
+			wantErr:     "requires a github-token or app",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateCreateIssueAssignFromTeam(tt.safeOutputs)
+			if tt.wantErr == "" {
+				assert.NoError(t, err, "Configuration should be valid")
+			} else {
+				require.Error(t, err, "Configuration should be rejected")
+				assert.Contains(t, err.Error(), tt.wantErr, "Error should explain the problem")
+			}
+		})
+	}
+}
+
+func TestCompileWorkflowWithAssignFromTeam(t *testing.T) {
+	tests := []struct {
+		name    string
+		token   string
+		wantErr bool
+	}{
+		{name: "with token", token: "  github-token: ${{ secrets.TRIAGE_TOKEN }}\n", wantErr: false},
+		{name: "without token", token: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := testutil.TempDir(t, "assign-from-team-test")
+			content := "---\non: workflow_dispatch\npermissions:\n  contents: read\nengine: copilot\nsafe-outputs:\n" + tt.token + "  create-issue:\n    assign-from-team: triage\n---\n\n# Test Workflow\n"
+			testFile := filepath.Join(tmpDir, "assign-from-team.md")
+			require.NoError(t, os.WriteFile(testFile, []byte(content), 0644), "Failed to write test file")
+
+			err := NewCompiler().CompileWorkflow(testFile)
+			if tt.wantErr {
+				require.Error(t, err, "Workflow without a suitable token should fail")
+				assert.Contains(t, err.Error(), "requires a github-token or app", "Error should explain the token requirement")
+				return
+			}
+			require.NoError(t, err, "Workflow should compile")
+
+			lockContent, err := os.ReadFile(filepath.Join(tmpDir, "assign-from-team.lock.yml"))
+			require.NoError(t, err, "Lock file should be written")
+			assert.Contains(t, string(lockContent), `\"assign_from_team\":\"triage\"`, "Handler config should carry the team")
+		})
+	}
+}
+
+func TestCompileWorkflowWithAssignFromTeamAppToken(t *testing.T) {
+	tests := []struct {
+		name            string
+		createIssue     string
+		wantMembersRead bool
+	}{
+		{name: "assign-from-team requests members read", createIssue: "  create-issue:\n    assign-from-team: triage\n", wantMembersRead: true},
+		{name: "no team assignment", createIssue: "  create-issue:\n", wantMembersRead: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := testutil.TempDir(t, "assign-from-team-app-test")
+			content := "---\non: workflow_dispatch\npermissions:\n  contents: read\nengine: copilot\nsafe-outputs:\n  app:\n    app-id: ${{ vars.APP_ID }}\n    private-key: ${{ secrets.APP_PRIVATE_KEY }}\n" + tt.createIssue + "---\n\n# Test Workflow\n"
+			testFile := filepath.Join(tmpDir, "assign-from-team-app.md")
+			require.NoError(t, os.WriteFile(testFile, []byte(content), 0644), "Failed to write test file")
+
+			require.NoError(t, NewCompiler().CompileWorkflow(testFile), "Workflow should compile")
+
+			lockContent, err := os.ReadFile(filepath.Join(tmpDir, "assign-from-team-app.lock.yml"))
+			require.NoError(t, err, "Lock file should be written")
+			lock := string(lockContent)
+			require.Contains(t, lock, "id: safe-outputs-app-token", "Lock file should mint the App token")
+			if tt.wantMembersRead {
+				assert.Contains(t, lock, "permission-members: read", "App token should request members read access")
+				assert.Less(t, strings.Index(lock, "permission-issues: write"), strings.Index(lock, "permission-members: read"), "Permission inputs should stay sorted")
+			} else {
+				assert.NotContains(t, lock, "permission-members", "App token should not request members access without a team")
+			}
+		})
+	}
+}
@@ -74,7 +74,7 @@ func (c *Compiler) buildCreateOutputPullRequestReviewCommentJob(data *WorkflowDa
 		MainJobName:    mainJobName,
 		CustomEnvVars:  customEnvVars,
 		Script:         getCreatePRReviewCommentScript(),
-		Permissions:    NewPermissionsContentsReadPRWrite(),
+		Permissions:    NewPermissionsBuilder().Read(PermissionContents).Write(PermissionPullRequests).Build(),
 		Outputs:        outputs,
 		Condition:      jobCondition,
 		Token:          data.SafeOutputs.CreatePullRequestReviewComments.GitHubToken,
@@ -171,11 +171,11 @@ func (c *Compiler) buildCreateOutputPullRequestJob(data *WorkflowData, mainJobNa
 	var permissions *Permissions
 	if fallbackAsIssue {
 		// Default: include issues: write for fallback behavior
-		permissions = NewPermissionsContentsWriteIssuesWritePRWrite()
+		permissions = NewPermissionsBuilder().Write(PermissionContents).Write(PermissionIssues).Write(PermissionPullRequests).Build()
 		createPRLog.Print("Using permissions with issues:write (fallback-as-issue enabled)")
 	} else {
 		// Fallback disabled: only need contents: write and pull-requests: write
-		permissions = NewPermissionsContentsWritePRWrite()
+		permissions = NewPermissionsBuilder().Write(PermissionContents).Write(PermissionPullRequests).Build()
 		createPRLog.Print("Using permissions without issues:write (fallback-as-issue disabled)")
 	}
 
@@ -26,6 +26,7 @@ func NewCustomEngine() *CustomEngine {
 			supportsMaxTurns:       true,  // Custom engine supports max-turns for consistency
 			supportsWebFetch:       false, // Custom engine does not have built-in web-fetch support
 			supportsWebSearch:      false, // Custom engine does not have built-in web-search support
+			supportsBashShell:      true,  // User-defined steps receive the selected shell through SHELL
 			supportsLLMGateway:     false, // Custom engine does not support LLM gateway
 		},
 	}
@@ -106,6 +107,9 @@ func (e *CustomEngine) GetExecutionSteps(workflowData *WorkflowData, logFile str
 						envVars[key] = value
 					}
 				}
+				for key, value := range engineEnvFilesStepEnv(workflowData) {
+					envVars[key] = value
+				}
 
 				// Merge environment variables into the step
 				if len(envVars) > 0 {
@@ -189,6 +193,9 @@ func (e *CustomEngine) GetExecutionSteps(workflowData *WorkflowData, logFile str
 					envVars[key] = value
 				}
 			}
+			for key, value := range engineEnvFilesStepEnv(workflowData) {
+				envVars[key] = value
+			}
 
 			// Merge environment variables into the step
 			if len(envVars) > 0 {
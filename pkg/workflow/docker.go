@@ -194,7 +194,7 @@ func generateDownloadDockerImagesStep(yaml *strings.Builder, dockerImages []stri
 	yaml.WriteString("      - name: Download container images\n")
 	yaml.WriteString("        run: bash /opt/gh-aw/actions/download_docker_images.sh")
 	for _, image := range dockerImages {
-		fmt.Fprintf(yaml, " %s", image)
+		fmt.Fprintf(yaml, " %s", shellQuoteArg(image))
 	}
 	yaml.WriteString("\n")
 }
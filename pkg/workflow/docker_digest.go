@@ -0,0 +1,177 @@
+package workflow
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var dockerDigestLog = logger.New("workflow:docker_digest")
+
+// DigestResolver looks up the content digest ("sha256:...") of a container image reference
+type DigestResolver interface {
+	ResolveDigest(image string) (string, error)
+}
+
+// CachedDigestResolver wraps a DigestResolver and caches resolved digests so that each
+// image is only looked up once per compiler (images are shared across many workflows).
+// It is safe for concurrent use by the workers of CompileWorkflows.
+type CachedDigestResolver struct {
+	resolver DigestResolver
+	mu       sync.Mutex
+	cache    map[string]string
+}
+
+// NewCachedDigestResolver creates a caching digest resolver
+func NewCachedDigestResolver(resolver DigestResolver) *CachedDigestResolver {
+	return &CachedDigestResolver{
+		resolver: resolver,
+		cache:    make(map[string]string),
+	}
+}
+
+// ResolveDigest returns the cached digest for image, resolving it on first use.
+// Failures are not cached so that a transient registry error can be retried.
+func (r *CachedDigestResolver) ResolveDigest(image string) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if digest, found := r.cache[image]; found {
+		dockerDigestLog.Printf("Cache hit for %s: %s", image, digest)
+		return digest, nil
+	}
+
+	digest, err := r.resolver.ResolveDigest(image)
+	if err != nil {
+		return "", err
+	}
+
+	dockerDigestLog.Printf("Caching digest: %s → %s", image, digest)
+	r.cache[image] = digest
+	return digest, nil
+}
+
+// registryDigestResolver resolves digests from the image registry using
+// `docker buildx imagetools inspect`, which reads the manifest without pulling the image
+type registryDigestResolver struct{}
+
+// ResolveDigest queries the registry for the manifest digest of image
+func (registryDigestResolver) ResolveDigest(image string) (string, error) {
+	dockerDigestLog.Printf("Resolving digest from registry: %s", image)
+
+	if _, err := exec.LookPath("docker"); err != nil {
+		return "", fmt.Errorf("docker not installed - could not resolve digest for container image '%s'. Install Docker or disable mcp.pin-digests", image)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "docker", "buildx", "imagetools", "inspect", image, "--format", "{{.Manifest.Digest}}")
+	output, err := cmd.CombinedOutput()
+	outputStr := strings.TrimSpace(string(output))
+	if err != nil {
+		lowerOutput := strings.ToLower(outputStr)
+		switch {
+		case strings.Contains(lowerOutput, "unauthorized") || strings.Contains(lowerOutput, "denied") || strings.Contains(lowerOutput, "authentication required"):
+			return "", fmt.Errorf("registry authentication required for container image '%s'. Run 'docker login' for the registry and retry: %s", image, outputStr)
+		case strings.Contains(lowerOutput, "not found") || strings.Contains(lowerOutput, "manifest unknown"):
+			return "", fmt.Errorf("container image '%s' not found in registry. Check the image name and tag", image)
+		default:
+			return "", fmt.Errorf("failed to resolve digest for container image '%s': %s", image, outputStr)
+		}
+	}
+
+	return parseImageDigest(image, outputStr)
+}
+
+// parseImageDigest validates a digest returned by the registry
+func parseImageDigest(image, digest string) (string, error) {
+	digest = strings.TrimSpace(digest)
+	if !strings.HasPrefix(digest, "sha256:") || len(digest) != len("sha256:")+64 {
+		return "", fmt.Errorf("unexpected digest %q returned for container image '%s'", digest, image)
+	}
+	return digest, nil
+}
+
+// isDigestPinnedImage returns true if the image reference is already pinned by digest
+func isDigestPinnedImage(image string) bool {
+	return strings.Contains(image, "@sha256:")
+}
+
+// pinImageDigests maps each tag-based image reference to the same reference pinned by digest
+// (e.g. "alpine:3.20" -> "alpine:3.20@sha256:..."). The tag is kept for readability;
+// Docker pulls and runs by the digest. References that are already digest-pinned are not mapped.
+func pinImageDigests(images []string, resolver DigestResolver) (map[string]string, error) {
+	pins := make(map[string]string, len(images))
+	for _, image := range images {
+		if isDigestPinnedImage(image) {
+			dockerDigestLog.Printf("Image already pinned by digest: %s", image)
+			continue
+		}
+
+		digest, err := resolver.ResolveDigest(image)
+		if err != nil {
+			return nil, fmt.Errorf("failed to pin container image '%s' by digest (mcp.pin-digests): %w", image, err)
+		}
+		pins[image] = image + "@" + digest
+	}
+	return pins, nil
+}
+
+// pinnedImageRef returns the digest-pinned reference for image, or image itself if it is not pinned
+func pinnedImageRef(image string, pins map[string]string) string {
+	if pinned, ok := pins[image]; ok {
+		return pinned
+	}
+	return image
+}
+
+// applyImagePinsToMCPConfig replaces every quoted image reference in a rendered MCP config
+// (JSON "container": "..." fields, TOML container = "..." fields and docker run args)
+// with its digest-pinned reference, so the MCP gateway starts exactly the pinned images
+func applyImagePinsToMCPConfig(config string, pins map[string]string) string {
+	if len(pins) == 0 {
+		return config
+	}
+
+	images := make([]string, 0, len(pins))
+	for image := range pins {
+		images = append(images, image)
+	}
+	sort.Strings(images)
+
+	replacements := make([]string, 0, 2*len(images))
+	for _, image := range images {
+		replacements = append(replacements, `"`+image+`"`, `"`+pins[image]+`"`)
+	}
+	return strings.NewReplacer(replacements...).Replace(config)
+}
+
+// extractMCPPinDigests returns true when mcp.pin-digests is enabled in the frontmatter
+func extractMCPPinDigests(frontmatter map[string]any) bool {
+	mcpConfig, ok := frontmatter["mcp"].(map[string]any)
+	if !ok {
+		return false
+	}
+	pinDigests, _ := mcpConfig["pin-digests"].(bool)
+	return pinDigests
+}
+
+// getDigestResolver returns the compiler's shared digest resolver, initializing it on first use
+func (c *Compiler) getDigestResolver() DigestResolver {
+	if c.digestResolver == nil {
+		c.digestResolver = NewCachedDigestResolver(registryDigestResolver{})
+	}
+	return c.digestResolver
+}
+
+// SetDigestResolver overrides the resolver used to pin container images by digest
+func (c *Compiler) SetDigestResolver(resolver DigestResolver) {
+	c.digestResolver = resolver
+}
@@ -0,0 +1,168 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testDigest = "sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+
+// fakeDigestResolver resolves every image to testDigest unless an error is configured for it
+type fakeDigestResolver struct {
+	errs  map[string]error
+	calls map[string]int
+}
+
+func newFakeDigestResolver() *fakeDigestResolver {
+	return &fakeDigestResolver{errs: make(map[string]error), calls: make(map[string]int)}
+}
+
+func (f *fakeDigestResolver) ResolveDigest(image string) (string, error) {
+	f.calls[image]++
+	if err, ok := f.errs[image]; ok {
+		return "", err
+	}
+	return testDigest, nil
+}
+
+func TestPinImageDigests(t *testing.T) {
+	resolver := newFakeDigestResolver()
+	pinnedRef := "alpine@sha256:fedcba9876543210fedcba9876543210fedcba9876543210fedcba9876543210"
+
+	pins, err := pinImageDigests([]string{"ghcr.io/github/github-mcp-server:v0.30.3", "mcr.microsoft.com/playwright/mcp", pinnedRef}, resolver)
+	require.NoError(t, err, "Images should be pinned")
+	assert.Equal(t, map[string]string{
+		"ghcr.io/github/github-mcp-server:v0.30.3": "ghcr.io/github/github-mcp-server:v0.30.3@" + testDigest,
+		"mcr.microsoft.com/playwright/mcp":         "mcr.microsoft.com/playwright/mcp@" + testDigest,
+	}, pins, "Tags should be pinned by digest")
+	assert.Zero(t, resolver.calls[pinnedRef], "Digest-pinned references should not be resolved")
+	assert.Equal(t, pinnedRef, pinnedImageRef(pinnedRef, pins), "Digest-pinned references should be used as-is")
+
+	t.Run("image not found", func(t *testing.T) {
+		resolver := newFakeDigestResolver()
+		resolver.errs["mcp/missing:latest"] = errors.New("container image 'mcp/missing:latest' not found in registry")
+		_, err := pinImageDigests([]string{"mcp/missing:latest"}, resolver)
+		require.Error(t, err, "Unresolvable images should fail")
+		assert.Contains(t, err.Error(), "mcp/missing:latest", "Error should name the image")
+		assert.Contains(t, err.Error(), "not found", "Error should explain the failure")
+	})
+
+	t.Run("registry auth", func(t *testing.T) {
+		resolver := newFakeDigestResolver()
+		resolver.errs["ghcr.io/private/tool:v1"] = errors.New("registry authentication required for container image 'ghcr.io/private/tool:v1'")
+		_, err := pinImageDigests([]string{"ghcr.io/private/tool:v1"}, resolver)
+		require.Error(t, err, "Images requiring auth should fail")
+		assert.Contains(t, err.Error(), "authentication required", "Error should explain the failure")
+	})
+}
+
+func TestApplyImagePinsToMCPConfig(t *testing.T) {
+	pins := map[string]string{
+		"alpine:latest":                    "alpine:latest@" + testDigest,
+		"mcr.microsoft.com/playwright/mcp": "mcr.microsoft.com/playwright/mcp@" + testDigest,
+	}
+	config := `"container": "mcr.microsoft.com/playwright/mcp",
+container = "alpine:latest"
+"args": ["run", "alpine:latest-edge", "node:lts-alpine"]`
+
+	pinned := applyImagePinsToMCPConfig(config, pins)
+	assert.Contains(t, pinned, `"container": "mcr.microsoft.com/playwright/mcp@`+testDigest+`"`, "JSON container should be pinned")
+	assert.Contains(t, pinned, `container = "alpine:latest@`+testDigest+`"`, "TOML container should be pinned")
+	assert.Contains(t, pinned, `"alpine:latest-edge", "node:lts-alpine"`, "Other images should be untouched")
+	assert.Equal(t, config, applyImagePinsToMCPConfig(config, nil), "Config should be unchanged without pins")
+}
+
+func TestCachedDigestResolver(t *testing.T) {
+	fake := newFakeDigestResolver()
+	resolver := NewCachedDigestResolver(fake)
+
+	for range 3 {
+		digest, err := resolver.ResolveDigest("alpine:3.20")
+		require.NoError(t, err, "Digest should resolve")
+		assert.Equal(t, testDigest, digest, "Digest should match")
+	}
+	assert.Equal(t, 1, fake.calls["alpine:3.20"], "Registry should only be queried once per image")
+
+	fake.errs["mcp/missing:latest"] = errors.New("not found")
+	_, err := resolver.ResolveDigest("mcp/missing:latest")
+	require.Error(t, err, "Errors should be returned")
+	_, err = resolver.ResolveDigest("mcp/missing:latest")
+	require.Error(t, err, "Errors should be returned")
+	assert.Equal(t, 2, fake.calls["mcp/missing:latest"], "Failures should not be cached")
+}
+
+func TestParseImageDigest(t *testing.T) {
+	digest, err := parseImageDigest("alpine", testDigest+"\n")
+	require.NoError(t, err, "Valid digest should parse")
+	assert.Equal(t, testDigest, digest, "Digest should be trimmed")
+
+	_, err = parseImageDigest("alpine", "sha256:abc")
+	assert.Error(t, err, "Truncated digest should be rejected")
+}
+
+func TestCompileWorkflowWithPinnedDigests(t *testing.T) {
+	tests := []struct {
+		name       string
+		pinDigests string
+		wantPinned bool
+	}{
+		{name: "enabled", pinDigests: "mcp:\n  pin-digests: true\n", wantPinned: true},
+		{name: "disabled by default"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			workflowsDir := filepath.Join(testutil.TempDir(t, "pin-digests-test"), ".github", "workflows")
+			require.NoError(t, os.MkdirAll(workflowsDir, 0755), "Failed to create workflows directory")
+
+			content := "---\non: workflow_dispatch\npermissions:\n  contents: read\nengine: copilot\n" + tt.pinDigests + "mcp-servers:\n  pinned:\n    container: mcp/already@" + testDigest + "\n  tagged:\n    container: mcp/example\n    version: \"1.2\"\n---\n\n# Test Workflow\n"
+			testFile := filepath.Join(workflowsDir, "pin-digests.md")
+			require.NoError(t, os.WriteFile(testFile, []byte(content), 0644), "Failed to write test file")
+
+			resolver := newFakeDigestResolver()
+			compiler := NewCompiler()
+			compiler.SetDigestResolver(resolver)
+			require.NoError(t, compiler.CompileWorkflow(testFile), "Workflow should compile")
+
+			lockContent, err := os.ReadFile(filepath.Join(workflowsDir, "pin-digests.lock.yml"))
+			require.NoError(t, err, "Lock file should be written")
+
+			var downloadLine string
+			for line := range strings.SplitSeq(string(lockContent), "\n") {
+				if strings.Contains(line, "download_docker_images.sh") {
+					downloadLine = line
+					break
+				}
+			}
+			require.NotEmpty(t, downloadLine, "Download step should be generated")
+
+			if !tt.wantPinned {
+				assert.NotContains(t, downloadLine, "mcp/example:1.2@", "Images should not be pinned by default")
+				assert.Empty(t, resolver.calls, "Digests should not be resolved by default")
+				return
+			}
+			lock := string(lockContent)
+			assert.Contains(t, lock, `"container": "mcp/example:1.2@`+testDigest+`"`, "MCP server config should run the pinned image")
+			assert.NotContains(t, lock, `"container": "mcp/example:1.2"`, "MCP server config should not run the tag")
+			assert.Contains(t, lock, `"container": "ghcr.io/github/github-mcp-server:`, "GitHub MCP server should be configured")
+			assert.NotRegexp(t, `"container": "ghcr.io/github/github-mcp-server:[^"@]+"`, lock, "GitHub MCP server config should run the pinned image")
+			assert.Regexp(t, `MCP_GATEWAY_DOCKER_COMMAND=.* ghcr\.io/github/gh-aw-mcpg:[^ ']+@`+testDigest, lock, "Gateway should run the pinned image")
+			assert.Contains(t, downloadLine, " mcp/example:1.2@"+testDigest, "Tagged image should be pinned by digest")
+			assert.Contains(t, downloadLine, " mcp/already@"+testDigest, "Digest-pinned image should pass through")
+			assert.NotContains(t, downloadLine, "@"+testDigest+"@", "Digest-pinned image should not be pinned twice")
+			assert.Zero(t, resolver.calls["mcp/already@"+testDigest], "Digest-pinned image should not be resolved")
+			for _, image := range strings.Fields(downloadLine)[3:] {
+				assert.Contains(t, image, "@sha256:", "Every downloaded image should be pinned: %s", image)
+			}
+		})
+	}
+}
@@ -524,6 +524,8 @@ func GetBlockedDomains(network *NetworkPermissions) []string {
 }
 
 // formatBlockedDomains formats blocked domains as a comma-separated string suitable for AWF's --block-domains flag
+// Wildcard patterns such as *.example.com are emitted verbatim; AWF matches them against the
+// base domain and all of its subdomains. Patterns are validated by validateNetworkBlockedDomains.
 // Returns empty string if no blocked domains
 func formatBlockedDomains(network *NetworkPermissions) string {
 	if network == nil {
@@ -125,6 +125,13 @@ func TestFormatBlockedDomains(t *testing.T) {
 			},
 			expected: "analytics.example.com,tracker.example.com", // Sorted and comma-separated
 		},
+		{
+			name: "wildcard patterns",
+			network: &NetworkPermissions{
+				Blocked: []string{"*.evil.com", "tracker.example.com", "*.ru"},
+			},
+			expected: "*.evil.com,*.ru,tracker.example.com", // Wildcards emitted verbatim
+		},
 		{
 			name: "ecosystem identifier",
 			network: &NetworkPermissions{
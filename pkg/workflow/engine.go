@@ -12,19 +12,39 @@ var engineLog = logger.New("workflow:engine")
 
 // EngineConfig represents the parsed engine configuration
 type EngineConfig struct {
-	ID          string
-	Version     string
-	Model       string
-	MaxTurns    string
-	Concurrency string // Agent job-level concurrency configuration (YAML format)
-	UserAgent   string
-	Command     string // Custom executable path (when set, skip installation steps)
-	Env         map[string]string
-	Steps       []map[string]any
-	Config      string
-	Args        []string
-	Firewall    *FirewallConfig // AWF firewall configuration
-	Agent       string          // Agent identifier for copilot --agent flag (copilot engine only)
+	ID             string
+	Version        string
+	Model          string
+	DetectionModel string // Model for the threat detection job, independent of Model
+	MaxTurns       string
+	MaxTokens      int    // Token budget for the agent run (engines that support max-tokens only)
+	Concurrency    string // Agent job-level concurrency configuration (YAML format)
+	UserAgent      string
+	Command        string // Custom executable path (when set, skip installation steps)
+	Env            map[string]string
+	EnvFiles       map[string]string // Env var name -> file read at runtime (workflow-relative when parsed, repository-relative after resolution)
+	Steps          []map[string]any
+	Config         string
+	Args           []string
+	Firewall       *FirewallConfig // AWF firewall configuration
+	Agent          string          // Agent identifier for copilot --agent flag (copilot engine only)
+
+	PromptPrefix     string // Text prepended to the user prompt (inline or loaded from PromptPrefixFile)
+	PromptSuffix     string // Text appended to the user prompt (inline or loaded from PromptSuffixFile)
+	PromptPrefixFile string // Repository-relative file providing the prompt prefix
+	PromptSuffixFile string // Repository-relative file providing the prompt suffix
+
+	ToolTimeoutPerCall int // Timeout in seconds for each tool call (engines that support tool-timeout-per-call only)
+
+	MaxCost float64       // Estimated cost budget in USD for the agent run, 0 means unlimited (engines that support max-cost only)
+	Pricing *ModelPricing // Token pricing used to estimate cost when the engine does not report it (engines that support max-cost only)
+}
+
+// ModelPricing represents token prices in USD per million tokens, configured with engine.pricing
+type ModelPricing struct {
+	Input       float64 `json:"input"`       // Price of uncached input tokens
+	CachedInput float64 `json:"cachedInput"` // Price of input tokens read from the prompt cache
+	Output      float64 `json:"output"`      // Price of output tokens
 }
 
 // NetworkPermissions represents network access permissions for workflow execution
@@ -105,6 +125,13 @@ func (c *Compiler) ExtractEngineConfig(frontmatter map[string]any) (string, *Eng
 				}
 			}
 
+			// Extract optional 'detection-model' field
+			if detectionModel, hasDetectionModel := engineObj["detection-model"]; hasDetectionModel {
+				if detectionModelStr, ok := detectionModel.(string); ok {
+					config.DetectionModel = detectionModelStr
+				}
+			}
+
 			// Extract optional 'max-turns' field
 			if maxTurns, hasMaxTurns := engineObj["max-turns"]; hasMaxTurns {
 				if maxTurnsInt, ok := maxTurns.(int); ok {
@@ -116,6 +143,38 @@ func (c *Compiler) ExtractEngineConfig(frontmatter map[string]any) (string, *Eng
 				}
 			}
 
+			// Extract optional 'max-tokens' field
+			if maxTokens, hasMaxTokens := engineObj["max-tokens"]; hasMaxTokens {
+				if maxTokensInt, ok := parseIntValue(maxTokens); ok && maxTokensInt > 0 {
+					config.MaxTokens = maxTokensInt
+				}
+			}
+
+			// Extract optional 'max-cost' field (validated as positive later)
+			if maxCost, hasMaxCost := engineObj["max-cost"]; hasMaxCost {
+				if maxCostFloat, ok := maxCost.(float64); ok {
+					config.MaxCost = maxCostFloat
+				} else if maxCostInt, ok := parseIntValue(maxCost); ok {
+					config.MaxCost = float64(maxCostInt)
+				} else {
+					config.MaxCost = -1
+				}
+			}
+
+			// Extract optional 'pricing' field (validated as non-negative later)
+			if pricing, hasPricing := engineObj["pricing"]; hasPricing {
+				config.Pricing = parseModelPricing(pricing)
+			}
+
+			// Extract optional 'tool-timeout-per-call' field (validated as positive later)
+			if toolTimeoutPerCall, hasToolTimeoutPerCall := engineObj["tool-timeout-per-call"]; hasToolTimeoutPerCall {
+				if toolTimeoutPerCallInt, ok := parseIntValue(toolTimeoutPerCall); ok {
+					config.ToolTimeoutPerCall = toolTimeoutPerCallInt
+				} else {
+					config.ToolTimeoutPerCall = -1
+				}
+			}
+
 			// Extract optional 'concurrency' field (string or object format)
 			if concurrency, hasConcurrency := engineObj["concurrency"]; hasConcurrency {
 				if concurrencyStr, ok := concurrency.(string); ok {
@@ -168,6 +227,11 @@ func (c *Compiler) ExtractEngineConfig(frontmatter map[string]any) (string, *Eng
 				}
 			}
 
+			// Extract optional 'env-file' field (object/map of env var names to files)
+			if envFile, hasEnvFile := engineObj["env-file"]; hasEnvFile {
+				config.EnvFiles = parseEngineEnvFiles(envFile)
+			}
+
 			// Extract optional 'steps' field (array of step objects)
 			if steps, hasSteps := engineObj["steps"]; hasSteps {
 				if stepsArray, ok := steps.([]any); ok {
@@ -209,6 +273,14 @@ func (c *Compiler) ExtractEngineConfig(frontmatter map[string]any) (string, *Eng
 				}
 			}
 
+			// Extract optional 'prompt-prefix' and 'prompt-suffix' fields (string or {file: path})
+			if prefix, hasPrefix := engineObj["prompt-prefix"]; hasPrefix {
+				config.PromptPrefix, config.PromptPrefixFile = parsePromptAffix(prefix)
+			}
+			if suffix, hasSuffix := engineObj["prompt-suffix"]; hasSuffix {
+				config.PromptSuffix, config.PromptSuffixFile = parsePromptAffix(suffix)
+			}
+
 			// Extract optional 'firewall' field (object format)
 			if firewall, hasFirewall := engineObj["firewall"]; hasFirewall {
 				if firewallObj, ok := firewall.(map[string]any); ok {
@@ -306,3 +378,28 @@ func (c *Compiler) extractEngineConfigFromJSON(engineJSON string) (*EngineConfig
 	_, config := c.ExtractEngineConfig(tempFrontmatter)
 	return config, nil
 }
+
+// parseModelPricing parses the engine.pricing object. Missing or invalid prices are set to -1
+// so validateMaxCost can reject them.
+func parseModelPricing(value any) *ModelPricing {
+	pricingObj, ok := value.(map[string]any)
+	if !ok {
+		return &ModelPricing{Input: -1, CachedInput: -1, Output: -1}
+	}
+	parsePrice := func(key string) float64 {
+		switch v := pricingObj[key].(type) {
+		case float64:
+			return v
+		default:
+			if price, ok := parseIntValue(v); ok {
+				return float64(price)
+			}
+			return -1
+		}
+	}
+	return &ModelPricing{
+		Input:       parsePrice("input"),
+		CachedInput: parsePrice("cached-input"),
+		Output:      parsePrice("output"),
+	}
+}
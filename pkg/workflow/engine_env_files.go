@@ -0,0 +1,145 @@
+package workflow
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var engineEnvFilesLog = logger.New("workflow:engine_env_files")
+
+var (
+	// envFileNamePattern matches valid environment variable names
+	envFileNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+	// envFilePathPattern restricts env-file paths to characters that are safe to embed in a shell script
+	envFilePathPattern = regexp.MustCompile(`^[A-Za-z0-9._/-]+$`)
+)
+
+// engineEnvFilesStepID is the id of the step that reads engine.env-file values
+const engineEnvFilesStepID = "engine-env-files"
+
+// reservedEngineEnvFileNames are variables that change how the runner, shells or Node.js
+// behave and therefore must not be loaded from repository files
+var reservedEngineEnvFileNames = []string{"PATH", "HOME", "SHELL", "ENV", "BASH_ENV", "NODE_OPTIONS", "LD_PRELOAD", "LD_LIBRARY_PATH"}
+
+// reservedEngineEnvFilePrefixes are variable name prefixes owned by GitHub Actions and gh-aw
+var reservedEngineEnvFilePrefixes = []string{"GITHUB_", "RUNNER_", "ACTIONS_", "GH_AW_"}
+
+// isReservedEngineEnvFileName reports whether name must not be set through engine.env-file
+func isReservedEngineEnvFileName(name string) bool {
+	upper := strings.ToUpper(name)
+	if slices.Contains(reservedEngineEnvFileNames, upper) {
+		return true
+	}
+	for _, prefix := range reservedEngineEnvFilePrefixes {
+		if strings.HasPrefix(upper, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseEngineEnvFiles parses engine.env-file, a map of environment variable names to
+// workflow-relative files whose contents become the variable values at runtime
+func parseEngineEnvFiles(val any) map[string]string {
+	envFileMap, ok := val.(map[string]any)
+	if !ok {
+		return nil
+	}
+	envFiles := make(map[string]string)
+	for name, file := range envFileMap {
+		if fileStr, ok := file.(string); ok {
+			envFiles[name] = fileStr
+		}
+	}
+	return envFiles
+}
+
+// resolveEngineEnvFiles rewrites engine.env-file paths, which are relative to the workflow
+// markdown file, as repository-relative paths. Paths must stay inside the repository and
+// variable names must not also be set inline in engine.env.
+func resolveEngineEnvFiles(config *EngineConfig, markdownPath string) error {
+	if config == nil || len(config.EnvFiles) == 0 {
+		return nil
+	}
+
+	workflowDir := path.Dir(workflowSourcePath(markdownPath))
+	resolved := make(map[string]string, len(config.EnvFiles))
+	for name, file := range config.EnvFiles {
+		if !envFileNamePattern.MatchString(name) {
+			return fmt.Errorf("engine.env-file: '%s' is not a valid environment variable name", name)
+		}
+		if isReservedEngineEnvFileName(name) {
+			return fmt.Errorf("engine.env-file: '%s' is reserved and cannot be loaded from a file", name)
+		}
+		if _, exists := config.Env[name]; exists {
+			return fmt.Errorf("engine.env-file: '%s' is also set in engine.env; set it in only one place", name)
+		}
+		if path.IsAbs(file) {
+			return fmt.Errorf("engine.env-file: '%s' must be relative to the workflow file, got absolute path '%s'", name, file)
+		}
+		if !envFilePathPattern.MatchString(file) {
+			return fmt.Errorf("engine.env-file: '%s' path '%s' may only contain letters, digits, '.', '_', '-' and '/'", name, file)
+		}
+
+		repoPath := path.Join(workflowDir, file)
+		if repoPath == ".." || strings.HasPrefix(repoPath, "../") {
+			return fmt.Errorf("engine.env-file: '%s' path '%s' must not reference files outside the repository", name, file)
+		}
+		engineEnvFilesLog.Printf("Resolved engine.env-file %s: %s -> %s", name, file, repoPath)
+		resolved[name] = repoPath
+	}
+	config.EnvFiles = resolved
+	return nil
+}
+
+// generateEngineEnvFilesStep generates a step that reads each engine.env-file from the
+// workspace and exposes its contents as a step output. It runs before the PR branch is
+// checked out, so pull requests cannot change the values, and the outputs are passed only
+// to the engine execution step (see engineEnvFilesStepEnv). A missing file fails the step.
+func generateEngineEnvFilesStep(yaml *strings.Builder, data *WorkflowData) {
+	if data.EngineConfig == nil || len(data.EngineConfig.EnvFiles) == 0 {
+		return
+	}
+
+	names := make([]string, 0, len(data.EngineConfig.EnvFiles))
+	for name := range data.EngineConfig.EnvFiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	engineEnvFilesLog.Printf("Generating env file loading step for %d variables", len(names))
+
+	yaml.WriteString("      - name: Load engine env files\n")
+	fmt.Fprintf(yaml, "        id: %s\n", engineEnvFilesStepID)
+	yaml.WriteString("        run: |\n")
+	yaml.WriteString("          load_env_file() {\n")
+	yaml.WriteString("            if [ ! -f \"${GITHUB_WORKSPACE}/$2\" ]; then\n")
+	yaml.WriteString("              echo \"::error::engine.env-file: $1 references $2, which does not exist in the repository\"\n")
+	yaml.WriteString("              exit 1\n")
+	yaml.WriteString("            fi\n")
+	yaml.WriteString("            delimiter=\"GH_AW_ENV_FILE_$(openssl rand -hex 16)\"\n")
+	yaml.WriteString("            printf '%s<<%s\\n%s\\n%s\\n' \"$1\" \"$delimiter\" \"$(cat \"${GITHUB_WORKSPACE}/$2\")\" \"$delimiter\" >> \"$GITHUB_OUTPUT\"\n")
+	yaml.WriteString("            echo \"Loaded $1 from $2\"\n")
+	yaml.WriteString("          }\n")
+	for _, name := range names {
+		fmt.Fprintf(yaml, "          load_env_file %s %s\n", name, data.EngineConfig.EnvFiles[name])
+	}
+}
+
+// engineEnvFilesStepEnv returns the engine execution step env entries that pass each
+// engine.env-file value from the loading step's outputs
+func engineEnvFilesStepEnv(workflowData *WorkflowData) map[string]string {
+	if workflowData == nil || workflowData.EngineConfig == nil || len(workflowData.EngineConfig.EnvFiles) == 0 {
+		return nil
+	}
+	env := make(map[string]string, len(workflowData.EngineConfig.EnvFiles))
+	for name := range workflowData.EngineConfig.EnvFiles {
+		env[name] = fmt.Sprintf("${{ steps.%s.outputs.%s }}", engineEnvFilesStepID, name)
+	}
+	return env
+}
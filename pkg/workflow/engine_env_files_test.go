@@ -0,0 +1,159 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseEngineEnvFiles(t *testing.T) {
+	envFiles := parseEngineEnvFiles(map[string]any{
+		"SYSTEM_MESSAGE": "prompts/system.txt",
+		"IGNORED":        42,
+	})
+	assert.Equal(t, map[string]string{"SYSTEM_MESSAGE": "prompts/system.txt"}, envFiles, "Only string paths should be kept")
+	assert.Nil(t, parseEngineEnvFiles("prompts/system.txt"), "Non-object values should be ignored")
+}
+
+func TestResolveEngineEnvFiles(t *testing.T) {
+	markdownPath := "/repo/.github/workflows/triage.md"
+
+	tests := []struct {
+		name        string
+		config      *EngineConfig
+		expected    map[string]string
+		errContains string
+	}{
+		{
+			name:     "workflow-relative file",
+			config:   &EngineConfig{EnvFiles: map[string]string{"SYSTEM_MESSAGE": "prompts/system.txt"}},
+			expected: map[string]string{"SYSTEM_MESSAGE": ".github/workflows/prompts/system.txt"},
+		},
+		{
+			name:     "file elsewhere in the repository",
+			config:   &EngineConfig{EnvFiles: map[string]string{"CONFIG": "../../config/agent.json"}},
+			expected: map[string]string{"CONFIG": "config/agent.json"},
+		},
+		{
+			name:        "file outside the repository",
+			config:      &EngineConfig{EnvFiles: map[string]string{"CONFIG": "../../../secrets.txt"}},
+			errContains: "outside the repository",
+		},
+		{
+			name:        "absolute path",
+			config:      &EngineConfig{EnvFiles: map[string]string{"CONFIG": "/etc/passwd"}},
+			errContains: "absolute path",
+		},
+		{
+			name:        "unsafe characters",
+			config:      &EngineConfig{EnvFiles: map[string]string{"CONFIG": "prompts/$(id).txt"}},
+			errContains: "may only contain",
+		},
+		{
+			name:        "invalid variable name",
+			config:      &EngineConfig{EnvFiles: map[string]string{"1CONFIG": "config.txt"}},
+			errContains: "not a valid environment variable name",
+		},
+		{
+			name: "variable also set in env",
+			config: &EngineConfig{
+				Env:      map[string]string{"CONFIG": "inline"},
+				EnvFiles: map[string]string{"CONFIG": "config.txt"},
+			},
+			errContains: "also set in engine.env",
+		},
+		{
+			name:        "reserved variable",
+			config:      &EngineConfig{EnvFiles: map[string]string{"NODE_OPTIONS": "config.txt"}},
+			errContains: "'NODE_OPTIONS' is reserved",
+		},
+		{
+			name:        "reserved prefix",
+			config:      &EngineConfig{EnvFiles: map[string]string{"GITHUB_TOKEN": "config.txt"}},
+			errContains: "'GITHUB_TOKEN' is reserved",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := resolveEngineEnvFiles(tt.config, markdownPath)
+			if tt.errContains != "" {
+				require.Error(t, err, "Invalid env-file should be rejected")
+				assert.Contains(t, err.Error(), tt.errContains, "Error should explain the problem")
+				return
+			}
+			require.NoError(t, err, "Valid env-file should resolve")
+			assert.Equal(t, tt.expected, tt.config.EnvFiles, "Paths should be repository-relative")
+		})
+	}
+}
+
+func TestEngineEnvFilesStep(t *testing.T) {
+	tests := []struct {
+		engine        string
+		executionStep string
+	}{
+		{engine: "copilot", executionStep: "id: agentic_execution"},
+		{engine: "claude", executionStep: "id: agentic_execution"},
+		{engine: "codex", executionStep: "- name: Run Codex"},
+		{engine: "copilot-sdk", executionStep: "- name: Execute Copilot SDK client"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.engine, func(t *testing.T) {
+			content := `---
+on: pull_request
+permissions:
+  contents: read
+engine:
+  id: ` + tt.engine + `
+  env-file:
+    SYSTEM_MESSAGE: prompts/system.txt
+    AGENT_CONFIG: ../agent.json
+---
+
+# Test Workflow
+`
+			markdownPath := filepath.Join(testutil.TempDir(t, "engine-env-files"), ".github", "workflows", "test.md")
+
+			lockContent, err := NewCompiler().CompileString(content, markdownPath)
+			require.NoError(t, err, "Workflow with engine.env-file should compile")
+			agentJob := agentJobSection(t, lockContent)
+
+			assert.Contains(t, agentJob, "- name: Load engine env files", "Agent job should load the env files")
+			assert.Contains(t, agentJob, "id: engine-env-files", "Env file step should have an id for its outputs")
+			assert.Contains(t, agentJob, `>> "$GITHUB_OUTPUT"`, "Env file contents should be exposed as step outputs")
+			assert.NotContains(t, agentJob, `>> "$GITHUB_ENV"`, "Env file contents should not become job-wide env")
+			assert.Contains(t, agentJob, "::error::engine.env-file: $1 references $2, which does not exist", "Missing files should fail the step")
+			assert.Contains(t, agentJob, "load_env_file AGENT_CONFIG .github/agent.json\n          load_env_file SYSTEM_MESSAGE .github/workflows/prompts/system.txt\n",
+				"Each variable should be loaded from its repository-relative file, sorted by name")
+
+			loadStep := strings.Index(agentJob, "- name: Load engine env files")
+			executionStep := strings.Index(agentJob, tt.executionStep)
+			require.NotEqual(t, -1, executionStep, "Agent job should contain the execution step")
+			assert.Less(t, loadStep, executionStep, "Env files should be loaded before the engine runs")
+
+			prCheckoutStep := strings.Index(agentJob, "- name: Checkout PR branch")
+			require.NotEqual(t, -1, prCheckoutStep, "Agent job should contain the PR checkout step")
+			assert.Less(t, loadStep, prCheckoutStep, "Env files should be loaded before the PR branch is checked out")
+
+			executionSection := agentJob[executionStep:]
+			assert.Contains(t, executionSection, "SYSTEM_MESSAGE: ${{ steps.engine-env-files.outputs.SYSTEM_MESSAGE }}", "Execution step should receive the env file value")
+			assert.Contains(t, executionSection, "AGENT_CONFIG: ${{ steps.engine-env-files.outputs.AGENT_CONFIG }}", "Execution step should receive the env file value")
+		})
+	}
+}
+
+func TestEngineEnvFilesKeepCheckout(t *testing.T) {
+	data := &WorkflowData{
+		CheckoutDisabled: true,
+		EngineConfig:     &EngineConfig{EnvFiles: map[string]string{"CONFIG": ".github/workflows/config.txt"}},
+	}
+	assert.False(t, isAgentCheckoutSkipped(data), "Env files are read from the workspace, so the checkout should be kept")
+}
@@ -73,7 +73,7 @@ func (c *Compiler) checkNetworkSupport(engine CodingAgentEngine, networkPermissi
 
 	// In non-strict mode, emit a warning
 	fmt.Fprintln(os.Stderr, console.FormatWarningMessage(message))
-	c.IncrementWarningCount()
+	c.addWarning(message)
 
 	return nil
 }
@@ -101,7 +101,7 @@ func (c *Compiler) checkFirewallDisable(engine CodingAgentEngine, networkPermiss
 
 			// In non-strict mode, emit a warning
 			fmt.Fprintln(os.Stderr, console.FormatWarningMessage(message))
-			c.IncrementWarningCount()
+			c.addWarning(message)
 		}
 
 		// Also check if engine doesn't support firewall in strict mode when there are no restrictions
@@ -33,6 +33,7 @@ package workflow
 
 import (
 	"fmt"
+	"path/filepath"
 	"sort"
 	"strings"
 
@@ -280,6 +281,19 @@ func FormatStepWithCommandAndEnv(stepLines []string, command string, env map[str
 	return stepLines
 }
 
+// getModelVarNames returns the repository variable an engine reads its default model from
+// when engine.model is not set. Threat detection jobs (no safe outputs) read detectionVar,
+// agent jobs read agentVar.
+func getModelVarNames(workflowData *WorkflowData, agentVar, detectionVar string) []string {
+	if workflowData.EngineConfig != nil && workflowData.EngineConfig.Model != "" {
+		return []string{}
+	}
+	if workflowData.SafeOutputs == nil {
+		return []string{detectionVar}
+	}
+	return []string{agentVar}
+}
+
 // FilterEnvForSecrets filters environment variables to only include allowed secrets
 // This is a security measure to ensure that only necessary secrets are passed to the execution step
 //
@@ -298,6 +312,43 @@ func FilterEnvForSecrets(env map[string]string, allowedSecrets []string) map[str
 		allowedSet[secret] = true
 	}
 
+	return filterEnvSecrets(env, func(secretName string) bool {
+		return allowedSet[secretName]
+	})
+}
+
+// FilterEnvForSecretsGlob filters environment variables like FilterEnvForSecrets, but
+// matches secret names against glob patterns (e.g. "GH_AW_*") using filepath.Match.
+// This is useful for secret families with dynamic names such as HTTP MCP header secrets
+// and safe-inputs secrets. Exact names are valid patterns; malformed patterns match nothing.
+//
+// Parameters:
+//   - env: Map of all environment variables
+//   - patterns: List of secret name patterns that are allowed to be passed
+//
+// Returns:
+//   - map[string]string: Filtered environment variables with only matching secrets
+func FilterEnvForSecretsGlob(env map[string]string, patterns []string) map[string]string {
+	engineHelpersLog.Printf("Filtering environment variables: total=%d, allowed_patterns=%d", len(env), len(patterns))
+
+	return filterEnvSecrets(env, func(secretName string) bool {
+		for _, pattern := range patterns {
+			matched, err := filepath.Match(pattern, secretName)
+			if err != nil {
+				engineHelpersLog.Printf("Ignoring invalid secret pattern %q: %v", pattern, err)
+				continue
+			}
+			if matched {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// filterEnvSecrets drops env vars whose value references a secret rejected by isAllowed.
+// Env vars that do not reference a secret are always kept.
+func filterEnvSecrets(env map[string]string, isAllowed func(secretName string) bool) map[string]string {
 	filtered := make(map[string]string)
 	secretsRemoved := 0
 
@@ -307,7 +358,7 @@ func FilterEnvForSecrets(env map[string]string, allowedSecrets []string) map[str
 			// Extract the secret name from the expression
 			// Format: ${{ secrets.SECRET_NAME }} or ${{ secrets.SECRET_NAME || ... }}
 			secretName := ExtractSecretName(value)
-			if secretName != "" && !allowedSet[secretName] {
+			if secretName != "" && !isAllowed(secretName) {
 				engineHelpersLog.Printf("Removing unauthorized secret from env: %s (secret: %s)", key, secretName)
 				secretsRemoved++
 				continue
@@ -5,6 +5,7 @@ package workflow
 import (
 	"testing"
 
+	"github.com/github/gh-aw/pkg/constants"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -101,6 +102,67 @@ func TestFilterEnvForSecrets(t *testing.T) {
 	}
 }
 
+// TestFilterEnvForSecretsGlob tests the FilterEnvForSecretsGlob function
+func TestFilterEnvForSecretsGlob(t *testing.T) {
+	tests := []struct {
+		name     string
+		env      map[string]string
+		patterns []string
+		wantKeys []string
+	}{
+		{
+			name: "exact names",
+			env: map[string]string{
+				"COPILOT_GITHUB_TOKEN": "${{ secrets.COPILOT_GITHUB_TOKEN }}",
+				"OTHER_SECRET":         "${{ secrets.OTHER_SECRET }}",
+				"NORMAL_ENV_VAR":       "some-value",
+			},
+			patterns: []string{"COPILOT_GITHUB_TOKEN"},
+			wantKeys: []string{"COPILOT_GITHUB_TOKEN", "NORMAL_ENV_VAR"},
+		},
+		{
+			name: "glob families",
+			env: map[string]string{
+				"GH_AW_SAFE_INPUTS_TOKEN": "${{ secrets.GH_AW_SAFE_INPUTS_TOKEN }}",
+				"HEADER_AUTH":             "${{ secrets.GH_AW_MCP_HEADER_AUTH }}",
+				"MCP_GATEWAY_API_KEY":     "${{ secrets.MCP_GATEWAY_API_KEY }}",
+			},
+			patterns: []string{"GH_AW_*", "MCP_GATEWAY_API_KEY"},
+			wantKeys: []string{"GH_AW_SAFE_INPUTS_TOKEN", "HEADER_AUTH", "MCP_GATEWAY_API_KEY"},
+		},
+		{
+			name: "non-matching names are dropped",
+			env: map[string]string{
+				"GH_AW_TOKEN":    "${{ secrets.GH_AW_TOKEN }}",
+				"AWS_SECRET":     "${{ secrets.AWS_SECRET }}",
+				"PREFIXED":       "${{ secrets.XGH_AW_TOKEN }}",
+				"NORMAL_ENV_VAR": "some-value",
+			},
+			patterns: []string{"GH_AW_*"},
+			wantKeys: []string{"GH_AW_TOKEN", "NORMAL_ENV_VAR"},
+		},
+		{
+			name: "invalid pattern matches nothing",
+			env: map[string]string{
+				"GH_AW_TOKEN": "${{ secrets.GH_AW_TOKEN }}",
+			},
+			patterns: []string{"GH_AW_["},
+			wantKeys: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := FilterEnvForSecretsGlob(tt.env, tt.patterns)
+
+			assert.Len(t, result, len(tt.wantKeys), "Filtered env should only contain the expected keys")
+			for _, key := range tt.wantKeys {
+				assert.Contains(t, result, key, "Expected key %s to be present in filtered env", key)
+			}
+		})
+	}
+}
+
 // TestGetRequiredSecretNames_Copilot tests CopilotEngine.GetRequiredSecretNames
 func TestGetRequiredSecretNames_Copilot(t *testing.T) {
 	engine := NewCopilotEngine()
@@ -267,3 +329,33 @@ func TestGetRequiredSecretNames_Custom(t *testing.T) {
 		assert.Contains(t, secrets, "MCP_GATEWAY_API_KEY")
 	})
 }
+
+// TestGetRequiredVarNames_Copilot tests CopilotEngine.GetRequiredVarNames
+func TestGetRequiredVarNames_Copilot(t *testing.T) {
+	engine := NewCopilotEngine()
+
+	t.Run("agent job reads agent model var", func(t *testing.T) {
+		workflowData := &WorkflowData{SafeOutputs: &SafeOutputsConfig{}}
+
+		vars := engine.GetRequiredVarNames(workflowData)
+
+		require.Len(t, vars, 1)
+		assert.Contains(t, vars, constants.EnvVarModelAgentCopilot)
+	})
+
+	t.Run("detection job reads detection model var", func(t *testing.T) {
+		vars := engine.GetRequiredVarNames(&WorkflowData{})
+
+		require.Len(t, vars, 1)
+		assert.Contains(t, vars, constants.EnvVarModelDetectionCopilot)
+	})
+
+	t.Run("configured model reads no vars", func(t *testing.T) {
+		workflowData := &WorkflowData{
+			EngineConfig: &EngineConfig{ID: "copilot", Model: "gpt-5"},
+			SafeOutputs:  &SafeOutputsConfig{},
+		}
+
+		assert.Empty(t, engine.GetRequiredVarNames(workflowData))
+	})
+}
@@ -0,0 +1,120 @@
+package workflow
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/stringutil"
+)
+
+var promptAffixesLog = logger.New("workflow:engine_prompt_affixes")
+
+// parsePromptAffix parses an engine.prompt-prefix or engine.prompt-suffix value.
+// A string is treated as inline text; an object with a 'file' key references a
+// repository-relative file whose contents are loaded at compile time.
+// Returns the inline text and the file path (at most one is non-empty).
+func parsePromptAffix(val any) (text string, file string) {
+	switch v := val.(type) {
+	case string:
+		return v, ""
+	case map[string]any:
+		if fileStr, ok := v["file"].(string); ok {
+			return "", fileStr
+		}
+	}
+	return "", ""
+}
+
+// loadEnginePromptAffixes resolves prompt-prefix/prompt-suffix files relative to the
+// repository root and strips ANSI escape sequences from the resulting text.
+// File paths must be relative and must not escape the repository root.
+func loadEnginePromptAffixes(config *EngineConfig, markdownDir string) error {
+	if config == nil {
+		return nil
+	}
+
+	// Navigate up from .github/workflows to repository root
+	repoRoot := filepath.Join(markdownDir, "..", "..")
+
+	if config.PromptPrefixFile != "" {
+		content, err := readPromptAffixFile("prompt-prefix", config.PromptPrefixFile, repoRoot)
+		if err != nil {
+			return err
+		}
+		config.PromptPrefix = content
+	}
+	if config.PromptSuffixFile != "" {
+		content, err := readPromptAffixFile("prompt-suffix", config.PromptSuffixFile, repoRoot)
+		if err != nil {
+			return err
+		}
+		config.PromptSuffix = content
+	}
+
+	config.PromptPrefix = stringutil.StripANSIEscapeCodes(config.PromptPrefix)
+	config.PromptSuffix = stringutil.StripANSIEscapeCodes(config.PromptSuffix)
+	return nil
+}
+
+// validatePromptAffixExpressions applies the markdown expression-safety check to the
+// prompt prefix and suffix, which are added to the prompt alongside the markdown body
+func validatePromptAffixExpressions(config *EngineConfig) error {
+	if config == nil {
+		return nil
+	}
+	if err := validateExpressionSafety(config.PromptPrefix); err != nil {
+		return fmt.Errorf("engine.prompt-prefix: %w", err)
+	}
+	if err := validateExpressionSafety(config.PromptSuffix); err != nil {
+		return fmt.Errorf("engine.prompt-suffix: %w", err)
+	}
+	return nil
+}
+
+// readPromptAffixFile validates and reads a prompt affix file located under repoRoot
+func readPromptAffixFile(field, path, repoRoot string) (string, error) {
+	promptAffixesLog.Printf("Loading engine.%s from file: %s", field, path)
+
+	if filepath.IsAbs(path) {
+		return "", fmt.Errorf("engine.%s.file must be a path relative to the repository root, got absolute path '%s'", field, path)
+	}
+
+	fullPath := filepath.Join(repoRoot, path)
+	if !isPathWithinDir(fullPath, repoRoot) {
+		return "", fmt.Errorf("engine.%s.file '%s' must not reference files outside the repository", field, path)
+	}
+
+	content, err := os.ReadFile(fullPath) // #nosec G304 -- Path is validated via isPathWithinDir above
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", fmt.Errorf("engine.%s.file '%s' does not exist", field, path)
+		}
+		return "", fmt.Errorf("failed to read engine.%s.file '%s': %w", field, path, err)
+	}
+
+	promptAffixesLog.Printf("Loaded engine.%s (%d bytes)", field, len(content))
+	return strings.TrimRight(string(content), "\n"), nil
+}
+
+// buildPromptAffixChunks prepares prompt prefix/suffix text for inclusion in the user prompt.
+// GitHub expressions are replaced with environment variable references, as for inlined imports.
+func buildPromptAffixChunks(text string) ([]string, []*ExpressionMapping) {
+	if strings.TrimSpace(text) == "" {
+		return nil, nil
+	}
+
+	cleaned := wrapExpressionsInTemplateConditionals(removeXMLComments(text))
+
+	var mappings []*ExpressionMapping
+	extractor := NewExpressionExtractor()
+	exprMappings, err := extractor.ExtractExpressions(cleaned)
+	if err == nil && len(exprMappings) > 0 {
+		cleaned = extractor.ReplaceExpressionsWithEnvVars(cleaned)
+		mappings = exprMappings
+	}
+
+	return splitContentIntoChunks(cleaned), mappings
+}
@@ -0,0 +1,207 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePromptAffix(t *testing.T) {
+	tests := []struct {
+		name         string
+		value        any
+		expectedText string
+		expectedFile string
+	}{
+		{
+			name:         "inline string",
+			value:        "Be concise.",
+			expectedText: "Be concise.",
+		},
+		{
+			name:         "file object",
+			value:        map[string]any{"file": ".github/prompts/prefix.md"},
+			expectedFile: ".github/prompts/prefix.md",
+		},
+		{
+			name:  "unsupported type",
+			value: 42,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			text, file := parsePromptAffix(tt.value)
+			assert.Equal(t, tt.expectedText, text, "Inline text should match")
+			assert.Equal(t, tt.expectedFile, file, "File path should match")
+		})
+	}
+}
+
+func TestExtractEngineConfigPromptAffixes(t *testing.T) {
+	compiler := NewCompiler()
+	_, config := compiler.ExtractEngineConfig(map[string]any{
+		"engine": map[string]any{
+			"id":            "copilot",
+			"prompt-prefix": "Follow the guidelines.",
+			"prompt-suffix": map[string]any{"file": ".github/prompts/suffix.md"},
+		},
+	})
+
+	require.NotNil(t, config, "Engine config should be extracted")
+	assert.Equal(t, "Follow the guidelines.", config.PromptPrefix, "Inline prefix should be extracted")
+	assert.Empty(t, config.PromptPrefixFile, "Inline prefix should not set a file")
+	assert.Empty(t, config.PromptSuffix, "File suffix should not be loaded during extraction")
+	assert.Equal(t, ".github/prompts/suffix.md", config.PromptSuffixFile, "Suffix file should be extracted")
+}
+
+func TestLoadEnginePromptAffixes(t *testing.T) {
+	repoRoot := testutil.TempDir(t, "prompt-affix-test")
+	workflowsDir := filepath.Join(repoRoot, ".github", "workflows")
+	promptsDir := filepath.Join(repoRoot, ".github", "prompts")
+	require.NoError(t, os.MkdirAll(workflowsDir, 0755), "Failed to create workflows dir")
+	require.NoError(t, os.MkdirAll(promptsDir, 0755), "Failed to create prompts dir")
+	require.NoError(t, os.WriteFile(filepath.Join(promptsDir, "prefix.md"), []byte("\x1b[31mPrefix from file\x1b[0m\n"), 0644), "Failed to write prefix file")
+
+	tests := []struct {
+		name           string
+		config         *EngineConfig
+		expectedPrefix string
+		expectedSuffix string
+		wantErr        string
+	}{
+		{
+			name:   "nil config",
+			config: nil,
+		},
+		{
+			name:           "file prefix is loaded and ANSI stripped",
+			config:         &EngineConfig{PromptPrefixFile: ".github/prompts/prefix.md", PromptSuffix: "Inline \x1b[1msuffix\x1b[0m"},
+			expectedPrefix: "Prefix from file",
+			expectedSuffix: "Inline suffix",
+		},
+		{
+			name:    "missing file",
+			config:  &EngineConfig{PromptSuffixFile: ".github/prompts/missing.md"},
+			wantErr: "does not exist",
+		},
+		{
+			name:    "absolute path",
+			config:  &EngineConfig{PromptPrefixFile: filepath.Join(promptsDir, "prefix.md")},
+			wantErr: "relative to the repository root",
+		},
+		{
+			name:    "path traversal",
+			config:  &EngineConfig{PromptPrefixFile: "../outside.md"},
+			wantErr: "outside the repository",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := loadEnginePromptAffixes(tt.config, workflowsDir)
+			if tt.wantErr != "" {
+				require.Error(t, err, "Expected load error")
+				assert.Contains(t, err.Error(), tt.wantErr, "Error message should describe the problem")
+				return
+			}
+			require.NoError(t, err, "Expected affixes to load")
+			if tt.config == nil {
+				return
+			}
+			assert.Equal(t, tt.expectedPrefix, tt.config.PromptPrefix, "Prefix should match")
+			assert.Equal(t, tt.expectedSuffix, tt.config.PromptSuffix, "Suffix should match")
+		})
+	}
+}
+
+func TestCompileWorkflowWithPromptAffixes(t *testing.T) {
+	repoRoot := testutil.TempDir(t, "prompt-affix-compile-test")
+	workflowsDir := filepath.Join(repoRoot, ".github", "workflows")
+	promptsDir := filepath.Join(repoRoot, ".github", "prompts")
+	require.NoError(t, os.MkdirAll(workflowsDir, 0755), "Failed to create workflows dir")
+	require.NoError(t, os.MkdirAll(promptsDir, 0755), "Failed to create prompts dir")
+	require.NoError(t, os.WriteFile(filepath.Join(promptsDir, "suffix.md"), []byte("SUFFIX_FROM_FILE for ${{ github.repository }}\n"), 0644), "Failed to write suffix file")
+
+	workflow := `---
+on: push
+permissions:
+  contents: read
+engine:
+  id: copilot
+  prompt-prefix: "INLINE_PREFIX_MARKER"
+  prompt-suffix:
+    file: .github/prompts/suffix.md
+---
+
+# Affix Test
+
+Main prompt body.
+`
+	workflowFile := filepath.Join(workflowsDir, "affix.md")
+	require.NoError(t, os.WriteFile(workflowFile, []byte(workflow), 0644), "Failed to write workflow file")
+
+	lockContent, err := NewCompiler().CompileWorkflowDryRun(workflowFile)
+	require.NoError(t, err, "Workflow with prompt affixes should compile")
+
+	prefixIdx := strings.Index(lockContent, "INLINE_PREFIX_MARKER")
+	mainIdx := strings.Index(lockContent, "{{#runtime-import .github/workflows/affix.md}}")
+	suffixIdx := strings.Index(lockContent, "SUFFIX_FROM_FILE")
+	require.NotEqual(t, -1, prefixIdx, "Prefix should be in the prompt")
+	require.NotEqual(t, -1, mainIdx, "Main workflow runtime-import should be in the prompt")
+	require.NotEqual(t, -1, suffixIdx, "Suffix loaded from file should be in the prompt")
+
+	assert.Less(t, prefixIdx, mainIdx, "Prefix should come before the main prompt")
+	assert.Less(t, mainIdx, suffixIdx, "Suffix should come after the main prompt")
+	assert.NotContains(t, lockContent, "SUFFIX_FROM_FILE for ${{ github.repository }}", "Expressions in the suffix should be replaced with environment variables")
+}
+
+func TestCompileWorkflowRejectsUnsafePromptAffixExpressions(t *testing.T) {
+	tests := []struct {
+		name       string
+		engine     string
+		suffixFile string
+		errField   string
+	}{
+		{
+			name:     "inline prefix",
+			engine:   "  prompt-prefix: \"${{ secrets.MY_SECRET }}\"\n",
+			errField: "engine.prompt-prefix",
+		},
+		{
+			name:       "suffix loaded from file",
+			engine:     "  prompt-suffix:\n    file: .github/prompts/suffix.md\n",
+			suffixFile: "Token: ${{ secrets.MY_SECRET }}\n",
+			errField:   "engine.prompt-suffix",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repoRoot := testutil.TempDir(t, "prompt-affix-unsafe-test")
+			workflowsDir := filepath.Join(repoRoot, ".github", "workflows")
+			require.NoError(t, os.MkdirAll(workflowsDir, 0755), "Failed to create workflows dir")
+			if tt.suffixFile != "" {
+				promptsDir := filepath.Join(repoRoot, ".github", "prompts")
+				require.NoError(t, os.MkdirAll(promptsDir, 0755), "Failed to create prompts dir")
+				require.NoError(t, os.WriteFile(filepath.Join(promptsDir, "suffix.md"), []byte(tt.suffixFile), 0644), "Failed to write suffix file")
+			}
+
+			workflow := "---\non: push\npermissions:\n  contents: read\nengine:\n  id: copilot\n" + tt.engine + "---\n\n# Affix Test\n"
+			workflowFile := filepath.Join(workflowsDir, "affix.md")
+			require.NoError(t, os.WriteFile(workflowFile, []byte(workflow), 0644), "Failed to write workflow file")
+
+			_, err := NewCompiler().CompileWorkflowDryRun(workflowFile)
+			require.Error(t, err, "Secrets in prompt affixes should be rejected")
+			assert.Contains(t, err.Error(), tt.errField, "Error should name the affix")
+			assert.Contains(t, err.Error(), "secrets.MY_SECRET", "Error should name the unauthorized expression")
+		})
+	}
+}
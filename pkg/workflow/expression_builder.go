@@ -257,6 +257,21 @@ func BuildEventTypeEquals(eventType string) *ComparisonNode {
 	)
 }
 
+// BuildEventTypeIn creates a condition to check if the event type is one of the given values.
+// The terms are chained with BuildOr in order, so the result renders exactly like a hand-built
+// OR chain of BuildEventTypeEquals conditions. A single type renders as a plain comparison.
+func BuildEventTypeIn(eventTypes ...string) ConditionNode {
+	if len(eventTypes) == 0 {
+		return BuildBooleanLiteral(false)
+	}
+
+	var condition ConditionNode = BuildEventTypeEquals(eventTypes[0])
+	for _, eventType := range eventTypes[1:] {
+		condition = BuildOr(condition, BuildEventTypeEquals(eventType))
+	}
+	return condition
+}
+
 // BuildRefStartsWith creates a condition to check if github.ref starts with a prefix
 func BuildRefStartsWith(prefix string) *FunctionCallNode {
 	return BuildFunctionCall("startsWith",
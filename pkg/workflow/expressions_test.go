@@ -717,6 +717,35 @@ func TestConvenienceHelpers(t *testing.T) {
 		}
 	})
 
+	t.Run("BuildEventTypeIn", func(t *testing.T) {
+		tests := []struct {
+			eventTypes []string
+			expected   ConditionNode
+		}{
+			{
+				eventTypes: []string{"issues"},
+				expected:   BuildEventTypeEquals("issues"),
+			},
+			{
+				eventTypes: []string{"issues", "issue_comment"},
+				expected:   BuildOr(BuildEventTypeEquals("issues"), BuildEventTypeEquals("issue_comment")),
+			},
+			{
+				eventTypes: []string{"issues", "issue_comment", "pull_request"},
+				expected: BuildOr(
+					BuildOr(BuildEventTypeEquals("issues"), BuildEventTypeEquals("issue_comment")),
+					BuildEventTypeEquals("pull_request"),
+				),
+			},
+		}
+		for _, tt := range tests {
+			expected := tt.expected.Render()
+			if result := BuildEventTypeIn(tt.eventTypes...).Render(); result != expected {
+				t.Errorf("BuildEventTypeIn(%v): expected '%s', got '%s'", tt.eventTypes, expected, result)
+			}
+		}
+	})
+
 	t.Run("BuildRefStartsWith", func(t *testing.T) {
 		node := BuildRefStartsWith("refs/heads/main")
 		expected := "startsWith(github.ref, 'refs/heads/main')"
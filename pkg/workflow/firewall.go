@@ -1,6 +1,8 @@
 package workflow
 
 import (
+	"fmt"
+	"regexp"
 	"strings"
 
 	"github.com/github/gh-aw/pkg/constants"
@@ -9,11 +11,15 @@ import (
 
 var firewallLog = logger.New("workflow:firewall")
 
+// awfImageTagPattern matches valid Docker image tags accepted for the firewall image-tag override
+var awfImageTagPattern = regexp.MustCompile(`^[A-Za-z0-9_][A-Za-z0-9_.-]{0,127}$`)
+
 // FirewallConfig represents AWF (gh-aw-firewall) configuration for network egress control.
 // These settings are specific to the AWF sandbox and do not apply to Sandbox Runtime (SRT).
 type FirewallConfig struct {
 	Enabled       bool     `yaml:"enabled,omitempty"`        // Enable/disable AWF (default: true for copilot when network restrictions present)
 	Version       string   `yaml:"version,omitempty"`        // AWF version (empty = latest)
+	ImageTag      string   `yaml:"image_tag,omitempty"`      // AWF container image tag override (empty = derived from Version)
 	Args          []string `yaml:"args,omitempty"`           // Additional arguments to pass to AWF
 	LogLevel      string   `yaml:"log_level,omitempty"`      // AWF log level (default: "info")
 	CleanupScript string   `yaml:"cleanup_script,omitempty"` // Cleanup script path (default: "./scripts/ci/cleanup.sh")
@@ -69,6 +75,28 @@ func getFirewallConfig(workflowData *WorkflowData) *FirewallConfig {
 	return nil
 }
 
+// getAWFLogLevel returns the configured AWF log level, defaulting to "info"
+func getAWFLogLevel(firewallConfig *FirewallConfig) string {
+	if firewallConfig != nil && firewallConfig.LogLevel != "" {
+		return firewallConfig.LogLevel
+	}
+	return "info"
+}
+
+// awfLogLevelArg is the AWF --log-level value. It reads the GH_AW_FIREWALL_LOG_LEVEL
+// environment variable set by applyFirewallLogLevelEnv, so the expression is never
+// interpolated into the shell script.
+const awfLogLevelArg = `"${` + constants.EnvVarFirewallLogLevel + `}"`
+
+// applyFirewallLogLevelEnv sets GH_AW_FIREWALL_LOG_LEVEL for the AWF execution step.
+// The level comes from the GH_AW_FIREWALL_LOG_LEVEL repository variable when set, so a
+// single run can be debugged without editing the workflow, and otherwise from the
+// configured firewall log-level.
+func applyFirewallLogLevelEnv(env map[string]string, workflowData *WorkflowData) {
+	logLevel := getAWFLogLevel(getFirewallConfig(workflowData))
+	env[constants.EnvVarFirewallLogLevel] = fmt.Sprintf("${{ vars.%s || '%s' }}", constants.EnvVarFirewallLogLevel, logLevel)
+}
+
 // getAgentConfig returns the agent sandbox configuration from sandbox config
 func getAgentConfig(workflowData *WorkflowData) *AgentSandboxConfig {
 	if workflowData == nil || workflowData.SandboxConfig == nil {
@@ -176,9 +204,16 @@ func enableFirewallByDefaultForEngine(engineID string, networkPermissions *Netwo
 
 // getAWFImageTag returns the AWF Docker image tag to use for the --image-tag flag.
 // This ensures the AWF binary pulls its matching Docker image version instead of latest.
-// Returns the version from firewall config if specified, otherwise returns the default version.
-// The version is returned without the 'v' prefix (e.g., "0.7.0" instead of "v0.7.0").
+// Returns the image-tag override from firewall config unchanged if specified. Otherwise returns
+// the version from firewall config if specified, or the default version, without the 'v'
+// prefix (e.g., "0.7.0" instead of "v0.7.0").
 func getAWFImageTag(firewallConfig *FirewallConfig) string {
+	// An explicit image tag wins over the version so unreleased AWF builds can be tested
+	if firewallConfig != nil && firewallConfig.ImageTag != "" {
+		firewallLog.Printf("Using AWF image tag override: %s", firewallConfig.ImageTag)
+		return firewallConfig.ImageTag
+	}
+
 	var version string
 	if firewallConfig != nil && firewallConfig.Version != "" {
 		version = firewallConfig.Version
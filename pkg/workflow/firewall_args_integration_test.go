@@ -229,8 +229,8 @@ Test workflow with SSL bump and allow-urls configuration.
 			t.Error("Compiled workflow should still contain '--env-all' flag")
 		}
 
-		if !strings.Contains(lockYAML, "--log-level debug") {
-			t.Error("Compiled workflow should contain '--log-level debug'")
+		if !strings.Contains(lockYAML, "GH_AW_FIREWALL_LOG_LEVEL: ${{ vars.GH_AW_FIREWALL_LOG_LEVEL || 'debug' }}") {
+			t.Error("Compiled workflow should fall back to the 'debug' log level")
 		}
 	})
 }
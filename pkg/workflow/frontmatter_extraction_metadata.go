@@ -242,6 +242,52 @@ func (c *Compiler) extractToolsStartupTimeout(tools map[string]any) (int, error)
 	return 0, nil
 }
 
+// extractToolTimeouts extracts the per-tool timeout overrides from tools.tool-timeouts
+// Returns nil if not set (tools fall back to the global tools.timeout)
+// Returns error if any override is not an integer or is less than 1 second
+func (c *Compiler) extractToolTimeouts(tools map[string]any) (map[string]int, error) {
+	if tools == nil {
+		return nil, nil
+	}
+
+	timeoutsValue, exists := tools["tool-timeouts"]
+	if !exists {
+		return nil, nil
+	}
+
+	timeoutsMap, ok := timeoutsValue.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("tools.tool-timeouts must be a map of tool names to timeouts in seconds, got %T", timeoutsValue)
+	}
+
+	result := make(map[string]int, len(timeoutsMap))
+	for toolName, value := range timeoutsMap {
+		var timeout int
+		switch v := value.(type) {
+		case int:
+			timeout = v
+		case int64:
+			timeout = int(v)
+		case uint:
+			timeout = safeUintToInt(v)
+		case uint64:
+			timeout = safeUint64ToInt(v)
+		case float64:
+			timeout = int(v)
+		default:
+			return nil, fmt.Errorf("tools.tool-timeouts.%s must be an integer, got %T", toolName, value)
+		}
+
+		if timeout < 1 {
+			return nil, fmt.Errorf("tools.tool-timeouts.%s must be at least 1 second, got %d. Example:\ntools:\n  tool-timeouts:\n    playwright: 300", toolName, timeout)
+		}
+		result[toolName] = timeout
+	}
+
+	frontmatterMetadataLog.Printf("Extracted tools.tool-timeouts: %d overrides", len(result))
+	return result, nil
+}
+
 // extractMapFromFrontmatter is a generic helper to extract a map[string]any from frontmatter
 // This now uses the structured extraction helper for better error handling
 func extractMapFromFrontmatter(frontmatter map[string]any, key string) map[string]any {
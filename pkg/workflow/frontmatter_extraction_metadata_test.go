@@ -4,6 +4,9 @@ package workflow
 
 import (
 	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestExtractFeatures(t *testing.T) {
@@ -218,3 +221,64 @@ func TestExtractToolsStartupTimeout(t *testing.T) {
 		})
 	}
 }
+
+func TestExtractToolTimeouts(t *testing.T) {
+	compiler := &Compiler{}
+
+	tests := []struct {
+		name     string
+		tools    map[string]any
+		expected map[string]int
+		wantErr  string
+	}{
+		{
+			name:     "nil tools",
+			tools:    nil,
+			expected: nil,
+		},
+		{
+			name:     "tool-timeouts not present",
+			tools:    map[string]any{"timeout": 60},
+			expected: nil,
+		},
+		{
+			name: "mixed numeric types",
+			tools: map[string]any{
+				"tool-timeouts": map[string]any{
+					"playwright": 300,
+					"github":     uint64(45),
+					"serena":     120.0,
+				},
+			},
+			expected: map[string]int{"playwright": 300, "github": 45, "serena": 120},
+		},
+		{
+			name:    "not a map",
+			tools:   map[string]any{"tool-timeouts": 300},
+			wantErr: "must be a map",
+		},
+		{
+			name:    "non-integer override",
+			tools:   map[string]any{"tool-timeouts": map[string]any{"playwright": "slow"}},
+			wantErr: "tools.tool-timeouts.playwright must be an integer",
+		},
+		{
+			name:    "zero override",
+			tools:   map[string]any{"tool-timeouts": map[string]any{"playwright": 0}},
+			wantErr: "must be at least 1 second",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := compiler.extractToolTimeouts(tt.tools)
+			if tt.wantErr != "" {
+				require.Error(t, err, "Expected validation error")
+				assert.Contains(t, err.Error(), tt.wantErr, "Error message should describe the problem")
+				return
+			}
+			require.NoError(t, err, "Expected valid tool timeouts")
+			assert.Equal(t, tt.expected, result, "Tool timeouts should match")
+		})
+	}
+}
@@ -119,6 +119,18 @@ func (c *Compiler) extractFirewallConfig(firewall any) *FirewallConfig {
 			}
 		}
 
+		// Extract image-tag if present. Only valid Docker tags are kept, since the tag is
+		// written into shell commands; the schema reports invalid values to the user.
+		if imageTag, hasImageTag := firewallObj["image-tag"]; hasImageTag {
+			if imageTagStr, ok := imageTag.(string); ok {
+				if awfImageTagPattern.MatchString(imageTagStr) {
+					config.ImageTag = imageTagStr
+				} else {
+					frontmatterExtractionSecurityLog.Printf("Ignoring invalid firewall image-tag: %q", imageTagStr)
+				}
+			}
+		}
+
 		// Extract log-level if present
 		if logLevel, hasLogLevel := firewallObj["log-level"]; hasLogLevel {
 			if logLevelStr, ok := logLevel.(string); ok {
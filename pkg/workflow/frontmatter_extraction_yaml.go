@@ -506,8 +506,10 @@ func (c *Compiler) extractExpressionFromIfString(ifString string) string {
 	return ifString
 }
 
-// extractCommandConfig extracts command configuration from frontmatter including name and events
-func (c *Compiler) extractCommandConfig(frontmatter map[string]any) (commandNames []string, commandEvents []string) {
+// extractCommandConfig extracts command configuration from frontmatter including name and events.
+// Command names come from name (a string or a list) or its alias names (a list of aliases);
+// every name triggers the workflow and the matched one is exported as slash_command.
+func (c *Compiler) extractCommandConfig(frontmatter map[string]any) (commandNames []string, commandEvents []string, err error) {
 	// Check new format: on.slash_command or on.slash_command.name (preferred)
 	// Also check legacy format: on.command or on.command.name (deprecated)
 	if onValue, exists := frontmatter["on"]; exists {
@@ -515,6 +517,7 @@ func (c *Compiler) extractCommandConfig(frontmatter map[string]any) (commandName
 			var commandValue any
 			var hasCommand bool
 			var isDeprecated bool
+			commandKey := "slash_command"
 
 			// Check for slash_command first (preferred)
 			if slashCommandValue, hasSlashCommand := onMap["slash_command"]; hasSlashCommand {
@@ -526,25 +529,35 @@ func (c *Compiler) extractCommandConfig(frontmatter map[string]any) (commandName
 				commandValue = legacyCommandValue
 				hasCommand = true
 				isDeprecated = true
+				commandKey = "command"
 			}
 
 			if hasCommand {
 				// Show deprecation warning if using old field name
 				if isDeprecated {
-					fmt.Fprintln(os.Stderr, console.FormatWarningMessage("The 'command:' trigger field is deprecated. Please use 'slash_command:' instead."))
-					c.IncrementWarningCount()
+					warningMsg := "The 'command:' trigger field is deprecated. Please use 'slash_command:' instead."
+					fmt.Fprintln(os.Stderr, console.FormatWarningMessage(warningMsg))
+					c.addWarning(warningMsg)
 				}
 
 				// Check if command is a string (shorthand format)
 				if commandStr, ok := commandValue.(string); ok {
-					return []string{commandStr}, nil // nil means default (all events)
+					return []string{commandStr}, nil, nil // nil means default (all events)
 				}
 				// Check if command is a map with a name key (object format)
 				if commandMap, ok := commandValue.(map[string]any); ok {
 					var names []string
 					var events []string
 
-					if nameValue, hasName := commandMap["name"]; hasName {
+					nameValue, hasName := commandMap["name"]
+					namesValue, hasNames := commandMap["names"]
+					if hasName && hasNames {
+						return nil, nil, fmt.Errorf("on.%s: use either 'name' or 'names' to list command names, not both", commandKey)
+					}
+					if hasNames {
+						nameValue, hasName = namesValue, true
+					}
+					if hasName {
 						// Handle string or array of strings
 						if nameStr, ok := nameValue.(string); ok {
 							names = []string{nameStr}
@@ -556,17 +569,20 @@ func (c *Compiler) extractCommandConfig(frontmatter map[string]any) (commandName
 							}
 						}
 					}
+					if err := validateCommandNames(names); err != nil {
+						return nil, nil, fmt.Errorf("on.%s: %w", commandKey, err)
+					}
 
 					// Extract events field
 					if eventsValue, hasEvents := commandMap["events"]; hasEvents {
 						events = ParseCommandEvents(eventsValue)
 					}
 
-					return names, events
+					return names, events, nil
 				}
 			}
 		}
 	}
 
-	return nil, nil
+	return nil, nil, nil
 }
@@ -3,8 +3,12 @@
 package workflow
 
 import (
+	"reflect"
+	"slices"
 	"strings"
 	"testing"
+
+	"github.com/github/gh-aw/pkg/parser"
 )
 
 func TestUnmarshalFromMap(t *testing.T) {
@@ -1332,3 +1336,29 @@ func TestTypedConfigsBackwardCompatibility(t *testing.T) {
 		}
 	})
 }
+
+// TestFrontmatterConfigFieldsInSchema keeps the exported frontmatter schema in sync with
+// the typed frontmatter configuration
+func TestFrontmatterConfigFieldsInSchema(t *testing.T) {
+	schemaKeys, err := parser.GetFrontmatterTopLevelKeys()
+	if err != nil {
+		t.Fatalf("GetFrontmatterTopLevelKeys() error = %v", err)
+	}
+
+	// Fields parsed for compatibility that are intentionally not part of the schema
+	notInSchema := map[string]bool{
+		"version": true,
+		"include": true,
+	}
+
+	configType := reflect.TypeFor[FrontmatterConfig]()
+	for i := range configType.NumField() {
+		name, _, _ := strings.Cut(configType.Field(i).Tag.Get("json"), ",")
+		if name == "" || name == "-" || notInSchema[name] {
+			continue
+		}
+		if !slices.Contains(schemaKeys, name) {
+			t.Errorf("FrontmatterConfig field %q is missing from the main workflow schema", name)
+		}
+	}
+}
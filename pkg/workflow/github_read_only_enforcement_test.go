@@ -0,0 +1,122 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetGitHubReadOnlyEnforcement(t *testing.T) {
+	tests := []struct {
+		name       string
+		githubTool any
+		expected   bool
+	}{
+		{name: "enabled", githubTool: map[string]any{"read-only-enforcement": true}, expected: true},
+		{name: "disabled", githubTool: map[string]any{"read-only-enforcement": false}, expected: false},
+		{name: "not specified defaults off", githubTool: map[string]any{"mode": "local"}, expected: false},
+		{name: "nil config defaults off", githubTool: nil, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, getGitHubReadOnlyEnforcement(tt.githubTool), "Read-only enforcement setting should match")
+		})
+	}
+}
+
+func TestValidateGitHubReadOnlyEnforcement(t *testing.T) {
+	config := parseGitHubTool(map[string]any{"read-only-enforcement": true})
+	require.NotNil(t, config, "GitHub tool config should be parsed")
+	assert.True(t, config.ReadOnlyEnforcement, "read-only-enforcement should be parsed")
+	require.NoError(t, validateGitHubReadOnlyEnforcement(&Tools{GitHub: config}, "test"), "Enforcement with read-only mode should be valid")
+
+	config = parseGitHubTool(map[string]any{"read-only-enforcement": true, "read-only": false})
+	err := validateGitHubReadOnlyEnforcement(&Tools{GitHub: config}, "test")
+	require.Error(t, err, "Enforcement without read-only mode should be rejected")
+	assert.Contains(t, err.Error(), "requires 'read-only: true'", "Error should explain the requirement")
+
+	require.NoError(t, validateGitHubReadOnlyEnforcement(nil, "test"), "Missing tools should be valid")
+}
+
+func TestGetJobWritePermissions(t *testing.T) {
+	assert.Empty(t, getJobWritePermissions("permissions:\n  contents: read\n  issues: read"), "Read permissions should not be reported")
+	assert.Equal(t, []string{"contents", "issues"}, getJobWritePermissions("permissions:\n  contents: write\n  issues: write\n  id-token: write"), "Write permissions other than id-token should be reported")
+	assert.Len(t, getJobWritePermissions("permissions: write-all"), len(GetAllPermissionScopes())-1, "write-all should report every scope except id-token")
+}
+
+func TestCompileWorkflowWithReadOnlyEnforcement(t *testing.T) {
+	tests := []struct {
+		name         string
+		githubTool   string
+		expectStep   bool
+		expectedEnvs []string
+	}{
+		{
+			name:         "enabled",
+			githubTool:   "    read-only-enforcement: true\n",
+			expectStep:   true,
+			expectedEnvs: []string{"GH_AW_READ_ONLY_TOKEN: ${{ secrets.GH_AW_GITHUB_MCP_SERVER_TOKEN || secrets.GH_AW_GITHUB_TOKEN || secrets.GITHUB_TOKEN }}"},
+		},
+		{
+			name:         "enabled with custom token",
+			githubTool:   "    read-only-enforcement: true\n    github-token: ${{ secrets.READ_TOKEN }}\n",
+			expectStep:   true,
+			expectedEnvs: []string{"GH_AW_READ_ONLY_TOKEN: ${{ secrets.READ_TOKEN }}"},
+		},
+		{
+			name:       "default off",
+			githubTool: "    toolsets: [default]\n",
+			expectStep: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := testutil.TempDir(t, "read-only-enforcement-test")
+			content := "---\non: workflow_dispatch\npermissions:\n  contents: read\nengine: copilot\ntools:\n  github:\n" + tt.githubTool + "---\n\n# Test Workflow\n"
+			testFile := filepath.Join(tmpDir, "read-only-enforcement.md")
+			require.NoError(t, os.WriteFile(testFile, []byte(content), 0644), "Failed to write test file")
+
+			require.NoError(t, NewCompiler().CompileWorkflow(testFile), "Workflow should compile")
+
+			lockContent, err := os.ReadFile(filepath.Join(tmpDir, "read-only-enforcement.lock.yml"))
+			require.NoError(t, err, "Lock file should be written")
+			lock := string(lockContent)
+			if !tt.expectStep {
+				assert.NotContains(t, lock, "Verify GitHub MCP token is read-only", "Verification step should be opt-in")
+				return
+			}
+
+			assert.Contains(t, lock, "- name: Verify GitHub MCP token is read-only", "Verification step should be emitted")
+			assert.Contains(t, lock, "validate_read_only_token.cjs", "Verification step should run the token check script")
+			assert.NotContains(t, lock, "GH_AW_JOB_WRITE_PERMISSIONS", "Read-only job permissions should not be reported")
+			for _, env := range tt.expectedEnvs {
+				assert.Contains(t, lock, env, "Verification step should check the MCP server token")
+			}
+			assert.Less(t, strings.Index(lock, "Verify GitHub MCP token is read-only"), strings.Index(lock, "Start MCP gateway"), "Token should be verified before the MCP gateway starts")
+		})
+	}
+}
+
+func TestReadOnlyTokenVerificationStepWithAppToken(t *testing.T) {
+	data := &WorkflowData{
+		Tools:       map[string]any{"github": map[string]any{"read-only-enforcement": true}},
+		ParsedTools: &Tools{GitHub: &GitHubToolConfig{ReadOnly: true, ReadOnlyEnforcement: true, App: &GitHubAppConfig{AppID: "123"}}},
+		Permissions: "permissions:\n  contents: read\n  issues: write",
+	}
+
+	var yaml strings.Builder
+	NewCompiler().generateGitHubMCPReadOnlyTokenVerificationStep(&yaml, data)
+	output := yaml.String()
+
+	assert.Contains(t, output, "GH_AW_READ_ONLY_TOKEN: ${{ steps.github-mcp-app-token.outputs.token }}", "App token should be verified when configured")
+	assert.Contains(t, output, `GH_AW_JOB_WRITE_PERMISSIONS: "issues"`, "Job write permissions should be passed for installation tokens")
+}
@@ -0,0 +1,97 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGitHubReadOnlyShorthand(t *testing.T) {
+	tests := []struct {
+		name        string
+		github      string
+		expected    []string
+		errContains string
+	}{
+		{
+			name:   "read-only expands to the read-only tools of the toolsets",
+			github: "    read-only: true\n    toolsets: [issues, pull_requests]",
+			expected: []string{
+				`"GITHUB_READ_ONLY": "1"`,
+				`"GITHUB_TOOLSETS": "issues,pull_requests"`,
+			},
+		},
+		{
+			name:   "read-only alone uses the default toolsets",
+			github: "    read-only: true",
+			expected: []string{
+				`"GITHUB_READ_ONLY": "1"`,
+				`"GITHUB_TOOLSETS": "context,repos,issues,pull_requests"`,
+			},
+		},
+		{
+			name:     "allowed without an explicit read-only",
+			github:   "    allowed: [list_issues]",
+			expected: []string{`"GITHUB_READ_ONLY": "1"`},
+		},
+		{
+			name:     "allowed with read-only disabled",
+			github:   "    read-only: false\n    allowed: [list_issues]",
+			expected: []string{`"GITHUB_TOOLSETS"`},
+		},
+		{
+			name:        "read-only combined with allowed",
+			github:      "    read-only: true\n    allowed: [list_issues, get_file_contents]",
+			errContains: "'read-only: true' cannot be combined with 'allowed'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content := "---\non: issues\npermissions:\n  contents: read\n  issues: read\n  pull-requests: read\nengine: copilot\ntools:\n  github:\n" + tt.github + "\n---\n\n# GitHub read-only\n"
+			markdownPath := filepath.Join(testutil.TempDir(t, "github-read-only"), "test.md")
+
+			lockContent, err := NewCompiler().CompileString(content, markdownPath)
+			if tt.errContains != "" {
+				require.Error(t, err, "Compilation should fail")
+				assert.Contains(t, err.Error(), tt.errContains, "Error should explain the read-only conflict")
+				return
+			}
+			require.NoError(t, err, "Compilation should succeed")
+			for _, expected := range tt.expected {
+				assert.Contains(t, lockContent, expected, "GitHub MCP server should be configured for read-only mode")
+			}
+		})
+	}
+}
+
+func TestValidateGitHubReadOnlyAllowed(t *testing.T) {
+	tests := []struct {
+		name    string
+		github  any
+		wantErr bool
+	}{
+		{name: "read-only only", github: map[string]any{"read-only": true}},
+		{name: "allowed only", github: map[string]any{"allowed": []any{"list_issues"}}},
+		{name: "read-only false with allowed", github: map[string]any{"read-only": false, "allowed": []any{"list_issues"}}},
+		{name: "read-only true with allowed", github: map[string]any{"read-only": true, "allowed": []any{"list_issues"}}, wantErr: true},
+		{name: "github enabled without config", github: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tools := NewTools(map[string]any{"github": tt.github})
+			err := validateGitHubReadOnlyAllowed(tools, "test-workflow")
+			if tt.wantErr {
+				assert.Error(t, err, "read-only with allowed should be rejected")
+				return
+			}
+			assert.NoError(t, err, "Configuration should be accepted")
+		})
+	}
+}
@@ -1,9 +1,12 @@
 package workflow
 
 import (
+	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/parser"
 )
 
 var toolsetsLog = logger.New("workflow:github_toolsets")
@@ -18,6 +21,9 @@ var DefaultGitHubToolsets = []string{"context", "repos", "issues", "pull_request
 // Use this when the workflow will run in GitHub Actions with GITHUB_TOKEN.
 var ActionFriendlyGitHubToolsets = []string{"context", "repos", "issues", "pull_requests"}
 
+// githubToolsetGroups are the toolset names that expand into several toolsets
+var githubToolsetGroups = []string{"default", "action-friendly", "all"}
+
 // ParseGitHubToolsets parses the toolsets string and expands "default" and "all"
 // into their constituent toolsets. It handles comma-separated lists and deduplicates.
 func ParseGitHubToolsets(toolsetsStr string) []string {
@@ -78,3 +84,76 @@ func ParseGitHubToolsets(toolsetsStr string) []string {
 	toolsetsLog.Printf("Parsed toolsets result: %d unique toolsets expanded from input", len(expanded))
 	return expanded
 }
+
+// knownGitHubToolsets returns all valid toolset names (groups and individual toolsets), sorted
+func knownGitHubToolsets() []string {
+	known := make([]string, 0, len(githubToolsetGroups)+len(toolsetPermissionsMap))
+	known = append(known, githubToolsetGroups...)
+	for toolset := range toolsetPermissionsMap {
+		known = append(known, toolset)
+	}
+	sort.Strings(known)
+	return known
+}
+
+// ResolveToolset validates a GitHub toolset name against the known toolsets. Exact matches
+// are returned unchanged; otherwise the error suggests the closest known toolset, or lists
+// all valid toolsets when none is close.
+func ResolveToolset(name string) (string, error) {
+	known := knownGitHubToolsets()
+	for _, toolset := range known {
+		if name == toolset {
+			return name, nil
+		}
+	}
+
+	toolsetsLog.Printf("Unknown GitHub toolset: %q", name)
+	suggestion := ""
+	for _, toolset := range known {
+		if strings.EqualFold(name, toolset) {
+			suggestion = toolset
+			break
+		}
+	}
+	if suggestion == "" {
+		if matches := parser.FindClosestMatches(name, known, 1); len(matches) > 0 {
+			suggestion = matches[0]
+		}
+	}
+
+	if suggestion != "" {
+		return "", fmt.Errorf("unknown GitHub toolset '%s'. Did you mean: %s?", name, suggestion)
+	}
+	return "", fmt.Errorf("unknown GitHub toolset '%s'. Valid toolsets are: %s", name, strings.Join(known, ", "))
+}
+
+// validateGitHubToolsetNames checks every toolset listed in tools.github.toolsets (or the
+// singular toolset alias) so misspelled toolsets are reported with a suggestion before
+// schema validation produces a generic enum error
+func validateGitHubToolsetNames(frontmatter map[string]any) error {
+	tools, ok := frontmatter["tools"].(map[string]any)
+	if !ok {
+		return nil
+	}
+	github, ok := tools["github"].(map[string]any)
+	if !ok {
+		return nil
+	}
+
+	for _, key := range []string{"toolsets", "toolset"} {
+		toolsets, ok := github[key].([]any)
+		if !ok {
+			continue
+		}
+		for _, item := range toolsets {
+			name, ok := item.(string)
+			if !ok {
+				continue
+			}
+			if _, err := ResolveToolset(name); err != nil {
+				return fmt.Errorf("invalid tools.github.%s: %w", key, err)
+			}
+		}
+	}
+	return nil
+}
@@ -3,7 +3,13 @@
 package workflow
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
+
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestDefaultGitHubToolsets(t *testing.T) {
@@ -197,3 +203,58 @@ func TestParseGitHubToolsetsDeduplication(t *testing.T) {
 		})
 	}
 }
+
+func TestResolveToolset(t *testing.T) {
+	tests := []struct {
+		name        string
+		toolset     string
+		errContains string
+	}{
+		{name: "exact group", toolset: "default"},
+		{name: "exact toolset", toolset: "pull_requests"},
+		{name: "action-friendly group", toolset: "action-friendly"},
+		{name: "default typo", toolset: "defalt", errContains: "Did you mean: default?"},
+		{name: "issues typo", toolset: "isssues", errContains: "Did you mean: issues?"},
+		{name: "pull requests with hyphen", toolset: "pull-requests", errContains: "Did you mean: pull_requests?"},
+		{name: "repos typo", toolset: "repo", errContains: "Did you mean: repos?"},
+		{name: "wrong case", toolset: "Issues", errContains: "Did you mean: issues?"},
+		{name: "no close match", toolset: "kubernetes", errContains: "Valid toolsets are: action-friendly, actions, all,"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resolved, err := ResolveToolset(tt.toolset)
+			if tt.errContains != "" {
+				require.Error(t, err, "Unknown toolset should error")
+				assert.Contains(t, err.Error(), tt.errContains, "Error message should match")
+				assert.Empty(t, resolved, "Unknown toolset should not resolve")
+				return
+			}
+			require.NoError(t, err, "Known toolset should resolve")
+			assert.Equal(t, tt.toolset, resolved, "Exact matches should pass through untouched")
+		})
+	}
+}
+
+func TestCompileWorkflowWithMisspelledToolset(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "toolset-typo-test")
+	content := `---
+on: workflow_dispatch
+permissions:
+  contents: read
+  issues: read
+engine: copilot
+tools:
+  github:
+    toolsets: [repos, isssues]
+---
+
+# Test Workflow
+`
+	testFile := filepath.Join(tmpDir, "toolset-typo.md")
+	require.NoError(t, os.WriteFile(testFile, []byte(content), 0644), "Failed to write test file")
+
+	err := NewCompiler().CompileWorkflow(testFile)
+	require.Error(t, err, "Misspelled toolset should fail compilation")
+	assert.Contains(t, err.Error(), "unknown GitHub toolset 'isssues'. Did you mean: issues?", "Error should suggest the correct toolset")
+}
@@ -77,3 +77,37 @@ func GenerateWorkflowHeader(sourceFile string, generatedBy string, customInstruc
 	headerLog.Printf("Generated header with %d bytes", header.Len())
 	return header.String()
 }
+
+// Lock file header keys. The header is a machine-readable comment block at the very
+// top of every compiled .lock.yml file:
+//
+//	# gh-aw-lock:
+//	#   compiler-version: v1.2.3
+//	#   source: .github/workflows/triage.md
+//	#   source-hash: <hex>
+const (
+	lockFileHeaderMarker        = "# gh-aw-lock:"
+	lockFileHeaderVersionKey    = "compiler-version"
+	lockFileHeaderSourceKey     = "source"
+	lockFileHeaderSourceHashKey = "source-hash"
+)
+
+// generateLockFileHeader generates the machine-readable lock file header recording the
+// compiler version, the source markdown path, and the frontmatter hash of the source.
+// The compiler version is included for released builds only, matching the generated-by
+// line. The hash is the import-aware frontmatter hash also written as frontmatter-hash,
+// so tools can detect a stale lock file offline by recomputing it from the source.
+func generateLockFileHeader(sourcePath string, frontmatterHash string) string {
+	var header strings.Builder
+	header.WriteString(lockFileHeaderMarker + "\n")
+	if IsReleasedVersion(GetVersion()) {
+		fmt.Fprintf(&header, "#   %s: %s\n", lockFileHeaderVersionKey, GetVersion())
+	}
+	if sourcePath != "" {
+		fmt.Fprintf(&header, "#   %s: %s\n", lockFileHeaderSourceKey, workflowSourcePath(sourcePath))
+	}
+	if frontmatterHash != "" {
+		fmt.Fprintf(&header, "#   %s: %s\n", lockFileHeaderSourceHashKey, frontmatterHash)
+	}
+	return header.String()
+}
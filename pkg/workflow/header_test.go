@@ -3,8 +3,13 @@
 package workflow
 
 import (
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
+
+	"github.com/github/gh-aw/pkg/parser"
+	"github.com/github/gh-aw/pkg/testutil"
 )
 
 func TestGenerateWorkflowHeader_VersionInDevBuild(t *testing.T) {
@@ -174,3 +179,73 @@ func TestGenerateWorkflowHeader_WithReleaseFlag(t *testing.T) {
 		})
 	}
 }
+
+func TestGenerateLockFileHeader(t *testing.T) {
+	originalVersion := compilerVersion
+	originalIsRelease := isReleaseBuild
+	defer func() {
+		compilerVersion = originalVersion
+		isReleaseBuild = originalIsRelease
+	}()
+	SetVersion("v1.2.3")
+	SetIsRelease(true)
+
+	header := generateLockFileHeader("/repo/.github/workflows/triage.md", "abc123")
+
+	if !strings.HasPrefix(header, "# gh-aw-lock:\n") {
+		t.Errorf("Expected header to start with the lock header marker, got:\n%s", header)
+	}
+	if !strings.Contains(header, "#   compiler-version: v1.2.3\n") {
+		t.Errorf("Expected header to contain the compiler version, got:\n%s", header)
+	}
+	if !strings.Contains(header, "#   source: .github/workflows/triage.md\n") {
+		t.Errorf("Expected header to contain the repository-relative source path, got:\n%s", header)
+	}
+	if !strings.Contains(header, "#   source-hash: abc123\n") {
+		t.Errorf("Expected header to contain the frontmatter hash, got:\n%s", header)
+	}
+
+	// Identical input produces an identical header
+	if again := generateLockFileHeader("/repo/.github/workflows/triage.md", "abc123"); again != header {
+		t.Errorf("Expected a stable header for identical input, got:\n%s\nand:\n%s", header, again)
+	}
+}
+
+func TestGenerateLockFileHeader_DevBuildOmitsVersion(t *testing.T) {
+	originalVersion := compilerVersion
+	defer func() { compilerVersion = originalVersion }()
+	SetVersion("dev")
+
+	header := generateLockFileHeader("/repo/.github/workflows/triage.md", "abc123")
+
+	if strings.Contains(header, "compiler-version") {
+		t.Errorf("Expected header to omit the compiler version for dev builds, got:\n%s", header)
+	}
+}
+
+func TestCompiledLockFileStartsWithLockHeader(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "lock-header-test")
+	markdownPath := filepath.Join(tmpDir, "header.md")
+	content := "---\non: issues\npermissions:\n  contents: read\nengine: copilot\n---\n\n# Header Test\n"
+	if err := os.WriteFile(markdownPath, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write workflow: %v", err)
+	}
+
+	compiled, err := NewCompiler().CompileWorkflowDryRun(markdownPath)
+	if err != nil {
+		t.Fatalf("Failed to compile workflow: %v", err)
+	}
+
+	// The lock header reuses the import-aware frontmatter hash
+	expectedHash, err := parser.ComputeFrontmatterHashFromFile(markdownPath, parser.NewImportCache(tmpDir))
+	if err != nil {
+		t.Fatalf("Failed to compute frontmatter hash: %v", err)
+	}
+	expectedHeader := generateLockFileHeader(markdownPath, expectedHash) + "#\n"
+	if !strings.HasPrefix(compiled, expectedHeader) {
+		t.Errorf("Expected lock file to start with:\n%s\ngot:\n%s", expectedHeader, strings.Join(strings.SplitN(compiled, "\n", 8)[:7], "\n"))
+	}
+	if !strings.Contains(compiled, "# frontmatter-hash: "+expectedHash+"\n") {
+		t.Errorf("Expected the lock header hash to match frontmatter-hash %s", expectedHash)
+	}
+}
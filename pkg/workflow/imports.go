@@ -501,6 +501,9 @@ func mergeSafeOutputConfig(result *SafeOutputsConfig, config map[string]any, c *
 		return result, nil
 	}
 
+	// Handlers configured before this import keep their own if conditions
+	existingHandlers := BuildHandlerManagerConfig(result)
+
 	// Merge each safe output type (only set if nil in result)
 	if result.CreateIssues == nil && importedConfig.CreateIssues != nil {
 		result.CreateIssues = importedConfig.CreateIssues
@@ -634,6 +637,17 @@ func mergeSafeOutputConfig(result *SafeOutputsConfig, config map[string]any, c *
 		result.RunsOn = importedConfig.RunsOn
 	}
 
+	// Merge if conditions for the handlers taken from the import
+	for handlerName, condition := range importedConfig.Conditions {
+		if _, exists := existingHandlers[handlerName]; exists {
+			continue
+		}
+		if result.Conditions == nil {
+			result.Conditions = make(map[string]string)
+		}
+		result.Conditions[handlerName] = condition
+	}
+
 	// Merge Messages configuration at field level (main workflow entries override imported entries)
 	if importedConfig.Messages != nil {
 		if result.Messages == nil {
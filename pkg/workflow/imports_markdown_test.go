@@ -358,3 +358,92 @@ This is the main workflow content.`
 		t.Error("Expected runtime-import macro for main workflow in lock file")
 	}
 }
+
+// TestImportsInlined tests that SetInlineImports inlines imported markdown, the main workflow
+// markdown and @include directives into the lock file instead of emitting runtime-import macros
+func TestImportsInlined(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "imports-inlined-test")
+
+	sharedDir := filepath.Join(tmpDir, "shared")
+	if err := os.Mkdir(sharedDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	files := map[string]string{
+		"import.md":       "# Imported Content\n\nThis comes from frontmatter imports.\n\n@include nested.md",
+		"nested.md":       "This comes from an include inside an import.",
+		"main-include.md": "This comes from an include in the main workflow.",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(sharedDir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	workflowContent := `---
+on: issues
+permissions:
+  contents: read
+  issues: read
+engine: claude
+imports:
+  - shared/import.md
+---
+
+# Main Workflow
+
+This is the main workflow content.
+
+@include shared/main-include.md`
+	testFile := filepath.Join(tmpDir, "inlined-workflow.md")
+	if err := os.WriteFile(testFile, []byte(workflowContent), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	compiler := NewCompiler()
+	compiler.SetInlineImports(true)
+	if err := compiler.CompileWorkflow(testFile); err != nil {
+		t.Fatalf("Unexpected error compiling workflow: %v", err)
+	}
+
+	content, err := os.ReadFile(stringutil.MarkdownToLockFile(testFile))
+	if err != nil {
+		t.Fatalf("Failed to read generated lock file: %v", err)
+	}
+	lockContent := string(content)
+
+	if strings.Contains(lockContent, "{{#runtime-import") {
+		t.Error("Expected no runtime-import macros in the lock file when imports are inlined")
+	}
+
+	for _, expected := range []string{
+		"# Imported Content",
+		"This comes from an include inside an import.",
+		"# Main Workflow",
+		"This is the main workflow content.",
+		"This comes from an include in the main workflow.",
+	} {
+		if !strings.Contains(lockContent, expected) {
+			t.Errorf("Expected inlined content %q in lock file", expected)
+		}
+	}
+
+	importedIdx := strings.Index(lockContent, "# Imported Content")
+	mainIdx := strings.Index(lockContent, "# Main Workflow")
+	if importedIdx == -1 || mainIdx == -1 || importedIdx >= mainIdx {
+		t.Error("Expected imported content to be inlined before the main workflow content")
+	}
+
+	// Without the option the same workflow loads its markdown at runtime
+	defaultCompiler := NewCompiler()
+	if err := defaultCompiler.CompileWorkflow(testFile); err != nil {
+		t.Fatalf("Unexpected error compiling workflow: %v", err)
+	}
+	content, err = os.ReadFile(stringutil.MarkdownToLockFile(testFile))
+	if err != nil {
+		t.Fatalf("Failed to read generated lock file: %v", err)
+	}
+	if !strings.Contains(string(content), "{{#runtime-import shared/import.md}}") {
+		t.Error("Expected a runtime-import macro for shared/import.md without inlined imports")
+	}
+}
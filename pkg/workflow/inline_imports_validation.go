@@ -0,0 +1,37 @@
+package workflow
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/console"
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var inlineImportsValidationLog = logger.New("workflow:inline_imports_validation")
+
+// validateInlineImports warns when imports are inlined but the workflow markdown itself still
+// uses {{#runtime-import}} macros, which keep reading files from the repository at runtime
+func (c *Compiler) validateInlineImports(data *WorkflowData) {
+	if !c.inlineImports {
+		return
+	}
+
+	promptContent := data.MarkdownContent
+	if data.EngineConfig != nil {
+		promptContent += "\n" + data.EngineConfig.PromptPrefix + "\n" + data.EngineConfig.PromptSuffix
+	}
+	paths := extractRuntimeImportPaths(promptContent)
+	if len(paths) == 0 {
+		inlineImportsValidationLog.Print("All imports inlined")
+		return
+	}
+
+	inlineImportsValidationLog.Printf("Runtime-import macros remain with inlined imports: %v", paths)
+	warningMsg := fmt.Sprintf(
+		"imports are inlined, but the workflow markdown uses {{#runtime-import}} macros that are still read from the repository at runtime: %s. Replace them with @include to inline the content.",
+		strings.Join(paths, ", "))
+	fmt.Fprintln(os.Stderr, console.FormatWarningMessage(warningMsg))
+	c.addWarning(warningMsg)
+}
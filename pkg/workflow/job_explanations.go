@@ -0,0 +1,53 @@
+package workflow
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var jobExplanationsLog = logger.New("workflow:job_explanations")
+
+// explainJob records why a generated job exists when the compiler runs with --explain.
+// The explanation is rendered as a comment above the job in the lock file.
+func (c *Compiler) explainJob(job *Job, format string, args ...any) {
+	if !c.explain || job == nil {
+		return
+	}
+	job.Explanation = fmt.Sprintf(format, args...)
+	jobExplanationsLog.Printf("Explaining job %s: %s", job.Name, job.Explanation)
+}
+
+// preActivationJobReasons lists the frontmatter settings that caused the pre-activation job
+// to be created, in the order they are checked by the job
+func preActivationJobReasons(needsPermissionCheck, hasStopTime, hasSkipIfMatch, hasSkipIfNoMatch, hasCommandTrigger bool) string {
+	var reasons []string
+	if needsPermissionCheck {
+		reasons = append(reasons, "the trigger requires a role check (on.roles)")
+	}
+	if hasStopTime {
+		reasons = append(reasons, "on.stop-after is configured")
+	}
+	if hasSkipIfMatch {
+		reasons = append(reasons, "on.skip-if-match is configured")
+	}
+	if hasSkipIfNoMatch {
+		reasons = append(reasons, "on.skip-if-no-match is configured")
+	}
+	if hasCommandTrigger {
+		reasons = append(reasons, "command is configured")
+	}
+	return strings.Join(reasons, ", ")
+}
+
+// agentJobEngineID returns the engine ID used to describe the agent job
+func agentJobEngineID(data *WorkflowData) string {
+	if data.EngineConfig != nil && data.EngineConfig.ID != "" {
+		return data.EngineConfig.ID
+	}
+	if data.AI != "" {
+		return data.AI
+	}
+	return "copilot"
+}
@@ -0,0 +1,100 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const explainTestWorkflow = `---
+on:
+  slash_command:
+    name: triage
+permissions:
+  contents: read
+  issues: read
+engine: claude
+safe-outputs:
+  add-comment:
+jobs:
+  lint:
+    runs-on: ubuntu-latest
+    steps:
+      - run: echo lint
+---
+
+# Triage
+`
+
+func TestExplainJobComments(t *testing.T) {
+	markdownPath := filepath.Join(testutil.TempDir(t, "explain"), "triage.md")
+
+	compiler := NewCompiler()
+	compiler.SetExplain(true)
+	lockContent, err := compiler.CompileString(explainTestWorkflow, markdownPath)
+	require.NoError(t, err, "Workflow should compile with --explain")
+
+	expected := []string{
+		"  # pre_activation: created because the trigger requires a role check (on.roles), command is configured; the workflow only runs when these checks pass\n  pre_activation:\n",
+		"  # activation: always created; checks that the lock file is up to date and prepares the prompt before the agent runs\n  activation:\n",
+		"  # agent: always created; runs the claude engine with the workflow prompt\n  agent:\n",
+		"  # detection: created because safe-outputs are configured and threat detection is enabled; scans the agent output before it is applied\n  detection:\n",
+		"  # safe_outputs: created because safe-outputs are configured; applies the agent output with write permissions\n  safe_outputs:\n",
+		"  # conclusion: created because safe-outputs are configured; reports the run outcome after all other jobs finish\n  conclusion:\n",
+		"  # lint: created because jobs.lint is defined in the frontmatter\n  lint:\n",
+	}
+	for _, comment := range expected {
+		assert.Contains(t, lockContent, comment, "Each generated job should be preceded by its explanation")
+	}
+}
+
+func TestExplainJobCommentsOffByDefault(t *testing.T) {
+	markdownPath := filepath.Join(testutil.TempDir(t, "explain-off"), "triage.md")
+
+	lockContent, err := NewCompiler().CompileString(explainTestWorkflow, markdownPath)
+	require.NoError(t, err, "Workflow should compile")
+
+	assert.NotContains(t, lockContent, "created because", "Job explanations should only be emitted with --explain")
+	assert.NotContains(t, lockContent, "always created", "Job explanations should only be emitted with --explain")
+}
+
+func TestPreActivationJobReasons(t *testing.T) {
+	tests := []struct {
+		name                 string
+		needsPermissionCheck bool
+		hasStopTime          bool
+		hasSkipIfMatch       bool
+		hasSkipIfNoMatch     bool
+		hasCommandTrigger    bool
+		expected             string
+	}{
+		{
+			name:                 "role check only",
+			needsPermissionCheck: true,
+			expected:             "the trigger requires a role check (on.roles)",
+		},
+		{
+			name:        "stop-after only",
+			hasStopTime: true,
+			expected:    "on.stop-after is configured",
+		},
+		{
+			name:             "skip conditions",
+			hasSkipIfMatch:   true,
+			hasSkipIfNoMatch: true,
+			expected:         "on.skip-if-match is configured, on.skip-if-no-match is configured",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reasons := preActivationJobReasons(tt.needsPermissionCheck, tt.hasStopTime, tt.hasSkipIfMatch, tt.hasSkipIfNoMatch, tt.hasCommandTrigger)
+			assert.Equal(t, tt.expected, reasons, "Reasons should name the configured checks")
+		})
+	}
+}
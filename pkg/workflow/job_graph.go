@@ -0,0 +1,141 @@
+// This file provides a JSON description of the jobs generated for a workflow.
+//
+// # Job Graph
+//
+// The job graph is a debugging aid written next to the lock file as <workflow>.jobs.json.
+// It lists every job built by the compiler with its dependencies, permissions and the
+// role it plays in the workflow (activation, agent, safe outputs, detection, custom...),
+// making complex dependency chains easier to inspect and visualize.
+//
+// The graph is serialized from the JobManager state of the most recent compilation, so
+// WriteJobGraph must be called after the workflow has been compiled.
+
+package workflow
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/stringutil"
+)
+
+var jobGraphLog = logger.New("workflow:job_graph")
+
+// Job kinds reported in the job graph
+const (
+	JobKindActivation  = "activation"   // pre_activation and activation jobs
+	JobKindAgent       = "agent"        // The main agent job
+	JobKindDetection   = "detection"    // The threat detection job
+	JobKindSafeOutputs = "safe_outputs" // The consolidated safe outputs job, safe-jobs and asset uploads
+	JobKindCustom      = "custom"       // Jobs declared in the frontmatter jobs section
+	JobKindReusable    = "reusable"     // Custom jobs calling a reusable workflow (uses: directive)
+	JobKindBuiltin     = "builtin"      // Other compiler-generated jobs (conclusion, memory jobs, ...)
+)
+
+// JobGraph describes the jobs generated for a workflow and their dependencies
+type JobGraph struct {
+	Workflow string         `json:"workflow,omitempty"`
+	Jobs     []JobGraphNode `json:"jobs"`
+}
+
+// JobGraphNode describes a single generated job
+type JobGraphNode struct {
+	Name        string   `json:"name"`
+	Kind        string   `json:"kind"`
+	Needs       []string `json:"needs"`
+	Permissions any      `json:"permissions,omitempty"` // Scope-to-level map, or the shorthand string (e.g. read-all)
+	Uses        string   `json:"uses,omitempty"`        // Reusable workflow called by the job
+}
+
+// BuildJobGraph builds the job graph from the jobs of the most recent compilation
+func (c *Compiler) BuildJobGraph(data *WorkflowData) *JobGraph {
+	graph := &JobGraph{Workflow: data.Name, Jobs: []JobGraphNode{}}
+
+	jobs := c.jobManager.GetAllJobs()
+	for _, name := range c.jobManager.jobOrder {
+		job := jobs[name]
+		needs := job.Needs
+		if needs == nil {
+			needs = []string{}
+		}
+		graph.Jobs = append(graph.Jobs, JobGraphNode{
+			Name:        job.Name,
+			Kind:        classifyJobKind(job, data),
+			Needs:       needs,
+			Permissions: jobGraphPermissions(job.Permissions),
+			Uses:        job.Uses,
+		})
+	}
+
+	jobGraphLog.Printf("Built job graph with %d jobs", len(graph.Jobs))
+	return graph
+}
+
+// WriteJobGraph writes the job graph of the most recent compilation to path as JSON
+func (c *Compiler) WriteJobGraph(data *WorkflowData, path string) error {
+	content, err := json.MarshalIndent(c.BuildJobGraph(data), "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal job graph: %w", err)
+	}
+
+	jobGraphLog.Printf("Writing job graph to: %s", path)
+	if err := os.WriteFile(path, append(content, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write job graph: %w", err)
+	}
+	return nil
+}
+
+// jobGraphFilePath returns the sidecar job graph path for a lock file
+func jobGraphFilePath(lockFile string) string {
+	return strings.TrimSuffix(lockFile, ".lock.yml") + ".jobs.json"
+}
+
+// classifyJobKind returns the role a job plays in the compiled workflow
+func classifyJobKind(job *Job, data *WorkflowData) string {
+	switch constants.JobName(job.Name) {
+	case constants.PreActivationJobName, constants.ActivationJobName:
+		return JobKindActivation
+	case constants.AgentJobName:
+		return JobKindAgent
+	case constants.DetectionJobName:
+		return JobKindDetection
+	}
+
+	if job.Name == "safe_outputs" || job.Name == "upload_assets" {
+		return JobKindSafeOutputs
+	}
+	if data.SafeOutputs != nil {
+		for safeJobName := range data.SafeOutputs.Jobs {
+			if stringutil.NormalizeSafeOutputIdentifier(safeJobName) == job.Name {
+				return JobKindSafeOutputs
+			}
+		}
+	}
+	if _, isCustom := data.Jobs[job.Name]; isCustom {
+		if job.Uses != "" {
+			return JobKindReusable
+		}
+		return JobKindCustom
+	}
+	return JobKindBuiltin
+}
+
+// jobGraphPermissions converts a job's rendered permissions block into a JSON-friendly value
+func jobGraphPermissions(permissionsYAML string) any {
+	if strings.TrimSpace(permissionsYAML) == "" {
+		return nil
+	}
+
+	parser := NewPermissionsParser(permissionsYAML)
+	if parser.isShorthand {
+		return parser.shorthandValue
+	}
+	if len(parser.parsedPerms) == 0 {
+		return nil
+	}
+	return parser.parsedPerms
+}
@@ -0,0 +1,106 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteJobGraph(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "job-graph-test")
+	content := `---
+on: workflow_dispatch
+permissions:
+  contents: read
+engine: copilot
+safe-outputs:
+  create-issue:
+jobs:
+  setup:
+    runs-on: ubuntu-latest
+    steps:
+      - run: echo "setup"
+  deploy:
+    needs: [agent]
+    uses: ./.github/workflows/deploy.yml
+---
+
+# Test Workflow
+`
+	testFile := filepath.Join(tmpDir, "job-graph.md")
+	require.NoError(t, os.WriteFile(testFile, []byte(content), 0644), "Failed to write test file")
+
+	compiler := NewCompiler()
+	compiler.SetEmitJobGraph(true)
+	require.NoError(t, compiler.CompileWorkflow(testFile), "Workflow should compile")
+
+	graphContent, err := os.ReadFile(filepath.Join(tmpDir, "job-graph.jobs.json"))
+	require.NoError(t, err, "Job graph sidecar should be written next to the lock file")
+
+	var graph JobGraph
+	require.NoError(t, json.Unmarshal(graphContent, &graph), "Job graph should be valid JSON")
+
+	nodes := make(map[string]JobGraphNode)
+	for _, node := range graph.Jobs {
+		nodes[node.Name] = node
+	}
+
+	tests := []struct {
+		job           string
+		expectedKind  string
+		expectedNeeds []string
+	}{
+		{job: "pre_activation", expectedKind: JobKindActivation, expectedNeeds: []string{}},
+		{job: "activation", expectedKind: JobKindActivation, expectedNeeds: []string{"pre_activation"}},
+		{job: "agent", expectedKind: JobKindAgent, expectedNeeds: []string{"activation", "setup"}},
+		{job: "detection", expectedKind: JobKindDetection, expectedNeeds: []string{"agent"}},
+		{job: "safe_outputs", expectedKind: JobKindSafeOutputs},
+		{job: "setup", expectedKind: JobKindCustom, expectedNeeds: []string{"activation"}},
+		{job: "deploy", expectedKind: JobKindReusable, expectedNeeds: []string{"agent"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.job, func(t *testing.T) {
+			node, exists := nodes[tt.job]
+			require.True(t, exists, "Job graph should contain job %s", tt.job)
+			assert.Equal(t, tt.expectedKind, node.Kind, "Job kind should match")
+			if tt.expectedNeeds != nil {
+				assert.Equal(t, tt.expectedNeeds, node.Needs, "Job needs should match")
+			}
+		})
+	}
+
+	assert.Equal(t, "./.github/workflows/deploy.yml", nodes["deploy"].Uses, "Reusable job should record the called workflow")
+	assert.Equal(t, map[string]any{"contents": "read"}, nodes["agent"].Permissions, "Agent job permissions should be reported")
+}
+
+func TestCompileWorkflowWithoutJobGraph(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "job-graph-disabled-test")
+	content := `---
+on: workflow_dispatch
+permissions:
+  contents: read
+engine: copilot
+---
+
+# Test Workflow
+`
+	testFile := filepath.Join(tmpDir, "no-graph.md")
+	require.NoError(t, os.WriteFile(testFile, []byte(content), 0644), "Failed to write test file")
+
+	require.NoError(t, NewCompiler().CompileWorkflow(testFile), "Workflow should compile")
+	assert.NoFileExists(t, filepath.Join(tmpDir, "no-graph.jobs.json"), "Job graph should only be written when enabled")
+}
+
+func TestJobGraphPermissions(t *testing.T) {
+	assert.Nil(t, jobGraphPermissions(""), "Empty permissions should be omitted")
+	assert.Equal(t, "read-all", jobGraphPermissions("permissions: read-all"), "Shorthand permissions should be kept as a string")
+	assert.Equal(t, map[string]string{"issues": "write"}, jobGraphPermissions("permissions:\n  issues: write"), "Scoped permissions should be a map")
+}
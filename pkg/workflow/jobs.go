@@ -20,14 +20,17 @@ type Job struct {
 	HasWorkflowRunSafetyChecks bool // If true, the job's if condition includes workflow_run safety checks
 	Permissions                string
 	TimeoutMinutes             int
+	TimeoutMinutesExpression   string            // GitHub Actions expression for timeout-minutes, rendered instead of TimeoutMinutes when set
 	Concurrency                string            // Job-level concurrency configuration
 	Environment                string            // Job environment configuration
 	Container                  string            // Job container configuration
 	Services                   string            // Job services configuration
+	Strategy                   string            // Job strategy configuration (matrix, fail-fast, max-parallel)
 	Env                        map[string]string // Job-level environment variables
 	Steps                      []string
 	Needs                      []string // Job dependencies (needs clause)
 	Outputs                    map[string]string
+	Explanation                string // Optional comment rendered above the job explaining why it was generated
 
 	// Reusable workflow call properties
 	Uses    string            // Path to reusable workflow (e.g., ./.github/workflows/reusable.yml)
@@ -82,6 +85,17 @@ func (jm *JobManager) GetAllJobs() map[string]*Job {
 	return result
 }
 
+// JobDependencyError reports a job that depends on a job which does not exist
+type JobDependencyError struct {
+	JobName    string // The job declaring the dependency
+	Dependency string // The missing job it depends on
+}
+
+// Error returns the error message
+func (e *JobDependencyError) Error() string {
+	return fmt.Sprintf("job '%s' depends on non-existent job '%s'", e.JobName, e.Dependency)
+}
+
 // ValidateDependencies checks that all job dependencies exist and there are no cycles
 func (jm *JobManager) ValidateDependencies() error {
 	jobLog.Printf("Validating dependencies for %d jobs", len(jm.jobs))
@@ -90,7 +104,7 @@ func (jm *JobManager) ValidateDependencies() error {
 		for _, dep := range job.Needs {
 			if _, exists := jm.jobs[dep]; !exists {
 				jobLog.Printf("Validation failed: job %s depends on non-existent job %s", jobName, dep)
-				return fmt.Errorf("job '%s' depends on non-existent job '%s'", jobName, dep)
+				return &JobDependencyError{JobName: jobName, Dependency: dep}
 			}
 		}
 	}
@@ -225,6 +239,11 @@ func (jm *JobManager) RenderToYAML() string {
 func (jm *JobManager) renderJob(job *Job) string {
 	var yaml strings.Builder
 
+	// Add explanation comment if present (compiled with --explain)
+	if job.Explanation != "" {
+		fmt.Fprintf(&yaml, "  # %s: %s\n", job.Name, job.Explanation)
+	}
+
 	fmt.Fprintf(&yaml, "  %s:\n", job.Name)
 
 	// Add display name if present
@@ -286,6 +305,11 @@ func (jm *JobManager) renderJob(job *Job) string {
 		fmt.Fprintf(&yaml, "    %s\n", job.RunsOn)
 	}
 
+	// Add strategy section
+	if job.Strategy != "" {
+		fmt.Fprintf(&yaml, "    %s\n", job.Strategy)
+	}
+
 	// Add environment section
 	if job.Environment != "" {
 		fmt.Fprintf(&yaml, "    %s\n", job.Environment)
@@ -312,7 +336,9 @@ func (jm *JobManager) renderJob(job *Job) string {
 	}
 
 	// Add timeout-minutes if specified
-	if job.TimeoutMinutes > 0 {
+	if job.TimeoutMinutesExpression != "" {
+		fmt.Fprintf(&yaml, "    timeout-minutes: %s\n", job.TimeoutMinutesExpression)
+	} else if job.TimeoutMinutes > 0 {
 		fmt.Fprintf(&yaml, "    timeout-minutes: %d\n", job.TimeoutMinutes)
 	}
 
@@ -0,0 +1,108 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMaxTurnsCeiling(t *testing.T) {
+	tests := []struct {
+		name        string
+		engine      string
+		ceiling     int
+		errContains string
+	}{
+		{
+			name:    "within ceiling",
+			engine:  "engine:\n  id: claude\n  max-turns: 20",
+			ceiling: 20,
+		},
+		{
+			name:        "over ceiling",
+			engine:      "engine:\n  id: claude\n  max-turns: 21",
+			ceiling:     20,
+			errContains: "max-turns 21 exceeds the max-turns ceiling of 20",
+		},
+		{
+			name:        "over ceiling on engine without max-turns support",
+			engine:      "engine:\n  id: copilot-sdk\n  max-turns: 100",
+			ceiling:     20,
+			errContains: "max-turns 100 exceeds the max-turns ceiling of 20",
+		},
+		{
+			name:        "expression cannot be checked",
+			engine:      "engine:\n  id: claude\n  max-turns: ${{ inputs.turns }}",
+			ceiling:     20,
+			errContains: "max-turns must be an integer when a max-turns ceiling of 20 is set",
+		},
+		{
+			name:    "no max-turns requested",
+			engine:  "engine: claude",
+			ceiling: 20,
+		},
+		{
+			name:   "no ceiling",
+			engine: "engine:\n  id: claude\n  max-turns: 500",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content := "---\non: workflow_dispatch\npermissions:\n  contents: read\n" + tt.engine + "\n---\n\n# Max turns ceiling\n"
+			repoRoot := testutil.TempDir(t, "max-turns-ceiling")
+			if tt.ceiling > 0 {
+				writeRepositoryConfig(t, repoRoot, fmt.Sprintf(`{"engine": {"max-turns-ceiling": %d}}`, tt.ceiling))
+			}
+			markdownPath := filepath.Join(repoRoot, ".github", "workflows", "test.md")
+
+			compiler := NewCompiler(WithGitRoot(repoRoot))
+			_, err := compiler.CompileString(content, markdownPath)
+
+			if tt.errContains != "" {
+				require.Error(t, err, "Workflow over the max-turns ceiling should be rejected")
+				assert.Contains(t, err.Error(), tt.errContains, "Error should explain the ceiling")
+				return
+			}
+			require.NoError(t, err, "Workflow within the max-turns ceiling should compile")
+		})
+	}
+}
+
+func writeRepositoryConfig(t *testing.T, repoRoot string, content string) {
+	t.Helper()
+	configDir := filepath.Join(repoRoot, ".github", "aw")
+	require.NoError(t, os.MkdirAll(configDir, 0755), "Failed to create .github/aw")
+	require.NoError(t, os.WriteFile(filepath.Join(configDir, RepositoryConfigFileName), []byte(content), 0644), "Failed to write repository config")
+}
+
+func TestLoadRepositoryConfig(t *testing.T) {
+	t.Run("missing file uses defaults", func(t *testing.T) {
+		config, err := LoadRepositoryConfig(testutil.TempDir(t, "repository-config"))
+		require.NoError(t, err, "A missing repository config should not be an error")
+		assert.Zero(t, config.Engine.MaxTurnsCeiling, "No ceiling should be set by default")
+	})
+
+	t.Run("invalid JSON", func(t *testing.T) {
+		repoRoot := testutil.TempDir(t, "repository-config")
+		writeRepositoryConfig(t, repoRoot, `{"engine": `)
+		_, err := LoadRepositoryConfig(repoRoot)
+		require.Error(t, err, "Invalid JSON should be rejected")
+		assert.Contains(t, err.Error(), "invalid repository config", "Error should name the config file")
+	})
+
+	t.Run("negative ceiling", func(t *testing.T) {
+		repoRoot := testutil.TempDir(t, "repository-config")
+		writeRepositoryConfig(t, repoRoot, `{"engine": {"max-turns-ceiling": -1}}`)
+		_, err := LoadRepositoryConfig(repoRoot)
+		require.Error(t, err, "A negative ceiling should be rejected")
+		assert.Contains(t, err.Error(), "engine.max-turns-ceiling must be a positive number of turns", "Error should explain the ceiling")
+	})
+}
@@ -0,0 +1,143 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateMCPAllowedWhen(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      map[string]any
+		errContains string
+	}{
+		{
+			name:   "no allowed-when",
+			config: map[string]any{"allowed": []any{"search"}},
+		},
+		{
+			name:   "bare expression",
+			config: map[string]any{"allowed": []any{"search"}, "allowed-when": "vars.ALLOW_EXTRA == 'true'"},
+		},
+		{
+			name:   "wrapped expression",
+			config: map[string]any{"allowed": []any{"search"}, "allowed-when": "${{ github.event_name == 'workflow_dispatch' && vars.ALLOW_EXTRA == 'true' }}"},
+		},
+		{
+			name:        "empty expression",
+			config:      map[string]any{"allowed": []any{"search"}, "allowed-when": "  "},
+			errContains: "must be a non-empty expression",
+		},
+		{
+			name:        "missing allowed list",
+			config:      map[string]any{"allowed-when": "vars.ALLOW_EXTRA == 'true'"},
+			errContains: "requires an 'allowed' list",
+		},
+		{
+			name:        "dangling operator",
+			config:      map[string]any{"allowed": []any{"search"}, "allowed-when": "vars.ALLOW_EXTRA == 'true' &&"},
+			errContains: "invalid 'allowed-when' expression",
+		},
+		{
+			name:        "unbalanced quotes",
+			config:      map[string]any{"allowed": []any{"search"}, "allowed-when": "vars.ALLOW_EXTRA == 'true"},
+			errContains: "invalid 'allowed-when' expression",
+		},
+		{
+			name:        "secrets reference",
+			config:      map[string]any{"allowed": []any{"search"}, "allowed-when": "secrets.TOKEN != ''"},
+			errContains: "cannot reference secrets",
+		},
+		{
+			name:        "tool name with quote",
+			config:      map[string]any{"allowed": []any{"it's"}, "allowed-when": "vars.ALLOW_EXTRA == 'true'"},
+			errContains: "invalid allowed tool name",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateMCPAllowedWhen("extra", tt.config)
+			if tt.errContains != "" {
+				require.Error(t, err, "Invalid allowed-when should be rejected")
+				assert.Contains(t, err.Error(), tt.errContains, "Error should explain the problem")
+				return
+			}
+			assert.NoError(t, err, "Valid allowed-when should be accepted")
+		})
+	}
+}
+
+func TestValidateMCPConfigsAllowedWhenEnvVarCollision(t *testing.T) {
+	gated := func() map[string]any {
+		return map[string]any{"container": "mcp/example", "allowed": []any{"search"}, "allowed-when": "vars.ALLOW_EXTRA == 'true'"}
+	}
+
+	err := ValidateMCPConfigs(map[string]any{"extra-tools": gated(), "extra_tools": gated()})
+	require.Error(t, err, "Tools mapping to the same allowed-when env var should be rejected")
+	assert.Contains(t, err.Error(), "tools 'extra-tools' and 'extra_tools'", "Error should name both tools")
+	assert.Contains(t, err.Error(), "GH_AW_ALLOWED_WHEN_EXTRA_TOOLS", "Error should name the shared env var")
+
+	ungated := map[string]any{"container": "mcp/example", "allowed": []any{"search"}}
+	assert.NoError(t, ValidateMCPConfigs(map[string]any{"extra-tools": gated(), "extra_tools": ungated}),
+		"Only tools that use allowed-when need distinct env vars")
+}
+
+func TestCompileWorkflowWithAllowedWhen(t *testing.T) {
+	tests := []struct {
+		name        string
+		engine      string
+		allowedWhen string
+		errContains string
+	}{
+		{
+			name:        "copilot gates the allowlist",
+			engine:      "copilot",
+			allowedWhen: "vars.ALLOW_EXTRA == 'true'",
+		},
+		{
+			name:        "invalid expression",
+			engine:      "copilot",
+			allowedWhen: "(vars.ALLOW_EXTRA == 'true'",
+			errContains: "invalid 'allowed-when' expression",
+		},
+		{
+			name:        "unsupported engine",
+			engine:      "claude",
+			allowedWhen: "vars.ALLOW_EXTRA == 'true'",
+			errContains: "allowed-when not supported",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			workflowsDir := filepath.Join(testutil.TempDir(t, "allowed-when-test"), ".github", "workflows")
+			require.NoError(t, os.MkdirAll(workflowsDir, 0755), "Failed to create workflows directory")
+
+			content := "---\non: workflow_dispatch\npermissions:\n  contents: read\nengine: " + tt.engine + "\nmcp-servers:\n  extra-tools:\n    container: mcp/example\n    allowed: [search, fetch]\n    allowed-when: \"" + tt.allowedWhen + "\"\n---\n\n# Test Workflow\n"
+			testFile := filepath.Join(workflowsDir, "allowed-when.md")
+			require.NoError(t, os.WriteFile(testFile, []byte(content), 0644), "Failed to write test file")
+
+			err := NewCompiler().CompileWorkflow(testFile)
+			if tt.errContains != "" {
+				require.Error(t, err, "Workflow should fail compilation")
+				assert.Contains(t, err.Error(), tt.errContains, "Error should explain the problem")
+				return
+			}
+			require.NoError(t, err, "Workflow with allowed-when should compile")
+
+			lockContent, err := os.ReadFile(filepath.Join(workflowsDir, "allowed-when.lock.yml"))
+			require.NoError(t, err, "Lock file should be written")
+			lock := string(lockContent)
+			assert.Contains(t, lock, "GH_AW_ALLOWED_WHEN_EXTRA_TOOLS: ${{ (vars.ALLOW_EXTRA == 'true') && 'true' || 'false' }}", "Condition should be evaluated into the gateway step env")
+			assert.Contains(t, lock, `"tools": $([ "$GH_AW_ALLOWED_WHEN_EXTRA_TOOLS" = "true" ] && echo '["search","fetch"]' || echo '[]')`, "Allowlist should be gated on the condition")
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package workflow
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
 	"sort"
@@ -219,7 +220,15 @@ func renderSharedMCPConfig(yaml *strings.Builder, toolName string, toolConfig ma
 				comma = ""
 			}
 			// Check if allowed tools are specified, otherwise default to "*"
-			if len(mcpConfig.Allowed) > 0 {
+			if len(mcpConfig.Allowed) > 0 && mcpConfig.AllowedWhen != "" {
+				// Gated allowlist: the heredoc is unquoted, so the shell picks the list at runtime
+				// from the condition evaluated into the step environment (fail closed to no tools)
+				allowedJSON, err := json.Marshal(mcpConfig.Allowed)
+				if err != nil {
+					return fmt.Errorf("failed to serialize allowed tools for '%s': %w", toolName, err)
+				}
+				fmt.Fprintf(yaml, "%s\"tools\": $([ \"$%s\" = \"true\" ] && echo '%s' || echo '[]')%s\n", renderer.IndentLevel, allowedWhenEnvVarName(toolName), allowedJSON, comma)
+			} else if len(mcpConfig.Allowed) > 0 {
 				fmt.Fprintf(yaml, "%s\"tools\": [\n", renderer.IndentLevel)
 				for toolIndex, tool := range mcpConfig.Allowed {
 					toolComma := ","
@@ -539,6 +548,9 @@ func getMCPConfig(toolConfig map[string]any, toolName string) (*parser.MCPServer
 		"headers":        true,
 		"registry":       true,
 		"allowed":        true,
+		"allowed-when":   true,
+		"auth":           true,
+		"health-check":   true,
 		"toolsets":       true, // Added for MCPServerConfig struct
 	}
 
@@ -654,6 +666,17 @@ func getMCPConfig(toolConfig map[string]any, toolName string) (*parser.MCPServer
 		if headers, hasHeaders := config.GetStringMap("headers"); hasHeaders {
 			result.Headers = headers
 		}
+		// Send the OAuth token fetched before the gateway starts as a bearer token
+		oauthConfig, err := parseMCPOAuthConfig(toolName, toolConfig)
+		if err != nil {
+			return nil, err
+		}
+		if oauthConfig != nil {
+			if _, exists := result.Headers["Authorization"]; exists {
+				return nil, fmt.Errorf("http MCP tool '%s' cannot set both 'auth' and an 'Authorization' header", toolName)
+			}
+			result.Headers["Authorization"] = "Bearer \\${" + mcpOAuthTokenEnvVarName(toolName) + "}"
+		}
 	default:
 		mcpCustomLog.Printf("Unsupported MCP type '%s' for tool '%s'", result.Type, toolName)
 		return nil, fmt.Errorf(
@@ -671,6 +694,9 @@ func getMCPConfig(toolConfig map[string]any, toolName string) (*parser.MCPServer
 	if allowed, hasAllowed := config.GetStringArray("allowed"); hasAllowed {
 		result.Allowed = allowed
 	}
+	if allowedWhen, hasAllowedWhen := config.GetString("allowed-when"); hasAllowedWhen {
+		result.AllowedWhen = allowedWhen
+	}
 
 	// Automatically assign well-known containers for stdio MCP servers based on command
 	// This ensures all stdio servers work with the MCP Gateway which requires containerization
@@ -724,3 +750,15 @@ func hasMCPConfig(toolConfig map[string]any) (bool, string) {
 
 	return false, ""
 }
+
+// allowedWhenEnvVarName returns the step environment variable that carries the evaluated
+// allowed-when condition for an MCP server (e.g. "my-tool" -> "GH_AW_ALLOWED_WHEN_MY_TOOL")
+func allowedWhenEnvVarName(toolName string) string {
+	return "GH_AW_ALLOWED_WHEN_" + strings.ToUpper(strings.ReplaceAll(toolName, "-", "_"))
+}
+
+// allowedWhenEnvVarValue converts an allowed-when condition into a step environment value
+// that evaluates to 'true' or 'false' at runtime
+func allowedWhenEnvVarValue(condition string) string {
+	return fmt.Sprintf("${{ (%s) && 'true' || 'false' }}", stripExpressionWrapper(condition))
+}
@@ -11,6 +11,10 @@
 //   - ValidateMCPConfigs() - Validates all MCP configurations in tools section
 //   - validateStringProperty() - Validates that a property is a string type
 //   - validateMCPRequirements() - Validates type-specific MCP requirements
+//   - validateMCPAllowedWhen() - Validates allowed-when conditions on gated allowlists
+//   - checkMCPEnvVarCollision() - Rejects tools whose derived env var names collide
+//   - validateMCPAuth() - Validates OAuth auth blocks on HTTP MCP servers
+//   - validateMCPHealthCheck() - Validates health-check readiness probes
 //
 // # Validation Pattern: Schema and Requirements Validation
 //
@@ -76,8 +80,13 @@ func ValidateMCPConfigs(tools map[string]any) error {
 		"safety-prompt":     true,
 		"timeout":           true,
 		"startup-timeout":   true,
+		"tool-timeouts":     true,
 	}
 
+	// Env vars derived from tool names, to reject names that differ only by "-" vs "_" or case
+	allowedWhenEnvVars := make(map[string]string)
+	oauthTokenEnvVars := make(map[string]string)
+
 	for toolName, toolConfig := range tools {
 		// Skip built-in tools - they have their own schema validation
 		if builtInTools[toolName] {
@@ -104,6 +113,28 @@ func ValidateMCPConfigs(tools map[string]any) error {
 			if err := validateMCPRequirements(toolName, mcpConfig, config); err != nil {
 				return err
 			}
+
+			if err := validateMCPAllowedWhen(toolName, config); err != nil {
+				return err
+			}
+			if _, hasAllowedWhen := config["allowed-when"]; hasAllowedWhen {
+				if err := checkMCPEnvVarCollision(allowedWhenEnvVars, allowedWhenEnvVarName(toolName), toolName, "allowed-when"); err != nil {
+					return err
+				}
+			}
+
+			if err := validateMCPAuth(toolName, config); err != nil {
+				return err
+			}
+			if _, hasAuth := config["auth"]; hasAuth {
+				if err := checkMCPEnvVarCollision(oauthTokenEnvVars, mcpOAuthTokenEnvVarName(toolName), toolName, "auth"); err != nil {
+					return err
+				}
+			}
+
+			if err := validateMCPHealthCheck(toolName, config); err != nil {
+				return err
+			}
 		}
 	}
 
@@ -111,6 +142,19 @@ func ValidateMCPConfigs(tools map[string]any) error {
 	return nil
 }
 
+// checkMCPEnvVarCollision records the env var derived from toolName and fails when another
+// tool already maps to the same name (e.g. "my-tool" and "my_tool"), since the value of one
+// tool would silently replace the other's in the step environment
+func checkMCPEnvVarCollision(seen map[string]string, envVar string, toolName string, field string) error {
+	if other, exists := seen[envVar]; exists && other != toolName {
+		names := []string{other, toolName}
+		sort.Strings(names)
+		return fmt.Errorf("tools '%s' and '%s' both use '%s' and map to the same environment variable %s. Rename one of the tools so the names differ by more than '-' versus '_' or letter case.\n\nSee: %s", names[0], names[1], field, envVar, constants.DocsToolsURL)
+	}
+	seen[envVar] = toolName
+	return nil
+}
+
 // getRawMCPConfig extracts MCP configuration without any transformations for validation
 func getRawMCPConfig(toolConfig map[string]any) (map[string]any, error) {
 	result := make(map[string]any)
@@ -138,6 +182,9 @@ func getRawMCPConfig(toolConfig map[string]any) (map[string]any, error) {
 		"proxy-args":      true,
 		"registry":        true,
 		"allowed":         true,
+		"allowed-when":    true,
+		"auth":            true,
+		"health-check":    true,
 		"mode":            true, // for github tool
 		"github-token":    true, // for github tool
 		"read-only":       true, // for github tool
@@ -285,3 +332,71 @@ func validateMCPRequirements(toolName string, mcpConfig map[string]any, toolConf
 
 	return nil
 }
+
+// validateMCPAllowedWhen validates the allowed-when condition that gates an MCP server's
+// allowlist at runtime. The condition must be a valid expression without secrets, and
+// the server must declare the allowed tools it gates.
+func validateMCPAllowedWhen(toolName string, toolConfig map[string]any) error {
+	value, hasAllowedWhen := toolConfig["allowed-when"]
+	if !hasAllowedWhen {
+		return nil
+	}
+
+	condition, ok := value.(string)
+	if !ok || strings.TrimSpace(condition) == "" {
+		return fmt.Errorf("tool '%s' mcp configuration 'allowed-when' must be a non-empty expression string.\n\nExample:\ntools:\n  %s:\n    allowed: [\"search\"]\n    allowed-when: \"vars.ALLOW_EXTRA == 'true'\"\n\nSee: %s", toolName, toolName, constants.DocsToolsURL)
+	}
+
+	allowed, hasAllowed := toolConfig["allowed"].([]any)
+	if !hasAllowed || len(allowed) == 0 {
+		return fmt.Errorf("tool '%s' mcp configuration 'allowed-when' requires an 'allowed' list of tools to gate.\n\nExample:\ntools:\n  %s:\n    allowed: [\"search\"]\n    allowed-when: \"vars.ALLOW_EXTRA == 'true'\"\n\nSee: %s", toolName, toolName, constants.DocsToolsURL)
+	}
+	for _, tool := range allowed {
+		if toolStr, ok := tool.(string); !ok || strings.ContainsAny(toolStr, "'\"$`\\") {
+			return fmt.Errorf("tool '%s' has an invalid allowed tool name %v: gated allowlists only support plain tool names", toolName, tool)
+		}
+	}
+
+	expression := stripExpressionWrapper(condition)
+	if strings.Contains(expression, "${{") || strings.Contains(expression, "}}") {
+		return fmt.Errorf("tool '%s' mcp configuration 'allowed-when' must be a single expression, got: %s", toolName, condition)
+	}
+	if strings.Contains(expression, "secrets.") {
+		return fmt.Errorf("tool '%s' mcp configuration 'allowed-when' cannot reference secrets, got: %s", toolName, condition)
+	}
+	if err := validateBalancedQuotes(expression); err != nil {
+		return fmt.Errorf("tool '%s' has an invalid 'allowed-when' expression: %w", toolName, err)
+	}
+	if err := validateExpressionForDangerousProps(expression); err != nil {
+		return fmt.Errorf("tool '%s' has an invalid 'allowed-when' expression: %w", toolName, err)
+	}
+	if _, err := ParseExpression(expression); err != nil {
+		return fmt.Errorf("tool '%s' has an invalid 'allowed-when' expression '%s': %w", toolName, condition, err)
+	}
+
+	mcpValidationLog.Printf("Validated allowed-when condition for tool %s", toolName)
+	return nil
+}
+
+// validateMCPAuth validates the OAuth auth block of an MCP server. Auth is only supported
+// for HTTP servers, where the fetched token replaces the Authorization header.
+func validateMCPAuth(toolName string, toolConfig map[string]any) error {
+	if _, hasAuth := toolConfig["auth"]; !hasAuth {
+		return nil
+	}
+
+	if _, mcpType := hasMCPConfig(toolConfig); mcpType != "http" {
+		return fmt.Errorf("tool '%s' mcp configuration 'auth' is only supported for http MCP servers.\n\nSee: %s", toolName, constants.DocsToolsURL)
+	}
+	if _, err := parseMCPOAuthConfig(toolName, toolConfig); err != nil {
+		return err
+	}
+	if headers, ok := toolConfig["headers"].(map[string]any); ok {
+		if _, exists := headers["Authorization"]; exists {
+			return fmt.Errorf("tool '%s' mcp configuration cannot set both 'auth' and an 'Authorization' header; the OAuth token is sent in the Authorization header.\n\nSee: %s", toolName, constants.DocsToolsURL)
+		}
+	}
+
+	mcpValidationLog.Printf("Validated OAuth auth for tool %s", toolName)
+	return nil
+}
@@ -22,6 +22,8 @@
 //   - Serena: GH_AW_SERENA_PORT (local mode only)
 //   - Playwright: Domain secrets from allowed_domains expressions
 //   - HTTP MCP: Custom secrets from headers and env sections
+//   - Gated allowlists: GH_AW_ALLOWED_WHEN_<SERVER> from allowed-when conditions
+//   - OAuth: GH_AW_MCP_OAUTH_TOKEN_<SERVER> from the token steps of HTTP MCP servers using auth
 //
 // Token precedence for GitHub MCP:
 //  1. GitHub App token (if app configuration exists)
@@ -46,6 +48,8 @@
 package workflow
 
 import (
+	"fmt"
+
 	"github.com/github/gh-aw/pkg/logger"
 )
 
@@ -134,6 +138,24 @@ func collectMCPEnvironmentVariables(tools map[string]any, mcpTools []string, wor
 		envVars["GH_AW_SAFE_OUTPUTS_API_KEY"] = "${{ steps.safe-outputs-start.outputs.api_key }}"
 	}
 
+	// Add evaluated allowed-when conditions for MCP servers with gated allowlists
+	for _, toolName := range mcpTools {
+		toolConfig, ok := tools[toolName].(map[string]any)
+		if !ok {
+			continue
+		}
+		if condition, ok := toolConfig["allowed-when"].(string); ok && condition != "" {
+			mcpEnvironmentLog.Printf("Adding allowed-when condition for MCP server: %s", toolName)
+			envVars[allowedWhenEnvVarName(toolName)] = allowedWhenEnvVarValue(condition)
+		}
+	}
+
+	// Add OAuth tokens fetched for HTTP MCP servers using auth
+	for toolName := range collectMCPOAuthConfigs(tools, mcpTools) {
+		mcpEnvironmentLog.Printf("Adding OAuth token for MCP server: %s", toolName)
+		envVars[mcpOAuthTokenEnvVarName(toolName)] = fmt.Sprintf("${{ steps.%s.outputs.token }}", mcpOAuthStepID(toolName))
+	}
+
 	// Check if serena is in local mode and add its environment variables
 	if workflowData != nil && isSerenaInLocalMode(workflowData.ParsedTools) {
 		envVars["GH_AW_SERENA_PORT"] = "${{ steps.serena-config.outputs.serena_port }}"
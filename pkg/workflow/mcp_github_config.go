@@ -15,6 +15,7 @@
 //   - Handling allowed tool lists for fine-grained access control
 //   - Determining Docker image versions for local mode
 //   - Generating automatic lockdown detection steps
+//   - Generating read-only token verification steps
 //   - Managing GitHub App token minting and invalidation
 //
 // GitHub MCP modes:
@@ -130,6 +131,17 @@ func getGitHubLockdown(githubTool any) bool {
 	return false // default to lockdown disabled
 }
 
+// getGitHubReadOnlyEnforcement checks if the GitHub MCP token must be verified as read-only at runtime
+// Defaults to false (opt-in)
+func getGitHubReadOnlyEnforcement(githubTool any) bool {
+	if toolConfig, ok := githubTool.(map[string]any); ok {
+		if enforcement, ok := toolConfig["read-only-enforcement"].(bool); ok {
+			return enforcement
+		}
+	}
+	return false
+}
+
 // hasGitHubLockdownExplicitlySet checks if lockdown field is explicitly set in GitHub tool config
 func hasGitHubLockdownExplicitlySet(githubTool any) bool {
 	if toolConfig, ok := githubTool.(map[string]any); ok {
@@ -358,6 +370,73 @@ func (c *Compiler) generateGitHubMCPLockdownValidationStep(yaml *strings.Builder
 	yaml.WriteString("            validateLockdownRequirements(core);\n")
 }
 
+// generateGitHubMCPReadOnlyTokenVerificationStep generates a step to verify that the GitHub MCP
+// server token cannot write
+// This step is added when:
+// - GitHub tool is enabled AND
+// - read-only-enforcement is set to true
+//
+// Read-only mode only limits the tools offered to the agent; this step fails the job when the
+// token itself has write scopes. It must run after the app token minting step so that the
+// minted token is the one being checked.
+func (c *Compiler) generateGitHubMCPReadOnlyTokenVerificationStep(yaml *strings.Builder, data *WorkflowData) {
+	githubTool, hasGitHub := data.Tools["github"]
+	if !hasGitHub || githubTool == false {
+		return
+	}
+
+	if !getGitHubReadOnlyEnforcement(githubTool) {
+		return
+	}
+
+	githubConfigLog.Print("Generating read-only token verification step (read-only-enforcement enabled)")
+
+	actionRepo := "actions/github-script"
+	actionVersion := string(constants.DefaultGitHubScriptVersion)
+	pinnedAction, err := GetActionPinWithData(actionRepo, actionVersion, data)
+	if err != nil {
+		githubConfigLog.Printf("Failed to resolve %s@%s: %v", actionRepo, actionVersion, err)
+		pinnedAction = fmt.Sprintf("%s@%s", actionRepo, actionVersion)
+	}
+
+	// Check the same token the MCP server receives
+	var token string
+	if data.ParsedTools != nil && data.ParsedTools.GitHub != nil && data.ParsedTools.GitHub.App != nil {
+		token = "${{ steps.github-mcp-app-token.outputs.token }}"
+	} else {
+		token = getEffectiveGitHubToken(getGitHubToken(githubTool), data.GitHubToken)
+	}
+
+	yaml.WriteString("      - name: Verify GitHub MCP token is read-only\n")
+	yaml.WriteString("        id: verify-read-only-token\n")
+	fmt.Fprintf(yaml, "        uses: %s\n", pinnedAction)
+	yaml.WriteString("        env:\n")
+	fmt.Fprintf(yaml, "          GH_AW_READ_ONLY_TOKEN: %s\n", token)
+	if writePermissions := getJobWritePermissions(data.Permissions); len(writePermissions) > 0 {
+		fmt.Fprintf(yaml, "          GH_AW_JOB_WRITE_PERMISSIONS: %q\n", strings.Join(writePermissions, ","))
+	}
+	yaml.WriteString("        with:\n")
+	yaml.WriteString("          script: |\n")
+	yaml.WriteString("            const { validateReadOnlyToken } = require('/opt/gh-aw/actions/validate_read_only_token.cjs');\n")
+	yaml.WriteString("            await validateReadOnlyToken(core);\n")
+}
+
+// getJobWritePermissions returns the permission scopes granted write access in the job permissions.
+// id-token is excluded because it only allows requesting OIDC tokens, not writing through the API.
+func getJobWritePermissions(permissionsYAML string) []string {
+	permissions := NewPermissionsParser(permissionsYAML).ToPermissions()
+	var writeScopes []string
+	for _, scope := range GetAllPermissionScopes() {
+		if scope == PermissionIdToken {
+			continue
+		}
+		if level, exists := permissions.Get(scope); exists && level == PermissionWrite {
+			writeScopes = append(writeScopes, string(scope))
+		}
+	}
+	return writeScopes
+}
+
 // generateGitHubMCPAppTokenMintingStep generates a step to mint a GitHub App token for GitHub MCP server
 // This step is added when:
 // - GitHub tool is enabled with app configuration
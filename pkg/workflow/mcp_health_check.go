@@ -0,0 +1,146 @@
+package workflow
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var mcpHealthCheckLog = logger.New("workflow:mcp_health_check")
+
+// defaultMCPHealthCheckTimeoutSeconds is how long a health check waits for an MCP server to
+// become ready when no timeout is configured. It is independent of the startup timeout,
+// which bounds tool initialization inside the agent.
+const defaultMCPHealthCheckTimeoutSeconds = 60
+
+// mcpHealthCheckIntervalSeconds is the delay between health check attempts
+const mcpHealthCheckIntervalSeconds = 2
+
+// MCPHealthCheckConfig is the health-check block of a custom MCP server. Exactly one of
+// URL and Command is set. The check runs on the runner host and is retried until it
+// succeeds or Timeout expires.
+type MCPHealthCheckConfig struct {
+	URL     string // HTTP endpoint that must return a success status (HTTP servers only)
+	Command string // Shell command run on the runner host that must exit with status 0
+	Timeout int    // Seconds to wait for the server to become healthy
+}
+
+// parseMCPHealthCheckConfig parses and validates the health-check block of an MCP server
+// configuration. Returns nil when the server has no health-check block.
+func parseMCPHealthCheckConfig(toolName string, toolConfig map[string]any) (*MCPHealthCheckConfig, error) {
+	value, hasHealthCheck := toolConfig["health-check"]
+	if !hasHealthCheck {
+		return nil, nil
+	}
+
+	example := fmt.Sprintf("\n\nExample:\nmcp-servers:\n  %s:\n    url: \"http://localhost:3000/mcp\"\n    health-check:\n      url: \"http://localhost:3000/health\"\n      timeout: 120\n\nSee: %s", toolName, constants.DocsToolsURL)
+
+	healthCheckMap, ok := value.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("tool '%s' mcp configuration 'health-check' must be an object%s", toolName, example)
+	}
+
+	config := &MCPHealthCheckConfig{Timeout: defaultMCPHealthCheckTimeoutSeconds}
+	config.URL, _ = healthCheckMap["url"].(string)
+	config.Command, _ = healthCheckMap["command"].(string)
+
+	switch {
+	case config.URL != "" && config.Command != "":
+		return nil, fmt.Errorf("tool '%s' mcp configuration 'health-check' must set either 'url' or 'command', not both%s", toolName, example)
+	case config.URL != "":
+		if !strings.HasPrefix(config.URL, "http://") && !strings.HasPrefix(config.URL, "https://") {
+			return nil, fmt.Errorf("tool '%s' mcp configuration 'health-check.url' must be an http:// or https:// URL, got: '%s'%s", toolName, config.URL, example)
+		}
+		// The check runs on the runner host, which cannot reach container and stdio servers
+		// running inside the MCP gateway
+		if _, mcpType := hasMCPConfig(toolConfig); mcpType != "http" {
+			return nil, fmt.Errorf("tool '%s' mcp configuration 'health-check.url' is only supported for HTTP MCP servers; %s servers run inside the MCP gateway and are not reachable from the runner. Use 'health-check.command' instead%s", toolName, mcpType, example)
+		}
+	case strings.TrimSpace(config.Command) == "":
+		return nil, fmt.Errorf("tool '%s' mcp configuration 'health-check' requires 'url' or 'command'%s", toolName, example)
+	}
+
+	if timeout, hasTimeout := healthCheckMap["timeout"]; hasTimeout {
+		seconds, ok := parseIntValue(timeout)
+		if !ok || seconds <= 0 {
+			return nil, fmt.Errorf("tool '%s' mcp configuration 'health-check.timeout' must be a positive number of seconds, got: %v%s", toolName, timeout, example)
+		}
+		config.Timeout = seconds
+	}
+
+	mcpHealthCheckLog.Printf("Parsed health check for MCP server %s: url=%s, command=%t, timeout=%ds", toolName, config.URL, config.Command != "", config.Timeout)
+	return config, nil
+}
+
+// validateMCPHealthCheck validates the health-check block of an MCP server
+func validateMCPHealthCheck(toolName string, toolConfig map[string]any) error {
+	_, err := parseMCPHealthCheckConfig(toolName, toolConfig)
+	return err
+}
+
+// collectMCPHealthCheckConfigs returns the health check configurations of the given MCP
+// servers, keyed by server name. Invalid configurations are skipped; they are reported by
+// ValidateMCPConfigs.
+func collectMCPHealthCheckConfigs(tools map[string]any, mcpTools []string) map[string]*MCPHealthCheckConfig {
+	configs := make(map[string]*MCPHealthCheckConfig)
+	for _, toolName := range mcpTools {
+		toolConfig, ok := tools[toolName].(map[string]any)
+		if !ok {
+			continue
+		}
+		if hasMcp, _ := hasMCPConfig(toolConfig); !hasMcp {
+			continue
+		}
+		if config, err := parseMCPHealthCheckConfig(toolName, toolConfig); err == nil && config != nil {
+			configs[toolName] = config
+		}
+	}
+	return configs
+}
+
+// generateMCPHealthCheckSteps generates one step per MCP server with a health-check. Each
+// step runs after the MCP gateway starts and before the agent, retrying the check until it
+// passes or its timeout expires, and fails the job with a clear message if the server never
+// becomes healthy.
+func generateMCPHealthCheckSteps(yaml *strings.Builder, tools map[string]any, mcpTools []string) {
+	configs := collectMCPHealthCheckConfigs(tools, mcpTools)
+	if len(configs) == 0 {
+		return
+	}
+
+	toolNames := make([]string, 0, len(configs))
+	for toolName := range configs {
+		toolNames = append(toolNames, toolName)
+	}
+	sort.Strings(toolNames)
+	mcpHealthCheckLog.Printf("Generating health check steps for %d MCP servers", len(toolNames))
+
+	for _, toolName := range toolNames {
+		config := configs[toolName]
+		fmt.Fprintf(yaml, "      - name: Check %s MCP server health\n", toolName)
+		yaml.WriteString("        env:\n")
+		probe := "sh -c \"$GH_AW_HEALTH_CHECK_COMMAND\" > /dev/null 2>&1"
+		target := "command"
+		if config.URL != "" {
+			fmt.Fprintf(yaml, "          GH_AW_HEALTH_CHECK_URL: %q\n", config.URL)
+			probe = "curl -sf --max-time 5 -o /dev/null \"$GH_AW_HEALTH_CHECK_URL\""
+			target = "$GH_AW_HEALTH_CHECK_URL"
+		} else {
+			fmt.Fprintf(yaml, "          GH_AW_HEALTH_CHECK_COMMAND: %q\n", config.Command)
+		}
+		fmt.Fprintf(yaml, "          GH_AW_HEALTH_CHECK_TIMEOUT: \"%d\"\n", config.Timeout)
+		yaml.WriteString("        run: |\n")
+		yaml.WriteString("          DEADLINE=$((SECONDS + GH_AW_HEALTH_CHECK_TIMEOUT))\n")
+		fmt.Fprintf(yaml, "          until %s; do\n", probe)
+		yaml.WriteString("            if [ \"$SECONDS\" -ge \"$DEADLINE\" ]; then\n")
+		fmt.Fprintf(yaml, "              echo \"::error::MCP server '%s' did not become healthy within ${GH_AW_HEALTH_CHECK_TIMEOUT}s (health check %s)\"\n", toolName, target)
+		yaml.WriteString("              exit 1\n")
+		yaml.WriteString("            fi\n")
+		fmt.Fprintf(yaml, "            sleep %d\n", mcpHealthCheckIntervalSeconds)
+		yaml.WriteString("          done\n")
+		fmt.Fprintf(yaml, "          echo \"MCP server '%s' is healthy\"\n", toolName)
+	}
+}
@@ -0,0 +1,181 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMCPHealthCheckConfig(t *testing.T) {
+	tests := []struct {
+		name        string
+		server      map[string]any
+		healthCheck any
+		expected    *MCPHealthCheckConfig
+		errContains string
+	}{
+		{
+			name:        "url with default timeout",
+			server:      map[string]any{"url": "http://localhost:3000/mcp"},
+			healthCheck: map[string]any{"url": "http://localhost:3000/health"},
+			expected:    &MCPHealthCheckConfig{URL: "http://localhost:3000/health", Timeout: defaultMCPHealthCheckTimeoutSeconds},
+		},
+		{
+			name:        "url on a container server",
+			healthCheck: map[string]any{"url": "http://localhost:3000/health"},
+			errContains: "'health-check.url' is only supported for HTTP MCP servers",
+		},
+		{
+			name:        "command with timeout",
+			healthCheck: map[string]any{"command": "nc -z localhost 3000", "timeout": 120},
+			expected:    &MCPHealthCheckConfig{Command: "nc -z localhost 3000", Timeout: 120},
+		},
+		{
+			name:        "not an object",
+			healthCheck: "http://localhost:3000/health",
+			errContains: "'health-check' must be an object",
+		},
+		{
+			name:        "url and command",
+			healthCheck: map[string]any{"url": "http://localhost:3000/health", "command": "true"},
+			errContains: "must set either 'url' or 'command', not both",
+		},
+		{
+			name:        "neither url nor command",
+			healthCheck: map[string]any{"timeout": 30},
+			errContains: "'health-check' requires 'url' or 'command'",
+		},
+		{
+			name:        "url without scheme",
+			server:      map[string]any{"url": "http://localhost:3000/mcp"},
+			healthCheck: map[string]any{"url": "localhost:3000/health"},
+			errContains: "'health-check.url' must be an http:// or https:// URL",
+		},
+		{
+			name:        "zero timeout",
+			server:      map[string]any{"url": "http://localhost:3000/mcp"},
+			healthCheck: map[string]any{"url": "http://localhost:3000/health", "timeout": 0},
+			errContains: "'health-check.timeout' must be a positive number of seconds",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			toolConfig := map[string]any{"container": "example/search"}
+			if tt.server != nil {
+				toolConfig = tt.server
+			}
+			toolConfig["health-check"] = tt.healthCheck
+			config, err := parseMCPHealthCheckConfig("search", toolConfig)
+			if tt.errContains != "" {
+				require.Error(t, err, "Invalid health-check should be rejected")
+				assert.Contains(t, err.Error(), tt.errContains, "Error should explain the problem")
+				return
+			}
+			require.NoError(t, err, "Valid health-check should parse")
+			assert.Equal(t, tt.expected, config, "Health check should be parsed")
+		})
+	}
+
+	config, err := parseMCPHealthCheckConfig("search", map[string]any{"container": "example/search"})
+	require.NoError(t, err, "Servers without a health-check should parse")
+	assert.Nil(t, config, "Servers without a health-check should have no configuration")
+}
+
+func TestMCPHealthCheckStep(t *testing.T) {
+	tests := []struct {
+		name        string
+		server      string
+		healthCheck string
+		expected    []string
+		errContains string
+	}{
+		{
+			name:        "url check",
+			server:      "    url: http://localhost:3000/mcp",
+			healthCheck: "      url: http://localhost:3000/health\n      timeout: 120",
+			expected: []string{
+				`GH_AW_HEALTH_CHECK_URL: "http://localhost:3000/health"`,
+				`GH_AW_HEALTH_CHECK_TIMEOUT: "120"`,
+				`until curl -sf --max-time 5 -o /dev/null "$GH_AW_HEALTH_CHECK_URL"; do`,
+			},
+		},
+		{
+			name:        "command check with default timeout",
+			healthCheck: "      command: nc -z localhost 3000",
+			expected: []string{
+				`GH_AW_HEALTH_CHECK_COMMAND: "nc -z localhost 3000"`,
+				`GH_AW_HEALTH_CHECK_TIMEOUT: "60"`,
+				`until sh -c "$GH_AW_HEALTH_CHECK_COMMAND" > /dev/null 2>&1; do`,
+			},
+		},
+		{
+			name:        "invalid health check",
+			healthCheck: "      timeout: 30",
+			errContains: "health-check",
+		},
+		{
+			name:        "url check on a container server",
+			healthCheck: "      url: http://localhost:3000/health",
+			errContains: "only supported for HTTP MCP servers",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := tt.server
+			if server == "" {
+				server = "    container: ghcr.io/example/search-mcp"
+			}
+			content := `---
+on: workflow_dispatch
+permissions:
+  contents: read
+engine: copilot
+mcp-servers:
+  search:
+` + server + `
+    health-check:
+` + tt.healthCheck + `
+    allowed: ["*"]
+---
+
+# Test Workflow
+`
+			markdownPath := filepath.Join(testutil.TempDir(t, "mcp-health-check"), "test.md")
+
+			lockContent, err := NewCompiler().CompileString(content, markdownPath)
+			if tt.errContains != "" {
+				require.Error(t, err, "Invalid health-check should fail compilation")
+				assert.Contains(t, err.Error(), tt.errContains, "Error should point at the health-check")
+				return
+			}
+			require.NoError(t, err, "Workflow with a health-checked MCP server should compile")
+			agentJob := agentJobSection(t, lockContent)
+
+			assert.Contains(t, agentJob, "- name: Check search MCP server health\n", "Agent job should check MCP server health")
+			for _, expected := range tt.expected {
+				assert.Contains(t, agentJob, expected, "Health check step should contain %q", expected)
+			}
+			assert.Contains(t, agentJob, "::error::MCP server 'search' did not become healthy within ${GH_AW_HEALTH_CHECK_TIMEOUT}s", "A failed health check should fail the job with a clear message")
+
+			gatewayStep := strings.Index(agentJob, "- name: Start MCP gateway")
+			healthStep := strings.Index(agentJob, "- name: Check search MCP server health")
+			require.NotEqual(t, -1, gatewayStep, "Agent job should start the MCP gateway")
+			assert.Less(t, gatewayStep, healthStep, "The health check should run after the gateway starts")
+		})
+	}
+}
+
+func TestMCPHealthCheckStepOmittedWithoutConfig(t *testing.T) {
+	var yaml strings.Builder
+	tools := map[string]any{"search": map[string]any{"container": "ghcr.io/example/search-mcp"}}
+	generateMCPHealthCheckSteps(&yaml, tools, []string{"search"})
+	assert.Empty(t, yaml.String(), "No step should be emitted without a health-check")
+}
@@ -0,0 +1,162 @@
+package workflow
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var mcpOAuthLog = logger.New("workflow:mcp_oauth")
+
+// mcpOAuthClientSecretPattern matches a single ${{ secrets.NAME }} reference
+var mcpOAuthClientSecretPattern = regexp.MustCompile(`^\$\{\{\s*secrets\.[A-Za-z_][A-Za-z0-9_]*\s*\}\}$`)
+
+// MCPOAuthConfig is the auth block of an HTTP MCP server using type: oauth. An access token
+// is fetched from TokenURL with the OAuth 2.0 client credentials grant at the start of each
+// run and sent to the server as a bearer token in the Authorization header.
+type MCPOAuthConfig struct {
+	TokenURL     string
+	ClientID     string
+	ClientSecret string // Must be a ${{ secrets.* }} reference
+	Scope        string
+}
+
+// parseMCPOAuthConfig parses and validates the auth block of an MCP server configuration.
+// Returns nil when the server has no auth block.
+func parseMCPOAuthConfig(toolName string, toolConfig map[string]any) (*MCPOAuthConfig, error) {
+	value, hasAuth := toolConfig["auth"]
+	if !hasAuth {
+		return nil, nil
+	}
+
+	example := fmt.Sprintf("\n\nExample:\nmcp-servers:\n  %s:\n    type: http\n    url: \"https://api.example.com/mcp\"\n    auth:\n      type: oauth\n      token-url: \"https://auth.example.com/oauth/token\"\n      client-id: \"my-client\"\n      client-secret: \"${{ secrets.MCP_CLIENT_SECRET }}\"\n\nSee: %s", toolName, constants.DocsToolsURL)
+
+	authMap, ok := value.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("tool '%s' mcp configuration 'auth' must be an object%s", toolName, example)
+	}
+	if authType, _ := authMap["type"].(string); authType != "oauth" {
+		return nil, fmt.Errorf("tool '%s' mcp configuration 'auth.type' must be 'oauth', got: %v%s", toolName, authMap["type"], example)
+	}
+
+	config := &MCPOAuthConfig{}
+	config.TokenURL, _ = authMap["token-url"].(string)
+	config.ClientID, _ = authMap["client-id"].(string)
+	config.ClientSecret, _ = authMap["client-secret"].(string)
+	config.Scope, _ = authMap["scope"].(string)
+
+	if !strings.HasPrefix(config.TokenURL, "https://") {
+		return nil, fmt.Errorf("tool '%s' mcp configuration 'auth.token-url' must be an https:// URL, got: '%s'%s", toolName, config.TokenURL, example)
+	}
+	if strings.TrimSpace(config.ClientID) == "" {
+		return nil, fmt.Errorf("tool '%s' mcp configuration 'auth.client-id' is required%s", toolName, example)
+	}
+	if !mcpOAuthClientSecretPattern.MatchString(strings.TrimSpace(config.ClientSecret)) {
+		return nil, fmt.Errorf("tool '%s' mcp configuration 'auth.client-secret' must be a ${{ secrets.* }} reference so it is never stored in the lock file%s", toolName, example)
+	}
+
+	mcpOAuthLog.Printf("Parsed OAuth config for MCP server %s: token-url=%s", toolName, config.TokenURL)
+	return config, nil
+}
+
+// mcpOAuthStepID returns the ID of the step that fetches the OAuth token for an MCP server
+func mcpOAuthStepID(toolName string) string {
+	return "mcp-oauth-" + toolName
+}
+
+// mcpOAuthTokenEnvVarName returns the environment variable that carries the OAuth token for
+// an MCP server into the MCP gateway (e.g. "my-tool" -> "GH_AW_MCP_OAUTH_TOKEN_MY_TOOL")
+func mcpOAuthTokenEnvVarName(toolName string) string {
+	return "GH_AW_MCP_OAUTH_TOKEN_" + strings.ToUpper(strings.ReplaceAll(toolName, "-", "_"))
+}
+
+// collectMCPOAuthConfigs returns the OAuth configurations of the given HTTP MCP servers,
+// keyed by server name. Invalid configurations are skipped; they are reported by
+// ValidateMCPConfigs.
+func collectMCPOAuthConfigs(tools map[string]any, mcpTools []string) map[string]*MCPOAuthConfig {
+	configs := make(map[string]*MCPOAuthConfig)
+	for _, toolName := range mcpTools {
+		toolConfig, ok := tools[toolName].(map[string]any)
+		if !ok {
+			continue
+		}
+		if hasMcp, mcpType := hasMCPConfig(toolConfig); !hasMcp || mcpType != "http" {
+			continue
+		}
+		if config, err := parseMCPOAuthConfig(toolName, toolConfig); err == nil && config != nil {
+			configs[toolName] = config
+		}
+	}
+	return configs
+}
+
+// collectHTTPMCPOAuthSecrets collects the client secrets of HTTP MCP servers using OAuth
+// Returns a map of secret names to their secret expressions
+func collectHTTPMCPOAuthSecrets(tools map[string]any) map[string]string {
+	toolNames := make([]string, 0, len(tools))
+	for toolName := range tools {
+		toolNames = append(toolNames, toolName)
+	}
+
+	allSecrets := make(map[string]string)
+	for _, config := range collectMCPOAuthConfigs(tools, toolNames) {
+		for varName, expr := range ExtractSecretsFromValue(config.ClientSecret) {
+			allSecrets[varName] = expr
+		}
+	}
+	return allSecrets
+}
+
+// generateMCPOAuthTokenSteps generates one step per HTTP MCP server using OAuth that fetches
+// an access token with the client credentials grant. The token is masked and exposed as the
+// step's token output, which the MCP gateway step receives through the server's
+// GH_AW_MCP_OAUTH_TOKEN_* environment variable. A failed token request fails the job.
+func generateMCPOAuthTokenSteps(yaml *strings.Builder, tools map[string]any, mcpTools []string) {
+	configs := collectMCPOAuthConfigs(tools, mcpTools)
+	if len(configs) == 0 {
+		return
+	}
+
+	toolNames := make([]string, 0, len(configs))
+	for toolName := range configs {
+		toolNames = append(toolNames, toolName)
+	}
+	sort.Strings(toolNames)
+	mcpOAuthLog.Printf("Generating OAuth token steps for %d MCP servers", len(toolNames))
+
+	for _, toolName := range toolNames {
+		config := configs[toolName]
+		fmt.Fprintf(yaml, "      - name: Fetch OAuth token for %s MCP server\n", toolName)
+		fmt.Fprintf(yaml, "        id: %s\n", mcpOAuthStepID(toolName))
+		yaml.WriteString("        env:\n")
+		fmt.Fprintf(yaml, "          GH_AW_OAUTH_TOKEN_URL: %q\n", config.TokenURL)
+		fmt.Fprintf(yaml, "          GH_AW_OAUTH_CLIENT_ID: %q\n", config.ClientID)
+		fmt.Fprintf(yaml, "          GH_AW_OAUTH_CLIENT_SECRET: %s\n", strings.TrimSpace(config.ClientSecret))
+		if config.Scope != "" {
+			fmt.Fprintf(yaml, "          GH_AW_OAUTH_SCOPE: %q\n", config.Scope)
+		}
+		yaml.WriteString("        run: |\n")
+		yaml.WriteString("          set -eo pipefail\n")
+		yaml.WriteString("          if ! RESPONSE=$(curl -sS --fail --retry 3 -X POST \"$GH_AW_OAUTH_TOKEN_URL\" \\\n")
+		yaml.WriteString("            --data-urlencode \"grant_type=client_credentials\" \\\n")
+		yaml.WriteString("            --data-urlencode \"client_id=$GH_AW_OAUTH_CLIENT_ID\" \\\n")
+		if config.Scope != "" {
+			yaml.WriteString("            --data-urlencode \"scope=$GH_AW_OAUTH_SCOPE\" \\\n")
+		}
+		yaml.WriteString("            --data-urlencode \"client_secret=$GH_AW_OAUTH_CLIENT_SECRET\"); then\n")
+		fmt.Fprintf(yaml, "            echo \"::error::Failed to fetch OAuth token for the %s MCP server from $GH_AW_OAUTH_TOKEN_URL\"\n", toolName)
+		yaml.WriteString("            exit 1\n")
+		yaml.WriteString("          fi\n")
+		yaml.WriteString("          TOKEN=$(echo \"$RESPONSE\" | jq -r '.access_token // empty')\n")
+		yaml.WriteString("          if [ -z \"$TOKEN\" ]; then\n")
+		fmt.Fprintf(yaml, "            echo \"::error::OAuth token response for the %s MCP server did not include an access_token\"\n", toolName)
+		yaml.WriteString("            exit 1\n")
+		yaml.WriteString("          fi\n")
+		yaml.WriteString("          echo \"::add-mask::$TOKEN\"\n")
+		yaml.WriteString("          echo \"token=$TOKEN\" >> \"$GITHUB_OUTPUT\"\n")
+	}
+}
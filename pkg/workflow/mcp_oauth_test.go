@@ -0,0 +1,196 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMCPOAuthConfig(t *testing.T) {
+	validAuth := func() map[string]any {
+		return map[string]any{
+			"type":          "oauth",
+			"token-url":     "https://auth.example.com/oauth/token",
+			"client-id":     "gh-aw",
+			"client-secret": "${{ secrets.MCP_CLIENT_SECRET }}",
+		}
+	}
+
+	tests := []struct {
+		name        string
+		modify      func(auth map[string]any)
+		errContains string
+	}{
+		{
+			name:   "valid configuration",
+			modify: func(auth map[string]any) {},
+		},
+		{
+			name:        "unsupported type",
+			modify:      func(auth map[string]any) { auth["type"] = "basic" },
+			errContains: "'auth.type' must be 'oauth'",
+		},
+		{
+			name:        "token url without https",
+			modify:      func(auth map[string]any) { auth["token-url"] = "http://auth.example.com/token" },
+			errContains: "'auth.token-url' must be an https:// URL",
+		},
+		{
+			name:        "missing client id",
+			modify:      func(auth map[string]any) { delete(auth, "client-id") },
+			errContains: "'auth.client-id' is required",
+		},
+		{
+			name:        "plain text client secret",
+			modify:      func(auth map[string]any) { auth["client-secret"] = "hunter2" },
+			errContains: "'auth.client-secret' must be a ${{ secrets.* }} reference",
+		},
+		{
+			name:        "client secret from a variable",
+			modify:      func(auth map[string]any) { auth["client-secret"] = "${{ vars.MCP_CLIENT_SECRET }}" },
+			errContains: "'auth.client-secret' must be a ${{ secrets.* }} reference",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			auth := validAuth()
+			tt.modify(auth)
+
+			config, err := parseMCPOAuthConfig("acme", map[string]any{"type": "http", "url": "https://mcp.example.com", "auth": auth})
+			if tt.errContains != "" {
+				require.Error(t, err, "Invalid auth block should be rejected")
+				assert.Contains(t, err.Error(), tt.errContains, "Error should explain the problem")
+				return
+			}
+			require.NoError(t, err, "Valid auth block should parse")
+			require.NotNil(t, config, "Auth block should produce a configuration")
+			assert.Equal(t, "https://auth.example.com/oauth/token", config.TokenURL, "Token URL should be parsed")
+		})
+	}
+
+	config, err := parseMCPOAuthConfig("acme", map[string]any{"type": "http", "url": "https://mcp.example.com"})
+	require.NoError(t, err, "Servers without auth should parse")
+	assert.Nil(t, config, "Servers without auth should have no OAuth configuration")
+}
+
+func TestValidateMCPAuth(t *testing.T) {
+	auth := map[string]any{
+		"type":          "oauth",
+		"token-url":     "https://auth.example.com/oauth/token",
+		"client-id":     "gh-aw",
+		"client-secret": "${{ secrets.MCP_CLIENT_SECRET }}",
+	}
+
+	err := validateMCPAuth("acme", map[string]any{"container": "acme/mcp", "auth": auth})
+	require.Error(t, err, "Auth on stdio servers should be rejected")
+	assert.Contains(t, err.Error(), "only supported for http MCP servers", "Error should name the supported server type")
+
+	err = validateMCPAuth("acme", map[string]any{
+		"url":     "https://mcp.example.com",
+		"headers": map[string]any{"Authorization": "Bearer ${{ secrets.TOKEN }}"},
+		"auth":    auth,
+	})
+	require.Error(t, err, "Auth combined with an Authorization header should be rejected")
+	assert.Contains(t, err.Error(), "cannot set both 'auth' and an 'Authorization' header", "Error should explain the conflict")
+}
+
+func TestValidateMCPConfigsOAuthTokenEnvVarCollision(t *testing.T) {
+	server := func() map[string]any {
+		return map[string]any{
+			"url": "https://mcp.example.com",
+			"auth": map[string]any{
+				"type":          "oauth",
+				"token-url":     "https://auth.example.com/oauth/token",
+				"client-id":     "gh-aw",
+				"client-secret": "${{ secrets.MCP_CLIENT_SECRET }}",
+			},
+		}
+	}
+
+	err := ValidateMCPConfigs(map[string]any{"acme-api": server(), "acme_api": server()})
+	require.Error(t, err, "Servers mapping to the same OAuth token env var should be rejected")
+	assert.Contains(t, err.Error(), "tools 'acme-api' and 'acme_api'", "Error should name both servers")
+	assert.Contains(t, err.Error(), "GH_AW_MCP_OAUTH_TOKEN_ACME_API", "Error should name the shared env var")
+
+	assert.NoError(t, ValidateMCPConfigs(map[string]any{"acme-api": server(), "acme-web": server()}),
+		"Distinct server names should validate")
+}
+
+func TestMCPOAuthTokenStep(t *testing.T) {
+	tests := []struct {
+		engine string
+	}{
+		{engine: "claude"},
+		{engine: "copilot"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.engine, func(t *testing.T) {
+			content := `---
+on: workflow_dispatch
+permissions:
+  contents: read
+engine: ` + tt.engine + `
+mcp-servers:
+  acme-search:
+    type: http
+    url: https://mcp.acme.example/mcp
+    auth:
+      type: oauth
+      token-url: https://auth.acme.example/oauth/token
+      client-id: gh-aw
+      client-secret: ${{ secrets.ACME_CLIENT_SECRET }}
+      scope: mcp.read
+    allowed: ["*"]
+---
+
+# Test Workflow
+`
+			markdownPath := filepath.Join(testutil.TempDir(t, "mcp-oauth"), "test.md")
+
+			lockContent, err := NewCompiler().CompileString(content, markdownPath)
+			require.NoError(t, err, "Workflow with an OAuth MCP server should compile")
+			agentJob := agentJobSection(t, lockContent)
+
+			assert.Contains(t, agentJob, "- name: Fetch OAuth token for acme-search MCP server\n        id: mcp-oauth-acme-search\n", "Agent job should fetch the OAuth token")
+			assert.Contains(t, agentJob, "GH_AW_OAUTH_CLIENT_SECRET: ${{ secrets.ACME_CLIENT_SECRET }}", "Client secret should be passed through the step env")
+			assert.Contains(t, agentJob, `GH_AW_OAUTH_SCOPE: "mcp.read"`, "Scope should be passed through the step env")
+			assert.Contains(t, agentJob, "::error::Failed to fetch OAuth token for the acme-search MCP server", "A failed token request should fail the job")
+			assert.Contains(t, agentJob, `echo "::add-mask::$TOKEN"`, "The token should be masked")
+			assert.Contains(t, agentJob, "GH_AW_MCP_OAUTH_TOKEN_ACME_SEARCH: ${{ steps.mcp-oauth-acme-search.outputs.token }}", "The gateway should receive the token")
+			assert.Contains(t, agentJob, "-e GH_AW_MCP_OAUTH_TOKEN_ACME_SEARCH", "The token should be passed into the gateway container")
+			assert.Contains(t, agentJob, `"Authorization": "Bearer \${GH_AW_MCP_OAUTH_TOKEN_ACME_SEARCH}"`, "The token should be sent as a bearer token")
+
+			tokenStep := strings.Index(agentJob, "- name: Fetch OAuth token for acme-search MCP server")
+			gatewayStep := strings.Index(agentJob, "- name: Start MCP gateway")
+			assert.Less(t, tokenStep, gatewayStep, "The token should be fetched before the gateway starts")
+		})
+	}
+}
+
+func TestMCPOAuthRequiredSecrets(t *testing.T) {
+	workflowData := &WorkflowData{
+		Tools: map[string]any{
+			"acme": map[string]any{
+				"type": "http",
+				"url":  "https://mcp.acme.example/mcp",
+				"auth": map[string]any{
+					"type":          "oauth",
+					"token-url":     "https://auth.acme.example/oauth/token",
+					"client-id":     "gh-aw",
+					"client-secret": "${{ secrets.ACME_CLIENT_SECRET }}",
+				},
+			},
+		},
+	}
+
+	assert.Contains(t, NewCopilotEngine().GetRequiredSecretNames(workflowData), "ACME_CLIENT_SECRET", "Copilot engine should require the OAuth client secret")
+	assert.Contains(t, NewCopilotSDKEngine().GetRequiredSecretNames(workflowData), "ACME_CLIENT_SECRET", "Copilot SDK engine should require the OAuth client secret")
+}
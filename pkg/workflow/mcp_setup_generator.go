@@ -73,14 +73,14 @@ import (
 var mcpSetupGeneratorLog = logger.New("workflow:mcp_setup_generator")
 
 // generateMCPSetup generates the MCP server configuration setup
-func (c *Compiler) generateMCPSetup(yaml *strings.Builder, tools map[string]any, engine CodingAgentEngine, workflowData *WorkflowData) {
+func (c *Compiler) generateMCPSetup(yaml *strings.Builder, tools map[string]any, engine CodingAgentEngine, workflowData *WorkflowData) error {
 	mcpSetupGeneratorLog.Print("Generating MCP server configuration setup")
 	// Collect tools that need MCP server configuration
 	var mcpTools []string
 
 	// Check if workflowData is valid before accessing its fields
 	if workflowData == nil {
-		return
+		return nil
 	}
 
 	workflowTools := workflowData.Tools
@@ -132,13 +132,27 @@ func (c *Compiler) generateMCPSetup(yaml *strings.Builder, tools map[string]any,
 	ensureDefaultMCPGatewayConfig(workflowData)
 
 	// Collect all Docker images that will be used and generate download step
+	// With mcp.pin-digests, the pinned references are used everywhere the images run:
+	// the download step, the MCP server configs, the gateway container and (via local
+	// tags created by the download script) the AWF containers
 	dockerImages := collectDockerImages(tools, workflowData, c.actionMode)
+	var imagePins map[string]string
+	if workflowData.PinDockerDigests {
+		pins, err := pinImageDigests(dockerImages, c.getDigestResolver())
+		if err != nil {
+			return err
+		}
+		imagePins = pins
+		for i, image := range dockerImages {
+			dockerImages[i] = pinnedImageRef(image, imagePins)
+		}
+	}
 	generateDownloadDockerImagesStep(yaml, dockerImages)
 
 	// If no MCP tools, no configuration needed
 	if len(mcpTools) == 0 {
 		mcpSetupGeneratorLog.Print("No MCP tools configured, skipping MCP setup")
-		return
+		return nil
 	}
 
 	// Install gh-aw extension if agentic-workflows tool is enabled
@@ -443,6 +457,9 @@ func (c *Compiler) generateMCPSetup(yaml *strings.Builder, tools map[string]any,
 		generateSerenaLocalModeSteps(yaml)
 	}
 
+	// Fetch OAuth tokens for HTTP MCP servers using auth before the gateway starts
+	generateMCPOAuthTokenSteps(yaml, tools, mcpTools)
+
 	// The MCP gateway is always enabled, even when agent sandbox is disabled
 	// Use the engine's RenderMCPConfig method
 	yaml.WriteString("      - name: Start MCP gateway\n")
@@ -550,6 +567,7 @@ func (c *Compiler) generateMCPSetup(yaml *strings.Builder, tools map[string]any,
 	} else {
 		containerImage += ":" + string(constants.DefaultMCPGatewayVersion)
 	}
+	containerImage = pinnedImageRef(containerImage, imagePins)
 
 	containerCmd := "docker run -i --rm --network host"
 	containerCmd += " -v /var/run/docker.sock:/var/run/docker.sock" // Enable docker-in-docker for MCP gateway
@@ -732,5 +750,12 @@ func (c *Compiler) generateMCPSetup(yaml *strings.Builder, tools map[string]any,
 
 	// Render MCP config - this will pipe directly to the gateway script
 	// The MCP gateway is always enabled, even when agent sandbox is disabled
-	engine.RenderMCPConfig(yaml, tools, mcpTools, workflowData)
+	var mcpConfig strings.Builder
+	engine.RenderMCPConfig(&mcpConfig, tools, mcpTools, workflowData)
+	yaml.WriteString(applyImagePinsToMCPConfig(mcpConfig.String(), imagePins))
+
+	// Wait for MCP servers with a health-check to become ready before the agent starts
+	generateMCPHealthCheckSteps(yaml, tools, mcpTools)
+
+	return nil
 }
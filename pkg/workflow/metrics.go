@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"regexp"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -30,7 +31,11 @@ type LogMetrics struct {
 	Turns         int            // Number of turns needed to complete the task
 	ToolCalls     []ToolCallInfo // Tool call statistics
 	ToolSequences [][]string     // Sequences of tool calls preserving order
+	Truncated     bool           // Log ended without a final result entry (e.g. the run was killed); metrics are partial
 	// Timestamp removed - use GitHub API timestamps instead of parsing from logs
+
+	// Turn in which each entry of ToolSequences started (parallel to ToolSequences; 0 means before the first turn)
+	ToolSequenceTurns []int
 }
 
 // ExtractFirstMatch extracts the first regex match from a string
@@ -283,35 +288,172 @@ func ExtractMCPServer(toolName string) string {
 	return toolName
 }
 
+// engineToolNameAliases maps each engine's names for built-in tools to the neutral names used in
+// frontmatter (bash, edit, web-fetch, web-search).
+var engineToolNameAliases = map[string]map[string]string{
+	"claude": {
+		"Bash":         "bash",
+		"BashOutput":   "bash",
+		"KillBash":     "bash",
+		"Edit":         "edit",
+		"MultiEdit":    "edit",
+		"NotebookEdit": "edit",
+		"Write":        "edit",
+		"WebFetch":     "web-fetch",
+		"WebSearch":    "web-search",
+	},
+	"copilot": {
+		"bash":       "bash",
+		"shell":      "bash",
+		"edit":       "edit",
+		"write":      "edit",
+		"web_fetch":  "web-fetch",
+		"web_search": "web-search",
+	},
+	"copilot-sdk": {
+		"bash":       "bash",
+		"shell":      "bash",
+		"edit":       "edit",
+		"write":      "edit",
+		"web_fetch":  "web-fetch",
+		"web_search": "web-search",
+	},
+	"codex": {
+		"exec":       "bash",
+		"shell":      "bash",
+		"web_search": "web-search",
+	},
+}
+
+// isNeutralToolName reports whether name is already one of the neutral built-in tool names
+func isNeutralToolName(name string) bool {
+	for _, aliases := range engineToolNameAliases {
+		for _, neutral := range aliases {
+			if name == neutral {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// CanonicalizeToolName maps an engine-specific tool name to a name that is the same across
+// engines, so metrics and reports can group equivalent tools from runs of different engines:
+//   - Built-in tools use the neutral frontmatter names: "Bash" (claude), "shell(git:*)" (copilot)
+//     and "bash_git_status" (per-command names from log parsing) all become "bash"
+//   - MCP tools use the server_method form: "mcp__github__get_issue" (claude),
+//     "github-get_issue" (copilot) and "github.get_issue" (codex) all become "github_get_issue"
+//
+// Names that are not recognized for the engine, and names that are already neutral, are
+// returned unchanged.
+func CanonicalizeToolName(engine string, tool string) string {
+	name := strings.TrimSpace(tool)
+
+	// Drop granular arguments such as "bash(git status)" or "github(get_issue)"
+	if open := strings.Index(name, "("); open > 0 && strings.HasSuffix(name, ")") {
+		name = name[:open]
+	}
+
+	// Per-command bash entries recorded by the log parsers
+	if strings.HasPrefix(name, "bash_") {
+		return "bash"
+	}
+
+	if canonical, ok := engineToolNameAliases[engine][name]; ok {
+		return canonical
+	}
+
+	// Already canonical, e.g. "web-fetch" must not become "web_fetch" through the copilot rule
+	if isNeutralToolName(name) {
+		return name
+	}
+
+	switch {
+	case strings.HasPrefix(name, "mcp__"):
+		return PrettifyToolName(name)
+	case engine == "copilot" || engine == "copilot-sdk":
+		// Server names may contain hyphens; method names do not
+		if dash := strings.LastIndex(name, "-"); dash > 0 && dash < len(name)-1 {
+			return name[:dash] + "_" + name[dash+1:]
+		}
+	case engine == "codex":
+		if server, method, found := strings.Cut(name, "."); found && server != "" && method != "" {
+			return server + "_" + strings.ReplaceAll(method, ".", "_")
+		}
+	}
+	return name
+}
+
+// AddToolSequence records a completed tool sequence together with the turn in which it started
+func (m *LogMetrics) AddToolSequence(sequence []string, turn int) {
+	m.ToolSequences = append(m.ToolSequences, sequence)
+	m.ToolSequenceTurns = append(m.ToolSequenceTurns, turn)
+}
+
+// FilterToolSequencesByTurn returns the tool sequences that started at or after minTurn,
+// for analyzing only the tail of a long run. When the metrics carry no turn markers
+// (for example, metrics built by hand), all sequences are returned.
+func FilterToolSequencesByTurn(metrics LogMetrics, minTurn int) [][]string {
+	if len(metrics.ToolSequenceTurns) != len(metrics.ToolSequences) {
+		metricsLog.Printf("Tool sequences have no turn markers, returning all %d sequences", len(metrics.ToolSequences))
+		return metrics.ToolSequences
+	}
+
+	var sequences [][]string
+	for i, sequence := range metrics.ToolSequences {
+		if metrics.ToolSequenceTurns[i] >= minTurn {
+			sequences = append(sequences, sequence)
+		}
+	}
+	metricsLog.Printf("Filtered tool sequences by turn >= %d: %d of %d", minTurn, len(sequences), len(metrics.ToolSequences))
+	return sequences
+}
+
 // FinalizeToolMetricsOptions holds the options for FinalizeToolMetrics
 type FinalizeToolMetricsOptions struct {
-	Metrics         *LogMetrics
-	ToolCallMap     map[string]*ToolCallInfo
-	CurrentSequence []string
-	Turns           int
-	TokenUsage      int
+	Metrics             *LogMetrics
+	ToolCallMap         map[string]*ToolCallInfo
+	CurrentSequence     []string
+	CurrentSequenceTurn int // Turn in which CurrentSequence started
+	Turns               int
+	TokenUsage          int
 }
 
 // FinalizeToolMetrics completes the metric collection process by finalizing sequences,
 // converting tool call maps to sorted slices, and optionally counting errors using patterns.
+// The output is identical across runs for identical input: the final sequence is copied
+// instead of sharing the caller's slice, and tool calls are ordered independently of map
+// iteration order.
 // This function is called by engine-specific ParseLogMetrics implementations to avoid code duplication.
 func FinalizeToolMetrics(opts FinalizeToolMetricsOptions) {
 	// Add final sequence if any
 	if len(opts.CurrentSequence) > 0 {
-		opts.Metrics.ToolSequences = append(opts.Metrics.ToolSequences, opts.CurrentSequence)
+		opts.Metrics.AddToolSequence(slices.Clone(opts.CurrentSequence), opts.CurrentSequenceTurn)
 	}
 
 	opts.Metrics.TokenUsage = opts.TokenUsage
 	opts.Metrics.Turns = opts.Turns
 
-	// Convert tool call map to slice
-	for _, toolInfo := range opts.ToolCallMap {
-		opts.Metrics.ToolCalls = append(opts.Metrics.ToolCalls, *toolInfo)
+	appendToolCalls(opts.Metrics, opts.ToolCallMap)
+}
+
+// appendToolCalls converts a tool call map to a slice sorted by name and appends it to the
+// metrics. The map is walked in key order so that the result does not depend on map
+// iteration order, even when several entries share a name.
+func appendToolCalls(metrics *LogMetrics, toolCallMap map[string]*ToolCallInfo) {
+	keys := make([]string, 0, len(toolCallMap))
+	for key := range toolCallMap {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		metrics.ToolCalls = append(metrics.ToolCalls, *toolCallMap[key])
 	}
 
 	// Sort tool calls by name for consistent output
-	sort.Slice(opts.Metrics.ToolCalls, func(i, j int) bool {
-		return opts.Metrics.ToolCalls[i].Name < opts.Metrics.ToolCalls[j].Name
+	sort.SliceStable(metrics.ToolCalls, func(i, j int) bool {
+		return metrics.ToolCalls[i].Name < metrics.ToolCalls[j].Name
 	})
 }
 
@@ -323,19 +465,186 @@ func FinalizeToolCallsAndSequence(
 	metrics *LogMetrics,
 	toolCallMap map[string]*ToolCallInfo,
 	currentSequence []string,
+	currentSequenceTurn int,
 ) {
 	// Add final sequence if any
 	if len(currentSequence) > 0 {
-		metrics.ToolSequences = append(metrics.ToolSequences, currentSequence)
+		metrics.AddToolSequence(currentSequence, currentSequenceTurn)
 	}
 
-	// Convert tool call map to slice
-	for _, toolInfo := range toolCallMap {
-		metrics.ToolCalls = append(metrics.ToolCalls, *toolInfo)
+	appendToolCalls(metrics, toolCallMap)
+}
+
+// AggregateLogMetrics combines metrics from multiple ParseLogMetrics calls (e.g., multiple jobs
+// of a single workflow run) into one rollup. Token usage, turns, and estimated cost are summed,
+// tool calls are merged by name (call counts are summed while max input/output sizes and
+// durations keep the largest value), and tool sequences are concatenated in order. Sequence
+// turns are offset by the turns of the preceding metrics so they stay ordered.
+func AggregateLogMetrics(metrics ...LogMetrics) LogMetrics {
+	var result LogMetrics
+	toolCallMap := make(map[string]*ToolCallInfo)
+
+	for _, m := range metrics {
+		hasTurns := len(m.ToolSequenceTurns) == len(m.ToolSequences)
+		for i, sequence := range m.ToolSequences {
+			turn := 0
+			if hasTurns {
+				turn = m.ToolSequenceTurns[i]
+			}
+			result.AddToolSequence(sequence, result.Turns+turn)
+		}
+		result.TokenUsage += m.TokenUsage
+		result.Turns += m.Turns
+		result.EstimatedCost += m.EstimatedCost
+
+		for _, toolCall := range m.ToolCalls {
+			existing, exists := toolCallMap[toolCall.Name]
+			if !exists {
+				merged := toolCall
+				toolCallMap[toolCall.Name] = &merged
+				continue
+			}
+			existing.CallCount += toolCall.CallCount
+			if toolCall.MaxInputSize > existing.MaxInputSize {
+				existing.MaxInputSize = toolCall.MaxInputSize
+			}
+			if toolCall.MaxOutputSize > existing.MaxOutputSize {
+				existing.MaxOutputSize = toolCall.MaxOutputSize
+			}
+			if toolCall.MaxDuration > existing.MaxDuration {
+				existing.MaxDuration = toolCall.MaxDuration
+			}
+		}
 	}
 
-	// Sort tool calls by name for consistent output
-	sort.Slice(metrics.ToolCalls, func(i, j int) bool {
-		return metrics.ToolCalls[i].Name < metrics.ToolCalls[j].Name
-	})
+	FinalizeToolCallsAndSequence(&result, toolCallMap, nil, 0)
+
+	metricsLog.Printf("Aggregated %d metrics: tokens=%d, turns=%d, cost=%.6f, tools=%d",
+		len(metrics), result.TokenUsage, result.Turns, result.EstimatedCost, len(result.ToolCalls))
+	return result
+}
+
+// MetricDelta describes how a single metric changed between two runs
+type MetricDelta struct {
+	Name          string  `json:"name"`
+	Base          float64 `json:"base"`
+	Head          float64 `json:"head"`
+	Change        float64 `json:"change"`         // Head minus Base
+	PercentChange float64 `json:"percent_change"` // Change relative to Base; 100 when a metric appears from zero
+}
+
+// String formats the delta as "Name: base -> head (+percent%)"
+func (d MetricDelta) String() string {
+	return fmt.Sprintf("%s: %s -> %s (%+.1f%%)", d.Name,
+		strconv.FormatFloat(d.Base, 'f', -1, 64), strconv.FormatFloat(d.Head, 'f', -1, 64), d.PercentChange)
+}
+
+// LogMetricsComparison is a side-by-side comparison of the metrics of two runs
+type LogMetricsComparison struct {
+	TokenUsage    MetricDelta   `json:"token_usage"`
+	Turns         MetricDelta   `json:"turns"`
+	EstimatedCost MetricDelta   `json:"estimated_cost"`
+	ToolCalls     []MetricDelta `json:"tool_calls"` // Per-tool call counts, sorted by tool name
+}
+
+// newMetricDelta computes the change of a metric from base to head
+func newMetricDelta(name string, base, head float64) MetricDelta {
+	delta := MetricDelta{Name: name, Base: base, Head: head, Change: head - base}
+	switch {
+	case base != 0:
+		delta.PercentChange = delta.Change / base * 100
+	case head > 0:
+		delta.PercentChange = 100
+	}
+	return delta
+}
+
+// CompareLogMetrics compares the metrics of a base run with those of a head run.
+// Tool calls are matched by name; a tool used by only one run is compared against zero calls.
+func CompareLogMetrics(base, head LogMetrics) LogMetricsComparison {
+	comparison := LogMetricsComparison{
+		TokenUsage:    newMetricDelta("Token Usage", float64(base.TokenUsage), float64(head.TokenUsage)),
+		Turns:         newMetricDelta("Turns", float64(base.Turns), float64(head.Turns)),
+		EstimatedCost: newMetricDelta("Estimated Cost", base.EstimatedCost, head.EstimatedCost),
+	}
+
+	baseCalls := make(map[string]int)
+	headCalls := make(map[string]int)
+	for _, toolCall := range base.ToolCalls {
+		baseCalls[toolCall.Name] += toolCall.CallCount
+	}
+	for _, toolCall := range head.ToolCalls {
+		headCalls[toolCall.Name] += toolCall.CallCount
+	}
+
+	var toolNames []string
+	for name := range baseCalls {
+		toolNames = append(toolNames, name)
+	}
+	for name := range headCalls {
+		if _, inBase := baseCalls[name]; !inBase {
+			toolNames = append(toolNames, name)
+		}
+	}
+	sort.Strings(toolNames)
+
+	for _, name := range toolNames {
+		comparison.ToolCalls = append(comparison.ToolCalls, newMetricDelta(name, float64(baseCalls[name]), float64(headCalls[name])))
+	}
+
+	metricsLog.Printf("Compared metrics: tokens=%+.0f, turns=%+.0f, cost=%+.6f, tools=%d",
+		comparison.TokenUsage.Change, comparison.Turns.Change, comparison.EstimatedCost.Change, len(comparison.ToolCalls))
+	return comparison
+}
+
+// Regressions returns the metrics that increased by more than thresholdPercent
+func (c LogMetricsComparison) Regressions(thresholdPercent float64) []MetricDelta {
+	var regressions []MetricDelta
+	deltas := append([]MetricDelta{c.TokenUsage, c.Turns, c.EstimatedCost}, c.ToolCalls...)
+	for _, delta := range deltas {
+		if delta.Change > 0 && delta.PercentChange > thresholdPercent {
+			regressions = append(regressions, delta)
+		}
+	}
+	return regressions
+}
+
+// MetricsThresholds sets how much each metric may grow, in percent of the baseline,
+// before DiffLogMetrics reports it as a regression. A zero threshold flags any increase.
+type MetricsThresholds struct {
+	TokenUsagePercent    float64 `json:"token_usage_percent"`
+	TurnsPercent         float64 `json:"turns_percent"`
+	EstimatedCostPercent float64 `json:"estimated_cost_percent"`
+}
+
+// Regression is a metric that grew beyond its threshold. The embedded delta names the field
+// and carries the baseline value, current value and percent increase.
+type Regression struct {
+	MetricDelta
+	ThresholdPercent float64 `json:"threshold_percent"` // Allowed growth that the metric exceeded
+}
+
+// DiffLogMetrics compares current metrics against a baseline and reports the token usage,
+// turn count and estimated cost increases that exceed their thresholds, in that order
+func DiffLogMetrics(baseline, current LogMetrics, thresholds MetricsThresholds) []Regression {
+	comparison := CompareLogMetrics(baseline, current)
+
+	checks := []struct {
+		delta     MetricDelta
+		threshold float64
+	}{
+		{comparison.TokenUsage, thresholds.TokenUsagePercent},
+		{comparison.Turns, thresholds.TurnsPercent},
+		{comparison.EstimatedCost, thresholds.EstimatedCostPercent},
+	}
+
+	var regressions []Regression
+	for _, check := range checks {
+		if check.delta.Change > 0 && check.delta.PercentChange > check.threshold {
+			regressions = append(regressions, Regression{MetricDelta: check.delta, ThresholdPercent: check.threshold})
+		}
+	}
+
+	metricsLog.Printf("Diffed metrics against baseline: %d regressions", len(regressions))
+	return regressions
 }
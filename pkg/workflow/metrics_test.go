@@ -5,8 +5,10 @@ package workflow
 import (
 	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/github/gh-aw/pkg/logger"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestExtractFirstMatch(t *testing.T) {
@@ -668,6 +670,73 @@ func TestPrettifyToolName(t *testing.T) {
 	}
 }
 
+func TestCanonicalizeToolName(t *testing.T) {
+	tests := []struct {
+		engine   string
+		tool     string
+		expected string
+	}{
+		{engine: "claude", tool: "Bash", expected: "bash"},
+		{engine: "claude", tool: "Bash(git status)", expected: "bash"},
+		{engine: "claude", tool: "bash_git_status", expected: "bash"},
+		{engine: "claude", tool: "WebFetch", expected: "web-fetch"},
+		{engine: "claude", tool: "WebSearch", expected: "web-search"},
+		{engine: "claude", tool: "MultiEdit", expected: "edit"},
+		{engine: "claude", tool: "Write", expected: "edit"},
+		{engine: "claude", tool: "mcp__github__get_issue", expected: "github_get_issue"},
+		{engine: "claude", tool: "Read", expected: "Read"},
+		{engine: "copilot", tool: "shell", expected: "bash"},
+		{engine: "copilot", tool: "shell(git:*)", expected: "bash"},
+		{engine: "copilot", tool: "web_fetch", expected: "web-fetch"},
+		{engine: "copilot", tool: "web_search", expected: "web-search"},
+		{engine: "copilot", tool: "write", expected: "edit"},
+		{engine: "copilot", tool: "web-fetch", expected: "web-fetch"},
+		{engine: "copilot", tool: "web-search", expected: "web-search"},
+		{engine: "copilot", tool: "github-get_issue", expected: "github_get_issue"},
+		{engine: "copilot", tool: "safe-outputs-create_issue", expected: "safe-outputs_create_issue"},
+		{engine: "copilot-sdk", tool: "web_fetch", expected: "web-fetch"},
+		{engine: "codex", tool: "bash_ls_-la", expected: "bash"},
+		{engine: "codex", tool: "github.get_issue", expected: "github_get_issue"},
+		{engine: "codex", tool: "github_get_issue", expected: "github_get_issue"},
+		{engine: "custom", tool: "WebFetch", expected: "WebFetch"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.engine+"/"+tt.tool, func(t *testing.T) {
+			assert.Equal(t, tt.expected, CanonicalizeToolName(tt.engine, tt.tool), "Tool name should be canonicalized")
+		})
+	}
+}
+
+func TestCanonicalizeToolNameGroupsEquivalentTools(t *testing.T) {
+	equivalent := [][2]string{
+		{"claude", "mcp__github__get_issue"},
+		{"copilot", "github-get_issue"},
+		{"codex", "github.get_issue"},
+	}
+	for _, pair := range equivalent {
+		assert.Equal(t, "github_get_issue", CanonicalizeToolName(pair[0], pair[1]), "%s should map %s to the shared name", pair[0], pair[1])
+	}
+}
+
+func TestCanonicalizeToolNameIsIdempotent(t *testing.T) {
+	tools := map[string][]string{
+		"claude":      {"Bash", "WebFetch", "WebSearch", "MultiEdit", "mcp__github__get_issue", "Read"},
+		"copilot":     {"shell", "web_fetch", "web_search", "write", "github-get_issue"},
+		"copilot-sdk": {"web_fetch", "web_search"},
+		"codex":       {"exec", "web_search", "github.get_issue"},
+	}
+	for engine, names := range tools {
+		for _, name := range names {
+			canonical := CanonicalizeToolName(engine, name)
+			assert.Equal(t, canonical, CanonicalizeToolName(engine, canonical), "%s should keep the canonical name of %s", engine, name)
+		}
+		for _, neutral := range []string{"bash", "edit", "web-fetch", "web-search"} {
+			assert.Equal(t, neutral, CanonicalizeToolName(engine, neutral), "%s should keep the neutral name %s", engine, neutral)
+		}
+	}
+}
+
 func TestExtractErrorMessage(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -876,6 +945,41 @@ func TestFinalizeToolMetrics(t *testing.T) {
 	}
 }
 
+func TestFinalizeToolMetricsDeterministic(t *testing.T) {
+	finalize := func(currentSequence []string) LogMetrics {
+		metrics := LogMetrics{
+			ToolSequences:     [][]string{{"bash", "github::search_issues"}},
+			ToolSequenceTurns: []int{1},
+		}
+		FinalizeToolMetrics(FinalizeToolMetricsOptions{
+			Metrics: &metrics,
+			ToolCallMap: map[string]*ToolCallInfo{
+				"mcp__github__search_issues": {Name: "github::search_issues", CallCount: 1},
+				"github__search_issues":      {Name: "github::search_issues", CallCount: 2},
+				"bash":                       {Name: "bash", CallCount: 3},
+				"web_fetch":                  {Name: "web_fetch", CallCount: 4},
+			},
+			CurrentSequence:     currentSequence,
+			CurrentSequenceTurn: 3,
+			Turns:               4,
+			TokenUsage:          1000,
+		})
+		return metrics
+	}
+
+	currentSequence := []string{"web_fetch", "bash"}
+	first := finalize(currentSequence)
+	second := finalize(currentSequence)
+
+	assert.Equal(t, first, second, "Finalizing identical input twice should produce identical metrics")
+	assert.Equal(t, [][]string{{"bash", "github::search_issues"}, {"web_fetch", "bash"}}, first.ToolSequences, "Tool sequences should keep their recorded order")
+	assert.Equal(t, []int{3, 2, 1, 4}, []int{first.ToolCalls[0].CallCount, first.ToolCalls[1].CallCount, first.ToolCalls[2].CallCount, first.ToolCalls[3].CallCount},
+		"Tool calls sharing a name should be ordered by their map key")
+
+	currentSequence[0] = "edit"
+	assert.Equal(t, []string{"web_fetch", "bash"}, first.ToolSequences[1], "Recorded sequences should not share the caller's slice")
+}
+
 func TestFinalizeToolCallsAndSequence(t *testing.T) {
 	tests := []struct {
 		name            string
@@ -934,7 +1038,7 @@ func TestFinalizeToolCallsAndSequence(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			metrics := tt.initialMetrics
 
-			FinalizeToolCallsAndSequence(&metrics, tt.toolCallMap, tt.currentSequence)
+			FinalizeToolCallsAndSequence(&metrics, tt.toolCallMap, tt.currentSequence, 0)
 
 			if len(metrics.ToolCalls) != tt.expectedToolLen {
 				t.Errorf("Expected %d tool calls, got %d", tt.expectedToolLen, len(metrics.ToolCalls))
@@ -957,6 +1061,171 @@ func TestFinalizeToolCallsAndSequence(t *testing.T) {
 	}
 }
 
+func TestAggregateLogMetrics(t *testing.T) {
+	tests := []struct {
+		name     string
+		metrics  []LogMetrics
+		expected LogMetrics
+	}{
+		{
+			name:     "no metrics",
+			metrics:  nil,
+			expected: LogMetrics{},
+		},
+		{
+			name: "single metrics passes through",
+			metrics: []LogMetrics{
+				{
+					TokenUsage:    100,
+					EstimatedCost: 0.5,
+					Turns:         2,
+					ToolCalls:     []ToolCallInfo{{Name: "bash", CallCount: 2, MaxInputSize: 10, MaxOutputSize: 20}},
+					ToolSequences: [][]string{{"bash", "bash"}},
+				},
+			},
+			expected: LogMetrics{
+				TokenUsage:    100,
+				EstimatedCost: 0.5,
+				Turns:         2,
+				ToolCalls:     []ToolCallInfo{{Name: "bash", CallCount: 2, MaxInputSize: 10, MaxOutputSize: 20}},
+				ToolSequences: [][]string{{"bash", "bash"}},
+			},
+		},
+		{
+			name: "overlapping tool names keep max sizes",
+			metrics: []LogMetrics{
+				{
+					TokenUsage:    1000,
+					EstimatedCost: 0.25,
+					Turns:         3,
+					ToolCalls: []ToolCallInfo{
+						{Name: "bash", CallCount: 2, MaxInputSize: 50, MaxOutputSize: 400, MaxDuration: 2 * time.Second},
+						{Name: "github_search_issues", CallCount: 1, MaxInputSize: 30, MaxOutputSize: 900},
+					},
+					ToolSequences: [][]string{{"bash", "github_search_issues", "bash"}},
+				},
+				{
+					TokenUsage:    500,
+					EstimatedCost: 0.75,
+					Turns:         4,
+					ToolCalls: []ToolCallInfo{
+						{Name: "bash", CallCount: 3, MaxInputSize: 120, MaxOutputSize: 100, MaxDuration: time.Second},
+					},
+					ToolSequences: [][]string{{"bash"}, {"bash", "bash"}},
+				},
+			},
+			expected: LogMetrics{
+				TokenUsage:    1500,
+				EstimatedCost: 1.0,
+				Turns:         7,
+				ToolCalls: []ToolCallInfo{
+					{Name: "bash", CallCount: 5, MaxInputSize: 120, MaxOutputSize: 400, MaxDuration: 2 * time.Second},
+					{Name: "github_search_issues", CallCount: 1, MaxInputSize: 30, MaxOutputSize: 900},
+				},
+				ToolSequences: [][]string{{"bash", "github_search_issues", "bash"}, {"bash"}, {"bash", "bash"}},
+			},
+		},
+		{
+			name: "disjoint tool names are sorted",
+			metrics: []LogMetrics{
+				{
+					TokenUsage: 10,
+					Turns:      1,
+					ToolCalls:  []ToolCallInfo{{Name: "web_fetch", CallCount: 1, MaxOutputSize: 5}},
+				},
+				{
+					TokenUsage:    20,
+					EstimatedCost: 0.1,
+					Turns:         1,
+					ToolCalls:     []ToolCallInfo{{Name: "bash", CallCount: 4, MaxInputSize: 7}},
+					ToolSequences: [][]string{{"bash"}},
+				},
+			},
+			expected: LogMetrics{
+				TokenUsage:    30,
+				EstimatedCost: 0.1,
+				Turns:         2,
+				ToolCalls: []ToolCallInfo{
+					{Name: "bash", CallCount: 4, MaxInputSize: 7},
+					{Name: "web_fetch", CallCount: 1, MaxOutputSize: 5},
+				},
+				ToolSequences: [][]string{{"bash"}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := AggregateLogMetrics(tt.metrics...)
+
+			assert.Equal(t, tt.expected.TokenUsage, result.TokenUsage, "Token usage should be summed")
+			assert.Equal(t, tt.expected.Turns, result.Turns, "Turns should be summed")
+			assert.InDelta(t, tt.expected.EstimatedCost, result.EstimatedCost, 1e-9, "Estimated cost should be summed")
+			assert.Equal(t, tt.expected.ToolCalls, result.ToolCalls, "Tool calls should be merged by name")
+			assert.Equal(t, tt.expected.ToolSequences, result.ToolSequences, "Tool sequences should be concatenated")
+		})
+	}
+}
+
+func TestFilterToolSequencesByTurn(t *testing.T) {
+	metrics := LogMetrics{
+		Turns:             5,
+		ToolSequences:     [][]string{{"bash"}, {"github_search_issues", "bash"}, {"edit"}, {"bash", "edit"}},
+		ToolSequenceTurns: []int{1, 2, 4, 5},
+	}
+
+	tests := []struct {
+		name     string
+		minTurn  int
+		expected [][]string
+	}{
+		{name: "from first turn", minTurn: 0, expected: metrics.ToolSequences},
+		{name: "from middle turn", minTurn: 3, expected: [][]string{{"edit"}, {"bash", "edit"}}},
+		{name: "at exact turn", minTurn: 2, expected: [][]string{{"github_search_issues", "bash"}, {"edit"}, {"bash", "edit"}}},
+		{name: "after last turn", minTurn: 6, expected: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, FilterToolSequencesByTurn(metrics, tt.minTurn), "Sequences should be filtered by start turn")
+		})
+	}
+
+	t.Run("without turn markers", func(t *testing.T) {
+		unmarked := LogMetrics{ToolSequences: [][]string{{"bash"}, {"edit"}}}
+		assert.Equal(t, unmarked.ToolSequences, FilterToolSequencesByTurn(unmarked, 3), "Sequences without turn markers should be returned unfiltered")
+	})
+}
+
+func TestAggregateLogMetricsOffsetsSequenceTurns(t *testing.T) {
+	first := LogMetrics{
+		Turns:             3,
+		ToolSequences:     [][]string{{"bash"}, {"edit"}},
+		ToolSequenceTurns: []int{1, 3},
+	}
+	second := LogMetrics{
+		Turns:             2,
+		ToolSequences:     [][]string{{"github_search_issues"}},
+		ToolSequenceTurns: []int{2},
+	}
+
+	result := AggregateLogMetrics(first, second)
+
+	assert.Equal(t, [][]string{{"bash"}, {"edit"}, {"github_search_issues"}}, result.ToolSequences, "Tool sequences should be concatenated")
+	assert.Equal(t, []int{1, 3, 5}, result.ToolSequenceTurns, "Sequence turns should be offset by the preceding turns")
+	assert.Equal(t, [][]string{{"edit"}, {"github_search_issues"}}, FilterToolSequencesByTurn(result, 3), "Aggregated sequences should be filterable by turn")
+}
+
+func TestAggregateLogMetricsDoesNotMutateInput(t *testing.T) {
+	first := LogMetrics{ToolCalls: []ToolCallInfo{{Name: "bash", CallCount: 1, MaxInputSize: 5}}}
+	second := LogMetrics{ToolCalls: []ToolCallInfo{{Name: "bash", CallCount: 2, MaxInputSize: 9}}}
+
+	AggregateLogMetrics(first, second)
+
+	assert.Equal(t, 1, first.ToolCalls[0].CallCount, "Input call count should not be modified")
+	assert.Equal(t, 5, first.ToolCalls[0].MaxInputSize, "Input max size should not be modified")
+}
+
 // TestConvertToIntTruncation tests float truncation scenarios in ConvertToInt
 func TestConvertToIntTruncation(t *testing.T) {
 	tests := []struct {
@@ -1037,3 +1306,107 @@ func TestConvertToIntTruncation(t *testing.T) {
 		})
 	}
 }
+
+func TestCompareLogMetrics(t *testing.T) {
+	base := LogMetrics{
+		TokenUsage:    1000,
+		Turns:         4,
+		EstimatedCost: 0.20,
+		ToolCalls: []ToolCallInfo{
+			{Name: "bash", CallCount: 4},
+			{Name: "github::search_issues", CallCount: 2},
+		},
+	}
+	head := LogMetrics{
+		TokenUsage:    1500,
+		Turns:         3,
+		EstimatedCost: 0.20,
+		ToolCalls: []ToolCallInfo{
+			{Name: "bash", CallCount: 6},
+			{Name: "edit", CallCount: 1},
+		},
+	}
+
+	comparison := CompareLogMetrics(base, head)
+
+	assert.InDelta(t, 500, comparison.TokenUsage.Change, 0.001, "Token usage increase should be reported")
+	assert.InDelta(t, 50, comparison.TokenUsage.PercentChange, 0.001, "Token usage percent change should be relative to base")
+	assert.InDelta(t, -1, comparison.Turns.Change, 0.001, "Turn decrease should be reported")
+	assert.InDelta(t, -25, comparison.Turns.PercentChange, 0.001, "Turn percent change should be negative")
+	assert.InDelta(t, 0, comparison.EstimatedCost.Change, 0.000001, "Unchanged cost should have no change")
+
+	names := make([]string, 0, len(comparison.ToolCalls))
+	for _, delta := range comparison.ToolCalls {
+		names = append(names, delta.Name)
+	}
+	assert.Equal(t, []string{"bash", "edit", "github::search_issues"}, names, "Tools from both runs should be compared in name order")
+	assert.InDelta(t, 100, comparison.ToolCalls[1].PercentChange, 0.001, "New tool should count as a 100% increase")
+	assert.InDelta(t, -100, comparison.ToolCalls[2].PercentChange, 0.001, "Removed tool should count as a 100% decrease")
+
+	regressions := comparison.Regressions(40)
+	regressionNames := make([]string, 0, len(regressions))
+	for _, delta := range regressions {
+		regressionNames = append(regressionNames, delta.Name)
+	}
+	assert.Equal(t, []string{"Token Usage", "bash", "edit"}, regressionNames, "Increases beyond the threshold should be regressions")
+	assert.Len(t, comparison.Regressions(60), 1, "Only the new tool exceeds a 60% threshold")
+}
+
+func TestDiffLogMetrics(t *testing.T) {
+	baseline := LogMetrics{TokenUsage: 1000, Turns: 4, EstimatedCost: 0.20}
+	thresholds := MetricsThresholds{TokenUsagePercent: 10, TurnsPercent: 25, EstimatedCostPercent: 10}
+
+	tests := []struct {
+		name     string
+		current  LogMetrics
+		expected []Regression
+	}{
+		{
+			name:    "within thresholds",
+			current: LogMetrics{TokenUsage: 1100, Turns: 5, EstimatedCost: 0.21},
+		},
+		{
+			name:    "improvements are not regressions",
+			current: LogMetrics{TokenUsage: 500, Turns: 2, EstimatedCost: 0.10},
+		},
+		{
+			name:    "over thresholds",
+			current: LogMetrics{TokenUsage: 1500, Turns: 6, EstimatedCost: 0.30},
+			expected: []Regression{
+				{MetricDelta: MetricDelta{Name: "Token Usage", Base: 1000, Head: 1500, PercentChange: 50}, ThresholdPercent: 10},
+				{MetricDelta: MetricDelta{Name: "Turns", Base: 4, Head: 6, PercentChange: 50}, ThresholdPercent: 25},
+				{MetricDelta: MetricDelta{Name: "Estimated Cost", Base: 0.20, Head: 0.30, PercentChange: 50}, ThresholdPercent: 10},
+			},
+		},
+		{
+			name:    "only token usage over threshold",
+			current: LogMetrics{TokenUsage: 1200, Turns: 4, EstimatedCost: 0.20},
+			expected: []Regression{
+				{MetricDelta: MetricDelta{Name: "Token Usage", Base: 1000, Head: 1200, PercentChange: 20}, ThresholdPercent: 10},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			regressions := DiffLogMetrics(baseline, tt.current, thresholds)
+			assert.Len(t, regressions, len(tt.expected), "Should report each regressed field once")
+			for i, expected := range tt.expected {
+				if i >= len(regressions) {
+					break
+				}
+				assert.Equal(t, expected.Name, regressions[i].Name, "Regression should name the field")
+				assert.InDelta(t, expected.Base, regressions[i].Base, 0.000001, "Regression should report the baseline value")
+				assert.InDelta(t, expected.Head, regressions[i].Head, 0.000001, "Regression should report the current value")
+				assert.InDelta(t, expected.PercentChange, regressions[i].PercentChange, 0.001, "Regression should report the percent increase")
+				assert.InDelta(t, expected.ThresholdPercent, regressions[i].ThresholdPercent, 0.000001, "Regression should report the exceeded threshold")
+			}
+		})
+	}
+}
+
+func TestMetricDeltaString(t *testing.T) {
+	delta := MetricDelta{Name: "Token Usage", Base: 1000, Head: 1500, Change: 500, PercentChange: 50}
+	assert.Equal(t, "Token Usage: 1000 -> 1500 (+50.0%)", delta.String(), "Delta should show base, head and percent")
+	assert.Equal(t, delta.String(), Regression{MetricDelta: delta, ThresholdPercent: 10}.String(), "Regression should format like its delta")
+}
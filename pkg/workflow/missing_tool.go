@@ -66,7 +66,7 @@ func (c *Compiler) buildCreateOutputMissingToolJob(data *WorkflowData, mainJobNa
 	jobCondition := BuildSafeOutputType("missing_tool")
 
 	// Set permissions based on whether issue creation is enabled
-	permissions := NewPermissionsContentsRead()
+	permissions := NewPermissionsBuilder().Read(PermissionContents).Build()
 	if data.SafeOutputs.MissingTool.CreateIssue {
 		// Add issues:write permission for creating/updating issues
 		permissions.Set(PermissionIssues, PermissionWrite)
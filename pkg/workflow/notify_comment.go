@@ -10,6 +10,11 @@ import (
 
 var notifyCommentLog = logger.New("workflow:notify_comment")
 
+// isConclusionJobDisabled returns true when the conclusion job is turned off with safe-outputs.conclusion: false
+func isConclusionJobDisabled(data *WorkflowData) bool {
+	return data.SafeOutputs != nil && data.SafeOutputs.Conclusion != nil && !*data.SafeOutputs.Conclusion
+}
+
 // buildConclusionJob creates a job that updates the activation comment with workflow completion status
 // This job is only generated when both add-comment and ai-reaction are configured.
 // This job runs when:
@@ -42,6 +47,11 @@ func (c *Compiler) buildConclusionJob(data *WorkflowData, mainJobName string, sa
 		return nil, nil // No safe-outputs configured, no need for conclusion job
 	}
 
+	if isConclusionJobDisabled(data) {
+		notifyCommentLog.Printf("Skipping job: disabled by safe-outputs.conclusion")
+		return nil, nil
+	}
+
 	// Build the job steps
 	var steps []string
 
@@ -314,10 +324,7 @@ func (c *Compiler) buildConclusionJob(data *WorkflowData, mainJobName string, sa
 		// Build condition: only unlock if issue was locked by activation job
 		// Must match lock condition: event type is 'issues' or 'issue_comment'
 		// Use the issue_locked output from activation job to determine if unlock is needed
-		eventTypeCheck := BuildOr(
-			BuildEventTypeEquals("issues"),
-			BuildEventTypeEquals("issue_comment"),
-		)
+		eventTypeCheck := BuildEventTypeIn("issues", "issue_comment")
 		lockedOutputCheck := BuildEquals(
 			BuildPropertyAccess(fmt.Sprintf("needs.%s.outputs.issue_locked", constants.ActivationJobName)),
 			BuildStringLiteral("true"),
@@ -413,7 +420,7 @@ func (c *Compiler) buildConclusionJob(data *WorkflowData, mainJobName string, sa
 	permissions := computePermissionsForSafeOutputs(data.SafeOutputs)
 
 	job := &Job{
-		Name:        "conclusion",
+		Name:        string(constants.ConclusionJobName),
 		If:          condition.Render(),
 		RunsOn:      c.formatSafeOutputsRunsOn(data.SafeOutputs),
 		Permissions: permissions.RenderToYAML(),
@@ -0,0 +1,113 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"go/ast"
+	"go/parser"
+	gotoken "go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// AssertNoDeprecatedPermissionFactories fails the test for every reference to a deprecated
+// NewPermissions* helper in the non-test sources of this package, whether it is called or
+// passed as a function value. The deprecated helpers
+// are found from their "Deprecated:" doc comments, so newly deprecated helpers are
+// checked without updating this function.
+func AssertNoDeprecatedPermissionFactories(t *testing.T) {
+	t.Helper()
+
+	fset := gotoken.NewFileSet()
+	factoryFile, err := parser.ParseFile(fset, "permissions_factory.go", nil, parser.ParseComments)
+	require.NoError(t, err, "Should parse permissions_factory.go")
+
+	deprecated := make(map[string]bool)
+	for _, decl := range factoryFile.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Recv != nil || fn.Doc == nil || !strings.HasPrefix(fn.Name.Name, "NewPermissions") {
+			continue
+		}
+		if strings.Contains(fn.Doc.Text(), "Deprecated:") {
+			deprecated[fn.Name.Name] = true
+		}
+	}
+	require.NotEmpty(t, deprecated, "permissions_factory.go should declare deprecated helpers")
+
+	sources, err := filepath.Glob("*.go")
+	require.NoError(t, err, "Should list package sources")
+	for _, source := range sources {
+		if strings.HasSuffix(source, "_test.go") || source == "permissions_factory.go" {
+			continue
+		}
+		content, err := os.ReadFile(source)
+		require.NoError(t, err, "Should read %s", source)
+		file, err := parser.ParseFile(fset, source, content, 0)
+		require.NoError(t, err, "Should parse %s", source)
+
+		ast.Inspect(file, func(node ast.Node) bool {
+			ident, ok := node.(*ast.Ident)
+			if ok && deprecated[ident.Name] {
+				t.Errorf("%s: %s is deprecated, use NewPermissionsBuilder instead", fset.Position(ident.Pos()), ident.Name)
+			}
+			return true
+		})
+	}
+}
+
+func TestNoDeprecatedPermissionFactories(t *testing.T) {
+	AssertNoDeprecatedPermissionFactories(t)
+}
+
+func TestPermissionsBuilder(t *testing.T) {
+	perms := NewPermissionsBuilder().Read(PermissionContents).Write(PermissionIssues).Read(PermissionIssues).Build()
+
+	level, exists := perms.Get(PermissionIssues)
+	require.True(t, exists, "issues should be set")
+	assert.Equal(t, PermissionRead, level, "Later grants should replace earlier ones")
+	assert.Equal(t, "permissions:\n      contents: read\n      issues: read", perms.RenderToYAML(), "Builder output should render like a permissions map")
+
+	assert.Equal(t, NewPermissions(), NewPermissionsBuilder().Build(), "An empty builder should match NewPermissions")
+}
+
+// TestPermissionsBuilderMatchesDeprecatedFactories proves the builder produces the same
+// permissions as each deprecated helper, so the helpers can be removed
+func TestPermissionsBuilderMatchesDeprecatedFactories(t *testing.T) {
+	tests := []struct {
+		name       string
+		deprecated *Permissions
+		builder    *PermissionsBuilder
+	}{
+		{"ContentsRead", NewPermissionsContentsRead(), NewPermissionsBuilder().Read(PermissionContents)},
+		{"ContentsReadIssuesWrite", NewPermissionsContentsReadIssuesWrite(), NewPermissionsBuilder().Read(PermissionContents).Write(PermissionIssues)},
+		{"ContentsReadIssuesWritePRWrite", NewPermissionsContentsReadIssuesWritePRWrite(), NewPermissionsBuilder().Read(PermissionContents).Write(PermissionIssues).Write(PermissionPullRequests)},
+		{"ContentsReadIssuesWritePRWriteDiscussionsWrite", NewPermissionsContentsReadIssuesWritePRWriteDiscussionsWrite(), NewPermissionsBuilder().Read(PermissionContents).Write(PermissionIssues).Write(PermissionPullRequests).Write(PermissionDiscussions)},
+		{"ActionsWrite", NewPermissionsActionsWrite(), NewPermissionsBuilder().Write(PermissionActions)},
+		{"ActionsWriteContentsWriteIssuesWritePRWrite", NewPermissionsActionsWriteContentsWriteIssuesWritePRWrite(), NewPermissionsBuilder().Write(PermissionActions).Write(PermissionContents).Write(PermissionIssues).Write(PermissionPullRequests)},
+		{"ContentsWrite", NewPermissionsContentsWrite(), NewPermissionsBuilder().Write(PermissionContents)},
+		{"ContentsWritePRWrite", NewPermissionsContentsWritePRWrite(), NewPermissionsBuilder().Write(PermissionContents).Write(PermissionPullRequests)},
+		{"ContentsWriteIssuesWritePRWrite", NewPermissionsContentsWriteIssuesWritePRWrite(), NewPermissionsBuilder().Write(PermissionContents).Write(PermissionIssues).Write(PermissionPullRequests)},
+		{"DiscussionsWrite", NewPermissionsDiscussionsWrite(), NewPermissionsBuilder().Write(PermissionDiscussions)},
+		{"ContentsReadDiscussionsWrite", NewPermissionsContentsReadDiscussionsWrite(), NewPermissionsBuilder().Read(PermissionContents).Write(PermissionDiscussions)},
+		{"ContentsReadIssuesWriteDiscussionsWrite", NewPermissionsContentsReadIssuesWriteDiscussionsWrite(), NewPermissionsBuilder().Read(PermissionContents).Write(PermissionIssues).Write(PermissionDiscussions)},
+		{"ContentsReadPRWrite", NewPermissionsContentsReadPRWrite(), NewPermissionsBuilder().Read(PermissionContents).Write(PermissionPullRequests)},
+		{"ContentsReadSecurityEventsWrite", NewPermissionsContentsReadSecurityEventsWrite(), NewPermissionsBuilder().Read(PermissionContents).Write(PermissionSecurityEvents)},
+		{"ContentsReadSecurityEventsWriteActionsRead", NewPermissionsContentsReadSecurityEventsWriteActionsRead(), NewPermissionsBuilder().Read(PermissionContents).Write(PermissionSecurityEvents).Read(PermissionActions)},
+		{"ContentsReadProjectsWrite", NewPermissionsContentsReadProjectsWrite(), NewPermissionsBuilder().Read(PermissionContents).Write(PermissionOrganizationProj)},
+		{"ContentsWritePRReadIssuesRead", NewPermissionsContentsWritePRReadIssuesRead(), NewPermissionsBuilder().Write(PermissionContents).Read(PermissionPullRequests).Read(PermissionIssues)},
+		{"ContentsWriteIssuesWritePRWriteDiscussionsWrite", NewPermissionsContentsWriteIssuesWritePRWriteDiscussionsWrite(), NewPermissionsBuilder().Write(PermissionContents).Write(PermissionIssues).Write(PermissionPullRequests).Write(PermissionDiscussions)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			built := tt.builder.Build()
+			assert.Equal(t, tt.deprecated, built, "Builder should produce the same permissions as NewPermissions%s", tt.name)
+			assert.Equal(t, tt.deprecated.RenderToYAML(), built.RenderToYAML(), "Builder should render the same YAML as NewPermissions%s", tt.name)
+		})
+	}
+}
@@ -62,9 +62,43 @@ func NewPermissionsAllRead() *Permissions {
 	}
 }
 
-// Helper functions for common permission patterns
+// PermissionsBuilder builds a Permissions one scope at a time
+type PermissionsBuilder struct {
+	permissions map[PermissionScope]PermissionLevel
+}
+
+// NewPermissionsBuilder starts a Permissions with no scopes, e.g.
+//
+//	NewPermissionsBuilder().Read(PermissionContents).Write(PermissionIssues).Build()
+func NewPermissionsBuilder() *PermissionsBuilder {
+	return &PermissionsBuilder{
+		permissions: make(map[PermissionScope]PermissionLevel),
+	}
+}
+
+// Read grants read access to the scope
+func (b *PermissionsBuilder) Read(scope PermissionScope) *PermissionsBuilder {
+	b.permissions[scope] = PermissionRead
+	return b
+}
+
+// Write grants write access to the scope
+func (b *PermissionsBuilder) Write(scope PermissionScope) *PermissionsBuilder {
+	b.permissions[scope] = PermissionWrite
+	return b
+}
+
+// Build returns the Permissions with the scopes granted so far
+func (b *PermissionsBuilder) Build() *Permissions {
+	return NewPermissionsFromMap(b.permissions)
+}
+
+// Helper functions for common permission patterns.
+// They predate PermissionsBuilder and remain only until they are removed.
 
 // NewPermissionsContentsRead creates permissions with contents: read
+//
+// Deprecated: Use NewPermissionsBuilder instead.
 func NewPermissionsContentsRead() *Permissions {
 	return NewPermissionsFromMap(map[PermissionScope]PermissionLevel{
 		PermissionContents: PermissionRead,
@@ -72,6 +106,8 @@ func NewPermissionsContentsRead() *Permissions {
 }
 
 // NewPermissionsContentsReadIssuesWrite creates permissions with contents: read and issues: write
+//
+// Deprecated: Use NewPermissionsBuilder instead.
 func NewPermissionsContentsReadIssuesWrite() *Permissions {
 	return NewPermissionsFromMap(map[PermissionScope]PermissionLevel{
 		PermissionContents: PermissionRead,
@@ -80,6 +116,8 @@ func NewPermissionsContentsReadIssuesWrite() *Permissions {
 }
 
 // NewPermissionsContentsReadIssuesWritePRWrite creates permissions with contents: read, issues: write, pull-requests: write
+//
+// Deprecated: Use NewPermissionsBuilder instead.
 func NewPermissionsContentsReadIssuesWritePRWrite() *Permissions {
 	return NewPermissionsFromMap(map[PermissionScope]PermissionLevel{
 		PermissionContents:     PermissionRead,
@@ -89,6 +127,8 @@ func NewPermissionsContentsReadIssuesWritePRWrite() *Permissions {
 }
 
 // NewPermissionsContentsReadIssuesWritePRWriteDiscussionsWrite creates permissions with contents: read, issues: write, pull-requests: write, discussions: write
+//
+// Deprecated: Use NewPermissionsBuilder instead.
 func NewPermissionsContentsReadIssuesWritePRWriteDiscussionsWrite() *Permissions {
 	return NewPermissionsFromMap(map[PermissionScope]PermissionLevel{
 		PermissionContents:     PermissionRead,
@@ -100,6 +140,8 @@ func NewPermissionsContentsReadIssuesWritePRWriteDiscussionsWrite() *Permissions
 
 // NewPermissionsActionsWrite creates permissions with actions: write
 // This is required for dispatching workflows via workflow_dispatch
+//
+// Deprecated: Use NewPermissionsBuilder instead.
 func NewPermissionsActionsWrite() *Permissions {
 	return NewPermissionsFromMap(map[PermissionScope]PermissionLevel{
 		PermissionActions: PermissionWrite,
@@ -108,6 +150,8 @@ func NewPermissionsActionsWrite() *Permissions {
 
 // NewPermissionsActionsWriteContentsWriteIssuesWritePRWrite creates permissions with actions: write, contents: write, issues: write, pull-requests: write
 // This is required for the replaceActorsForAssignable GraphQL mutation used to assign GitHub Copilot agents to issues
+//
+// Deprecated: Use NewPermissionsBuilder instead.
 func NewPermissionsActionsWriteContentsWriteIssuesWritePRWrite() *Permissions {
 	return NewPermissionsFromMap(map[PermissionScope]PermissionLevel{
 		PermissionActions:      PermissionWrite,
@@ -118,6 +162,8 @@ func NewPermissionsActionsWriteContentsWriteIssuesWritePRWrite() *Permissions {
 }
 
 // NewPermissionsContentsWrite creates permissions with contents: write
+//
+// Deprecated: Use NewPermissionsBuilder instead.
 func NewPermissionsContentsWrite() *Permissions {
 	return NewPermissionsFromMap(map[PermissionScope]PermissionLevel{
 		PermissionContents: PermissionWrite,
@@ -126,6 +172,8 @@ func NewPermissionsContentsWrite() *Permissions {
 
 // NewPermissionsContentsWritePRWrite creates permissions with contents: write, pull-requests: write
 // Used when create-pull-request has fallback-as-issue: false (no issue creation fallback)
+//
+// Deprecated: Use NewPermissionsBuilder instead.
 func NewPermissionsContentsWritePRWrite() *Permissions {
 	return NewPermissionsFromMap(map[PermissionScope]PermissionLevel{
 		PermissionContents:     PermissionWrite,
@@ -134,6 +182,8 @@ func NewPermissionsContentsWritePRWrite() *Permissions {
 }
 
 // NewPermissionsContentsWriteIssuesWritePRWrite creates permissions with contents: write, issues: write, pull-requests: write
+//
+// Deprecated: Use NewPermissionsBuilder instead.
 func NewPermissionsContentsWriteIssuesWritePRWrite() *Permissions {
 	return NewPermissionsFromMap(map[PermissionScope]PermissionLevel{
 		PermissionContents:     PermissionWrite,
@@ -143,6 +193,8 @@ func NewPermissionsContentsWriteIssuesWritePRWrite() *Permissions {
 }
 
 // NewPermissionsDiscussionsWrite creates permissions with discussions: write
+//
+// Deprecated: Use NewPermissionsBuilder instead.
 func NewPermissionsDiscussionsWrite() *Permissions {
 	return NewPermissionsFromMap(map[PermissionScope]PermissionLevel{
 		PermissionDiscussions: PermissionWrite,
@@ -150,6 +202,8 @@ func NewPermissionsDiscussionsWrite() *Permissions {
 }
 
 // NewPermissionsContentsReadDiscussionsWrite creates permissions with contents: read and discussions: write
+//
+// Deprecated: Use NewPermissionsBuilder instead.
 func NewPermissionsContentsReadDiscussionsWrite() *Permissions {
 	return NewPermissionsFromMap(map[PermissionScope]PermissionLevel{
 		PermissionContents:    PermissionRead,
@@ -159,6 +213,8 @@ func NewPermissionsContentsReadDiscussionsWrite() *Permissions {
 
 // NewPermissionsContentsReadIssuesWriteDiscussionsWrite creates permissions with contents: read, issues: write, discussions: write
 // This is used for create-discussion jobs that support fallback-to-issue when discussion creation fails
+//
+// Deprecated: Use NewPermissionsBuilder instead.
 func NewPermissionsContentsReadIssuesWriteDiscussionsWrite() *Permissions {
 	return NewPermissionsFromMap(map[PermissionScope]PermissionLevel{
 		PermissionContents:    PermissionRead,
@@ -168,6 +224,8 @@ func NewPermissionsContentsReadIssuesWriteDiscussionsWrite() *Permissions {
 }
 
 // NewPermissionsContentsReadPRWrite creates permissions with contents: read and pull-requests: write
+//
+// Deprecated: Use NewPermissionsBuilder instead.
 func NewPermissionsContentsReadPRWrite() *Permissions {
 	return NewPermissionsFromMap(map[PermissionScope]PermissionLevel{
 		PermissionContents:     PermissionRead,
@@ -176,6 +234,8 @@ func NewPermissionsContentsReadPRWrite() *Permissions {
 }
 
 // NewPermissionsContentsReadSecurityEventsWrite creates permissions with contents: read and security-events: write
+//
+// Deprecated: Use NewPermissionsBuilder instead.
 func NewPermissionsContentsReadSecurityEventsWrite() *Permissions {
 	return NewPermissionsFromMap(map[PermissionScope]PermissionLevel{
 		PermissionContents:       PermissionRead,
@@ -184,6 +244,8 @@ func NewPermissionsContentsReadSecurityEventsWrite() *Permissions {
 }
 
 // NewPermissionsContentsReadSecurityEventsWriteActionsRead creates permissions with contents: read, security-events: write, actions: read
+//
+// Deprecated: Use NewPermissionsBuilder instead.
 func NewPermissionsContentsReadSecurityEventsWriteActionsRead() *Permissions {
 	return NewPermissionsFromMap(map[PermissionScope]PermissionLevel{
 		PermissionContents:       PermissionRead,
@@ -194,6 +256,8 @@ func NewPermissionsContentsReadSecurityEventsWriteActionsRead() *Permissions {
 
 // NewPermissionsContentsReadProjectsWrite creates permissions with contents: read and organization-projects: write
 // Note: organization-projects is only valid for GitHub App tokens, not workflow permissions
+//
+// Deprecated: Use NewPermissionsBuilder instead.
 func NewPermissionsContentsReadProjectsWrite() *Permissions {
 	return NewPermissionsFromMap(map[PermissionScope]PermissionLevel{
 		PermissionContents:         PermissionRead,
@@ -202,6 +266,8 @@ func NewPermissionsContentsReadProjectsWrite() *Permissions {
 }
 
 // NewPermissionsContentsWritePRReadIssuesRead creates permissions with contents: write, pull-requests: read, issues: read
+//
+// Deprecated: Use NewPermissionsBuilder instead.
 func NewPermissionsContentsWritePRReadIssuesRead() *Permissions {
 	return NewPermissionsFromMap(map[PermissionScope]PermissionLevel{
 		PermissionContents:     PermissionWrite,
@@ -211,6 +277,8 @@ func NewPermissionsContentsWritePRReadIssuesRead() *Permissions {
 }
 
 // NewPermissionsContentsWriteIssuesWritePRWriteDiscussionsWrite creates permissions with contents: write, issues: write, pull-requests: write, discussions: write
+//
+// Deprecated: Use NewPermissionsBuilder instead.
 func NewPermissionsContentsWriteIssuesWritePRWriteDiscussionsWrite() *Permissions {
 	return NewPermissionsFromMap(map[PermissionScope]PermissionLevel{
 		PermissionContents:     PermissionWrite,
@@ -0,0 +1,94 @@
+package workflow
+
+import (
+	"maps"
+	"sort"
+
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var permissionsToolsLog = logger.New("workflow:permissions_tools")
+
+// DerivePermissionsFromTools computes the minimal permission set required by the enabled
+// tools and merges it into a copy of base. The agent job always needs contents: read to
+// access the .github and .actions folders; GitHub MCP toolsets add the scopes listed in
+// github_toolsets_permissions.json (write scopes only when the tool is not read-only), and
+// the agentic-workflows tool adds actions: read.
+//
+// A required scope is only granted when base leaves it unset. Levels set explicitly in base,
+// including none, are never changed, so a tool requiring write does not override an explicit
+// read; the missing access is reported by ValidatePermissions instead. The only exception is
+// the contents: read baseline, which is raised from none because the agent job cannot run
+// without it. The base permissions are not modified.
+//
+// The agent job passes nil tools: GitHub toolset scopes stay validation-only there, so the
+// compiled permissions never exceed what the workflow author wrote beyond the baseline.
+func DerivePermissionsFromTools(tools *ToolsConfig, base *Permissions) *Permissions {
+	required := requiredPermissionsForTools(tools)
+
+	derived := NewPermissions()
+	if base != nil {
+		derived = base.clone()
+	}
+
+	scopes := make([]string, 0, len(required))
+	for scope := range required {
+		scopes = append(scopes, string(scope))
+	}
+	sort.Strings(scopes)
+
+	for _, scopeStr := range scopes {
+		scope := PermissionScope(scopeStr)
+		requiredLevel := required[scope]
+		level, exists := derived.Get(scope)
+		if exists && (level != PermissionNone || scope != PermissionContents) {
+			if level != requiredLevel && (requiredLevel == PermissionWrite || level == PermissionNone) {
+				permissionsToolsLog.Printf("Keeping explicit %s: %s although tools require %s", scope, level, requiredLevel)
+			}
+			continue
+		}
+		permissionsToolsLog.Printf("Granting %s: %s required by tools", scope, requiredLevel)
+		derived.Set(scope, requiredLevel)
+	}
+
+	return derived
+}
+
+// requiredPermissionsForTools returns the permissions required by the enabled tools,
+// including the contents: read baseline of the agent job
+func requiredPermissionsForTools(tools *ToolsConfig) map[PermissionScope]PermissionLevel {
+	required := map[PermissionScope]PermissionLevel{
+		PermissionContents: PermissionRead,
+	}
+	if tools == nil {
+		return required
+	}
+
+	if tools.GitHub != nil {
+		toolsets := ParseGitHubToolsets(tools.GitHub.GetToolsets())
+		for scope, level := range collectRequiredPermissions(toolsets, tools.GitHub.IsReadOnly()) {
+			if existing, found := required[scope]; !found || level == PermissionWrite || existing == PermissionNone {
+				required[scope] = level
+			}
+		}
+	}
+
+	if tools.AgenticWorkflows != nil {
+		if _, found := required[PermissionActions]; !found {
+			required[PermissionActions] = PermissionRead
+		}
+	}
+
+	permissionsToolsLog.Printf("Tools require %d permission scopes", len(required))
+	return required
+}
+
+// clone returns a deep copy of the permissions
+func (p *Permissions) clone() *Permissions {
+	cloned := *p
+	cloned.permissions = maps.Clone(p.permissions)
+	if cloned.permissions == nil {
+		cloned.permissions = make(map[PermissionScope]PermissionLevel)
+	}
+	return &cloned
+}
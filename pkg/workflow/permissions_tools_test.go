@@ -0,0 +1,117 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDerivePermissionsFromTools(t *testing.T) {
+	tests := []struct {
+		name     string
+		tools    *ToolsConfig
+		base     *Permissions
+		expected string
+	}{
+		{
+			name:     "no tools and no base",
+			expected: "permissions:\n      contents: read",
+		},
+		{
+			name:     "base without contents",
+			base:     NewPermissionsFromMap(map[PermissionScope]PermissionLevel{PermissionIssues: PermissionWrite}),
+			expected: "permissions:\n      contents: read\n      issues: write",
+		},
+		{
+			name:     "contents none is raised to read",
+			base:     NewPermissionsFromMap(map[PermissionScope]PermissionLevel{PermissionContents: PermissionNone}),
+			expected: "permissions:\n      contents: read",
+		},
+		{
+			name: "github issues toolset read-only",
+			tools: &ToolsConfig{GitHub: &GitHubToolConfig{
+				Toolset:  GitHubToolsets{"issues"},
+				ReadOnly: true,
+			}},
+			expected: "permissions:\n      contents: read\n      issues: read",
+		},
+		{
+			name: "github issues toolset with write access",
+			tools: &ToolsConfig{GitHub: &GitHubToolConfig{
+				Toolset: GitHubToolsets{"issues"},
+			}},
+			expected: "permissions:\n      contents: read\n      issues: write",
+		},
+		{
+			name: "github default toolsets read-only",
+			tools: &ToolsConfig{GitHub: &GitHubToolConfig{
+				ReadOnly: true,
+			}},
+			expected: "permissions:\n      contents: read\n      issues: read\n      pull-requests: read",
+		},
+		{
+			name: "write requirement keeps explicit read",
+			tools: &ToolsConfig{GitHub: &GitHubToolConfig{
+				Toolset: GitHubToolsets{"issues"},
+			}},
+			base:     NewPermissionsFromMap(map[PermissionScope]PermissionLevel{PermissionIssues: PermissionRead}),
+			expected: "permissions:\n      contents: read\n      issues: read",
+		},
+		{
+			name: "explicit write is preserved for read-only tools",
+			tools: &ToolsConfig{GitHub: &GitHubToolConfig{
+				Toolset:  GitHubToolsets{"issues"},
+				ReadOnly: true,
+			}},
+			base:     NewPermissionsFromMap(map[PermissionScope]PermissionLevel{PermissionIssues: PermissionWrite}),
+			expected: "permissions:\n      contents: read\n      issues: write",
+		},
+		{
+			name: "explicit none is kept for tool scopes",
+			tools: &ToolsConfig{GitHub: &GitHubToolConfig{
+				Toolset:  GitHubToolsets{"issues"},
+				ReadOnly: true,
+			}},
+			base:     NewPermissionsFromMap(map[PermissionScope]PermissionLevel{PermissionIssues: PermissionNone}),
+			expected: "permissions:\n      contents: read\n      issues: none",
+		},
+		{
+			name:     "explicit none is kept for actions",
+			tools:    &ToolsConfig{AgenticWorkflows: &AgenticWorkflowsToolConfig{Enabled: true}},
+			base:     NewPermissionsFromMap(map[PermissionScope]PermissionLevel{PermissionActions: PermissionNone}),
+			expected: "permissions:\n      actions: none\n      contents: read",
+		},
+		{
+			name:     "agentic-workflows requires actions read",
+			tools:    &ToolsConfig{AgenticWorkflows: &AgenticWorkflowsToolConfig{Enabled: true}},
+			expected: "permissions:\n      actions: read\n      contents: read",
+		},
+		{
+			name: "read-all shorthand already covers read requirements",
+			tools: &ToolsConfig{GitHub: &GitHubToolConfig{
+				Toolset:  GitHubToolsets{"issues"},
+				ReadOnly: true,
+			}},
+			base:     NewPermissionsReadAll(),
+			expected: "permissions: read-all",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			derived := DerivePermissionsFromTools(tt.tools, tt.base)
+			assert.Equal(t, tt.expected, derived.RenderToYAML(), "Derived permissions should match the tool requirements")
+		})
+	}
+}
+
+func TestDerivePermissionsFromToolsDoesNotModifyBase(t *testing.T) {
+	base := NewPermissionsFromMap(map[PermissionScope]PermissionLevel{PermissionIssues: PermissionRead})
+
+	derived := DerivePermissionsFromTools(&ToolsConfig{AgenticWorkflows: &AgenticWorkflowsToolConfig{Enabled: true}}, base)
+
+	assert.Equal(t, "permissions:\n      issues: read", base.RenderToYAML(), "Base permissions should be left unchanged")
+	assert.Equal(t, "permissions:\n      actions: read\n      contents: read\n      issues: read", derived.RenderToYAML(), "Derived permissions should include tool requirements")
+}
@@ -10,8 +10,12 @@ import (
 var prLog = logger.New("workflow:pr")
 
 // ShouldGeneratePRCheckoutStep returns true if the checkout-pr step should be generated
-// based on the workflow permissions. The step requires contents read access.
+// based on the workflow permissions. The step requires contents read access and a
+// repository checkout in the agent job.
 func ShouldGeneratePRCheckoutStep(data *WorkflowData) bool {
+	if isAgentCheckoutSkipped(data) {
+		return false
+	}
 	permParser := NewPermissionsParser(data.Permissions)
 	return permParser.HasContentsReadAccess()
 }
@@ -0,0 +1,125 @@
+package workflow
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var promptRedactionLog = logger.New("workflow:prompt_redaction")
+
+// promptRedactEnvVar lists, comma-separated, the names of the interpolation step env vars
+// whose values prompt.redact hides. Only names are emitted: the values are already carried
+// by the step for substitution and are never copied into another env var.
+const promptRedactEnvVar = "GH_AW_PROMPT_REDACT"
+
+// promptRedactedPath is the copy of the prompt, with redacted values replaced by ***, that
+// the interpolation step writes and the print step reads.
+const promptRedactedPath = "/tmp/gh-aw/aw-prompts/prompt-redacted.txt"
+
+// extractPromptRedact returns the prompt.redact expressions from the frontmatter, without
+// the ${{ }} wrapper. Entries that are not strings are ignored.
+func extractPromptRedact(frontmatter map[string]any) []string {
+	promptConfig, ok := frontmatter["prompt"].(map[string]any)
+	if !ok {
+		return nil
+	}
+	entries, ok := promptConfig["redact"].([]any)
+	if !ok {
+		return nil
+	}
+
+	var expressions []string
+	for _, entry := range entries {
+		expression, ok := entry.(string)
+		if !ok {
+			continue
+		}
+		expression = strings.TrimSpace(expression)
+		if strings.HasPrefix(expression, "${{") && strings.HasSuffix(expression, "}}") {
+			expression = strings.TrimSpace(expression[3 : len(expression)-2])
+		}
+		if expression != "" {
+			expressions = append(expressions, expression)
+		}
+	}
+	return expressions
+}
+
+// validatePromptRedact checks that every prompt.redact entry is an expression that is allowed
+// in the workflow markdown, since the redacted value is evaluated in the agent job
+func validatePromptRedact(expressions []string) error {
+	for _, expression := range expressions {
+		if err := validateExpressionSafety(fmt.Sprintf("${{ %s }}", expression)); err != nil {
+			return fmt.Errorf("prompt.redact: %w", err)
+		}
+	}
+	return nil
+}
+
+// promptRedactMappings returns the expression mappings whose values prompt.redact hides.
+// User prompt mappings are matched first; a redacted expression that only appears in a
+// built-in prompt section gets a mapping under the section's env var so the interpolation
+// step can read the same value the placeholder substitution used. Expressions that do not
+// appear in the prompt are skipped since there is nothing to redact.
+func promptRedactMappings(redact []string, builtinSections []PromptSection, expressionMappings []*ExpressionMapping) []*ExpressionMapping {
+	if len(redact) == 0 {
+		return nil
+	}
+
+	redacted := make(map[string]bool, len(redact))
+	for _, expression := range redact {
+		redacted[normalizeRedactExpression(expression)] = true
+	}
+
+	var mappings []*ExpressionMapping
+	seen := make(map[string]bool)
+	for _, mapping := range expressionMappings {
+		if redacted[normalizeRedactExpression(mapping.Content)] && !seen[mapping.EnvVar] {
+			seen[mapping.EnvVar] = true
+			mappings = append(mappings, mapping)
+		}
+	}
+	for _, section := range builtinSections {
+		for _, envVar := range slices.Sorted(maps.Keys(section.EnvVars)) {
+			value := section.EnvVars[envVar]
+			if !strings.HasPrefix(value, "${{") || !strings.HasSuffix(value, "}}") {
+				continue
+			}
+			content := strings.TrimSpace(value[3 : len(value)-2])
+			if redacted[normalizeRedactExpression(content)] && !seen[envVar] {
+				seen[envVar] = true
+				mappings = append(mappings, &ExpressionMapping{EnvVar: envVar, Content: content})
+			}
+		}
+	}
+
+	promptRedactionLog.Printf("Matched %d of %d redacted expressions in the prompt", len(mappings), len(redact))
+	return mappings
+}
+
+// normalizeRedactExpression collapses whitespace so that "github.actor" written in
+// prompt.redact matches "github.actor " extracted from the markdown
+func normalizeRedactExpression(expression string) string {
+	return strings.Join(strings.Fields(expression), " ")
+}
+
+// writePromptRedactEnv writes the comma-separated names of the redacted env vars and the
+// path of the redacted copy of the prompt. The interpolation step replaces the values of
+// those env vars in the copy; the prompt file itself keeps the substituted values.
+func writePromptRedactEnv(yaml *strings.Builder, redactMappings []*ExpressionMapping) {
+	if len(redactMappings) == 0 {
+		return
+	}
+	names := make([]string, 0, len(redactMappings))
+	for _, mapping := range redactMappings {
+		names = append(names, mapping.EnvVar)
+	}
+	sort.Strings(names)
+	fmt.Fprintf(yaml, "          %s: %s\n", promptRedactEnvVar, strings.Join(names, ","))
+	fmt.Fprintf(yaml, "          GH_AW_PROMPT_REDACTED: %s\n", promptRedactedPath)
+}
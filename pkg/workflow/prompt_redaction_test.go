@@ -0,0 +1,112 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtractPromptRedact(t *testing.T) {
+	frontmatter := map[string]any{
+		"prompt": map[string]any{
+			"redact": []any{"github.actor", "${{ github.event.issue.user.login }}", "  ", 42},
+		},
+	}
+	assert.Equal(t, []string{"github.actor", "github.event.issue.user.login"}, extractPromptRedact(frontmatter),
+		"Redact entries should be unwrapped and invalid entries skipped")
+	assert.Nil(t, extractPromptRedact(map[string]any{}), "No prompt section should yield no redactions")
+}
+
+func TestValidatePromptRedact(t *testing.T) {
+	require.NoError(t, validatePromptRedact([]string{"github.actor", "github.event.issue.number"}), "Allowed expressions should validate")
+
+	err := validatePromptRedact([]string{"secrets.GITHUB_TOKEN"})
+	require.Error(t, err, "Secrets should not be allowed in prompt.redact")
+	assert.Contains(t, err.Error(), "prompt.redact", "Error should name the field")
+}
+
+func TestPromptRedactInLockFile(t *testing.T) {
+	content := `---
+on: issues
+permissions:
+  contents: read
+engine: copilot
+prompt:
+  redact:
+    - github.actor
+    - github.event.issue.title
+---
+
+# Greeter
+
+Greet ${{ github.actor }} on issue #${{ github.event.issue.number }} titled ${{ github.event.issue.title }}.
+`
+	markdownPath := filepath.Join(testutil.TempDir(t, "prompt-redact"), "greeter.md")
+	lockContent, err := NewCompiler().CompileString(content, markdownPath)
+	require.NoError(t, err, "Workflow with prompt.redact should compile")
+
+	printStep := lockContent[strings.Index(lockContent, "- name: Print prompt"):]
+	printStep = printStep[:strings.Index(printStep, "run:")]
+	assert.Contains(t, printStep, "GH_AW_PROMPT: "+promptRedactedPath, "Print prompt step should print the redacted copy")
+	assert.NotContains(t, printStep, "${{", "Print prompt step should not receive any expression value")
+
+	interpolateStep := lockContent[strings.Index(lockContent, "- name: Interpolate variables and render templates"):]
+	interpolateStep = interpolateStep[:strings.Index(interpolateStep, "with:")]
+	assert.Contains(t, interpolateStep, promptRedactEnvVar+": GH_AW_GITHUB_ACTOR,GH_AW_GITHUB_EVENT_ISSUE_TITLE\n",
+		"Interpolation step should list the redacted env vars by name")
+	assert.Contains(t, interpolateStep, "GH_AW_PROMPT_REDACTED: "+promptRedactedPath, "Interpolation step should write the redacted copy")
+	assert.Equal(t, 1, strings.Count(interpolateStep, "${{ github.actor }}"), "Redacted value should only be carried by its substitution env var")
+
+	substituteStep := lockContent[strings.Index(lockContent, "- name: Substitute placeholders"):]
+	substituteStep = substituteStep[:strings.Index(substituteStep, "with:")]
+	assert.Contains(t, substituteStep, "GH_AW_GITHUB_ACTOR: ${{ github.actor }}", "Redacted expression should still be substituted into the prompt")
+
+	validateStep := lockContent[strings.Index(lockContent, "- name: Validate prompt placeholders"):]
+	validateStep = validateStep[:strings.Index(validateStep, "run:")]
+	assert.Contains(t, validateStep, "GH_AW_PROMPT: /tmp/gh-aw/aw-prompts/prompt.txt", "Placeholders should be validated in the real prompt")
+}
+
+func TestPromptRedactMappings(t *testing.T) {
+	sections := []PromptSection{{EnvVars: map[string]string{
+		"GH_AW_GITHUB_ACTOR":      "${{ github.actor }}",
+		"GH_AW_GITHUB_REPOSITORY": "${{ github.repository }}",
+	}}}
+	userMappings := []*ExpressionMapping{
+		{EnvVar: "GH_AW_GITHUB_EVENT_ISSUE_TITLE", Content: "github.event.issue.title"},
+		{EnvVar: "GH_AW_GITHUB_EVENT_ISSUE_NUMBER", Content: "github.event.issue.number"},
+	}
+
+	mappings := promptRedactMappings([]string{"github.actor", "github.event.issue.title", "github.event.issue.body"}, sections, userMappings)
+	envVars := make([]string, 0, len(mappings))
+	for _, mapping := range mappings {
+		envVars = append(envVars, mapping.EnvVar)
+	}
+	assert.Equal(t, []string{"GH_AW_GITHUB_EVENT_ISSUE_TITLE", "GH_AW_GITHUB_ACTOR"}, envVars,
+		"User and built-in mappings should be matched and expressions missing from the prompt skipped")
+	assert.Nil(t, promptRedactMappings(nil, sections, userMappings), "No prompt.redact should yield no mappings")
+}
+
+func TestPromptRedactNotEmittedByDefault(t *testing.T) {
+	content := `---
+on: issues
+permissions:
+  contents: read
+engine: copilot
+---
+
+# Greeter
+
+Greet ${{ github.actor }}.
+`
+	markdownPath := filepath.Join(testutil.TempDir(t, "prompt-no-redact"), "greeter.md")
+	lockContent, err := NewCompiler().CompileString(content, markdownPath)
+	require.NoError(t, err, "Workflow should compile")
+	assert.NotContains(t, lockContent, promptRedactEnvVar, "No redaction env vars should be emitted without prompt.redact")
+	assert.NotContains(t, lockContent, promptRedactedPath, "The prompt should be printed directly without prompt.redact")
+}
@@ -0,0 +1,182 @@
+// This file provides compile-time detection of oversized agent prompts.
+//
+// # Prompt Size Validation
+//
+// After the prompt is assembled, the compiler estimates its token count with a simple
+// characters-per-token heuristic and warns when the estimate exceeds either the
+// configured warning threshold or the engine's known context window. The estimate
+// covers the built-in prompt sections, the workflow markdown (including imports) and
+// the engine prompt prefix and suffix. Content loaded at runtime from external files
+// or produced by expressions is not counted, so the estimate is a lower bound.
+//
+// # Prompt Budget
+//
+// Workflows can declare a character budget with prompt.max-chars. The budget check adds
+// the size of files referenced by runtime-import macros (including imports without
+// inputs) to the assembled prompt and warns when the total exceeds the budget, or fails
+// compilation in strict mode. Missing files and URLs are not counted.
+
+package workflow
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/console"
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var promptSizeLog = logger.New("workflow:prompt_size_validation")
+
+const (
+	// DefaultPromptTokenWarningThreshold is the estimated prompt size (in tokens) above
+	// which the compiler warns about context overflow risk
+	DefaultPromptTokenWarningThreshold = 100000
+
+	// promptCharsPerToken is the approximate number of characters per token used to
+	// estimate prompt size
+	promptCharsPerToken = 4
+)
+
+// engineContextWindowTokens lists the known context window (in tokens) of each engine's
+// default model. Engines not listed are only checked against the warning threshold.
+var engineContextWindowTokens = map[string]int{
+	"claude":      200000,
+	"codex":       200000,
+	"copilot":     128000,
+	"copilot-sdk": 128000,
+}
+
+// estimatePromptTokens estimates the number of tokens in text
+func estimatePromptTokens(text string) int {
+	return (len(text) + promptCharsPerToken - 1) / promptCharsPerToken
+}
+
+// assemblePromptTextForEstimate concatenates the prompt content known at compile time
+func assemblePromptTextForEstimate(data *WorkflowData, builtinSections []PromptSection) string {
+	var b strings.Builder
+	for _, section := range builtinSections {
+		if !section.IsFile {
+			b.WriteString(section.Content)
+		}
+	}
+	if data.EngineConfig != nil {
+		b.WriteString(data.EngineConfig.PromptPrefix)
+		b.WriteString(data.EngineConfig.PromptSuffix)
+	}
+	b.WriteString(data.MarkdownContent)
+	return b.String()
+}
+
+// promptTokenLimit returns the effective token limit for the workflow: the configured
+// warning threshold, lowered to the engine's context window when that is smaller
+func (c *Compiler) promptTokenLimit(data *WorkflowData) int {
+	limit := c.promptTokenThreshold
+	if limit <= 0 {
+		limit = DefaultPromptTokenWarningThreshold
+	}
+
+	engineID := data.AI
+	if data.EngineConfig != nil && data.EngineConfig.ID != "" {
+		engineID = data.EngineConfig.ID
+	}
+	if window, ok := engineContextWindowTokens[engineID]; ok && window < limit {
+		limit = window
+	}
+	return limit
+}
+
+// checkPromptSize warns when the estimated token count of the assembled prompt exceeds
+// the effective token limit
+func (c *Compiler) checkPromptSize(data *WorkflowData, builtinSections []PromptSection) {
+	estimated := estimatePromptTokens(assemblePromptTextForEstimate(data, builtinSections))
+	limit := c.promptTokenLimit(data)
+	promptSizeLog.Printf("Estimated prompt size: %d tokens (limit: %d)", estimated, limit)
+
+	if estimated <= limit {
+		return
+	}
+
+	warningMsg := fmt.Sprintf(
+		"Estimated prompt size (~%d tokens) exceeds %d tokens and risks overflowing the engine context window. Consider trimming the workflow markdown or imports.",
+		estimated, limit)
+	fmt.Fprintln(os.Stderr, console.FormatWarningMessage(warningMsg))
+	c.addWarning(warningMsg)
+}
+
+// extractPromptMaxChars returns the prompt.max-chars budget from the frontmatter, or 0
+// when no budget is configured
+func extractPromptMaxChars(frontmatter map[string]any) int {
+	promptConfig, ok := frontmatter["prompt"].(map[string]any)
+	if !ok {
+		return 0
+	}
+	maxChars, ok := parseIntValue(promptConfig["max-chars"])
+	if !ok || maxChars < 0 {
+		return 0
+	}
+	return maxChars
+}
+
+// runtimeImportedPromptChars returns the total size of the files the prompt loads through
+// runtime-import macros. Paths are resolved relative to the .github folder of workspaceDir,
+// matching the runtime resolution. Missing files are skipped.
+func runtimeImportedPromptChars(data *WorkflowData, workspaceDir string) int {
+	text := data.MarkdownContent
+	if data.EngineConfig != nil {
+		text += data.EngineConfig.PromptPrefix + data.EngineConfig.PromptSuffix
+	}
+
+	paths := extractRuntimeImportPaths(text)
+	for _, importPath := range data.ImportPaths {
+		paths = append(paths, filepath.ToSlash(importPath))
+	}
+
+	seen := make(map[string]bool)
+	total := 0
+	for _, importPath := range paths {
+		normalizedPath := strings.TrimPrefix(strings.TrimPrefix(importPath, ".github/"), "./")
+		absolutePath := filepath.Join(workspaceDir, ".github", normalizedPath)
+		if seen[absolutePath] {
+			continue
+		}
+		seen[absolutePath] = true
+
+		info, err := os.Stat(absolutePath)
+		if err != nil || info.IsDir() {
+			promptSizeLog.Printf("Skipping runtime-import file for prompt budget: %s", importPath)
+			continue
+		}
+		total += int(info.Size())
+	}
+	return total
+}
+
+// validatePromptBudget checks the assembled prompt size, including runtime-imported files,
+// against the prompt.max-chars budget. Exceeding the budget is a warning, or an error in
+// strict mode.
+func (c *Compiler) validatePromptBudget(data *WorkflowData, workspaceDir string) error {
+	if data.PromptMaxChars <= 0 {
+		return nil
+	}
+
+	chars := len(assemblePromptTextForEstimate(data, c.collectPromptSections(data)))
+	chars += runtimeImportedPromptChars(data, workspaceDir)
+	promptSizeLog.Printf("Assembled prompt size: %d chars (budget: %d)", chars, data.PromptMaxChars)
+
+	if chars <= data.PromptMaxChars {
+		return nil
+	}
+
+	message := fmt.Sprintf(
+		"Estimated prompt size (%d chars, ~%d tokens) exceeds the prompt.max-chars budget of %d. Consider trimming the workflow markdown, imports or runtime-imported files.",
+		chars, (chars+promptCharsPerToken-1)/promptCharsPerToken, data.PromptMaxChars)
+	if c.strictMode {
+		return fmt.Errorf("strict mode: %s", message)
+	}
+	fmt.Fprintln(os.Stderr, console.FormatWarningMessage(message))
+	c.addWarning(message)
+	return nil
+}
@@ -0,0 +1,173 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEstimatePromptTokens(t *testing.T) {
+	assert.Equal(t, 0, estimatePromptTokens(""), "Empty text should have no tokens")
+	assert.Equal(t, 1, estimatePromptTokens("abc"), "Partial tokens should round up")
+	assert.Equal(t, 2, estimatePromptTokens("abcdefgh"), "Eight characters should be two tokens")
+}
+
+func TestPromptTokenLimit(t *testing.T) {
+	tests := []struct {
+		name      string
+		threshold int
+		engineID  string
+		expected  int
+	}{
+		{name: "default threshold for unknown engine", engineID: "custom", expected: DefaultPromptTokenWarningThreshold},
+		{name: "custom threshold", threshold: 5000, engineID: "claude", expected: 5000},
+		{name: "engine context window below threshold", threshold: 500000, engineID: "copilot", expected: 128000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			compiler := NewCompiler(WithPromptTokenWarningThreshold(tt.threshold))
+			data := &WorkflowData{EngineConfig: &EngineConfig{ID: tt.engineID}}
+			assert.Equal(t, tt.expected, compiler.promptTokenLimit(data), "Effective token limit should match")
+		})
+	}
+}
+
+func TestCheckPromptSizeWarnings(t *testing.T) {
+	tests := []struct {
+		name          string
+		markdown      string
+		expectWarning bool
+	}{
+		{name: "small prompt", markdown: "# Task\n\nSummarize the issue.", expectWarning: false},
+		{name: "oversized prompt", markdown: strings.Repeat("x", 4*1000+4), expectWarning: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			compiler := NewCompiler(WithPromptTokenWarningThreshold(1000))
+			data := &WorkflowData{MarkdownContent: tt.markdown, EngineConfig: &EngineConfig{ID: "claude"}}
+
+			compiler.checkPromptSize(data, nil)
+			if tt.expectWarning {
+				assert.Equal(t, 1, compiler.GetWarningCount(), "Oversized prompt should emit a warning")
+			} else {
+				assert.Zero(t, compiler.GetWarningCount(), "Small prompt should not emit a warning")
+			}
+		})
+	}
+}
+
+func TestCompileWorkflowWarnsOnOversizedPrompt(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "prompt-size-test")
+	content := `---
+on: workflow_dispatch
+permissions:
+  contents: read
+engine: copilot
+---
+
+# Large Prompt
+
+` + strings.Repeat("Analyze the repository and report findings.\n", 2000)
+
+	testFile := filepath.Join(tmpDir, "large-prompt.md")
+	require.NoError(t, os.WriteFile(testFile, []byte(content), 0644), "Failed to write test file")
+
+	compiler := NewCompiler(WithPromptTokenWarningThreshold(5000))
+	require.NoError(t, compiler.CompileWorkflow(testFile), "Oversized prompt should only warn, not fail")
+	assert.Positive(t, compiler.GetWarningCount(), "Compilation should count the prompt size warning")
+}
+
+func TestExtractPromptMaxChars(t *testing.T) {
+	assert.Zero(t, extractPromptMaxChars(map[string]any{}), "Missing prompt config should disable the budget")
+	assert.Zero(t, extractPromptMaxChars(map[string]any{"prompt": map[string]any{}}), "Missing max-chars should disable the budget")
+	assert.Equal(t, 5000, extractPromptMaxChars(map[string]any{"prompt": map[string]any{"max-chars": 5000}}), "max-chars should be parsed")
+	assert.Equal(t, 5000, extractPromptMaxChars(map[string]any{"prompt": map[string]any{"max-chars": uint64(5000)}}), "Unsigned max-chars should be parsed")
+}
+
+func TestRuntimeImportedPromptChars(t *testing.T) {
+	workspaceDir := testutil.TempDir(t, "prompt-budget-runtime-import")
+	sharedDir := filepath.Join(workspaceDir, ".github", "workflows", "shared")
+	require.NoError(t, os.MkdirAll(sharedDir, 0755), "Failed to create shared directory")
+	require.NoError(t, os.WriteFile(filepath.Join(sharedDir, "a.md"), []byte(strings.Repeat("a", 300)), 0644), "Failed to write import")
+	require.NoError(t, os.WriteFile(filepath.Join(sharedDir, "b.md"), []byte(strings.Repeat("b", 200)), 0644), "Failed to write import")
+
+	data := &WorkflowData{
+		MarkdownContent: "{{#runtime-import workflows/shared/b.md:1-5}}\n{{#runtime-import? workflows/shared/missing.md}}\n{{#runtime-import https://example.com/x.md}}",
+		ImportPaths:     []string{".github/workflows/shared/a.md", ".github/workflows/shared/b.md"},
+	}
+
+	assert.Equal(t, 500, runtimeImportedPromptChars(data, workspaceDir), "Each existing runtime-imported file should be counted once")
+}
+
+func TestValidatePromptBudget(t *testing.T) {
+	tests := []struct {
+		name          string
+		markdown      string
+		maxChars      int
+		strict        bool
+		expectWarning bool
+		expectError   bool
+	}{
+		{name: "no budget", markdown: strings.Repeat("x", 5000)},
+		{name: "within budget", markdown: "# Task\n\nSummarize the issue.", maxChars: 1000},
+		{name: "over budget", markdown: strings.Repeat("x", 2000), maxChars: 1000, expectWarning: true},
+		{name: "over budget in strict mode", markdown: strings.Repeat("x", 2000), maxChars: 1000, strict: true, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			compiler := NewCompiler()
+			compiler.SetStrictMode(tt.strict)
+			data := &WorkflowData{MarkdownContent: tt.markdown, PromptMaxChars: tt.maxChars}
+
+			err := compiler.validatePromptBudget(data, t.TempDir())
+			if tt.expectError {
+				require.Error(t, err, "Strict mode should reject an oversized prompt")
+				assert.Contains(t, err.Error(), "prompt.max-chars budget of 1000", "Error should mention the budget")
+				return
+			}
+			require.NoError(t, err, "Prompt budget check should not fail outside strict mode")
+			if tt.expectWarning {
+				assert.Equal(t, 1, compiler.GetWarningCount(), "Oversized prompt should emit a warning")
+			} else {
+				assert.Zero(t, compiler.GetWarningCount(), "Prompt within budget should not emit a warning")
+			}
+		})
+	}
+}
+
+func TestCompileWorkflowPromptBudgetCountsRuntimeImports(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "prompt-budget-test")
+	workflowsDir := filepath.Join(tmpDir, ".github", "workflows")
+	require.NoError(t, os.MkdirAll(filepath.Join(workflowsDir, "shared"), 0755), "Failed to create workflows directory")
+	require.NoError(t, os.WriteFile(filepath.Join(workflowsDir, "shared", "context.md"), []byte(strings.Repeat("Background context.\n", 1000)), 0644), "Failed to write shared file")
+
+	content := `---
+on: workflow_dispatch
+permissions:
+  contents: read
+engine: copilot
+prompt:
+  max-chars: 15000
+---
+
+# Budgeted Prompt
+
+{{#runtime-import workflows/shared/context.md}}
+`
+	testFile := filepath.Join(workflowsDir, "budget.md")
+	require.NoError(t, os.WriteFile(testFile, []byte(content), 0644), "Failed to write test file")
+
+	compiler := NewCompiler()
+	require.NoError(t, compiler.CompileWorkflow(testFile), "Prompt over budget should only warn outside strict mode")
+	assert.Positive(t, compiler.GetWarningCount(), "Runtime-imported file should push the prompt over budget")
+}
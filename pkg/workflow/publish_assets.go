@@ -2,6 +2,7 @@ package workflow
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/github/gh-aw/pkg/constants"
@@ -13,9 +14,78 @@ var publishAssetsLog = logger.New("workflow:publish_assets")
 // UploadAssetsConfig holds configuration for publishing assets to an orphaned git branch
 type UploadAssetsConfig struct {
 	BaseSafeOutputConfig `yaml:",inline"`
-	BranchName           string   `yaml:"branch,omitempty"`       // Branch name (default: "assets/${{ github.workflow }}")
-	MaxSizeKB            int      `yaml:"max-size,omitempty"`     // Maximum file size in KB (default: 10240 = 10MB)
-	AllowedExts          []string `yaml:"allowed-exts,omitempty"` // Allowed file extensions (default: common non-executable types)
+	BranchName           string                   `yaml:"branch,omitempty"`       // Branch name (default: "assets/${{ github.workflow }}")
+	MaxSizeKB            int                      `yaml:"max-size,omitempty"`     // Maximum file size in KB (default: 10240 = 10MB)
+	AllowedExts          []string                 `yaml:"allowed-exts,omitempty"` // Allowed file extensions (default: common non-executable types)
+	Groups               []UploadAssetGroupConfig `yaml:"groups,omitempty"`       // Named asset groups with their own branch and limits, sorted by name
+}
+
+// UploadAssetGroupConfig holds the branch and limits of a named upload-asset group.
+// Unset fields inherit the top-level upload-asset values.
+type UploadAssetGroupConfig struct {
+	Name        string   `yaml:"name"`
+	BranchName  string   `yaml:"branch,omitempty"`
+	MaxSizeKB   int      `yaml:"max-size,omitempty"`
+	AllowedExts []string `yaml:"allowed-exts,omitempty"`
+}
+
+// uploadAssetGroupEnvPrefix returns the env var prefix for a named asset group,
+// e.g. "GH_AW_ASSETS_RELEASE_LOGS_" for the group "release-logs"
+func uploadAssetGroupEnvPrefix(name string) string {
+	return "GH_AW_ASSETS_" + strings.ToUpper(strings.ReplaceAll(name, "-", "_")) + "_"
+}
+
+// parseUploadAssetAllowedExts returns the string entries of an allowed-exts list
+func parseUploadAssetAllowedExts(value any) []string {
+	allowedExtsArray, ok := value.([]any)
+	if !ok {
+		return nil
+	}
+	var extStrings []string
+	for _, ext := range allowedExtsArray {
+		if extStr, ok := ext.(string); ok {
+			extStrings = append(extStrings, extStr)
+		}
+	}
+	return extStrings
+}
+
+// parseUploadAssetGroups parses the groups map of the upload-asset configuration.
+// Groups start from the top-level branch, max-size and allowed-exts values.
+func parseUploadAssetGroups(groupsData any, defaults *UploadAssetsConfig) []UploadAssetGroupConfig {
+	groupsMap, ok := groupsData.(map[string]any)
+	if !ok {
+		return nil
+	}
+
+	names := make([]string, 0, len(groupsMap))
+	for name := range groupsMap {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	groups := make([]UploadAssetGroupConfig, 0, len(names))
+	for _, name := range names {
+		group := UploadAssetGroupConfig{
+			Name:        name,
+			BranchName:  defaults.BranchName,
+			MaxSizeKB:   defaults.MaxSizeKB,
+			AllowedExts: defaults.AllowedExts,
+		}
+		if groupMap, ok := groupsMap[name].(map[string]any); ok {
+			if branchName, ok := groupMap["branch"].(string); ok && branchName != "" {
+				group.BranchName = branchName
+			}
+			if maxSizeInt, ok := parseIntValue(groupMap["max-size"]); ok && maxSizeInt > 0 {
+				group.MaxSizeKB = maxSizeInt
+			}
+			if extStrings := parseUploadAssetAllowedExts(groupMap["allowed-exts"]); len(extStrings) > 0 {
+				group.AllowedExts = extStrings
+			}
+		}
+		groups = append(groups, group)
+	}
+	return groups
 }
 
 // parseUploadAssetConfig handles upload-asset configuration
@@ -50,22 +120,19 @@ func (c *Compiler) parseUploadAssetConfig(outputMap map[string]any) *UploadAsset
 
 			// Parse allowed-exts
 			if allowedExts, exists := configMap["allowed-exts"]; exists {
-				if allowedExtsArray, ok := allowedExts.([]any); ok {
-					var extStrings []string
-					for _, ext := range allowedExtsArray {
-						if extStr, ok := ext.(string); ok {
-							extStrings = append(extStrings, extStr)
-						}
-					}
-					if len(extStrings) > 0 {
-						config.AllowedExts = extStrings
-					}
+				if extStrings := parseUploadAssetAllowedExts(allowedExts); len(extStrings) > 0 {
+					config.AllowedExts = extStrings
 				}
 			}
 
+			// Parse named groups after the top-level values they inherit
+			if groupsData, exists := configMap["groups"]; exists {
+				config.Groups = parseUploadAssetGroups(groupsData, config)
+			}
+
 			// Parse common base fields with default max of 0 (no limit)
 			c.parseBaseSafeOutputConfig(configMap, &config.BaseSafeOutputConfig, 0)
-			publishAssetsLog.Printf("Parsed upload-asset config: branch=%s, max_size_kb=%d, allowed_exts=%d", config.BranchName, config.MaxSizeKB, len(config.AllowedExts))
+			publishAssetsLog.Printf("Parsed upload-asset config: branch=%s, max_size_kb=%d, allowed_exts=%d, groups=%d", config.BranchName, config.MaxSizeKB, len(config.AllowedExts), len(config.Groups))
 		} else if configData == nil {
 			// Handle null case: create config with defaults
 			publishAssetsLog.Print("Using default upload-asset configuration")
@@ -128,6 +195,21 @@ func (c *Compiler) buildUploadAssetsJob(data *WorkflowData, mainJobName string,
 	customEnvVars = append(customEnvVars, fmt.Sprintf("          GH_AW_ASSETS_MAX_SIZE_KB: %d\n", data.SafeOutputs.UploadAssets.MaxSizeKB))
 	customEnvVars = append(customEnvVars, fmt.Sprintf("          GH_AW_ASSETS_ALLOWED_EXTS: %q\n", strings.Join(data.SafeOutputs.UploadAssets.AllowedExts, ",")))
 
+	// Each named group gets its own branch and limits, listed in GH_AW_ASSETS_GROUPS
+	if groups := data.SafeOutputs.UploadAssets.Groups; len(groups) > 0 {
+		groupNames := make([]string, 0, len(groups))
+		for _, group := range groups {
+			groupNames = append(groupNames, group.Name)
+		}
+		customEnvVars = append(customEnvVars, fmt.Sprintf("          GH_AW_ASSETS_GROUPS: %q\n", strings.Join(groupNames, ",")))
+		for _, group := range groups {
+			prefix := uploadAssetGroupEnvPrefix(group.Name)
+			customEnvVars = append(customEnvVars, fmt.Sprintf("          %sBRANCH: %q\n", prefix, group.BranchName))
+			customEnvVars = append(customEnvVars, fmt.Sprintf("          %sMAX_SIZE_KB: %d\n", prefix, group.MaxSizeKB))
+			customEnvVars = append(customEnvVars, fmt.Sprintf("          %sALLOWED_EXTS: %q\n", prefix, strings.Join(group.AllowedExts, ",")))
+		}
+	}
+
 	// Add standard environment variables (metadata + staged/target repo)
 	customEnvVars = append(customEnvVars, c.buildStandardSafeOutputEnvVars(data, "")...) // No target repo for upload assets
 
@@ -156,11 +238,12 @@ func (c *Compiler) buildUploadAssetsJob(data *WorkflowData, mainJobName string,
 		MainJobName:   mainJobName,
 		CustomEnvVars: customEnvVars,
 		Script:        getUploadAssetsScript(),
-		Permissions:   NewPermissionsContentsWrite(),
+		Permissions:   NewPermissionsBuilder().Write(PermissionContents).Build(),
 		Outputs:       outputs,
 		Condition:     jobCondition,
 		PreSteps:      preSteps,
 		Token:         data.SafeOutputs.UploadAssets.GitHubToken,
+		Environment:   data.SafeOutputs.UploadAssets.Environment,
 		Needs:         needs,
 	})
 }
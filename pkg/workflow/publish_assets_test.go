@@ -3,8 +3,11 @@
 package workflow
 
 import (
+	"path/filepath"
 	"strings"
 	"testing"
+
+	"github.com/github/gh-aw/pkg/testutil"
 )
 
 func TestParseUploadAssetConfig(t *testing.T) {
@@ -171,3 +174,63 @@ func TestUploadAssetsJobUsesFileInput(t *testing.T) {
 		t.Error("Expected GH_AW_ASSETS_ALLOWED_EXTS environment variable")
 	}
 }
+
+func TestUploadAssetGroupsCompile(t *testing.T) {
+	content := `---
+on: issues
+permissions:
+  contents: read
+engine: copilot
+safe-outputs:
+  upload-asset:
+    max-size: 4096
+    groups:
+      release-logs:
+        branch: assets/logs
+        allowed-exts: [.log, .txt]
+      images:
+        branch: assets/images
+        max-size: 2048
+        allowed-exts: [.png]
+---
+
+# Publish assets
+`
+	markdownPath := filepath.Join(testutil.TempDir(t, "upload-asset-groups"), "assets.md")
+	lockContent, err := NewCompiler().CompileString(content, markdownPath)
+	if err != nil {
+		t.Fatalf("Failed to compile workflow with upload-asset groups: %v", err)
+	}
+
+	expectedEnvVars := []string{
+		`GH_AW_ASSETS_GROUPS: "images,release-logs"`,
+		`GH_AW_ASSETS_IMAGES_BRANCH: "assets/images"`,
+		`GH_AW_ASSETS_IMAGES_MAX_SIZE_KB: 2048`,
+		`GH_AW_ASSETS_IMAGES_ALLOWED_EXTS: ".png"`,
+		`GH_AW_ASSETS_RELEASE_LOGS_BRANCH: "assets/logs"`,
+		`GH_AW_ASSETS_RELEASE_LOGS_MAX_SIZE_KB: 4096`,
+		`GH_AW_ASSETS_RELEASE_LOGS_ALLOWED_EXTS: ".log,.txt"`,
+	}
+	for _, envVar := range expectedEnvVars {
+		if !strings.Contains(lockContent, envVar) {
+			t.Errorf("Expected lock file to contain %q", envVar)
+		}
+	}
+
+	// The default group keeps the single-group env vars
+	if !strings.Contains(lockContent, `GH_AW_ASSETS_BRANCH: "assets/${{ github.workflow }}"`) {
+		t.Error("Expected the default GH_AW_ASSETS_BRANCH to still be emitted")
+	}
+}
+
+func TestUploadAssetConfigWithoutGroups(t *testing.T) {
+	config := (&Compiler{}).parseUploadAssetConfig(map[string]any{
+		"upload-asset": map[string]any{"branch": "assets/single"},
+	})
+	if config == nil {
+		t.Fatal("Expected upload-asset config to be parsed")
+	}
+	if len(config.Groups) != 0 {
+		t.Errorf("Expected no groups for a single-group config, got %d", len(config.Groups))
+	}
+}
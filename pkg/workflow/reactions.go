@@ -2,6 +2,7 @@ package workflow
 
 import (
 	"fmt"
+	"slices"
 
 	"github.com/github/gh-aw/pkg/logger"
 )
@@ -82,6 +83,53 @@ func parseReactionValue(value any) (string, error) {
 	}
 }
 
+// parseReactionList converts a reaction value from YAML to an ordered list of reactions.
+// The value may be a single reaction or a list of reactions to try in order. Each entry
+// is parsed with parseReactionValue and validated against the set of valid reactions.
+func parseReactionList(value any) ([]string, error) {
+	items, isList := value.([]any)
+	if !isList {
+		reaction, err := parseReactionValue(value)
+		if err != nil {
+			return nil, err
+		}
+		items = []any{reaction}
+	}
+	if len(items) == 0 {
+		return nil, fmt.Errorf("invalid reaction value: list must contain at least one of %v", getValidReactions())
+	}
+
+	reactions := make([]string, 0, len(items))
+	for _, item := range items {
+		reaction, err := parseReactionValue(item)
+		if err != nil {
+			return nil, err
+		}
+		if !isValidReaction(reaction) {
+			return nil, fmt.Errorf("invalid reaction value '%s': must be one of %v", reaction, getValidReactions())
+		}
+		reactions = append(reactions, reaction)
+	}
+	reactionsLog.Printf("Parsed reaction list: %v", reactions)
+	return reactions, nil
+}
+
+// resolveReactions splits an ordered reaction list into the primary reaction and the
+// fallbacks tried when the primary reaction cannot be applied. "none" anywhere in the
+// list disables reactions.
+func resolveReactions(reactions []string) (string, []string) {
+	if len(reactions) == 0 {
+		return "", nil
+	}
+	if slices.Contains(reactions, "none") {
+		return "none", nil
+	}
+	if len(reactions) == 1 {
+		return reactions[0], nil
+	}
+	return reactions[0], reactions[1:]
+}
+
 // intToReactionString converts an integer to a reaction string.
 // Only 1 (+1) and -1 are valid integer values for reactions.
 func intToReactionString(v int64) (string, error) {
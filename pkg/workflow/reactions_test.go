@@ -3,6 +3,7 @@
 package workflow
 
 import (
+	"slices"
 	"sort"
 	"testing"
 )
@@ -140,6 +141,70 @@ func TestParseReactionValue(t *testing.T) {
 	}
 }
 
+func TestParseReactionList(t *testing.T) {
+	tests := []struct {
+		name        string
+		value       any
+		expected    []string
+		expectError bool
+	}{
+		{"single string", "eyes", []string{"eyes"}, false},
+		{"single int", int(1), []string{"+1"}, false},
+		{"list", []any{"rocket", "eyes"}, []string{"rocket", "eyes"}, false},
+		{"list with numeric entries", []any{uint64(1), "heart"}, []string{"+1", "heart"}, false},
+		{"list with none", []any{"rocket", "none"}, []string{"rocket", "none"}, false},
+		{"invalid string", "thumbsup", nil, true},
+		{"invalid list entry", []any{"rocket", "thumbsup"}, nil, true},
+		{"empty list", []any{}, nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := parseReactionList(tt.value)
+
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("parseReactionList(%v) expected error, got result %v", tt.value, result)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("parseReactionList(%v) unexpected error: %v", tt.value, err)
+			}
+			if !slices.Equal(result, tt.expected) {
+				t.Errorf("parseReactionList(%v) = %v, want %v", tt.value, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestResolveReactions(t *testing.T) {
+	tests := []struct {
+		name              string
+		reactions         []string
+		expectedReaction  string
+		expectedFallbacks []string
+	}{
+		{"empty", nil, "", nil},
+		{"single", []string{"eyes"}, "eyes", nil},
+		{"fallbacks", []string{"rocket", "heart", "eyes"}, "rocket", []string{"heart", "eyes"}},
+		{"none first", []string{"none", "eyes"}, "none", nil},
+		{"none last", []string{"rocket", "eyes", "none"}, "none", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reaction, fallbacks := resolveReactions(tt.reactions)
+			if reaction != tt.expectedReaction {
+				t.Errorf("resolveReactions(%v) reaction = %q, want %q", tt.reactions, reaction, tt.expectedReaction)
+			}
+			if !slices.Equal(fallbacks, tt.expectedFallbacks) {
+				t.Errorf("resolveReactions(%v) fallbacks = %v, want %v", tt.reactions, fallbacks, tt.expectedFallbacks)
+			}
+		})
+	}
+}
+
 func TestIntToReactionString(t *testing.T) {
 	tests := []struct {
 		name        string
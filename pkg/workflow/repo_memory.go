@@ -704,7 +704,7 @@ func (c *Compiler) buildPushRepoMemoryJob(data *WorkflowData, threatDetectionEna
 	}
 
 	job := &Job{
-		Name:        "push_repo_memory",
+		Name:        string(constants.PushRepoMemoryJobName),
 		DisplayName: "", // No display name - job ID is sufficient
 		RunsOn:      "runs-on: ubuntu-latest",
 		If:          jobCondition,
@@ -0,0 +1,103 @@
+package workflow
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var repositoryConfigLog = logger.New("workflow:repository_config")
+
+const (
+	// RepositoryConfigFileName is the name of the repository-level policy file in .github/aw/.
+	RepositoryConfigFileName = "config.json"
+)
+
+// RepositoryConfig holds repository-level policies that apply to every workflow compiled in
+// the repository. It is read from .github/aw/config.json:
+//
+//	{
+//	  "engine": {
+//	    "max-turns-ceiling": 50
+//	  },
+//	  "schedule": {
+//	    "budget": 100,
+//	    "run-cost": {"copilot": 0.40}
+//	  }
+//	}
+type RepositoryConfig struct {
+	Engine   RepositoryEngineConfig   `json:"engine"`
+	Schedule RepositoryScheduleConfig `json:"schedule"`
+}
+
+// RepositoryEngineConfig holds repository-level engine policies
+type RepositoryEngineConfig struct {
+	MaxTurnsCeiling int `json:"max-turns-ceiling,omitempty"` // Highest engine.max-turns any workflow may request (0 = no ceiling)
+}
+
+// RepositoryScheduleConfig holds repository-level schedule budget settings
+type RepositoryScheduleConfig struct {
+	Budget  float64            `json:"budget,omitempty"`   // Monthly budget in USD for scheduled workflows without on.schedule.budget (0 = none)
+	RunCost map[string]float64 `json:"run-cost,omitempty"` // Estimated cost in USD of one run, keyed by engine ID; overrides estimatedRunCostUSD
+}
+
+// LoadRepositoryConfig reads .github/aw/config.json from the repository root. A missing file
+// yields an empty configuration.
+func LoadRepositoryConfig(repoRoot string) (*RepositoryConfig, error) {
+	configPath := filepath.Join(repoRoot, ".github", "aw", RepositoryConfigFileName)
+	repositoryConfigLog.Printf("Loading repository config from: %s", configPath)
+
+	config := &RepositoryConfig{}
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			repositoryConfigLog.Print("Repository config does not exist, using defaults")
+			return config, nil
+		}
+		return nil, fmt.Errorf("failed to read repository config %s: %w", configPath, err)
+	}
+
+	if err := json.Unmarshal(data, config); err != nil {
+		return nil, fmt.Errorf("invalid repository config %s: %w", configPath, err)
+	}
+	if config.Engine.MaxTurnsCeiling < 0 {
+		return nil, fmt.Errorf("invalid repository config %s: engine.max-turns-ceiling must be a positive number of turns, got %d", configPath, config.Engine.MaxTurnsCeiling)
+	}
+	if config.Schedule.Budget < 0 {
+		return nil, fmt.Errorf("invalid repository config %s: schedule.budget must not be negative, got %v", configPath, config.Schedule.Budget)
+	}
+	for engineID, cost := range config.Schedule.RunCost {
+		if cost < 0 {
+			return nil, fmt.Errorf("invalid repository config %s: schedule.run-cost.%s must not be negative, got %v", configPath, engineID, cost)
+		}
+	}
+
+	repositoryConfigLog.Printf("Loaded repository config: max-turns-ceiling=%d, schedule budget=%.2f, run costs=%d", config.Engine.MaxTurnsCeiling, config.Schedule.Budget, len(config.Schedule.RunCost))
+	return config, nil
+}
+
+// getRepositoryConfig returns the repository config, loading it from the git root on first use
+func (c *Compiler) getRepositoryConfig() (*RepositoryConfig, error) {
+	if c.repositoryConfig != nil {
+		return c.repositoryConfig, nil
+	}
+
+	baseDir := c.gitRoot
+	if baseDir == "" {
+		cwd, err := os.Getwd()
+		if err != nil {
+			cwd = "."
+		}
+		baseDir = cwd
+	}
+
+	config, err := LoadRepositoryConfig(baseDir)
+	if err != nil {
+		return nil, err
+	}
+	c.repositoryConfig = config
+	return config, nil
+}
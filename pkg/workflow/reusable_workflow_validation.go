@@ -0,0 +1,105 @@
+// This file provides validation for reusable workflows called from custom jobs.
+//
+// # Reusable Workflow Validation
+//
+// Custom jobs can call a reusable workflow with `uses:` instead of defining steps.
+// The reference is passed through to the lock file unchanged, so a malformed path
+// only fails when GitHub Actions loads the workflow. This file validates that:
+//   - Remote references have the form owner/repo/.github/workflows/file.yml@ref
+//   - Local references have the form ./.github/workflows/file.yml
+//   - Remote references are pinned to a commit SHA or a version tag (strict mode warning)
+//
+// Unpinned references such as @main let the called workflow change underneath the
+// caller, so strict mode warns about them.
+
+package workflow
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+
+	"github.com/github/gh-aw/pkg/console"
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var reusableWorkflowValidationLog = logger.New("workflow:reusable_workflow_validation")
+
+var (
+	// remoteReusableWorkflowPattern matches owner/repo/.github/workflows/file.yml@ref
+	remoteReusableWorkflowPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9-]*/[A-Za-z0-9._-]+/\.github/workflows/[^@/]+\.ya?ml@(\S+)$`)
+	// localReusableWorkflowPattern matches ./.github/workflows/file.yml
+	localReusableWorkflowPattern = regexp.MustCompile(`^\./\.github/workflows/[^@/]+\.ya?ml$`)
+	// pinnedWorkflowRefPattern matches a full commit SHA or a version tag such as v1, v1.2.3, or 1.2.3
+	pinnedWorkflowRefPattern = regexp.MustCompile(`^([0-9a-f]{40}|(v\d+(\.\d+){0,2}|\d+\.\d+(\.\d+)?)([-+][0-9A-Za-z.-]+)?)$`)
+)
+
+// reusableWorkflowUses returns the `uses` reference of each custom job that calls a
+// reusable workflow, keyed by job name
+func reusableWorkflowUses(jobs map[string]any) map[string]string {
+	uses := make(map[string]string)
+	for jobName, jobValue := range jobs {
+		jobConfig, ok := jobValue.(map[string]any)
+		if !ok {
+			continue
+		}
+		if ref, ok := jobConfig["uses"].(string); ok {
+			uses[jobName] = ref
+		}
+	}
+	return uses
+}
+
+// sortedJobNames returns the job names of a uses map in a stable order
+func sortedJobNames(uses map[string]string) []string {
+	names := make([]string, 0, len(uses))
+	for name := range uses {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// validateReusableWorkflowUses checks that custom jobs calling a reusable workflow
+// reference a well-formed local or remote workflow path
+func validateReusableWorkflowUses(jobs map[string]any) error {
+	uses := reusableWorkflowUses(jobs)
+	for _, jobName := range sortedJobNames(uses) {
+		ref := uses[jobName]
+		reusableWorkflowValidationLog.Printf("Validating reusable workflow reference: job=%s, uses=%s", jobName, ref)
+		if localReusableWorkflowPattern.MatchString(ref) || remoteReusableWorkflowPattern.MatchString(ref) {
+			continue
+		}
+		return fmt.Errorf("jobs.%s.uses: invalid reusable workflow reference '%s'. Expected 'owner/repo/.github/workflows/<file>.yml@<ref>' or './.github/workflows/<file>.yml'", jobName, ref)
+	}
+	return nil
+}
+
+// warnUnpinnedReusableWorkflows emits a warning for each remote reusable workflow
+// that is not pinned to a commit SHA or a version tag. Only called in strict mode.
+func (c *Compiler) warnUnpinnedReusableWorkflows(frontmatter map[string]any) {
+	jobs, ok := frontmatter["jobs"].(map[string]any)
+	if !ok {
+		return
+	}
+
+	uses := reusableWorkflowUses(jobs)
+	for _, jobName := range sortedJobNames(uses) {
+		match := remoteReusableWorkflowPattern.FindStringSubmatch(uses[jobName])
+		if match == nil || isPinnedWorkflowRef(match[1]) {
+			continue
+		}
+		reusableWorkflowValidationLog.Printf("Unpinned reusable workflow: job=%s, ref=%s", jobName, match[1])
+		warningMsg := fmt.Sprintf(
+			"strict mode: jobs.%s.uses references '%s', which is not pinned to a commit SHA or version tag. Pin it (e.g. @<40-character-sha> or @v1) so the called workflow cannot change unexpectedly.",
+			jobName, uses[jobName])
+		fmt.Fprintln(os.Stderr, console.FormatWarningMessage(warningMsg))
+		c.addWarning(warningMsg)
+	}
+}
+
+// isPinnedWorkflowRef reports whether a reusable workflow ref is a commit SHA or a version tag
+func isPinnedWorkflowRef(ref string) bool {
+	return pinnedWorkflowRefPattern.MatchString(ref)
+}
@@ -0,0 +1,131 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsPinnedWorkflowRef(t *testing.T) {
+	tests := []struct {
+		ref      string
+		expected bool
+	}{
+		{ref: "0123456789abcdef0123456789abcdef01234567", expected: true},
+		{ref: "v1", expected: true},
+		{ref: "v1.2.3", expected: true},
+		{ref: "1.2.3", expected: true},
+		{ref: "v2.0.0-beta.1", expected: true},
+		{ref: "main", expected: false},
+		{ref: "feature/branch", expected: false},
+		{ref: "0123456", expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.ref, func(t *testing.T) {
+			assert.Equal(t, tt.expected, isPinnedWorkflowRef(tt.ref), "Pinned ref detection should match")
+		})
+	}
+}
+
+func TestValidateReusableWorkflowUses(t *testing.T) {
+	tests := []struct {
+		name    string
+		uses    string
+		wantErr bool
+	}{
+		{name: "remote pinned to sha", uses: "owner/repo/.github/workflows/deploy.yml@0123456789abcdef0123456789abcdef01234567"},
+		{name: "remote on branch", uses: "owner/repo/.github/workflows/deploy.yaml@main"},
+		{name: "local workflow", uses: "./.github/workflows/deploy.yml"},
+		{name: "remote without ref", uses: "owner/repo/.github/workflows/deploy.yml", wantErr: true},
+		{name: "outside workflows directory", uses: "owner/repo/deploy.yml@v1", wantErr: true},
+		{name: "local with ref", uses: "./.github/workflows/deploy.yml@v1", wantErr: true},
+		{name: "not a workflow file", uses: "owner/repo/.github/workflows/deploy@v1", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			jobs := map[string]any{"deploy": map[string]any{"uses": tt.uses}}
+			err := validateReusableWorkflowUses(jobs)
+			if tt.wantErr {
+				require.Error(t, err, "Malformed reference should be rejected")
+				assert.Contains(t, err.Error(), "jobs.deploy.uses", "Error should name the job")
+			} else {
+				assert.NoError(t, err, "Well-formed reference should be accepted")
+			}
+		})
+	}
+}
+
+func TestCompileWorkflowReusableWorkflowPinning(t *testing.T) {
+	tests := []struct {
+		name          string
+		uses          string
+		strict        string
+		expectWarning bool
+	}{
+		{
+			name:          "pinned sha in strict mode passes",
+			uses:          "owner/repo/.github/workflows/deploy.yml@0123456789abcdef0123456789abcdef01234567",
+			expectWarning: false,
+		},
+		{
+			name:          "version tag in strict mode passes",
+			uses:          "owner/repo/.github/workflows/deploy.yml@v1",
+			expectWarning: false,
+		},
+		{
+			name:          "branch ref in strict mode warns",
+			uses:          "owner/repo/.github/workflows/deploy.yml@main",
+			expectWarning: true,
+		},
+		{
+			name:          "branch ref without strict mode passes",
+			uses:          "owner/repo/.github/workflows/deploy.yml@main",
+			strict:        "strict: false\n",
+			expectWarning: false,
+		},
+		{
+			name:          "local workflow in strict mode passes",
+			uses:          "./.github/workflows/deploy.yml",
+			expectWarning: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := testutil.TempDir(t, "reusable-workflow-test")
+			content := "---\non: workflow_dispatch\npermissions:\n  contents: read\nengine: copilot\n" + tt.strict +
+				"jobs:\n  deploy:\n    needs: [agent]\n    uses: " + tt.uses + "\n---\n\n# Test Workflow\n"
+			testFile := filepath.Join(tmpDir, "reusable.md")
+			require.NoError(t, os.WriteFile(testFile, []byte(content), 0644), "Failed to write test file")
+
+			compiler := NewCompiler()
+			require.NoError(t, compiler.CompileWorkflow(testFile), "Unpinned reference should only warn, not fail")
+
+			if tt.expectWarning {
+				assert.Equal(t, 1, compiler.GetWarningCount(), "Unpinned reference should emit a warning in strict mode")
+			} else {
+				assert.Zero(t, compiler.GetWarningCount(), "Reference should not emit a warning")
+			}
+		})
+	}
+}
+
+func TestCompileWorkflowMalformedReusableWorkflow(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "reusable-workflow-invalid-test")
+	content := "---\non: workflow_dispatch\npermissions:\n  contents: read\nengine: copilot\n" +
+		"jobs:\n  deploy:\n    needs: [agent]\n    uses: owner/repo/deploy.yml\n---\n\n# Test Workflow\n"
+	testFile := filepath.Join(tmpDir, "reusable.md")
+	require.NoError(t, os.WriteFile(testFile, []byte(content), 0644), "Failed to write test file")
+
+	err := NewCompiler().CompileWorkflow(testFile)
+	require.Error(t, err, "Malformed reusable workflow reference should fail")
+	assert.Contains(t, err.Error(), "invalid reusable workflow reference", "Error should explain the invalid reference")
+}
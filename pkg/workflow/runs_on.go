@@ -0,0 +1,199 @@
+package workflow
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var runsOnLog = logger.New("workflow:runs_on")
+
+// runsOnGroupExample shows the object form used to combine a runner group with labels
+const runsOnGroupExample = "\n\nExample:\nruns-on:\n  group: my-runner-group\n  labels: [linux, x64]"
+
+// runsOnForm records which runs-on syntax was used so it can be rendered back the same way
+type runsOnForm int
+
+const (
+	runsOnLabel  runsOnForm = iota // runs-on: ubuntu-latest
+	runsOnList                     // runs-on: [self-hosted, linux]
+	runsOnObject                   // runs-on: {group: my-group, labels: [linux]}
+)
+
+// RunsOnConfig is a parsed runs-on value. GitHub Actions accepts a single runner label,
+// a list of labels, or an object selecting a runner group with optional labels.
+type RunsOnConfig struct {
+	Group  string   // Runner group name (object form only)
+	Labels []string // Runner labels
+	form   runsOnForm
+}
+
+// parseRunsOn parses and validates a runs-on value from frontmatter
+func parseRunsOn(value any) (*RunsOnConfig, error) {
+	switch v := value.(type) {
+	case string:
+		if strings.TrimSpace(v) == "" {
+			return nil, errors.New("runs-on must not be empty")
+		}
+		return &RunsOnConfig{Labels: []string{v}, form: runsOnLabel}, nil
+	case []any:
+		if len(v) == 0 {
+			return nil, errors.New("runs-on list must contain at least one runner label")
+		}
+		config := &RunsOnConfig{form: runsOnList}
+		for _, item := range v {
+			label, ok := item.(string)
+			if !ok {
+				if itemMap, isMap := item.(map[string]any); isMap {
+					if _, hasGroup := itemMap["group"]; hasGroup {
+						return nil, errors.New("a runner group cannot be mixed into a list of runner labels. Use the object form and list the labels under 'labels'" + runsOnGroupExample)
+					}
+				}
+				return nil, fmt.Errorf("runs-on list entries must be runner labels, got %v", item)
+			}
+			if strings.TrimSpace(label) == "" {
+				return nil, errors.New("runs-on list must not contain empty runner labels")
+			}
+			config.Labels = append(config.Labels, label)
+		}
+		return config, nil
+	case map[string]any:
+		return parseRunsOnObject(v)
+	default:
+		return nil, fmt.Errorf("runs-on must be a runner label, a list of runner labels, or an object with 'group' and 'labels', got %v", value)
+	}
+}
+
+// parseRunsOnObject parses the {group, labels} form of runs-on
+func parseRunsOnObject(runsOn map[string]any) (*RunsOnConfig, error) {
+	keys := make([]string, 0, len(runsOn))
+	for key := range runsOn {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	config := &RunsOnConfig{form: runsOnObject}
+	for _, key := range keys {
+		switch key {
+		case "group":
+			group, ok := runsOn[key].(string)
+			if !ok || strings.TrimSpace(group) == "" {
+				return nil, fmt.Errorf("runs-on group must be a non-empty runner group name, got %v", runsOn[key])
+			}
+			config.Group = group
+		case "labels":
+			labels, err := parseRunsOnLabels(runsOn[key])
+			if err != nil {
+				return nil, err
+			}
+			config.Labels = labels
+		default:
+			// A bare label next to group (e.g. "ubuntu-latest:") parses as an extra key
+			return nil, fmt.Errorf("unknown runs-on field '%s'. Only 'group' and 'labels' are allowed; list runner labels under 'labels'%s", key, runsOnGroupExample)
+		}
+	}
+
+	if config.Group == "" && len(config.Labels) == 0 {
+		return nil, errors.New("runs-on object must set 'group', 'labels', or both" + runsOnGroupExample)
+	}
+	return config, nil
+}
+
+// parseRunsOnLabels parses runs-on labels given as a single label or a list of labels
+func parseRunsOnLabels(value any) ([]string, error) {
+	if label, ok := value.(string); ok && strings.TrimSpace(label) != "" {
+		return []string{label}, nil
+	}
+	items, ok := value.([]any)
+	if !ok || len(items) == 0 {
+		return nil, fmt.Errorf("runs-on labels must be a runner label or a non-empty list of runner labels, got %v", value)
+	}
+	labels := make([]string, 0, len(items))
+	for _, item := range items {
+		label, ok := item.(string)
+		if !ok || strings.TrimSpace(label) == "" {
+			return nil, fmt.Errorf("runs-on labels must be non-empty strings, got %v", item)
+		}
+		labels = append(labels, label)
+	}
+	return labels, nil
+}
+
+// RenderToYAML renders the runs-on value as an unindented "runs-on:" YAML entry
+func (r *RunsOnConfig) RenderToYAML() string {
+	var yaml strings.Builder
+	switch r.form {
+	case runsOnLabel:
+		yaml.WriteString("runs-on: " + r.Labels[0])
+	case runsOnList:
+		yaml.WriteString("runs-on:")
+		for _, label := range r.Labels {
+			yaml.WriteString("\n  - " + label)
+		}
+	case runsOnObject:
+		yaml.WriteString("runs-on:")
+		if r.Group != "" {
+			yaml.WriteString("\n  group: " + r.Group)
+		}
+		if len(r.Labels) > 0 {
+			yaml.WriteString("\n  labels:")
+			for _, label := range r.Labels {
+				yaml.WriteString("\n    - " + label)
+			}
+		}
+	}
+	return yaml.String()
+}
+
+// validateRunsOnFields parses every runs-on in the frontmatter (top level, custom jobs and
+// safe-output jobs) so misconfigured runner groups are reported with an example before
+// schema validation produces a generic oneOf error
+func validateRunsOnFields(frontmatter map[string]any) error {
+	if runsOn, exists := frontmatter["runs-on"]; exists {
+		if _, err := parseRunsOn(runsOn); err != nil {
+			return fmt.Errorf("invalid runs-on: %w", err)
+		}
+	}
+
+	if err := validateJobsRunsOn(frontmatter["jobs"], "jobs"); err != nil {
+		return err
+	}
+	if safeOutputs, ok := frontmatter["safe-outputs"].(map[string]any); ok {
+		if err := validateJobsRunsOn(safeOutputs["jobs"], "safe-outputs.jobs"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateJobsRunsOn parses the runs-on of each job in a jobs map
+func validateJobsRunsOn(jobs any, path string) error {
+	jobsMap, ok := jobs.(map[string]any)
+	if !ok {
+		return nil
+	}
+	jobNames := make([]string, 0, len(jobsMap))
+	for jobName := range jobsMap {
+		jobNames = append(jobNames, jobName)
+	}
+	slices.Sort(jobNames)
+
+	for _, jobName := range jobNames {
+		jobConfig, ok := jobsMap[jobName].(map[string]any)
+		if !ok {
+			continue
+		}
+		runsOn, exists := jobConfig["runs-on"]
+		if !exists {
+			continue
+		}
+		if _, err := parseRunsOn(runsOn); err != nil {
+			runsOnLog.Printf("Invalid runs-on for %s.%s: %v", path, jobName, err)
+			return fmt.Errorf("invalid %s.%s.runs-on: %w", path, jobName, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,155 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRunsOn(t *testing.T) {
+	tests := []struct {
+		name        string
+		value       any
+		group       string
+		labels      []string
+		yaml        string
+		errContains string
+	}{
+		{
+			name:   "single label",
+			value:  "ubuntu-latest",
+			labels: []string{"ubuntu-latest"},
+			yaml:   "runs-on: ubuntu-latest",
+		},
+		{
+			name:   "label list",
+			value:  []any{"self-hosted", "linux"},
+			labels: []string{"self-hosted", "linux"},
+			yaml:   "runs-on:\n  - self-hosted\n  - linux",
+		},
+		{
+			name:   "valid group config",
+			value:  map[string]any{"group": "larger-runners", "labels": []any{"linux", "x64"}},
+			group:  "larger-runners",
+			labels: []string{"linux", "x64"},
+			yaml:   "runs-on:\n  group: larger-runners\n  labels:\n    - linux\n    - x64",
+		},
+		{
+			name:  "group only",
+			value: map[string]any{"group": "larger-runners"},
+			group: "larger-runners",
+			yaml:  "runs-on:\n  group: larger-runners",
+		},
+		{
+			name:   "group with a single label",
+			value:  map[string]any{"group": "larger-runners", "labels": "ubuntu-24.04-16core"},
+			group:  "larger-runners",
+			labels: []string{"ubuntu-24.04-16core"},
+			yaml:   "runs-on:\n  group: larger-runners\n  labels:\n    - ubuntu-24.04-16core",
+		},
+		{
+			name:   "labels only",
+			value:  map[string]any{"labels": []any{"linux"}},
+			labels: []string{"linux"},
+			yaml:   "runs-on:\n  labels:\n    - linux",
+		},
+		{
+			name:        "group mixed with a bare label",
+			value:       map[string]any{"group": "larger-runners", "ubuntu-latest": nil},
+			errContains: "unknown runs-on field 'ubuntu-latest'",
+		},
+		{
+			name:        "group mixed into a label list",
+			value:       []any{"ubuntu-latest", map[string]any{"group": "larger-runners"}},
+			errContains: "a runner group cannot be mixed into a list of runner labels",
+		},
+		{
+			name:        "empty object",
+			value:       map[string]any{},
+			errContains: "runs-on object must set 'group', 'labels', or both",
+		},
+		{
+			name:        "empty group",
+			value:       map[string]any{"group": ""},
+			errContains: "runs-on group must be a non-empty runner group name",
+		},
+		{
+			name:        "empty label",
+			value:       "",
+			errContains: "runs-on must not be empty",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, err := parseRunsOn(tt.value)
+			if tt.errContains != "" {
+				require.Error(t, err, "Invalid runs-on should be rejected")
+				assert.Contains(t, err.Error(), tt.errContains, "Error should explain the problem")
+				return
+			}
+			require.NoError(t, err, "Valid runs-on should parse")
+			assert.Equal(t, tt.group, config.Group, "Group should be parsed")
+			assert.Equal(t, tt.labels, config.Labels, "Labels should be parsed")
+			assert.Equal(t, tt.yaml, config.RenderToYAML(), "runs-on should render in the form it was given")
+		})
+	}
+}
+
+func TestRunsOnGroupCompilation(t *testing.T) {
+	tests := []struct {
+		name        string
+		frontmatter string
+		expected    []string
+		errContains string
+	}{
+		{
+			name:        "valid group config",
+			frontmatter: "runs-on:\n  group: larger-runners\n  labels: [linux, x64]",
+			expected:    []string{"    runs-on:\n      group: larger-runners\n      labels:\n      - linux\n      - x64\n"},
+		},
+		{
+			name:        "labels only",
+			frontmatter: "runs-on:\n  labels: [linux]",
+			expected:    []string{"    runs-on:\n      labels:\n      - linux\n"},
+		},
+		{
+			name:        "custom job with group config",
+			frontmatter: "jobs:\n  build:\n    runs-on:\n      group: larger-runners\n      labels: linux\n    steps:\n      - run: echo hi",
+			expected:    []string{"  build:\n    needs: activation\n    runs-on:\n      group: larger-runners\n      labels:\n        - linux\n"},
+		},
+		{
+			name:        "invalid mix of group and bare label",
+			frontmatter: "runs-on:\n  group: larger-runners\n  ubuntu-latest:",
+			errContains: "invalid runs-on: unknown runs-on field 'ubuntu-latest'",
+		},
+		{
+			name:        "invalid mix in custom job",
+			frontmatter: "jobs:\n  build:\n    runs-on: [ubuntu-latest, {group: larger-runners}]\n    steps:\n      - run: echo hi",
+			errContains: "invalid jobs.build.runs-on: a runner group cannot be mixed into a list of runner labels",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content := "---\non: workflow_dispatch\npermissions:\n  contents: read\nengine: copilot\n" + tt.frontmatter + "\n---\n\n# Runner groups\n"
+			markdownPath := filepath.Join(testutil.TempDir(t, "runs-on-group"), "test.md")
+
+			lockContent, err := NewCompiler().CompileString(content, markdownPath)
+			if tt.errContains != "" {
+				require.Error(t, err, "Compilation should fail")
+				assert.Contains(t, err.Error(), tt.errContains, "Error should explain the runs-on problem")
+				return
+			}
+			require.NoError(t, err, "Compilation should succeed")
+			for _, expected := range tt.expected {
+				assert.Contains(t, lockContent, expected, "Lock file should contain the runner configuration")
+			}
+		})
+	}
+}
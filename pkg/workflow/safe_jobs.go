@@ -2,7 +2,6 @@ package workflow
 
 import (
 	"fmt"
-	"strings"
 
 	"github.com/github/gh-aw/pkg/constants"
 	"github.com/github/gh-aw/pkg/logger"
@@ -183,20 +182,11 @@ func (c *Compiler) buildSafeJobs(data *WorkflowData, threatDetectionEnabled bool
 
 		// Set runs-on
 		if jobConfig.RunsOn != nil {
-			if runsOnStr, ok := jobConfig.RunsOn.(string); ok {
-				job.RunsOn = fmt.Sprintf("runs-on: %s", runsOnStr)
-			} else if runsOnList, ok := jobConfig.RunsOn.([]any); ok {
-				// Handle array format
-				var runsOnItems []string
-				for _, item := range runsOnList {
-					if itemStr, ok := item.(string); ok {
-						runsOnItems = append(runsOnItems, fmt.Sprintf("      - %s", itemStr))
-					}
-				}
-				if len(runsOnItems) > 0 {
-					job.RunsOn = fmt.Sprintf("runs-on:\n%s", strings.Join(runsOnItems, "\n"))
-				}
+			runsOnConfig, err := parseRunsOn(jobConfig.RunsOn)
+			if err != nil {
+				return nil, fmt.Errorf("invalid safe-outputs.jobs.%s.runs-on: %w", jobName, err)
 			}
+			job.RunsOn = c.indentYAMLLines(runsOnConfig.RenderToYAML(), "    ")
 		} else {
 			job.RunsOn = "runs-on: ubuntu-latest" // Default
 		}
@@ -280,6 +270,8 @@ func (c *Compiler) buildSafeJobs(data *WorkflowData, threatDetectionEnabled bool
 			job.Permissions = perms.RenderToYAML()
 		}
 
+		c.explainJob(job, "created because safe-outputs.jobs.%s is configured", jobName)
+
 		// Add the job to the job manager
 		if err := c.jobManager.AddJob(job); err != nil {
 			safeJobsLog.Printf("Failed to add safe-job %s: %v", normalizedJobName, err)
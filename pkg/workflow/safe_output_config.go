@@ -9,9 +9,13 @@ func (c *Compiler) parseBaseSafeOutputConfig(configMap map[string]any, config *B
 		config.Max = defaultMax
 	}
 
-	// Parse max (this will override the default if present in configMap)
+	// Parse max (this will override the default if present in configMap).
+	// Repository variable and workflow input expressions are resolved at runtime from an
+	// environment variable, keeping the default max in the generated configuration.
 	if max, exists := configMap["max"]; exists {
-		if maxInt, ok := parseIntValue(max); ok {
+		if maxStr, ok := max.(string); ok && isSafeOutputsMaxExpression(maxStr) {
+			config.MaxExpression = maxStr
+		} else if maxInt, ok := parseIntValue(max); ok {
 			config.Max = maxInt
 		}
 	}
@@ -22,4 +26,21 @@ func (c *Compiler) parseBaseSafeOutputConfig(configMap map[string]any, config *B
 			config.GitHubToken = githubTokenStr
 		}
 	}
+
+	// Parse environment
+	if environment, exists := configMap["environment"]; exists {
+		if environmentStr, ok := environment.(string); ok {
+			config.Environment = environmentStr
+		}
+	}
+}
+
+// maxExpressionSetter is implemented by safe output configs embedding BaseSafeOutputConfig
+type maxExpressionSetter interface {
+	setMaxExpression(expression string)
+}
+
+// setMaxExpression records a max given as a GitHub Actions expression
+func (b *BaseSafeOutputConfig) setMaxExpression(expression string) {
+	b.MaxExpression = expression
 }
@@ -224,7 +224,8 @@ var ValidationConfig = map[string]TypeValidationConfig{
 	"upload_asset": {
 		DefaultMax: 10,
 		Fields: map[string]FieldValidation{
-			"path": {Required: true, Type: "string"},
+			"path":  {Required: true, Type: "string"},
+			"group": {Type: "string", MaxLength: 64},
 		},
 	},
 	"noop": {
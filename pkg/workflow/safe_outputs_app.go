@@ -121,6 +121,17 @@ func (c *Compiler) mergeAppFromIncludedConfigs(topSafeOutputs *SafeOutputsConfig
 // buildGitHubAppTokenMintStep generates the step to mint a GitHub App installation access token
 // Permissions are automatically computed from the safe output job requirements
 func (c *Compiler) buildGitHubAppTokenMintStep(app *GitHubAppConfig, permissions *Permissions) []string {
+	var permissionFields map[string]string
+	if permissions != nil {
+		permissionFields = convertPermissionsToAppTokenFields(permissions)
+	}
+	return c.buildGitHubAppTokenMintStepWithFields(app, permissionFields)
+}
+
+// buildGitHubAppTokenMintStepWithFields generates the step to mint a GitHub App installation
+// access token requesting the given permission-* inputs. Callers use it to request organization
+// permissions that have no GitHub Actions job permission equivalent, such as permission-members.
+func (c *Compiler) buildGitHubAppTokenMintStepWithFields(app *GitHubAppConfig, permissionFields map[string]string) []string {
 	safeOutputsAppLog.Printf("Building GitHub App token mint step: owner=%s, repos=%d", app.Owner, len(app.Repositories))
 	var steps []string
 
@@ -153,20 +164,15 @@ func (c *Compiler) buildGitHubAppTokenMintStep(app *GitHubAppConfig, permissions
 
 	// Add permission-* fields automatically computed from job permissions
 	// Sort keys to ensure deterministic compilation order
-	if permissions != nil {
-		permissionFields := convertPermissionsToAppTokenFields(permissions)
-
-		// Extract and sort keys for deterministic ordering
-		keys := make([]string, 0, len(permissionFields))
-		for key := range permissionFields {
-			keys = append(keys, key)
-		}
-		sort.Strings(keys)
+	keys := make([]string, 0, len(permissionFields))
+	for key := range permissionFields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
 
-		// Add permissions in sorted order
-		for _, key := range keys {
-			steps = append(steps, fmt.Sprintf("          %s: %s\n", key, permissionFields[key]))
-		}
+	// Add permissions in sorted order
+	for _, key := range keys {
+		steps = append(steps, fmt.Sprintf("          %s: %s\n", key, permissionFields[key]))
 	}
 
 	return steps
@@ -420,6 +420,12 @@ func (c *Compiler) extractSafeOutputsConfig(frontmatter map[string]any) *SafeOut
 				config.Mentions = parseMentionsConfig(mentions)
 			}
 
+			// Handle retry policy (defaults to 3 attempts with exponential backoff)
+			config.Retry = parseSafeOutputsRetryConfig(outputMap)
+
+			// Handle per-handler if conditions
+			config.Conditions = parseSafeOutputsHandlerConditions(outputMap)
+
 			// Handle global footer flag
 			if footer, exists := outputMap["footer"]; exists {
 				if footerBool, ok := footer.(bool); ok {
@@ -428,6 +434,14 @@ func (c *Compiler) extractSafeOutputsConfig(frontmatter map[string]any) *SafeOut
 				}
 			}
 
+			// Handle conclusion job toggle
+			if conclusion, exists := outputMap["conclusion"]; exists {
+				if conclusionBool, ok := conclusion.(bool); ok {
+					config.Conclusion = &conclusionBool
+					safeOutputsConfigLog.Printf("Conclusion job enabled: %t", conclusionBool)
+				}
+			}
+
 			// Handle jobs (safe-jobs must be under safe-outputs)
 			if jobs, exists := outputMap["jobs"]; exists {
 				if jobsMap, ok := jobs.(map[string]any); ok {
@@ -0,0 +1,82 @@
+package workflow
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var safeOutputsConditionsLog = logger.New("workflow:safe_outputs_config_conditions")
+
+// ========================================
+// Safe Output Handler Conditions
+// ========================================
+
+// safeOutputHandlerName returns the handler manager name for a safe-outputs key
+// (e.g. "add-comment" -> "add_comment")
+func safeOutputHandlerName(key string) string {
+	return strings.ReplaceAll(key, "-", "_")
+}
+
+// handlerConditionExpression wraps an if condition in ${{ }} unless it already is an
+// expression, matching how GitHub Actions accepts job and step if conditions
+func handlerConditionExpression(condition string) string {
+	condition = strings.TrimSpace(condition)
+	if strings.HasPrefix(condition, "${{") && strings.HasSuffix(condition, "}}") {
+		return condition
+	}
+	return "${{ " + condition + " }}"
+}
+
+// safeOutputsConditionEnvVarName returns the environment variable that carries the evaluated
+// if condition for a handler (e.g. add_comment -> GH_AW_SAFE_OUTPUTS_IF_ADD_COMMENT)
+func safeOutputsConditionEnvVarName(handlerName string) string {
+	return "GH_AW_SAFE_OUTPUTS_IF_" + strings.ToUpper(safeOutputHandlerName(handlerName))
+}
+
+// renderSafeOutputsConditionEnvVars returns step env lines that pass each handler condition to
+// the handler manager, sorted by handler name. GitHub Actions evaluates the conditions into env
+// values, so event data used in a condition never becomes part of the handler config JSON.
+func renderSafeOutputsConditionEnvVars(conditions map[string]string) []string {
+	handlerNames := make([]string, 0, len(conditions))
+	for handlerName := range conditions {
+		handlerNames = append(handlerNames, handlerName)
+	}
+	sort.Strings(handlerNames)
+
+	lines := make([]string, 0, len(handlerNames))
+	for _, handlerName := range handlerNames {
+		lines = append(lines, fmt.Sprintf("          %s: %q\n", safeOutputsConditionEnvVarName(handlerName), conditions[handlerName]))
+	}
+	return lines
+}
+
+// parseSafeOutputsHandlerConditions collects the if conditions of the safe-outputs handled by the
+// handler manager, keyed by handler name. The handler config only marks a handler as conditional;
+// the conditions are evaluated by GitHub Actions into env vars (see renderSafeOutputsConditionEnvVars)
+// and the handler manager skips handlers whose condition is false.
+func parseSafeOutputsHandlerConditions(outputMap map[string]any) map[string]string {
+	var conditions map[string]string
+	for key, value := range outputMap {
+		handlerName := safeOutputHandlerName(key)
+		if _, isHandler := handlerRegistry[handlerName]; !isHandler {
+			continue
+		}
+		configMap, ok := value.(map[string]any)
+		if !ok {
+			continue
+		}
+		condition, ok := configMap["if"].(string)
+		if !ok || strings.TrimSpace(condition) == "" {
+			continue
+		}
+		if conditions == nil {
+			conditions = make(map[string]string)
+		}
+		conditions[handlerName] = handlerConditionExpression(condition)
+		safeOutputsConditionsLog.Printf("Handler %s runs only if: %s", handlerName, conditions[handlerName])
+	}
+	return conditions
+}
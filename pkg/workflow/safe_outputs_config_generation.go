@@ -268,10 +268,23 @@ func generateSafeOutputsConfig(data *WorkflowData) string {
 			)
 		}
 		if data.SafeOutputs.UploadAssets != nil {
-			safeOutputsConfig["upload_asset"] = generateMaxConfig(
+			uploadAssetConfig := generateMaxConfig(
 				data.SafeOutputs.UploadAssets.Max,
 				0, // default: unlimited
 			)
+			// Named groups are validated by the MCP server when the agent passes a group
+			if len(data.SafeOutputs.UploadAssets.Groups) > 0 {
+				groups := make(map[string]any, len(data.SafeOutputs.UploadAssets.Groups))
+				for _, group := range data.SafeOutputs.UploadAssets.Groups {
+					groups[group.Name] = map[string]any{
+						"branch":       group.BranchName,
+						"max_size_kb":  group.MaxSizeKB,
+						"allowed_exts": group.AllowedExts,
+					}
+				}
+				uploadAssetConfig["groups"] = groups
+			}
+			safeOutputsConfig["upload_asset"] = uploadAssetConfig
 		}
 		if data.SafeOutputs.MissingTool != nil {
 			// Generate config for missing_tool with issue creation support
@@ -484,6 +497,9 @@ func generateSafeOutputsConfig(data *WorkflowData) string {
 		}
 	}
 
+	// max expressions are resolved at runtime from env vars, bounded by the schema maximum
+	applyMaxLimits(data.SafeOutputs, safeOutputsConfig)
+
 	configJSON, _ := json.Marshal(safeOutputsConfig)
 	safeOutputsConfigLog.Printf("Safe outputs config generation complete: %d tool types configured", len(safeOutputsConfig))
 	return string(configJSON)
@@ -1,6 +1,7 @@
 package workflow
 
 import (
+	"fmt"
 	"reflect"
 	"sort"
 
@@ -105,3 +106,57 @@ func getEnabledSafeOutputToolNamesReflection(safeOutputs *SafeOutputsConfig) []s
 	safeOutputReflectionLog.Printf("Found %d enabled safe output tools", len(tools))
 	return tools
 }
+
+// getMaxExpressionsReflection returns the max expression of each enabled tool, keyed by tool name
+func getMaxExpressionsReflection(safeOutputs *SafeOutputsConfig) map[string]string {
+	expressions := make(map[string]string)
+	if safeOutputs == nil {
+		return expressions
+	}
+	val := reflect.ValueOf(safeOutputs).Elem()
+	for fieldName, toolName := range safeOutputFieldMapping {
+		field := val.FieldByName(fieldName)
+		if !field.IsValid() || field.IsNil() {
+			continue
+		}
+		expression := field.Elem().FieldByName("MaxExpression")
+		if !expression.IsValid() || expression.String() == "" {
+			continue
+		}
+		expressions[toolName] = expression.String()
+	}
+	return expressions
+}
+
+// applyMaxLimits records the schema maximum for each tool whose max is an expression, so the
+// runtime can bound the evaluated value. The expression itself is never written into the
+// configuration; it reaches the runtime through the env vars from renderSafeOutputsMaxEnvVars.
+func applyMaxLimits(safeOutputs *SafeOutputsConfig, toolConfigs map[string]any) {
+	for toolName := range getMaxExpressionsReflection(safeOutputs) {
+		toolConfig, ok := toolConfigs[toolName].(map[string]any)
+		if !ok {
+			continue
+		}
+		if limit := getSafeOutputsMaxLimit(toolName); limit > 0 {
+			safeOutputReflectionLog.Printf("Bounding max expression for %s to %d", toolName, limit)
+			toolConfig["max_limit"] = limit
+		}
+	}
+}
+
+// renderSafeOutputsMaxEnvVars returns step env lines that pass each max expression to the
+// runtime, sorted by tool name. Values stay in env so they are never spliced into scripts or JSON.
+func renderSafeOutputsMaxEnvVars(safeOutputs *SafeOutputsConfig) []string {
+	expressions := getMaxExpressionsReflection(safeOutputs)
+	toolNames := make([]string, 0, len(expressions))
+	for toolName := range expressions {
+		toolNames = append(toolNames, toolName)
+	}
+	sort.Strings(toolNames)
+
+	lines := make([]string, 0, len(toolNames))
+	for _, toolName := range toolNames {
+		lines = append(lines, fmt.Sprintf("          %s: %s\n", safeOutputsMaxEnvVarName(toolName), expressions[toolName]))
+	}
+	return lines
+}
@@ -0,0 +1,65 @@
+package workflow
+
+import "github.com/github/gh-aw/pkg/logger"
+
+var safeOutputsRetryLog = logger.New("workflow:safe_outputs_config_retry")
+
+// ========================================
+// Safe Output Retry Configuration
+// ========================================
+
+const (
+	// defaultSafeOutputsRetryMaxAttempts is the default number of attempts per safe output message
+	defaultSafeOutputsRetryMaxAttempts = 3
+	// defaultSafeOutputsRetryBaseDelayMs is the default delay before the first retry;
+	// later retries back off exponentially
+	defaultSafeOutputsRetryBaseDelayMs = 1000
+)
+
+// SafeOutputsRetryConfig holds the retry policy applied by the safe output handlers
+// when a GitHub API call fails with a transient error such as a secondary rate limit
+type SafeOutputsRetryConfig struct {
+	MaxAttempts int `yaml:"max-attempts,omitempty"`  // Total attempts per API request, including the first
+	BaseDelayMs int `yaml:"base-delay-ms,omitempty"` // Delay before the first retry in milliseconds, doubled for each further retry
+}
+
+// defaultSafeOutputsRetryConfig returns the retry policy used when safe-outputs.retry is omitted
+func defaultSafeOutputsRetryConfig() *SafeOutputsRetryConfig {
+	return &SafeOutputsRetryConfig{
+		MaxAttempts: defaultSafeOutputsRetryMaxAttempts,
+		BaseDelayMs: defaultSafeOutputsRetryBaseDelayMs,
+	}
+}
+
+// parseSafeOutputsRetryConfig parses the retry configuration from safe-outputs frontmatter.
+// Missing or invalid fields fall back to the defaults.
+func parseSafeOutputsRetryConfig(outputMap map[string]any) *SafeOutputsRetryConfig {
+	config := defaultSafeOutputsRetryConfig()
+
+	retryMap, ok := outputMap["retry"].(map[string]any)
+	if !ok {
+		return config
+	}
+
+	if maxAttempts, ok := parseIntValue(retryMap["max-attempts"]); ok && maxAttempts >= 1 {
+		config.MaxAttempts = maxAttempts
+	}
+	if baseDelayMs, ok := parseIntValue(retryMap["base-delay-ms"]); ok && baseDelayMs >= 0 {
+		config.BaseDelayMs = baseDelayMs
+	}
+
+	safeOutputsRetryLog.Printf("Parsed retry configuration: max_attempts=%d, base_delay_ms=%d", config.MaxAttempts, config.BaseDelayMs)
+	return config
+}
+
+// handlerRetryConfig renders the retry policy for a handler entry in the handler manager
+// config, which applies it to every GitHub API request made by the handlers
+func handlerRetryConfig(retry *SafeOutputsRetryConfig) map[string]any {
+	if retry == nil {
+		retry = defaultSafeOutputsRetryConfig()
+	}
+	return map[string]any{
+		"max_attempts":  retry.MaxAttempts,
+		"base_delay_ms": retry.BaseDelayMs,
+	}
+}
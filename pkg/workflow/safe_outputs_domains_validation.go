@@ -37,6 +37,48 @@ func (c *Compiler) validateNetworkAllowedDomains(network *NetworkPermissions) er
 	return collector.Error()
 }
 
+// validateNetworkBlockedDomains validates the blocked domains list in network configuration.
+// Entries must be ecosystem identifiers or valid domain patterns, and a wildcard pattern
+// cannot be both allowed and blocked.
+func (c *Compiler) validateNetworkBlockedDomains(network *NetworkPermissions) error {
+	if network == nil || len(network.Blocked) == 0 {
+		return nil
+	}
+
+	safeOutputsDomainsValidationLog.Printf("Validating %d network blocked domains", len(network.Blocked))
+
+	allowedWildcards := make(map[string]bool)
+	for _, domain := range network.Allowed {
+		if strings.Contains(domain, "*") {
+			allowedWildcards[strings.ToLower(domain)] = true
+		}
+	}
+
+	collector := NewErrorCollector(c.failFast)
+
+	for i, domain := range network.Blocked {
+		// Skip ecosystem identifiers - they don't need domain pattern validation.
+		// A bare wildcard would block everything and is validated as a pattern.
+		if isEcosystemIdentifier(domain) && !strings.Contains(domain, "*") {
+			continue
+		}
+
+		var err error
+		if patternErr := validateDomainPattern(domain); patternErr != nil {
+			err = fmt.Errorf("network.blocked[%d]: %w", i, patternErr)
+		} else if allowedWildcards[strings.ToLower(domain)] {
+			err = fmt.Errorf("network.blocked[%d]: wildcard pattern '%s' is listed in both network.allowed and network.blocked. Remove it from one of the lists", i, domain)
+		}
+		if err != nil {
+			if returnErr := collector.Add(err); returnErr != nil {
+				return returnErr // Fail-fast mode
+			}
+		}
+	}
+
+	return collector.Error()
+}
+
 // isEcosystemIdentifier checks if a domain string is actually an ecosystem identifier
 func isEcosystemIdentifier(domain string) bool {
 	// Ecosystem identifiers don't contain dots and don't have protocol prefixes
@@ -198,6 +198,59 @@ func TestValidateSafeOutputsAllowedDomains(t *testing.T) {
 	}
 }
 
+func TestValidateNetworkBlockedDomains(t *testing.T) {
+	tests := []struct {
+		name    string
+		network *NetworkPermissions
+		wantErr bool
+		errMsg  string
+	}{
+		{
+			name:    "nil network",
+			network: nil,
+		},
+		{
+			name:    "wildcard and TLD patterns",
+			network: &NetworkPermissions{Allowed: []string{"defaults"}, Blocked: []string{"*.evil.com", "*.ru", "tracker.example.com", "python"}},
+		},
+		{
+			name:    "wildcard blocked inside allowed base domain",
+			network: &NetworkPermissions{Allowed: []string{"*.example.com"}, Blocked: []string{"*.ads.example.com"}},
+		},
+		{
+			name:    "wildcard in middle",
+			network: &NetworkPermissions{Blocked: []string{"evil.*.com"}},
+			wantErr: true,
+			errMsg:  "network.blocked[0]",
+		},
+		{
+			name:    "wildcard only",
+			network: &NetworkPermissions{Blocked: []string{"tracker.example.com", "*"}},
+			wantErr: true,
+			errMsg:  "network.blocked[1]",
+		},
+		{
+			name:    "same wildcard allowed and blocked",
+			network: &NetworkPermissions{Allowed: []string{"defaults", "*.evil.com"}, Blocked: []string{"*.Evil.com"}},
+			wantErr: true,
+			errMsg:  "'*.Evil.com' is listed in both network.allowed and network.blocked",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewCompiler()
+			err := c.validateNetworkBlockedDomains(tt.network)
+			if tt.wantErr {
+				require.Error(t, err, "Expected an error but got none")
+				assert.Contains(t, err.Error(), tt.errMsg, "Error message should contain expected text")
+			} else {
+				assert.NoError(t, err, "Expected no error but got: %v", err)
+			}
+		})
+	}
+}
+
 func TestValidateDomainPattern(t *testing.T) {
 	tests := []struct {
 		name    string
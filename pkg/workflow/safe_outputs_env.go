@@ -2,6 +2,7 @@ package workflow
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 	"testing"
 
@@ -211,11 +212,17 @@ func buildEngineMetadataEnvVars(engineConfig *EngineConfig) []string {
 // Safe Output Environment Helpers
 // ========================================
 
-// addCustomSafeOutputEnvVars adds custom environment variables to safe output job steps
+// addCustomSafeOutputEnvVars adds custom environment variables to safe output job steps,
+// sorted by name so the generated step is stable across compilations
 func (c *Compiler) addCustomSafeOutputEnvVars(steps *[]string, data *WorkflowData) {
 	if data.SafeOutputs != nil && len(data.SafeOutputs.Env) > 0 {
-		for key, value := range data.SafeOutputs.Env {
-			*steps = append(*steps, fmt.Sprintf("          %s: %s\n", key, value))
+		keys := make([]string, 0, len(data.SafeOutputs.Env))
+		for key := range data.SafeOutputs.Env {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			*steps = append(*steps, fmt.Sprintf("          %s: %s\n", key, data.SafeOutputs.Env[key]))
 		}
 	}
 }
@@ -0,0 +1,101 @@
+package workflow
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var safeOutputsEnvironmentLog = logger.New("workflow:safe_outputs_environment")
+
+// environmentNamePattern matches the characters GitHub accepts in deployment environment names
+var environmentNamePattern = regexp.MustCompile(`^[A-Za-z0-9._/-](?:[A-Za-z0-9 ._/-]{0,253}[A-Za-z0-9._/-])?$`)
+
+// separateJobSafeOutputFields lists the safe outputs that run in their own job rather than the
+// consolidated safe_outputs job. Their environment is declared on that job instead.
+var separateJobSafeOutputFields = map[string]bool{
+	"UploadAssets": true,
+}
+
+// validateSafeOutputsEnvironmentName checks that an environment name is a GitHub deployment
+// environment name or a GitHub Actions expression, so it is safe to write into the lock file
+func validateSafeOutputsEnvironmentName(handlerName, environment string) error {
+	if strings.HasPrefix(environment, "${{") && strings.HasSuffix(environment, "}}") && !strings.ContainsAny(environment, "\n\r") {
+		return nil
+	}
+	if environmentNamePattern.MatchString(environment) {
+		return nil
+	}
+	return fmt.Errorf("invalid safe-outputs.%s.environment value %q: expected a deployment environment name (letters, digits, spaces, '.', '_', '-', '/') or a GitHub Actions expression", handlerName, environment)
+}
+
+// renderSafeOutputsJobEnvironment renders the job-level environment declaration for a
+// validated environment name, quoted so the name cannot change the YAML structure
+func renderSafeOutputsJobEnvironment(environment string) string {
+	if environment == "" {
+		return ""
+	}
+	return fmt.Sprintf("environment: %q", environment)
+}
+
+// resolveSafeOutputsEnvironment returns the deployment environment declared by the
+// enabled safe outputs, or an empty string if none declares one.
+//
+// A safe output sets environment alongside a github-token that references environment
+// secrets. All handlers run in the single safe_outputs job, which can only declare one
+// environment, so handlers that name different environments are rejected. Handlers that run
+// in their own job (upload-asset) are validated but declare their environment on that job.
+func resolveSafeOutputsEnvironment(safeOutputs *SafeOutputsConfig) (string, error) {
+	if safeOutputs == nil {
+		return "", nil
+	}
+
+	handlersByEnvironment := make(map[string][]string)
+	val := reflect.ValueOf(safeOutputs).Elem()
+	for fieldName, toolName := range safeOutputFieldMapping {
+		field := val.FieldByName(fieldName)
+		if !field.IsValid() || field.IsNil() {
+			continue
+		}
+		environment := field.Elem().FieldByName("Environment")
+		if !environment.IsValid() || environment.String() == "" {
+			continue
+		}
+		handlerName := strings.ReplaceAll(toolName, "_", "-")
+		if err := validateSafeOutputsEnvironmentName(handlerName, environment.String()); err != nil {
+			return "", err
+		}
+		if separateJobSafeOutputFields[fieldName] {
+			continue
+		}
+		handlersByEnvironment[environment.String()] = append(handlersByEnvironment[environment.String()], handlerName)
+	}
+
+	if len(handlersByEnvironment) == 0 {
+		return "", nil
+	}
+
+	environments := make([]string, 0, len(handlersByEnvironment))
+	for environment := range handlersByEnvironment {
+		environments = append(environments, environment)
+	}
+	sort.Strings(environments)
+
+	if len(environments) == 1 {
+		safeOutputsEnvironmentLog.Printf("Safe outputs use environment %q", environments[0])
+		return environments[0], nil
+	}
+
+	var conflicts []string
+	for _, environment := range environments {
+		handlers := handlersByEnvironment[environment]
+		sort.Strings(handlers)
+		conflicts = append(conflicts, fmt.Sprintf("  - %s: %s", environment, strings.Join(handlers, ", ")))
+	}
+	safeOutputsEnvironmentLog.Printf("Conflicting safe outputs environments: %v", environments)
+	return "", fmt.Errorf("safe outputs declare conflicting environments:\n%s\n\nAll safe outputs run in the safe_outputs job, which can only use one environment. Set the same environment on every safe output whose github-token references environment secrets", strings.Join(conflicts, "\n"))
+}
@@ -0,0 +1,165 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/stringutil"
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveSafeOutputsEnvironment(t *testing.T) {
+	tests := []struct {
+		name          string
+		safeOutputs   *SafeOutputsConfig
+		expected      string
+		errorContains []string
+	}{
+		{
+			name:        "nil safe outputs",
+			safeOutputs: nil,
+		},
+		{
+			name: "no environment",
+			safeOutputs: &SafeOutputsConfig{
+				CreateIssues: &CreateIssuesConfig{BaseSafeOutputConfig: BaseSafeOutputConfig{GitHubToken: "${{ secrets.PAT }}"}},
+			},
+		},
+		{
+			name: "single handler environment",
+			safeOutputs: &SafeOutputsConfig{
+				CreateIssues: &CreateIssuesConfig{BaseSafeOutputConfig: BaseSafeOutputConfig{GitHubToken: "${{ secrets.PROD_TOKEN }}", Environment: "prod"}},
+				AddComments:  &AddCommentsConfig{},
+			},
+			expected: "prod",
+		},
+		{
+			name: "same environment on several handlers",
+			safeOutputs: &SafeOutputsConfig{
+				CreateIssues: &CreateIssuesConfig{BaseSafeOutputConfig: BaseSafeOutputConfig{Environment: "prod"}},
+				AddLabels:    &AddLabelsConfig{BaseSafeOutputConfig: BaseSafeOutputConfig{Environment: "prod"}},
+			},
+			expected: "prod",
+		},
+		{
+			name: "conflicting environments",
+			safeOutputs: &SafeOutputsConfig{
+				CreateIssues: &CreateIssuesConfig{BaseSafeOutputConfig: BaseSafeOutputConfig{Environment: "prod"}},
+				AddLabels:    &AddLabelsConfig{BaseSafeOutputConfig: BaseSafeOutputConfig{Environment: "staging"}},
+				AddComments:  &AddCommentsConfig{BaseSafeOutputConfig: BaseSafeOutputConfig{Environment: "prod"}},
+			},
+			errorContains: []string{"conflicting environments", "  - prod: add-comment, create-issue", "  - staging: add-labels"},
+		},
+		{
+			name: "expression environment",
+			safeOutputs: &SafeOutputsConfig{
+				CreateIssues: &CreateIssuesConfig{BaseSafeOutputConfig: BaseSafeOutputConfig{Environment: "${{ inputs.environment }}"}},
+			},
+			expected: "${{ inputs.environment }}",
+		},
+		{
+			name: "upload-asset runs in its own job",
+			safeOutputs: &SafeOutputsConfig{
+				CreateIssues: &CreateIssuesConfig{BaseSafeOutputConfig: BaseSafeOutputConfig{Environment: "prod"}},
+				UploadAssets: &UploadAssetsConfig{BaseSafeOutputConfig: BaseSafeOutputConfig{Environment: "assets"}},
+			},
+			expected: "prod",
+		},
+		{
+			name: "environment name with YAML syntax",
+			safeOutputs: &SafeOutputsConfig{
+				CreateIssues: &CreateIssuesConfig{BaseSafeOutputConfig: BaseSafeOutputConfig{Environment: "prod: x #y"}},
+			},
+			errorContains: []string{"invalid safe-outputs.create-issue.environment value"},
+		},
+		{
+			name: "invalid upload-asset environment",
+			safeOutputs: &SafeOutputsConfig{
+				UploadAssets: &UploadAssetsConfig{BaseSafeOutputConfig: BaseSafeOutputConfig{Environment: "[prod]"}},
+			},
+			errorContains: []string{"invalid safe-outputs.upload-asset.environment value"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			environment, err := resolveSafeOutputsEnvironment(tt.safeOutputs)
+			if len(tt.errorContains) > 0 {
+				require.Error(t, err, "Environment should be rejected")
+				for _, expected := range tt.errorContains {
+					assert.Contains(t, err.Error(), expected, "Error message should contain %q", expected)
+				}
+				return
+			}
+			require.NoError(t, err, "Environment should resolve")
+			assert.Equal(t, tt.expected, environment, "Resolved environment should match")
+		})
+	}
+}
+
+func TestSafeOutputsEnvironmentCompile(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "safe-outputs-environment")
+
+	tests := []struct {
+		name        string
+		safeOutputs string
+		expected    string
+		environment string
+		errContains string
+	}{
+		{
+			name:        "handler environment",
+			safeOutputs: "  add-labels:\n    github-token: ${{ secrets.PROD_TOKEN }}\n    environment: prod\n",
+			expected:    "  safe_outputs:\n    needs:",
+			environment: `    environment: "prod"`,
+		},
+		{
+			name:        "upload-asset environment",
+			safeOutputs: "  upload-asset:\n    github-token: ${{ secrets.ASSETS_TOKEN }}\n    environment: assets\n",
+			expected:    "  upload_assets:\n    needs:",
+			environment: `    environment: "assets"`,
+		},
+		{
+			name:        "conflicting environments",
+			safeOutputs: "  add-labels:\n    github-token: ${{ secrets.PROD_TOKEN }}\n    environment: prod\n  add-reviewer:\n    github-token: ${{ secrets.STAGING_TOKEN }}\n    environment: staging\n",
+			errContains: "conflicting environments",
+		},
+		{
+			name:        "environment name with YAML syntax",
+			safeOutputs: "  add-labels:\n    github-token: ${{ secrets.PROD_TOKEN }}\n    environment: \"prod: x\"\n",
+			errContains: "invalid safe-outputs.add-labels.environment value",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content := "---\non: issues\npermissions:\n  contents: read\nengine: copilot\nsafe-outputs:\n" + tt.safeOutputs + "---\n\n# Test Workflow\n"
+			testFile := filepath.Join(tmpDir, tt.name+".md")
+			require.NoError(t, os.WriteFile(testFile, []byte(content), 0644), "Failed to write test file")
+
+			err := NewCompiler().CompileWorkflow(testFile)
+			if tt.errContains != "" {
+				require.Error(t, err, "Compilation should fail")
+				assert.Contains(t, err.Error(), tt.errContains, "Error should explain the invalid environment")
+				return
+			}
+			require.NoError(t, err, "Compilation should succeed")
+
+			lockContent, err := os.ReadFile(stringutil.MarkdownToLockFile(testFile))
+			require.NoError(t, err, "Failed to read lock file")
+			lock := string(lockContent)
+			jobStart := strings.Index(lock, tt.expected)
+			require.GreaterOrEqual(t, jobStart, 0, "Lock file should contain the job")
+			jobEnd := strings.Index(lock[jobStart:], "    steps:")
+			require.Positive(t, jobEnd, "Job should have steps")
+			assert.Contains(t, lock[jobStart:jobStart+jobEnd], tt.environment, "Job should declare the quoted environment")
+			assert.Equal(t, 1, strings.Count(lock, "    environment: \""), "Only the job running the handler should declare the environment")
+		})
+	}
+}
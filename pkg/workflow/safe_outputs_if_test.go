@@ -0,0 +1,133 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSafeOutputsHandlerIf(t *testing.T) {
+	tests := []struct {
+		name          string
+		safeOutputs   string
+		handlerConfig []string
+		envVars       []string
+		notExpected   string
+		errContains   string
+	}{
+		{
+			name:          "bare condition is wrapped in an expression",
+			safeOutputs:   "  add-comment:\n    if: github.event_name == 'pull_request'\n  create-issue:",
+			handlerConfig: []string{`\"add_comment\":{\"conditional\":true,`},
+			envVars:       []string{`GH_AW_SAFE_OUTPUTS_IF_ADD_COMMENT: "${{ github.event_name == 'pull_request' }}"`},
+			notExpected:   `\"create_issue\":{\"conditional\"`,
+		},
+		{
+			name:          "wrapped condition is passed through",
+			safeOutputs:   "  add-labels:\n    if: ${{ github.event_name == 'issues' && github.event.action == 'opened' }}",
+			handlerConfig: []string{`\"add_labels\":{\"conditional\":true`},
+			envVars:       []string{`GH_AW_SAFE_OUTPUTS_IF_ADD_LABELS: "${{ github.event_name == 'issues' && github.event.action == 'opened' }}"`},
+		},
+		{
+			name:          "event data stays out of the handler config",
+			safeOutputs:   "  add-comment:\n    if: github.event.issue.title",
+			handlerConfig: []string{`\"add_comment\":{\"conditional\":true,`},
+			envVars:       []string{`GH_AW_SAFE_OUTPUTS_IF_ADD_COMMENT: "${{ github.event.issue.title }}"`},
+		},
+		{
+			name:        "unbalanced quote is rejected",
+			safeOutputs: "  add-comment:\n    if: github.event_name == 'pull_request",
+			errContains: "invalid if condition for safe-outputs.add-comment",
+		},
+		{
+			name:        "unbalanced parentheses are rejected",
+			safeOutputs: "  add-comment:\n    if: (github.event_name == 'pull_request'",
+			errContains: "invalid if condition for safe-outputs.add-comment",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content := "---\non:\n  issues:\n    types: [opened]\n  pull_request:\n    types: [opened]\npermissions:\n  contents: read\nengine: copilot\nsafe-outputs:\n" + tt.safeOutputs + "\n---\n\n# Handler conditions\n"
+			markdownPath := filepath.Join(testutil.TempDir(t, "safe-outputs-if"), "test.md")
+
+			lockContent, err := NewCompiler().CompileString(content, markdownPath)
+			if tt.errContains != "" {
+				require.Error(t, err, "Compilation should fail")
+				assert.Contains(t, err.Error(), tt.errContains, "Error should name the safe output with the invalid condition")
+				return
+			}
+			require.NoError(t, err, "Compilation should succeed")
+
+			handlerConfigLine := ""
+			for line := range strings.SplitSeq(lockContent, "\n") {
+				if strings.Contains(line, "GH_AW_SAFE_OUTPUTS_HANDLER_CONFIG:") {
+					handlerConfigLine = line
+					break
+				}
+			}
+			require.NotEmpty(t, handlerConfigLine, "Lock file should contain the handler config")
+			for _, expected := range tt.handlerConfig {
+				assert.Contains(t, handlerConfigLine, expected, "Handler config should carry the if condition")
+			}
+			assert.NotContains(t, handlerConfigLine, "${{", "Handler config JSON should not contain expressions")
+			for _, expected := range tt.envVars {
+				assert.Contains(t, lockContent, expected, "Condition should be passed as an env var")
+			}
+			if tt.notExpected != "" {
+				assert.NotContains(t, handlerConfigLine, tt.notExpected, "Handlers without a condition should not carry one")
+			}
+		})
+	}
+}
+
+func TestValidateSafeOutputsIf(t *testing.T) {
+	tests := []struct {
+		name        string
+		key         string
+		condition   any
+		errContains string
+	}{
+		{name: "bare condition", key: "add-comment", condition: "github.event_name == 'pull_request'"},
+		{name: "wrapped condition", key: "create-issue", condition: "${{ github.event_name == 'issues' }}"},
+		{name: "not a string", key: "add-comment", condition: true, errContains: "invalid if condition for safe-outputs.add-comment: true"},
+		{name: "unbalanced quote", key: "add-comment", condition: "github.event_name == 'pull_request", errContains: "invalid if condition for safe-outputs.add-comment"},
+		{name: "standalone output", key: "upload-asset", condition: "always()", errContains: "safe-outputs.upload-asset does not support 'if'"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			frontmatter := map[string]any{
+				"safe-outputs": map[string]any{
+					tt.key: map[string]any{"if": tt.condition},
+				},
+			}
+			err := validateSafeOutputsIf(frontmatter)
+			if tt.errContains != "" {
+				require.Error(t, err, "Invalid condition should be rejected")
+				assert.Contains(t, err.Error(), tt.errContains, "Error should name the safe output and problem")
+				return
+			}
+			assert.NoError(t, err, "Valid condition should be accepted")
+		})
+	}
+}
+
+func TestMergeSafeOutputsKeepsMainHandlerConditions(t *testing.T) {
+	compiler := NewCompiler()
+	main := compiler.extractSafeOutputsConfig(map[string]any{
+		"safe-outputs": map[string]any{"add-comment": map[string]any{"if": "github.event_name == 'issues'"}},
+	})
+	imported := []string{`{"add-comment": {"if": "github.event_name == 'pull_request'"}, "create-issue": {"if": "github.event_name == 'pull_request'"}}`}
+
+	merged, err := compiler.MergeSafeOutputs(main, imported)
+	require.NoError(t, err, "Merge should succeed")
+	assert.Equal(t, "${{ github.event_name == 'issues' }}", merged.Conditions["add_comment"], "The main workflow condition should win")
+	assert.Equal(t, "${{ github.event_name == 'pull_request' }}", merged.Conditions["create_issue"], "Imported handlers should keep their condition")
+}
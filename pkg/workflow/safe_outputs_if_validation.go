@@ -0,0 +1,51 @@
+package workflow
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var safeOutputsIfValidationLog = logger.New("workflow:safe_outputs_if_validation")
+
+// validateSafeOutputsIf validates the if condition of every safe-outputs handler. A condition
+// must be a GitHub Actions expression, with or without the ${{ }} wrapper
+// (e.g., "github.event_name == 'pull_request'"). Its syntax is checked with the same validator
+// used for concurrency group expressions, so mistakes such as unbalanced quotes are reported at
+// compile time instead of when the safe_outputs job runs.
+func validateSafeOutputsIf(frontmatter map[string]any) error {
+	safeOutputs, ok := frontmatter["safe-outputs"].(map[string]any)
+	if !ok {
+		return nil
+	}
+
+	names := make([]string, 0, len(safeOutputs))
+	for name := range safeOutputs {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	for _, name := range names {
+		configMap, ok := safeOutputs[name].(map[string]any)
+		if !ok {
+			continue
+		}
+		condition, hasIf := configMap["if"]
+		if !hasIf {
+			continue
+		}
+		if _, isHandler := handlerRegistry[safeOutputHandlerName(name)]; !isHandler {
+			return fmt.Errorf("safe-outputs.%s does not support 'if'", name)
+		}
+		conditionStr, ok := condition.(string)
+		if !ok {
+			return fmt.Errorf("invalid if condition for safe-outputs.%s: %v\n\nif must be a GitHub Actions expression (e.g., \"github.event_name == 'pull_request'\")", name, condition)
+		}
+		safeOutputsIfValidationLog.Printf("Validating if condition for %s", name)
+		if err := ValidateConcurrencyExpression(handlerConditionExpression(conditionStr)); err != nil {
+			return fmt.Errorf("invalid if condition for safe-outputs.%s: %w", name, err)
+		}
+	}
+	return nil
+}
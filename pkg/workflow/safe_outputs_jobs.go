@@ -35,6 +35,7 @@ type SafeOutputJobConfig struct {
 	Outputs         map[string]string // Job outputs
 	Condition       ConditionNode     // Job condition (if clause)
 	Needs           []string          // Job dependencies
+	Environment     string            // Deployment environment declared on the job
 	PreSteps        []string          // Optional steps to run before the GitHub Script step
 	PostSteps       []string          // Optional steps to run after the GitHub Script step
 	Token           string            // GitHub token for this output type
@@ -134,6 +135,7 @@ func (c *Compiler) buildSafeOutputJob(data *WorkflowData, config SafeOutputJobCo
 		Name:           config.JobName,
 		If:             jobCondition.Render(),
 		RunsOn:         c.formatSafeOutputsRunsOn(data.SafeOutputs),
+		Environment:    renderSafeOutputsJobEnvironment(config.Environment),
 		Permissions:    config.Permissions.RenderToYAML(),
 		TimeoutMinutes: 10, // 10-minute timeout as required for all safe output jobs
 		Steps:          steps,
@@ -0,0 +1,128 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSafeOutputsMaxExpression(t *testing.T) {
+	tests := []struct {
+		name          string
+		safeOutputs   string
+		handlerConfig string
+		envVar        string
+		errContains   string
+	}{
+		{
+			name:          "literal int",
+			safeOutputs:   "  add-comment:\n    max: 3",
+			handlerConfig: `\"add_comment\":{\"max\":3,`,
+		},
+		{
+			name:          "expression is passed via env and bounded by the schema maximum",
+			safeOutputs:   "  add-comment:\n    max: ${{ vars.MAX_COMMENTS }}",
+			handlerConfig: `\"add_comment\":{\"max\":1,\"max_limit\":100,`,
+			envVar:        "GH_AW_SAFE_OUTPUTS_MAX_ADD_COMMENT: ${{ vars.MAX_COMMENTS }}",
+		},
+		{
+			name:          "expression on a config parsed without unmarshaling",
+			safeOutputs:   "  update-issue:\n    max: ${{ inputs.max_updates }}",
+			handlerConfig: `\"max_limit\":100`,
+			envVar:        "GH_AW_SAFE_OUTPUTS_MAX_UPDATE_ISSUE: ${{ inputs.max_updates }}",
+		},
+		{
+			name:        "invalid literal",
+			safeOutputs: "  add-comment:\n    max: 0",
+			errContains: "/safe-outputs/add-comment/max",
+		},
+		{
+			name:        "untrusted context",
+			safeOutputs: "  add-comment:\n    max: ${{ github.event.issue.title }}",
+			errContains: "/safe-outputs/add-comment/max",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content := "---\non: issues\npermissions:\n  contents: read\nengine: copilot\nsafe-outputs:\n" + tt.safeOutputs + "\n---\n\n# Max expression\n"
+			markdownPath := filepath.Join(testutil.TempDir(t, "safe-outputs-max"), "test.md")
+
+			compiler := NewCompiler()
+			lockContent, err := compiler.CompileString(content, markdownPath)
+			if tt.errContains != "" {
+				require.Error(t, err, "Compilation should fail")
+				assert.Contains(t, err.Error(), tt.errContains, "Error should explain the invalid max")
+				return
+			}
+			require.NoError(t, err, "Compilation should succeed")
+
+			handlerConfigLine := ""
+			for line := range strings.SplitSeq(lockContent, "\n") {
+				if strings.Contains(line, "GH_AW_SAFE_OUTPUTS_HANDLER_CONFIG:") {
+					handlerConfigLine = line
+					break
+				}
+			}
+			require.NotEmpty(t, handlerConfigLine, "Lock file should contain the handler config")
+			assert.Contains(t, handlerConfigLine, tt.handlerConfig, "Handler config should carry the max value")
+			assert.NotContains(t, handlerConfigLine, "${{", "Handler config JSON should not contain expressions")
+
+			for line := range strings.SplitSeq(lockContent, "\n") {
+				if strings.Contains(line, "config.json") || strings.HasPrefix(strings.TrimSpace(line), "{\"") {
+					assert.NotContains(t, line, "vars.", "Expressions should not be written into run scripts")
+					assert.NotContains(t, line, "inputs.", "Expressions should not be written into run scripts")
+				}
+			}
+			if tt.envVar != "" {
+				assert.Equal(t, 2, strings.Count(lockContent, tt.envVar), "Max expression should be passed as env to the ingestion and handler steps")
+			}
+		})
+	}
+}
+
+func TestValidateSafeOutputsMax(t *testing.T) {
+	tests := []struct {
+		name        string
+		max         any
+		errContains string
+	}{
+		{name: "literal int", max: 3},
+		{name: "valid expression", max: "${{ vars.MAX_COMMENTS }}"},
+		{name: "valid input expression", max: "${{ inputs.max }}"},
+		{name: "untrusted context", max: "${{ github.event.issue.title }}", errContains: "invalid max expression for safe-outputs.add-comment"},
+		{name: "compound expression", max: "${{ vars.A || github.event.issue.body }}", errContains: "invalid max expression for safe-outputs.add-comment"},
+		{name: "invalid literal string", max: "many", errContains: "invalid max value for safe-outputs.add-comment: many"},
+		{name: "zero", max: 0, errContains: "invalid max value for safe-outputs.add-comment: 0"},
+		{name: "negative", max: -2, errContains: "invalid max value for safe-outputs.add-comment: -2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			frontmatter := map[string]any{
+				"safe-outputs": map[string]any{
+					"add-comment": map[string]any{"max": tt.max},
+				},
+			}
+			err := validateSafeOutputsMax(frontmatter)
+			if tt.errContains != "" {
+				require.Error(t, err, "Invalid max should be rejected")
+				assert.Contains(t, err.Error(), tt.errContains, "Error should name the safe output and value")
+				return
+			}
+			assert.NoError(t, err, "Valid max should be accepted")
+		})
+	}
+}
+
+func TestGetSafeOutputsMaxLimit(t *testing.T) {
+	assert.Equal(t, 100, getSafeOutputsMaxLimit("add_comment"), "add-comment max should be bounded by the schema")
+	assert.Equal(t, 10, getSafeOutputsMaxLimit("create_agent_session"), "create-agent-session max should be bounded by the schema")
+	assert.Equal(t, 0, getSafeOutputsMaxLimit("add_labels"), "add-labels max has no schema maximum")
+}
@@ -0,0 +1,155 @@
+package workflow
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/github/gh-aw/pkg/logger"
+	"github.com/github/gh-aw/pkg/parser"
+	"github.com/github/gh-aw/pkg/stringutil"
+)
+
+var safeOutputsMaxValidationLog = logger.New("workflow:safe_outputs_max_validation")
+
+// safeOutputsMaxExpressionPattern matches the expressions allowed as a safe-outputs max:
+// a single repository variable or workflow input. Values from other contexts (such as
+// github.event.*) are attacker-controlled and are rejected.
+var safeOutputsMaxExpressionPattern = regexp.MustCompile(`^\$\{\{\s*(vars|inputs)\.[A-Za-z_][A-Za-z0-9_-]*\s*\}\}$`)
+
+// isSafeOutputsMaxExpression reports whether value is an allowed safe-outputs max expression
+func isSafeOutputsMaxExpression(value string) bool {
+	return safeOutputsMaxExpressionPattern.MatchString(strings.TrimSpace(value))
+}
+
+// safeOutputsMaxEnvVarName returns the environment variable that carries the evaluated
+// max expression for a safe output tool (e.g. add_comment -> GH_AW_SAFE_OUTPUTS_MAX_ADD_COMMENT)
+func safeOutputsMaxEnvVarName(toolName string) string {
+	return "GH_AW_SAFE_OUTPUTS_MAX_" + strings.ToUpper(strings.ReplaceAll(toolName, "-", "_"))
+}
+
+// validateSafeOutputsMax validates the max field of every safe-outputs configuration.
+// Valid max values:
+//   - A positive integer (e.g., 3)
+//   - A repository variable or workflow input expression (e.g., "${{ vars.MAX_COMMENTS }}"), resolved at runtime
+//
+// Parsing silently ignores values that are neither, which would fall back to the default
+// max, so they are rejected here instead.
+func validateSafeOutputsMax(frontmatter map[string]any) error {
+	safeOutputs, ok := frontmatter["safe-outputs"].(map[string]any)
+	if !ok {
+		return nil
+	}
+
+	safeOutputsMaxValidationLog.Print("Validating safe-outputs max fields")
+
+	names := make([]string, 0, len(safeOutputs))
+	for name := range safeOutputs {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	for _, name := range names {
+		configMap, ok := safeOutputs[name].(map[string]any)
+		if !ok {
+			continue
+		}
+		max, hasMax := configMap["max"]
+		if !hasMax {
+			continue
+		}
+		if maxStr, ok := max.(string); ok && isGitHubExpression(maxStr) {
+			if !isSafeOutputsMaxExpression(maxStr) {
+				return fmt.Errorf("invalid max expression for safe-outputs.%s: %s\n\nmax expressions may only reference a repository variable or workflow input (e.g., \"${{ vars.MAX_COMMENTS }}\" or \"${{ inputs.max }}\")", name, maxStr)
+			}
+			safeOutputsMaxValidationLog.Printf("Max for %s is a GitHub Actions expression", name)
+			continue
+		}
+		if !stringutil.IsPositiveInteger(fmt.Sprint(max)) {
+			return fmt.Errorf("invalid max value for safe-outputs.%s: %v\n\nmax must be a positive integer (e.g., 3) or a repository variable or workflow input expression (e.g., \"${{ vars.MAX_COMMENTS }}\")", name, max)
+		}
+	}
+	return nil
+}
+
+var (
+	safeOutputsMaxLimitsOnce sync.Once
+	safeOutputsMaxLimits     map[string]int
+)
+
+// getSafeOutputsMaxLimit returns the schema maximum for a safe output tool's max, or 0
+// when the schema does not bound it. Literal values are checked against the schema at
+// compile time; max expressions are checked against this limit at runtime.
+func getSafeOutputsMaxLimit(toolName string) int {
+	safeOutputsMaxLimitsOnce.Do(func() {
+		safeOutputsMaxLimits = loadSafeOutputsMaxLimits()
+	})
+	return safeOutputsMaxLimits[strings.ReplaceAll(toolName, "_", "-")]
+}
+
+// loadSafeOutputsMaxLimits reads the integer maximum of every safe-outputs max from the
+// main workflow schema, keyed by safe output name (e.g. "add-comment")
+func loadSafeOutputsMaxLimits() map[string]int {
+	limits := make(map[string]int)
+
+	var schema struct {
+		Properties struct {
+			SafeOutputs struct {
+				Properties map[string]json.RawMessage `json:"properties"`
+			} `json:"safe-outputs"`
+		} `json:"properties"`
+	}
+	if err := json.Unmarshal([]byte(parser.GetMainWorkflowSchema()), &schema); err != nil {
+		safeOutputsMaxValidationLog.Printf("Failed to parse workflow schema: %v", err)
+		return limits
+	}
+
+	for name, raw := range schema.Properties.SafeOutputs.Properties {
+		var node any
+		if err := json.Unmarshal(raw, &node); err != nil {
+			continue
+		}
+		if limit := findSchemaMaxLimit(node); limit > 0 {
+			limits[name] = limit
+		}
+	}
+	return limits
+}
+
+// findSchemaMaxLimit finds the integer maximum of the max property in a safe output schema node
+func findSchemaMaxLimit(node any) int {
+	nodeMap, ok := node.(map[string]any)
+	if !ok {
+		return 0
+	}
+	if properties, ok := nodeMap["properties"].(map[string]any); ok {
+		if maxNode, ok := properties["max"].(map[string]any); ok {
+			variants, ok := maxNode["oneOf"].([]any)
+			if !ok {
+				variants = []any{maxNode}
+			}
+			for _, variant := range variants {
+				variantMap, ok := variant.(map[string]any)
+				if !ok || variantMap["type"] != "integer" {
+					continue
+				}
+				if maximum, ok := variantMap["maximum"].(float64); ok {
+					return int(maximum)
+				}
+			}
+			return 0
+		}
+	}
+	for _, key := range []string{"oneOf", "anyOf"} {
+		variants, _ := nodeMap[key].([]any)
+		for _, variant := range variants {
+			if limit := findSchemaMaxLimit(variant); limit > 0 {
+				return limit
+			}
+		}
+	}
+	return 0
+}
@@ -22,135 +22,135 @@ func computePermissionsForSafeOutputs(safeOutputs *SafeOutputsConfig) *Permissio
 	// Merge permissions for all handler-managed types
 	if safeOutputs.CreateIssues != nil {
 		safeOutputsPermissionsLog.Print("Adding permissions for create-issue")
-		permissions.Merge(NewPermissionsContentsReadIssuesWrite())
+		permissions.Merge(NewPermissionsBuilder().Read(PermissionContents).Write(PermissionIssues).Build())
 	}
 	if safeOutputs.CreateDiscussions != nil {
 		safeOutputsPermissionsLog.Print("Adding permissions for create-discussion")
-		permissions.Merge(NewPermissionsContentsReadIssuesWriteDiscussionsWrite())
+		permissions.Merge(NewPermissionsBuilder().Read(PermissionContents).Write(PermissionIssues).Write(PermissionDiscussions).Build())
 	}
 	if safeOutputs.AddComments != nil {
 		safeOutputsPermissionsLog.Print("Adding permissions for add-comment")
-		permissions.Merge(NewPermissionsContentsReadIssuesWritePRWriteDiscussionsWrite())
+		permissions.Merge(NewPermissionsBuilder().Read(PermissionContents).Write(PermissionIssues).Write(PermissionPullRequests).Write(PermissionDiscussions).Build())
 	}
 	if safeOutputs.CloseIssues != nil {
 		safeOutputsPermissionsLog.Print("Adding permissions for close-issue")
-		permissions.Merge(NewPermissionsContentsReadIssuesWrite())
+		permissions.Merge(NewPermissionsBuilder().Read(PermissionContents).Write(PermissionIssues).Build())
 	}
 	if safeOutputs.CloseDiscussions != nil {
 		safeOutputsPermissionsLog.Print("Adding permissions for close-discussion")
-		permissions.Merge(NewPermissionsContentsReadDiscussionsWrite())
+		permissions.Merge(NewPermissionsBuilder().Read(PermissionContents).Write(PermissionDiscussions).Build())
 	}
 	if safeOutputs.AddLabels != nil {
 		safeOutputsPermissionsLog.Print("Adding permissions for add-labels")
-		permissions.Merge(NewPermissionsContentsReadIssuesWritePRWrite())
+		permissions.Merge(NewPermissionsBuilder().Read(PermissionContents).Write(PermissionIssues).Write(PermissionPullRequests).Build())
 	}
 	if safeOutputs.RemoveLabels != nil {
 		safeOutputsPermissionsLog.Print("Adding permissions for remove-labels")
-		permissions.Merge(NewPermissionsContentsReadIssuesWritePRWrite())
+		permissions.Merge(NewPermissionsBuilder().Read(PermissionContents).Write(PermissionIssues).Write(PermissionPullRequests).Build())
 	}
 	if safeOutputs.UpdateIssues != nil {
 		safeOutputsPermissionsLog.Print("Adding permissions for update-issue")
-		permissions.Merge(NewPermissionsContentsReadIssuesWrite())
+		permissions.Merge(NewPermissionsBuilder().Read(PermissionContents).Write(PermissionIssues).Build())
 	}
 	if safeOutputs.UpdateDiscussions != nil {
 		safeOutputsPermissionsLog.Print("Adding permissions for update-discussion")
-		permissions.Merge(NewPermissionsContentsReadDiscussionsWrite())
+		permissions.Merge(NewPermissionsBuilder().Read(PermissionContents).Write(PermissionDiscussions).Build())
 	}
 	if safeOutputs.LinkSubIssue != nil {
 		safeOutputsPermissionsLog.Print("Adding permissions for link-sub-issue")
-		permissions.Merge(NewPermissionsContentsReadIssuesWrite())
+		permissions.Merge(NewPermissionsBuilder().Read(PermissionContents).Write(PermissionIssues).Build())
 	}
 	if safeOutputs.UpdateRelease != nil {
 		safeOutputsPermissionsLog.Print("Adding permissions for update-release")
-		permissions.Merge(NewPermissionsContentsWrite())
+		permissions.Merge(NewPermissionsBuilder().Write(PermissionContents).Build())
 	}
 	if safeOutputs.CreatePullRequestReviewComments != nil || safeOutputs.SubmitPullRequestReview != nil ||
 		safeOutputs.ReplyToPullRequestReviewComment != nil || safeOutputs.ResolvePullRequestReviewThread != nil {
 		safeOutputsPermissionsLog.Print("Adding permissions for PR review operations")
-		permissions.Merge(NewPermissionsContentsReadPRWrite())
+		permissions.Merge(NewPermissionsBuilder().Read(PermissionContents).Write(PermissionPullRequests).Build())
 	}
 	if safeOutputs.CreatePullRequests != nil {
 		// Check fallback-as-issue setting to determine permissions
 		if getFallbackAsIssue(safeOutputs.CreatePullRequests) {
 			safeOutputsPermissionsLog.Print("Adding permissions for create-pull-request with fallback-as-issue")
-			permissions.Merge(NewPermissionsContentsWriteIssuesWritePRWrite())
+			permissions.Merge(NewPermissionsBuilder().Write(PermissionContents).Write(PermissionIssues).Write(PermissionPullRequests).Build())
 		} else {
 			safeOutputsPermissionsLog.Print("Adding permissions for create-pull-request")
-			permissions.Merge(NewPermissionsContentsWritePRWrite())
+			permissions.Merge(NewPermissionsBuilder().Write(PermissionContents).Write(PermissionPullRequests).Build())
 		}
 	}
 	if safeOutputs.PushToPullRequestBranch != nil {
 		safeOutputsPermissionsLog.Print("Adding permissions for push-to-pull-request-branch")
-		permissions.Merge(NewPermissionsContentsWriteIssuesWritePRWrite())
+		permissions.Merge(NewPermissionsBuilder().Write(PermissionContents).Write(PermissionIssues).Write(PermissionPullRequests).Build())
 	}
 	if safeOutputs.UpdatePullRequests != nil {
 		safeOutputsPermissionsLog.Print("Adding permissions for update-pull-request")
-		permissions.Merge(NewPermissionsContentsReadPRWrite())
+		permissions.Merge(NewPermissionsBuilder().Read(PermissionContents).Write(PermissionPullRequests).Build())
 	}
 	if safeOutputs.ClosePullRequests != nil {
 		safeOutputsPermissionsLog.Print("Adding permissions for close-pull-request")
-		permissions.Merge(NewPermissionsContentsReadPRWrite())
+		permissions.Merge(NewPermissionsBuilder().Read(PermissionContents).Write(PermissionPullRequests).Build())
 	}
 	if safeOutputs.MarkPullRequestAsReadyForReview != nil {
 		safeOutputsPermissionsLog.Print("Adding permissions for mark-pull-request-as-ready-for-review")
-		permissions.Merge(NewPermissionsContentsReadPRWrite())
+		permissions.Merge(NewPermissionsBuilder().Read(PermissionContents).Write(PermissionPullRequests).Build())
 	}
 	if safeOutputs.HideComment != nil {
 		safeOutputsPermissionsLog.Print("Adding permissions for hide-comment")
-		permissions.Merge(NewPermissionsContentsReadIssuesWritePRWriteDiscussionsWrite())
+		permissions.Merge(NewPermissionsBuilder().Read(PermissionContents).Write(PermissionIssues).Write(PermissionPullRequests).Write(PermissionDiscussions).Build())
 	}
 	if safeOutputs.DispatchWorkflow != nil {
 		safeOutputsPermissionsLog.Print("Adding permissions for dispatch-workflow")
-		permissions.Merge(NewPermissionsActionsWrite())
+		permissions.Merge(NewPermissionsBuilder().Write(PermissionActions).Build())
 	}
 	// Project-related types
 	if safeOutputs.CreateProjects != nil {
 		safeOutputsPermissionsLog.Print("Adding permissions for create-project")
-		permissions.Merge(NewPermissionsContentsReadProjectsWrite())
+		permissions.Merge(NewPermissionsBuilder().Read(PermissionContents).Write(PermissionOrganizationProj).Build())
 	}
 	if safeOutputs.UpdateProjects != nil {
 		safeOutputsPermissionsLog.Print("Adding permissions for update-project")
-		permissions.Merge(NewPermissionsContentsReadProjectsWrite())
+		permissions.Merge(NewPermissionsBuilder().Read(PermissionContents).Write(PermissionOrganizationProj).Build())
 	}
 	if safeOutputs.CreateProjectStatusUpdates != nil {
 		safeOutputsPermissionsLog.Print("Adding permissions for create-project-status-update")
-		permissions.Merge(NewPermissionsContentsReadProjectsWrite())
+		permissions.Merge(NewPermissionsBuilder().Read(PermissionContents).Write(PermissionOrganizationProj).Build())
 	}
 	if safeOutputs.AssignToAgent != nil {
 		safeOutputsPermissionsLog.Print("Adding permissions for assign-to-agent")
-		permissions.Merge(NewPermissionsContentsReadIssuesWrite())
+		permissions.Merge(NewPermissionsBuilder().Read(PermissionContents).Write(PermissionIssues).Build())
 	}
 	if safeOutputs.CreateAgentSessions != nil {
 		safeOutputsPermissionsLog.Print("Adding permissions for create-agent-session")
-		permissions.Merge(NewPermissionsContentsReadIssuesWrite())
+		permissions.Merge(NewPermissionsBuilder().Read(PermissionContents).Write(PermissionIssues).Build())
 	}
 	if safeOutputs.CreateCodeScanningAlerts != nil {
 		safeOutputsPermissionsLog.Print("Adding permissions for create-code-scanning-alert")
-		permissions.Merge(NewPermissionsContentsReadSecurityEventsWrite())
+		permissions.Merge(NewPermissionsBuilder().Read(PermissionContents).Write(PermissionSecurityEvents).Build())
 	}
 	if safeOutputs.AutofixCodeScanningAlert != nil {
 		safeOutputsPermissionsLog.Print("Adding permissions for autofix-code-scanning-alert")
-		permissions.Merge(NewPermissionsContentsReadSecurityEventsWriteActionsRead())
+		permissions.Merge(NewPermissionsBuilder().Read(PermissionContents).Write(PermissionSecurityEvents).Read(PermissionActions).Build())
 	}
 	if safeOutputs.AssignToUser != nil {
 		safeOutputsPermissionsLog.Print("Adding permissions for assign-to-user")
-		permissions.Merge(NewPermissionsContentsReadIssuesWrite())
+		permissions.Merge(NewPermissionsBuilder().Read(PermissionContents).Write(PermissionIssues).Build())
 	}
 	if safeOutputs.UnassignFromUser != nil {
 		safeOutputsPermissionsLog.Print("Adding permissions for unassign-from-user")
-		permissions.Merge(NewPermissionsContentsReadIssuesWrite())
+		permissions.Merge(NewPermissionsBuilder().Read(PermissionContents).Write(PermissionIssues).Build())
 	}
 	if safeOutputs.AssignMilestone != nil {
 		safeOutputsPermissionsLog.Print("Adding permissions for assign-milestone")
-		permissions.Merge(NewPermissionsContentsReadIssuesWrite())
+		permissions.Merge(NewPermissionsBuilder().Read(PermissionContents).Write(PermissionIssues).Build())
 	}
 	if safeOutputs.AddReviewer != nil {
 		safeOutputsPermissionsLog.Print("Adding permissions for add-reviewer")
-		permissions.Merge(NewPermissionsContentsReadPRWrite())
+		permissions.Merge(NewPermissionsBuilder().Read(PermissionContents).Write(PermissionPullRequests).Build())
 	}
 	if safeOutputs.UploadAssets != nil {
 		safeOutputsPermissionsLog.Print("Adding permissions for upload-asset")
-		permissions.Merge(NewPermissionsContentsWrite())
+		permissions.Merge(NewPermissionsBuilder().Write(PermissionContents).Build())
 	}
 
 	// NoOp and MissingTool don't require write permissions beyond what's already included
@@ -0,0 +1,55 @@
+package workflow
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/console"
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var safeOutputsPermissionsValidationLog = logger.New("workflow:safe_outputs_permissions_validation")
+
+// validateSafeOutputsPermissionsShorthand warns when the read-all or none permission
+// shorthand is used together with safe outputs that write to GitHub.
+//
+// The combination is valid: the shorthand only applies to the agent job, and the
+// safe_outputs job is granted the write scopes its handlers need. The warning points
+// out that the agent job gets a blanket level instead of the scopes it actually reads.
+func (c *Compiler) validateSafeOutputsPermissionsShorthand(workflowData *WorkflowData) {
+	warningMsg := safeOutputsPermissionsShorthandWarning(workflowData)
+	if warningMsg == "" {
+		return
+	}
+	fmt.Fprintln(os.Stderr, console.FormatWarningMessage(warningMsg))
+	c.addWarning(warningMsg)
+}
+
+// safeOutputsPermissionsShorthandWarning returns the warning for a permissions shorthand
+// combined with write safe outputs, or an empty string when there is nothing to report.
+// Staged safe outputs make no GitHub API calls and are not checked.
+func safeOutputsPermissionsShorthandWarning(workflowData *WorkflowData) string {
+	if workflowData.SafeOutputs == nil || workflowData.SafeOutputs.Staged || workflowData.Permissions == "" {
+		return ""
+	}
+
+	parser := NewPermissionsParser(workflowData.Permissions)
+	if !parser.isShorthand || (parser.shorthandValue != "read-all" && parser.shorthandValue != "none") {
+		return ""
+	}
+
+	writePermissions := findWritePermissions(computePermissionsForSafeOutputs(workflowData.SafeOutputs))
+	if len(writePermissions) == 0 {
+		safeOutputsPermissionsValidationLog.Printf("permissions: %s used without write safe outputs", parser.shorthandValue)
+		return ""
+	}
+
+	safeOutputsPermissionsValidationLog.Printf("permissions: %s used with %d write scopes required by safe outputs", parser.shorthandValue, len(writePermissions))
+	scopes := make([]string, 0, len(writePermissions))
+	for _, scope := range writePermissions {
+		scopes = append(scopes, fmt.Sprintf("%s: write", scope))
+	}
+	return fmt.Sprintf("permissions: %s applies to the agent job only; the safe_outputs job is granted its own write scopes (%s). Consider an explicit scoped permission set so the agent job only gets the read scopes it needs.",
+		parser.shorthandValue, strings.Join(scopes, ", "))
+}
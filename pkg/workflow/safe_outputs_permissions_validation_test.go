@@ -0,0 +1,116 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateSafeOutputsPermissionsShorthand(t *testing.T) {
+	tests := []struct {
+		name            string
+		permissions     string
+		safeOutputs     *SafeOutputsConfig
+		shouldWarn      bool
+		warningContains []string
+	}{
+		{
+			name:            "read-all with write safe output",
+			permissions:     "permissions: read-all",
+			safeOutputs:     &SafeOutputsConfig{CreateIssues: &CreateIssuesConfig{}},
+			shouldWarn:      true,
+			warningContains: []string{"permissions: read-all applies to the agent job only", "(issues: write)"},
+		},
+		{
+			name:            "none with write safe output",
+			permissions:     "permissions: none",
+			safeOutputs:     &SafeOutputsConfig{CreatePullRequests: &CreatePullRequestsConfig{}},
+			shouldWarn:      true,
+			warningContains: []string{"permissions: none applies to the agent job only", "contents: write", "pull-requests: write"},
+		},
+		{
+			name:        "scoped permissions with write safe output",
+			permissions: "permissions:\n  contents: read\n  issues: read",
+			safeOutputs: &SafeOutputsConfig{CreateIssues: &CreateIssuesConfig{}},
+		},
+		{
+			name:        "scoped write permissions with write safe output",
+			permissions: "permissions:\n  contents: read\n  issues: write",
+			safeOutputs: &SafeOutputsConfig{CreateIssues: &CreateIssuesConfig{}},
+		},
+		{
+			name:        "read-all without safe outputs",
+			permissions: "permissions: read-all",
+		},
+		{
+			name:        "read-all with non-writing safe output",
+			permissions: "permissions: read-all",
+			safeOutputs: &SafeOutputsConfig{NoOp: &NoOpConfig{}},
+		},
+		{
+			name:        "read-all with staged safe outputs",
+			permissions: "permissions: read-all",
+			safeOutputs: &SafeOutputsConfig{Staged: true, CreateIssues: &CreateIssuesConfig{}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			warning := safeOutputsPermissionsShorthandWarning(&WorkflowData{
+				Permissions: tt.permissions,
+				SafeOutputs: tt.safeOutputs,
+			})
+
+			if !tt.shouldWarn {
+				assert.Empty(t, warning, "Permissions should not produce a warning")
+				return
+			}
+			for _, expected := range tt.warningContains {
+				assert.Contains(t, warning, expected, "Warning should contain %q", expected)
+			}
+		})
+	}
+}
+
+func TestSafeOutputsPermissionsShorthandCompile(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "safe-outputs-permissions-shorthand")
+
+	tests := []struct {
+		name        string
+		permissions string
+		shouldWarn  bool
+	}{
+		{
+			name:        "read-all",
+			permissions: "permissions: read-all",
+			shouldWarn:  true,
+		},
+		{
+			name:        "scoped",
+			permissions: "permissions:\n  contents: read\n  issues: read",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content := "---\non: issues\n" + tt.permissions + "\nengine: copilot\nsafe-outputs:\n  create-issue:\n---\n\n# Test Workflow\n"
+			testFile := filepath.Join(tmpDir, tt.name+".md")
+			require.NoError(t, os.WriteFile(testFile, []byte(content), 0644), "Failed to write test file")
+
+			compiler := NewCompiler()
+			require.NoError(t, compiler.CompileWorkflow(testFile), "Compilation should succeed")
+			if tt.shouldWarn {
+				require.Len(t, compiler.GetWarnings(), 1, "Compilation should warn about the shorthand")
+				assert.Contains(t, compiler.GetWarnings()[0], "explicit scoped permission set", "Warning should suggest scoped permissions")
+				return
+			}
+			assert.Empty(t, compiler.GetWarnings(), "Scoped permissions should not warn")
+		})
+	}
+}
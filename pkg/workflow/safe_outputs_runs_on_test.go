@@ -185,3 +185,46 @@ func TestFormatSafeOutputsRunsOnEdgeCases(t *testing.T) {
 		})
 	}
 }
+
+func TestSafeOutputsRunsOnSeparateFromAgentJob(t *testing.T) {
+	frontmatter := `---
+on: push
+runs-on: ubuntu-22.04
+safe-outputs:
+  create-issue:
+  runs-on: ubuntu-24.04
+---
+
+# Test Workflow
+
+This is a test workflow.`
+
+	tmpDir := testutil.TempDir(t, "workflow-runs-on-test")
+	testFile := filepath.Join(tmpDir, "test.md")
+	if err := os.WriteFile(testFile, []byte(frontmatter), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	compiler := NewCompiler()
+	if err := compiler.CompileWorkflow(testFile); err != nil {
+		t.Fatalf("Failed to compile workflow: %v", err)
+	}
+
+	yamlContent, err := os.ReadFile(filepath.Join(tmpDir, "test.lock.yml"))
+	if err != nil {
+		t.Fatalf("Failed to read lock file: %v", err)
+	}
+
+	safeOutputsSection := extractJobSection(string(yamlContent), "safe_outputs")
+	if !strings.Contains(safeOutputsSection, "runs-on: ubuntu-24.04") {
+		t.Errorf("Expected safe_outputs job to use safe-outputs.runs-on.\nJob section:\n%s", safeOutputsSection)
+	}
+
+	agentSection := extractJobSection(string(yamlContent), string(constants.AgentJobName))
+	if !strings.Contains(agentSection, "runs-on: ubuntu-22.04") {
+		t.Errorf("Expected agent job to keep the top-level runs-on.\nJob section:\n%s", agentSection)
+	}
+	if strings.Contains(agentSection, "runs-on: ubuntu-24.04") {
+		t.Errorf("Expected agent job not to use safe-outputs.runs-on.\nJob section:\n%s", agentSection)
+	}
+}
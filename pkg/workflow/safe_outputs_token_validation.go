@@ -0,0 +1,122 @@
+// This file provides advisory least-privilege checks for safe-outputs tokens.
+//
+// # Safe Outputs Token Validation
+//
+// The safe-outputs level github-token applies to every safe output that does not set its
+// own github-token (see addSafeOutputGitHubTokenForConfig for the precedence chain). When
+// it is a personal access token, every handler runs with the PAT's permissions even though
+// most handlers work with the default GITHUB_TOKEN. Only a few handlers need a token with
+// more access than GITHUB_TOKEN (agent sessions, agent assignment and Projects v2).
+//
+// validateSafeOutputsTokenScope warns when the global token is a PAT expression and at
+// least one enabled handler that inherits it would work with GITHUB_TOKEN, suggesting a
+// per-handler github-token instead. The auto-enabled reporting handlers (noop, missing_tool,
+// missing_data) are not counted. The check is advisory and only runs when token
+// linting is enabled (compile --lint-tokens).
+
+package workflow
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/console"
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var safeOutputsTokenValidationLog = logger.New("workflow:safe_outputs_token_validation")
+
+// secretReferencePattern matches secret references in token expressions
+var secretReferencePattern = regexp.MustCompile(`secrets\.([A-Za-z_][A-Za-z0-9_]*)`)
+
+// elevatedTokenSafeOutputs lists safe outputs that need a token with more access than
+// GITHUB_TOKEN, keyed by tool name
+var elevatedTokenSafeOutputs = map[string]bool{
+	"create_agent_session":         true,
+	"assign_to_agent":              true,
+	"create_project":               true,
+	"update_project":               true,
+	"create_project_status_update": true,
+}
+
+// reportingSafeOutputs lists safe outputs that report on the run itself rather than act on
+// the repository. They are auto-enabled for every workflow, so they do not indicate that
+// the safe-outputs token is broader than the configured handlers need.
+var reportingSafeOutputs = map[string]bool{
+	"noop":         true,
+	"missing_tool": true,
+	"missing_data": true,
+}
+
+// isBroadGitHubTokenExpression returns true if the token expression references a secret
+// other than GITHUB_TOKEN, i.e. a personal access token
+func isBroadGitHubTokenExpression(token string) bool {
+	for _, match := range secretReferencePattern.FindAllStringSubmatch(token, -1) {
+		if match[1] != "GITHUB_TOKEN" {
+			return true
+		}
+	}
+	return false
+}
+
+// safeOutputsInheritingGlobalToken returns the enabled safe outputs (by tool name) that do
+// not set their own github-token and therefore use the safe-outputs level token
+func safeOutputsInheritingGlobalToken(safeOutputs *SafeOutputsConfig) []string {
+	var inheriting []string
+	val := reflect.ValueOf(safeOutputs).Elem()
+	for fieldName, toolName := range safeOutputFieldMapping {
+		field := val.FieldByName(fieldName)
+		if !field.IsValid() || field.IsNil() {
+			continue
+		}
+		if token := field.Elem().FieldByName("GitHubToken"); token.IsValid() && token.String() != "" {
+			continue
+		}
+		inheriting = append(inheriting, toolName)
+	}
+	sort.Strings(inheriting)
+	return inheriting
+}
+
+// validateSafeOutputsTokenScope warns when the safe-outputs github-token is a personal
+// access token applied to safe outputs that only need GITHUB_TOKEN
+func (c *Compiler) validateSafeOutputsTokenScope(workflowData *WorkflowData) {
+	if !c.lintTokens || workflowData.SafeOutputs == nil {
+		return
+	}
+	safeOutputs := workflowData.SafeOutputs
+	// A GitHub App token replaces the global token for every handler
+	if safeOutputs.App != nil || !isBroadGitHubTokenExpression(safeOutputs.GitHubToken) {
+		return
+	}
+
+	var elevated, narrow []string
+	for _, toolName := range safeOutputsInheritingGlobalToken(safeOutputs) {
+		if reportingSafeOutputs[toolName] {
+			continue
+		}
+		if elevatedTokenSafeOutputs[toolName] {
+			elevated = append(elevated, toolName)
+		} else {
+			narrow = append(narrow, toolName)
+		}
+	}
+	safeOutputsTokenValidationLog.Printf("Global safe-outputs token is a PAT: elevated=%v, narrow=%v", elevated, narrow)
+
+	if len(narrow) == 0 {
+		return
+	}
+
+	message := fmt.Sprintf("safe-outputs.github-token is a personal access token, but %s only need GITHUB_TOKEN.", strings.Join(narrow, ", "))
+	if len(elevated) > 0 {
+		message += fmt.Sprintf(" Set github-token on %s instead of on safe-outputs so the other safe outputs use the default token.", strings.Join(elevated, ", "))
+	} else {
+		message += " Remove safe-outputs.github-token, or set github-token only on the safe outputs that need it."
+	}
+	fmt.Fprintln(os.Stderr, console.FormatWarningMessage(message))
+	c.addWarning(message)
+}
@@ -0,0 +1,183 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsBroadGitHubTokenExpression(t *testing.T) {
+	tests := []struct {
+		token    string
+		expected bool
+	}{
+		{token: "", expected: false},
+		{token: "${{ secrets.GITHUB_TOKEN }}", expected: false},
+		{token: "${{ steps.app-token.outputs.token }}", expected: false},
+		{token: "${{ secrets.GH_AW_GITHUB_TOKEN }}", expected: true},
+		{token: "${{ secrets.MY_PAT || secrets.GITHUB_TOKEN }}", expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.token, func(t *testing.T) {
+			assert.Equal(t, tt.expected, isBroadGitHubTokenExpression(tt.token), "Broad token detection should match")
+		})
+	}
+}
+
+func TestValidateSafeOutputsTokenScope(t *testing.T) {
+	pat := "${{ secrets.GH_AW_GITHUB_TOKEN }}"
+
+	tests := []struct {
+		name          string
+		lintTokens    bool
+		safeOutputs   *SafeOutputsConfig
+		expectWarning bool
+	}{
+		{
+			name:       "broad token with narrow handlers warns",
+			lintTokens: true,
+			safeOutputs: &SafeOutputsConfig{
+				GitHubToken:  pat,
+				CreateIssues: &CreateIssuesConfig{},
+				AddComments:  &AddCommentsConfig{},
+			},
+			expectWarning: true,
+		},
+		{
+			name:       "broad token shared by elevated and narrow handlers warns",
+			lintTokens: true,
+			safeOutputs: &SafeOutputsConfig{
+				GitHubToken:   pat,
+				AssignToAgent: &AssignToAgentConfig{},
+				AddComments:   &AddCommentsConfig{},
+			},
+			expectWarning: true,
+		},
+		{
+			name:       "broad token needed by every handler does not warn",
+			lintTokens: true,
+			safeOutputs: &SafeOutputsConfig{
+				GitHubToken:         pat,
+				CreateAgentSessions: &CreateAgentSessionConfig{},
+			},
+			expectWarning: false,
+		},
+		{
+			name:       "auto-enabled reporting handlers do not warn",
+			lintTokens: true,
+			safeOutputs: &SafeOutputsConfig{
+				GitHubToken:   pat,
+				AssignToAgent: &AssignToAgentConfig{},
+				NoOp:          &NoOpConfig{},
+				MissingTool:   &MissingToolConfig{},
+				MissingData:   &MissingDataConfig{},
+			},
+			expectWarning: false,
+		},
+		{
+			name:       "narrow handlers with their own tokens do not warn",
+			lintTokens: true,
+			safeOutputs: &SafeOutputsConfig{
+				GitHubToken:   pat,
+				AssignToAgent: &AssignToAgentConfig{},
+				AddComments:   &AddCommentsConfig{BaseSafeOutputConfig: BaseSafeOutputConfig{GitHubToken: "${{ secrets.GITHUB_TOKEN }}"}},
+			},
+			expectWarning: false,
+		},
+		{
+			name:       "default token does not warn",
+			lintTokens: true,
+			safeOutputs: &SafeOutputsConfig{
+				GitHubToken:  "${{ secrets.GITHUB_TOKEN }}",
+				CreateIssues: &CreateIssuesConfig{},
+			},
+			expectWarning: false,
+		},
+		{
+			name:       "app token does not warn",
+			lintTokens: true,
+			safeOutputs: &SafeOutputsConfig{
+				GitHubToken:  pat,
+				App:          &GitHubAppConfig{AppID: "${{ vars.APP_ID }}"},
+				CreateIssues: &CreateIssuesConfig{},
+			},
+			expectWarning: false,
+		},
+		{
+			name:       "linting disabled does not warn",
+			lintTokens: false,
+			safeOutputs: &SafeOutputsConfig{
+				GitHubToken:  pat,
+				CreateIssues: &CreateIssuesConfig{},
+			},
+			expectWarning: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			compiler := NewCompiler()
+			compiler.SetLintTokens(tt.lintTokens)
+			compiler.validateSafeOutputsTokenScope(&WorkflowData{SafeOutputs: tt.safeOutputs})
+
+			if tt.expectWarning {
+				assert.Equal(t, 1, compiler.GetWarningCount(), "Over-broad token should emit a warning")
+			} else {
+				assert.Zero(t, compiler.GetWarningCount(), "Appropriately scoped token should not emit a warning")
+			}
+		})
+	}
+}
+
+func TestCompileWorkflowWithLintTokens(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "lint-tokens-test")
+	content := `---
+on: workflow_dispatch
+permissions:
+  contents: read
+engine: copilot
+safe-outputs:
+  github-token: ${{ secrets.GH_AW_GITHUB_TOKEN }}
+  create-issue:
+---
+
+# Test Workflow
+`
+	testFile := filepath.Join(tmpDir, "lint-tokens.md")
+	require.NoError(t, os.WriteFile(testFile, []byte(content), 0644), "Failed to write test file")
+
+	compiler := NewCompiler()
+	compiler.SetLintTokens(true)
+	require.NoError(t, compiler.CompileWorkflow(testFile), "Token linting should only warn, not fail")
+	assert.Equal(t, 1, compiler.GetWarningCount(), "Compilation should count the token scope warning")
+}
+
+func TestCompileWorkflowWithLintTokensIgnoresReportingHandlers(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "lint-tokens-reporting-test")
+	content := `---
+on: workflow_dispatch
+permissions:
+  contents: read
+engine: copilot
+safe-outputs:
+  github-token: ${{ secrets.GH_AW_GITHUB_TOKEN }}
+  assign-to-agent:
+---
+
+# Test Workflow
+`
+	testFile := filepath.Join(tmpDir, "lint-tokens-reporting.md")
+	require.NoError(t, os.WriteFile(testFile, []byte(content), 0644), "Failed to write test file")
+
+	compiler := NewCompiler()
+	compiler.SetLintTokens(true)
+	require.NoError(t, compiler.CompileWorkflow(testFile), "Token linting should only warn, not fail")
+	assert.Zero(t, compiler.GetWarningCount(), "Auto-enabled noop, missing_tool and missing_data should not trigger the token scope warning")
+}
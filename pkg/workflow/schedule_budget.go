@@ -0,0 +1,231 @@
+// This file provides compile-time cost checks for scheduled workflows.
+//
+// # Schedule Budget
+//
+// A schedule can declare a monthly cost budget using the object form:
+//
+//	on:
+//	  schedule:
+//	    cron: daily
+//	    budget: 20
+//
+// The budget is in US dollars per month. Workflows using any other schedule form (the
+// "on: daily" shorthand, a schedule string or a list of cron items) are checked against
+// the repository-wide schedule.budget in .github/aw/config.json, if one is set.
+//
+// The compiler estimates how often the schedule fires in a month from its cron expressions
+// and multiplies that by the estimated cost of one run of the selected engine. The cost
+// comes from schedule.run-cost in the repository config, falling back to
+// estimatedRunCostUSD. If the projected monthly cost exceeds the budget a warning is
+// emitted so the cost risk is visible before deployment. The check is advisory: the budget
+// is removed from the generated lock file and never enforced at runtime.
+
+package workflow
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/github/gh-aw/pkg/console"
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var scheduleBudgetLog = logger.New("workflow:schedule_budget")
+
+// daysPerMonth is the average number of days in a month
+const daysPerMonth = 365.25 / 12
+
+// estimatedRunCostUSD is the default pricing table used for schedule budgets: the estimated
+// cost in US dollars of a single agentic run, keyed by engine ID. Entries can be overridden
+// or added with schedule.run-cost in the repository config. Engines without a cost (such as
+// custom) are not checked.
+var estimatedRunCostUSD = map[string]float64{
+	"copilot":     0.50,
+	"copilot-sdk": 0.50,
+	"claude":      1.00,
+	"codex":       0.75,
+}
+
+// cronFieldNames maps month and weekday names to their numeric cron values
+var cronFieldNames = map[string]string{
+	"jan": "1", "feb": "2", "mar": "3", "apr": "4", "may": "5", "jun": "6",
+	"jul": "7", "aug": "8", "sep": "9", "oct": "10", "nov": "11", "dec": "12",
+	"sun": "0", "mon": "1", "tue": "2", "wed": "3", "thu": "4", "fri": "5", "sat": "6",
+}
+
+// parseScheduleBudget parses the budget value of an object-form schedule
+func parseScheduleBudget(value any) (float64, error) {
+	var budget float64
+	switch v := value.(type) {
+	case int:
+		budget = float64(v)
+	case int64:
+		budget = float64(v)
+	case uint64:
+		budget = float64(v)
+	case float64:
+		budget = v
+	default:
+		return 0, fmt.Errorf("schedule budget must be a number, got %T", value)
+	}
+	if budget <= 0 {
+		return 0, fmt.Errorf("schedule budget must be greater than 0, got %v", value)
+	}
+	return budget, nil
+}
+
+// cronFieldValues returns the distinct values matched by a cron field within
+// [minValue, maxValue]. Returns false if the field cannot be parsed.
+func cronFieldValues(field string, minValue, maxValue int) (map[int]bool, bool) {
+	matched := make(map[int]bool)
+	for _, part := range strings.Split(strings.ToLower(field), ",") {
+		step := 1
+		if rangePart, stepPart, hasStep := strings.Cut(part, "/"); hasStep {
+			s, err := strconv.Atoi(stepPart)
+			if err != nil || s <= 0 {
+				return nil, false
+			}
+			step = s
+			part = rangePart
+		}
+
+		start, end := minValue, maxValue
+		if part != "*" {
+			startStr, endStr, isRange := strings.Cut(part, "-")
+			var err error
+			if start, err = parseCronFieldValue(startStr); err != nil {
+				return nil, false
+			}
+			end = start
+			if isRange {
+				if end, err = parseCronFieldValue(endStr); err != nil {
+					return nil, false
+				}
+			} else if step > 1 {
+				// "N/step" runs from N to the end of the field
+				end = maxValue
+			}
+		}
+		if start < minValue || end > maxValue || start > end {
+			return nil, false
+		}
+
+		for value := start; value <= end; value += step {
+			matched[value] = true
+		}
+	}
+	return matched, true
+}
+
+// parseCronFieldValue parses a single numeric or named cron value
+func parseCronFieldValue(value string) (int, error) {
+	if numeric, ok := cronFieldNames[value]; ok {
+		value = numeric
+	}
+	return strconv.Atoi(value)
+}
+
+// estimateMonthlyRuns estimates how many times a cron expression fires in an average month.
+// Returns false if the expression cannot be parsed.
+func estimateMonthlyRuns(cronExpr string) (float64, bool) {
+	fields := strings.Fields(cronExpr)
+	if len(fields) != 5 {
+		return 0, false
+	}
+
+	var counts [5]int
+	bounds := [5][2]int{{0, 59}, {0, 23}, {1, 31}, {1, 12}, {0, 7}}
+	for i, field := range fields {
+		values, ok := cronFieldValues(field, bounds[i][0], bounds[i][1])
+		if !ok {
+			return 0, false
+		}
+		// Sunday can be written as 0 or 7
+		if i == 4 && values[7] {
+			delete(values, 7)
+			values[0] = true
+		}
+		counts[i] = len(values)
+	}
+	minutes, hours, daysOfMonth, months, weekdays := counts[0], counts[1], counts[2], counts[3], counts[4]
+
+	// When both day fields are restricted, cron fires when either one matches
+	var days float64
+	domRestricted := fields[2] != "*"
+	dowRestricted := fields[4] != "*"
+	switch {
+	case domRestricted && dowRestricted:
+		days = float64(daysOfMonth) + float64(weekdays)*daysPerMonth/7
+	case domRestricted:
+		days = float64(daysOfMonth)
+	case dowRestricted:
+		days = float64(weekdays) * daysPerMonth / 7
+	default:
+		days = daysPerMonth
+	}
+	if days > daysPerMonth {
+		days = daysPerMonth
+	}
+
+	return float64(minutes*hours) * days * float64(months) / 12, true
+}
+
+// validateScheduleBudget warns when the projected monthly cost of the workflow schedule
+// exceeds on.schedule.budget, or the repository-wide schedule.budget when none is declared
+func (c *Compiler) validateScheduleBudget(workflowData *WorkflowData) error {
+	if len(c.scheduleCrons) == 0 {
+		return nil
+	}
+
+	repositoryConfig, err := c.getRepositoryConfig()
+	if err != nil {
+		return err
+	}
+
+	budget := c.scheduleBudget
+	if budget <= 0 {
+		budget = repositoryConfig.Schedule.Budget
+	}
+	if budget <= 0 {
+		return nil
+	}
+
+	engineID := "copilot"
+	if workflowData.EngineConfig != nil && workflowData.EngineConfig.ID != "" {
+		engineID = workflowData.EngineConfig.ID
+	}
+	runCost, hasCost := repositoryConfig.Schedule.RunCost[engineID]
+	if !hasCost {
+		runCost, hasCost = estimatedRunCostUSD[engineID]
+	}
+	if !hasCost {
+		scheduleBudgetLog.Printf("No run cost estimate for engine %s, skipping budget check", engineID)
+		return nil
+	}
+
+	var monthlyRuns float64
+	for _, cronExpr := range c.scheduleCrons {
+		runs, ok := estimateMonthlyRuns(cronExpr)
+		if !ok {
+			scheduleBudgetLog.Printf("Could not estimate runs for cron %q, skipping budget check", cronExpr)
+			return nil
+		}
+		monthlyRuns += runs
+	}
+
+	monthlyCost := monthlyRuns * runCost
+	scheduleBudgetLog.Printf("Schedule budget: runs/month=%.1f, cost/run=%.2f, cost/month=%.2f, budget=%.2f", monthlyRuns, runCost, monthlyCost, budget)
+	if monthlyCost <= budget {
+		return nil
+	}
+
+	warningMsg := fmt.Sprintf(
+		"Schedule runs about %.0f times per month at an estimated $%.2f per %s run ($%.2f/month), which exceeds the schedule budget of $%.2f. Consider a less frequent schedule or a higher budget.",
+		monthlyRuns, runCost, engineID, monthlyCost, budget,
+	)
+	fmt.Fprintln(os.Stderr, console.FormatWarningMessage(warningMsg))
+	c.addWarning(warningMsg)
+	return nil
+}
@@ -0,0 +1,140 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEstimateMonthlyRuns(t *testing.T) {
+	tests := []struct {
+		cron     string
+		expected float64
+	}{
+		{cron: "0 9 * * *", expected: daysPerMonth},
+		{cron: "*/10 * * * *", expected: 6 * 24 * daysPerMonth},
+		{cron: "0 */2 * * *", expected: 12 * daysPerMonth},
+		{cron: "30 9 * * 1", expected: daysPerMonth / 7},
+		{cron: "0 9 * * MON-FRI", expected: 5 * daysPerMonth / 7},
+		{cron: "0 0 1,15 * *", expected: 2},
+		{cron: "0 0 1 1 *", expected: 1.0 / 12},
+		{cron: "0 0 * * 0,7", expected: daysPerMonth / 7},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.cron, func(t *testing.T) {
+			runs, ok := estimateMonthlyRuns(tt.cron)
+			require.True(t, ok, "Cron expression should be parsed")
+			assert.InDelta(t, tt.expected, runs, 0.001, "Monthly run estimate should match")
+		})
+	}
+
+	_, ok := estimateMonthlyRuns("not a cron")
+	assert.False(t, ok, "Invalid cron expression should not be estimated")
+}
+
+func TestScheduleBudgetWarning(t *testing.T) {
+	tests := []struct {
+		name             string
+		on               string
+		repositoryConfig string
+		expectWarning    bool
+	}{
+		{
+			name:          "frequent schedule with low budget warns",
+			on:            "on:\n  schedule:\n    cron: every 10 minutes\n    budget: 10",
+			expectWarning: true,
+		},
+		{
+			name:          "sparse schedule within budget passes",
+			on:            "on:\n  schedule:\n    cron: weekly\n    budget: 10",
+			expectWarning: false,
+		},
+		{
+			name:          "schedule without budget is not checked",
+			on:            "on:\n  schedule:\n    cron: every 10 minutes",
+			expectWarning: false,
+		},
+		{
+			name:             "on shorthand uses repository budget",
+			on:               "on: every 10 minutes",
+			repositoryConfig: `{"schedule": {"budget": 10}}`,
+			expectWarning:    true,
+		},
+		{
+			name:             "schedule string uses repository budget",
+			on:               "on:\n  schedule: every 10 minutes",
+			repositoryConfig: `{"schedule": {"budget": 10}}`,
+			expectWarning:    true,
+		},
+		{
+			name:             "cron items are added up",
+			on:               "on:\n  schedule:\n    - cron: every 2h\n    - cron: daily",
+			repositoryConfig: `{"schedule": {"budget": 100}}`,
+			expectWarning:    true,
+		},
+		{
+			name:             "workflow budget overrides repository budget",
+			on:               "on:\n  schedule:\n    cron: every 10 minutes\n    budget: 100000",
+			repositoryConfig: `{"schedule": {"budget": 10}}`,
+			expectWarning:    false,
+		},
+		{
+			name:             "repository run cost overrides default",
+			on:               "on:\n  schedule:\n    cron: daily\n    budget: 10",
+			repositoryConfig: `{"schedule": {"run-cost": {"copilot": 2}}}`,
+			expectWarning:    true,
+		},
+		{
+			name:             "zero repository run cost passes",
+			on:               "on:\n  schedule:\n    cron: every 10 minutes\n    budget: 10",
+			repositoryConfig: `{"schedule": {"run-cost": {"copilot": 0}}}`,
+			expectWarning:    false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := testutil.TempDir(t, "schedule-budget-test")
+			if tt.repositoryConfig != "" {
+				writeRepositoryConfig(t, tmpDir, tt.repositoryConfig)
+			}
+			content := "---\n" + tt.on + "\npermissions:\n  contents: read\nengine: copilot\n---\n\n# Scheduled Workflow\n"
+			testFile := filepath.Join(tmpDir, "scheduled.md")
+			require.NoError(t, os.WriteFile(testFile, []byte(content), 0644), "Failed to write test file")
+
+			compiler := NewCompiler(WithGitRoot(tmpDir))
+			compiler.SetWorkflowIdentifier("scheduled.md")
+			require.NoError(t, compiler.CompileWorkflow(testFile), "Budget check should only warn, not fail")
+
+			if tt.expectWarning {
+				assert.Equal(t, 1, compiler.GetWarningCount(), "Schedule exceeding the budget should emit a warning")
+			} else {
+				assert.Zero(t, compiler.GetWarningCount(), "Schedule within the budget should not emit a warning")
+			}
+
+			lockContent, err := os.ReadFile(filepath.Join(tmpDir, "scheduled.lock.yml"))
+			require.NoError(t, err, "Lock file should be written")
+			assert.NotContains(t, string(lockContent), "budget:", "Budget should not be emitted to the lock file")
+		})
+	}
+}
+
+func TestScheduleBudgetInvalid(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "schedule-budget-invalid-test")
+	content := "---\non:\n  schedule:\n    cron: daily\n    budget: -5\npermissions:\n  contents: read\nengine: copilot\n---\n\n# Scheduled Workflow\n"
+	testFile := filepath.Join(tmpDir, "scheduled.md")
+	require.NoError(t, os.WriteFile(testFile, []byte(content), 0644), "Failed to write test file")
+
+	compiler := NewCompiler()
+	compiler.SetWorkflowIdentifier("scheduled.md")
+	err := compiler.CompileWorkflow(testFile)
+	require.Error(t, err, "Negative budget should fail")
+	assert.Contains(t, err.Error(), "schedule budget must be greater than 0", "Error should explain the invalid budget")
+}
@@ -58,8 +58,9 @@ func (c *Compiler) normalizeScheduleString(scheduleStr string, itemIndex int) (p
 			} else {
 				// Warn if repository slug is not available - scattering will not be org-aware
 				schedulePreprocessingLog.Printf("Warning: repository slug not available for fuzzy schedule scattering")
-				c.IncrementWarningCount()
-				c.addScheduleWarning("Fuzzy schedule scattering without repository context. Workflows with the same name in different repositories may collide. Ensure you are in a git repository with a configured remote.")
+				warningMsg := "Fuzzy schedule scattering without repository context. Workflows with the same name in different repositories may collide. Ensure you are in a git repository with a configured remote."
+				c.addWarning(warningMsg)
+				c.addScheduleWarning(warningMsg)
 			}
 		} else {
 			// Dev mode: use "dev" prefix for consistent scattering across all workflows
@@ -103,6 +104,10 @@ func (c *Compiler) normalizeScheduleString(scheduleStr string, itemIndex int) (p
 func (c *Compiler) preprocessScheduleFields(frontmatter map[string]any, markdownPath string, content string) error {
 	schedulePreprocessingLog.Print("Preprocessing schedule fields in frontmatter")
 
+	// Reset the schedule budget for this workflow
+	c.scheduleBudget = 0
+	c.scheduleCrons = nil
+
 	// Check if "on" field exists
 	onValue, exists := frontmatter["on"]
 	if !exists {
@@ -189,6 +194,7 @@ func (c *Compiler) preprocessScheduleFields(frontmatter map[string]any, markdown
 			"workflow_dispatch": nil,
 		}
 		frontmatter["on"] = onMap
+		c.scheduleCrons = []string{parsedCron}
 
 		// Store friendly format if it was converted
 		if original != "" {
@@ -214,6 +220,28 @@ func (c *Compiler) preprocessScheduleFields(frontmatter map[string]any, markdown
 		return nil
 	}
 
+	// Handle object format with a monthly cost budget: schedule: {cron: "daily", budget: 20}
+	if scheduleObj, ok := scheduleValue.(map[string]any); ok {
+		for key := range scheduleObj {
+			if key != "cron" && key != "budget" {
+				return fmt.Errorf("unknown schedule field '%s': schedule object supports only 'cron' and 'budget'", key)
+			}
+		}
+		cronStr, ok := scheduleObj["cron"].(string)
+		if !ok || cronStr == "" {
+			return fmt.Errorf("schedule object must have a 'cron' string field")
+		}
+		if budgetValue, hasBudget := scheduleObj["budget"]; hasBudget {
+			budget, err := parseScheduleBudget(budgetValue)
+			if err != nil {
+				return err
+			}
+			schedulePreprocessingLog.Printf("Schedule budget: $%.2f per month", budget)
+			c.scheduleBudget = budget
+		}
+		scheduleValue = cronStr
+	}
+
 	// Handle shorthand string format: schedule: "daily at 02:00"
 	if scheduleStr, ok := scheduleValue.(string); ok {
 		schedulePreprocessingLog.Printf("Converting shorthand schedule string to array format: %s", scheduleStr)
@@ -230,6 +258,7 @@ func (c *Compiler) preprocessScheduleFields(frontmatter map[string]any, markdown
 			},
 		}
 		onMap["schedule"] = scheduleArray
+		c.scheduleCrons = []string{parsedCron}
 
 		// Store friendly format if it was converted
 		if original != "" {
@@ -286,6 +315,7 @@ func (c *Compiler) preprocessScheduleFields(frontmatter map[string]any, markdown
 
 		// Update the cron field with the parsed cron expression
 		itemMap["cron"] = parsedCron
+		c.scheduleCrons = append(c.scheduleCrons, parsedCron)
 
 		// If there was an original friendly format, store it for later use
 		if original != "" {
@@ -445,7 +475,7 @@ func (c *Compiler) addDailyCronWarning(cronExpr string) {
 
 		// This warning is added to the warning count
 		// It will be collected and displayed by the compilation process
-		c.IncrementWarningCount()
+		c.addWarning(warningMsg)
 
 		// Store the warning for later display
 		c.addScheduleWarning(warningMsg)
@@ -471,7 +501,7 @@ func (c *Compiler) addHourlyCronWarning(cronExpr string) {
 		)
 
 		// This warning is added to the warning count
-		c.IncrementWarningCount()
+		c.addWarning(warningMsg)
 
 		// Store the warning for later display
 		c.addScheduleWarning(warningMsg)
@@ -510,7 +540,7 @@ func (c *Compiler) addWeeklyCronWarning(cronExpr string) {
 		)
 
 		// This warning is added to the warning count
-		c.IncrementWarningCount()
+		c.addWarning(warningMsg)
 
 		// Store the warning for later display
 		c.addScheduleWarning(warningMsg)
@@ -4,26 +4,31 @@ import (
 	"strings"
 
 	"github.com/github/gh-aw/pkg/logger"
-	"golang.org/x/mod/semver"
+	"github.com/github/gh-aw/pkg/stringutil"
 )
 
 var semverLog = logger.New("workflow:semver")
 
 // compareVersions compares two semantic versions, returns 1 if v1 > v2, -1 if v1 < v2, 0 if equal
-// Uses golang.org/x/mod/semver for proper semantic version comparison
+// A version that is not a semantic version (e.g. "lts") ranks below any semantic version,
+// and two such versions are compared as plain strings
 func compareVersions(v1, v2 string) int {
 	semverLog.Printf("Comparing versions: v1=%s, v2=%s", v1, v2)
 
-	// Ensure versions have 'v' prefix for semver package
-	if !strings.HasPrefix(v1, "v") {
-		v1 = "v" + v1
-	}
-	if !strings.HasPrefix(v2, "v") {
-		v2 = "v" + v2
+	result, err := stringutil.CompareSemver(v1, v2)
+	if err != nil {
+		semverLog.Printf("Cannot compare as semantic versions: %v", err)
+		v1Valid, v2Valid := stringutil.IsSemverTag(v1), stringutil.IsSemverTag(v2)
+		switch {
+		case v1Valid && !v2Valid:
+			return 1
+		case !v1Valid && v2Valid:
+			return -1
+		default:
+			return strings.Compare(v1, v2)
+		}
 	}
 
-	result := semver.Compare(v1, v2)
-
 	if result > 0 {
 		semverLog.Printf("Version comparison result: %s > %s", v1, v2)
 	} else if result < 0 {
@@ -37,36 +42,8 @@ func compareVersions(v1, v2 string) int {
 
 // extractMajorVersion extracts the major version number from a version string
 // Examples: "v5.0.0" -> 5, "v6" -> 6, "5.1.0" -> 5
-// Uses golang.org/x/mod/semver.Major for proper semantic version parsing
 func extractMajorVersion(version string) int {
-	// Ensure version has 'v' prefix for semver package
-	if !strings.HasPrefix(version, "v") {
-		version = "v" + version
-	}
-
-	// Get major version string (e.g., "v5")
-	majorStr := semver.Major(version)
-	if majorStr == "" {
-		return 0
-	}
-
-	// Parse the integer from the major version string
-	// Major returns "v5", we need to extract 5
-	var major int
-	// Strip 'v' prefix and parse the number
-	numStr := strings.TrimPrefix(majorStr, "v")
-	if numStr != "" {
-		// Parse the number
-		for _, ch := range numStr {
-			if ch >= '0' && ch <= '9' {
-				major = major*10 + int(ch-'0')
-			} else {
-				break
-			}
-		}
-	}
-
-	return major
+	return stringutil.SemverMajor(version)
 }
 
 // isSemverCompatible checks if pinVersion is semver-compatible with requestedVersion
@@ -76,20 +53,14 @@ func extractMajorVersion(version string) int {
 //   - isSemverCompatible("v5.1.0", "v5.0.0") -> true
 //   - isSemverCompatible("v6.0.0", "v5") -> false
 func isSemverCompatible(pinVersion, requestedVersion string) bool {
-	// Ensure versions have 'v' prefix for semver package
-	if !strings.HasPrefix(pinVersion, "v") {
-		pinVersion = "v" + pinVersion
-	}
-	if !strings.HasPrefix(requestedVersion, "v") {
-		requestedVersion = "v" + requestedVersion
-	}
-
-	// Use semver.Major to get major version strings
-	pinMajor := semver.Major(pinVersion)
-	requestedMajor := semver.Major(requestedVersion)
-
-	compatible := pinMajor == requestedMajor
-	semverLog.Printf("Checking semver compatibility: pin=%s (major=%s), requested=%s (major=%s) -> %v",
+	// Versions that are not semantic versions have no major version and only match each other
+	pinValid := stringutil.IsSemverTag(pinVersion)
+	requestedValid := stringutil.IsSemverTag(requestedVersion)
+	pinMajor := stringutil.SemverMajor(pinVersion)
+	requestedMajor := stringutil.SemverMajor(requestedVersion)
+
+	compatible := pinValid == requestedValid && pinMajor == requestedMajor
+	semverLog.Printf("Checking semver compatibility: pin=%s (major=%d), requested=%s (major=%d) -> %v",
 		pinVersion, pinMajor, requestedVersion, requestedMajor, compatible)
 
 	return compatible
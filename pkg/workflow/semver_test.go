@@ -19,6 +19,9 @@ func TestCompareVersions(t *testing.T) {
 		{"3.9", "3.11", -1},
 		{"24", "20", 1},
 		{"20", "24", -1},
+		{"20", "lts", 1},
+		{"lts", "20", -1},
+		{"lts", "node", -1},
 	}
 
 	for _, tt := range tests {
@@ -48,6 +48,16 @@ func shellEscapeArg(arg string) string {
 	return arg
 }
 
+// shellQuoteArg quotes a single argument taken from user input for safe use in shell commands.
+// Unlike shellEscapeArg it never treats the argument as already quoted, so quotes inside the
+// argument cannot end the quoting early.
+func shellQuoteArg(arg string) string {
+	if strings.ContainsAny(arg, "()[]{}*?$`\"'\\|&;<> \t\n") {
+		return "'" + strings.ReplaceAll(arg, "'", "'\\''") + "'"
+	}
+	return arg
+}
+
 // shellEscapeCommandString escapes a complete command string (which may already contain
 // quoted arguments) for passing as a single argument to another command.
 // It wraps the command in double quotes and escapes any double quotes, dollar signs,
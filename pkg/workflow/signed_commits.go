@@ -0,0 +1,148 @@
+package workflow
+
+import (
+	"fmt"
+
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var signedCommitsLog = logger.New("workflow:signed_commits")
+
+// defaultCommitSigningKey is the secret expression used when require-signed-commits
+// is enabled without an explicit signing-key.
+// nolint:gosec // G101: False positive - this is a GitHub Actions expression template placeholder, not a hardcoded credential
+const defaultCommitSigningKey = "${{ secrets.GH_AW_GIT_SIGNING_KEY }}"
+
+// SignedCommitsConfig configures commit signing for safe outputs that push commits
+// (create-pull-request and push-to-pull-request-branch).
+type SignedCommitsConfig struct {
+	SigningKey string `yaml:"signing-key,omitempty"` // Secret expression holding the signing key (defaults to GH_AW_GIT_SIGNING_KEY)
+	Format     string `yaml:"format,omitempty"`      // Signing key format: "gpg" (default) or "ssh"
+}
+
+// parseSignedCommitsConfig converts the raw require-signed-commits value to a SignedCommitsConfig.
+// Supports a boolean (true enables signing with defaults) or an object with signing-key/format.
+// Returns nil when signing is disabled.
+func parseSignedCommitsConfig(val any) *SignedCommitsConfig {
+	switch v := val.(type) {
+	case bool:
+		if !v {
+			return nil
+		}
+		signedCommitsLog.Print("require-signed-commits enabled with default configuration")
+		return &SignedCommitsConfig{}
+	case map[string]any:
+		config := &SignedCommitsConfig{}
+		if key, ok := v["signing-key"].(string); ok {
+			config.SigningKey = key
+		}
+		if format, ok := v["format"].(string); ok {
+			config.Format = format
+		}
+		signedCommitsLog.Printf("require-signed-commits enabled: format=%s, custom_key=%v", config.Format, config.SigningKey != "")
+		return config
+	}
+	return nil
+}
+
+// getSigningKey returns the configured signing key expression or the default secret expression
+func (s *SignedCommitsConfig) getSigningKey() string {
+	if s.SigningKey != "" {
+		return s.SigningKey
+	}
+	return defaultCommitSigningKey
+}
+
+// getFormat returns the configured signing format, defaulting to "gpg"
+func (s *SignedCommitsConfig) getFormat() string {
+	if s.Format != "" {
+		return s.Format
+	}
+	return "gpg"
+}
+
+// getSignedCommitsConfig returns the commit signing configuration from tools.github, or nil if not enabled
+func getSignedCommitsConfig(data *WorkflowData) *SignedCommitsConfig {
+	if data == nil || data.ParsedTools == nil || data.ParsedTools.GitHub == nil {
+		return nil
+	}
+	return data.ParsedTools.GitHub.RequireSignedCommits
+}
+
+// validateSignedCommitsConfig validates the tools.github.require-signed-commits configuration.
+// It checks that:
+// 1. The signing key is a GitHub Actions secrets expression
+// 2. The format is either "gpg" or "ssh"
+// 3. A safe output that pushes commits (create-pull-request or push-to-pull-request-branch) is configured
+func validateSignedCommitsConfig(workflowData *WorkflowData) error {
+	config := getSignedCommitsConfig(workflowData)
+	if config == nil {
+		return nil
+	}
+
+	signedCommitsLog.Print("Validating require-signed-commits configuration")
+
+	if err := validateSecretsExpression(config.getSigningKey()); err != nil {
+		return fmt.Errorf("tools.github.require-signed-commits.signing-key: %w", err)
+	}
+
+	format := config.getFormat()
+	if format != "gpg" && format != "ssh" {
+		return fmt.Errorf("tools.github.require-signed-commits.format must be 'gpg' or 'ssh', got %q", format)
+	}
+
+	so := workflowData.SafeOutputs
+	if so == nil || (so.CreatePullRequests == nil && so.PushToPullRequestBranch == nil) {
+		return fmt.Errorf("tools.github.require-signed-commits requires safe-outputs.create-pull-request or safe-outputs.push-to-pull-request-branch to be configured")
+	}
+
+	signedCommitsLog.Printf("require-signed-commits configuration validated: format=%s", format)
+	return nil
+}
+
+// buildCommitSigningSteps generates the step that imports the signing key and configures git
+// to sign every commit created by the safe output handlers.
+// The signing key is passed through an environment variable to prevent template injection.
+func buildCommitSigningSteps(config *SignedCommitsConfig, condition ConditionNode) []string {
+	format := config.getFormat()
+	signedCommitsLog.Printf("Building commit signing steps: format=%s", format)
+
+	steps := []string{
+		"      - name: Configure commit signing\n",
+	}
+	if condition != nil {
+		steps = append(steps, fmt.Sprintf("        if: %s\n", condition.Render()))
+	}
+	steps = append(steps,
+		"        env:\n",
+		fmt.Sprintf("          GIT_SIGNING_KEY: %s\n", config.getSigningKey()),
+		"        run: |\n",
+		"          if [ -z \"$GIT_SIGNING_KEY\" ]; then\n",
+		"            echo \"::error::require-signed-commits is enabled but the signing key secret is empty\"\n",
+		"            exit 1\n",
+		"          fi\n",
+	)
+
+	if format == "ssh" {
+		steps = append(steps,
+			"          mkdir -p ~/.ssh\n",
+			"          printf '%s\\n' \"$GIT_SIGNING_KEY\" > ~/.ssh/gh-aw-signing-key\n",
+			"          chmod 600 ~/.ssh/gh-aw-signing-key\n",
+			"          git config --global gpg.format ssh\n",
+			"          git config --global user.signingkey ~/.ssh/gh-aw-signing-key\n",
+		)
+	} else {
+		steps = append(steps,
+			"          printf '%s\\n' \"$GIT_SIGNING_KEY\" | gpg --batch --import\n",
+			"          KEY_ID=$(gpg --list-secret-keys --with-colons | awk -F: '/^sec/ { print $5; exit }')\n",
+			"          git config --global user.signingkey \"$KEY_ID\"\n",
+		)
+	}
+
+	steps = append(steps,
+		"          git config --global commit.gpgsign true\n",
+		"          git config --global tag.gpgsign true\n",
+		fmt.Sprintf("          echo \"Git configured to sign commits (%s)\"\n", format),
+	)
+	return steps
+}
@@ -110,9 +110,10 @@ func (c *Compiler) processStopAfterConfiguration(frontmatter map[string]any, wor
 	return nil
 }
 
-// resolveStopTime resolves a stop-time value to an absolute timestamp
-// If the stop-time is relative (starts with '+'), it calculates the absolute time
-// from the compilation time. Otherwise, it parses the absolute time using various formats.
+// resolveStopTime resolves a stop-time value to an absolute UTC timestamp
+// If the stop-time is relative ("+4h" or a bare duration like "4h"), it calculates the absolute
+// time from the compilation time. Otherwise, it parses the absolute time using various formats,
+// optionally followed by an IANA time zone name.
 func resolveStopTime(stopTime string, compilationTime time.Time) (string, error) {
 	if stopTime == "" {
 		return "", nil
@@ -120,7 +121,7 @@ func resolveStopTime(stopTime string, compilationTime time.Time) (string, error)
 
 	if isRelativeStopTime(stopTime) {
 		// Parse the relative time delta (minutes not allowed for stop-after)
-		delta, err := parseTimeDeltaForStopAfter(stopTime)
+		delta, err := parseTimeDeltaForStopAfter("+" + strings.TrimPrefix(stopTime, "+"))
 		if err != nil {
 			return "", err
 		}
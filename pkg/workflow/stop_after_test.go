@@ -256,3 +256,49 @@ jobs:
 		}
 	})
 }
+
+// TestStopAfterDurationResolvesToFutureInstant tests that a bare duration resolves to a UTC
+// instant after compilation and is passed to the pre-activation stop-time check step
+func TestStopAfterDurationResolvesToFutureInstant(t *testing.T) {
+	tmpDir := t.TempDir()
+	workflowFile := filepath.Join(tmpDir, "scheduled.md")
+	workflowContent := `---
+on:
+  schedule:
+    - cron: "0 9 * * 1"
+  stop-after: "4h"
+engine: claude
+---
+
+# Scheduled Workflow
+`
+	if err := os.WriteFile(workflowFile, []byte(workflowContent), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	compileTime := time.Now().UTC()
+	if err := NewCompiler().CompileWorkflow(workflowFile); err != nil {
+		t.Fatalf("Compilation failed: %v", err)
+	}
+
+	lockContent, err := os.ReadFile(filepath.Join(tmpDir, "scheduled.lock.yml"))
+	if err != nil {
+		t.Fatalf("Failed to read lock file: %v", err)
+	}
+
+	if !strings.Contains(string(lockContent), "id: check_stop_time") {
+		t.Error("Expected the pre-activation job to contain the stop-time check step")
+	}
+
+	stopTime := ExtractStopTimeFromLockFile(filepath.Join(tmpDir, "scheduled.lock.yml"))
+	resolved, err := time.Parse("2006-01-02 15:04:05", stopTime)
+	if err != nil {
+		t.Fatalf("Expected GH_AW_STOP_TIME to be a UTC timestamp, got %q: %v", stopTime, err)
+	}
+	if !resolved.After(compileTime) {
+		t.Errorf("Expected stop time %s to be after compilation time %s", resolved, compileTime)
+	}
+	if resolved.After(compileTime.Add(4*time.Hour + time.Minute)) {
+		t.Errorf("Expected stop time %s to be about 4 hours after compilation time %s", resolved, compileTime)
+	}
+}
@@ -255,6 +255,7 @@ func (c *Compiler) validateStrictDeprecatedFields(frontmatter map[string]any) er
 //  3. validateStrictMCPNetwork() - Requires top-level network config for container-based MCP servers
 //  4. validateStrictTools() - Validates tools configuration (e.g., serena local mode)
 //  5. validateStrictDeprecatedFields() - Refuses deprecated fields
+//  6. warnUnpinnedReusableWorkflows() - Warns about reusable workflows not pinned to a SHA or tag
 //
 // Note: Strict mode also affects zizmor security scanner behavior (see pkg/cli/zizmor.go)
 // When zizmor is enabled with --zizmor flag, strict mode will treat any security
@@ -305,6 +306,9 @@ func (c *Compiler) validateStrictMode(frontmatter map[string]any, networkPermiss
 		}
 	}
 
+	// 6. Warn about reusable workflows that are not pinned
+	c.warnUnpinnedReusableWorkflows(frontmatter)
+
 	strictModeValidationLog.Printf("Strict mode validation completed: error_count=%d", collector.Count())
 
 	return collector.FormattedError("strict mode")
@@ -77,16 +77,18 @@ func wrapExpressionsInTemplateConditionals(markdown string) string {
 // Parameters:
 //   - yaml: The string builder to write the YAML to
 //   - expressionMappings: Array of ExpressionMapping containing the mappings between placeholders and GitHub expressions
+//   - redactMappings: Mappings whose values prompt.redact hides in the printed copy of the prompt
 //   - data: WorkflowData containing markdown content and parsed tools
 //
 // The generated step:
 //   - Uses actions/github-script action
 //   - Sets GH_AW_PROMPT environment variable to the prompt file path
 //   - Sets GH_AW_EXPR_* environment variables with the actual GitHub expressions (${{ ... }})
+//   - Sets GH_AW_PROMPT_REDACT to the names of the redacted variables, when prompt.redact matches
 //   - Runs interpolate_prompt.cjs script to replace placeholders and render template conditionals
-func (c *Compiler) generateInterpolationAndTemplateStep(yaml *strings.Builder, expressionMappings []*ExpressionMapping, data *WorkflowData) {
+func (c *Compiler) generateInterpolationAndTemplateStep(yaml *strings.Builder, expressionMappings []*ExpressionMapping, redactMappings []*ExpressionMapping, data *WorkflowData) {
 	// Check if we need interpolation
-	hasExpressions := len(expressionMappings) > 0
+	hasExpressions := len(expressionMappings) > 0 || len(redactMappings) > 0
 
 	// Check if we need template rendering
 	hasTemplatePattern := strings.Contains(data.MarkdownContent, "{{#if ")
@@ -108,11 +110,23 @@ func (c *Compiler) generateInterpolationAndTemplateStep(yaml *strings.Builder, e
 	yaml.WriteString("          GH_AW_PROMPT: /tmp/gh-aw/aw-prompts/prompt.txt\n")
 
 	// Add environment variables for extracted expressions
+	written := make(map[string]bool, len(expressionMappings))
 	for _, mapping := range expressionMappings {
 		// Write the environment variable with the original GitHub expression
 		fmt.Fprintf(yaml, "          %s: ${{ %s }}\n", mapping.EnvVar, mapping.Content)
+		written[mapping.EnvVar] = true
 	}
 
+	// Redacted expressions only used in built-in sections still need their value here
+	for _, mapping := range redactMappings {
+		if !written[mapping.EnvVar] {
+			fmt.Fprintf(yaml, "          %s: ${{ %s }}\n", mapping.EnvVar, mapping.Content)
+		}
+	}
+
+	// Name the env vars whose values are redacted in the printed copy of the prompt
+	writePromptRedactEnv(yaml, redactMappings)
+
 	yaml.WriteString("        with:\n")
 	yaml.WriteString("          script: |\n")
 
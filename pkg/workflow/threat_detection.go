@@ -115,7 +115,7 @@ func (c *Compiler) buildThreatDetectionJob(data *WorkflowData, mainJobName strin
 	// Set permissions based on whether checkout is needed
 	var permissions string
 	if needsContentsRead {
-		permissions = NewPermissionsContentsRead().RenderToYAML()
+		permissions = NewPermissionsBuilder().Read(PermissionContents).Build().RenderToYAML()
 	} else {
 		permissions = NewPermissionsEmpty().RenderToYAML()
 	}
@@ -307,6 +307,14 @@ func (c *Compiler) buildEngineSteps(data *WorkflowData) []string {
 		}
 	}
 
+	// engine.detection-model replaces the agent model when detection runs on the main engine
+	if engineConfig != nil && engineConfig == data.EngineConfig && engineConfig.DetectionModel != "" {
+		threatLog.Printf("Using engine.detection-model for threat detection: %s", engineConfig.DetectionModel)
+		detectionModelConfig := *engineConfig
+		detectionModelConfig.Model = engineConfig.DetectionModel
+		engineConfig = &detectionModelConfig
+	}
+
 	// Use engine config ID if available
 	if engineConfig != nil {
 		engineSetting = engineConfig.ID
@@ -325,6 +333,7 @@ func (c *Compiler) buildEngineSteps(data *WorkflowData) []string {
 	// Apply default detection model if the engine provides one and no model is specified
 	// Detection models can be configured via:
 	// 1. Explicit model in threat-detection engine config (highest priority)
+	//    or engine.detection-model on the main engine
 	// 2. Engine-specific environment variables (e.g., GH_AW_MODEL_DETECTION_COPILOT)
 	// 3. Default detection model from the engine (as environment variable fallback)
 	detectionEngineConfig := engineConfig
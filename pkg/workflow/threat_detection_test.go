@@ -3,10 +3,12 @@
 package workflow
 
 import (
+	"path/filepath"
 	"strings"
 	"testing"
 
 	"github.com/github/gh-aw/pkg/constants"
+	"github.com/github/gh-aw/pkg/testutil"
 )
 
 func TestParseThreatDetectionConfig(t *testing.T) {
@@ -1047,3 +1049,95 @@ func TestCopilotDetectionDefaultModel(t *testing.T) {
 		})
 	}
 }
+
+// TestEngineDetectionModel verifies that engine.detection-model sets the detection job
+// model without changing the agent model
+func TestEngineDetectionModel(t *testing.T) {
+	content := `---
+on: issues
+permissions:
+  contents: read
+engine:
+  id: copilot
+  model: claude-sonnet-4
+  detection-model: gpt-5.1-codex-mini
+safe-outputs:
+  create-issue:
+---
+
+# Test Workflow
+`
+	markdownPath := filepath.Join(testutil.TempDir(t, "detection-model"), "test.md")
+
+	lockContent, err := NewCompiler().CompileString(content, markdownPath)
+	if err != nil {
+		t.Fatalf("Failed to compile workflow: %v", err)
+	}
+
+	agentJob := agentJobSection(t, lockContent)
+	if !strings.Contains(agentJob, "--model claude-sonnet-4") {
+		t.Errorf("Expected agent job to use the agent model, got:\n%s", agentJob)
+	}
+	if strings.Contains(agentJob, "gpt-5.1-codex-mini") {
+		t.Errorf("Expected agent job not to use the detection model, got:\n%s", agentJob)
+	}
+
+	detectionStart := strings.Index(lockContent, "\n  detection:\n")
+	if detectionStart == -1 {
+		t.Fatal("Expected lock file to contain the detection job")
+	}
+	detectionJob := lockContent[detectionStart:]
+	if !strings.Contains(detectionJob, "--model gpt-5.1-codex-mini") {
+		t.Errorf("Expected detection job to use the detection model, got:\n%s", detectionJob)
+	}
+	if strings.Contains(detectionJob, "--model claude-sonnet-4") {
+		t.Errorf("Expected detection job not to use the agent model, got:\n%s", detectionJob)
+	}
+}
+
+// TestEngineDetectionModelPrecedence verifies that a model set in the threat-detection
+// engine config takes precedence over engine.detection-model
+func TestEngineDetectionModelPrecedence(t *testing.T) {
+	compiler := NewCompiler()
+
+	tests := []struct {
+		name          string
+		data          *WorkflowData
+		expectedModel string
+	}{
+		{
+			name: "detection-model on the main engine",
+			data: &WorkflowData{
+				AI:           "copilot",
+				EngineConfig: &EngineConfig{ID: "copilot", Model: "gpt-4", DetectionModel: "gpt-4o-mini"},
+				SafeOutputs:  &SafeOutputsConfig{ThreatDetection: &ThreatDetectionConfig{}},
+			},
+			expectedModel: "gpt-4o-mini",
+		},
+		{
+			name: "threat-detection engine overrides detection-model",
+			data: &WorkflowData{
+				AI:           "copilot",
+				EngineConfig: &EngineConfig{ID: "copilot", DetectionModel: "gpt-4o-mini"},
+				SafeOutputs: &SafeOutputsConfig{
+					ThreatDetection: &ThreatDetectionConfig{
+						EngineConfig: &EngineConfig{ID: "copilot", Model: "gpt-4o"},
+					},
+				},
+			},
+			expectedModel: "gpt-4o",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			allSteps := strings.Join(compiler.buildEngineSteps(tt.data), "")
+			if !strings.Contains(allSteps, "--model "+tt.expectedModel+" ") {
+				t.Errorf("Expected detection steps to use model %q, got:\n%s", tt.expectedModel, allSteps)
+			}
+			if strings.Contains(allSteps, "--model gpt-4 ") {
+				t.Errorf("Expected detection steps not to use the agent model, got:\n%s", allSteps)
+			}
+		})
+	}
+}
@@ -15,6 +15,7 @@ var timeDeltaLog = logger.New("workflow:time_delta")
 // Pre-compiled regexes for time parsing (performance optimization)
 var (
 	timeDeltaPattern = regexp.MustCompile(`(\d+)(mo|w|d|h|m)`)
+	durationPattern  = regexp.MustCompile(`^(\d+(mo|w|d|h|m))+$`)
 	ordinalPattern   = regexp.MustCompile(`\b(\d+)(st|nd|rd|th)\b`)
 )
 
@@ -183,15 +184,38 @@ func (td *TimeDelta) String() string {
 	return "+" + strings.Join(parts, "")
 }
 
-// isRelativeStopTime checks if a stop-time value is a relative time delta
+// isRelativeStopTime checks if a stop-time value is a relative time delta,
+// either with a '+' prefix ("+4h") or as a bare duration ("4h", "1d12h")
 func isRelativeStopTime(stopTime string) bool {
-	return strings.HasPrefix(stopTime, "+")
+	return strings.HasPrefix(stopTime, "+") || durationPattern.MatchString(stopTime)
 }
 
-// parseAbsoluteDateTime parses various date-time formats and returns a standardized timestamp
+// parseAbsoluteDateTime parses various date-time formats and returns a standardized UTC timestamp.
+// The date-time may end with an IANA time zone name (e.g. "2025-06-01 18:00 America/New_York"),
+// in which case it is interpreted as a local time in that zone.
 func parseAbsoluteDateTime(dateTimeStr string) (string, error) {
 	timeDeltaLog.Printf("Parsing absolute date-time: %s", dateTimeStr)
 
+	dateTimeStr = strings.TrimSpace(dateTimeStr)
+	if idx := strings.LastIndex(dateTimeStr, " "); idx != -1 {
+		zoneName := dateTimeStr[idx+1:]
+		if strings.Contains(zoneName, "/") || zoneName == "UTC" {
+			loc, err := time.LoadLocation(zoneName)
+			if err != nil {
+				return "", fmt.Errorf("unknown time zone '%s' in date-time: %s. Use an IANA time zone name like America/New_York", zoneName, dateTimeStr)
+			}
+			return parseAbsoluteDateTimeInLocation(strings.TrimSpace(dateTimeStr[:idx]), loc)
+		}
+	}
+
+	return parseAbsoluteDateTimeInLocation(dateTimeStr, time.UTC)
+}
+
+// parseAbsoluteDateTimeInLocation parses a date-time without a zone name as a local time in loc
+// and returns it as a standardized UTC timestamp. Local times that do not exist or occur twice
+// because of a daylight saving time transition are rejected.
+func parseAbsoluteDateTimeInLocation(dateTimeStr string, loc *time.Location) (string, error) {
+
 	// Try multiple date-time formats in order of preference
 	formats := []string{
 		// Standard formats
@@ -244,7 +268,10 @@ func parseAbsoluteDateTime(dateTimeStr string) (string, error) {
 
 	// Try to parse with each format
 	for _, format := range formats {
-		if parsed, err := time.Parse(format, dateTimeStr); err == nil {
+		if parsed, err := time.ParseInLocation(format, dateTimeStr, loc); err == nil {
+			if err := checkLocalTime(parsed, format, dateTimeStr); err != nil {
+				return "", err
+			}
 			// Successfully parsed, convert to UTC and return in standard format
 			result := parsed.UTC().Format("2006-01-02 15:04:05")
 			timeDeltaLog.Printf("Successfully parsed date-time using format, result: %s", result)
@@ -257,7 +284,10 @@ func parseAbsoluteDateTime(dateTimeStr string) (string, error) {
 	normalizedStr = strings.TrimSpace(normalizedStr)
 
 	for _, format := range formats {
-		if parsed, err := time.Parse(format, normalizedStr); err == nil {
+		if parsed, err := time.ParseInLocation(format, normalizedStr, loc); err == nil {
+			if err := checkLocalTime(parsed, format, normalizedStr); err != nil {
+				return "", err
+			}
 			// Successfully parsed, convert to UTC and return in standard format
 			result := parsed.UTC().Format("2006-01-02 15:04:05")
 			timeDeltaLog.Printf("Successfully parsed date-time using ordinal normalization, result: %s", result)
@@ -283,7 +313,10 @@ func parseAbsoluteDateTime(dateTimeStr string) (string, error) {
 	}
 
 	for _, format := range smartFormats {
-		if parsed, err := time.Parse(format, dateTimeStr); err == nil {
+		if parsed, err := time.ParseInLocation(format, dateTimeStr, loc); err == nil {
+			if err := checkLocalTime(parsed, format, dateTimeStr); err != nil {
+				return "", err
+			}
 			return parsed.UTC().Format("2006-01-02 15:04:05"), nil
 		}
 	}
@@ -291,6 +324,50 @@ func parseAbsoluteDateTime(dateTimeStr string) (string, error) {
 	return "", fmt.Errorf("unable to parse date-time: %s. Supported formats include: YYYY-MM-DD HH:MM:SS, MM/DD/YYYY, January 2 2006, 1st June 2025, etc", dateTimeStr)
 }
 
+// checkLocalTime rejects local times that are skipped or repeated by a daylight saving time
+// transition in the zone they were parsed in, since they do not identify a single instant
+func checkLocalTime(parsed time.Time, format, value string) error {
+	loc := parsed.Location()
+	if loc == time.UTC {
+		return nil
+	}
+
+	// The wall clock time as written, independent of any zone
+	wall, err := time.Parse(format, value)
+	if err != nil {
+		return nil
+	}
+
+	// A skipped local time (e.g. 02:30 when clocks jump from 02:00 to 03:00) is normalized
+	// to a different wall clock time than the one written
+	if !sameWallClock(parsed, wall) {
+		return fmt.Errorf("time '%s' does not exist in %s because of a daylight saving time transition", value, loc)
+	}
+
+	// A repeated local time (e.g. 01:30 when clocks fall back from 02:00 to 01:00) has a
+	// second instant with the other UTC offset and the same wall clock time
+	_, offset := parsed.Zone()
+	for _, probe := range []time.Time{parsed.Add(-3 * time.Hour), parsed.Add(3 * time.Hour)} {
+		_, otherOffset := probe.Zone()
+		if otherOffset == offset {
+			continue
+		}
+		alternate := parsed.Add(time.Duration(offset-otherOffset) * time.Second).In(loc)
+		if sameWallClock(alternate, wall) {
+			return fmt.Errorf("time '%s' is ambiguous in %s because of a daylight saving time transition. Use a UTC offset instead, e.g. %s", value, loc, parsed.Format(time.RFC3339))
+		}
+	}
+
+	return nil
+}
+
+// sameWallClock reports whether two times show the same date and time of day in their own zones
+func sameWallClock(a, b time.Time) bool {
+	ay, am, ad := a.Date()
+	by, bm, bd := b.Date()
+	return ay == by && am == bm && ad == bd && a.Hour() == b.Hour() && a.Minute() == b.Minute() && a.Second() == b.Second()
+}
+
 // isRelativeDate checks if a date string is a relative time delta (starts with + or -)
 func isRelativeDate(dateStr string) bool {
 	return strings.HasPrefix(dateStr, "+") || strings.HasPrefix(dateStr, "-")
@@ -378,35 +455,43 @@ func ResolveRelativeDate(dateStr string, baseTime time.Time) (string, error) {
 func parseExpiresFromConfig(configMap map[string]any) int {
 	timeDeltaLog.Printf("DEBUG: parseExpiresFromConfig called with configMap: %+v", configMap)
 	if expires, exists := configMap["expires"]; exists {
-		// Try numeric types first
-		switch v := expires.(type) {
-		case bool:
-			// false explicitly disables expiration
-			if !v {
-				timeDeltaLog.Print("expires set to false, expiration disabled")
-				return -1
-			}
-			// true is not a valid expires value
+		return parseRelativeDurationValue(expires)
+	}
+	return 0
+}
+
+// parseRelativeDurationValue converts a relative duration value to hours.
+// Integers are treated as days; strings use relative time specs like "2h", "7d", "2w", "1m", "1y".
+// Returns -1 for boolean false (explicitly disabled), or 0 if the value is invalid.
+func parseRelativeDurationValue(value any) int {
+	// Try numeric types first
+	switch v := value.(type) {
+	case bool:
+		// false explicitly disables the duration
+		if !v {
+			timeDeltaLog.Print("Duration set to false, disabled")
+			return -1
+		}
+		// true is not a valid duration value
+		return 0
+	case int:
+		// Integer values without units are treated as days for backward compatibility
+		return v * 24
+	case int64:
+		return int(v) * 24
+	case float64:
+		return int(v) * 24
+	case uint64:
+		// Check for overflow before converting uint64 to int
+		const maxInt = int(^uint(0) >> 1)
+		if v > uint64(maxInt/24) {
+			timeDeltaLog.Printf("uint64 value %d for duration exceeds max int value, returning 0", v)
 			return 0
-		case int:
-			// Integer values without units are treated as days for backward compatibility
-			return v * 24
-		case int64:
-			return int(v) * 24
-		case float64:
-			return int(v) * 24
-		case uint64:
-			// Check for overflow before converting uint64 to int
-			const maxInt = int(^uint(0) >> 1)
-			if v > uint64(maxInt/24) {
-				timeDeltaLog.Printf("uint64 value %d for expires exceeds max int value, returning 0", v)
-				return 0
-			}
-			return int(v) * 24
-		case string:
-			// Parse relative time specification like "2h", "7d", "2w", "1m", "1y"
-			return parseRelativeTimeSpec(v)
 		}
+		return int(v) * 24
+	case string:
+		// Parse relative time specification like "2h", "7d", "2w", "1m", "1y"
+		return parseRelativeTimeSpec(v)
 	}
 	return 0
 }
@@ -699,6 +699,77 @@ func TestResolveStopTime(t *testing.T) {
 			compileTime: baseTime,
 			expected:    "2025-09-24 17:00:00",
 		},
+		{
+			name:        "duration without plus",
+			stopTime:    "4h",
+			compileTime: baseTime,
+			expected:    "2025-08-15 16:00:00",
+		},
+		{
+			name:        "compound duration without plus",
+			stopTime:    "1d12h",
+			compileTime: baseTime,
+			expected:    "2025-08-17 00:00:00",
+		},
+		{
+			name:        "duration without plus in minutes",
+			stopTime:    "90m",
+			compileTime: baseTime,
+			expectError: true,
+			errorMsg:    "minute unit 'm' is not allowed for stop-after",
+		},
+		{
+			name:        "invalid duration",
+			stopTime:    "4x",
+			compileTime: baseTime,
+			expectError: true,
+			errorMsg:    "unable to parse date-time",
+		},
+		{
+			name:        "time zone during daylight saving time",
+			stopTime:    "2025-06-01 18:00 America/New_York",
+			compileTime: baseTime,
+			expected:    "2025-06-01 22:00:00",
+		},
+		{
+			name:        "time zone during standard time",
+			stopTime:    "2025-12-01 09:00 Europe/Berlin",
+			compileTime: baseTime,
+			expected:    "2025-12-01 08:00:00",
+		},
+		{
+			name:        "time zone with readable format",
+			stopTime:    "June 1, 2025 18:00 America/Los_Angeles",
+			compileTime: baseTime,
+			expected:    "2025-06-02 01:00:00",
+		},
+		{
+			name:        "unknown time zone",
+			stopTime:    "2025-06-01 18:00 Mars/Olympus_Mons",
+			compileTime: baseTime,
+			expectError: true,
+			errorMsg:    "unknown time zone 'Mars/Olympus_Mons'",
+		},
+		{
+			name:        "time skipped by daylight saving transition",
+			stopTime:    "2026-03-08 02:30 America/New_York",
+			compileTime: baseTime,
+			expectError: true,
+			errorMsg:    "does not exist in America/New_York",
+		},
+		{
+			name:        "time repeated by daylight saving transition",
+			stopTime:    "2025-11-02 01:30 America/New_York",
+			compileTime: baseTime,
+			expectError: true,
+			errorMsg:    "is ambiguous in America/New_York",
+		},
+		{
+			name:        "UTC offset resolves repeated local time",
+			stopTime:    "2025-11-02T01:30:00-05:00",
+			compileTime: baseTime,
+			expected:    "2025-11-02 06:30:00",
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,95 @@
+package workflow
+
+import (
+	"sort"
+
+	"github.com/github/gh-aw/pkg/logger"
+)
+
+var tokenScopesLog = logger.New("workflow:token_scopes")
+
+// fineGrainedTokenPermissionNames maps workflow permission scopes to the permission names
+// shown when creating a fine-grained personal access token. Scopes without an entry cannot
+// be granted to a fine-grained token: id-token is only issued to GITHUB_TOKEN, and
+// GitHub Packages only accepts classic tokens.
+var fineGrainedTokenPermissionNames = map[PermissionScope]string{
+	PermissionActions:          "Actions",
+	PermissionAttestations:     "Attestations",
+	PermissionChecks:           "Checks",
+	PermissionContents:         "Contents",
+	PermissionDeployments:      "Deployments",
+	PermissionDiscussions:      "Discussions",
+	PermissionIssues:           "Issues",
+	PermissionMetadata:         "Metadata",
+	PermissionModels:           "Models",
+	PermissionPages:            "Pages",
+	PermissionPullRequests:     "Pull requests",
+	PermissionRepositoryProj:   "Projects",
+	PermissionOrganizationProj: "Projects (organization)",
+	PermissionSecurityEvents:   "Code scanning alerts",
+	PermissionStatuses:         "Commit statuses",
+}
+
+// ComputeRequiredTokenScopes returns the fine-grained personal access token permissions
+// implied by the workflow's permissions and safe outputs, e.g. "Issues: Read and write".
+// Use it to configure tokens such as GH_AW_GITHUB_TOKEN or GH_AW_PROJECT_GITHUB_TOKEN
+// that replace GITHUB_TOKEN.
+//
+// Safe outputs that manage GitHub Projects require "Projects (organization): Read and write",
+// which GITHUB_TOKEN can never provide. Metadata: Read-only is always included when a
+// repository permission is needed, since fine-grained tokens cannot omit it.
+// The result is sorted by permission name.
+func ComputeRequiredTokenScopes(data *WorkflowData) []string {
+	if data == nil {
+		return nil
+	}
+
+	sources := []*Permissions{computePermissionsForSafeOutputs(data.SafeOutputs)}
+	if data.Permissions != "" {
+		sources = append(sources, NewPermissionsParser(data.Permissions).ToPermissions())
+	}
+
+	levels := make(map[PermissionScope]PermissionLevel)
+	needsMetadata := false
+	for _, source := range sources {
+		for _, scope := range GetAllPermissionScopes() {
+			level, exists := source.Get(scope)
+			if !exists || level == PermissionNone {
+				continue
+			}
+			// Models access is read-only for fine-grained tokens
+			if scope == PermissionModels {
+				level = PermissionRead
+			}
+			if level == PermissionWrite || levels[scope] == "" {
+				levels[scope] = level
+			}
+			if scope != PermissionOrganizationProj && scope != PermissionModels && scope != PermissionIdToken {
+				needsMetadata = true
+			}
+		}
+	}
+
+	// Fine-grained tokens always carry read access to repository metadata
+	if needsMetadata && levels[PermissionMetadata] == "" {
+		levels[PermissionMetadata] = PermissionRead
+	}
+
+	var scopes []string
+	for scope, level := range levels {
+		name, ok := fineGrainedTokenPermissionNames[scope]
+		if !ok {
+			tokenScopesLog.Printf("Skipping %s: not available for fine-grained tokens", scope)
+			continue
+		}
+		access := "Read-only"
+		if level == PermissionWrite {
+			access = "Read and write"
+		}
+		scopes = append(scopes, name+": "+access)
+	}
+	sort.Strings(scopes)
+
+	tokenScopesLog.Printf("Computed %d required token scopes", len(scopes))
+	return scopes
+}
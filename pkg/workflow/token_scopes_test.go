@@ -0,0 +1,86 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComputeRequiredTokenScopes(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     *WorkflowData
+		expected []string
+	}{
+		{
+			name:     "nil workflow data",
+			data:     nil,
+			expected: nil,
+		},
+		{
+			name:     "no permissions or safe outputs",
+			data:     &WorkflowData{},
+			expected: nil,
+		},
+		{
+			name: "workflow permissions",
+			data: &WorkflowData{Permissions: "permissions:\n  contents: read\n  issues: write"},
+			expected: []string{
+				"Contents: Read-only",
+				"Issues: Read and write",
+				"Metadata: Read-only",
+			},
+		},
+		{
+			name: "safe outputs add write access",
+			data: &WorkflowData{
+				Permissions: "permissions:\n  contents: read\n  pull-requests: read",
+				SafeOutputs: &SafeOutputsConfig{
+					CreateIssues:       &CreateIssuesConfig{},
+					CreatePullRequests: &CreatePullRequestsConfig{},
+				},
+			},
+			expected: []string{
+				"Contents: Read and write",
+				"Issues: Read and write",
+				"Metadata: Read-only",
+				"Pull requests: Read and write",
+			},
+		},
+		{
+			name: "project safe outputs need organization projects",
+			data: &WorkflowData{
+				Permissions: "permissions:\n  contents: read",
+				SafeOutputs: &SafeOutputsConfig{UpdateProjects: &UpdateProjectConfig{}},
+			},
+			expected: []string{
+				"Contents: Read-only",
+				"Metadata: Read-only",
+				"Projects (organization): Read and write",
+			},
+		},
+		{
+			name: "scopes unavailable to fine-grained tokens are skipped",
+			data: &WorkflowData{Permissions: "permissions:\n  id-token: write\n  packages: read\n  security-events: write"},
+			expected: []string{
+				"Code scanning alerts: Read and write",
+				"Metadata: Read-only",
+			},
+		},
+		{
+			name: "models is read-only",
+			data: &WorkflowData{Permissions: "permissions:\n  models: read"},
+			expected: []string{
+				"Models: Read-only",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ComputeRequiredTokenScopes(tt.data), "Token scopes should match the workflow configuration")
+		})
+	}
+}
@@ -267,6 +267,9 @@ func enhanceToolDescription(toolName, baseDescription string, safeOutputs *SafeO
 			if len(config.AllowedExts) > 0 {
 				constraints = append(constraints, fmt.Sprintf("Allowed file extensions: %v.", config.AllowedExts))
 			}
+			for _, group := range config.Groups {
+				constraints = append(constraints, fmt.Sprintf("Group %q: allowed file extensions %v, maximum file size %dKB.", group.Name, group.AllowedExts, group.MaxSizeKB))
+			}
 		}
 
 	case "update_release":
@@ -3,7 +3,6 @@ package workflow
 import (
 	"encoding/json"
 	"fmt"
-	"os"
 	"strings"
 	"time"
 
@@ -23,7 +22,7 @@ func (c *Compiler) applyDefaults(data *WorkflowData, markdownPath string) error
 	isCommandTrigger := false
 	if data.On == "" {
 		// Check the original frontmatter for command trigger
-		content, err := os.ReadFile(markdownPath)
+		content, err := c.readWorkflowSource(markdownPath)
 		if err == nil {
 			result, err := parser.ExtractFrontmatterFromContent(string(content))
 			if err == nil {
@@ -170,7 +169,7 @@ func (c *Compiler) applyDefaults(data *WorkflowData, markdownPath string) error
 		// This provides minimal access needed for most workflows while following
 		// the principle of least privilege.
 		// ============================================================================
-		perms := NewPermissionsContentsRead()
+		perms := NewPermissionsBuilder().Read(PermissionContents).Build()
 		yaml := perms.RenderToYAML()
 		// RenderToYAML uses job-friendly indentation (6 spaces). WorkflowData.Permissions
 		// is stored in workflow-level indentation (2 spaces) and later re-indented for jobs.
@@ -135,6 +135,7 @@ func NewTools(toolsMap map[string]any) *Tools {
 		"safety-prompt":     true,
 		"timeout":           true,
 		"startup-timeout":   true,
+		"tool-timeouts":     true,
 	}
 
 	customCount := 0
@@ -229,11 +230,20 @@ func parseGitHubTool(val any) *GitHubToolConfig {
 			config.Lockdown = lockdown
 		}
 
+		if readOnlyEnforcement, ok := configMap["read-only-enforcement"].(bool); ok {
+			config.ReadOnlyEnforcement = readOnlyEnforcement
+		}
+
 		// Parse app configuration for GitHub App token minting
 		if app, ok := configMap["app"].(map[string]any); ok {
 			config.App = parseAppConfig(app)
 		}
 
+		// Parse commit signing configuration for safe outputs that push commits
+		if signed, exists := configMap["require-signed-commits"]; exists {
+			config.RequireSignedCommits = parseSignedCommitsConfig(signed)
+		}
+
 		return config
 	}
 
@@ -269,6 +269,9 @@ type GitHubToolConfig struct {
 	Toolset     GitHubToolsets     `yaml:"toolsets,omitempty"`
 	Lockdown    bool               `yaml:"lockdown,omitempty"`
 	App         *GitHubAppConfig   `yaml:"app,omitempty"` // GitHub App configuration for token minting
+
+	RequireSignedCommits *SignedCommitsConfig `yaml:"require-signed-commits,omitempty"` // Sign commits created by safe outputs
+	ReadOnlyEnforcement  bool                 `yaml:"read-only-enforcement,omitempty"`  // Verify at runtime that the token cannot write
 }
 
 // PlaywrightDomain represents a domain name allowed for Playwright browser automation
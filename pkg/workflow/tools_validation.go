@@ -104,3 +104,38 @@ func validateGitToolForSafeOutputs(tools *Tools, safeOutputs *SafeOutputsConfig,
 
 	return nil
 }
+
+// validateGitHubReadOnlyEnforcement validates that read-only-enforcement is only used with
+// read-only GitHub tools, since verifying a read-only token is pointless when write tools are enabled
+func validateGitHubReadOnlyEnforcement(tools *Tools, workflowName string) error {
+	if tools == nil || tools.GitHub == nil || !tools.GitHub.ReadOnlyEnforcement {
+		return nil
+	}
+
+	if !tools.GitHub.ReadOnly {
+		toolsValidationLog.Printf("Workflow %s enables read-only-enforcement without read-only mode", workflowName)
+		return fmt.Errorf("invalid github tool configuration: 'read-only-enforcement: true' requires 'read-only: true'. Remove 'read-only: false' or disable read-only-enforcement")
+	}
+
+	return nil
+}
+
+// validateGitHubReadOnlyAllowed rejects an explicit 'read-only: true' combined with an
+// 'allowed' list. Read-only mode already exposes the read-only tools of the enabled
+// toolsets, so an additional allowed list makes it unclear which tools the agent gets.
+func validateGitHubReadOnlyAllowed(tools *Tools, workflowName string) error {
+	if tools == nil || tools.GitHub == nil || len(tools.GitHub.Allowed) == 0 {
+		return nil
+	}
+
+	githubConfig, ok := tools.ToMap()["github"].(map[string]any)
+	if !ok {
+		return nil
+	}
+	if readOnly, ok := githubConfig["read-only"].(bool); !ok || !readOnly {
+		return nil
+	}
+
+	toolsValidationLog.Printf("Workflow %s combines GitHub read-only mode with an allowed list", workflowName)
+	return fmt.Errorf("invalid github tool configuration: 'read-only: true' cannot be combined with 'allowed'. Read-only mode already limits the agent to the read-only tools of the enabled toolsets; use 'toolsets' to choose them, or remove 'read-only' to restrict tools with 'allowed'")
+}